@@ -9,6 +9,7 @@ import (
 	billingpb "skillForce/internal/delivery/grpc/proto"
 	"skillForce/internal/repository"
 	"skillForce/internal/usecase"
+	"skillForce/pkg/auth"
 	"skillForce/pkg/logs"
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
@@ -32,11 +33,24 @@ func main() {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
+	creds, err := auth.ServerCredentials(auth.TLSFiles{
+		CaFile:   cfg.Tls.CaFile,
+		CertFile: cfg.Tls.CertFile,
+		KeyFile:  cfg.Tls.KeyFile,
+	})
+	if err != nil {
+		log.Fatalf("failed to load tls credentials: %v", err)
+	}
+	// вызывать сервис может только MainService
+	verifier := auth.NewTokenVerifier(cfg.Secrets.ServiceTokenSecret, auth.ServiceBilling, auth.ServiceMain)
+
 	grpcServer := grpc.NewServer(
+		grpc.Creds(creds),
 		grpc.UnaryInterceptor(
 			grpc_middleware.ChainUnaryServer(
 				logs.GRPCLoggerInterceptor(),
 				grpc_prometheus.UnaryServerInterceptor,
+				auth.UnaryServerInterceptor(verifier, billingGrpcHandler.MethodPermissions),
 			),
 		),
 	)
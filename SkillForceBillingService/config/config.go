@@ -27,7 +27,14 @@ type Config struct {
 	}
 
 	Secrets struct {
-		JwtSessionSecret string
+		JwtSessionSecret   string
+		ServiceTokenSecret string
+	}
+
+	Tls struct {
+		CaFile   string
+		CertFile string
+		KeyFile  string
 	}
 
 	Mail struct {
@@ -56,6 +63,12 @@ type yamlConfig struct {
 		VideoBucket string `yaml:"video_bucket_name"`
 		UseSSL      bool   `yaml:"use_ssl"`
 	} `yaml:"minio"`
+
+	Tls struct {
+		CaFile   string `yaml:"ca_file"`
+		CertFile string `yaml:"cert_file"`
+		KeyFile  string `yaml:"key_file"`
+	} `yaml:"tls"`
 }
 
 func LoadConfig() *Config {
@@ -104,8 +117,21 @@ func LoadConfig() *Config {
 			VideoBucket:     ycfg.Minio.VideoBucket,
 			UseSSL:          ycfg.Minio.UseSSL,
 		},
-		Secrets: struct{ JwtSessionSecret string }{
-			JwtSessionSecret: os.Getenv("JWT_SESSION_SECRET"),
+		Secrets: struct {
+			JwtSessionSecret   string
+			ServiceTokenSecret string
+		}{
+			JwtSessionSecret:   os.Getenv("JWT_SESSION_SECRET"),
+			ServiceTokenSecret: requireEnv("SERVICE_TOKEN_SECRET"),
+		},
+		Tls: struct {
+			CaFile   string
+			CertFile string
+			KeyFile  string
+		}{
+			CaFile:   ycfg.Tls.CaFile,
+			CertFile: ycfg.Tls.CertFile,
+			KeyFile:  ycfg.Tls.KeyFile,
 		},
 		Mail: struct {
 			From     string
@@ -127,3 +153,13 @@ func LoadConfig() *Config {
 		},
 	}
 }
+
+// requireEnv - секрет из .env. Пустой секрет не допускается: HMAC подпись с пустым ключом
+// может подделать кто угодно
+func requireEnv(name string) string {
+	value := os.Getenv(name)
+	if value == "" {
+		log.Fatalf("не задана переменная окружения %s", name)
+	}
+	return value
+}
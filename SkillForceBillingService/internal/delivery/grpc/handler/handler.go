@@ -20,7 +20,7 @@ func NewBillingHandler(uc *usecase.BillingUsecase) *BillingHandler {
 }
 
 func (h *BillingHandler) CreatePayment(ctx context.Context, req *billingpb.CreatePaymentRequest) (*billingpb.CreatePaymentResponse, error) {
-	response, err := h.usecase.CreatePayment(ctx, int(req.UserId), int(req.CourseId), req.ReturnUrl)
+	response, err := h.usecase.CreatePayment(ctx, userId(ctx), int(req.CourseId), req.ReturnUrl)
 	if err != nil {
 		return nil, err
 	}
@@ -34,3 +34,7 @@ func (h *BillingHandler) HandleWebhook(ctx context.Context, req *billingpb.YooKa
 	}
 	return &emptypb.Empty{}, nil
 }
+
+func (h *BillingHandler) ExportUserData(ctx context.Context, req *billingpb.ExportUserDataRequest) (*billingpb.ExportUserDataResponse, error) {
+	return h.usecase.ExportUserData(ctx, userId(ctx))
+}
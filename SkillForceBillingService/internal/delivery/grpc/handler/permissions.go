@@ -0,0 +1,21 @@
+package grpc
+
+import (
+	"context"
+	"skillForce/pkg/auth"
+)
+
+// MethodPermissions - права, необходимые для вызова методов BillingService
+var MethodPermissions = map[string]auth.Permission{
+	"/billing.BillingService/CreatePayment":  auth.PermPurchase,
+	"/billing.BillingService/HandleWebhook":  auth.PermPublic,
+	"/billing.BillingService/ExportUserData": auth.PermLearn,
+}
+
+func userId(ctx context.Context) int {
+	identity, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return 0
+	}
+	return identity.UserId
+}
@@ -0,0 +1,11 @@
+package billingmodels
+
+type Purchase struct {
+	Id          int    `json:"id"`
+	CourseId    int    `json:"course_id"`
+	CourseTitle string `json:"course_title"`
+	Price       int    `json:"price"`
+	Status      string `json:"status"`
+	BillingId   string `json:"billing_id"`
+	UpdatedAt   string `json:"updated_at"`
+}
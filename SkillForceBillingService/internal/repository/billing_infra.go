@@ -6,6 +6,7 @@ import (
 	"log"
 	"skillForce/config"
 	billingpb "skillForce/internal/delivery/grpc/proto"
+	billingmodels "skillForce/internal/models/billing"
 	"skillForce/internal/repository/postgres"
 	"skillForce/internal/repository/yookassa"
 )
@@ -55,3 +56,7 @@ func (i *BillingInfrastructure) HandleWebhook(ctx context.Context, req *billingp
 func (i *BillingInfrastructure) GetBillingInfo(ctx context.Context, courseID int) (string, int, error) {
 	return i.Database.GetBillingInfo(ctx, courseID)
 }
+
+func (i *BillingInfrastructure) GetUserPurchases(ctx context.Context, userID int) ([]*billingmodels.Purchase, error) {
+	return i.Database.GetUserPurchases(ctx, userID)
+}
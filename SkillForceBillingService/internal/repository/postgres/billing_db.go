@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+
+	billingmodels "skillForce/internal/models/billing"
 )
 
 func (d *Database) AddNewBilling(ctx context.Context, userID int, courseID int, billing_id string) error {
@@ -71,3 +73,32 @@ func (d *Database) GetBillingInfo(ctx context.Context, courseID int) (string, in
 	}
 	return title, price, nil
 }
+
+// GetUserPurchases - история платежей пользователя. Платежи хранятся и после удаления аккаунта,
+// так как это финансовые документы
+func (d *Database) GetUserPurchases(ctx context.Context, userID int) ([]*billingmodels.Purchase, error) {
+	query := `
+	SELECT p.ID, p.Course_ID, COALESCE(c.Title, ''), COALESCE(c.Price, 0), p.Status, p.Billing_ID, COALESCE(p.Updated_at::text, '')
+	FROM PURCHACES p
+	LEFT JOIN COURSE c ON c.ID = p.Course_ID
+	WHERE p.User_ID = $1
+	ORDER BY p.ID
+`
+
+	rows, err := d.conn.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error executing select: %w", err)
+	}
+	defer rows.Close()
+
+	purchases := make([]*billingmodels.Purchase, 0)
+	for rows.Next() {
+		var purchase billingmodels.Purchase
+		err := rows.Scan(&purchase.Id, &purchase.CourseId, &purchase.CourseTitle, &purchase.Price, &purchase.Status, &purchase.BillingId, &purchase.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning purchase: %w", err)
+		}
+		purchases = append(purchases, &purchase)
+	}
+	return purchases, rows.Err()
+}
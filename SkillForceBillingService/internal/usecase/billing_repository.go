@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	billingpb "skillForce/internal/delivery/grpc/proto"
+	billingmodels "skillForce/internal/models/billing"
 )
 
 type BillingRepository interface {
@@ -11,4 +12,5 @@ type BillingRepository interface {
 	GetBillingInfo(ctx context.Context, courseID int) (string, int, error)
 	CreatePayment(returnUrl string, title string, userID int32, courseID int32, amount int) (string, *billingpb.CreatePaymentResponse, error)
 	HandleWebhook(ctx context.Context, req *billingpb.YooKassaWebhook) (bool, error)
+	GetUserPurchases(ctx context.Context, userID int) ([]*billingmodels.Purchase, error)
 }
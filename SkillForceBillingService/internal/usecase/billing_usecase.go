@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	billingpb "skillForce/internal/delivery/grpc/proto"
 	"skillForce/pkg/logs"
@@ -55,3 +56,21 @@ func (uc *BillingUsecase) UpdateBilling(ctx context.Context, req *billingpb.YooK
 	}
 	return &emptypb.Empty{}, nil
 }
+
+func (uc *BillingUsecase) ExportUserData(ctx context.Context, userId int) (*billingpb.ExportUserDataResponse, error) {
+	purchases, err := uc.repo.GetUserPurchases(ctx, userId)
+	if err != nil {
+		logs.PrintLog(ctx, "ExportUserData", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+
+	content, err := json.MarshalIndent(purchases, "", "  ")
+	if err != nil {
+		logs.PrintLog(ctx, "ExportUserData", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+
+	return &billingpb.ExportUserDataResponse{
+		Files: []*billingpb.UserDataFile{{Name: "purchases.json", Content: content}},
+	}, nil
+}
@@ -1,14 +1,19 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net"
+	"time"
+	_ "time/tzdata"
 
 	"skillForce/config"
 	courseGrpcHandler "skillForce/internal/delivery/grpc/handler"
 	coursepb "skillForce/internal/delivery/grpc/proto"
+	coursemodels "skillForce/internal/models/course"
 	"skillForce/internal/repository"
 	"skillForce/internal/usecase"
+	"skillForce/pkg/auth"
 	"skillForce/pkg/logs"
 	"skillForce/pkg/metrics"
 
@@ -26,6 +31,38 @@ func main() {
 
 	courseUsecase := usecase.NewCourseUsecase(infrastructure)
 
+	// удаление курсовых данных аккаунтов, срок отмены удаления которых истёк
+	go courseUsecase.RunAccountPurger(context.Background(), time.Hour)
+	// письма вовлечения по прогрессу на курсах и неоплаченным покупкам
+	if cfg.Campaigns.Enabled {
+		go courseUsecase.RunCampaigns(context.Background(), coursemodels.CampaignConfig{
+			Interval:               cfg.Campaigns.Interval,
+			BatchSize:              cfg.Campaigns.BatchSize,
+			InactivityAfter:        cfg.Campaigns.InactivityAfter,
+			AbandonedCheckoutAfter: cfg.Campaigns.AbandonedCheckoutAfter,
+			MaxPerWeek:             cfg.Campaigns.MaxPerWeek,
+			MinInterval:            cfg.Campaigns.MinInterval,
+		})
+	}
+	// еженедельная сводка обучения в утро пользователя по его часовому поясу
+	if cfg.Digest.Enabled {
+		err := courseUsecase.StartWeeklyDigests(context.Background(), infrastructure.Scheduler, coursemodels.DigestConfig{
+			Weekday:      cfg.Digest.Weekday,
+			Hour:         cfg.Digest.Hour,
+			PlanInterval: cfg.Digest.PlanInterval,
+			BatchSize:    cfg.Digest.BatchSize,
+			MaxLateness:  cfg.Digest.MaxLateness,
+			MaxCourses:   cfg.Digest.MaxCourses,
+		})
+		if err != nil {
+			log.Fatalf("failed to start weekly digests: %v", err)
+		}
+	}
+	// отложенные задачи из scheduled_jobs
+	go infrastructure.Scheduler.Run(context.Background())
+	// отправка писем из outbox_events в Kafka
+	go infrastructure.OutboxRelay.Run(context.Background())
+
 	metrics.Init(":9082")
 
 	lis, err := net.Listen("tcp", ":8082")
@@ -33,11 +70,25 @@ func main() {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
+	creds, err := auth.ServerCredentials(auth.TLSFiles{
+		CaFile:   cfg.Tls.CaFile,
+		CertFile: cfg.Tls.CertFile,
+		KeyFile:  cfg.Tls.KeyFile,
+	})
+	if err != nil {
+		log.Fatalf("failed to load tls credentials: %v", err)
+	}
+	// вызывать сервис может только MainService
+	verifier := auth.NewTokenVerifier(cfg.Secrets.ServiceTokenSecret, auth.ServiceCourse, auth.ServiceMain)
+
 	grpcServer := grpc.NewServer(
+		grpc.Creds(creds),
 		grpc.UnaryInterceptor(
 			grpc_middleware.ChainUnaryServer(
 				logs.GRPCLoggerInterceptor(),
+				logs.CorrelationInterceptor(),
 				grpc_prometheus.UnaryServerInterceptor,
+				auth.UnaryServerInterceptor(verifier, courseGrpcHandler.MethodPermissions),
 			),
 		),
 	)
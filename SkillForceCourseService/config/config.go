@@ -3,6 +3,8 @@ package config
 import (
 	"log"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"gopkg.in/yaml.v2"
@@ -25,8 +27,21 @@ type Config struct {
 		UseSSL          bool
 	}
 
+	Storage struct {
+		Backend   string
+		LocalDir  string
+		PublicURL string
+	}
+
 	Secrets struct {
-		JwtSessionSecret string
+		JwtSessionSecret   string
+		ServiceTokenSecret string
+	}
+
+	Tls struct {
+		CaFile   string
+		CertFile string
+		KeyFile  string
 	}
 
 	Mail struct {
@@ -35,6 +50,41 @@ type Config struct {
 		Host     string
 		Port     string
 	}
+
+	Kafka struct {
+		Brokers string
+	}
+
+	Outbox struct {
+		Interval  time.Duration
+		BatchSize int
+		Retention time.Duration
+	}
+
+	Campaigns struct {
+		Enabled                bool
+		Interval               time.Duration
+		BatchSize              int
+		InactivityAfter        time.Duration
+		AbandonedCheckoutAfter time.Duration
+		MaxPerWeek             int
+		MinInterval            time.Duration
+	}
+
+	Scheduler struct {
+		Interval  time.Duration
+		BatchSize int
+	}
+
+	Digest struct {
+		Enabled      bool
+		Weekday      time.Weekday
+		Hour         int
+		PlanInterval time.Duration
+		BatchSize    int
+		MaxLateness  time.Duration
+		MaxCourses   int
+	}
 }
 
 type yamlConfig struct {
@@ -49,6 +99,63 @@ type yamlConfig struct {
 		BucketName string `yaml:"bucket_name"`
 		UseSSL     bool   `yaml:"use_ssl"`
 	} `yaml:"minio"`
+
+	Storage struct {
+		Backend   string `yaml:"backend"`
+		LocalDir  string `yaml:"local_dir"`
+		PublicURL string `yaml:"public_url"`
+	} `yaml:"storage"`
+
+	Tls struct {
+		CaFile   string `yaml:"ca_file"`
+		CertFile string `yaml:"cert_file"`
+		KeyFile  string `yaml:"key_file"`
+	} `yaml:"tls"`
+
+	Kafka struct {
+		Brokers string `yaml:"brokers"`
+	} `yaml:"kafka"`
+
+	Outbox struct {
+		Interval  time.Duration `yaml:"interval"`
+		BatchSize int           `yaml:"batch_size"`
+		Retention time.Duration `yaml:"retention"`
+	} `yaml:"outbox"`
+
+	Campaigns struct {
+		Enabled                bool          `yaml:"enabled"`
+		Interval               time.Duration `yaml:"interval"`
+		BatchSize              int           `yaml:"batch_size"`
+		InactivityAfter        time.Duration `yaml:"inactivity_after"`
+		AbandonedCheckoutAfter time.Duration `yaml:"abandoned_checkout_after"`
+		MaxPerWeek             int           `yaml:"max_per_week"`
+		MinInterval            time.Duration `yaml:"min_interval"`
+	} `yaml:"campaigns"`
+
+	Scheduler struct {
+		Interval  time.Duration `yaml:"interval"`
+		BatchSize int           `yaml:"batch_size"`
+	} `yaml:"scheduler"`
+
+	Digest struct {
+		Enabled      bool          `yaml:"enabled"`
+		Weekday      string        `yaml:"weekday"`
+		Hour         int           `yaml:"hour"`
+		PlanInterval time.Duration `yaml:"plan_interval"`
+		BatchSize    int           `yaml:"batch_size"`
+		MaxLateness  time.Duration `yaml:"max_lateness"`
+		MaxCourses   int           `yaml:"max_courses"`
+	} `yaml:"digest"`
+}
+
+// parseWeekday - день недели по английскому названию: monday, Sunday
+func parseWeekday(name string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(day.String(), name) {
+			return day, true
+		}
+	}
+	return time.Sunday, false
 }
 
 func LoadConfig() *Config {
@@ -68,6 +175,11 @@ func LoadConfig() *Config {
 		log.Fatalf("ошибка парсинга YAML: %v", err)
 	}
 
+	digestWeekday, ok := parseWeekday(ycfg.Digest.Weekday)
+	if !ok && ycfg.Digest.Enabled {
+		log.Fatalf("неизвестный день недели сводки: %q", ycfg.Digest.Weekday)
+	}
+
 	return &Config{
 		Database: struct {
 			Host     string
@@ -95,8 +207,30 @@ func LoadConfig() *Config {
 			BucketName:      ycfg.Minio.BucketName,
 			UseSSL:          ycfg.Minio.UseSSL,
 		},
-		Secrets: struct{ JwtSessionSecret string }{
-			JwtSessionSecret: os.Getenv("JWT_SESSION_SECRET"),
+		Storage: struct {
+			Backend   string
+			LocalDir  string
+			PublicURL string
+		}{
+			Backend:   ycfg.Storage.Backend,
+			LocalDir:  ycfg.Storage.LocalDir,
+			PublicURL: ycfg.Storage.PublicURL,
+		},
+		Secrets: struct {
+			JwtSessionSecret   string
+			ServiceTokenSecret string
+		}{
+			JwtSessionSecret:   os.Getenv("JWT_SESSION_SECRET"),
+			ServiceTokenSecret: requireEnv("SERVICE_TOKEN_SECRET"),
+		},
+		Tls: struct {
+			CaFile   string
+			CertFile string
+			KeyFile  string
+		}{
+			CaFile:   ycfg.Tls.CaFile,
+			CertFile: ycfg.Tls.CertFile,
+			KeyFile:  ycfg.Tls.KeyFile,
 		},
 		Mail: struct {
 			From     string
@@ -109,5 +243,68 @@ func LoadConfig() *Config {
 			Host:     os.Getenv("MAIL_HOST"),
 			Port:     os.Getenv("MAIL_PORT"),
 		},
+		Kafka: struct{ Brokers string }{
+			Brokers: ycfg.Kafka.Brokers,
+		},
+		Outbox: struct {
+			Interval  time.Duration
+			BatchSize int
+			Retention time.Duration
+		}{
+			Interval:  ycfg.Outbox.Interval,
+			BatchSize: ycfg.Outbox.BatchSize,
+			Retention: ycfg.Outbox.Retention,
+		},
+		Campaigns: struct {
+			Enabled                bool
+			Interval               time.Duration
+			BatchSize              int
+			InactivityAfter        time.Duration
+			AbandonedCheckoutAfter time.Duration
+			MaxPerWeek             int
+			MinInterval            time.Duration
+		}{
+			Enabled:                ycfg.Campaigns.Enabled,
+			Interval:               ycfg.Campaigns.Interval,
+			BatchSize:              ycfg.Campaigns.BatchSize,
+			InactivityAfter:        ycfg.Campaigns.InactivityAfter,
+			AbandonedCheckoutAfter: ycfg.Campaigns.AbandonedCheckoutAfter,
+			MaxPerWeek:             ycfg.Campaigns.MaxPerWeek,
+			MinInterval:            ycfg.Campaigns.MinInterval,
+		},
+		Scheduler: struct {
+			Interval  time.Duration
+			BatchSize int
+		}{
+			Interval:  ycfg.Scheduler.Interval,
+			BatchSize: ycfg.Scheduler.BatchSize,
+		},
+		Digest: struct {
+			Enabled      bool
+			Weekday      time.Weekday
+			Hour         int
+			PlanInterval time.Duration
+			BatchSize    int
+			MaxLateness  time.Duration
+			MaxCourses   int
+		}{
+			Enabled:      ycfg.Digest.Enabled,
+			Weekday:      digestWeekday,
+			Hour:         ycfg.Digest.Hour,
+			PlanInterval: ycfg.Digest.PlanInterval,
+			BatchSize:    ycfg.Digest.BatchSize,
+			MaxLateness:  ycfg.Digest.MaxLateness,
+			MaxCourses:   ycfg.Digest.MaxCourses,
+		},
+	}
+}
+
+// requireEnv - секрет из .env. Пустой секрет не допускается: HMAC подпись с пустым ключом
+// может подделать кто угодно
+func requireEnv(name string) string {
+	value := os.Getenv(name)
+	if value == "" {
+		log.Fatalf("не задана переменная окружения %s", name)
 	}
+	return value
 }
@@ -20,6 +20,7 @@ require (
 	github.com/sirupsen/logrus v1.9.3
 	github.com/stretchr/testify v1.10.0
 	github.com/swaggo/swag v1.8.1
+	github.com/yuin/goldmark v1.7.8
 	golang.org/x/crypto v0.35.0
 	google.golang.org/grpc v1.72.0
 	google.golang.org/protobuf v1.36.5
@@ -22,7 +22,7 @@ func NewCourseHandler(uc *usecase.CourseUsecase) *CourseHandler {
 }
 
 func (h *CourseHandler) GetBucketCourses(ctx context.Context, req *coursepb.GetBucketCoursesRequest) (*coursepb.GetBucketCoursesResponse, error) {
-	courses, err := h.usecase.GetBucketCourses(ctx, mapToGetUserProfile(req.UserProfile))
+	courses, err := h.usecase.GetBucketCourses(ctx, userProfile(ctx, req.UserProfile))
 	if err != nil {
 		return nil, err
 	}
@@ -30,7 +30,7 @@ func (h *CourseHandler) GetBucketCourses(ctx context.Context, req *coursepb.GetB
 }
 
 func (h *CourseHandler) GetPurchasedBucketCourses(ctx context.Context, req *coursepb.GetBucketCoursesRequest) (*coursepb.GetBucketCoursesResponse, error) {
-	courses, err := h.usecase.GetPurchasedBucketCourses(ctx, mapToGetUserProfile(req.UserProfile))
+	courses, err := h.usecase.GetPurchasedBucketCourses(ctx, userProfile(ctx, req.UserProfile))
 	if err != nil {
 		return nil, err
 	}
@@ -38,7 +38,7 @@ func (h *CourseHandler) GetPurchasedBucketCourses(ctx context.Context, req *cour
 }
 
 func (h *CourseHandler) GetCompletedBucketCourses(ctx context.Context, req *coursepb.GetBucketCoursesRequest) (*coursepb.GetBucketCoursesResponse, error) {
-	courses, err := h.usecase.GetCompletedBucketCourses(ctx, mapToGetUserProfile(req.UserProfile))
+	courses, err := h.usecase.GetCompletedBucketCourses(ctx, userProfile(ctx, req.UserProfile))
 	if err != nil {
 		return nil, err
 	}
@@ -46,7 +46,7 @@ func (h *CourseHandler) GetCompletedBucketCourses(ctx context.Context, req *cour
 }
 
 func (h *CourseHandler) GetCourseLesson(ctx context.Context, req *coursepb.GetCourseLessonRequest) (*coursepb.GetCourseLessonResponse, error) {
-	lesson, err := h.usecase.GetCourseLesson(ctx, int(req.UserId), int(req.CourseId))
+	lesson, err := h.usecase.GetCourseLesson(ctx, userId(ctx), int(req.CourseId))
 	if err != nil {
 		return nil, err
 	}
@@ -54,7 +54,7 @@ func (h *CourseHandler) GetCourseLesson(ctx context.Context, req *coursepb.GetCo
 }
 
 func (h *CourseHandler) GetNextLesson(ctx context.Context, req *coursepb.GetNextLessonRequest) (*coursepb.GetNextLessonResponse, error) {
-	lesson, err := h.usecase.GetNextLesson(ctx, int(req.UserId), int(req.CourseId), int(req.LessonId))
+	lesson, err := h.usecase.GetNextLesson(ctx, userId(ctx), int(req.CourseId), int(req.LessonId))
 	if err != nil {
 		return nil, err
 	}
@@ -62,7 +62,7 @@ func (h *CourseHandler) GetNextLesson(ctx context.Context, req *coursepb.GetNext
 }
 
 func (h *CourseHandler) MarkLessonAsNotCompleted(ctx context.Context, req *coursepb.MarkLessonAsNotCompletedRequest) (*emptypb.Empty, error) {
-	err := h.usecase.MarkLessonAsNotCompleted(ctx, int(req.UserId), int(req.LessonId))
+	err := h.usecase.MarkLessonAsNotCompleted(ctx, userId(ctx), int(req.LessonId))
 	if err != nil {
 		return nil, err
 	}
@@ -70,7 +70,7 @@ func (h *CourseHandler) MarkLessonAsNotCompleted(ctx context.Context, req *cours
 }
 
 func (h *CourseHandler) MarkLessonAsCompleted(ctx context.Context, req *coursepb.MarkLessonAsCompletedRequest) (*emptypb.Empty, error) {
-	err := h.usecase.MarkLessonAsCompleted(ctx, int(req.UserId), int(req.LessonId))
+	err := h.usecase.MarkLessonAsCompleted(ctx, userId(ctx), int(req.LessonId))
 	if err != nil {
 		return nil, err
 	}
@@ -78,8 +78,8 @@ func (h *CourseHandler) MarkLessonAsCompleted(ctx context.Context, req *coursepb
 }
 
 func (h *CourseHandler) MarkCourseAsCompleted(ctx context.Context, req *coursepb.MarkCourseAsCompletedRequest) (*emptypb.Empty, error) {
-	err := h.usecase.MarkCourseAsCompleted(ctx, int(req.UserId), int(req.CourseId))
-	logs.PrintLog(ctx, "MarkCourseAsCompleted", fmt.Sprintf("course id: %v, user id: %v", int(req.CourseId), int(req.UserId)))
+	err := h.usecase.MarkCourseAsCompleted(ctx, userId(ctx), int(req.CourseId))
+	logs.PrintLog(ctx, "MarkCourseAsCompleted", fmt.Sprintf("course id: %v, user id: %v", int(req.CourseId), userId(ctx)))
 	if err != nil {
 		return nil, err
 	}
@@ -87,7 +87,7 @@ func (h *CourseHandler) MarkCourseAsCompleted(ctx context.Context, req *coursepb
 }
 
 func (h *CourseHandler) GetCourseRoadmap(ctx context.Context, req *coursepb.GetCourseRoadmapRequest) (*coursepb.GetCourseRoadmapResponse, error) {
-	roadmap, err := h.usecase.GetCourseRoadmap(ctx, int(req.UserId), int(req.CourseId))
+	roadmap, err := h.usecase.GetCourseRoadmap(ctx, userId(ctx), int(req.CourseId))
 	if err != nil {
 		return nil, err
 	}
@@ -95,7 +95,7 @@ func (h *CourseHandler) GetCourseRoadmap(ctx context.Context, req *coursepb.GetC
 }
 
 func (h *CourseHandler) GetRating(ctx context.Context, req *coursepb.GetRatingRequest) (*coursepb.GetRatingResponse, error) {
-	rating, err := h.usecase.GetRating(ctx, int(req.UserId), int(req.CourseId))
+	rating, err := h.usecase.GetRating(ctx, userId(ctx), int(req.CourseId))
 	if err != nil {
 		return nil, err
 	}
@@ -103,7 +103,7 @@ func (h *CourseHandler) GetRating(ctx context.Context, req *coursepb.GetRatingRe
 }
 
 func (h *CourseHandler) GetSertificate(ctx context.Context, req *coursepb.GetSertificateRequest) (*coursepb.GetSertificateResponse, error) {
-	sertificateUrl, err := h.usecase.GetSertificate(ctx, mapToGetUserProfile(req.User), int(req.CourseId))
+	sertificateUrl, err := h.usecase.GetSertificate(ctx, userProfile(ctx, req.User), int(req.CourseId))
 	if err != nil {
 		return nil, err
 	}
@@ -111,7 +111,7 @@ func (h *CourseHandler) GetSertificate(ctx context.Context, req *coursepb.GetSer
 }
 
 func (h *CourseHandler) GetGeneratedSertificate(ctx context.Context, req *coursepb.GetSertificateRequest) (*coursepb.GetSertificateResponse, error) {
-	sertificateUrl, err := h.usecase.GetGeneratedSertificate(ctx, mapToGetUserProfile(req.User), int(req.CourseId))
+	sertificateUrl, err := h.usecase.GetGeneratedSertificate(ctx, userProfile(ctx, req.User), int(req.CourseId))
 	if err != nil {
 		return nil, err
 	}
@@ -119,7 +119,7 @@ func (h *CourseHandler) GetGeneratedSertificate(ctx context.Context, req *course
 }
 
 func (h *CourseHandler) GetStatistic(ctx context.Context, req *coursepb.GetStatisticRequest) (*coursepb.GetStatisticResponse, error) {
-	stats, err := h.usecase.GetStatistic(ctx, int(req.UserId), int(req.CourseId))
+	stats, err := h.usecase.GetStatistic(ctx, userId(ctx), int(req.CourseId))
 	if err != nil {
 		return nil, err
 	}
@@ -127,7 +127,7 @@ func (h *CourseHandler) GetStatistic(ctx context.Context, req *coursepb.GetStati
 }
 
 func (h *CourseHandler) GetCourse(ctx context.Context, req *coursepb.GetCourseRequest) (*coursepb.GetCourseResponse, error) {
-	course, err := h.usecase.GetCourse(ctx, int(req.CourseId), mapToGetUserProfile(req.UserProfile))
+	course, err := h.usecase.GetCourse(ctx, int(req.CourseId), userProfile(ctx, req.UserProfile))
 	if err != nil {
 		return nil, err
 	}
@@ -135,7 +135,7 @@ func (h *CourseHandler) GetCourse(ctx context.Context, req *coursepb.GetCourseRe
 }
 
 func (h *CourseHandler) CreateCourse(ctx context.Context, req *coursepb.CreateCourseRequest) (*emptypb.Empty, error) {
-	err := h.usecase.CreateCourse(ctx, mapPbCourseDTOToDTO(req.Course), mapToGetUserProfile(req.UserProfile))
+	err := h.usecase.CreateCourse(ctx, mapPbCourseDTOToDTO(req.Course), userProfile(ctx, req.UserProfile))
 	if err != nil {
 		return nil, err
 	}
@@ -143,7 +143,7 @@ func (h *CourseHandler) CreateCourse(ctx context.Context, req *coursepb.CreateCo
 }
 
 func (h *CourseHandler) AddCourseToFavourites(ctx context.Context, req *coursepb.AddToFavouritesRequest) (*emptypb.Empty, error) {
-	err := h.usecase.AddCourseToFavourites(ctx, mapPbCourseDTOToDTO(req.Course), mapToGetUserProfile(req.UserProfile))
+	err := h.usecase.AddCourseToFavourites(ctx, mapPbCourseDTOToDTO(req.Course), userProfile(ctx, req.UserProfile))
 	if err != nil {
 		return nil, err
 	}
@@ -151,7 +151,7 @@ func (h *CourseHandler) AddCourseToFavourites(ctx context.Context, req *coursepb
 }
 
 func (h *CourseHandler) DeleteCourseFromFavourites(ctx context.Context, req *coursepb.DeleteCourseFromFavouritesRequest) (*emptypb.Empty, error) {
-	err := h.usecase.DeleteCourseFromFavourites(ctx, mapPbCourseDTOToDTO(req.Course), mapToGetUserProfile(req.UserProfile))
+	err := h.usecase.DeleteCourseFromFavourites(ctx, mapPbCourseDTOToDTO(req.Course), userProfile(ctx, req.UserProfile))
 	if err != nil {
 		return nil, err
 	}
@@ -159,7 +159,7 @@ func (h *CourseHandler) DeleteCourseFromFavourites(ctx context.Context, req *cou
 }
 
 func (h *CourseHandler) GetFavouriteCourses(ctx context.Context, req *coursepb.GetFavouritesRequest) (*coursepb.GetFavouritesResponse, error) {
-	courses, err := h.usecase.GetFavouriteCourses(ctx, mapToGetUserProfile(req.UserProfile))
+	courses, err := h.usecase.GetFavouriteCourses(ctx, userProfile(ctx, req.UserProfile))
 	if err != nil {
 		return nil, err
 	}
@@ -167,7 +167,7 @@ func (h *CourseHandler) GetFavouriteCourses(ctx context.Context, req *coursepb.G
 }
 
 func (h *CourseHandler) GetTestLesson(ctx context.Context, req *coursepb.GetTestLessonRequest) (*coursepb.GetTestLessonResponse, error) {
-	tests, err := h.usecase.GetTestLesson(ctx, int(req.LessonId), int(req.UserId))
+	tests, err := h.usecase.GetTestLesson(ctx, int(req.LessonId), userId(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -175,7 +175,7 @@ func (h *CourseHandler) GetTestLesson(ctx context.Context, req *coursepb.GetTest
 }
 
 func (h *CourseHandler) AnswerQuiz(ctx context.Context, req *coursepb.AnswerQuizRequest) (*coursepb.AnswerQuizResponse, error) {
-	res, err := h.usecase.AnswerQuiz(ctx, int(req.QuestionId), int(req.AnswerId), int(req.UserId), int(req.CourseId))
+	res, err := h.usecase.AnswerQuiz(ctx, int(req.QuestionId), int(req.AnswerId), userId(ctx), int(req.CourseId))
 	if err != nil {
 		return nil, err
 	}
@@ -183,7 +183,7 @@ func (h *CourseHandler) AnswerQuiz(ctx context.Context, req *coursepb.AnswerQuiz
 }
 
 func (h *CourseHandler) GetQuestionTestLesson(ctx context.Context, req *coursepb.GetQuestionTestLessonRequest) (*coursepb.GetQuestionTestLessonResponse, error) {
-	question, err := h.usecase.GetQuestionTestLesson(ctx, int(req.LessonId), int(req.UserId))
+	question, err := h.usecase.GetQuestionTestLesson(ctx, int(req.LessonId), userId(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -191,7 +191,7 @@ func (h *CourseHandler) GetQuestionTestLesson(ctx context.Context, req *coursepb
 }
 
 func (h *CourseHandler) AnswerQuestion(ctx context.Context, req *coursepb.AnswerQuestionRequest) (*emptypb.Empty, error) {
-	err := h.usecase.AnswerQuestion(ctx, int(req.QuestionId), int(req.UserId), req.Answer)
+	err := h.usecase.AnswerQuestion(ctx, int(req.QuestionId), userId(ctx), req.Answer)
 	if err != nil {
 		return nil, err
 	}
@@ -199,9 +199,78 @@ func (h *CourseHandler) AnswerQuestion(ctx context.Context, req *coursepb.Answer
 }
 
 func (h *CourseHandler) SearchCoursesByTitle(ctx context.Context, req *coursepb.SearchCoursesByTitleRequest) (*coursepb.GetBucketCoursesResponse, error) {
-	courses, err := h.usecase.SearchCoursesByTitle(ctx, mapToGetUserProfile(req.UserProfile), req.Keywords)
+	courses, err := h.usecase.SearchCoursesByTitle(ctx, userProfile(ctx, req.UserProfile), req.Keywords)
 	if err != nil {
 		return nil, err
 	}
 	return mapToGetBucketCoursesResponse(courses), nil
 }
+
+func (h *CourseHandler) GetUserCoursesSummary(ctx context.Context, req *coursepb.GetUserCoursesSummaryRequest) (*coursepb.GetUserCoursesSummaryResponse, error) {
+	summary, err := h.usecase.GetUserCoursesSummary(ctx, int(req.UserId))
+	if err != nil {
+		return nil, err
+	}
+	return mapToUserCoursesSummaryResponse(summary), nil
+}
+
+func (h *CourseHandler) ExportUserData(ctx context.Context, req *coursepb.ExportUserDataRequest) (*coursepb.ExportUserDataResponse, error) {
+	files, err := h.usecase.ExportUserData(ctx, userId(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return mapToExportUserDataResponse(files), nil
+}
+
+func (h *CourseHandler) CanViewLesson(ctx context.Context, req *coursepb.CanViewLessonRequest) (*coursepb.CanViewLessonResponse, error) {
+	allowed, err := h.usecase.CanViewLesson(ctx, userProfile(ctx, nil), int(req.LessonId))
+	if err != nil {
+		return nil, err
+	}
+	return &coursepb.CanViewLessonResponse{Allowed: allowed}, nil
+}
+
+func (h *CourseHandler) AnswerBlockQuiz(ctx context.Context, req *coursepb.AnswerBlockQuizRequest) (*coursepb.AnswerBlockQuizResponse, error) {
+	correct, err := h.usecase.AnswerBlockQuiz(ctx, userProfile(ctx, nil), int(req.LessonId), int(req.BlockIndex), int(req.Option))
+	if err != nil {
+		return nil, err
+	}
+	return &coursepb.AnswerBlockQuizResponse{Correct: correct}, nil
+}
+
+func (h *CourseHandler) PreviewLessonBlocks(ctx context.Context, req *coursepb.PreviewLessonBlocksRequest) (*coursepb.PreviewLessonBlocksResponse, error) {
+	blocks, err := h.usecase.PreviewLessonBlocks(ctx, userProfile(ctx, nil), mapPbBlocks(req.Blocks))
+	if err != nil {
+		return nil, err
+	}
+	return &coursepb.PreviewLessonBlocksResponse{Blocks: mapBlocks(blocks)}, nil
+}
+
+func (h *CourseHandler) GetCourseBundle(ctx context.Context, req *coursepb.GetCourseBundleRequest) (*coursepb.GetCourseBundleResponse, error) {
+	bundle, err := h.usecase.GetCourseBundle(ctx, userProfile(ctx, nil), int(req.CourseId))
+	if err != nil {
+		return nil, err
+	}
+	return mapToCourseBundleResponse(bundle), nil
+}
+
+func (h *CourseHandler) MarkLessonsCompleted(ctx context.Context, req *coursepb.MarkLessonsCompletedRequest) (*coursepb.MarkLessonsCompletedResponse, error) {
+	lessonIds := make([]int, 0, len(req.LessonIds))
+	for _, lessonId := range req.LessonIds {
+		lessonIds = append(lessonIds, int(lessonId))
+	}
+
+	marked, err := h.usecase.MarkLessonsCompleted(ctx, userProfile(ctx, nil), int(req.CourseId), lessonIds)
+	if err != nil {
+		return nil, err
+	}
+	return &coursepb.MarkLessonsCompletedResponse{Marked: int32(marked)}, nil
+}
+
+func (h *CourseHandler) SetCourseHidden(ctx context.Context, req *coursepb.SetCourseHiddenRequest) (*emptypb.Empty, error) {
+	err := h.usecase.SetCourseHidden(ctx, userProfile(ctx, nil), int(req.CourseId), req.Hidden, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
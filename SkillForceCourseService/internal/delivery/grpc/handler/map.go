@@ -89,7 +89,7 @@ func mapToLessonDTO(dto *dto.LessonDTO) *coursepb.LessonDTO {
 			}(dto.LessonHeader.Points)),
 		},
 		Body: &coursepb.LessonDtoBody{
-			Blocks: mapBlocks([]struct{ Body string }(dto.LessonBody.Blocks)),
+			Blocks: mapBlocks(dto.LessonBody.Blocks),
 			Footer: &coursepb.Footer{
 				NextLessonId:     int32(dto.LessonBody.Footer.NextLessonId),
 				CurrentLessonId:  int32(dto.LessonBody.Footer.CurrentLessonId),
@@ -115,10 +115,54 @@ func mapLessonHeaderPoints(points []struct {
 	return res
 }
 
-func mapBlocks(blocks []struct{ Body string }) []*coursepb.Block {
+func mapBlocks(blocks []dto.LessonBlockDTO) []*coursepb.Block {
 	res := make([]*coursepb.Block, 0, len(blocks))
 	for _, b := range blocks {
-		res = append(res, &coursepb.Block{Body: b.Body})
+		res = append(res, &coursepb.Block{
+			Body:        b.Body,
+			Type:        b.Type,
+			Text:        b.Text,
+			Object:      b.Object,
+			FileName:    b.FileName,
+			ContentType: b.ContentType,
+			Size:        b.Size,
+			Alt:         b.Alt,
+			Caption:     b.Caption,
+			Language:    b.Language,
+			Code:        b.Code,
+			Variant:     b.Variant,
+			Url:         b.Url,
+			Provider:    b.Provider,
+			VideoId:     b.VideoId,
+			EmbedUrl:    b.EmbedUrl,
+			Question:    b.Question,
+			Options:     b.Options,
+		})
+	}
+	return res
+}
+
+func mapPbBlocks(blocks []*coursepb.Block) []dto.LessonBlockDTO {
+	res := make([]dto.LessonBlockDTO, 0, len(blocks))
+	for _, b := range blocks {
+		block := dto.LessonBlockDTO{
+			Type:     b.Type,
+			Text:     b.Text,
+			Object:   b.Object,
+			Alt:      b.Alt,
+			Caption:  b.Caption,
+			Language: b.Language,
+			Code:     b.Code,
+			Variant:  b.Variant,
+			Url:      b.Url,
+			Question: b.Question,
+			Options:  b.Options,
+		}
+		if b.Answer != nil {
+			answer := int(*b.Answer)
+			block.Answer = &answer
+		}
+		res = append(res, block)
 	}
 	return res
 }
@@ -178,6 +222,7 @@ func mapPbCourseDTOToDTO(coursepb *coursepb.CourseDTO) *dto.CourseDTO {
 					Title:    lesson.Title,
 					Value:    lesson.Value,
 					IsDone:   lesson.IsDone,
+					Blocks:   mapPbBlocks(lesson.Blocks),
 				})
 			}
 			buckets = append(buckets, &dto.LessonBucketDTO{
@@ -331,3 +376,75 @@ func mapToStatisticResponse(userStats *dto.UserStats) *coursepb.GetStatisticResp
 		AmountQuestions:       int32(userStats.AmountQuestions),
 	}
 }
+
+func mapToUserCoursesSummaryResponse(summary *dto.UserCoursesSummaryDTO) *coursepb.GetUserCoursesSummaryResponse {
+	resp := &coursepb.GetUserCoursesSummaryResponse{
+		AuthoredCourses:  make([]*coursepb.CourseDTO, 0, len(summary.AuthoredCourses)),
+		CompletedCourses: make([]*coursepb.CompletedCourse, 0, len(summary.CompletedCourses)),
+		RatingPositions:  make([]*coursepb.RatingPosition, 0, len(summary.RatingPositions)),
+	}
+
+	for _, course := range summary.AuthoredCourses {
+		resp.AuthoredCourses = append(resp.AuthoredCourses, mapToCourseDTO(course))
+	}
+
+	for _, completed := range summary.CompletedCourses {
+		resp.CompletedCourses = append(resp.CompletedCourses, &coursepb.CompletedCourse{
+			Course:         mapToCourseDTO(completed.Course),
+			SertificateUrl: completed.SertificateUrl,
+		})
+	}
+
+	for _, position := range summary.RatingPositions {
+		resp.RatingPositions = append(resp.RatingPositions, &coursepb.RatingPosition{
+			CourseId:    int32(position.CourseId),
+			CourseTitle: position.CourseTitle,
+			Position:    int32(position.Position),
+			Score:       int32(position.Score),
+		})
+	}
+
+	if summary.AuthorStats != nil {
+		resp.AuthorStats = &coursepb.AuthorStats{
+			CoursesAmount:  int32(summary.AuthorStats.CoursesAmount),
+			StudentsAmount: int32(summary.AuthorStats.StudentsAmount),
+			Rating:         summary.AuthorStats.Rating,
+		}
+	}
+
+	return resp
+}
+
+func mapToExportUserDataResponse(files []*dto.UserDataFileDTO) *coursepb.ExportUserDataResponse {
+	resp := &coursepb.ExportUserDataResponse{
+		Files: make([]*coursepb.UserDataFile, 0, len(files)),
+	}
+	for _, file := range files {
+		resp.Files = append(resp.Files, &coursepb.UserDataFile{
+			Name:    file.Name,
+			Content: file.Content,
+		})
+	}
+	return resp
+}
+
+func mapToCourseBundleResponse(bundle *dto.CourseBundleDTO) *coursepb.GetCourseBundleResponse {
+	resp := &coursepb.GetCourseBundleResponse{
+		CourseId:    int32(bundle.CourseId),
+		Title:       bundle.Title,
+		Description: bundle.Description,
+		Version:     bundle.Version,
+		Lessons:     make([]*coursepb.BundleLesson, 0, len(bundle.Lessons)),
+	}
+	for _, lesson := range bundle.Lessons {
+		resp.Lessons = append(resp.Lessons, &coursepb.BundleLesson{
+			LessonId:    int32(lesson.LessonId),
+			PartTitle:   lesson.PartTitle,
+			BucketTitle: lesson.BucketTitle,
+			Title:       lesson.Title,
+			Type:        lesson.Type,
+			Blocks:      mapBlocks(lesson.Blocks),
+		})
+	}
+	return resp
+}
@@ -0,0 +1,67 @@
+package grpc
+
+import (
+	"context"
+	coursepb "skillForce/internal/delivery/grpc/proto"
+	usermodels "skillForce/internal/models/user"
+	"skillForce/pkg/auth"
+)
+
+// MethodPermissions - права, необходимые для вызова методов CourseService
+var MethodPermissions = map[string]auth.Permission{
+	"/course.CourseService/GetBucketCourses":           auth.PermPublic,
+	"/course.CourseService/SearchCoursesByTitle":       auth.PermPublic,
+	"/course.CourseService/GetCourse":                  auth.PermPublic,
+	"/course.CourseService/GetUserCoursesSummary":      auth.PermPublic,
+	"/course.CourseService/GetPurchasedBucketCourses":  auth.PermLearn,
+	"/course.CourseService/GetCompletedBucketCourses":  auth.PermLearn,
+	"/course.CourseService/GetCourseLesson":            auth.PermLearn,
+	"/course.CourseService/GetNextLesson":              auth.PermLearn,
+	"/course.CourseService/MarkLessonAsNotCompleted":   auth.PermLearn,
+	"/course.CourseService/MarkLessonAsCompleted":      auth.PermLearn,
+	"/course.CourseService/MarkCourseAsCompleted":      auth.PermLearn,
+	"/course.CourseService/GetCourseRoadmap":           auth.PermLearn,
+	"/course.CourseService/GetRating":                  auth.PermLearn,
+	"/course.CourseService/GetStatistic":               auth.PermLearn,
+	"/course.CourseService/GetSertificate":             auth.PermLearn,
+	"/course.CourseService/GetGeneratedSertificate":    auth.PermLearn,
+	"/course.CourseService/AddCourseToFavourites":      auth.PermLearn,
+	"/course.CourseService/DeleteCourseFromFavourites": auth.PermLearn,
+	"/course.CourseService/GetFavouriteCourses":        auth.PermLearn,
+	"/course.CourseService/GetTestLesson":              auth.PermLearn,
+	"/course.CourseService/AnswerQuiz":                 auth.PermLearn,
+	"/course.CourseService/GetQuestionTestLesson":      auth.PermLearn,
+	"/course.CourseService/AnswerQuestion":             auth.PermLearn,
+	"/course.CourseService/ExportUserData":             auth.PermLearn,
+	"/course.CourseService/CanViewLesson":              auth.PermLearn,
+	"/course.CourseService/AnswerBlockQuiz":            auth.PermLearn,
+	"/course.CourseService/GetCourseBundle":            auth.PermLearn,
+	"/course.CourseService/MarkLessonsCompleted":       auth.PermLearn,
+	"/course.CourseService/CreateCourse":               auth.PermCreateCourse,
+	"/course.CourseService/PreviewLessonBlocks":        auth.PermCreateCourse,
+	"/course.CourseService/SetCourseHidden":            auth.PermModerate,
+}
+
+// userProfile - профиль из запроса, id и роль в котором берутся из проверенных метаданных
+func userProfile(ctx context.Context, profile *coursepb.UserProfile) *usermodels.UserProfile {
+	identity, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	userProfile := mapToGetUserProfile(profile)
+	if userProfile == nil {
+		userProfile = &usermodels.UserProfile{}
+	}
+	userProfile.Id = identity.UserId
+	userProfile.IsAdmin = identity.HasRole(auth.RoleAdmin)
+	return userProfile
+}
+
+func userId(ctx context.Context) int {
+	identity, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return 0
+	}
+	return identity.UserId
+}
@@ -0,0 +1,52 @@
+package coursemodels
+
+// Типы блоков текстового урока. html - блоки, созданные до появления типов
+const (
+	BlockHTML     = "html"
+	BlockMarkdown = "markdown"
+	BlockImage    = "image"
+	BlockFile     = "file"
+	BlockCode     = "code"
+	BlockCallout  = "callout"
+	BlockVideo    = "video"
+	BlockQuiz     = "quiz"
+)
+
+// LessonBlock - блок текстового урока, хранится в text_lesson_block.payload.
+// Какие поля заполнены, зависит от типа
+type LessonBlock struct {
+	Type string `json:"type"`
+	// html, markdown и callout. У markdown это исходник автора
+	Text string `json:"text,omitempty"`
+	// markdown: очищенный HTML, в который исходник превращён при сохранении
+	Html string `json:"html,omitempty"`
+	// image и file: объект в бакете lesson-files и данные о нём из lesson_files
+	Object      string `json:"object,omitempty"`
+	FileName    string `json:"file_name,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Size        int64  `json:"size,omitempty"`
+	Alt         string `json:"alt,omitempty"`
+	Caption     string `json:"caption,omitempty"`
+	// code
+	Language string `json:"language,omitempty"`
+	Code     string `json:"code,omitempty"`
+	// callout: info, tip, warning или danger
+	Variant string `json:"variant,omitempty"`
+	// video: ролик одного из разрешённых сервисов
+	Provider string `json:"provider,omitempty"`
+	VideoId  string `json:"video_id,omitempty"`
+	// quiz: номер правильного ответа не отдаётся клиенту
+	Question string   `json:"question,omitempty"`
+	Options  []string `json:"options,omitempty"`
+	Answer   int      `json:"answer,omitempty"`
+}
+
+// LessonFile - картинка или вложение, загруженные автором курса
+type LessonFile struct {
+	ObjectName  string
+	AuthorId    int
+	Kind        string
+	FileName    string
+	ContentType string
+	Size        int64
+}
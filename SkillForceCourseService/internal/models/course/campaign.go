@@ -0,0 +1,60 @@
+package coursemodels
+
+import (
+	"skillForce/pkg/events"
+	"time"
+)
+
+// Кампании писем вовлечения. Имя кампании - имя шаблона письма в mail-service
+const (
+	CampaignWelcome           = "welcome"
+	CampaignMidCourse         = "mid_course"
+	CampaignInactivity        = "inactivity"
+	CampaignCourseCompleted   = "course_completed"
+	CampaignAbandonedCheckout = "abandoned_checkout"
+)
+
+// Campaigns - кампании в порядке важности: при ограничении частоты письма идут в этом порядке
+var Campaigns = []string{
+	CampaignCourseCompleted,
+	CampaignWelcome,
+	CampaignAbandonedCheckout,
+	CampaignMidCourse,
+	CampaignInactivity,
+}
+
+// CampaignCategory - категория настроек уведомлений, отписка от которой отключает письма кампании
+func CampaignCategory(campaign string) string {
+	if campaign == CampaignAbandonedCheckout {
+		return events.CategoryMarketing
+	}
+	return events.CategoryCourseProgress
+}
+
+// CampaignConfig - параметры кампаний писем вовлечения
+type CampaignConfig struct {
+	// Interval - как часто ищутся пользователи для писем
+	Interval time.Duration
+	// BatchSize - сколько писем одной кампании отправляется за раз
+	BatchSize int
+	// InactivityAfter - сколько дней без пройденных уроков считается перерывом в обучении
+	InactivityAfter time.Duration
+	// AbandonedCheckoutAfter - через сколько после начала неоплаченной покупки о ней напоминается
+	AbandonedCheckoutAfter time.Duration
+	// MaxPerWeek - не больше стольких писем кампаний пользователю за 7 дней
+	MaxPerWeek int
+	// MinInterval - не чаще одного письма кампаний пользователю за это время
+	MinInterval time.Duration
+}
+
+// CampaignMail - письмо кампании пользователю по курсу
+type CampaignMail struct {
+	Campaign   string
+	UserId     int
+	CourseId   int
+	CourseName string
+	// Ref - отличает повторные письма одной кампании по курсу
+	Ref string
+	// Progress - процент пройденных уроков курса
+	Progress int
+}
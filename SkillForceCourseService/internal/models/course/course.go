@@ -35,6 +35,7 @@ type LessonPoint struct {
 	IsImage  bool
 	BucketId int
 	Order    int
+	Blocks   []*LessonBlock
 }
 
 type Survey struct {
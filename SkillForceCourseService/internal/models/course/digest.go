@@ -0,0 +1,122 @@
+package coursemodels
+
+import (
+	"fmt"
+	"time"
+)
+
+// Задачи еженедельной сводки в scheduled_jobs
+const (
+	// JobWeeklyDigest - сводка пользователю, key - id пользователя
+	JobWeeklyDigest = "weekly_digest"
+	// JobPlanWeeklyDigests - планирование сводок пользователям, у которых их ещё нет
+	JobPlanWeeklyDigests = "plan_weekly_digests"
+)
+
+// DefaultTimezone - часовой пояс пользователя, если его пояс неизвестен
+const DefaultTimezone = "Europe/Moscow"
+
+// DigestConfig - параметры еженедельной сводки
+type DigestConfig struct {
+	// Weekday и Hour - день недели и час по времени пользователя, когда приходит сводка
+	Weekday time.Weekday
+	Hour    int
+	// PlanInterval - как часто планируются сводки новым и снова подписавшимся пользователям
+	PlanInterval time.Duration
+	// BatchSize - сколько пользователей планируется за раз
+	BatchSize int
+	// MaxLateness - сводка, опоздавшая больше чем на это время (сервис не работал), не отправляется
+	MaxLateness time.Duration
+	// MaxCourses - не больше стольких курсов в каждом разделе сводки
+	MaxCourses int
+}
+
+// DigestUser - получатель сводки
+type DigestUser struct {
+	Id       int
+	Email    string
+	Name     string
+	Locale   string
+	Timezone string
+	// Subscribed - пользователь не отписан от категории digest
+	Subscribed bool
+	// Deleting - пользователь запросил удаление аккаунта
+	Deleting bool
+}
+
+// DigestCourse - курс в сводке
+type DigestCourse struct {
+	CourseId   int
+	CourseName string
+	// Lessons - уроков курса пройдено за неделю
+	Lessons int
+	// Progress - процент пройденных уроков курса
+	Progress int
+}
+
+// DigestRating - место в рейтинге курса
+type DigestRating struct {
+	CourseId   int
+	CourseName string
+	Position   int
+	// Change - на сколько мест поднялся с прошлой сводки
+	Change int
+}
+
+// WeeklyDigest - сводка обучения за неделю [PeriodStart, PeriodEnd)
+type WeeklyDigest struct {
+	User        *DigestUser
+	Week        string
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	Lessons     int
+	Courses     []*DigestCourse
+	Ratings     []*DigestRating
+	NewCourses  []*DigestCourse
+}
+
+// IsEmpty - в сводке нечего показать
+func (d *WeeklyDigest) IsEmpty() bool {
+	return d.Lessons == 0 && len(d.Courses) == 0 && len(d.Ratings) == 0 && len(d.NewCourses) == 0
+}
+
+// RatingPositions - места в рейтингах курсов по id курса, с ними сравнивается следующая сводка
+func (d *WeeklyDigest) RatingPositions() map[int]int {
+	positions := make(map[int]int, len(d.Ratings))
+	for _, rating := range d.Ratings {
+		positions[rating.CourseId] = rating.Position
+	}
+	return positions
+}
+
+// LoadTimezone - часовой пояс по имени из базы IANA, незнакомое имя заменяется DefaultTimezone
+func LoadTimezone(name string) *time.Location {
+	if loc, err := time.LoadLocation(name); err == nil && name != "" {
+		return loc
+	}
+	loc, err := time.LoadLocation(DefaultTimezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// NextDigestAt - ближайшее после now время сводки: день weekday, час hour в часовом поясе loc
+func NextDigestAt(now time.Time, loc *time.Location, weekday time.Weekday, hour int) time.Time {
+	local := now.In(loc)
+	days := (int(weekday) - int(local.Weekday()) + 7) % 7
+	next := time.Date(local.Year(), local.Month(), local.Day()+days, hour, 0, 0, 0, loc)
+	if !next.After(now) {
+		next = time.Date(local.Year(), local.Month(), local.Day()+days+7, hour, 0, 0, 0, loc)
+	}
+	return next
+}
+
+// DigestPeriod - неделя, итоги которой подводит сводка, отправляемая в at, и её номер по ISO 8601
+// (2026-W43) в часовом поясе loc
+func DigestPeriod(at time.Time, loc *time.Location) (start time.Time, end time.Time, week string) {
+	end = at.In(loc)
+	start = end.AddDate(0, 0, -7)
+	year, number := start.ISOWeek()
+	return start, end, fmt.Sprintf("%d-W%02d", year, number)
+}
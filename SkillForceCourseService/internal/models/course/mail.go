@@ -0,0 +1,4 @@
+package coursemodels
+
+// MailTopic - топик, из которого письма читает mail-service. Письма описаны в pkg/events
+const MailTopic = "mail"
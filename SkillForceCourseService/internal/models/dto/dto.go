@@ -38,9 +38,7 @@ type LessonDTO struct {
 }
 
 type LessonDtoBody struct {
-	Blocks []struct {
-		Body string `json:"body"`
-	} `json:"blocks"`
+	Blocks []LessonBlockDTO `json:"blocks"`
 	Footer struct {
 		NextLessonId     int `json:"next_lesson_id"`
 		CurrentLessonId  int `json:"current_lesson_id"`
@@ -71,11 +69,36 @@ type CourseRoadmapDTO struct {
 }
 
 type LessonPointDTO struct {
-	LessonId int    `json:"lesson_id"`
-	Type     string `json:"lesson_type"`
-	Title    string `json:"lesson_title"`
-	Value    string `json:"lesson_value"`
-	IsDone   bool   `json:"is_done"`
+	LessonId int              `json:"lesson_id"`
+	Type     string           `json:"lesson_type"`
+	Title    string           `json:"lesson_title"`
+	Value    string           `json:"lesson_value"`
+	IsDone   bool             `json:"is_done"`
+	Blocks   []LessonBlockDTO `json:"blocks"`
+}
+
+// LessonBlockDTO - блок текстового урока. При создании курса автор присылает Object, Url и Answer,
+// в уроке вместо них отдаются данные файла и EmbedUrl, а правильный ответ на вопрос не отдаётся
+type LessonBlockDTO struct {
+	Type        string   `json:"type"`
+	Body        string   `json:"body"`
+	Text        string   `json:"text"`
+	Object      string   `json:"object"`
+	FileName    string   `json:"file_name"`
+	ContentType string   `json:"content_type"`
+	Size        int64    `json:"size"`
+	Alt         string   `json:"alt"`
+	Caption     string   `json:"caption"`
+	Language    string   `json:"language"`
+	Code        string   `json:"code"`
+	Variant     string   `json:"variant"`
+	Url         string   `json:"url"`
+	Provider    string   `json:"provider"`
+	VideoId     string   `json:"video_id"`
+	EmbedUrl    string   `json:"embed_url"`
+	Question    string   `json:"question"`
+	Options     []string `json:"options"`
+	Answer      *int     `json:"answer"`
 }
 
 type LessonBucketDTO struct {
@@ -194,3 +217,80 @@ type UserStats struct {
 	CompletedQuestions    int `json:"completed_questions"`
 	AmountQuestions       int `json:"amount_questions"`
 }
+
+type RatingPositionDTO struct {
+	CourseId    int    `json:"course_id"`
+	CourseTitle string `json:"course_title"`
+	Position    int    `json:"position"`
+	Score       int    `json:"score"`
+}
+
+type AuthorStatsDTO struct {
+	CoursesAmount  int     `json:"courses_amount"`
+	StudentsAmount int     `json:"students_amount"`
+	Rating         float32 `json:"rating"`
+}
+
+type CompletedCourseDTO struct {
+	Course         *CourseDTO `json:"course"`
+	SertificateUrl string     `json:"sertificate_url"`
+}
+
+type UserCoursesSummaryDTO struct {
+	AuthoredCourses  []*CourseDTO          `json:"authored_courses"`
+	CompletedCourses []*CompletedCourseDTO `json:"completed_courses"`
+	RatingPositions  []*RatingPositionDTO  `json:"rating_positions"`
+	AuthorStats      *AuthorStatsDTO       `json:"author_stats"`
+}
+
+type LessonProgressDTO struct {
+	CourseId int `json:"course_id"`
+	LessonId int `json:"lesson_id"`
+}
+
+type QuizAnswerExportDTO struct {
+	QuestionId int  `json:"question_id"`
+	AnswerId   int  `json:"answer_id"`
+	IsRight    bool `json:"is_right"`
+}
+
+type QuestionAnswerExportDTO struct {
+	QuestionId int    `json:"question_id"`
+	Answer     string `json:"answer"`
+}
+
+type SertificateExportDTO struct {
+	CourseId       int    `json:"course_id"`
+	SertificateUrl string `json:"sertificate_url"`
+}
+
+type UserCourseDataDTO struct {
+	Progress         []*LessonProgressDTO       `json:"progress"`
+	QuizAnswers      []*QuizAnswerExportDTO     `json:"quiz_answers"`
+	QuestionAnswers  []*QuestionAnswerExportDTO `json:"question_answers"`
+	CompletedCourses []int                      `json:"completed_courses"`
+	Sertificates     []*SertificateExportDTO    `json:"sertificates"`
+}
+
+type UserDataFileDTO struct {
+	Name    string
+	Content []byte
+}
+
+// CourseBundleDTO - содержимое курса для офлайн выгрузки
+type CourseBundleDTO struct {
+	CourseId    int                `json:"course_id"`
+	Title       string             `json:"title"`
+	Description string             `json:"description"`
+	Version     string             `json:"version"`
+	Lessons     []*BundleLessonDTO `json:"lessons"`
+}
+
+type BundleLessonDTO struct {
+	LessonId    int              `json:"lesson_id"`
+	PartTitle   string           `json:"part_title"`
+	BucketTitle string           `json:"bucket_title"`
+	Title       string           `json:"title"`
+	Type        string           `json:"type"`
+	Blocks      []LessonBlockDTO `json:"blocks"`
+}
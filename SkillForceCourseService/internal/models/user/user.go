@@ -7,6 +7,7 @@ type User struct {
 	Password  string
 	Salt      []byte
 	HideEmail bool
+	Locale    string
 }
 
 type UserProfile struct {
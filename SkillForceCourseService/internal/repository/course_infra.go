@@ -12,12 +12,18 @@ import (
 	"skillForce/internal/repository/kafka"
 	"skillForce/internal/repository/minio"
 	"skillForce/internal/repository/postgres"
+	"skillForce/pkg/outbox"
+	"skillForce/pkg/scheduler"
+	"skillForce/pkg/storage"
+	"time"
 )
 
 type CourseInfrastructure struct {
 	Database      *postgres.Database
 	Minio         *minio.Minio
 	KafkaProducer *kafka.Producer
+	OutboxRelay   *outbox.Relay
+	Scheduler     *scheduler.Scheduler
 }
 
 func NewCourseInfrastructure(conf *config.Config) *CourseInfrastructure {
@@ -27,21 +33,42 @@ func NewCourseInfrastructure(conf *config.Config) *CourseInfrastructure {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 
-	mn, err := minio.NewMinio(conf.Minio.Endpoint, conf.Minio.AccessKey, conf.Minio.SecretAccessKey, conf.Minio.UseSSL, conf.Minio.BucketName)
+	store, err := storage.New(storage.Config{
+		Backend:         conf.Storage.Backend,
+		Endpoint:        conf.Minio.Endpoint,
+		AccessKey:       conf.Minio.AccessKey,
+		SecretAccessKey: conf.Minio.SecretAccessKey,
+		UseSSL:          conf.Minio.UseSSL,
+		LocalDir:        conf.Storage.LocalDir,
+		PublicURL:       conf.Storage.PublicURL,
+	})
 	if err != nil {
-		log.Fatalf("Failed to connect to MinIO: %v", err)
+		log.Fatalf("Failed to connect to object storage: %v", err)
 	}
-
-	kafkaProducer := kafka.NewKafkaProducer()
+	mn := minio.NewMinio(store, conf.Storage.PublicURL, conf.Minio.BucketName)
+
+	kafkaProducer := kafka.NewKafkaProducer(conf.Kafka.Brokers)
+	relay := database.NewOutboxRelay(kafkaProducer, outbox.Config{
+		Interval:  conf.Outbox.Interval,
+		BatchSize: conf.Outbox.BatchSize,
+		Retention: conf.Outbox.Retention,
+	})
+	jobs := database.NewScheduler(scheduler.Config{
+		Interval:  conf.Scheduler.Interval,
+		BatchSize: conf.Scheduler.BatchSize,
+	})
 	return &CourseInfrastructure{
 		Database:      database,
 		Minio:         mn,
 		KafkaProducer: kafkaProducer,
+		OutboxRelay:   relay,
+		Scheduler:     jobs,
 	}
 }
 
 func (i *CourseInfrastructure) Close() {
 	i.Database.Close()
+	i.KafkaProducer.Close()
 }
 
 func (i *CourseInfrastructure) GetBucketCourses(ctx context.Context) ([]*coursemodels.Course, error) {
@@ -56,6 +83,22 @@ func (i *CourseInfrastructure) GetCompletedBucketCourses(ctx context.Context, us
 	return i.Database.GetCompletedBucketCourses(ctx, userId)
 }
 
+func (i *CourseInfrastructure) GetAuthoredCourses(ctx context.Context, userId int) ([]*coursemodels.Course, error) {
+	return i.Database.GetAuthoredCourses(ctx, userId)
+}
+
+func (i *CourseInfrastructure) GetUserSertificates(ctx context.Context, userId int) (map[int]string, error) {
+	return i.Database.GetUserSertificates(ctx, userId)
+}
+
+func (i *CourseInfrastructure) GetUserRatingPositions(ctx context.Context, userId int) ([]*dto.RatingPositionDTO, error) {
+	return i.Database.GetUserRatingPositions(ctx, userId)
+}
+
+func (i *CourseInfrastructure) GetAuthorStats(ctx context.Context, userId int) (*dto.AuthorStatsDTO, error) {
+	return i.Database.GetAuthorStats(ctx, userId)
+}
+
 func (i *CourseInfrastructure) GetCoursesRaitings(ctx context.Context, bucketCoursesWithoutRating []*coursemodels.Course) (map[int]float32, error) {
 	return i.Database.GetCoursesRaitings(ctx, bucketCoursesWithoutRating)
 }
@@ -76,10 +119,14 @@ func (i *CourseInfrastructure) GetLastLessonHeader(ctx context.Context, userId i
 	return i.Database.GetLastLessonHeader(ctx, userId, courseId)
 }
 
-func (i *CourseInfrastructure) GetLessonBlocks(ctx context.Context, currentLessonId int) ([]string, error) {
+func (i *CourseInfrastructure) GetLessonBlocks(ctx context.Context, currentLessonId int) ([]*coursemodels.LessonBlock, error) {
 	return i.Database.GetLessonBlocks(ctx, currentLessonId)
 }
 
+func (i *CourseInfrastructure) GetLessonFile(ctx context.Context, objectName string) (*coursemodels.LessonFile, error) {
+	return i.Database.GetLessonFile(ctx, objectName)
+}
+
 func (i *CourseInfrastructure) GetLessonFooters(ctx context.Context, currentLessonId int) ([]int, error) {
 	return i.Database.GetLessonFooters(ctx, currentLessonId)
 }
@@ -132,6 +179,10 @@ func (i *CourseInfrastructure) IsUserPurchasedCourse(ctx context.Context, userId
 	return i.Database.IsUserPurchasedCourse(ctx, userId, courseId)
 }
 
+func (i *CourseInfrastructure) GetLessonCourse(ctx context.Context, lessonId int) (int, int, error) {
+	return i.Database.GetLessonCourse(ctx, lessonId)
+}
+
 func (i *CourseInfrastructure) IsUserCompletedCourse(ctx context.Context, userId int, courseId int) (bool, error) {
 	return i.Database.IsUserCompletedCourse(ctx, userId, courseId)
 }
@@ -144,10 +195,6 @@ func (i *CourseInfrastructure) GetLessonById(ctx context.Context, lessonId int)
 	return i.Database.GetLessonById(ctx, lessonId)
 }
 
-func (i *CourseInfrastructure) IsMiddle(ctx context.Context, userId int, courseId int) (bool, error) {
-	return i.Database.IsMiddle(ctx, userId, courseId)
-}
-
 func (i *CourseInfrastructure) CreateCourse(ctx context.Context, course *coursemodels.Course, userProfile *usermodels.UserProfile) (int, error) {
 	return i.Database.CreateCourse(ctx, course, userProfile)
 }
@@ -180,6 +227,10 @@ func (i *CourseInfrastructure) DeleteCourseFromFavourites(ctx context.Context, c
 	return i.Database.DeleteCourseFromFavourites(ctx, courseId, userId)
 }
 
+func (i *CourseInfrastructure) SetCourseHidden(ctx context.Context, courseId int, hidden bool, reason string) error {
+	return i.Database.SetCourseHidden(ctx, courseId, hidden, reason)
+}
+
 func (i *CourseInfrastructure) GetFavouriteCourses(ctx context.Context, userId int) ([]*coursemodels.Course, error) {
 	return i.Database.GetFavouriteCourses(ctx, userId)
 }
@@ -228,10 +279,54 @@ func (i *CourseInfrastructure) GetStatistic(ctx context.Context, userId int, cou
 	return i.Database.GetStatistic(ctx, userId, courseId)
 }
 
-func (i *CourseInfrastructure) SendWelcomeCourseMail(ctx context.Context, user *usermodels.User, course *coursemodels.Course) error {
-	return i.KafkaProducer.SendWelcomeCourseMail(ctx, user, course)
+func (i *CourseInfrastructure) GetUserCourseData(ctx context.Context, userId int) (*dto.UserCourseDataDTO, error) {
+	return i.Database.GetUserCourseData(ctx, userId)
+}
+
+func (i *CourseInfrastructure) GetAccountsToPurge(ctx context.Context) ([]int, error) {
+	return i.Database.GetAccountsToPurge(ctx)
+}
+
+func (i *CourseInfrastructure) PurgeUserCourseData(ctx context.Context, userId int) error {
+	return i.Database.PurgeUserCourseData(ctx, userId)
+}
+
+func (i *CourseInfrastructure) GetCampaignMails(ctx context.Context, campaign string, conf coursemodels.CampaignConfig) ([]*coursemodels.CampaignMail, error) {
+	return i.Database.GetCampaignMails(ctx, campaign, conf)
+}
+
+func (i *CourseInfrastructure) SendCampaignMail(ctx context.Context, mail *coursemodels.CampaignMail, conf coursemodels.CampaignConfig) (bool, error) {
+	return i.Database.SendCampaignMail(ctx, mail, conf)
+}
+
+func (i *CourseInfrastructure) ScheduleJob(ctx context.Context, kind string, key string, runAt time.Time) error {
+	return i.Database.ScheduleJob(ctx, kind, key, runAt)
+}
+
+func (i *CourseInfrastructure) GetDigestUser(ctx context.Context, userId int) (*coursemodels.DigestUser, error) {
+	return i.Database.GetDigestUser(ctx, userId)
+}
+
+func (i *CourseInfrastructure) GetUsersWithoutDigest(ctx context.Context, limit int) ([]*coursemodels.DigestUser, error) {
+	return i.Database.GetUsersWithoutDigest(ctx, limit)
+}
+
+func (i *CourseInfrastructure) GetWeekLessons(ctx context.Context, userId int, from time.Time, to time.Time) ([]*coursemodels.DigestCourse, error) {
+	return i.Database.GetWeekLessons(ctx, userId, from, to)
+}
+
+func (i *CourseInfrastructure) GetUnfinishedCourses(ctx context.Context, userId int, limit int) ([]*coursemodels.DigestCourse, error) {
+	return i.Database.GetUnfinishedCourses(ctx, userId, limit)
+}
+
+func (i *CourseInfrastructure) GetNewCoursesByFavouriteTags(ctx context.Context, userId int, since time.Time, limit int) ([]*coursemodels.DigestCourse, error) {
+	return i.Database.GetNewCoursesByFavouriteTags(ctx, userId, since, limit)
+}
+
+func (i *CourseInfrastructure) GetLastDigestRating(ctx context.Context, userId int) (map[int]int, error) {
+	return i.Database.GetLastDigestRating(ctx, userId)
 }
 
-func (i *CourseInfrastructure) IsWelcomeCourseMailSended(ctx context.Context, userId int, courseId int) (bool, error) {
-	return i.Database.IsWelcomeCourseMailSended(ctx, userId, courseId)
+func (i *CourseInfrastructure) SendWeeklyDigest(ctx context.Context, digest *coursemodels.WeeklyDigest) (bool, error) {
+	return i.Database.SendWeeklyDigest(ctx, digest)
 }
@@ -2,77 +2,61 @@ package kafka
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
 	"log"
 
-	coursemodels "skillForce/internal/models/course"
-	usermodels "skillForce/internal/models/user"
+	"skillForce/pkg/outbox"
 
 	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 )
 
+// Producer - отправка событий outbox. Письма и другие события сервис пишет в outbox_events,
+// напрямую в Kafka отправляет только outbox.Relay
 type Producer struct {
 	producer *kafka.Producer
-	topik    string
 }
 
-type KafkaMessage struct {
-	Method     string
-	Token      string
-	UserEmail  string
-	UserName   string
-	CourseName string
-	CourseId   int
-	Url        string
-}
-
-func NewKafkaProducer() *Producer {
+func NewKafkaProducer(brokers string) *Producer {
 	producer, err := kafka.NewProducer(&kafka.ConfigMap{
-		"bootstrap.servers": "kafka:9092",
+		"bootstrap.servers":  brokers,
+		"acks":               "all",
+		"enable.idempotence": true,
 	})
 	if err != nil {
 		log.Fatalf("Failed to create producer: %s", err)
 	}
 
-	return &Producer{producer: producer, topik: "mail"}
+	return &Producer{producer: producer}
 }
 
 func (p *Producer) Close() {
+	p.producer.Flush(10000)
 	p.producer.Close()
 }
 
-func (p *Producer) SendWelcomeCourseMail(ctx context.Context, user *usermodels.User, course *coursemodels.Course) error {
-	msg := KafkaMessage{
-		Method:     "send_welcome_course_mail",
-		UserEmail:  user.Email,
-		UserName:   user.Name,
-		CourseId:   course.Id,
-		CourseName: course.Title,
-	}
-
-	value, err := json.Marshal(msg)
-	if err != nil {
-		fmt.Println("SendWelcomeCourseMail", err.Error())
+// Publish - отправка события с ожиданием подтверждения доставки
+func (p *Producer) Publish(ctx context.Context, event outbox.Event) error {
+	var key []byte
+	if event.Key != "" {
+		key = []byte(event.Key)
 	}
 
-	err = p.producer.Produce(&kafka.Message{
-		TopicPartition: kafka.TopicPartition{Topic: &p.topik, Partition: kafka.PartitionAny},
-		Value:          value,
-	}, nil)
-
+	deliveryChan := make(chan kafka.Event, 1)
+	err := p.producer.Produce(&kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &event.Topic, Partition: kafka.PartitionAny},
+		Key:            key,
+		Value:          event.Payload,
+		Headers:        []kafka.Header{{Key: outbox.HeaderEventId, Value: []byte(event.EventId)}},
+	}, deliveryChan)
 	if err != nil {
-		fmt.Println("SendWelcomeCourseMail", err.Error())
+		return err
 	}
 
-	// ожидаем подтверждение доставки
-	e := <-p.producer.Events()
-	switch ev := e.(type) {
-	case *kafka.Message:
-		if ev.TopicPartition.Error != nil {
-			fmt.Printf("Delivery failed: %v\n", ev.TopicPartition.Error)
-		} else {
-			fmt.Printf("Message delivered to %v\n", ev.TopicPartition)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case e := <-deliveryChan:
+		if m, ok := e.(*kafka.Message); ok && m.TopicPartition.Error != nil {
+			return m.TopicPartition.Error
 		}
 	}
 	return nil
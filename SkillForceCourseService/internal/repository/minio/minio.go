@@ -4,43 +4,42 @@ import (
 	"context"
 	"fmt"
 	"mime/multipart"
-	"strings"
+	"path"
+
+	"skillForce/pkg/storage"
 
 	"github.com/google/uuid"
-	"github.com/minio/minio-go"
 )
 
 type Minio struct {
-	MinioClient        *minio.Client
+	Storage            storage.Storage
+	PublicURL          string
 	SertificatesBucket string
 }
 
-func NewMinio(endpoint string, accessKeyID string, secretAccessKey string, useSSL bool, bucketName string) (*Minio, error) {
-	minioClient, err := minio.New(endpoint, accessKeyID, secretAccessKey, useSSL)
-	return &Minio{MinioClient: minioClient, SertificatesBucket: bucketName}, err
+func NewMinio(store storage.Storage, publicURL string, bucketName string) *Minio {
+	return &Minio{Storage: store, PublicURL: publicURL, SertificatesBucket: bucketName}
 }
 
 func (mn *Minio) UploadFileToMinIO(ctx context.Context, file multipart.File, fileHeader *multipart.FileHeader) (string, error) {
 
 	uniqueID := uuid.New().String()
-	ext := ""
-	if fileHeader.Filename != "" {
-		ext = fileHeader.Filename[strings.LastIndex(fileHeader.Filename, "."):]
-	}
+	// у имени без точки расширения нет, а не паника на срезе с индексом -1
+	ext := path.Ext(fileHeader.Filename)
 	objectName := fmt.Sprintf("%s%s", uniqueID, ext)
 	contentType := fileHeader.Header.Get("Content-Type")
 
-	_, err := mn.MinioClient.PutObject(
+	err := mn.Storage.Put(
+		ctx,
 		mn.SertificatesBucket,
 		objectName,
 		file,
 		fileHeader.Size,
-		minio.PutObjectOptions{ContentType: contentType},
+		storage.PutOptions{ContentType: contentType},
 	)
 	if err != nil {
 		return "", err
 	}
 
-	fileURL := fmt.Sprintf("http://skill-force.ru/%s/%s", mn.SertificatesBucket, objectName)
-	return fileURL, nil
+	return storage.PublicURL(mn.PublicURL, mn.SertificatesBucket, objectName), nil
 }
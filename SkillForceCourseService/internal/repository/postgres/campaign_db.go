@@ -0,0 +1,204 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	coursemodels "skillForce/internal/models/course"
+	"skillForce/pkg/events"
+	"skillForce/pkg/logs"
+	"skillForce/pkg/outbox"
+)
+
+// campaignLock - пространство advisory блокировок писем кампаний, второй ключ - id пользователя
+const campaignLock = 45
+
+// courseProgress - пройдено уроков done из total курса s.course_id пользователем s.user_id
+const courseProgress = `
+	CROSS JOIN LATERAL (SELECT
+		(SELECT COUNT(*) FROM lesson_checkpoint lc WHERE lc.user_id = s.user_id AND lc.course_id = s.course_id) AS done,
+		(SELECT COUNT(l.id) FROM lesson l
+			JOIN lesson_bucket lb ON l.lesson_bucket_id = lb.id
+			JOIN part p ON lb.part_id = p.id
+			WHERE p.course_id = s.course_id) AS total
+	) lessons`
+
+// campaignSources - пользователи и курсы, которым подходит письмо кампании: user_id, course_id, ref, progress.
+// $6 - срок кампании в секундах, если он у неё есть
+var campaignSources = map[string]string{
+	// записался на курс
+	coursemodels.CampaignWelcome: `
+		SELECT user_id, course_id, '' AS ref, 0 AS progress FROM signups`,
+	// прошёл половину уроков
+	coursemodels.CampaignMidCourse: `
+		SELECT s.user_id, s.course_id, '' AS ref, lessons.done * 100 / lessons.total AS progress
+		FROM signups s` + courseProgress + `
+		WHERE lessons.total > 0 AND lessons.done * 2 >= lessons.total AND lessons.done < lessons.total`,
+	// начал курс и давно не проходил уроков. Новый перерыв - новое письмо
+	coursemodels.CampaignInactivity: `
+		SELECT s.user_id, s.course_id, last.at::text AS ref, lessons.done * 100 / GREATEST(lessons.total, 1) AS progress
+		FROM signups s
+		JOIN LATERAL (SELECT MAX(lc.created_at) AS at FROM lesson_checkpoint lc
+			WHERE lc.user_id = s.user_id AND lc.course_id = s.course_id) last ON last.at IS NOT NULL` + courseProgress + `
+		WHERE last.at < NOW() - make_interval(secs => $6) AND lessons.done < lessons.total`,
+	// закончил курс, в письме ссылка на сертификат
+	coursemodels.CampaignCourseCompleted: `
+		SELECT user_id, course_id, '' AS ref, 100 AS progress FROM completed_courses`,
+	// начал оплату и не закончил. Напоминание только о последней покупке курса
+	coursemodels.CampaignAbandonedCheckout: `
+		SELECT p.user_id, p.course_id, p.id::text AS ref, 0 AS progress
+		FROM purchaces p
+		WHERE p.status = 'pending' AND p.created_at < NOW() - make_interval(secs => $6)
+			AND NOT EXISTS (SELECT 1 FROM signups s WHERE s.user_id = p.user_id AND s.course_id = p.course_id)
+			AND NOT EXISTS (SELECT 1 FROM completed_courses cc WHERE cc.user_id = p.user_id AND cc.course_id = p.course_id)
+			AND NOT EXISTS (SELECT 1 FROM purchaces newer
+				WHERE newer.user_id = p.user_id AND newer.course_id = p.course_id AND newer.id > p.id)`,
+}
+
+// campaignCandidates - письма кампании $1, которые ещё не отправлены, пользователям, не отписанным
+// от категории $2, не удаляющим аккаунт и не упирающимся в ограничение частоты:
+// меньше $3 писем за 7 дней и ни одного за последние $4 секунд
+const campaignCandidates = `
+	SELECT x.user_id, x.course_id, c.title, x.ref, x.progress
+	FROM (%s) x
+	JOIN course c ON c.id = x.course_id
+	WHERE NOT EXISTS (SELECT 1 FROM campaign_mails cm
+			WHERE cm.user_id = x.user_id AND cm.course_id = x.course_id AND cm.campaign = $1 AND cm.ref = x.ref)
+		AND NOT EXISTS (SELECT 1 FROM notification_preferences np
+			WHERE np.user_id = x.user_id AND np.category = $2 AND NOT np.enabled)
+		AND NOT EXISTS (SELECT 1 FROM account_deletions ad WHERE ad.user_id = x.user_id)
+		AND (SELECT COUNT(*) FROM campaign_mails cm
+			WHERE cm.user_id = x.user_id AND cm.sent_at > NOW() - INTERVAL '7 days') < $3
+		AND NOT EXISTS (SELECT 1 FROM campaign_mails cm
+			WHERE cm.user_id = x.user_id AND cm.sent_at > NOW() - make_interval(secs => $4))
+	ORDER BY x.user_id, x.course_id
+	LIMIT $5`
+
+// GetCampaignMails - до conf.BatchSize писем кампании campaign, которые пора отправить
+func (d *Database) GetCampaignMails(ctx context.Context, campaign string, conf coursemodels.CampaignConfig) ([]*coursemodels.CampaignMail, error) {
+	source, ok := campaignSources[campaign]
+	if !ok {
+		logs.PrintLog(ctx, "GetCampaignMails", fmt.Sprintf("unknown campaign %q", campaign))
+		return nil, errors.New("unknown campaign")
+	}
+
+	args := []any{campaign, coursemodels.CampaignCategory(campaign), conf.MaxPerWeek, conf.MinInterval.Seconds(), conf.BatchSize}
+	switch campaign {
+	case coursemodels.CampaignInactivity:
+		args = append(args, conf.InactivityAfter.Seconds())
+	case coursemodels.CampaignAbandonedCheckout:
+		args = append(args, conf.AbandonedCheckoutAfter.Seconds())
+	}
+
+	rows, err := d.conn.QueryContext(ctx, fmt.Sprintf(campaignCandidates, source), args...)
+	if err != nil {
+		logs.PrintLog(ctx, "GetCampaignMails", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logs.PrintLog(ctx, "GetCampaignMails", fmt.Sprintf("%+v", err))
+		}
+	}()
+
+	var mails []*coursemodels.CampaignMail
+	for rows.Next() {
+		mail := &coursemodels.CampaignMail{Campaign: campaign}
+		if err := rows.Scan(&mail.UserId, &mail.CourseId, &mail.CourseName, &mail.Ref, &mail.Progress); err != nil {
+			logs.PrintLog(ctx, "GetCampaignMails", fmt.Sprintf("%+v", err))
+			return nil, err
+		}
+		mails = append(mails, mail)
+	}
+	return mails, rows.Err()
+}
+
+// SendCampaignMail - запись письма в campaign_mails и outbox одной транзакцией. Ограничение частоты
+// проверяется повторно под блокировкой пользователя: между выборкой и отправкой могли уйти другие письма.
+// false - письмо не отправлено: уже было или пользователь упёрся в ограничение
+func (d *Database) SendCampaignMail(ctx context.Context, mail *coursemodels.CampaignMail, conf coursemodels.CampaignConfig) (bool, error) {
+	tx, err := d.conn.BeginTx(ctx, nil)
+	if err != nil {
+		logs.PrintLog(ctx, "SendCampaignMail", fmt.Sprintf("failed to begin transaction: %+v", err))
+		return false, err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logs.PrintLog(ctx, "transaction rollback failed", fmt.Sprintf("error: %v", err))
+		}
+	}()
+
+	if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1, $2)", campaignLock, mail.UserId); err != nil {
+		logs.PrintLog(ctx, "SendCampaignMail", fmt.Sprintf("%+v", err))
+		return false, err
+	}
+
+	var week, recent int
+	err = tx.QueryRowContext(ctx, `
+		SELECT COUNT(*) FILTER (WHERE sent_at > NOW() - INTERVAL '7 days'),
+			COUNT(*) FILTER (WHERE sent_at > NOW() - make_interval(secs => $2))
+		FROM campaign_mails WHERE user_id = $1`, mail.UserId, conf.MinInterval.Seconds()).Scan(&week, &recent)
+	if err != nil {
+		logs.PrintLog(ctx, "SendCampaignMail", fmt.Sprintf("%+v", err))
+		return false, err
+	}
+	if week >= conf.MaxPerWeek || recent > 0 {
+		logs.PrintLog(ctx, "SendCampaignMail", fmt.Sprintf("user %d reached mail limit", mail.UserId))
+		return false, nil
+	}
+
+	recipient := events.Recipient{UserId: int32(mail.UserId)}
+	err = tx.QueryRowContext(ctx, "SELECT email, name, locale FROM usertable WHERE id = $1", mail.UserId).
+		Scan(&recipient.Email, &recipient.Name, &recipient.Locale)
+	if err != nil {
+		logs.PrintLog(ctx, "SendCampaignMail", fmt.Sprintf("%+v", err))
+		return false, err
+	}
+
+	res, err := tx.ExecContext(ctx,
+		"INSERT INTO campaign_mails (user_id, course_id, campaign, ref) VALUES ($1, $2, $3, $4) ON CONFLICT DO NOTHING",
+		mail.UserId, mail.CourseId, mail.Campaign, mail.Ref)
+	if err != nil {
+		logs.PrintLog(ctx, "SendCampaignMail", fmt.Sprintf("%+v", err))
+		return false, err
+	}
+	if inserted, err := res.RowsAffected(); err != nil || inserted == 0 {
+		return false, err
+	}
+
+	var payload events.Payload = &events.EngagementMail{
+		Recipient:       &recipient,
+		Campaign:        mail.Campaign,
+		CourseId:        int32(mail.CourseId),
+		CourseName:      mail.CourseName,
+		ProgressPercent: int32(mail.Progress),
+		Category:        coursemodels.CampaignCategory(mail.Campaign),
+	}
+	// у письма о записи на курс свой шаблон и событие
+	if mail.Campaign == coursemodels.CampaignWelcome {
+		payload = &events.WelcomeCourseMail{Recipient: &recipient, CourseId: int32(mail.CourseId), CourseName: mail.CourseName}
+	}
+	env, err := events.New(ctx, OutboxSource, payload)
+	if errors.Is(err, events.ErrInvalidEvent) {
+		// письмо не отправить, например у пользователя некорректный адрес. Отметка в campaign_mails
+		// остаётся, чтобы кампания не пыталась отправить его снова
+		logs.PrintLog(ctx, "SendCampaignMail", fmt.Sprintf("skip mail for user %d: %+v", mail.UserId, err))
+		return false, tx.Commit()
+	}
+	if err == nil {
+		err = outbox.Enqueue(ctx, tx, coursemodels.MailTopic, recipient.Email, env)
+	}
+	if err != nil {
+		logs.PrintLog(ctx, "SendCampaignMail", fmt.Sprintf("%+v", err))
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logs.PrintLog(ctx, "SendCampaignMail", fmt.Sprintf("failed to commit transaction: %+v", err))
+		return false, err
+	}
+
+	logs.PrintLog(ctx, "SendCampaignMail", fmt.Sprintf("%s mail for course %d enqueued for user %d", mail.Campaign, mail.CourseId, mail.UserId))
+	return true, nil
+}
@@ -0,0 +1,198 @@
+package postgres
+
+import (
+	"database/sql/driver"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	coursemodels "skillForce/internal/models/course"
+	"skillForce/pkg/events"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+var campaignConf = coursemodels.CampaignConfig{
+	BatchSize:              10,
+	InactivityAfter:        7 * 24 * time.Hour,
+	AbandonedCheckoutAfter: 24 * time.Hour,
+	MaxPerWeek:             2,
+	MinInterval:            24 * time.Hour,
+}
+
+func TestGetCampaignMails(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	database := &Database{conn: db}
+
+	mock.ExpectQuery(regexp.QuoteMeta("last.at < NOW() - make_interval(secs => $6)")).
+		WithArgs(coursemodels.CampaignInactivity, events.CategoryCourseProgress, 2, float64(24*60*60), 10, float64(7*24*60*60)).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "course_id", "title", "ref", "progress"}).
+			AddRow(3, 5, "Go", "2025-03-01 10:00:00", 40))
+
+	mails, err := database.GetCampaignMails(profileTestCtx(), coursemodels.CampaignInactivity, campaignConf)
+	require.NoError(t, err)
+	require.Equal(t, []*coursemodels.CampaignMail{{
+		Campaign: coursemodels.CampaignInactivity, UserId: 3, CourseId: 5, CourseName: "Go", Ref: "2025-03-01 10:00:00", Progress: 40,
+	}}, mails)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetCampaignMails_NoDuration(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	database := &Database{conn: db}
+
+	// у кампании без срока нет параметра $6
+	mock.ExpectQuery(regexp.QuoteMeta("FROM completed_courses")).
+		WithArgs(coursemodels.CampaignCourseCompleted, events.CategoryCourseProgress, 2, float64(24*60*60), 10).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "course_id", "title", "ref", "progress"}))
+
+	mails, err := database.GetCampaignMails(profileTestCtx(), coursemodels.CampaignCourseCompleted, campaignConf)
+	require.NoError(t, err)
+	require.Empty(t, mails)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetCampaignMails_Marketing(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	database := &Database{conn: db}
+
+	// брошенная оплата - маркетинговое письмо, от него отписываются отдельно от писем о прогрессе
+	mock.ExpectQuery(regexp.QuoteMeta("FROM purchaces p")).
+		WithArgs(coursemodels.CampaignAbandonedCheckout, events.CategoryMarketing, 2, float64(24*60*60), 10, float64(24*60*60)).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "course_id", "title", "ref", "progress"}))
+
+	_, err = database.GetCampaignMails(profileTestCtx(), coursemodels.CampaignAbandonedCheckout, campaignConf)
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetCampaignMails_Unknown(t *testing.T) {
+	db, _, err := sqlmock.New()
+	require.NoError(t, err)
+	database := &Database{conn: db}
+
+	_, err = database.GetCampaignMails(profileTestCtx(), "spam", campaignConf)
+	require.Error(t, err)
+}
+
+func TestCampaignSources(t *testing.T) {
+	for _, campaign := range coursemodels.Campaigns {
+		require.Contains(t, campaignSources, campaign)
+	}
+	require.Len(t, campaignSources, len(coursemodels.Campaigns))
+}
+
+func expectCampaignLimits(mock sqlmock.Sqlmock, week int, recent int) {
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_xact_lock($1, $2)")).
+		WithArgs(campaignLock, 3).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM campaign_mails WHERE user_id = $1")).
+		WithArgs(3, float64(24*60*60)).
+		WillReturnRows(sqlmock.NewRows([]string{"week", "recent"}).AddRow(week, recent))
+}
+
+func TestSendCampaignMail(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	database := &Database{conn: db}
+
+	mail := &coursemodels.CampaignMail{Campaign: coursemodels.CampaignMidCourse, UserId: 3, CourseId: 5, CourseName: "Go", Progress: 50}
+	expectCampaignLimits(mock, 1, 0)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT email, name, locale FROM usertable WHERE id = $1")).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"email", "name", "locale"}).AddRow("alice@example.com", "Alice", "en"))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO campaign_mails (user_id, course_id, campaign, ref)")).
+		WithArgs(3, 5, coursemodels.CampaignMidCourse, "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	var payload []byte
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO outbox_events")).
+		WithArgs(sqlmock.AnyArg(), OutboxSource, coursemodels.MailTopic, "alice@example.com", payloadArg{&payload}).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	sent, err := database.SendCampaignMail(profileTestCtx(), mail, campaignConf)
+	require.NoError(t, err)
+	require.True(t, sent)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	env, err := events.Unmarshal(payload)
+	require.NoError(t, err)
+	require.Equal(t, events.TypeEngagementMail, env.GetType())
+	require.Equal(t, "mid_course", env.GetEngagementMail().GetCampaign())
+	require.Equal(t, int32(50), env.GetEngagementMail().GetProgressPercent())
+	require.Equal(t, "en", env.GetEngagementMail().GetRecipient().GetLocale())
+	require.Equal(t, int32(3), env.GetEngagementMail().GetRecipient().GetUserId())
+	require.Equal(t, events.CategoryCourseProgress, events.Category(env))
+}
+
+func TestSendCampaignMail_Limit(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	database := &Database{conn: db}
+
+	for _, limits := range [][2]int{{2, 0}, {1, 1}} {
+		expectCampaignLimits(mock, limits[0], limits[1])
+		mock.ExpectRollback()
+
+		sent, err := database.SendCampaignMail(profileTestCtx(), &coursemodels.CampaignMail{Campaign: coursemodels.CampaignWelcome, UserId: 3, CourseId: 5}, campaignConf)
+		require.NoError(t, err)
+		require.False(t, sent)
+	}
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSendCampaignMail_AlreadySent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	database := &Database{conn: db}
+
+	expectCampaignLimits(mock, 0, 0)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT email, name, locale FROM usertable WHERE id = $1")).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"email", "name", "locale"}).AddRow("alice@example.com", "Alice", "ru"))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO campaign_mails")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	sent, err := database.SendCampaignMail(profileTestCtx(), &coursemodels.CampaignMail{Campaign: coursemodels.CampaignWelcome, UserId: 3, CourseId: 5, CourseName: "Go"}, campaignConf)
+	require.NoError(t, err)
+	require.False(t, sent)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSendCampaignMail_InvalidRecipient(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	database := &Database{conn: db}
+
+	// письмо не отправляется, но отметка сохраняется, чтобы не пытаться снова
+	expectCampaignLimits(mock, 0, 0)
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT email, name, locale FROM usertable WHERE id = $1")).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"email", "name", "locale"}).AddRow("not an email", "Alice", "ru"))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO campaign_mails")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	sent, err := database.SendCampaignMail(profileTestCtx(), &coursemodels.CampaignMail{Campaign: coursemodels.CampaignWelcome, UserId: 3, CourseId: 5, CourseName: "Go"}, campaignConf)
+	require.NoError(t, err)
+	require.False(t, sent)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+// payloadArg - сохраняет записанное в outbox событие для проверки
+type payloadArg struct {
+	payload *[]byte
+}
+
+func (a payloadArg) Match(v driver.Value) bool {
+	data, ok := v.([]byte)
+	*a.payload = data
+	return ok && strings.HasPrefix(string(data), "{")
+}
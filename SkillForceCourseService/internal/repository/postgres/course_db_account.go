@@ -0,0 +1,179 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"skillForce/internal/models/dto"
+	"skillForce/pkg/logs"
+)
+
+// GetUserCourseData - данные пользователя о прохождении курсов для выгрузки
+func (d *Database) GetUserCourseData(ctx context.Context, userId int) (*dto.UserCourseDataDTO, error) {
+	data := &dto.UserCourseDataDTO{
+		Progress:         make([]*dto.LessonProgressDTO, 0),
+		QuizAnswers:      make([]*dto.QuizAnswerExportDTO, 0),
+		QuestionAnswers:  make([]*dto.QuestionAnswerExportDTO, 0),
+		CompletedCourses: make([]int, 0),
+		Sertificates:     make([]*dto.SertificateExportDTO, 0),
+	}
+
+	err := d.queryUserRows(ctx, "SELECT course_id, lesson_id FROM LESSON_CHECKPOINT WHERE user_id = $1 ORDER BY course_id, lesson_id", userId,
+		func(rows *sql.Rows) error {
+			var progress dto.LessonProgressDTO
+			if err := rows.Scan(&progress.CourseId, &progress.LessonId); err != nil {
+				return err
+			}
+			data.Progress = append(data.Progress, &progress)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.queryUserRows(ctx, "SELECT question_lesson_id, answer_id, is_right FROM USER_ANSWERS WHERE user_id = $1 ORDER BY question_lesson_id", userId,
+		func(rows *sql.Rows) error {
+			var answer dto.QuizAnswerExportDTO
+			if err := rows.Scan(&answer.QuestionId, &answer.AnswerId, &answer.IsRight); err != nil {
+				return err
+			}
+			data.QuizAnswers = append(data.QuizAnswers, &answer)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.queryUserRows(ctx, "SELECT question_test_id, answer FROM question_task_answers WHERE user_id = $1 ORDER BY question_test_id", userId,
+		func(rows *sql.Rows) error {
+			var answer dto.QuestionAnswerExportDTO
+			if err := rows.Scan(&answer.QuestionId, &answer.Answer); err != nil {
+				return err
+			}
+			data.QuestionAnswers = append(data.QuestionAnswers, &answer)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.queryUserRows(ctx, "SELECT course_id FROM COMPLETED_COURSES WHERE user_id = $1 ORDER BY course_id", userId,
+		func(rows *sql.Rows) error {
+			var courseId int
+			if err := rows.Scan(&courseId); err != nil {
+				return err
+			}
+			data.CompletedCourses = append(data.CompletedCourses, courseId)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	err = d.queryUserRows(ctx, "SELECT course_id, sertificate_src FROM SERTIFICATES WHERE user_id = $1 ORDER BY course_id", userId,
+		func(rows *sql.Rows) error {
+			var sertificate dto.SertificateExportDTO
+			if err := rows.Scan(&sertificate.CourseId, &sertificate.SertificateUrl); err != nil {
+				return err
+			}
+			data.Sertificates = append(data.Sertificates, &sertificate)
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	logs.PrintLog(ctx, "GetUserCourseData", fmt.Sprintf("get course data of user %d from db", userId))
+	return data, nil
+}
+
+func (d *Database) queryUserRows(ctx context.Context, query string, userId int, scan func(rows *sql.Rows) error) error {
+	rows, err := d.conn.QueryContext(ctx, query, userId)
+	if err != nil {
+		logs.PrintLog(ctx, "GetUserCourseData", fmt.Sprintf("%+v", err))
+		return err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logs.PrintLog(ctx, "GetUserCourseData", fmt.Sprintf("%+v", err))
+		}
+	}()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			logs.PrintLog(ctx, "GetUserCourseData", fmt.Sprintf("%+v", err))
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// GetAccountsToPurge - аккаунты, у которых истёк срок на отмену удаления, а курсовые данные ещё не удалены
+func (d *Database) GetAccountsToPurge(ctx context.Context) ([]int, error) {
+	var userIds []int
+	rows, err := d.conn.QueryContext(ctx, "SELECT user_id FROM account_deletions WHERE delete_after <= NOW() AND course_purged_at IS NULL ORDER BY delete_after")
+	if err != nil {
+		logs.PrintLog(ctx, "GetAccountsToPurge", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logs.PrintLog(ctx, "GetAccountsToPurge", fmt.Sprintf("%+v", err))
+		}
+	}()
+
+	for rows.Next() {
+		var userId int
+		if err := rows.Scan(&userId); err != nil {
+			logs.PrintLog(ctx, "GetAccountsToPurge", fmt.Sprintf("%+v", err))
+			return nil, err
+		}
+		userIds = append(userIds, userId)
+	}
+	return userIds, rows.Err()
+}
+
+// PurgeUserCourseData - удаление прогресса, ответов и сертификатов пользователя.
+// Созданные пользователем курсы остаются: их уже купили другие пользователи
+func (d *Database) PurgeUserCourseData(ctx context.Context, userId int) error {
+	tx, err := d.conn.BeginTx(ctx, nil)
+	if err != nil {
+		logs.PrintLog(ctx, "PurgeUserCourseData", fmt.Sprintf("failed to begin transaction: %+v", err))
+		return err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logs.PrintLog(ctx, "transaction rollback failed", fmt.Sprintf("error: %v", err))
+		}
+	}()
+
+	queries := []string{
+		"DELETE FROM LESSON_CHECKPOINT WHERE user_id = $1",
+		"DELETE FROM USER_ANSWERS WHERE user_id = $1",
+		"DELETE FROM question_task_answers WHERE user_id = $1",
+		"DELETE FROM COMPLETED_COURSES WHERE user_id = $1",
+		"DELETE FROM SERTIFICATES WHERE user_id = $1",
+		"DELETE FROM survey_answer WHERE user_id = $1",
+		"DELETE FROM SENDED_MAILS WHERE user_id = $1",
+		"DELETE FROM WELCOME_COURSE_SENDED_MAILS WHERE user_id = $1",
+		"DELETE FROM campaign_mails WHERE user_id = $1",
+		"DELETE FROM digest_mails WHERE user_id = $1",
+		"UPDATE account_deletions SET course_purged_at = NOW() WHERE user_id = $1",
+	}
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query, userId); err != nil {
+			logs.PrintLog(ctx, "PurgeUserCourseData", fmt.Sprintf("%+v", err))
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		logs.PrintLog(ctx, "PurgeUserCourseData", fmt.Sprintf("failed to commit transaction: %+v", err))
+		return err
+	}
+
+	logs.PrintLog(ctx, "PurgeUserCourseData", fmt.Sprintf("purge course data of user %d", userId))
+	return nil
+}
@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurgeUserCourseData(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	database := &Database{conn: db}
+
+	mock.ExpectBegin()
+	for _, table := range []string{"LESSON_CHECKPOINT", "USER_ANSWERS", "question_task_answers", "COMPLETED_COURSES", "SERTIFICATES", "survey_answer", "SENDED_MAILS", "WELCOME_COURSE_SENDED_MAILS", "campaign_mails", "digest_mails"} {
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM " + table + " WHERE user_id = $1")).
+			WithArgs(7).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE account_deletions SET course_purged_at = NOW() WHERE user_id = $1")).
+		WithArgs(7).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, database.PurgeUserCourseData(profileTestCtx(), 7))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurgeUserCourseDataRollback(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	database := &Database{conn: db}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM LESSON_CHECKPOINT WHERE user_id = $1")).
+		WithArgs(7).
+		WillReturnError(errors.New("db error"))
+	mock.ExpectRollback()
+
+	require.Error(t, database.PurgeUserCourseData(profileTestCtx(), 7))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAccountsToPurge(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	database := &Database{conn: db}
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM account_deletions WHERE delete_after <= NOW() AND course_purged_at IS NULL")).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(3).AddRow(5))
+
+	userIds, err := database.GetAccountsToPurge(profileTestCtx())
+	require.NoError(t, err)
+	require.Equal(t, []int{3, 5}, userIds)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
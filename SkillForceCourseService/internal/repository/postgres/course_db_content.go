@@ -3,6 +3,8 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 
 	coursemodels "skillForce/internal/models/course"
@@ -17,7 +19,7 @@ func (d *Database) SearchCoursesByTitle(ctx context.Context, keyword string) ([]
 	query := `
 		SELECT id, creator_user_id, title, description, avatar_src, price, time_to_pass
 		FROM course
-		WHERE title ILIKE '%' || $1 || '%'
+		WHERE title ILIKE '%' || $1 || '%' AND NOT hidden
 		LIMIT 16
 	`
 
@@ -50,7 +52,7 @@ func (d *Database) SearchCoursesByTitle(ctx context.Context, keyword string) ([]
 func (d *Database) GetBucketCourses(ctx context.Context) ([]*coursemodels.Course, error) {
 	//TODO: можно заморочиться и сделать самописную пагинацию через LIMIT OFFSET
 	var bucketCourses []*coursemodels.Course
-	rows, err := d.conn.Query("SELECT id, creator_user_id, title, description, avatar_src, price, time_to_pass FROM course LIMIT 16")
+	rows, err := d.conn.Query("SELECT id, creator_user_id, title, description, avatar_src, price, time_to_pass FROM course WHERE NOT hidden LIMIT 16")
 	if err != nil {
 		logs.PrintLog(ctx, "GetBucketCourses", fmt.Sprintf("%+v", err))
 		return nil, err
@@ -226,6 +228,9 @@ func (d *Database) GetCourseById(ctx context.Context, courseId int) (*coursemode
 	var course coursemodels.Course
 	err := d.conn.QueryRow("SELECT id, creator_user_id, title, description, avatar_src, price, time_to_pass FROM course WHERE id = $1", courseId).Scan(
 		&course.Id, &course.CreatorId, &course.Title, &course.Description, &course.ScrImage, &course.Price, &course.TimeToPass)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("course not found")
+	}
 	if err != nil {
 		logs.PrintLog(ctx, "GetCourseById", fmt.Sprintf("%+v", err))
 		return nil, err
@@ -234,10 +239,10 @@ func (d *Database) GetCourseById(ctx context.Context, courseId int) (*coursemode
 	return &course, nil
 }
 
-func (d *Database) GetLessonBlocks(ctx context.Context, currentLessonId int) ([]string, error) {
-	var blocks []string
+func (d *Database) GetLessonBlocks(ctx context.Context, currentLessonId int) ([]*coursemodels.LessonBlock, error) {
+	var blocks []*coursemodels.LessonBlock
 	rows, err := d.conn.Query(`
-			SELECT tlb.value
+			SELECT tlb.value, tlb.block_type, tlb.payload
 			FROM TEXT_LESSON_BLOCK tlb
 			JOIN TEXT_LESSON tl ON tlb.Text_Lesson_ID = tl.ID
 			WHERE tl.Lesson_ID = $1
@@ -254,16 +259,44 @@ func (d *Database) GetLessonBlocks(ctx context.Context, currentLessonId int) ([]
 	}()
 
 	for rows.Next() {
-		var block string
-		if err := rows.Scan(&block); err != nil {
+		var value, blockType string
+		var payload []byte
+		if err := rows.Scan(&value, &blockType, &payload); err != nil {
 			logs.PrintLog(ctx, "GetLessonBlocks", fmt.Sprintf("%+v", err))
 			return nil, err
 		}
+
+		// у блоков, созданных до появления типов, payload нет
+		block := &coursemodels.LessonBlock{Type: coursemodels.BlockHTML, Text: value}
+		if payload != nil {
+			if err := json.Unmarshal(payload, block); err != nil {
+				logs.PrintLog(ctx, "GetLessonBlocks", fmt.Sprintf("%+v", err))
+				return nil, err
+			}
+			block.Type = blockType
+		}
 		blocks = append(blocks, block)
 	}
 	return blocks, nil
 }
 
+func (d *Database) GetLessonFile(ctx context.Context, objectName string) (*coursemodels.LessonFile, error) {
+	var file coursemodels.LessonFile
+	err := d.conn.QueryRow(`
+			SELECT object_name, author_id, kind, file_name, content_type, size
+			FROM lesson_files
+			WHERE object_name = $1
+		`, objectName).Scan(&file.ObjectName, &file.AuthorId, &file.Kind, &file.FileName, &file.ContentType, &file.Size)
+	if err == sql.ErrNoRows {
+		return nil, errors.New("lesson file not found")
+	}
+	if err != nil {
+		logs.PrintLog(ctx, "GetLessonFile", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+	return &file, nil
+}
+
 func (d *Database) GetLessonVideo(ctx context.Context, currentLessonId int) ([]string, error) {
 	var videoSrc string
 	err := d.conn.QueryRow(`
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	coursemodels "skillForce/internal/models/course"
 	usermodels "skillForce/internal/models/user"
@@ -124,22 +125,59 @@ func (d *Database) CreateTextLesson(ctx context.Context, lesson *coursemodels.Le
 		return err
 	}
 
-	query3 := `
+	if len(lesson.Blocks) == 0 {
+		query3 := `
 		INSERT INTO text_lesson_block (text_lesson_id, value, is_image, text_lesson_block_order)
 		VALUES ($1, $2, $3, $4)	
 	`
 
-	_, err = d.conn.Exec(
-		query3,
-		textLessonID,
-		lesson.Value,
-		lesson.IsImage,
-		1,
-	)
+		_, err = d.conn.Exec(
+			query3,
+			textLessonID,
+			lesson.Value,
+			lesson.IsImage,
+			1,
+		)
 
-	if err != nil {
-		logs.PrintLog(ctx, "CreateTextLesson", fmt.Sprintf("%+v", err))
-		return err
+		if err != nil {
+			logs.PrintLog(ctx, "CreateTextLesson", fmt.Sprintf("%+v", err))
+			return err
+		}
+
+		return nil
+	}
+
+	// value дублирует текст блока для старых клиентов, остальное лежит в payload
+	query4 := `
+		INSERT INTO text_lesson_block (text_lesson_id, value, is_image, text_lesson_block_order, block_type, payload)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	for i, block := range lesson.Blocks {
+		payload, err := json.Marshal(block)
+		if err != nil {
+			logs.PrintLog(ctx, "CreateTextLesson", fmt.Sprintf("%+v", err))
+			return err
+		}
+
+		// в value у markdown лежит готовый HTML, чтобы урок читался и без разбора payload
+		value := block.Text
+		if block.Type == coursemodels.BlockMarkdown {
+			value = block.Html
+		}
+
+		_, err = d.conn.Exec(
+			query4,
+			textLessonID,
+			value,
+			block.Type == coursemodels.BlockImage,
+			i+1,
+			block.Type,
+			payload,
+		)
+		if err != nil {
+			logs.PrintLog(ctx, "CreateTextLesson", fmt.Sprintf("%+v", err))
+			return err
+		}
 	}
 
 	return nil
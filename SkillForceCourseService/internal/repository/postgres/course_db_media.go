@@ -0,0 +1,30 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"skillForce/pkg/logs"
+)
+
+// GetLessonCourse - курс урока и его автор
+func (d *Database) GetLessonCourse(ctx context.Context, lessonId int) (int, int, error) {
+	var courseId, creatorId int
+	err := d.conn.QueryRowContext(ctx, `
+		SELECT c.id, c.creator_user_id
+		FROM lesson l
+		JOIN lesson_bucket lb ON lb.id = l.lesson_bucket_id
+		JOIN part p ON p.id = lb.part_id
+		JOIN course c ON c.id = p.course_id
+		WHERE l.id = $1`, lessonId).Scan(&courseId, &creatorId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, 0, errors.New("lesson not found")
+	}
+	if err != nil {
+		logs.PrintLog(ctx, "GetLessonCourse", fmt.Sprintf("%+v", err))
+		return 0, 0, err
+	}
+	return courseId, creatorId, nil
+}
@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLessonCourse(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	database := &Database{conn: db}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT c.id, c.creator_user_id")).
+		WithArgs(12).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "creator_user_id"}).AddRow(3, 9))
+
+	courseId, creatorId, err := database.GetLessonCourse(profileTestCtx(), 12)
+	require.NoError(t, err)
+	require.Equal(t, 3, courseId)
+	require.Equal(t, 9, creatorId)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetLessonCourseNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	database := &Database{conn: db}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT c.id, c.creator_user_id")).
+		WithArgs(12).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "creator_user_id"}))
+
+	_, _, err = database.GetLessonCourse(profileTestCtx(), 12)
+	require.EqualError(t, err, "lesson not found")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetLessonCourseQueryError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	database := &Database{conn: db}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT c.id, c.creator_user_id")).
+		WithArgs(12).
+		WillReturnError(errors.New("db error"))
+
+	_, _, err = database.GetLessonCourse(profileTestCtx(), 12)
+	require.Error(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
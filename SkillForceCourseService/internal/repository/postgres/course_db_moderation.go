@@ -0,0 +1,27 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"skillForce/pkg/logs"
+)
+
+// SetCourseHidden - скрывает курс из каталога и поиска или возвращает его обратно
+func (d *Database) SetCourseHidden(ctx context.Context, courseId int, hidden bool, reason string) error {
+	res, err := d.conn.ExecContext(ctx, "UPDATE course SET hidden = $1, hidden_reason = $2 WHERE id = $3", hidden, reason, courseId)
+	if err != nil {
+		logs.PrintLog(ctx, "SetCourseHidden", fmt.Sprintf("%+v", err))
+		return err
+	}
+	updated, err := res.RowsAffected()
+	if err != nil {
+		logs.PrintLog(ctx, "SetCourseHidden", fmt.Sprintf("%+v", err))
+		return err
+	}
+	if updated == 0 {
+		return errors.New("course not found")
+	}
+	return nil
+}
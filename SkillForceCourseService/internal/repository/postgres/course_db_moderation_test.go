@@ -0,0 +1,23 @@
+package postgres
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetCourseHidden(t *testing.T) {
+	db, mock := setupDB(t)
+	query := regexp.QuoteMeta("UPDATE course SET hidden = $1, hidden_reason = $2 WHERE id = $3")
+
+	mock.ExpectExec(query).WithArgs(true, "spam", 10).WillReturnResult(sqlmock.NewResult(0, 1))
+	assert.NoError(t, db.SetCourseHidden(context.Background(), 10, true, "spam"))
+
+	mock.ExpectExec(query).WithArgs(false, "", 11).WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.EqualError(t, db.SetCourseHidden(context.Background(), 11, false, ""), "course not found")
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -502,92 +502,3 @@ func (d *Database) GetLessonFooters(ctx context.Context, currentLessonId int) ([
 
 	return footers, nil
 }
-
-func (d *Database) IsMiddle(ctx context.Context, userId int, courseId int) (bool, error) {
-	var exists bool
-	err := d.conn.QueryRow(`
-			SELECT EXISTS (
-			SELECT 1 FROM SENDED_MAILS 
-			WHERE User_ID = $1 AND Course_ID = $2
-		)
-		`, userId, courseId).Scan(&exists)
-
-	if err != nil {
-		logs.PrintLog(ctx, "IsMiddle", fmt.Sprintf("%+v", err))
-		return false, err
-	}
-
-	if exists {
-		return false, nil
-	}
-
-	var countMarkedLessons int
-	err = d.conn.QueryRow(`
-			SELECT COUNT(Lesson_ID)
-			FROM LESSON_CHECKPOINT
-			WHERE User_ID = $1 AND Course_ID = $2
-		`, userId, courseId).Scan(&countMarkedLessons)
-
-	if err != nil {
-		logs.PrintLog(ctx, "IsMiddle", fmt.Sprintf("%+v", err))
-		return false, err
-	}
-
-	var countLessons int
-	err = d.conn.QueryRow(`
-			SELECT COUNT(l.ID)
-			FROM LESSON l
-			JOIN LESSON_BUCKET lb ON l.LESSON_BUCKET_ID = lb.ID
-			JOIN PART p ON lb.PART_ID = p.ID
-			WHERE p.Course_ID = $1
-		`, courseId).Scan(&countLessons)
-
-	if err != nil {
-		logs.PrintLog(ctx, "IsMiddle", fmt.Sprintf("%+v", err))
-		return false, err
-	}
-
-	if countLessons*2 > countMarkedLessons {
-		_, err = d.conn.Exec(
-			"INSERT INTO SENDED_MAILS (User_ID, Course_ID) VALUES ($1, $2)",
-			userId, courseId)
-		if err != nil {
-			logs.PrintLog(ctx, "IsMiddle", fmt.Sprintf("%+v", err))
-			return false, err
-		}
-		logs.PrintLog(ctx, "IsMiddle", "marked that sended mail")
-		return true, nil
-	}
-
-	return false, nil
-}
-
-func (d *Database) IsWelcomeCourseMailSended(ctx context.Context, userId int, courseId int) (bool, error) {
-	var exists bool
-	err := d.conn.QueryRow(`
-			SELECT EXISTS (
-			SELECT 1 FROM WELCOME_COURSE_SENDED_MAILS 
-			WHERE User_ID = $1 AND Course_ID = $2
-		)
-		`, userId, courseId).Scan(&exists)
-
-	if err != nil {
-		logs.PrintLog(ctx, "IsWelcomeCourseMailSended", fmt.Sprintf("%+v", err))
-		return false, err
-	}
-
-	if exists {
-		return false, nil
-	}
-
-	_, err = d.conn.Exec(
-		"INSERT INTO WELCOME_COURSE_SENDED_MAILS (User_ID, Course_ID) VALUES ($1, $2)",
-		userId, courseId)
-	if err != nil {
-		logs.PrintLog(ctx, "IsWelcomeCourseMailSended", fmt.Sprintf("%+v", err))
-		return false, err
-	}
-	logs.PrintLog(ctx, "IsWelcomeCourseMailSended", "marked that sended mail")
-
-	return true, nil
-}
@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	coursemodels "skillForce/internal/models/course"
+	"skillForce/internal/models/dto"
+	"skillForce/pkg/logs"
+)
+
+func (d *Database) GetAuthoredCourses(ctx context.Context, userId int) ([]*coursemodels.Course, error) {
+	var authoredCourses []*coursemodels.Course
+	rows, err := d.conn.Query("SELECT id, creator_user_id, title, description, avatar_src, price, time_to_pass FROM course WHERE creator_user_id = $1 ORDER BY id", userId)
+	if err != nil {
+		logs.PrintLog(ctx, "GetAuthoredCourses", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logs.PrintLog(ctx, "GetAuthoredCourses", fmt.Sprintf("%+v", err))
+		}
+	}()
+
+	for rows.Next() {
+		var course coursemodels.Course
+		if err := rows.Scan(&course.Id, &course.CreatorId, &course.Title, &course.Description, &course.ScrImage, &course.Price, &course.TimeToPass); err != nil {
+			logs.PrintLog(ctx, "GetAuthoredCourses", fmt.Sprintf("%+v", err))
+			return nil, err
+		}
+		authoredCourses = append(authoredCourses, &course)
+	}
+
+	logs.PrintLog(ctx, "GetAuthoredCourses", fmt.Sprintf("get %d courses of author %d from db", len(authoredCourses), userId))
+	return authoredCourses, rows.Err()
+}
+
+// GetUserSertificates - ссылки на сертификаты пользователя по id курса
+func (d *Database) GetUserSertificates(ctx context.Context, userId int) (map[int]string, error) {
+	sertificates := make(map[int]string)
+	rows, err := d.conn.Query("SELECT course_id, sertificate_src FROM SERTIFICATES WHERE user_id = $1", userId)
+	if err != nil {
+		logs.PrintLog(ctx, "GetUserSertificates", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logs.PrintLog(ctx, "GetUserSertificates", fmt.Sprintf("%+v", err))
+		}
+	}()
+
+	for rows.Next() {
+		var courseId int
+		var sertificateUrl string
+		if err := rows.Scan(&courseId, &sertificateUrl); err != nil {
+			logs.PrintLog(ctx, "GetUserSertificates", fmt.Sprintf("%+v", err))
+			return nil, err
+		}
+		sertificates[courseId] = sertificateUrl
+	}
+	return sertificates, rows.Err()
+}
+
+// GetUserRatingPositions - место пользователя в рейтинге каждого курса, где у него есть баллы.
+// Баллы считаются так же, как в GetRating: пройденные уроки + правильные ответы × 5
+func (d *Database) GetUserRatingPositions(ctx context.Context, userId int) ([]*dto.RatingPositionDTO, error) {
+	query := `
+		WITH scores AS (
+			SELECT p.course_id, lc.user_id, COUNT(*) AS score
+			FROM lesson_checkpoint lc
+			JOIN lesson l ON lc.lesson_id = l.id
+			JOIN lesson_bucket lb ON l.lesson_bucket_id = lb.id
+			JOIN part p ON lb.part_id = p.id
+			WHERE l.type IN ('text', 'video')
+			GROUP BY p.course_id, lc.user_id
+			UNION ALL
+			SELECT p.course_id, ua.user_id, COUNT(ua.id) * 5 AS score
+			FROM user_answers ua
+			JOIN lesson l ON ua.question_lesson_id = l.id
+			JOIN lesson_bucket lb ON l.lesson_bucket_id = lb.id
+			JOIN part p ON lb.part_id = p.id
+			WHERE ua.is_right = true
+			GROUP BY p.course_id, ua.user_id
+		), ranked AS (
+			SELECT course_id, user_id, SUM(score) AS score,
+				RANK() OVER (PARTITION BY course_id ORDER BY SUM(score) DESC) AS position
+			FROM scores
+			GROUP BY course_id, user_id
+		)
+		SELECT r.course_id, c.title, r.position, r.score
+		FROM ranked r
+		JOIN course c ON c.id = r.course_id
+		WHERE r.user_id = $1 AND r.score > 0
+		ORDER BY r.position, r.course_id
+	`
+
+	rows, err := d.conn.Query(query, userId)
+	if err != nil {
+		logs.PrintLog(ctx, "GetUserRatingPositions", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logs.PrintLog(ctx, "GetUserRatingPositions", fmt.Sprintf("%+v", err))
+		}
+	}()
+
+	positions := make([]*dto.RatingPositionDTO, 0)
+	for rows.Next() {
+		var position dto.RatingPositionDTO
+		if err := rows.Scan(&position.CourseId, &position.CourseTitle, &position.Position, &position.Score); err != nil {
+			logs.PrintLog(ctx, "GetUserRatingPositions", fmt.Sprintf("%+v", err))
+			return nil, err
+		}
+		positions = append(positions, &position)
+	}
+	return positions, rows.Err()
+}
+
+// GetAuthorStats - количество курсов, уникальных студентов и средняя оценка курсов автора
+func (d *Database) GetAuthorStats(ctx context.Context, userId int) (*dto.AuthorStatsDTO, error) {
+	var stats dto.AuthorStatsDTO
+	err := d.conn.QueryRow(`
+		SELECT
+			(SELECT COUNT(*) FROM course WHERE creator_user_id = $1),
+			(SELECT COUNT(DISTINCT s.user_id) FROM signups s JOIN course c ON c.id = s.course_id WHERE c.creator_user_id = $1),
+			(SELECT COALESCE(AVG(cm.rating), 0) FROM course_metrik cm JOIN course c ON c.id = cm.course_id WHERE c.creator_user_id = $1)
+	`, userId).Scan(&stats.CoursesAmount, &stats.StudentsAmount, &stats.Rating)
+	if err != nil {
+		logs.PrintLog(ctx, "GetAuthorStats", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+	return &stats, nil
+}
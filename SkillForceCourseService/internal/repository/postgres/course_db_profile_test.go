@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+	"regexp"
+	"skillForce/pkg/logs"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func profileTestCtx() context.Context {
+	return context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{
+		Data: make([]*logs.LogString, 0),
+	})
+}
+
+func TestGetAuthoredCourses(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	database := &Database{conn: db}
+
+	rows := sqlmock.NewRows([]string{"id", "creator_user_id", "title", "description", "avatar_src", "price", "time_to_pass"}).
+		AddRow(3, 7, "Go", "Desc", "img.jpg", 0, 10)
+	mock.ExpectQuery(regexp.QuoteMeta("FROM course WHERE creator_user_id = $1")).
+		WithArgs(7).
+		WillReturnRows(rows)
+
+	courses, err := database.GetAuthoredCourses(profileTestCtx(), 7)
+	require.NoError(t, err)
+	require.Len(t, courses, 1)
+	require.Equal(t, 3, courses[0].Id)
+	require.Equal(t, 7, courses[0].CreatorId)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetUserSertificates(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	database := &Database{conn: db}
+
+	rows := sqlmock.NewRows([]string{"course_id", "sertificate_src"}).
+		AddRow(1, "http://minio/sertificates/1.pdf").
+		AddRow(2, "http://minio/sertificates/2.pdf")
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT course_id, sertificate_src FROM SERTIFICATES WHERE user_id = $1")).
+		WithArgs(5).
+		WillReturnRows(rows)
+
+	sertificates, err := database.GetUserSertificates(profileTestCtx(), 5)
+	require.NoError(t, err)
+	require.Equal(t, map[int]string{1: "http://minio/sertificates/1.pdf", 2: "http://minio/sertificates/2.pdf"}, sertificates)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetUserRatingPositions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	database := &Database{conn: db}
+
+	rows := sqlmock.NewRows([]string{"course_id", "title", "position", "score"}).
+		AddRow(1, "Go", 1, 42).
+		AddRow(2, "SQL", 3, 10)
+	mock.ExpectQuery(regexp.QuoteMeta("RANK() OVER (PARTITION BY course_id")).
+		WithArgs(5).
+		WillReturnRows(rows)
+
+	positions, err := database.GetUserRatingPositions(profileTestCtx(), 5)
+	require.NoError(t, err)
+	require.Len(t, positions, 2)
+	require.Equal(t, "Go", positions[0].CourseTitle)
+	require.Equal(t, 1, positions[0].Position)
+	require.Equal(t, 42, positions[0].Score)
+	require.Equal(t, 3, positions[1].Position)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetAuthorStats(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	database := &Database{conn: db}
+
+	mock.ExpectQuery(regexp.QuoteMeta("COUNT(DISTINCT s.user_id)")).
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"courses", "students", "rating"}).AddRow(2, 15, 4.5))
+
+	stats, err := database.GetAuthorStats(profileTestCtx(), 7)
+	require.NoError(t, err)
+	require.Equal(t, 2, stats.CoursesAmount)
+	require.Equal(t, 15, stats.StudentsAmount)
+	require.InDelta(t, 4.5, stats.Rating, 0.001)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
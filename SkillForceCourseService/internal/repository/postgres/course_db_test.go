@@ -23,7 +23,7 @@ func TestGetBucketCourses_Success(t *testing.T) {
 		AddRow(1, 101, "Course 1", "Desc 1", "img1.jpg", 100, 10).
 		AddRow(2, 102, "Course 2", "Desc 2", "img2.jpg", 200, 15)
 
-	mock.ExpectQuery("SELECT id, creator_user_id, title, description, avatar_src, price, time_to_pass FROM course LIMIT 16").
+	mock.ExpectQuery("SELECT id, creator_user_id, title, description, avatar_src, price, time_to_pass FROM course WHERE NOT hidden LIMIT 16").
 		WillReturnRows(rows)
 
 	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{
@@ -423,22 +423,24 @@ func TestGetLessonBlocks_Success(t *testing.T) {
 	lessonID := 123
 
 	mock.ExpectQuery(regexp.QuoteMeta(`
-		SELECT tlb.value
+		SELECT tlb.value, tlb.block_type, tlb.payload
 		FROM TEXT_LESSON_BLOCK tlb
 		JOIN TEXT_LESSON tl ON tlb.Text_Lesson_ID = tl.ID
 		WHERE tl.Lesson_ID = $1
 		ORDER BY tlb.Text_Lesson_Block_Order ASC
 	`)).
 		WithArgs(lessonID).
-		WillReturnRows(sqlmock.NewRows([]string{"value"}).
-			AddRow("Block 1").
-			AddRow("Block 2").
-			AddRow("Block 3"))
+		WillReturnRows(sqlmock.NewRows([]string{"value", "block_type", "payload"}).
+			AddRow("Block 1", "html", nil).
+			AddRow("", "code", []byte(`{"type":"code","language":"go","code":"package main"}`)).
+			AddRow("Block 3", "html", nil))
 
 	blocks, err := database.GetLessonBlocks(ctx, lessonID)
 	require.NoError(t, err)
 	require.Len(t, blocks, 3)
-	require.Equal(t, []string{"Block 1", "Block 2", "Block 3"}, blocks)
+	require.Equal(t, &coursemodels.LessonBlock{Type: coursemodels.BlockHTML, Text: "Block 1"}, blocks[0])
+	require.Equal(t, &coursemodels.LessonBlock{Type: coursemodels.BlockCode, Language: "go", Code: "package main"}, blocks[1])
+	require.Equal(t, "Block 3", blocks[2].Text)
 
 	require.NoError(t, mock.ExpectationsWereMet())
 }
@@ -0,0 +1,279 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	coursemodels "skillForce/internal/models/course"
+	"skillForce/pkg/events"
+	"skillForce/pkg/logs"
+	"skillForce/pkg/outbox"
+	"skillForce/pkg/scheduler"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// digestRetention - сколько хранятся отправленные сводки: для сравнения рейтинга нужна только последняя
+const digestRetention = 35 * 24 * time.Hour
+
+// NewScheduler - выполнение отложенных задач course-service из scheduled_jobs
+func (d *Database) NewScheduler(conf scheduler.Config) *scheduler.Scheduler {
+	return scheduler.New(d.conn, conf)
+}
+
+// ScheduleJob - задача kind с key на время runAt, если такой задачи ещё нет
+func (d *Database) ScheduleJob(ctx context.Context, kind string, key string, runAt time.Time) error {
+	if err := scheduler.Schedule(ctx, d.conn, kind, key, runAt); err != nil {
+		logs.PrintLog(ctx, "ScheduleJob", fmt.Sprintf("%+v", err))
+		return err
+	}
+	return nil
+}
+
+// GetDigestUser - получатель сводки, nil - пользователя нет
+func (d *Database) GetDigestUser(ctx context.Context, userId int) (*coursemodels.DigestUser, error) {
+	user := &coursemodels.DigestUser{Id: userId}
+	err := d.conn.QueryRowContext(ctx, `
+		SELECT u.email, u.name, u.locale, u.timezone,
+			NOT EXISTS (SELECT 1 FROM notification_preferences np
+				WHERE np.user_id = u.id AND np.category = $2 AND NOT np.enabled),
+			EXISTS (SELECT 1 FROM account_deletions ad WHERE ad.user_id = u.id)
+		FROM usertable u WHERE u.id = $1`, userId, events.CategoryDigest).
+		Scan(&user.Email, &user.Name, &user.Locale, &user.Timezone, &user.Subscribed, &user.Deleting)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		logs.PrintLog(ctx, "GetDigestUser", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+	return user, nil
+}
+
+// GetUsersWithoutDigest - до limit подписанных на сводку пользователей, которым она ещё не запланирована
+func (d *Database) GetUsersWithoutDigest(ctx context.Context, limit int) ([]*coursemodels.DigestUser, error) {
+	rows, err := d.conn.QueryContext(ctx, `
+		SELECT u.id, u.timezone FROM usertable u
+		WHERE NOT EXISTS (SELECT 1 FROM scheduled_jobs j WHERE j.kind = $1 AND j.key = u.id::text)
+			AND NOT EXISTS (SELECT 1 FROM notification_preferences np
+				WHERE np.user_id = u.id AND np.category = $2 AND NOT np.enabled)
+			AND NOT EXISTS (SELECT 1 FROM account_deletions ad WHERE ad.user_id = u.id)
+		ORDER BY u.id
+		LIMIT $3`, coursemodels.JobWeeklyDigest, events.CategoryDigest, limit)
+	if err != nil {
+		logs.PrintLog(ctx, "GetUsersWithoutDigest", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logs.PrintLog(ctx, "GetUsersWithoutDigest", fmt.Sprintf("%+v", err))
+		}
+	}()
+
+	var users []*coursemodels.DigestUser
+	for rows.Next() {
+		user := &coursemodels.DigestUser{Subscribed: true}
+		if err := rows.Scan(&user.Id, &user.Timezone); err != nil {
+			logs.PrintLog(ctx, "GetUsersWithoutDigest", fmt.Sprintf("%+v", err))
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
+// GetWeekLessons - сколько уроков каждого курса пользователь прошёл за [from, to)
+func (d *Database) GetWeekLessons(ctx context.Context, userId int, from time.Time, to time.Time) ([]*coursemodels.DigestCourse, error) {
+	// created_at без часового пояса, сравнение с timestamptz переводит его в пояс сессии, в котором он и записан
+	rows, err := d.conn.QueryContext(ctx, `
+		SELECT lc.course_id, c.title, COUNT(*) AS lessons
+		FROM lesson_checkpoint lc
+		JOIN course c ON c.id = lc.course_id
+		WHERE lc.user_id = $1 AND lc.created_at >= $2::timestamptz AND lc.created_at < $3::timestamptz
+		GROUP BY lc.course_id, c.title
+		ORDER BY lessons DESC, lc.course_id`, userId, from, to)
+	if err != nil {
+		logs.PrintLog(ctx, "GetWeekLessons", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+	return scanDigestCourses(ctx, "GetWeekLessons", rows)
+}
+
+// GetUnfinishedCourses - до limit курсов, на которые пользователь записан и которые не закончил,
+// начиная с тех, где он недавно проходил уроки
+func (d *Database) GetUnfinishedCourses(ctx context.Context, userId int, limit int) ([]*coursemodels.DigestCourse, error) {
+	rows, err := d.conn.QueryContext(ctx, `
+		SELECT s.course_id, c.title, 0 AS lessons
+		FROM signups s
+		JOIN course c ON c.id = s.course_id
+		WHERE s.user_id = $1
+			AND NOT EXISTS (SELECT 1 FROM completed_courses cc WHERE cc.user_id = s.user_id AND cc.course_id = s.course_id)
+		ORDER BY (SELECT MAX(lc.created_at) FROM lesson_checkpoint lc
+			WHERE lc.user_id = s.user_id AND lc.course_id = s.course_id) DESC NULLS LAST, s.course_id
+		LIMIT $2`, userId, limit)
+	if err != nil {
+		logs.PrintLog(ctx, "GetUnfinishedCourses", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+	return scanDigestCourses(ctx, "GetUnfinishedCourses", rows)
+}
+
+// GetNewCoursesByFavouriteTags - до limit курсов, опубликованных после since, с тегами избранных курсов
+// пользователя. Свои курсы, курсы, на которые он записан, уже избранные и скрытые модератором не предлагаются
+func (d *Database) GetNewCoursesByFavouriteTags(ctx context.Context, userId int, since time.Time, limit int) ([]*coursemodels.DigestCourse, error) {
+	rows, err := d.conn.QueryContext(ctx, `
+		SELECT c.id, c.title, 0 AS lessons
+		FROM course c
+		WHERE c.created_at >= $2::timestamptz AND c.creator_user_id <> $1 AND NOT c.hidden
+			AND EXISTS (SELECT 1 FROM tags t
+				JOIN tags ft ON ft.tag_id = t.tag_id
+				JOIN favourite_courses fc ON fc.course_id = ft.course_id
+				WHERE t.course_id = c.id AND fc.user_id = $1)
+			AND NOT EXISTS (SELECT 1 FROM favourite_courses fc WHERE fc.course_id = c.id AND fc.user_id = $1)
+			AND NOT EXISTS (SELECT 1 FROM signups s WHERE s.course_id = c.id AND s.user_id = $1)
+		ORDER BY c.created_at DESC, c.id
+		LIMIT $3`, userId, since, limit)
+	if err != nil {
+		logs.PrintLog(ctx, "GetNewCoursesByFavouriteTags", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+	return scanDigestCourses(ctx, "GetNewCoursesByFavouriteTags", rows)
+}
+
+func scanDigestCourses(ctx context.Context, funcName string, rows *sql.Rows) ([]*coursemodels.DigestCourse, error) {
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logs.PrintLog(ctx, funcName, fmt.Sprintf("%+v", err))
+		}
+	}()
+
+	var courses []*coursemodels.DigestCourse
+	for rows.Next() {
+		var course coursemodels.DigestCourse
+		if err := rows.Scan(&course.CourseId, &course.CourseName, &course.Lessons); err != nil {
+			logs.PrintLog(ctx, funcName, fmt.Sprintf("%+v", err))
+			return nil, err
+		}
+		courses = append(courses, &course)
+	}
+	return courses, rows.Err()
+}
+
+// GetLastDigestRating - места в рейтингах курсов по id курса из последней сводки, пустые - сводок не было
+func (d *Database) GetLastDigestRating(ctx context.Context, userId int) (map[int]int, error) {
+	var data []byte
+	err := d.conn.QueryRowContext(ctx, "SELECT rating FROM digest_mails WHERE user_id = $1 ORDER BY sent_at DESC LIMIT 1", userId).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return map[int]int{}, nil
+	}
+	if err != nil {
+		logs.PrintLog(ctx, "GetLastDigestRating", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+
+	positions := make(map[int]int)
+	if err := json.Unmarshal(data, &positions); err != nil {
+		logs.PrintLog(ctx, "GetLastDigestRating", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+	return positions, nil
+}
+
+// SendWeeklyDigest - запись сводки в digest_mails и outbox одной транзакцией.
+// false - сводка за эту неделю уже отправлена
+func (d *Database) SendWeeklyDigest(ctx context.Context, digest *coursemodels.WeeklyDigest) (bool, error) {
+	rating, err := json.Marshal(digest.RatingPositions())
+	if err != nil {
+		return false, err
+	}
+
+	tx, err := d.conn.BeginTx(ctx, nil)
+	if err != nil {
+		logs.PrintLog(ctx, "SendWeeklyDigest", fmt.Sprintf("failed to begin transaction: %+v", err))
+		return false, err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logs.PrintLog(ctx, "transaction rollback failed", fmt.Sprintf("error: %v", err))
+		}
+	}()
+
+	user := digest.User
+	res, err := tx.ExecContext(ctx, "INSERT INTO digest_mails (user_id, week, rating) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING",
+		user.Id, digest.Week, rating)
+	if err != nil {
+		logs.PrintLog(ctx, "SendWeeklyDigest", fmt.Sprintf("%+v", err))
+		return false, err
+	}
+	if inserted, err := res.RowsAffected(); err != nil || inserted == 0 {
+		return false, err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM digest_mails WHERE user_id = $1 AND sent_at < NOW() - make_interval(secs => $2)",
+		user.Id, digestRetention.Seconds()); err != nil {
+		logs.PrintLog(ctx, "SendWeeklyDigest", fmt.Sprintf("%+v", err))
+		return false, err
+	}
+
+	recipient := &events.Recipient{Email: user.Email, Name: user.Name, Locale: user.Locale, UserId: int32(user.Id)}
+	env, err := events.New(ctx, OutboxSource, &events.WeeklyDigestMail{
+		Recipient:        recipient,
+		PeriodStart:      timestamppb.New(digest.PeriodStart),
+		PeriodEnd:        timestamppb.New(digest.PeriodEnd),
+		LessonsCompleted: int32(digest.Lessons),
+		Courses:          digestCoursesEvent(digest.Courses),
+		Ratings:          digestRatingsEvent(digest.Ratings),
+		NewCourses:       digestCoursesEvent(digest.NewCourses),
+		Timezone:         user.Timezone,
+	})
+	if errors.Is(err, events.ErrInvalidEvent) {
+		// сводку не отправить, например у пользователя некорректный адрес. Отметка остаётся,
+		// чтобы сводка за эту неделю не собиралась снова
+		logs.PrintLog(ctx, "SendWeeklyDigest", fmt.Sprintf("skip digest for user %d: %+v", user.Id, err))
+		return false, tx.Commit()
+	}
+	if err == nil {
+		err = outbox.Enqueue(ctx, tx, coursemodels.MailTopic, recipient.Email, env)
+	}
+	if err != nil {
+		logs.PrintLog(ctx, "SendWeeklyDigest", fmt.Sprintf("%+v", err))
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logs.PrintLog(ctx, "SendWeeklyDigest", fmt.Sprintf("failed to commit transaction: %+v", err))
+		return false, err
+	}
+
+	logs.PrintLog(ctx, "SendWeeklyDigest", fmt.Sprintf("digest for %s enqueued for user %d", digest.Week, user.Id))
+	return true, nil
+}
+
+func digestCoursesEvent(courses []*coursemodels.DigestCourse) []*events.DigestCourse {
+	result := make([]*events.DigestCourse, 0, len(courses))
+	for _, course := range courses {
+		result = append(result, &events.DigestCourse{
+			CourseId:         int32(course.CourseId),
+			CourseName:       course.CourseName,
+			LessonsCompleted: int32(course.Lessons),
+			ProgressPercent:  int32(course.Progress),
+		})
+	}
+	return result
+}
+
+func digestRatingsEvent(ratings []*coursemodels.DigestRating) []*events.DigestRating {
+	result := make([]*events.DigestRating, 0, len(ratings))
+	for _, rating := range ratings {
+		result = append(result, &events.DigestRating{
+			CourseId:   int32(rating.CourseId),
+			CourseName: rating.CourseName,
+			Position:   int32(rating.Position),
+			Change:     int32(rating.Change),
+		})
+	}
+	return result
+}
@@ -0,0 +1,179 @@
+package postgres
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	coursemodels "skillForce/internal/models/course"
+	"skillForce/pkg/events"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDigestUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	database := &Database{conn: db}
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM usertable u WHERE u.id = $1")).
+		WithArgs(7, events.CategoryDigest).
+		WillReturnRows(sqlmock.NewRows([]string{"email", "name", "locale", "timezone", "subscribed", "deleting"}).
+			AddRow("alice@example.com", "Alice", "en", "Asia/Tokyo", true, false))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM usertable u WHERE u.id = $1")).
+		WithArgs(8, events.CategoryDigest).
+		WillReturnRows(sqlmock.NewRows([]string{"email", "name", "locale", "timezone", "subscribed", "deleting"}))
+
+	user, err := database.GetDigestUser(profileTestCtx(), 7)
+	require.NoError(t, err)
+	require.Equal(t, &coursemodels.DigestUser{
+		Id: 7, Email: "alice@example.com", Name: "Alice", Locale: "en", Timezone: "Asia/Tokyo", Subscribed: true,
+	}, user)
+
+	user, err = database.GetDigestUser(profileTestCtx(), 8)
+	require.NoError(t, err)
+	require.Nil(t, user)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetUsersWithoutDigest(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	database := &Database{conn: db}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT 1 FROM scheduled_jobs j WHERE j.kind = $1 AND j.key = u.id::text")).
+		WithArgs(coursemodels.JobWeeklyDigest, events.CategoryDigest, 100).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "timezone"}).AddRow(3, "Europe/Moscow").AddRow(5, "UTC"))
+
+	users, err := database.GetUsersWithoutDigest(profileTestCtx(), 100)
+	require.NoError(t, err)
+	require.Equal(t, []*coursemodels.DigestUser{
+		{Id: 3, Timezone: "Europe/Moscow", Subscribed: true},
+		{Id: 5, Timezone: "UTC", Subscribed: true},
+	}, users)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetWeekLessons(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	database := &Database{conn: db}
+
+	from := time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+	mock.ExpectQuery(regexp.QuoteMeta("lc.created_at >= $2::timestamptz AND lc.created_at < $3::timestamptz")).
+		WithArgs(7, from, to).
+		WillReturnRows(sqlmock.NewRows([]string{"course_id", "title", "lessons"}).AddRow(1, "Go", 3))
+
+	courses, err := database.GetWeekLessons(profileTestCtx(), 7, from, to)
+	require.NoError(t, err)
+	require.Equal(t, []*coursemodels.DigestCourse{{CourseId: 1, CourseName: "Go", Lessons: 3}}, courses)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetLastDigestRating(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	database := &Database{conn: db}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT rating FROM digest_mails WHERE user_id = $1 ORDER BY sent_at DESC LIMIT 1")).
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"rating"}).AddRow([]byte(`{"1": 4, "2": 1}`)))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT rating FROM digest_mails")).
+		WithArgs(8).
+		WillReturnRows(sqlmock.NewRows([]string{"rating"}))
+
+	positions, err := database.GetLastDigestRating(profileTestCtx(), 7)
+	require.NoError(t, err)
+	require.Equal(t, map[int]int{1: 4, 2: 1}, positions)
+
+	positions, err = database.GetLastDigestRating(profileTestCtx(), 8)
+	require.NoError(t, err)
+	require.Empty(t, positions)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func testDigest(email string) *coursemodels.WeeklyDigest {
+	start := time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC)
+	return &coursemodels.WeeklyDigest{
+		User:        &coursemodels.DigestUser{Id: 7, Email: email, Name: "Alice", Locale: "en", Timezone: "Asia/Tokyo", Subscribed: true},
+		Week:        "2026-W42",
+		PeriodStart: start,
+		PeriodEnd:   start.AddDate(0, 0, 7),
+		Lessons:     3,
+		Courses:     []*coursemodels.DigestCourse{{CourseId: 1, CourseName: "Go", Lessons: 3, Progress: 60}},
+		Ratings:     []*coursemodels.DigestRating{{CourseId: 1, CourseName: "Go", Position: 2, Change: 1}},
+	}
+}
+
+func TestSendWeeklyDigest(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	database := &Database{conn: db}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO digest_mails (user_id, week, rating) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING")).
+		WithArgs(7, "2026-W42", []byte(`{"1":2}`)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM digest_mails WHERE user_id = $1 AND sent_at < NOW()")).
+		WithArgs(7, digestRetention.Seconds()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	var payload []byte
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO outbox_events")).
+		WithArgs(sqlmock.AnyArg(), OutboxSource, coursemodels.MailTopic, "alice@example.com", payloadArg{&payload}).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	sent, err := database.SendWeeklyDigest(profileTestCtx(), testDigest("alice@example.com"))
+	require.NoError(t, err)
+	require.True(t, sent)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	env, err := events.Unmarshal(payload)
+	require.NoError(t, err)
+	require.Equal(t, events.TypeWeeklyDigestMail, env.GetType())
+	require.Equal(t, events.CategoryDigest, events.Category(env))
+	digest := env.GetWeeklyDigestMail()
+	require.Equal(t, int32(7), digest.GetRecipient().GetUserId())
+	require.Equal(t, int32(3), digest.GetLessonsCompleted())
+	require.Equal(t, int32(60), digest.GetCourses()[0].GetProgressPercent())
+	require.Equal(t, int32(1), digest.GetRatings()[0].GetChange())
+	require.Equal(t, time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC), digest.GetPeriodEnd().AsTime())
+	require.Equal(t, "Asia/Tokyo", digest.GetTimezone())
+}
+
+func TestSendWeeklyDigest_AlreadySent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	database := &Database{conn: db}
+
+	// другой экземпляр сервиса уже отправил сводку за эту неделю
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO digest_mails")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	sent, err := database.SendWeeklyDigest(profileTestCtx(), testDigest("alice@example.com"))
+	require.NoError(t, err)
+	require.False(t, sent)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSendWeeklyDigest_InvalidRecipient(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	database := &Database{conn: db}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO digest_mails")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM digest_mails")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	sent, err := database.SendWeeklyDigest(profileTestCtx(), testDigest("not an email"))
+	require.NoError(t, err)
+	require.False(t, sent)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,13 @@
+package postgres
+
+import (
+	"skillForce/pkg/outbox"
+)
+
+// OutboxSource - события course-service в outbox_events
+const OutboxSource = "course-service"
+
+// NewOutboxRelay - отправка событий course-service из outbox_events
+func (d *Database) NewOutboxRelay(publisher outbox.Publisher, conf outbox.Config) *outbox.Relay {
+	return outbox.NewRelay(d.conn, publisher, OutboxSource, conf)
+}
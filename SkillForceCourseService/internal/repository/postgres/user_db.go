@@ -9,7 +9,7 @@ import (
 
 func (d *Database) GetUserById(ctx context.Context, userId int) (*usermodels.User, error) {
 	var user usermodels.User
-	err := d.conn.QueryRow("SELECT email, name, hide_email FROM usertable WHERE id = $1", userId).Scan(&user.Email, &user.Name, &user.HideEmail)
+	err := d.conn.QueryRow("SELECT email, name, hide_email, locale FROM usertable WHERE id = $1", userId).Scan(&user.Email, &user.Name, &user.HideEmail, &user.Locale)
 	if err != nil {
 		logs.PrintLog(ctx, "GetUserById", fmt.Sprintf("%+v", err))
 		return nil, err
@@ -24,12 +24,13 @@ func TestGetUserById_Success(t *testing.T) {
 		Email:     "test@example.com",
 		Name:      "John Doe",
 		HideEmail: true,
+		Locale:    "en",
 	}
 
-	mock.ExpectQuery("SELECT email, name, hide_email FROM usertable WHERE id = \\$1").
+	mock.ExpectQuery("SELECT email, name, hide_email, locale FROM usertable WHERE id = \\$1").
 		WithArgs(userId).
-		WillReturnRows(sqlmock.NewRows([]string{"email", "name", "hide_email"}).
-			AddRow(expectedUser.Email, expectedUser.Name, expectedUser.HideEmail))
+		WillReturnRows(sqlmock.NewRows([]string{"email", "name", "hide_email", "locale"}).
+			AddRow(expectedUser.Email, expectedUser.Name, expectedUser.HideEmail, expectedUser.Locale))
 
 	user, err := database.GetUserById(ctx, userId)
 	require.NoError(t, err)
@@ -46,7 +47,7 @@ func TestGetUserById_NoRows(t *testing.T) {
 	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
 	userId := 999
 
-	mock.ExpectQuery("SELECT email, name, hide_email FROM usertable WHERE id = \\$1").
+	mock.ExpectQuery("SELECT email, name, hide_email, locale FROM usertable WHERE id = \\$1").
 		WithArgs(userId).
 		WillReturnError(sql.ErrNoRows)
 
@@ -65,7 +66,7 @@ func TestGetUserById_QueryError(t *testing.T) {
 	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
 	userId := 42
 
-	mock.ExpectQuery("SELECT email, name, hide_email FROM usertable WHERE id = \\$1").
+	mock.ExpectQuery("SELECT email, name, hide_email, locale FROM usertable WHERE id = \\$1").
 		WithArgs(userId).
 		WillReturnError(fmt.Errorf("some db error"))
 
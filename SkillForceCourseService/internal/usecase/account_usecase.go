@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"skillForce/internal/models/dto"
+	"skillForce/pkg/logs"
+	"time"
+)
+
+// ExportUserData - курсовая часть выгрузки данных пользователя: по JSON файлу на каждый вид данных
+func (uc *CourseUsecase) ExportUserData(ctx context.Context, userId int) ([]*dto.UserDataFileDTO, error) {
+	data, err := uc.repo.GetUserCourseData(ctx, userId)
+	if err != nil {
+		logs.PrintLog(ctx, "ExportUserData", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+
+	parts := []struct {
+		name  string
+		value interface{}
+	}{
+		{"progress.json", data.Progress},
+		{"quiz_answers.json", data.QuizAnswers},
+		{"question_answers.json", data.QuestionAnswers},
+		{"completed_courses.json", data.CompletedCourses},
+		{"sertificates.json", data.Sertificates},
+	}
+
+	files := make([]*dto.UserDataFileDTO, 0, len(parts))
+	for _, part := range parts {
+		content, err := json.MarshalIndent(part.value, "", "  ")
+		if err != nil {
+			logs.PrintLog(ctx, "ExportUserData", fmt.Sprintf("%+v", err))
+			return nil, err
+		}
+		files = append(files, &dto.UserDataFileDTO{Name: part.name, Content: content})
+	}
+
+	logs.PrintLog(ctx, "ExportUserData", fmt.Sprintf("export course data of user %d", userId))
+	return files, nil
+}
+
+// PurgeDeletedAccounts - удаление курсовых данных аккаунтов, срок отмены удаления которых истёк
+func (uc *CourseUsecase) PurgeDeletedAccounts(ctx context.Context) error {
+	userIds, err := uc.repo.GetAccountsToPurge(ctx)
+	if err != nil {
+		logs.PrintLog(ctx, "PurgeDeletedAccounts", fmt.Sprintf("%+v", err))
+		return err
+	}
+
+	for _, userId := range userIds {
+		if err := uc.repo.PurgeUserCourseData(ctx, userId); err != nil {
+			logs.PrintLog(ctx, "PurgeDeletedAccounts", fmt.Sprintf("user %d: %+v", userId, err))
+			return err
+		}
+	}
+	return nil
+}
+
+// RunAccountPurger - периодический запуск PurgeDeletedAccounts до отмены контекста
+func (uc *CourseUsecase) RunAccountPurger(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		logs.RunJob(ctx, "PurgeDeletedAccounts", func(ctx context.Context) {
+			_ = uc.PurgeDeletedAccounts(ctx)
+		})
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
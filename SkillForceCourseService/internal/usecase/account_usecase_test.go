@@ -0,0 +1,57 @@
+package usecase_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"skillForce/internal/models/dto"
+	"skillForce/internal/usecase"
+	"skillForce/pkg/logs"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportUserData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := usecase.NewMockCourseRepository(ctrl)
+	uc := usecase.NewCourseUsecase(mockRepo)
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	mockRepo.EXPECT().GetUserCourseData(ctx, 7).Return(&dto.UserCourseDataDTO{
+		Progress:         []*dto.LessonProgressDTO{{CourseId: 1, LessonId: 2}},
+		QuizAnswers:      []*dto.QuizAnswerExportDTO{},
+		QuestionAnswers:  []*dto.QuestionAnswerExportDTO{},
+		CompletedCourses: []int{1},
+		Sertificates:     []*dto.SertificateExportDTO{{CourseId: 1, SertificateUrl: "http://minio/1.pdf"}},
+	}, nil)
+
+	files, err := uc.ExportUserData(ctx, 7)
+	require.NoError(t, err)
+	require.Len(t, files, 5)
+	require.Equal(t, "progress.json", files[0].Name)
+
+	var progress []*dto.LessonProgressDTO
+	require.NoError(t, json.Unmarshal(files[0].Content, &progress))
+	require.Equal(t, 2, progress[0].LessonId)
+	require.JSONEq(t, "[]", string(files[1].Content))
+}
+
+func TestPurgeDeletedAccounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := usecase.NewMockCourseRepository(ctrl)
+	uc := usecase.NewCourseUsecase(mockRepo)
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	mockRepo.EXPECT().GetAccountsToPurge(ctx).Return([]int{3, 5}, nil)
+	mockRepo.EXPECT().PurgeUserCourseData(ctx, 3).Return(nil)
+	mockRepo.EXPECT().PurgeUserCourseData(ctx, 5).Return(errors.New("db error"))
+
+	require.Error(t, uc.PurgeDeletedAccounts(ctx))
+}
@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	coursemodels "skillForce/internal/models/course"
+	"skillForce/internal/models/dto"
+	usermodels "skillForce/internal/models/user"
+	"skillForce/pkg/logs"
+)
+
+// MaxOfflineLessons - сколько уроков можно отметить пройденными за один запрос
+const MaxOfflineLessons = 500
+
+// canViewCourse - доступ к содержимому курса есть у купивших его, автора и администраторов
+func (uc *CourseUsecase) canViewCourse(ctx context.Context, userProfile *usermodels.UserProfile, course *coursemodels.Course) (bool, error) {
+	if userProfile == nil {
+		return false, nil
+	}
+	if course.CreatorId == userProfile.Id || userProfile.IsAdmin {
+		return true, nil
+	}
+	return uc.repo.IsUserPurchasedCourse(ctx, userProfile.Id, course.Id)
+}
+
+// GetCourseBundle - содержимое курса для офлайн выгрузки. Версия - хеш содержимого, по ней
+// main-service понимает, что собранную выгрузку пора пересобрать
+func (uc *CourseUsecase) GetCourseBundle(ctx context.Context, userProfile *usermodels.UserProfile, courseId int) (*dto.CourseBundleDTO, error) {
+	course, err := uc.repo.GetCourseById(ctx, courseId)
+	if err != nil {
+		logs.PrintLog(ctx, "GetCourseBundle", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+	allowed, err := uc.canViewCourse(ctx, userProfile, course)
+	if err != nil {
+		logs.PrintLog(ctx, "GetCourseBundle", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+	if !allowed {
+		return nil, errors.New("course is not purchased")
+	}
+	bundle := &dto.CourseBundleDTO{
+		CourseId:    course.Id,
+		Title:       course.Title,
+		Description: course.Description,
+		Lessons:     []*dto.BundleLessonDTO{},
+	}
+
+	parts, err := uc.repo.GetCourseParts(ctx, courseId)
+	if err != nil {
+		logs.PrintLog(ctx, "GetCourseBundle", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+	for _, part := range parts {
+		buckets, err := uc.repo.GetPartBuckets(ctx, part.Id)
+		if err != nil {
+			logs.PrintLog(ctx, "GetCourseBundle", fmt.Sprintf("%+v", err))
+			return nil, err
+		}
+		for _, bucket := range buckets {
+			lessons, err := uc.repo.GetBucketLessons(ctx, userProfile.Id, courseId, bucket.Id)
+			if err != nil {
+				logs.PrintLog(ctx, "GetCourseBundle", fmt.Sprintf("%+v", err))
+				return nil, err
+			}
+			for _, lesson := range lessons {
+				bundleLesson := &dto.BundleLessonDTO{
+					LessonId:    lesson.LessonId,
+					PartTitle:   part.Title,
+					BucketTitle: bucket.Title,
+					Title:       lesson.Title,
+					Type:        lesson.Type,
+				}
+				// видео, тесты и вопросы main-service выгружает сам или оставляет для онлайна
+				if lesson.Type == "text" {
+					blocks, err := uc.repo.GetLessonBlocks(ctx, lesson.LessonId)
+					if err != nil {
+						logs.PrintLog(ctx, "GetCourseBundle", fmt.Sprintf("%+v", err))
+						return nil, err
+					}
+					bundleLesson.Blocks = renderLessonBlocks(blocks)
+				}
+				bundle.Lessons = append(bundle.Lessons, bundleLesson)
+			}
+		}
+	}
+
+	bundle.Version, err = bundleVersion(bundle)
+	if err != nil {
+		logs.PrintLog(ctx, "GetCourseBundle", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+	return bundle, nil
+}
+
+// bundleVersion - хеш содержимого выгрузки без самой версии. Прогресс ученика в выгрузку
+// не входит, поэтому версия одинакова для всех учеников курса
+func bundleVersion(bundle *dto.CourseBundleDTO) (string, error) {
+	content, err := json.Marshal(struct {
+		Title       string
+		Description string
+		Lessons     []*dto.BundleLessonDTO
+	}{bundle.Title, bundle.Description, bundle.Lessons})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:16]), nil
+}
+
+// MarkLessonsCompleted - отметка уроков, пройденных без сети. Все уроки должны относиться к курсу,
+// иначе не отмечается ни один. Возвращает число отмеченных уроков без повторов
+func (uc *CourseUsecase) MarkLessonsCompleted(ctx context.Context, userProfile *usermodels.UserProfile, courseId int, lessonIds []int) (int, error) {
+	if len(lessonIds) > MaxOfflineLessons {
+		return 0, fmt.Errorf("too many lessons, max %d", MaxOfflineLessons)
+	}
+
+	course, err := uc.repo.GetCourseById(ctx, courseId)
+	if err != nil {
+		logs.PrintLog(ctx, "MarkLessonsCompleted", fmt.Sprintf("%+v", err))
+		return 0, err
+	}
+	allowed, err := uc.canViewCourse(ctx, userProfile, course)
+	if err != nil {
+		logs.PrintLog(ctx, "MarkLessonsCompleted", fmt.Sprintf("%+v", err))
+		return 0, err
+	}
+	if !allowed {
+		return 0, errors.New("course is not purchased")
+	}
+
+	unique := make([]int, 0, len(lessonIds))
+	seen := make(map[int]bool, len(lessonIds))
+	for _, lessonId := range lessonIds {
+		if seen[lessonId] {
+			continue
+		}
+		seen[lessonId] = true
+
+		lessonCourseId, _, err := uc.repo.GetLessonCourse(ctx, lessonId)
+		if err != nil {
+			return 0, err
+		}
+		if lessonCourseId != courseId {
+			return 0, errors.New("lesson not found")
+		}
+		unique = append(unique, lessonId)
+	}
+
+	for _, lessonId := range unique {
+		if err := uc.MarkLessonAsCompleted(ctx, userProfile.Id, lessonId); err != nil {
+			logs.PrintLog(ctx, "MarkLessonsCompleted", fmt.Sprintf("lesson %d: %+v", lessonId, err))
+			return 0, err
+		}
+	}
+	return len(unique), nil
+}
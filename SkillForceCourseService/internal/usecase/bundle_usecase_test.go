@@ -0,0 +1,120 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	coursemodels "skillForce/internal/models/course"
+	usermodels "skillForce/internal/models/user"
+	"skillForce/internal/usecase"
+	"skillForce/pkg/logs"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func expectCourseContent(ctx context.Context, mockRepo *usecase.MockCourseRepository, userId int, text string) {
+	mockRepo.EXPECT().GetCourseParts(ctx, 3).Return([]*coursemodels.CoursePart{{Id: 4, Title: "Part"}}, nil)
+	mockRepo.EXPECT().GetPartBuckets(ctx, 4).Return([]*coursemodels.LessonBucket{{Id: 5, Title: "Bucket"}}, nil)
+	mockRepo.EXPECT().GetBucketLessons(ctx, userId, 3, 5).Return([]*coursemodels.LessonPoint{
+		{LessonId: 10, Title: "Intro", Type: "text", IsDone: userId == 5},
+		{LessonId: 11, Title: "Video", Type: "video"},
+	}, nil)
+	mockRepo.EXPECT().GetLessonBlocks(ctx, 10).Return([]*coursemodels.LessonBlock{
+		{Type: "html", Text: text},
+		{Type: "quiz", Question: "2+2?", Options: []string{"3", "4"}, Answer: 1},
+	}, nil)
+}
+
+func TestGetCourseBundle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := usecase.NewMockCourseRepository(ctrl)
+	uc := usecase.NewCourseUsecase(mockRepo)
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+	course := &coursemodels.Course{Id: 3, CreatorId: 9, Title: "Go"}
+
+	mockRepo.EXPECT().GetCourseById(ctx, 3).Return(course, nil).Times(3)
+	mockRepo.EXPECT().IsUserPurchasedCourse(ctx, 5, 3).Return(true, nil)
+	expectCourseContent(ctx, mockRepo, 5, "<p>hello</p>")
+	bundle, err := uc.GetCourseBundle(ctx, &usermodels.UserProfile{Id: 5}, 3)
+	require.NoError(t, err)
+	require.Equal(t, "Go", bundle.Title)
+	require.Len(t, bundle.Lessons, 2)
+	require.Equal(t, "Part", bundle.Lessons[0].PartTitle)
+	require.Equal(t, "<p>hello</p>", bundle.Lessons[0].Blocks[0].Body)
+	require.Nil(t, bundle.Lessons[0].Blocks[1].Answer)
+	require.Empty(t, bundle.Lessons[1].Blocks)
+
+	// прогресс ученика не меняет версию, а правка урока меняет
+	expectCourseContent(ctx, mockRepo, 9, "<p>hello</p>")
+	authorBundle, err := uc.GetCourseBundle(ctx, &usermodels.UserProfile{Id: 9}, 3)
+	require.NoError(t, err)
+	require.Equal(t, bundle.Version, authorBundle.Version)
+
+	expectCourseContent(ctx, mockRepo, 9, "<p>edited</p>")
+	editedBundle, err := uc.GetCourseBundle(ctx, &usermodels.UserProfile{Id: 9}, 3)
+	require.NoError(t, err)
+	require.NotEqual(t, bundle.Version, editedBundle.Version)
+}
+
+func TestGetCourseBundleNotPurchased(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := usecase.NewMockCourseRepository(ctrl)
+	uc := usecase.NewCourseUsecase(mockRepo)
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	mockRepo.EXPECT().GetCourseById(ctx, 3).Return(&coursemodels.Course{Id: 3, CreatorId: 9}, nil).Times(2)
+	mockRepo.EXPECT().IsUserPurchasedCourse(ctx, 5, 3).Return(false, nil)
+
+	_, err := uc.GetCourseBundle(ctx, &usermodels.UserProfile{Id: 5}, 3)
+	require.EqualError(t, err, "course is not purchased")
+
+	_, err = uc.GetCourseBundle(ctx, nil, 3)
+	require.EqualError(t, err, "course is not purchased")
+}
+
+func TestMarkLessonsCompleted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := usecase.NewMockCourseRepository(ctrl)
+	uc := usecase.NewCourseUsecase(mockRepo)
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+	profile := &usermodels.UserProfile{Id: 5}
+
+	mockRepo.EXPECT().GetCourseById(ctx, 3).Return(&coursemodels.Course{Id: 3, CreatorId: 9}, nil)
+	mockRepo.EXPECT().IsUserPurchasedCourse(ctx, 5, 3).Return(true, nil)
+	mockRepo.EXPECT().GetLessonCourse(ctx, 10).Return(3, 9, nil)
+	mockRepo.EXPECT().GetLessonCourse(ctx, 11).Return(3, 9, nil)
+	mockRepo.EXPECT().MarkLessonCompleted(ctx, 5, 10).Return(nil)
+	mockRepo.EXPECT().MarkLessonCompleted(ctx, 5, 11).Return(nil)
+
+	marked, err := uc.MarkLessonsCompleted(ctx, profile, 3, []int{10, 11, 10})
+	require.NoError(t, err)
+	require.Equal(t, 2, marked)
+}
+
+func TestMarkLessonsCompletedRejectsForeignLesson(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := usecase.NewMockCourseRepository(ctrl)
+	uc := usecase.NewCourseUsecase(mockRepo)
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	mockRepo.EXPECT().GetCourseById(ctx, 3).Return(&coursemodels.Course{Id: 3, CreatorId: 9}, nil)
+	mockRepo.EXPECT().IsUserPurchasedCourse(ctx, 5, 3).Return(true, nil)
+	mockRepo.EXPECT().GetLessonCourse(ctx, 10).Return(3, 9, nil)
+	mockRepo.EXPECT().GetLessonCourse(ctx, 20).Return(4, 9, nil)
+
+	marked, err := uc.MarkLessonsCompleted(ctx, &usermodels.UserProfile{Id: 5}, 3, []int{10, 20})
+	require.EqualError(t, err, "lesson not found")
+	require.Zero(t, marked)
+
+	_, err = uc.MarkLessonsCompleted(ctx, &usermodels.UserProfile{Id: 5}, 3, make([]int, usecase.MaxOfflineLessons+1))
+	require.EqualError(t, err, "too many lessons, max 500")
+}
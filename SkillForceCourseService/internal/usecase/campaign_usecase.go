@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	coursemodels "skillForce/internal/models/course"
+	"skillForce/pkg/logs"
+	"time"
+)
+
+// SendCampaignMails - письма всех кампаний вовлечения, которым пришло время. Кампании идут по важности,
+// поэтому упёршийся в ограничение частоты пользователь получит сначала более важные письма
+func (uc *CourseUsecase) SendCampaignMails(ctx context.Context, conf coursemodels.CampaignConfig) error {
+	for _, campaign := range coursemodels.Campaigns {
+		mails, err := uc.repo.GetCampaignMails(ctx, campaign, conf)
+		if err != nil {
+			logs.PrintLog(ctx, "SendCampaignMails", fmt.Sprintf("%s: %+v", campaign, err))
+			return err
+		}
+
+		sent := 0
+		for _, mail := range mails {
+			ok, err := uc.repo.SendCampaignMail(ctx, mail, conf)
+			if err != nil {
+				logs.PrintLog(ctx, "SendCampaignMails", fmt.Sprintf("%s to user %d: %+v", campaign, mail.UserId, err))
+				return err
+			}
+			if ok {
+				sent++
+			}
+		}
+		if len(mails) > 0 {
+			logs.PrintLog(ctx, "SendCampaignMails", fmt.Sprintf("%s: %d of %d mails sent", campaign, sent, len(mails)))
+		}
+	}
+	return nil
+}
+
+// RunCampaigns - периодический запуск SendCampaignMails до отмены контекста. Отправленные письма
+// хранятся в базе, поэтому после перезапуска сервиса письма не повторяются
+func (uc *CourseUsecase) RunCampaigns(ctx context.Context, conf coursemodels.CampaignConfig) {
+	ticker := time.NewTicker(conf.Interval)
+	defer ticker.Stop()
+
+	for {
+		logs.RunJob(ctx, "SendCampaignMails", func(ctx context.Context) {
+			_ = uc.SendCampaignMails(ctx, conf)
+		})
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	coursemodels "skillForce/internal/models/course"
+	"skillForce/internal/usecase"
+	"skillForce/pkg/logs"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+var campaignConf = coursemodels.CampaignConfig{
+	Interval:               time.Minute,
+	BatchSize:              10,
+	InactivityAfter:        7 * 24 * time.Hour,
+	AbandonedCheckoutAfter: 24 * time.Hour,
+	MaxPerWeek:             2,
+	MinInterval:            24 * time.Hour,
+}
+
+func TestSendCampaignMails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := usecase.NewMockCourseRepository(ctrl)
+	uc := usecase.NewCourseUsecase(mockRepo)
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	completed := &coursemodels.CampaignMail{Campaign: coursemodels.CampaignCourseCompleted, UserId: 1, CourseId: 2}
+	middle := &coursemodels.CampaignMail{Campaign: coursemodels.CampaignMidCourse, UserId: 1, CourseId: 3}
+	// кампании запрашиваются в порядке важности
+	gomock.InOrder(
+		mockRepo.EXPECT().GetCampaignMails(ctx, coursemodels.CampaignCourseCompleted, campaignConf).Return([]*coursemodels.CampaignMail{completed}, nil),
+		mockRepo.EXPECT().SendCampaignMail(ctx, completed, campaignConf).Return(true, nil),
+		mockRepo.EXPECT().GetCampaignMails(ctx, coursemodels.CampaignWelcome, campaignConf).Return(nil, nil),
+		mockRepo.EXPECT().GetCampaignMails(ctx, coursemodels.CampaignAbandonedCheckout, campaignConf).Return(nil, nil),
+		mockRepo.EXPECT().GetCampaignMails(ctx, coursemodels.CampaignMidCourse, campaignConf).Return([]*coursemodels.CampaignMail{middle}, nil),
+		// пользователь упёрся в ограничение частоты
+		mockRepo.EXPECT().SendCampaignMail(ctx, middle, campaignConf).Return(false, nil),
+		mockRepo.EXPECT().GetCampaignMails(ctx, coursemodels.CampaignInactivity, campaignConf).Return(nil, nil),
+	)
+
+	require.NoError(t, uc.SendCampaignMails(ctx, campaignConf))
+}
+
+func TestSendCampaignMails_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := usecase.NewMockCourseRepository(ctrl)
+	uc := usecase.NewCourseUsecase(mockRepo)
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	mockRepo.EXPECT().GetCampaignMails(ctx, coursemodels.CampaignCourseCompleted, campaignConf).Return(nil, errors.New("db error"))
+
+	require.Error(t, uc.SendCampaignMails(ctx, campaignConf))
+}
@@ -6,6 +6,7 @@ import (
 	coursemodels "skillForce/internal/models/course"
 	"skillForce/internal/models/dto"
 	usermodels "skillForce/internal/models/user"
+	"time"
 )
 
 type CourseRepository interface {
@@ -13,6 +14,10 @@ type CourseRepository interface {
 	GetPurchasedBucketCourses(ctx context.Context, userId int) ([]*coursemodels.Course, error)
 	GetCompletedBucketCourses(ctx context.Context, userId int) ([]*coursemodels.Course, error)
 	SearchCoursesByTitle(ctx context.Context, keywords string) ([]*coursemodels.Course, error)
+	GetAuthoredCourses(ctx context.Context, userId int) ([]*coursemodels.Course, error)
+	GetUserSertificates(ctx context.Context, userId int) (map[int]string, error)
+	GetUserRatingPositions(ctx context.Context, userId int) ([]*dto.RatingPositionDTO, error)
+	GetAuthorStats(ctx context.Context, userId int) (*dto.AuthorStatsDTO, error)
 	GetCourseById(ctx context.Context, courseId int) (*coursemodels.Course, error)
 	GetCourseParts(ctx context.Context, courseId int) ([]*coursemodels.CoursePart, error)
 	GetPartBuckets(ctx context.Context, partId int) ([]*coursemodels.LessonBucket, error)
@@ -20,7 +25,8 @@ type CourseRepository interface {
 	GetLessonById(ctx context.Context, lessonId int) (*coursemodels.LessonPoint, error)
 	GetBucketByLessonId(ctx context.Context, lessonId int) (*coursemodels.LessonBucket, error)
 	GetLessonVideo(ctx context.Context, currentLessonId int) ([]string, error)
-	GetLessonBlocks(ctx context.Context, currentLessonId int) ([]string, error)
+	GetLessonBlocks(ctx context.Context, currentLessonId int) ([]*coursemodels.LessonBlock, error)
+	GetLessonFile(ctx context.Context, objectName string) (*coursemodels.LessonFile, error)
 	GetLessonTest(ctx context.Context, currentLessonId int, user_id int) (*dto.Test, error)
 	AnswerQuiz(ctx context.Context, question_id int, answer_id int, user_id int, course_id int) (*dto.QuizResult, error)
 	GetQuestionTestLesson(ctx context.Context, currentLessonId int, user_id int) (*dto.QuestionTest, error)
@@ -32,7 +38,6 @@ type CourseRepository interface {
 	GetLastLessonHeader(ctx context.Context, userId int, courseId int) (*dto.LessonDtoHeader, int, string, bool, error)
 	GetLessonHeaderByLessonId(ctx context.Context, userId int, currentLessonId int) (*dto.LessonDtoHeader, error)
 	GetLessonFooters(ctx context.Context, currentLessonId int) ([]int, error)
-	IsMiddle(ctx context.Context, userId int, courseId int) (bool, error)
 
 	MarkLessonCompleted(ctx context.Context, userId int, lessonId int) error
 	MarkLessonAsNotCompleted(ctx context.Context, userId int, lessonId int) error
@@ -60,8 +65,25 @@ type CourseRepository interface {
 	GetFavouriteCourses(ctx context.Context, userId int) ([]*coursemodels.Course, error)
 	GetCoursesFavouriteStatus(ctx context.Context, bucketCourses []*coursemodels.Course, userId int) (map[int]bool, error)
 
+	SetCourseHidden(ctx context.Context, courseId int, hidden bool, reason string) error
+
 	UploadFileToMinIO(ctx context.Context, file multipart.File, fileHeader *multipart.FileHeader) (string, error)
 
-	SendWelcomeCourseMail(ctx context.Context, user *usermodels.User, course *coursemodels.Course) error
-	IsWelcomeCourseMailSended(ctx context.Context, userId int, courseId int) (bool, error)
+	GetCampaignMails(ctx context.Context, campaign string, conf coursemodels.CampaignConfig) ([]*coursemodels.CampaignMail, error)
+	SendCampaignMail(ctx context.Context, mail *coursemodels.CampaignMail, conf coursemodels.CampaignConfig) (bool, error)
+
+	ScheduleJob(ctx context.Context, kind string, key string, runAt time.Time) error
+	GetDigestUser(ctx context.Context, userId int) (*coursemodels.DigestUser, error)
+	GetUsersWithoutDigest(ctx context.Context, limit int) ([]*coursemodels.DigestUser, error)
+	GetWeekLessons(ctx context.Context, userId int, from time.Time, to time.Time) ([]*coursemodels.DigestCourse, error)
+	GetUnfinishedCourses(ctx context.Context, userId int, limit int) ([]*coursemodels.DigestCourse, error)
+	GetNewCoursesByFavouriteTags(ctx context.Context, userId int, since time.Time, limit int) ([]*coursemodels.DigestCourse, error)
+	GetLastDigestRating(ctx context.Context, userId int) (map[int]int, error)
+	SendWeeklyDigest(ctx context.Context, digest *coursemodels.WeeklyDigest) (bool, error)
+
+	GetUserCourseData(ctx context.Context, userId int) (*dto.UserCourseDataDTO, error)
+	GetAccountsToPurge(ctx context.Context) ([]int, error)
+	PurgeUserCourseData(ctx context.Context, userId int) error
+
+	GetLessonCourse(ctx context.Context, lessonId int) (int, int, error)
 }
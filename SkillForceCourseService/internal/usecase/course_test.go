@@ -137,7 +137,7 @@ func TestMockCourseRepository(t *testing.T) {
 	})
 
 	t.Run("Test GetLessonBlocks", func(t *testing.T) {
-		expectedBlocks := []string{"block1", "block2"}
+		expectedBlocks := []*course.LessonBlock{{Type: course.BlockHTML, Text: "block1"}, {Type: course.BlockHTML, Text: "block2"}}
 		mockRepo.EXPECT().GetLessonBlocks(ctx, 1).Return(expectedBlocks, nil)
 
 		blocks, err := mockRepo.GetLessonBlocks(ctx, 1)
@@ -351,15 +351,7 @@ func (uc *CourseUsecase) GetCourseLesson(ctx context.Context, userId int, course
 			return nil, err
 		}
 		var LessonBody dto.LessonDtoBody
-		for _, block := range blocks {
-			block = sanitize.Sanitize(block)
-			logs.PrintLog(ctx, "GetCourseLesson", fmt.Sprintf("block: %+v", block))
-			LessonBody.Blocks = append(LessonBody.Blocks, struct {
-				Body string `json:"body"`
-			}{
-				Body: block,
-			})
-		}
+		LessonBody.Blocks = renderLessonBlocks(blocks)
 
 		footers, err := uc.repo.GetLessonFooters(ctx, currentLessonId)
 
@@ -378,26 +370,6 @@ func (uc *CourseUsecase) GetCourseLesson(ctx context.Context, userId int, course
 		LessonBody.Footer.PreviousLessonId = footers[0]
 		lessonDto.LessonBody = LessonBody
 
-		/*
-			first, err := uc.repo.IsWelcomeCourseMailSended(ctx, userId, courseId)
-			if first {
-				logs.PrintLog(ctx, "GetCourseLesson", fmt.Sprintf("first lesson of the course of the user %+v", userId))
-				user, err := uc.repo.GetUserById(ctx, userId)
-				if err != nil {
-					logs.PrintLog(ctx, "GetCourseLesson", fmt.Sprintf("can't get user by id: %+v", err))
-					return nil, err
-				}
-				course, err := uc.repo.GetCourseById(ctx, courseId)
-				if err != nil {
-					logs.PrintLog(ctx, "GetCourseLesson", fmt.Sprintf("can't get course by id: %+v", err))
-					return nil, err
-				}
-				if !user.HideEmail {
-					go uc.repo.SendWelcomeCourseMail(ctx, user, course)
-				}
-			}
-		*/
-
 		return lessonDto, err
 	}
 
@@ -412,11 +384,7 @@ func (uc *CourseUsecase) GetCourseLesson(ctx context.Context, userId int, course
 		for _, block := range blocks {
 			//block = sanitize.Sanitize(block)
 			logs.PrintLog(ctx, "GetCourseLesson", fmt.Sprintf("block: %+v", block))
-			LessonBody.Blocks = append(LessonBody.Blocks, struct {
-				Body string `json:"body"`
-			}{
-				Body: block,
-			})
+			LessonBody.Blocks = append(LessonBody.Blocks, dto.LessonBlockDTO{Body: block})
 		}
 
 		footers, err := uc.repo.GetLessonFooters(ctx, currentLessonId)
@@ -436,37 +404,13 @@ func (uc *CourseUsecase) GetCourseLesson(ctx context.Context, userId int, course
 		LessonBody.Footer.PreviousLessonId = footers[0]
 		lessonDto.LessonBody = LessonBody
 
-		/*
-			first, err := uc.repo.IsWelcomeCourseMailSended(ctx, userId, courseId)
-			if first {
-				logs.PrintLog(ctx, "GetCourseLesson", fmt.Sprintf("first lesson of the course of the user %+v", userId))
-				user, err := uc.repo.GetUserById(ctx, userId)
-				if err != nil {
-					logs.PrintLog(ctx, "GetCourseLesson", fmt.Sprintf("can't get user by id: %+v", err))
-					return nil, err
-				}
-				course, err := uc.repo.GetCourseById(ctx, courseId)
-				if err != nil {
-					logs.PrintLog(ctx, "GetCourseLesson", fmt.Sprintf("can't get course by id: %+v", err))
-					return nil, err
-				}
-				if !user.HideEmail {
-					go uc.repo.SendWelcomeCourseMail(ctx, user, course)
-				}
-			}
-		*/
-
 		return lessonDto, err
 
 	}
 
 	if lessonType == "quiz" {
 		var LessonBody dto.LessonDtoBody
-		LessonBody.Blocks = append(LessonBody.Blocks, struct {
-			Body string `json:"body"`
-		}{
-			Body: "quiz",
-		})
+		LessonBody.Blocks = append(LessonBody.Blocks, dto.LessonBlockDTO{Body: "quiz"})
 
 		footers, err := uc.repo.GetLessonFooters(ctx, currentLessonId)
 
@@ -490,11 +434,7 @@ func (uc *CourseUsecase) GetCourseLesson(ctx context.Context, userId int, course
 
 	if lessonType == "question" {
 		var LessonBody dto.LessonDtoBody
-		LessonBody.Blocks = append(LessonBody.Blocks, struct {
-			Body string `json:"body"`
-		}{
-			Body: "question",
-		})
+		LessonBody.Blocks = append(LessonBody.Blocks, dto.LessonBlockDTO{Body: "question"})
 
 		footers, err := uc.repo.GetLessonFooters(ctx, currentLessonId)
 
@@ -534,15 +474,7 @@ func (uc *CourseUsecase) GetNextLesson(ctx context.Context, userId int, courseId
 		}
 
 		var LessonBody dto.LessonDtoBody
-		for _, block := range blocks {
-			block = sanitize.Sanitize(block)
-			logs.PrintLog(ctx, "GetCourseLesson", fmt.Sprintf("block: %+v", block))
-			LessonBody.Blocks = append(LessonBody.Blocks, struct {
-				Body string `json:"body"`
-			}{
-				Body: block,
-			})
-		}
+		LessonBody.Blocks = renderLessonBlocks(blocks)
 
 		footers, err := uc.repo.GetLessonFooters(ctx, lessonId)
 		if err != nil {
@@ -569,16 +501,6 @@ func (uc *CourseUsecase) GetNextLesson(ctx context.Context, userId int, courseId
 			LessonHeader: *lessonHeader,
 			LessonBody:   LessonBody,
 		}
-		/*
-			user, _ := uc.repo.GetUserById(ctx, userId)
-			if user.HideEmail {
-				isMiddle, _ := uc.repo.IsMiddle(ctx, userId, courseId)
-				user, _ := uc.repo.GetUserById(ctx, userId)
-				if isMiddle {
-					go uc.repo.SendMiddleCourseMail(ctx, user, courseId)
-				}
-			}
-		*/
 		return lessonDto, err
 	}
 
@@ -593,11 +515,7 @@ func (uc *CourseUsecase) GetNextLesson(ctx context.Context, userId int, courseId
 		for _, block := range blocks {
 			block = sanitize.Sanitize(block)
 			logs.PrintLog(ctx, "GetCourseLesson", fmt.Sprintf("block: %+v", block))
-			LessonBody.Blocks = append(LessonBody.Blocks, struct {
-				Body string `json:"body"`
-			}{
-				Body: block,
-			})
+			LessonBody.Blocks = append(LessonBody.Blocks, dto.LessonBlockDTO{Body: block})
 		}
 
 		footers, err := uc.repo.GetLessonFooters(ctx, lessonId)
@@ -625,27 +543,13 @@ func (uc *CourseUsecase) GetNextLesson(ctx context.Context, userId int, courseId
 			LessonHeader: *lessonHeader,
 			LessonBody:   LessonBody,
 		}
-		/*
-			user, _ := uc.repo.GetUserById(ctx, userId)
-			if user.HideEmail {
-				isMiddle, _ := uc.repo.IsMiddle(ctx, userId, courseId)
-				user, _ := uc.repo.GetUserById(ctx, userId)
-				if isMiddle {
-					go uc.repo.SendMiddleCourseMail(ctx, user, courseId)
-				}
-			}
-		*/
 		return lessonDto, err
 	}
 
 	if lesson.Type == "question" {
 
 		var LessonBody dto.LessonDtoBody
-		LessonBody.Blocks = append(LessonBody.Blocks, struct {
-			Body string `json:"body"`
-		}{
-			Body: "question",
-		})
+		LessonBody.Blocks = append(LessonBody.Blocks, dto.LessonBlockDTO{Body: "question"})
 
 		footers, err := uc.repo.GetLessonFooters(ctx, lessonId)
 		if err != nil {
@@ -678,27 +582,13 @@ func (uc *CourseUsecase) GetNextLesson(ctx context.Context, userId int, courseId
 			LessonHeader: *lessonHeader,
 			LessonBody:   LessonBody,
 		}
-		/*
-			user, _ := uc.repo.GetUserById(ctx, userId)
-			if user.HideEmail {
-				isMiddle, _ := uc.repo.IsMiddle(ctx, userId, courseId)
-				user, _ := uc.repo.GetUserById(ctx, userId)
-				if isMiddle {
-					go uc.repo.SendMiddleCourseMail(ctx, user, courseId)
-				}
-			}
-		*/
 		return lessonDto, err
 	}
 
 	if lesson.Type == "quiz" {
 
 		var LessonBody dto.LessonDtoBody
-		LessonBody.Blocks = append(LessonBody.Blocks, struct {
-			Body string `json:"body"`
-		}{
-			Body: "quiz",
-		})
+		LessonBody.Blocks = append(LessonBody.Blocks, dto.LessonBlockDTO{Body: "quiz"})
 
 		footers, err := uc.repo.GetLessonFooters(ctx, lessonId)
 		if err != nil {
@@ -731,16 +621,6 @@ func (uc *CourseUsecase) GetNextLesson(ctx context.Context, userId int, courseId
 			LessonHeader: *lessonHeader,
 			LessonBody:   LessonBody,
 		}
-		/*
-			user, _ := uc.repo.GetUserById(ctx, userId)
-			if user.HideEmail {
-				isMiddle, _ := uc.repo.IsMiddle(ctx, userId, courseId)
-				user, _ := uc.repo.GetUserById(ctx, userId)
-				if isMiddle {
-					go uc.repo.SendMiddleCourseMail(ctx, user, courseId)
-				}
-			}
-		*/
 		return lessonDto, err
 	}
 
@@ -937,6 +817,14 @@ func (uc *CourseUsecase) CreateCourse(ctx context.Context, courseDto *dto.Course
 					Type:  lesson.Type,
 					Title: lesson.Title,
 				}
+				// блоки проверяются до создания курса, чтобы не оставить его недособранным
+				if lesson.Type == "text" && len(lesson.Blocks) > 0 {
+					blocks, err := uc.prepareLessonBlocks(ctx, userProfile.Id, lesson.Blocks)
+					if err != nil {
+						return err
+					}
+					courseLesson.Blocks = blocks
+				}
 				courseBucket.Lessons = append(courseBucket.Lessons, &courseLesson)
 			}
 		}
@@ -1184,3 +1072,104 @@ func (uc *CourseUsecase) SearchCoursesByTitle(ctx context.Context, userProfile *
 
 	return resultBucketCourses, nil
 }
+
+// publicCoursesToDTO - курсы для публичного профиля: с рейтингом, тегами и покупками, без статусов текущего пользователя
+func (uc *CourseUsecase) publicCoursesToDTO(ctx context.Context, courses []*coursemodels.Course) ([]*dto.CourseDTO, error) {
+	coursesRatings, err := uc.repo.GetCoursesRaitings(ctx, courses)
+	if err != nil {
+		logs.PrintLog(ctx, "publicCoursesToDTO", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+
+	courseTags, err := uc.repo.GetCoursesTags(ctx, courses)
+	if err != nil {
+		logs.PrintLog(ctx, "publicCoursesToDTO", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+
+	coursePurchases, err := uc.repo.GetCoursesPurchases(ctx, courses)
+	if err != nil {
+		logs.PrintLog(ctx, "publicCoursesToDTO", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+
+	result := make([]*dto.CourseDTO, 0, len(courses))
+	for _, course := range courses {
+		tags, ok := courseTags[course.Id]
+		if !ok {
+			tags = []string{}
+		}
+		result = append(result, &dto.CourseDTO{
+			Id:              course.Id,
+			CreatorId:       course.CreatorId,
+			Title:           course.Title,
+			Description:     sanitize.Sanitize(course.Description),
+			ScrImage:        course.ScrImage,
+			Price:           course.Price,
+			TimeToPass:      course.TimeToPass,
+			Rating:          coursesRatings[course.Id],
+			Tags:            tags,
+			PurchasesAmount: coursePurchases[course.Id],
+		})
+	}
+	return result, nil
+}
+
+// GetUserCoursesSummary - курсовая часть публичного профиля пользователя:
+// авторские курсы, пройденные курсы с сертификатами, места в рейтингах и статистика автора
+func (uc *CourseUsecase) GetUserCoursesSummary(ctx context.Context, userId int) (*dto.UserCoursesSummaryDTO, error) {
+	authoredCourses, err := uc.repo.GetAuthoredCourses(ctx, userId)
+	if err != nil {
+		logs.PrintLog(ctx, "GetUserCoursesSummary", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+	authoredCoursesDTO, err := uc.publicCoursesToDTO(ctx, authoredCourses)
+	if err != nil {
+		return nil, err
+	}
+
+	completedCourses, err := uc.repo.GetCompletedBucketCourses(ctx, userId)
+	if err != nil {
+		logs.PrintLog(ctx, "GetUserCoursesSummary", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+	completedCoursesDTO, err := uc.publicCoursesToDTO(ctx, completedCourses)
+	if err != nil {
+		return nil, err
+	}
+
+	sertificates, err := uc.repo.GetUserSertificates(ctx, userId)
+	if err != nil {
+		logs.PrintLog(ctx, "GetUserCoursesSummary", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+
+	summary := &dto.UserCoursesSummaryDTO{
+		AuthoredCourses:  authoredCoursesDTO,
+		CompletedCourses: make([]*dto.CompletedCourseDTO, 0, len(completedCoursesDTO)),
+	}
+	for _, course := range completedCoursesDTO {
+		course.IsCompleted = true
+		summary.CompletedCourses = append(summary.CompletedCourses, &dto.CompletedCourseDTO{
+			Course:         course,
+			SertificateUrl: sertificates[course.Id],
+		})
+	}
+
+	summary.RatingPositions, err = uc.repo.GetUserRatingPositions(ctx, userId)
+	if err != nil {
+		logs.PrintLog(ctx, "GetUserCoursesSummary", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+
+	if len(authoredCourses) > 0 {
+		summary.AuthorStats, err = uc.repo.GetAuthorStats(ctx, userId)
+		if err != nil {
+			logs.PrintLog(ctx, "GetUserCoursesSummary", fmt.Sprintf("%+v", err))
+			return nil, err
+		}
+	}
+
+	logs.PrintLog(ctx, "GetUserCoursesSummary", fmt.Sprintf("get courses summary of user %d", userId))
+	return summary, nil
+}
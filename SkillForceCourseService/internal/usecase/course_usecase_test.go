@@ -0,0 +1,74 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	course "skillForce/internal/models/course"
+	"skillForce/internal/models/dto"
+	"skillForce/internal/usecase"
+	"skillForce/pkg/logs"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetUserCoursesSummary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := usecase.NewMockCourseRepository(ctrl)
+	uc := usecase.NewCourseUsecase(mockRepo)
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	authored := []*course.Course{{Id: 1, CreatorId: 7, Title: "Go"}}
+	completed := []*course.Course{{Id: 2, CreatorId: 3, Title: "SQL"}, {Id: 4, CreatorId: 3, Title: "Docker"}}
+
+	mockRepo.EXPECT().GetAuthoredCourses(ctx, 7).Return(authored, nil)
+	mockRepo.EXPECT().GetCompletedBucketCourses(ctx, 7).Return(completed, nil)
+	for _, courses := range [][]*course.Course{authored, completed} {
+		mockRepo.EXPECT().GetCoursesRaitings(ctx, courses).Return(map[int]float32{1: 4.5}, nil)
+		mockRepo.EXPECT().GetCoursesTags(ctx, courses).Return(map[int][]string{}, nil)
+		mockRepo.EXPECT().GetCoursesPurchases(ctx, courses).Return(map[int]int{1: 10}, nil)
+	}
+	mockRepo.EXPECT().GetUserSertificates(ctx, 7).Return(map[int]string{2: "http://minio/2.pdf"}, nil)
+	mockRepo.EXPECT().GetUserRatingPositions(ctx, 7).Return([]*dto.RatingPositionDTO{{CourseId: 2, Position: 1, Score: 20}}, nil)
+	mockRepo.EXPECT().GetAuthorStats(ctx, 7).Return(&dto.AuthorStatsDTO{CoursesAmount: 1, StudentsAmount: 10, Rating: 4.5}, nil)
+
+	summary, err := uc.GetUserCoursesSummary(ctx, 7)
+	require.NoError(t, err)
+
+	require.Len(t, summary.AuthoredCourses, 1)
+	require.Equal(t, float32(4.5), summary.AuthoredCourses[0].Rating)
+	require.Equal(t, 10, summary.AuthoredCourses[0].PurchasesAmount)
+
+	require.Len(t, summary.CompletedCourses, 2)
+	require.Equal(t, "http://minio/2.pdf", summary.CompletedCourses[0].SertificateUrl)
+	require.True(t, summary.CompletedCourses[0].Course.IsCompleted)
+	require.Empty(t, summary.CompletedCourses[1].SertificateUrl)
+
+	require.Len(t, summary.RatingPositions, 1)
+	require.Equal(t, 10, summary.AuthorStats.StudentsAmount)
+}
+
+func TestGetUserCoursesSummaryNotAuthor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := usecase.NewMockCourseRepository(ctrl)
+	uc := usecase.NewCourseUsecase(mockRepo)
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	mockRepo.EXPECT().GetAuthoredCourses(ctx, 5).Return(nil, nil)
+	mockRepo.EXPECT().GetCompletedBucketCourses(ctx, 5).Return(nil, nil)
+	mockRepo.EXPECT().GetCoursesRaitings(ctx, gomock.Any()).Return(map[int]float32{}, nil).Times(2)
+	mockRepo.EXPECT().GetCoursesTags(ctx, gomock.Any()).Return(map[int][]string{}, nil).Times(2)
+	mockRepo.EXPECT().GetCoursesPurchases(ctx, gomock.Any()).Return(map[int]int{}, nil).Times(2)
+	mockRepo.EXPECT().GetUserSertificates(ctx, 5).Return(map[int]string{}, nil)
+	mockRepo.EXPECT().GetUserRatingPositions(ctx, 5).Return([]*dto.RatingPositionDTO{}, nil)
+
+	summary, err := uc.GetUserCoursesSummary(ctx, 5)
+	require.NoError(t, err)
+	require.Empty(t, summary.AuthoredCourses)
+	require.Nil(t, summary.AuthorStats)
+}
@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	coursemodels "skillForce/internal/models/course"
+	"skillForce/pkg/logs"
+	"skillForce/pkg/scheduler"
+	"strconv"
+	"time"
+)
+
+// StartWeeklyDigests - обработчики задач еженедельной сводки и задача планирования сводок.
+// Задача планирования одна на все экземпляры сервиса: повторное добавление её не меняет
+func (uc *CourseUsecase) StartWeeklyDigests(ctx context.Context, jobs *scheduler.Scheduler, conf coursemodels.DigestConfig) error {
+	jobs.Handle(coursemodels.JobPlanWeeklyDigests, func(ctx context.Context, job *scheduler.Job) (time.Time, error) {
+		if err := uc.PlanWeeklyDigests(ctx, conf); err != nil {
+			return time.Time{}, err
+		}
+		return time.Now().Add(conf.PlanInterval), nil
+	})
+	jobs.Handle(coursemodels.JobWeeklyDigest, func(ctx context.Context, job *scheduler.Job) (time.Time, error) {
+		userId, err := strconv.Atoi(job.Key)
+		if err != nil {
+			// задачу не выполнить, она удаляется
+			logs.PrintLog(ctx, "WeeklyDigest", fmt.Sprintf("invalid job key %q", job.Key))
+			return time.Time{}, nil
+		}
+		return uc.SendWeeklyDigest(ctx, userId, job.RunAt, conf)
+	})
+	return uc.repo.ScheduleJob(ctx, coursemodels.JobPlanWeeklyDigests, "", time.Now())
+}
+
+// PlanWeeklyDigests - сводки пользователям, которым они ещё не запланированы: новым, снова подписавшимся
+// и отменившим удаление аккаунта. Сводка планируется на ближайшее время сводки в часовом поясе пользователя
+func (uc *CourseUsecase) PlanWeeklyDigests(ctx context.Context, conf coursemodels.DigestConfig) error {
+	if conf.BatchSize <= 0 {
+		return errors.New("invalid batch size")
+	}
+
+	planned := 0
+	for {
+		users, err := uc.repo.GetUsersWithoutDigest(ctx, conf.BatchSize)
+		if err != nil {
+			logs.PrintLog(ctx, "PlanWeeklyDigests", fmt.Sprintf("%+v", err))
+			return err
+		}
+
+		now := time.Now()
+		for _, user := range users {
+			at := coursemodels.NextDigestAt(now, coursemodels.LoadTimezone(user.Timezone), conf.Weekday, conf.Hour)
+			if err := uc.repo.ScheduleJob(ctx, coursemodels.JobWeeklyDigest, strconv.Itoa(user.Id), at); err != nil {
+				logs.PrintLog(ctx, "PlanWeeklyDigests", fmt.Sprintf("user %d: %+v", user.Id, err))
+				return err
+			}
+		}
+		planned += len(users)
+
+		if len(users) < conf.BatchSize {
+			if planned > 0 {
+				logs.PrintLog(ctx, "PlanWeeklyDigests", fmt.Sprintf("%d digests planned", planned))
+			}
+			return nil
+		}
+	}
+}
+
+// SendWeeklyDigest - сводка пользователю за неделю до at. Возвращает время следующей сводки,
+// нулевое - сводки пользователю больше не нужны: он удалён, отписался или удаляет аккаунт
+func (uc *CourseUsecase) SendWeeklyDigest(ctx context.Context, userId int, at time.Time, conf coursemodels.DigestConfig) (time.Time, error) {
+	user, err := uc.repo.GetDigestUser(ctx, userId)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if user == nil || !user.Subscribed || user.Deleting {
+		// задача удаляется, после подписки или отмены удаления PlanWeeklyDigests запланирует её снова
+		logs.PrintLog(ctx, "SendWeeklyDigest", fmt.Sprintf("digests for user %d stopped", userId))
+		return time.Time{}, nil
+	}
+
+	// часовой пояс мог поменяться: следующая сводка считается в текущем
+	loc := coursemodels.LoadTimezone(user.Timezone)
+	next := coursemodels.NextDigestAt(time.Now(), loc, conf.Weekday, conf.Hour)
+
+	if late := time.Since(at); late > conf.MaxLateness {
+		logs.PrintLog(ctx, "SendWeeklyDigest", fmt.Sprintf("skip digest for user %d: %s late", userId, late.Round(time.Minute)))
+		return next, nil
+	}
+
+	digest, err := uc.buildWeeklyDigest(ctx, user, at, loc, conf)
+	if err != nil {
+		logs.PrintLog(ctx, "SendWeeklyDigest", fmt.Sprintf("user %d: %+v", userId, err))
+		return time.Time{}, err
+	}
+	if digest.IsEmpty() {
+		logs.PrintLog(ctx, "SendWeeklyDigest", fmt.Sprintf("skip empty digest for user %d", userId))
+		return next, nil
+	}
+
+	if _, err := uc.repo.SendWeeklyDigest(ctx, digest); err != nil {
+		return time.Time{}, err
+	}
+	return next, nil
+}
+
+// buildWeeklyDigest - уроки за неделю, незаконченные курсы с процентом прохождения, места в рейтингах
+// с изменением с прошлой сводки и новые курсы с тегами избранных
+func (uc *CourseUsecase) buildWeeklyDigest(ctx context.Context, user *coursemodels.DigestUser, at time.Time, loc *time.Location, conf coursemodels.DigestConfig) (*coursemodels.WeeklyDigest, error) {
+	start, end, week := coursemodels.DigestPeriod(at, loc)
+	digest := &coursemodels.WeeklyDigest{User: user, Week: week, PeriodStart: start, PeriodEnd: end}
+
+	weekLessons, err := uc.repo.GetWeekLessons(ctx, user.Id, start, end)
+	if err != nil {
+		return nil, err
+	}
+	lessons := make(map[int]int, len(weekLessons))
+	for _, course := range weekLessons {
+		digest.Lessons += course.Lessons
+		lessons[course.CourseId] = course.Lessons
+	}
+
+	digest.Courses, err = uc.repo.GetUnfinishedCourses(ctx, user.Id, conf.MaxCourses)
+	if err != nil {
+		return nil, err
+	}
+	for _, course := range digest.Courses {
+		stats, err := uc.repo.GetStatistic(ctx, user.Id, course.CourseId)
+		if err != nil {
+			return nil, err
+		}
+		course.Progress = stats.Percentage
+		course.Lessons = lessons[course.CourseId]
+	}
+
+	positions, err := uc.repo.GetUserRatingPositions(ctx, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := uc.repo.GetLastDigestRating(ctx, user.Id)
+	if err != nil {
+		return nil, err
+	}
+	for _, position := range positions[:min(len(positions), conf.MaxCourses)] {
+		rating := &coursemodels.DigestRating{CourseId: position.CourseId, CourseName: position.CourseTitle, Position: position.Position}
+		if last, ok := previous[position.CourseId]; ok {
+			rating.Change = last - position.Position
+		}
+		digest.Ratings = append(digest.Ratings, rating)
+	}
+
+	digest.NewCourses, err = uc.repo.GetNewCoursesByFavouriteTags(ctx, user.Id, start, conf.MaxCourses)
+	if err != nil {
+		return nil, err
+	}
+	return digest, nil
+}
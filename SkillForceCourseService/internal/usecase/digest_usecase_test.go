@@ -0,0 +1,213 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	coursemodels "skillForce/internal/models/course"
+	"skillForce/internal/models/dto"
+	"skillForce/internal/usecase"
+	"skillForce/pkg/logs"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+var digestConf = coursemodels.DigestConfig{
+	Weekday:      time.Monday,
+	Hour:         9,
+	PlanInterval: time.Hour,
+	BatchSize:    2,
+	MaxLateness:  12 * time.Hour,
+	MaxCourses:   5,
+}
+
+func TestNextDigestAt(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	moscow := coursemodels.LoadTimezone("Europe/Moscow")
+
+	tests := []struct {
+		name string
+		now  time.Time
+		loc  *time.Location
+		want time.Time
+	}{
+		{"later this week", time.Date(2026, 10, 15, 12, 0, 0, 0, moscow), moscow, time.Date(2026, 10, 19, 9, 0, 0, 0, moscow)},
+		{"earlier today", time.Date(2026, 10, 19, 8, 59, 0, 0, moscow), moscow, time.Date(2026, 10, 19, 9, 0, 0, 0, moscow)},
+		{"exactly now", time.Date(2026, 10, 19, 9, 0, 0, 0, moscow), moscow, time.Date(2026, 10, 26, 9, 0, 0, 0, moscow)},
+		// в Москве уже понедельник, в Нью-Йорке ещё воскресенье
+		{"other timezone", time.Date(2026, 10, 19, 9, 30, 0, 0, moscow), newYork, time.Date(2026, 10, 19, 9, 0, 0, 0, newYork)},
+		// переход на зимнее время 1 ноября: сводка всё равно в 9 утра
+		{"dst change", time.Date(2026, 10, 27, 10, 0, 0, 0, newYork), newYork, time.Date(2026, 11, 2, 9, 0, 0, 0, newYork)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := coursemodels.NextDigestAt(tt.now, tt.loc, time.Monday, 9)
+			require.True(t, tt.want.Equal(got), "NextDigestAt() = %v, want %v", got, tt.want)
+		})
+	}
+
+	require.Equal(t, moscow, coursemodels.LoadTimezone("Mars/Olympus"))
+}
+
+func TestDigestPeriod(t *testing.T) {
+	moscow := coursemodels.LoadTimezone("Europe/Moscow")
+	start, end, week := coursemodels.DigestPeriod(time.Date(2026, 10, 19, 6, 0, 0, 0, time.UTC), moscow)
+	require.Equal(t, "2026-W42", week)
+	require.Equal(t, time.Date(2026, 10, 12, 9, 0, 0, 0, moscow), start)
+	require.Equal(t, time.Date(2026, 10, 19, 9, 0, 0, 0, moscow), end)
+}
+
+func TestPlanWeeklyDigests(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := usecase.NewMockCourseRepository(ctrl)
+	uc := usecase.NewCourseUsecase(mockRepo)
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	// пачки по BatchSize, пока пользователи без сводки не кончатся
+	gomock.InOrder(
+		mockRepo.EXPECT().GetUsersWithoutDigest(ctx, 2).Return([]*coursemodels.DigestUser{{Id: 1, Timezone: "Asia/Tokyo"}, {Id: 2, Timezone: ""}}, nil),
+		mockRepo.EXPECT().ScheduleJob(ctx, coursemodels.JobWeeklyDigest, "1", gomock.Any()).DoAndReturn(
+			func(ctx context.Context, kind string, key string, runAt time.Time) error {
+				local := runAt.In(coursemodels.LoadTimezone("Asia/Tokyo"))
+				require.Equal(t, time.Monday, local.Weekday())
+				require.Equal(t, 9, local.Hour())
+				return nil
+			}),
+		mockRepo.EXPECT().ScheduleJob(ctx, coursemodels.JobWeeklyDigest, "2", gomock.Any()).Return(nil),
+		mockRepo.EXPECT().GetUsersWithoutDigest(ctx, 2).Return([]*coursemodels.DigestUser{{Id: 3, Timezone: "Europe/Moscow"}}, nil),
+		mockRepo.EXPECT().ScheduleJob(ctx, coursemodels.JobWeeklyDigest, "3", gomock.Any()).Return(nil),
+	)
+
+	require.NoError(t, uc.PlanWeeklyDigests(ctx, digestConf))
+}
+
+func TestSendWeeklyDigest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := usecase.NewMockCourseRepository(ctrl)
+	uc := usecase.NewCourseUsecase(mockRepo)
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	user := &coursemodels.DigestUser{Id: 7, Email: "alice@example.com", Timezone: "Asia/Tokyo", Subscribed: true}
+	at := time.Now().Add(-time.Minute)
+	start, end, week := coursemodels.DigestPeriod(at, coursemodels.LoadTimezone("Asia/Tokyo"))
+
+	mockRepo.EXPECT().GetDigestUser(ctx, 7).Return(user, nil)
+	mockRepo.EXPECT().GetWeekLessons(ctx, 7, start, end).Return([]*coursemodels.DigestCourse{
+		{CourseId: 1, CourseName: "Go", Lessons: 3},
+		{CourseId: 2, CourseName: "SQL", Lessons: 1},
+	}, nil)
+	mockRepo.EXPECT().GetUnfinishedCourses(ctx, 7, 5).Return([]*coursemodels.DigestCourse{
+		{CourseId: 1, CourseName: "Go"},
+		{CourseId: 4, CourseName: "Rust"},
+	}, nil)
+	mockRepo.EXPECT().GetStatistic(ctx, 7, 1).Return(&dto.UserStats{Percentage: 60}, nil)
+	mockRepo.EXPECT().GetStatistic(ctx, 7, 4).Return(&dto.UserStats{Percentage: 10}, nil)
+	mockRepo.EXPECT().GetUserRatingPositions(ctx, 7).Return([]*dto.RatingPositionDTO{
+		{CourseId: 1, CourseTitle: "Go", Position: 2},
+		{CourseId: 2, CourseTitle: "SQL", Position: 5},
+	}, nil)
+	mockRepo.EXPECT().GetLastDigestRating(ctx, 7).Return(map[int]int{1: 4}, nil)
+	mockRepo.EXPECT().GetNewCoursesByFavouriteTags(ctx, 7, start, 5).Return([]*coursemodels.DigestCourse{{CourseId: 9, CourseName: "Kafka"}}, nil)
+	mockRepo.EXPECT().SendWeeklyDigest(ctx, &coursemodels.WeeklyDigest{
+		User:        user,
+		Week:        week,
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Lessons:     4,
+		Courses: []*coursemodels.DigestCourse{
+			{CourseId: 1, CourseName: "Go", Lessons: 3, Progress: 60},
+			{CourseId: 4, CourseName: "Rust", Lessons: 0, Progress: 10},
+		},
+		// на курсе Go поднялся с 4 места на 2, рейтинга SQL в прошлой сводке не было
+		Ratings: []*coursemodels.DigestRating{
+			{CourseId: 1, CourseName: "Go", Position: 2, Change: 2},
+			{CourseId: 2, CourseName: "SQL", Position: 5},
+		},
+		NewCourses: []*coursemodels.DigestCourse{{CourseId: 9, CourseName: "Kafka"}},
+	}).Return(true, nil)
+
+	next, err := uc.SendWeeklyDigest(ctx, 7, at, digestConf)
+	require.NoError(t, err)
+	require.True(t, next.After(time.Now()))
+	require.Equal(t, coursemodels.NextDigestAt(time.Now(), coursemodels.LoadTimezone("Asia/Tokyo"), time.Monday, 9), next)
+}
+
+func TestSendWeeklyDigest_Stopped(t *testing.T) {
+	for _, user := range []*coursemodels.DigestUser{
+		nil,
+		{Id: 7, Subscribed: false},
+		{Id: 7, Subscribed: true, Deleting: true},
+	} {
+		ctrl := gomock.NewController(t)
+		mockRepo := usecase.NewMockCourseRepository(ctrl)
+		uc := usecase.NewCourseUsecase(mockRepo)
+		ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+		mockRepo.EXPECT().GetDigestUser(ctx, 7).Return(user, nil)
+
+		next, err := uc.SendWeeklyDigest(ctx, 7, time.Now(), digestConf)
+		require.NoError(t, err)
+		require.True(t, next.IsZero())
+		ctrl.Finish()
+	}
+}
+
+func TestSendWeeklyDigest_Late(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := usecase.NewMockCourseRepository(ctrl)
+	uc := usecase.NewCourseUsecase(mockRepo)
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	// сервис не работал сутки: старая сводка пропускается, следующая планируется как обычно
+	mockRepo.EXPECT().GetDigestUser(ctx, 7).Return(&coursemodels.DigestUser{Id: 7, Subscribed: true}, nil)
+
+	next, err := uc.SendWeeklyDigest(ctx, 7, time.Now().Add(-24*time.Hour), digestConf)
+	require.NoError(t, err)
+	require.True(t, next.After(time.Now()))
+}
+
+func TestSendWeeklyDigest_Empty(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := usecase.NewMockCourseRepository(ctrl)
+	uc := usecase.NewCourseUsecase(mockRepo)
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	mockRepo.EXPECT().GetDigestUser(ctx, 7).Return(&coursemodels.DigestUser{Id: 7, Subscribed: true}, nil)
+	mockRepo.EXPECT().GetWeekLessons(ctx, 7, gomock.Any(), gomock.Any()).Return(nil, nil)
+	mockRepo.EXPECT().GetUnfinishedCourses(ctx, 7, 5).Return(nil, nil)
+	mockRepo.EXPECT().GetUserRatingPositions(ctx, 7).Return([]*dto.RatingPositionDTO{}, nil)
+	mockRepo.EXPECT().GetLastDigestRating(ctx, 7).Return(map[int]int{}, nil)
+	mockRepo.EXPECT().GetNewCoursesByFavouriteTags(ctx, 7, gomock.Any(), 5).Return(nil, nil)
+
+	next, err := uc.SendWeeklyDigest(ctx, 7, time.Now(), digestConf)
+	require.NoError(t, err)
+	require.False(t, next.IsZero())
+}
+
+func TestSendWeeklyDigest_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := usecase.NewMockCourseRepository(ctrl)
+	uc := usecase.NewCourseUsecase(mockRepo)
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	mockRepo.EXPECT().GetDigestUser(ctx, 7).Return(&coursemodels.DigestUser{Id: 7, Subscribed: true}, nil)
+	mockRepo.EXPECT().GetWeekLessons(ctx, 7, gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+
+	_, err := uc.SendWeeklyDigest(ctx, 7, time.Now(), digestConf)
+	require.Error(t, err)
+}
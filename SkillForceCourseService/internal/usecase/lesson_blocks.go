@@ -0,0 +1,360 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	coursemodels "skillForce/internal/models/course"
+	"skillForce/internal/models/dto"
+	usermodels "skillForce/internal/models/user"
+	"skillForce/pkg/logs"
+	"skillForce/pkg/markdown"
+	"skillForce/pkg/sanitize"
+	"strings"
+	"unicode/utf8"
+)
+
+// Ограничения на содержимое блоков текстового урока
+const (
+	MaxLessonBlocks  = 200
+	MaxBlockText     = 100_000
+	MaxBlockCaption  = 500
+	MaxQuizOptions   = 10
+	MaxQuizOption    = 500
+	MaxQuizQuestion  = 1000
+	MaxCalloutText   = 10_000
+	minQuizOptions   = 2
+	lessonFileImage  = "image"
+	lessonFileAttach = "file"
+)
+
+var (
+	codeLanguagePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9+#.-]{0,29}$`)
+	youtubeIdPattern    = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+	vimeoIdPattern      = regexp.MustCompile(`^[0-9]{1,12}$`)
+	rutubeIdPattern     = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+	calloutVariants = map[string]bool{"info": true, "tip": true, "warning": true, "danger": true}
+)
+
+// blockError - ошибка проверки блока, по префиксу "invalid lesson block" main-service отвечает 400
+func blockError(index int, reason string) error {
+	return fmt.Errorf("invalid lesson block %d: %s", index+1, reason)
+}
+
+// prepareLessonBlocks - проверка и очистка блоков, присланных автором, перед сохранением.
+// Данные файлов берутся из lesson_files, а не из запроса
+func (uc *CourseUsecase) prepareLessonBlocks(ctx context.Context, authorId int, blocks []dto.LessonBlockDTO) ([]*coursemodels.LessonBlock, error) {
+	if len(blocks) > MaxLessonBlocks {
+		return nil, fmt.Errorf("invalid lesson block: lesson has more than %d blocks", MaxLessonBlocks)
+	}
+
+	result := make([]*coursemodels.LessonBlock, 0, len(blocks))
+	for i, block := range blocks {
+		prepared, err := uc.prepareLessonBlock(ctx, authorId, i, block)
+		if err != nil {
+			logs.PrintLog(ctx, "prepareLessonBlocks", fmt.Sprintf("%+v", err))
+			return nil, err
+		}
+		result = append(result, prepared)
+	}
+	return result, nil
+}
+
+func (uc *CourseUsecase) prepareLessonBlock(ctx context.Context, authorId int, i int, block dto.LessonBlockDTO) (*coursemodels.LessonBlock, error) {
+	for _, field := range []string{block.Text, block.Code, block.Question, block.Alt, block.Caption} {
+		if !utf8.ValidString(field) {
+			return nil, blockError(i, "text is not valid UTF-8")
+		}
+	}
+
+	switch block.Type {
+	case coursemodels.BlockHTML:
+		text := sanitize.Sanitize(block.Text)
+		if strings.TrimSpace(text) == "" || len(block.Text) > MaxBlockText {
+			return nil, blockError(i, "html must be non-empty and shorter than 100000 bytes")
+		}
+		return &coursemodels.LessonBlock{Type: block.Type, Text: text}, nil
+
+	case coursemodels.BlockMarkdown:
+		// исходник хранится для редактирования, ученикам отдаётся HTML из него
+		if strings.TrimSpace(block.Text) == "" || len(block.Text) > MaxBlockText {
+			return nil, blockError(i, "markdown must be non-empty and shorter than 100000 bytes")
+		}
+		rendered, err := markdown.Render(block.Text)
+		if err != nil {
+			logs.PrintLog(ctx, "prepareLessonBlock", fmt.Sprintf("%+v", err))
+			return nil, blockError(i, "failed to render markdown")
+		}
+		return &coursemodels.LessonBlock{Type: block.Type, Text: block.Text, Html: rendered}, nil
+
+	case coursemodels.BlockImage, coursemodels.BlockFile:
+		return uc.prepareFileBlock(ctx, authorId, i, block)
+
+	case coursemodels.BlockCode:
+		language := strings.ToLower(strings.TrimSpace(block.Language))
+		if !codeLanguagePattern.MatchString(language) {
+			return nil, blockError(i, "invalid code language")
+		}
+		if strings.TrimSpace(block.Code) == "" || len(block.Code) > MaxBlockText {
+			return nil, blockError(i, "code must be non-empty and shorter than 100000 bytes")
+		}
+		return &coursemodels.LessonBlock{Type: block.Type, Language: language, Code: block.Code}, nil
+
+	case coursemodels.BlockCallout:
+		if !calloutVariants[block.Variant] {
+			return nil, blockError(i, "callout variant must be info, tip, warning or danger")
+		}
+		text := sanitize.PlainText(block.Text)
+		if text == "" || len(text) > MaxCalloutText {
+			return nil, blockError(i, "callout text must be non-empty and shorter than 10000 bytes")
+		}
+		return &coursemodels.LessonBlock{Type: block.Type, Variant: block.Variant, Text: text}, nil
+
+	case coursemodels.BlockVideo:
+		provider, videoId, err := parseVideoURL(block.Url)
+		if err != nil {
+			return nil, blockError(i, err.Error())
+		}
+		caption, err := plainField(block.Caption, MaxBlockCaption)
+		if err != nil {
+			return nil, blockError(i, "caption "+err.Error())
+		}
+		return &coursemodels.LessonBlock{Type: block.Type, Provider: provider, VideoId: videoId, Caption: caption}, nil
+
+	case coursemodels.BlockQuiz:
+		return prepareQuizBlock(i, block)
+	}
+
+	return nil, blockError(i, fmt.Sprintf("unknown block type %q", block.Type))
+}
+
+func (uc *CourseUsecase) prepareFileBlock(ctx context.Context, authorId int, i int, block dto.LessonBlockDTO) (*coursemodels.LessonBlock, error) {
+	file, err := uc.repo.GetLessonFile(ctx, block.Object)
+	if err != nil || file.AuthorId != authorId {
+		return nil, blockError(i, "file not found")
+	}
+
+	kind := lessonFileAttach
+	if block.Type == coursemodels.BlockImage {
+		kind = lessonFileImage
+	}
+	if file.Kind != kind {
+		return nil, blockError(i, fmt.Sprintf("file was not uploaded as %s", kind))
+	}
+
+	alt, err := plainField(block.Alt, MaxBlockCaption)
+	if err != nil {
+		return nil, blockError(i, "alt "+err.Error())
+	}
+	caption, err := plainField(block.Caption, MaxBlockCaption)
+	if err != nil {
+		return nil, blockError(i, "caption "+err.Error())
+	}
+
+	return &coursemodels.LessonBlock{
+		Type:        block.Type,
+		Object:      file.ObjectName,
+		FileName:    file.FileName,
+		ContentType: file.ContentType,
+		Size:        file.Size,
+		Alt:         alt,
+		Caption:     caption,
+	}, nil
+}
+
+func prepareQuizBlock(i int, block dto.LessonBlockDTO) (*coursemodels.LessonBlock, error) {
+	question := sanitize.PlainText(block.Question)
+	if question == "" || len(question) > MaxQuizQuestion {
+		return nil, blockError(i, "quiz question must be non-empty and shorter than 1000 bytes")
+	}
+	if len(block.Options) < minQuizOptions || len(block.Options) > MaxQuizOptions {
+		return nil, blockError(i, fmt.Sprintf("quiz must have from %d to %d options", minQuizOptions, MaxQuizOptions))
+	}
+
+	options := make([]string, 0, len(block.Options))
+	for _, option := range block.Options {
+		if !utf8.ValidString(option) {
+			return nil, blockError(i, "text is not valid UTF-8")
+		}
+		option = sanitize.PlainText(option)
+		if option == "" || len(option) > MaxQuizOption {
+			return nil, blockError(i, "quiz options must be non-empty and shorter than 500 bytes")
+		}
+		options = append(options, option)
+	}
+
+	if block.Answer == nil || *block.Answer < 0 || *block.Answer >= len(options) {
+		return nil, blockError(i, "quiz answer must be an index of an option")
+	}
+	return &coursemodels.LessonBlock{Type: block.Type, Question: question, Options: options, Answer: *block.Answer}, nil
+}
+
+func plainField(value string, limit int) (string, error) {
+	value = sanitize.PlainText(value)
+	if len(value) > limit {
+		return "", fmt.Errorf("must be shorter than %d bytes", limit)
+	}
+	return value, nil
+}
+
+// parseVideoURL - ролик YouTube, Vimeo или RuTube по ссылке на страницу или плеер.
+// Хранится только сервис и id, ссылка на плеер собирается при выдаче урока
+func parseVideoURL(raw string) (string, string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+		return "", "", errors.New("invalid video url")
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+	var provider, id string
+	switch host {
+	case "youtube.com", "m.youtube.com", "youtube-nocookie.com":
+		provider = "youtube"
+		switch {
+		case len(parts) == 1 && parts[0] == "watch":
+			id = u.Query().Get("v")
+		case len(parts) == 2 && (parts[0] == "embed" || parts[0] == "shorts"):
+			id = parts[1]
+		}
+	case "youtu.be":
+		provider = "youtube"
+		if len(parts) == 1 {
+			id = parts[0]
+		}
+	case "vimeo.com":
+		provider = "vimeo"
+		if len(parts) == 1 {
+			id = parts[0]
+		}
+	case "player.vimeo.com":
+		provider = "vimeo"
+		if len(parts) == 2 && parts[0] == "video" {
+			id = parts[1]
+		}
+	case "rutube.ru":
+		provider = "rutube"
+		switch {
+		case len(parts) == 2 && parts[0] == "video":
+			id = parts[1]
+		case len(parts) == 3 && parts[0] == "play" && parts[1] == "embed":
+			id = parts[2]
+		}
+	default:
+		return "", "", errors.New("video must be hosted on youtube, vimeo or rutube")
+	}
+
+	valid := map[string]*regexp.Regexp{"youtube": youtubeIdPattern, "vimeo": vimeoIdPattern, "rutube": rutubeIdPattern}[provider]
+	if !valid.MatchString(id) {
+		return "", "", errors.New("invalid video url")
+	}
+	return provider, id, nil
+}
+
+func videoEmbedURL(provider, videoId string) string {
+	switch provider {
+	case "youtube":
+		return "https://www.youtube-nocookie.com/embed/" + videoId
+	case "vimeo":
+		return "https://player.vimeo.com/video/" + videoId
+	case "rutube":
+		return "https://rutube.ru/play/embed/" + videoId
+	}
+	return ""
+}
+
+// renderLessonBlocks - блоки урока для выдачи. html блоки ещё раз очищаются, так как могли
+// попасть в базу до появления проверки, правильные ответы на вопросы не отдаются
+func renderLessonBlocks(blocks []*coursemodels.LessonBlock) []dto.LessonBlockDTO {
+	result := make([]dto.LessonBlockDTO, 0, len(blocks))
+	for _, block := range blocks {
+		out := dto.LessonBlockDTO{Type: block.Type}
+		switch block.Type {
+		case coursemodels.BlockHTML:
+			out.Body = sanitize.Sanitize(block.Text)
+		case coursemodels.BlockMarkdown:
+			out.Text = block.Text
+			out.Body = renderedMarkdown(block)
+		case coursemodels.BlockImage, coursemodels.BlockFile:
+			out.Object = block.Object
+			out.FileName = block.FileName
+			out.ContentType = block.ContentType
+			out.Size = block.Size
+			out.Alt = block.Alt
+			out.Caption = block.Caption
+		case coursemodels.BlockCode:
+			out.Language = block.Language
+			out.Code = block.Code
+			out.Body = fmt.Sprintf(`<pre><code class="language-%s">%s</code></pre>`, block.Language, html.EscapeString(block.Code))
+		case coursemodels.BlockCallout:
+			out.Variant = block.Variant
+			out.Text = block.Text
+		case coursemodels.BlockVideo:
+			out.Provider = block.Provider
+			out.VideoId = block.VideoId
+			out.EmbedUrl = videoEmbedURL(block.Provider, block.VideoId)
+			out.Caption = block.Caption
+		case coursemodels.BlockQuiz:
+			out.Question = block.Question
+			out.Options = block.Options
+		}
+		result = append(result, out)
+	}
+	return result
+}
+
+// renderedMarkdown - HTML markdown блока. Сохранённый HTML ещё раз проходит политику на случай, если её
+// ужесточили после сохранения, а у блоков без него HTML собирается из исходника
+func renderedMarkdown(block *coursemodels.LessonBlock) string {
+	if block.Html != "" {
+		return sanitize.Markdown(block.Html)
+	}
+	rendered, err := markdown.Render(block.Text)
+	if err != nil {
+		return ""
+	}
+	return rendered
+}
+
+// PreviewLessonBlocks - блоки в том виде, в котором их увидят ученики. Проверка та же, что при создании курса
+func (uc *CourseUsecase) PreviewLessonBlocks(ctx context.Context, userProfile *usermodels.UserProfile, blocks []dto.LessonBlockDTO) ([]dto.LessonBlockDTO, error) {
+	if userProfile == nil {
+		return nil, errors.New("not authorized")
+	}
+	prepared, err := uc.prepareLessonBlocks(ctx, userProfile.Id, blocks)
+	if err != nil {
+		return nil, err
+	}
+	return renderLessonBlocks(prepared), nil
+}
+
+// AnswerBlockQuiz - проверка ответа на вопрос из блока урока. Вопрос доступен тем же, кому доступен урок
+func (uc *CourseUsecase) AnswerBlockQuiz(ctx context.Context, userProfile *usermodels.UserProfile, lessonId int, blockIndex int, option int) (bool, error) {
+	allowed, err := uc.CanViewLesson(ctx, userProfile, lessonId)
+	if err != nil {
+		return false, err
+	}
+	if !allowed {
+		return false, errors.New("course is not purchased")
+	}
+
+	blocks, err := uc.repo.GetLessonBlocks(ctx, lessonId)
+	if err != nil {
+		logs.PrintLog(ctx, "AnswerBlockQuiz", fmt.Sprintf("%+v", err))
+		return false, err
+	}
+	if blockIndex < 0 || blockIndex >= len(blocks) || blocks[blockIndex].Type != coursemodels.BlockQuiz {
+		return false, errors.New("quiz not found")
+	}
+
+	quiz := blocks[blockIndex]
+	if option < 0 || option >= len(quiz.Options) {
+		return false, errors.New("invalid option")
+	}
+	return option == quiz.Answer, nil
+}
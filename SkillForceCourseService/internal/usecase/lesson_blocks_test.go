@@ -0,0 +1,220 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	coursemodels "skillForce/internal/models/course"
+	"skillForce/internal/models/dto"
+	usermodels "skillForce/internal/models/user"
+	"skillForce/internal/usecase"
+	"skillForce/pkg/logs"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func courseWithBlocks(blocks ...dto.LessonBlockDTO) *dto.CourseDTO {
+	return &dto.CourseDTO{
+		Title: "Go",
+		Parts: []*dto.CoursePartDTO{{
+			Title: "Part",
+			Buckets: []*dto.LessonBucketDTO{{
+				Title:   "Bucket",
+				Lessons: []*dto.LessonPointDTO{{Type: "text", Title: "Lesson", Blocks: blocks}},
+			}},
+		}},
+	}
+}
+
+func answer(i int) *int {
+	return &i
+}
+
+func TestCreateCourseWithBlocks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := usecase.NewMockCourseRepository(ctrl)
+	uc := usecase.NewCourseUsecase(mockRepo)
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+	profile := &usermodels.UserProfile{Id: 7}
+
+	mockRepo.EXPECT().GetLessonFile(ctx, "7/pic.png").Return(&coursemodels.LessonFile{
+		ObjectName: "7/pic.png", AuthorId: 7, Kind: "image", FileName: "pic.png", ContentType: "image/png", Size: 42,
+	}, nil)
+	mockRepo.EXPECT().CreateCourse(ctx, gomock.Any(), profile).Return(1, nil)
+	mockRepo.EXPECT().CreatePart(ctx, gomock.Any(), 1).Return(2, nil)
+	mockRepo.EXPECT().CreateBucket(ctx, gomock.Any(), 2).Return(3, nil)
+	mockRepo.EXPECT().CreateTextLesson(ctx, gomock.Any(), 3).DoAndReturn(
+		func(_ context.Context, lesson *coursemodels.LessonPoint, _ int) error {
+			require.Equal(t, []*coursemodels.LessonBlock{
+				{Type: "markdown", Text: "# Title", Html: "<h1>Title</h1>"},
+				{Type: "image", Object: "7/pic.png", FileName: "pic.png", ContentType: "image/png", Size: 42, Alt: "alt"},
+				{Type: "code", Language: "go", Code: "fmt.Println()"},
+				{Type: "callout", Variant: "tip", Text: "note"},
+				{Type: "video", Provider: "youtube", VideoId: "dQw4w9WgXcQ"},
+				{Type: "quiz", Question: "2+2?", Options: []string{"3", "4"}, Answer: 1},
+			}, lesson.Blocks)
+			return nil
+		})
+
+	err := uc.CreateCourse(ctx, courseWithBlocks(
+		dto.LessonBlockDTO{Type: "markdown", Text: "# Title"},
+		dto.LessonBlockDTO{Type: "image", Object: "7/pic.png", FileName: "evil.exe", Alt: "<b>alt</b>"},
+		dto.LessonBlockDTO{Type: "code", Language: "Go", Code: "fmt.Println()"},
+		dto.LessonBlockDTO{Type: "callout", Variant: "tip", Text: "<script>x</script>note"},
+		dto.LessonBlockDTO{Type: "video", Url: "https://youtu.be/dQw4w9WgXcQ"},
+		dto.LessonBlockDTO{Type: "quiz", Question: "2+2?", Options: []string{"3", "4"}, Answer: answer(1)},
+	), profile)
+	require.NoError(t, err)
+}
+
+func TestCreateCourseRejectsInvalidBlocks(t *testing.T) {
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+	profile := &usermodels.UserProfile{Id: 7}
+
+	tests := []struct {
+		name  string
+		block dto.LessonBlockDTO
+		file  *coursemodels.LessonFile
+	}{
+		{name: "unknown type", block: dto.LessonBlockDTO{Type: "iframe", Text: "x"}},
+		{name: "empty markdown", block: dto.LessonBlockDTO{Type: "markdown", Text: "  "}},
+		{name: "invalid utf8", block: dto.LessonBlockDTO{Type: "markdown", Text: "\xff"}},
+		{name: "long markdown", block: dto.LessonBlockDTO{Type: "markdown", Text: strings.Repeat("a", 100_001)}},
+		{name: "code language", block: dto.LessonBlockDTO{Type: "code", Language: "go\" onclick", Code: "x"}},
+		{name: "callout variant", block: dto.LessonBlockDTO{Type: "callout", Variant: "error", Text: "x"}},
+		{name: "video host", block: dto.LessonBlockDTO{Type: "video", Url: "https://evil.example/watch?v=dQw4w9WgXcQ"}},
+		{name: "video scheme", block: dto.LessonBlockDTO{Type: "video", Url: "javascript:alert(1)"}},
+		{name: "video id", block: dto.LessonBlockDTO{Type: "video", Url: "https://www.youtube.com/watch?v=short"}},
+		{name: "quiz without answer", block: dto.LessonBlockDTO{Type: "quiz", Question: "q", Options: []string{"a", "b"}}},
+		{name: "quiz answer out of range", block: dto.LessonBlockDTO{Type: "quiz", Question: "q", Options: []string{"a", "b"}, Answer: answer(2)}},
+		{name: "quiz one option", block: dto.LessonBlockDTO{Type: "quiz", Question: "q", Options: []string{"a"}, Answer: answer(0)}},
+		{
+			name:  "foreign file",
+			block: dto.LessonBlockDTO{Type: "image", Object: "8/pic.png"},
+			file:  &coursemodels.LessonFile{ObjectName: "8/pic.png", AuthorId: 8, Kind: "image"},
+		},
+		{
+			name:  "attachment as image",
+			block: dto.LessonBlockDTO{Type: "image", Object: "7/doc.pdf"},
+			file:  &coursemodels.LessonFile{ObjectName: "7/doc.pdf", AuthorId: 7, Kind: "file"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := usecase.NewMockCourseRepository(ctrl)
+			uc := usecase.NewCourseUsecase(mockRepo)
+			if tt.file != nil {
+				mockRepo.EXPECT().GetLessonFile(ctx, tt.block.Object).Return(tt.file, nil)
+			}
+
+			err := uc.CreateCourse(ctx, courseWithBlocks(tt.block), profile)
+			require.Error(t, err)
+			require.True(t, strings.HasPrefix(err.Error(), "invalid lesson block"), err.Error())
+		})
+	}
+}
+
+func TestVideoBlockProviders(t *testing.T) {
+	tests := []struct {
+		url      string
+		provider string
+		embed    string
+	}{
+		{url: "https://www.youtube.com/watch?v=dQw4w9WgXcQ&t=10", provider: "youtube", embed: "https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ"},
+		{url: "https://youtube.com/shorts/dQw4w9WgXcQ", provider: "youtube", embed: "https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ"},
+		{url: "https://vimeo.com/76979871", provider: "vimeo", embed: "https://player.vimeo.com/video/76979871"},
+		{url: "https://rutube.ru/video/c6cc4d620b1d4338901770a44b3e82f4/", provider: "rutube", embed: "https://rutube.ru/play/embed/c6cc4d620b1d4338901770a44b3e82f4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := usecase.NewMockCourseRepository(ctrl)
+			uc := usecase.NewCourseUsecase(mockRepo)
+			ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+			var stored []*coursemodels.LessonBlock
+			mockRepo.EXPECT().CreateCourse(ctx, gomock.Any(), gomock.Any()).Return(1, nil)
+			mockRepo.EXPECT().CreatePart(ctx, gomock.Any(), 1).Return(2, nil)
+			mockRepo.EXPECT().CreateBucket(ctx, gomock.Any(), 2).Return(3, nil)
+			mockRepo.EXPECT().CreateTextLesson(ctx, gomock.Any(), 3).DoAndReturn(
+				func(_ context.Context, lesson *coursemodels.LessonPoint, _ int) error {
+					stored = lesson.Blocks
+					return nil
+				})
+			require.NoError(t, uc.CreateCourse(ctx, courseWithBlocks(dto.LessonBlockDTO{Type: "video", Url: tt.url}), &usermodels.UserProfile{Id: 1}))
+			require.Equal(t, tt.provider, stored[0].Provider)
+
+			mockRepo.EXPECT().GetLessonById(ctx, 5).Return(&coursemodels.LessonPoint{Type: "text"}, nil)
+			mockRepo.EXPECT().GetLessonBlocks(ctx, 5).Return(stored, nil)
+			mockRepo.EXPECT().GetLessonFooters(ctx, 5).Return([]int{0, 5, 6}, nil)
+			mockRepo.EXPECT().GetLessonHeaderByLessonId(ctx, 1, 5).Return(&dto.LessonDtoHeader{}, nil)
+			lesson, err := uc.GetNextLesson(ctx, 1, 1, 5)
+			require.NoError(t, err)
+			require.Equal(t, tt.embed, lesson.LessonBody.Blocks[0].EmbedUrl)
+		})
+	}
+}
+
+func TestAnswerBlockQuiz(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := usecase.NewMockCourseRepository(ctrl)
+	uc := usecase.NewCourseUsecase(mockRepo)
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+	profile := &usermodels.UserProfile{Id: 5}
+	blocks := []*coursemodels.LessonBlock{
+		{Type: "markdown", Text: "text"},
+		{Type: "quiz", Question: "q", Options: []string{"a", "b", "c"}, Answer: 2},
+	}
+
+	mockRepo.EXPECT().GetLessonCourse(ctx, 12).Return(3, 9, nil).Times(4)
+	mockRepo.EXPECT().IsUserPurchasedCourse(ctx, 5, 3).Return(true, nil).Times(3)
+	mockRepo.EXPECT().GetLessonBlocks(ctx, 12).Return(blocks, nil).Times(3)
+
+	correct, err := uc.AnswerBlockQuiz(ctx, profile, 12, 1, 2)
+	require.NoError(t, err)
+	require.True(t, correct)
+
+	correct, err = uc.AnswerBlockQuiz(ctx, profile, 12, 1, 0)
+	require.NoError(t, err)
+	require.False(t, correct)
+
+	_, err = uc.AnswerBlockQuiz(ctx, profile, 12, 0, 0)
+	require.EqualError(t, err, "quiz not found")
+
+	mockRepo.EXPECT().IsUserPurchasedCourse(ctx, 6, 3).Return(false, nil)
+	_, err = uc.AnswerBlockQuiz(ctx, &usermodels.UserProfile{Id: 6}, 12, 1, 2)
+	require.EqualError(t, err, "course is not purchased")
+}
+
+func TestPreviewLessonBlocks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := usecase.NewMockCourseRepository(ctrl)
+	uc := usecase.NewCourseUsecase(mockRepo)
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	blocks, err := uc.PreviewLessonBlocks(ctx, &usermodels.UserProfile{Id: 7}, []dto.LessonBlockDTO{
+		{Type: "markdown", Text: "Формула $x^2$\n\n<script>alert(1)</script>"},
+		{Type: "quiz", Question: "q", Options: []string{"a", "b"}, Answer: answer(0)},
+	})
+	require.NoError(t, err)
+	require.Len(t, blocks, 2)
+	require.Equal(t, "<p>Формула <span class=\"math math-inline\">x^2</span></p>", blocks[0].Body)
+	require.Equal(t, "Формула $x^2$\n\n<script>alert(1)</script>", blocks[0].Text)
+	require.Nil(t, blocks[1].Answer)
+
+	_, err = uc.PreviewLessonBlocks(ctx, &usermodels.UserProfile{Id: 7}, []dto.LessonBlockDTO{{Type: "markdown"}})
+	require.EqualError(t, err, "invalid lesson block 1: markdown must be non-empty and shorter than 100000 bytes")
+}
@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	usermodels "skillForce/internal/models/user"
+	"skillForce/pkg/logs"
+)
+
+// CanViewLesson - доступ к видео урока есть у купивших курс, его автора и администраторов
+func (uc *CourseUsecase) CanViewLesson(ctx context.Context, userProfile *usermodels.UserProfile, lessonId int) (bool, error) {
+	if userProfile == nil {
+		return false, nil
+	}
+
+	courseId, creatorId, err := uc.repo.GetLessonCourse(ctx, lessonId)
+	if err != nil {
+		logs.PrintLog(ctx, "CanViewLesson", fmt.Sprintf("%+v", err))
+		return false, err
+	}
+	if creatorId == userProfile.Id || userProfile.IsAdmin {
+		return true, nil
+	}
+
+	purchased, err := uc.repo.IsUserPurchasedCourse(ctx, userProfile.Id, courseId)
+	if err != nil {
+		logs.PrintLog(ctx, "CanViewLesson", fmt.Sprintf("%+v", err))
+		return false, err
+	}
+	return purchased, nil
+}
@@ -0,0 +1,75 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	usermodels "skillForce/internal/models/user"
+	"skillForce/internal/usecase"
+	"skillForce/pkg/logs"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanViewLesson(t *testing.T) {
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	tests := []struct {
+		name      string
+		profile   *usermodels.UserProfile
+		purchased bool
+		checkBuy  bool
+		want      bool
+	}{
+		{name: "author", profile: &usermodels.UserProfile{Id: 9}, want: true},
+		{name: "admin", profile: &usermodels.UserProfile{Id: 5, IsAdmin: true}, want: true},
+		{name: "purchased", profile: &usermodels.UserProfile{Id: 5}, checkBuy: true, purchased: true, want: true},
+		{name: "not purchased", profile: &usermodels.UserProfile{Id: 5}, checkBuy: true, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := usecase.NewMockCourseRepository(ctrl)
+			uc := usecase.NewCourseUsecase(mockRepo)
+
+			mockRepo.EXPECT().GetLessonCourse(ctx, 12).Return(3, 9, nil)
+			if tt.checkBuy {
+				mockRepo.EXPECT().IsUserPurchasedCourse(ctx, tt.profile.Id, 3).Return(tt.purchased, nil)
+			}
+
+			allowed, err := uc.CanViewLesson(ctx, tt.profile, 12)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, allowed)
+		})
+	}
+}
+
+func TestCanViewLessonNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := usecase.NewMockCourseRepository(ctrl)
+	uc := usecase.NewCourseUsecase(mockRepo)
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	mockRepo.EXPECT().GetLessonCourse(ctx, 12).Return(0, 0, errors.New("lesson not found"))
+
+	allowed, err := uc.CanViewLesson(ctx, &usermodels.UserProfile{Id: 5}, 12)
+	require.EqualError(t, err, "lesson not found")
+	require.False(t, allowed)
+}
+
+func TestCanViewLessonAnonymous(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := usecase.NewCourseUsecase(usecase.NewMockCourseRepository(ctrl))
+
+	allowed, err := uc.CanViewLesson(context.Background(), nil, 12)
+	require.NoError(t, err)
+	require.False(t, allowed)
+}
@@ -6,10 +6,12 @@ package usecase
 
 import (
 	context "context"
+	multipart "mime/multipart"
 	reflect "reflect"
 	course "skillForce/internal/models/course"
 	dto "skillForce/internal/models/dto"
-	user "skillForce/internal/models/user"
+	usermodels "skillForce/internal/models/user"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -110,7 +112,7 @@ func (mr *MockCourseRepositoryMockRecorder) CreateBucket(ctx, bucket, partId int
 }
 
 // CreateCourse mocks base method.
-func (m *MockCourseRepository) CreateCourse(ctx context.Context, course *course.Course, userProfile *user.UserProfile) (int, error) {
+func (m *MockCourseRepository) CreateCourse(ctx context.Context, course *course.Course, userProfile *usermodels.UserProfile) (int, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CreateCourse", ctx, course, userProfile)
 	ret0, _ := ret[0].(int)
@@ -181,6 +183,51 @@ func (mr *MockCourseRepositoryMockRecorder) DeleteCourseFromFavourites(ctx, cour
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCourseFromFavourites", reflect.TypeOf((*MockCourseRepository)(nil).DeleteCourseFromFavourites), ctx, courseId, userId)
 }
 
+// GetAccountsToPurge mocks base method.
+func (m *MockCourseRepository) GetAccountsToPurge(ctx context.Context) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountsToPurge", ctx)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountsToPurge indicates an expected call of GetAccountsToPurge.
+func (mr *MockCourseRepositoryMockRecorder) GetAccountsToPurge(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountsToPurge", reflect.TypeOf((*MockCourseRepository)(nil).GetAccountsToPurge), ctx)
+}
+
+// GetAuthorStats mocks base method.
+func (m *MockCourseRepository) GetAuthorStats(ctx context.Context, userId int) (*dto.AuthorStatsDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthorStats", ctx, userId)
+	ret0, _ := ret[0].(*dto.AuthorStatsDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthorStats indicates an expected call of GetAuthorStats.
+func (mr *MockCourseRepositoryMockRecorder) GetAuthorStats(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthorStats", reflect.TypeOf((*MockCourseRepository)(nil).GetAuthorStats), ctx, userId)
+}
+
+// GetAuthoredCourses mocks base method.
+func (m *MockCourseRepository) GetAuthoredCourses(ctx context.Context, userId int) ([]*course.Course, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthoredCourses", ctx, userId)
+	ret0, _ := ret[0].([]*course.Course)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthoredCourses indicates an expected call of GetAuthoredCourses.
+func (mr *MockCourseRepositoryMockRecorder) GetAuthoredCourses(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthoredCourses", reflect.TypeOf((*MockCourseRepository)(nil).GetAuthoredCourses), ctx, userId)
+}
+
 // GetBucketByLessonId mocks base method.
 func (m *MockCourseRepository) GetBucketByLessonId(ctx context.Context, lessonId int) (*course.LessonBucket, error) {
 	m.ctrl.T.Helper()
@@ -226,6 +273,36 @@ func (mr *MockCourseRepositoryMockRecorder) GetBucketLessons(ctx, userId, course
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBucketLessons", reflect.TypeOf((*MockCourseRepository)(nil).GetBucketLessons), ctx, userId, courseId, bucketId)
 }
 
+// GetCampaignMails mocks base method.
+func (m *MockCourseRepository) GetCampaignMails(ctx context.Context, campaign string, conf course.CampaignConfig) ([]*course.CampaignMail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCampaignMails", ctx, campaign, conf)
+	ret0, _ := ret[0].([]*course.CampaignMail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCampaignMails indicates an expected call of GetCampaignMails.
+func (mr *MockCourseRepositoryMockRecorder) GetCampaignMails(ctx, campaign, conf interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCampaignMails", reflect.TypeOf((*MockCourseRepository)(nil).GetCampaignMails), ctx, campaign, conf)
+}
+
+// GetCompletedBucketCourses mocks base method.
+func (m *MockCourseRepository) GetCompletedBucketCourses(ctx context.Context, userId int) ([]*course.Course, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompletedBucketCourses", ctx, userId)
+	ret0, _ := ret[0].([]*course.Course)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompletedBucketCourses indicates an expected call of GetCompletedBucketCourses.
+func (mr *MockCourseRepositoryMockRecorder) GetCompletedBucketCourses(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompletedBucketCourses", reflect.TypeOf((*MockCourseRepository)(nil).GetCompletedBucketCourses), ctx, userId)
+}
+
 // GetCourseById mocks base method.
 func (m *MockCourseRepository) GetCourseById(ctx context.Context, courseId int) (*course.Course, error) {
 	m.ctrl.T.Helper()
@@ -316,6 +393,21 @@ func (mr *MockCourseRepositoryMockRecorder) GetCoursesTags(ctx, bucketCoursesWit
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCoursesTags", reflect.TypeOf((*MockCourseRepository)(nil).GetCoursesTags), ctx, bucketCoursesWithoutTags)
 }
 
+// GetDigestUser mocks base method.
+func (m *MockCourseRepository) GetDigestUser(ctx context.Context, userId int) (*course.DigestUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDigestUser", ctx, userId)
+	ret0, _ := ret[0].(*course.DigestUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDigestUser indicates an expected call of GetDigestUser.
+func (mr *MockCourseRepositoryMockRecorder) GetDigestUser(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDigestUser", reflect.TypeOf((*MockCourseRepository)(nil).GetDigestUser), ctx, userId)
+}
+
 // GetFavouriteCourses mocks base method.
 func (m *MockCourseRepository) GetFavouriteCourses(ctx context.Context, userId int) ([]*course.Course, error) {
 	m.ctrl.T.Helper()
@@ -331,6 +423,36 @@ func (mr *MockCourseRepositoryMockRecorder) GetFavouriteCourses(ctx, userId inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFavouriteCourses", reflect.TypeOf((*MockCourseRepository)(nil).GetFavouriteCourses), ctx, userId)
 }
 
+// GetGeneratedSertificate mocks base method.
+func (m *MockCourseRepository) GetGeneratedSertificate(ctx context.Context, userProfile *usermodels.UserProfile, courseId int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGeneratedSertificate", ctx, userProfile, courseId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGeneratedSertificate indicates an expected call of GetGeneratedSertificate.
+func (mr *MockCourseRepositoryMockRecorder) GetGeneratedSertificate(ctx, userProfile, courseId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGeneratedSertificate", reflect.TypeOf((*MockCourseRepository)(nil).GetGeneratedSertificate), ctx, userProfile, courseId)
+}
+
+// GetLastDigestRating mocks base method.
+func (m *MockCourseRepository) GetLastDigestRating(ctx context.Context, userId int) (map[int]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastDigestRating", ctx, userId)
+	ret0, _ := ret[0].(map[int]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastDigestRating indicates an expected call of GetLastDigestRating.
+func (mr *MockCourseRepositoryMockRecorder) GetLastDigestRating(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastDigestRating", reflect.TypeOf((*MockCourseRepository)(nil).GetLastDigestRating), ctx, userId)
+}
+
 // GetLastLessonHeader mocks base method.
 func (m *MockCourseRepository) GetLastLessonHeader(ctx context.Context, userId, courseId int) (*dto.LessonDtoHeader, int, string, bool, error) {
 	m.ctrl.T.Helper()
@@ -350,10 +472,10 @@ func (mr *MockCourseRepositoryMockRecorder) GetLastLessonHeader(ctx, userId, cou
 }
 
 // GetLessonBlocks mocks base method.
-func (m *MockCourseRepository) GetLessonBlocks(ctx context.Context, currentLessonId int) ([]string, error) {
+func (m *MockCourseRepository) GetLessonBlocks(ctx context.Context, currentLessonId int) ([]*course.LessonBlock, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetLessonBlocks", ctx, currentLessonId)
-	ret0, _ := ret[0].([]string)
+	ret0, _ := ret[0].([]*course.LessonBlock)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -379,6 +501,37 @@ func (mr *MockCourseRepositoryMockRecorder) GetLessonById(ctx, lessonId interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLessonById", reflect.TypeOf((*MockCourseRepository)(nil).GetLessonById), ctx, lessonId)
 }
 
+// GetLessonCourse mocks base method.
+func (m *MockCourseRepository) GetLessonCourse(ctx context.Context, lessonId int) (int, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLessonCourse", ctx, lessonId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLessonCourse indicates an expected call of GetLessonCourse.
+func (mr *MockCourseRepositoryMockRecorder) GetLessonCourse(ctx, lessonId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLessonCourse", reflect.TypeOf((*MockCourseRepository)(nil).GetLessonCourse), ctx, lessonId)
+}
+
+// GetLessonFile mocks base method.
+func (m *MockCourseRepository) GetLessonFile(ctx context.Context, objectName string) (*course.LessonFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLessonFile", ctx, objectName)
+	ret0, _ := ret[0].(*course.LessonFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLessonFile indicates an expected call of GetLessonFile.
+func (mr *MockCourseRepositoryMockRecorder) GetLessonFile(ctx, objectName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLessonFile", reflect.TypeOf((*MockCourseRepository)(nil).GetLessonFile), ctx, objectName)
+}
+
 // GetLessonFooters mocks base method.
 func (m *MockCourseRepository) GetLessonFooters(ctx context.Context, currentLessonId int) ([]int, error) {
 	m.ctrl.T.Helper()
@@ -439,6 +592,21 @@ func (mr *MockCourseRepositoryMockRecorder) GetLessonVideo(ctx, currentLessonId
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLessonVideo", reflect.TypeOf((*MockCourseRepository)(nil).GetLessonVideo), ctx, currentLessonId)
 }
 
+// GetNewCoursesByFavouriteTags mocks base method.
+func (m *MockCourseRepository) GetNewCoursesByFavouriteTags(ctx context.Context, userId int, since time.Time, limit int) ([]*course.DigestCourse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNewCoursesByFavouriteTags", ctx, userId, since, limit)
+	ret0, _ := ret[0].([]*course.DigestCourse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNewCoursesByFavouriteTags indicates an expected call of GetNewCoursesByFavouriteTags.
+func (mr *MockCourseRepositoryMockRecorder) GetNewCoursesByFavouriteTags(ctx, userId, since, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNewCoursesByFavouriteTags", reflect.TypeOf((*MockCourseRepository)(nil).GetNewCoursesByFavouriteTags), ctx, userId, since, limit)
+}
+
 // GetPartBuckets mocks base method.
 func (m *MockCourseRepository) GetPartBuckets(ctx context.Context, partId int) ([]*course.LessonBucket, error) {
 	m.ctrl.T.Helper()
@@ -454,6 +622,21 @@ func (mr *MockCourseRepositoryMockRecorder) GetPartBuckets(ctx, partId interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPartBuckets", reflect.TypeOf((*MockCourseRepository)(nil).GetPartBuckets), ctx, partId)
 }
 
+// GetPurchasedBucketCourses mocks base method.
+func (m *MockCourseRepository) GetPurchasedBucketCourses(ctx context.Context, userId int) ([]*course.Course, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPurchasedBucketCourses", ctx, userId)
+	ret0, _ := ret[0].([]*course.Course)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPurchasedBucketCourses indicates an expected call of GetPurchasedBucketCourses.
+func (mr *MockCourseRepositoryMockRecorder) GetPurchasedBucketCourses(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurchasedBucketCourses", reflect.TypeOf((*MockCourseRepository)(nil).GetPurchasedBucketCourses), ctx, userId)
+}
+
 // GetQuestionTestLesson mocks base method.
 func (m *MockCourseRepository) GetQuestionTestLesson(ctx context.Context, currentLessonId, user_id int) (*dto.QuestionTest, error) {
 	m.ctrl.T.Helper()
@@ -469,11 +652,56 @@ func (mr *MockCourseRepositoryMockRecorder) GetQuestionTestLesson(ctx, currentLe
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuestionTestLesson", reflect.TypeOf((*MockCourseRepository)(nil).GetQuestionTestLesson), ctx, currentLessonId, user_id)
 }
 
+// GetRating mocks base method.
+func (m *MockCourseRepository) GetRating(ctx context.Context, userId, courseId int) (*dto.Raiting, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRating", ctx, userId, courseId)
+	ret0, _ := ret[0].(*dto.Raiting)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRating indicates an expected call of GetRating.
+func (mr *MockCourseRepositoryMockRecorder) GetRating(ctx, userId, courseId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRating", reflect.TypeOf((*MockCourseRepository)(nil).GetRating), ctx, userId, courseId)
+}
+
+// GetStatistic mocks base method.
+func (m *MockCourseRepository) GetStatistic(ctx context.Context, userId, courseId int) (*dto.UserStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatistic", ctx, userId, courseId)
+	ret0, _ := ret[0].(*dto.UserStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatistic indicates an expected call of GetStatistic.
+func (mr *MockCourseRepositoryMockRecorder) GetStatistic(ctx, userId, courseId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatistic", reflect.TypeOf((*MockCourseRepository)(nil).GetStatistic), ctx, userId, courseId)
+}
+
+// GetUnfinishedCourses mocks base method.
+func (m *MockCourseRepository) GetUnfinishedCourses(ctx context.Context, userId, limit int) ([]*course.DigestCourse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnfinishedCourses", ctx, userId, limit)
+	ret0, _ := ret[0].([]*course.DigestCourse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnfinishedCourses indicates an expected call of GetUnfinishedCourses.
+func (mr *MockCourseRepositoryMockRecorder) GetUnfinishedCourses(ctx, userId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnfinishedCourses", reflect.TypeOf((*MockCourseRepository)(nil).GetUnfinishedCourses), ctx, userId, limit)
+}
+
 // GetUserById mocks base method.
-func (m *MockCourseRepository) GetUserById(ctx context.Context, userId int) (*user.User, error) {
+func (m *MockCourseRepository) GetUserById(ctx context.Context, userId int) (*usermodels.User, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetUserById", ctx, userId)
-	ret0, _ := ret[0].(*user.User)
+	ret0, _ := ret[0].(*usermodels.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -484,19 +712,109 @@ func (mr *MockCourseRepositoryMockRecorder) GetUserById(ctx, userId interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserById", reflect.TypeOf((*MockCourseRepository)(nil).GetUserById), ctx, userId)
 }
 
-// IsMiddle mocks base method.
-func (m *MockCourseRepository) IsMiddle(ctx context.Context, userId, courseId int) (bool, error) {
+// GetUserCourseData mocks base method.
+func (m *MockCourseRepository) GetUserCourseData(ctx context.Context, userId int) (*dto.UserCourseDataDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserCourseData", ctx, userId)
+	ret0, _ := ret[0].(*dto.UserCourseDataDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserCourseData indicates an expected call of GetUserCourseData.
+func (mr *MockCourseRepositoryMockRecorder) GetUserCourseData(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserCourseData", reflect.TypeOf((*MockCourseRepository)(nil).GetUserCourseData), ctx, userId)
+}
+
+// GetUserRatingPositions mocks base method.
+func (m *MockCourseRepository) GetUserRatingPositions(ctx context.Context, userId int) ([]*dto.RatingPositionDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserRatingPositions", ctx, userId)
+	ret0, _ := ret[0].([]*dto.RatingPositionDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserRatingPositions indicates an expected call of GetUserRatingPositions.
+func (mr *MockCourseRepositoryMockRecorder) GetUserRatingPositions(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRatingPositions", reflect.TypeOf((*MockCourseRepository)(nil).GetUserRatingPositions), ctx, userId)
+}
+
+// GetUserSertificates mocks base method.
+func (m *MockCourseRepository) GetUserSertificates(ctx context.Context, userId int) (map[int]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSertificates", ctx, userId)
+	ret0, _ := ret[0].(map[int]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSertificates indicates an expected call of GetUserSertificates.
+func (mr *MockCourseRepositoryMockRecorder) GetUserSertificates(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSertificates", reflect.TypeOf((*MockCourseRepository)(nil).GetUserSertificates), ctx, userId)
+}
+
+// GetUsersWithoutDigest mocks base method.
+func (m *MockCourseRepository) GetUsersWithoutDigest(ctx context.Context, limit int) ([]*course.DigestUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUsersWithoutDigest", ctx, limit)
+	ret0, _ := ret[0].([]*course.DigestUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsersWithoutDigest indicates an expected call of GetUsersWithoutDigest.
+func (mr *MockCourseRepositoryMockRecorder) GetUsersWithoutDigest(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersWithoutDigest", reflect.TypeOf((*MockCourseRepository)(nil).GetUsersWithoutDigest), ctx, limit)
+}
+
+// GetWeekLessons mocks base method.
+func (m *MockCourseRepository) GetWeekLessons(ctx context.Context, userId int, from, to time.Time) ([]*course.DigestCourse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWeekLessons", ctx, userId, from, to)
+	ret0, _ := ret[0].([]*course.DigestCourse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWeekLessons indicates an expected call of GetWeekLessons.
+func (mr *MockCourseRepositoryMockRecorder) GetWeekLessons(ctx, userId, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWeekLessons", reflect.TypeOf((*MockCourseRepository)(nil).GetWeekLessons), ctx, userId, from, to)
+}
+
+// IsSertificateExists mocks base method.
+func (m *MockCourseRepository) IsSertificateExists(ctx context.Context, userId, courseId int) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IsMiddle", ctx, userId, courseId)
+	ret := m.ctrl.Call(m, "IsSertificateExists", ctx, userId, courseId)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// IsMiddle indicates an expected call of IsMiddle.
-func (mr *MockCourseRepositoryMockRecorder) IsMiddle(ctx, userId, courseId interface{}) *gomock.Call {
+// IsSertificateExists indicates an expected call of IsSertificateExists.
+func (mr *MockCourseRepositoryMockRecorder) IsSertificateExists(ctx, userId, courseId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsMiddle", reflect.TypeOf((*MockCourseRepository)(nil).IsMiddle), ctx, userId, courseId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSertificateExists", reflect.TypeOf((*MockCourseRepository)(nil).IsSertificateExists), ctx, userId, courseId)
+}
+
+// IsUserCompletedCourse mocks base method.
+func (m *MockCourseRepository) IsUserCompletedCourse(ctx context.Context, userId, courseId int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsUserCompletedCourse", ctx, userId, courseId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsUserCompletedCourse indicates an expected call of IsUserCompletedCourse.
+func (mr *MockCourseRepositoryMockRecorder) IsUserCompletedCourse(ctx, userId, courseId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUserCompletedCourse", reflect.TypeOf((*MockCourseRepository)(nil).IsUserCompletedCourse), ctx, userId, courseId)
 }
 
 // IsUserPurchasedCourse mocks base method.
@@ -514,6 +832,20 @@ func (mr *MockCourseRepositoryMockRecorder) IsUserPurchasedCourse(ctx, userId, c
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUserPurchasedCourse", reflect.TypeOf((*MockCourseRepository)(nil).IsUserPurchasedCourse), ctx, userId, courseId)
 }
 
+// MarkCourseAsCompleted mocks base method.
+func (m *MockCourseRepository) MarkCourseAsCompleted(ctx context.Context, userId, courseId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkCourseAsCompleted", ctx, userId, courseId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkCourseAsCompleted indicates an expected call of MarkCourseAsCompleted.
+func (mr *MockCourseRepositoryMockRecorder) MarkCourseAsCompleted(ctx, userId, courseId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkCourseAsCompleted", reflect.TypeOf((*MockCourseRepository)(nil).MarkCourseAsCompleted), ctx, userId, courseId)
+}
+
 // MarkLessonAsNotCompleted mocks base method.
 func (m *MockCourseRepository) MarkLessonAsNotCompleted(ctx context.Context, userId, lessonId int) error {
 	m.ctrl.T.Helper()
@@ -529,17 +861,59 @@ func (mr *MockCourseRepositoryMockRecorder) MarkLessonAsNotCompleted(ctx, userId
 }
 
 // MarkLessonCompleted mocks base method.
-func (m *MockCourseRepository) MarkLessonCompleted(ctx context.Context, userId, courseId, lessonId int) error {
+func (m *MockCourseRepository) MarkLessonCompleted(ctx context.Context, userId, lessonId int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MarkLessonCompleted", ctx, userId, courseId, lessonId)
+	ret := m.ctrl.Call(m, "MarkLessonCompleted", ctx, userId, lessonId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // MarkLessonCompleted indicates an expected call of MarkLessonCompleted.
-func (mr *MockCourseRepositoryMockRecorder) MarkLessonCompleted(ctx, userId, courseId, lessonId interface{}) *gomock.Call {
+func (mr *MockCourseRepositoryMockRecorder) MarkLessonCompleted(ctx, userId, lessonId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkLessonCompleted", reflect.TypeOf((*MockCourseRepository)(nil).MarkLessonCompleted), ctx, userId, lessonId)
+}
+
+// PurgeUserCourseData mocks base method.
+func (m *MockCourseRepository) PurgeUserCourseData(ctx context.Context, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeUserCourseData", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeUserCourseData indicates an expected call of PurgeUserCourseData.
+func (mr *MockCourseRepositoryMockRecorder) PurgeUserCourseData(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeUserCourseData", reflect.TypeOf((*MockCourseRepository)(nil).PurgeUserCourseData), ctx, userId)
+}
+
+// SaveSertificate mocks base method.
+func (m *MockCourseRepository) SaveSertificate(ctx context.Context, userId, courseId int, sertificateUrl string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSertificate", ctx, userId, courseId, sertificateUrl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSertificate indicates an expected call of SaveSertificate.
+func (mr *MockCourseRepositoryMockRecorder) SaveSertificate(ctx, userId, courseId, sertificateUrl interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkLessonCompleted", reflect.TypeOf((*MockCourseRepository)(nil).MarkLessonCompleted), ctx, userId, courseId, lessonId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSertificate", reflect.TypeOf((*MockCourseRepository)(nil).SaveSertificate), ctx, userId, courseId, sertificateUrl)
+}
+
+// ScheduleJob mocks base method.
+func (m *MockCourseRepository) ScheduleJob(ctx context.Context, kind, key string, runAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleJob", ctx, kind, key, runAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ScheduleJob indicates an expected call of ScheduleJob.
+func (mr *MockCourseRepositoryMockRecorder) ScheduleJob(ctx, kind, key, runAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleJob", reflect.TypeOf((*MockCourseRepository)(nil).ScheduleJob), ctx, kind, key, runAt)
 }
 
 // SearchCoursesByTitle mocks base method.
@@ -556,3 +930,62 @@ func (mr *MockCourseRepositoryMockRecorder) SearchCoursesByTitle(ctx, keywords i
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchCoursesByTitle", reflect.TypeOf((*MockCourseRepository)(nil).SearchCoursesByTitle), ctx, keywords)
 }
+
+// SendCampaignMail mocks base method.
+func (m *MockCourseRepository) SendCampaignMail(ctx context.Context, mail *course.CampaignMail, conf course.CampaignConfig) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendCampaignMail", ctx, mail, conf)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendCampaignMail indicates an expected call of SendCampaignMail.
+func (mr *MockCourseRepositoryMockRecorder) SendCampaignMail(ctx, mail, conf interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendCampaignMail", reflect.TypeOf((*MockCourseRepository)(nil).SendCampaignMail), ctx, mail, conf)
+}
+
+// SendWeeklyDigest mocks base method.
+func (m *MockCourseRepository) SendWeeklyDigest(ctx context.Context, digest *course.WeeklyDigest) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendWeeklyDigest", ctx, digest)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendWeeklyDigest indicates an expected call of SendWeeklyDigest.
+func (mr *MockCourseRepositoryMockRecorder) SendWeeklyDigest(ctx, digest interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendWeeklyDigest", reflect.TypeOf((*MockCourseRepository)(nil).SendWeeklyDigest), ctx, digest)
+}
+
+// SetCourseHidden mocks base method.
+func (m *MockCourseRepository) SetCourseHidden(ctx context.Context, courseId int, hidden bool, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCourseHidden", ctx, courseId, hidden, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCourseHidden indicates an expected call of SetCourseHidden.
+func (mr *MockCourseRepositoryMockRecorder) SetCourseHidden(ctx, courseId, hidden, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCourseHidden", reflect.TypeOf((*MockCourseRepository)(nil).SetCourseHidden), ctx, courseId, hidden, reason)
+}
+
+// UploadFileToMinIO mocks base method.
+func (m *MockCourseRepository) UploadFileToMinIO(ctx context.Context, file multipart.File, fileHeader *multipart.FileHeader) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadFileToMinIO", ctx, file, fileHeader)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadFileToMinIO indicates an expected call of UploadFileToMinIO.
+func (mr *MockCourseRepositoryMockRecorder) UploadFileToMinIO(ctx, file, fileHeader interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadFileToMinIO", reflect.TypeOf((*MockCourseRepository)(nil).UploadFileToMinIO), ctx, file, fileHeader)
+}
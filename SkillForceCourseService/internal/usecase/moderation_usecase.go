@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	usermodels "skillForce/internal/models/user"
+	"skillForce/pkg/logs"
+)
+
+// MaxHiddenReasonLength - максимальная длина причины скрытия курса в символах
+const MaxHiddenReasonLength = 500
+
+// SetCourseHidden - модератор скрывает курс из каталога и поиска с указанием причины
+// или возвращает его обратно. Право модерации проверяется в interceptor по MethodPermissions
+func (uc *CourseUsecase) SetCourseHidden(ctx context.Context, userProfile *usermodels.UserProfile, courseId int, hidden bool, reason string) error {
+	if userProfile == nil {
+		return errors.New("not authorized")
+	}
+	reason = strings.TrimSpace(reason)
+	if !hidden {
+		reason = ""
+	}
+	if hidden && reason == "" {
+		return errors.New("hidden reason is required")
+	}
+	if utf8.RuneCountInString(reason) > MaxHiddenReasonLength {
+		return errors.New("hidden reason is too long")
+	}
+
+	logs.PrintLog(ctx, "SetCourseHidden", fmt.Sprintf("moderator %d sets hidden=%v for course %d: %s", userProfile.Id, hidden, courseId, reason))
+	return uc.repo.SetCourseHidden(ctx, courseId, hidden, reason)
+}
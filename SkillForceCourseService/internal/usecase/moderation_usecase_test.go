@@ -0,0 +1,53 @@
+package usecase_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	usermodels "skillForce/internal/models/user"
+	"skillForce/internal/usecase"
+	"skillForce/pkg/logs"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetCourseHidden(t *testing.T) {
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+	moderator := &usermodels.UserProfile{Id: 3}
+
+	tests := []struct {
+		name       string
+		profile    *usermodels.UserProfile
+		hidden     bool
+		reason     string
+		wantReason string
+		wantErr    string
+	}{
+		{name: "hide", profile: moderator, hidden: true, reason: "  spam  ", wantReason: "spam"},
+		{name: "restore clears reason", profile: moderator, hidden: false, reason: "ok now", wantReason: ""},
+		{name: "hide without reason", profile: moderator, hidden: true, reason: " ", wantErr: "hidden reason is required"},
+		{name: "reason too long", profile: moderator, hidden: true, reason: strings.Repeat("я", usecase.MaxHiddenReasonLength+1), wantErr: "hidden reason is too long"},
+		{name: "anonymous", hidden: true, reason: "spam", wantErr: "not authorized"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := usecase.NewMockCourseRepository(ctrl)
+			uc := usecase.NewCourseUsecase(mockRepo)
+			if tt.wantErr == "" {
+				mockRepo.EXPECT().SetCourseHidden(ctx, 10, tt.hidden, tt.wantReason).Return(nil)
+			}
+
+			err := uc.SetCourseHidden(ctx, tt.profile, 10, tt.hidden, tt.reason)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,340 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func recipient() *Recipient {
+	return &Recipient{Email: "alice@example.com", Name: "Alice"}
+}
+
+func TestRoundTrip(t *testing.T) {
+	ctx := WithCorrelationId(context.Background(), "0b7e2a44-1c3d-4e5f-9a8b-7c6d5e4f3a2b")
+	payloads := []Payload{
+		&ConfirmRegistrationMail{Recipient: recipient(), Token: "3f2a9c"},
+		&WelcomeCourseMail{Recipient: recipient(), CourseId: 7, CourseName: "Go"},
+		&DataExportMail{Recipient: recipient(), Url: "https://skill-force.ru/exports/1.zip"},
+	}
+	for _, payload := range payloads {
+		env, err := New(ctx, "user-service", payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if env.GetVersion() != 1 || env.GetCorrelationId() != "0b7e2a44-1c3d-4e5f-9a8b-7c6d5e4f3a2b" {
+			t.Fatalf("unexpected envelope %v", env)
+		}
+
+		data, err := Marshal(env)
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded, err := Unmarshal(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !proto.Equal(env, decoded) {
+			t.Fatalf("%s: got %v, want %v", env.GetType(), decoded, env)
+		}
+	}
+}
+
+func TestNew_CorrelationIdDefaultsToId(t *testing.T) {
+	env, err := New(context.Background(), "user-service", &ConfirmRegistrationMail{Recipient: recipient(), Token: "t"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if env.GetCorrelationId() != env.GetId() {
+		t.Fatalf("correlation id %q, want %q", env.GetCorrelationId(), env.GetId())
+	}
+}
+
+func TestNew_Invalid(t *testing.T) {
+	_, err := New(context.Background(), "user-service", &ConfirmRegistrationMail{Recipient: &Recipient{Email: "alice"}, Token: "t"})
+	if !errors.Is(err, ErrInvalidEvent) {
+		t.Fatalf("got %v, want ErrInvalidEvent", err)
+	}
+
+	_, err = New(context.Background(), "user-service", &Recipient{Email: "alice@example.com"})
+	if !errors.Is(err, ErrUnknownType) {
+		t.Fatalf("got %v, want ErrUnknownType", err)
+	}
+
+	// имя кампании - имя шаблона, поэтому в нём не может быть пути
+	_, err = New(context.Background(), "course-service", &EngagementMail{
+		Recipient: &Recipient{Email: "alice@example.com"}, Campaign: "../confirm_registration", CourseId: 1, CourseName: "Go",
+	})
+	if !errors.Is(err, ErrInvalidEvent) {
+		t.Fatalf("got %v, want ErrInvalidEvent", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := func() *Envelope {
+		env, err := Unmarshal(readFile(t, "testdata/welcome_course_v1.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return env
+	}
+
+	tests := []struct {
+		name   string
+		modify func(env *Envelope)
+		want   error
+	}{
+		{"bad id", func(env *Envelope) { env.Id = "42" }, ErrInvalidEvent},
+		{"no source", func(env *Envelope) { env.Source = "" }, ErrInvalidEvent},
+		{"no occurred_at", func(env *Envelope) { env.OccurredAt = nil }, ErrInvalidEvent},
+		{"no payload", func(env *Envelope) { env.Payload = nil }, ErrInvalidEvent},
+		{"type mismatch", func(env *Envelope) { env.Type = TypeDataExportMail }, ErrInvalidEvent},
+		{"newer version", func(env *Envelope) { env.Version = 2 }, ErrUnsupportedVersion},
+		{"zero version", func(env *Envelope) { env.Version = 0 }, ErrUnsupportedVersion},
+		{"bad payload", func(env *Envelope) { env.GetWelcomeCourseMail().CourseId = 0 }, ErrInvalidEvent},
+		{"no recipient", func(env *Envelope) { env.GetWelcomeCourseMail().Recipient = nil }, ErrInvalidEvent},
+		{"bad user id", func(env *Envelope) { env.GetWelcomeCourseMail().Recipient.UserId = -1 }, ErrInvalidEvent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := valid()
+			tt.modify(env)
+			if err := Validate(env); !errors.Is(err, tt.want) {
+				t.Fatalf("got %v, want %v", err, tt.want)
+			}
+			if _, err := Marshal(env); err == nil {
+				t.Fatal("invalid event marshaled")
+			}
+		})
+	}
+}
+
+func TestCategory(t *testing.T) {
+	tests := []struct {
+		payload Payload
+		want    string
+	}{
+		{&ConfirmRegistrationMail{Recipient: recipient(), Token: "t"}, CategoryTransactional},
+		{&DataExportMail{Recipient: recipient(), Url: "https://skill-force.ru/exports/1.zip"}, CategoryTransactional},
+		{&WelcomeCourseMail{Recipient: recipient(), CourseId: 1, CourseName: "Go"}, CategoryCourseProgress},
+		{&EngagementMail{Recipient: recipient(), Campaign: "mid_course", CourseId: 1, CourseName: "Go"}, CategoryCourseProgress},
+		{&EngagementMail{Recipient: recipient(), Campaign: "abandoned_checkout", CourseId: 1, CourseName: "Go", Category: CategoryMarketing}, CategoryMarketing},
+		{&WeeklyDigestMail{Recipient: recipient(), PeriodStart: timestamppb.New(time.Unix(0, 0)), PeriodEnd: timestamppb.Now()}, CategoryDigest},
+	}
+	for _, tt := range tests {
+		env, err := New(context.Background(), "course-service", tt.payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := Category(env); got != tt.want {
+			t.Fatalf("%s: category %q, want %q", env.GetType(), got, tt.want)
+		}
+		if GetRecipient(env).GetEmail() != "alice@example.com" {
+			t.Fatalf("%s: recipient %v", env.GetType(), GetRecipient(env))
+		}
+	}
+
+	_, err := New(context.Background(), "course-service", &EngagementMail{
+		Recipient: recipient(), Campaign: "mid_course", CourseId: 1, CourseName: "Go", Category: "spam",
+	})
+	if !errors.Is(err, ErrInvalidEvent) {
+		t.Fatalf("got %v, want ErrInvalidEvent", err)
+	}
+}
+
+func TestWeeklyDigestMail_Validate(t *testing.T) {
+	valid := func() *WeeklyDigestMail {
+		env, err := Unmarshal(readFile(t, "testdata/weekly_digest_v1.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return env.GetWeeklyDigestMail()
+	}
+
+	tests := []struct {
+		name   string
+		modify func(m *WeeklyDigestMail)
+	}{
+		{"no period", func(m *WeeklyDigestMail) { m.PeriodStart = nil }},
+		{"reversed period", func(m *WeeklyDigestMail) { m.PeriodStart, m.PeriodEnd = m.PeriodEnd, m.PeriodStart }},
+		{"negative lessons", func(m *WeeklyDigestMail) { m.LessonsCompleted = -1 }},
+		{"bad course", func(m *WeeklyDigestMail) { m.Courses[0].ProgressPercent = 101 }},
+		{"bad new course", func(m *WeeklyDigestMail) { m.NewCourses[0].CourseName = "" }},
+		{"bad rating", func(m *WeeklyDigestMail) { m.Ratings[0].Position = 0 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := valid()
+			tt.modify(m)
+			if m.Validate() == nil {
+				t.Fatal("invalid digest accepted")
+			}
+		})
+	}
+}
+
+// Сообщения, записанные прошлыми версиями продюсеров, должны читаться текущим кодом
+func TestCompatibility_Golden(t *testing.T) {
+	files, err := filepath.Glob("testdata/*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := make(map[string]bool)
+	for _, file := range files {
+		env, err := Unmarshal(readFile(t, file))
+		if err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+		seen[env.GetType()] = true
+	}
+	for _, contract := range contracts {
+		if !seen[contract.Type] {
+			t.Errorf("no testdata for %s", contract.Type)
+		}
+	}
+}
+
+// Продюсер новее консьюмера: незнакомые поля пропускаются
+func TestCompatibility_UnknownFields(t *testing.T) {
+	data := strings.Replace(string(readFile(t, "testdata/data_export_v1.json")),
+		`"source"`, `"priority": "high", "source"`, 1)
+	data = strings.Replace(data, `"url"`, `"expires_at": "2025-03-08T10:00:00Z", "url"`, 1)
+
+	env, err := Unmarshal([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if env.GetDataExportMail().GetUrl() != "https://skill-force.ru/exports/1.zip" {
+		t.Fatalf("unexpected payload %v", env.GetDataExportMail())
+	}
+}
+
+// testdata/schema.golden - поля всех сообщений. Поле из файла нельзя удалить, переименовать
+// или сменить ему номер и тип; новые поля дописываются в файл
+func TestCompatibility_Schema(t *testing.T) {
+	current := make(map[string]string)
+	collectFields((&Envelope{}).ProtoReflect().Descriptor(), current)
+
+	file, err := os.Open("testdata/schema.golden")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	known := make(map[string]bool)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, _, _ := strings.Cut(line, " ")
+		known[name] = true
+		if current[name] != line {
+			t.Errorf("incompatible change: was %q, now %q", line, current[name])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+	for name, line := range current {
+		if !known[name] {
+			t.Errorf("new field is not in testdata/schema.golden: %s", line)
+		}
+	}
+}
+
+// Пакет копируется из events/ в корне репозитория скриптом events/sync.sh: копия не должна отличаться
+// ни одним файлом, кроме generate.go. В самом events/ и вне репозитория (например, при сборке образа сервиса)
+// проверять не с чем
+func TestCopies(t *testing.T) {
+	if _, err := os.Stat("sync.sh"); err == nil {
+		t.Skip("events/ is the source")
+	}
+	source := filepath.Join("..", "..", "..", "events")
+	if _, err := os.Stat(filepath.Join(source, "sync.sh")); errors.Is(err, os.ErrNotExist) {
+		t.Skip("events/ not found")
+	}
+
+	sourceFiles := listFiles(t, source)
+	for _, name := range []string{"sync.sh", "go.mod", "go.sum"} {
+		delete(sourceFiles, name)
+	}
+	ownFiles := listFiles(t, ".")
+	delete(ownFiles, "generate.go")
+	for name, data := range sourceFiles {
+		own, ok := ownFiles[name]
+		if !ok {
+			t.Errorf("%s is missing, run go generate ./pkg/events", name)
+			continue
+		}
+		if string(own) != string(data) {
+			t.Errorf("%s differs from events/%s, run go generate ./pkg/events", name, name)
+		}
+	}
+	for name := range ownFiles {
+		if _, ok := sourceFiles[name]; !ok {
+			t.Errorf("%s is not in events/, run go generate ./pkg/events", name)
+		}
+	}
+}
+
+// listFiles - содержимое файлов каталога dir по путям относительно него
+func listFiles(t *testing.T, dir string) map[string][]byte {
+	t.Helper()
+	files := make(map[string][]byte)
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(name)], err = os.ReadFile(path)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+func collectFields(message protoreflect.MessageDescriptor, fields map[string]string) {
+	if _, ok := fields[string(message.FullName())]; ok {
+		return
+	}
+	fields[string(message.FullName())] = string(message.FullName())
+	for i := 0; i < message.Fields().Len(); i++ {
+		field := message.Fields().Get(i)
+		kind := field.Kind().String()
+		if field.Message() != nil {
+			kind = string(field.Message().FullName())
+		}
+		name := string(field.FullName())
+		fields[name] = fmt.Sprintf("%s %d %s", name, field.Number(), kind)
+		if field.Message() != nil && field.Message().ParentFile() == message.ParentFile() {
+			collectFields(field.Message(), fields)
+		}
+	}
+}
+
+func readFile(t *testing.T, path string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
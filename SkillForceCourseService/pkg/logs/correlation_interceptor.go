@@ -0,0 +1,35 @@
+package logs
+
+import (
+	"context"
+
+	"skillForce/pkg/events"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// CorrelationHeader - metadata с id запроса. События, созданные при обработке запроса, получают
+// этот id в correlation_id
+const CorrelationHeader = "x-correlation-id"
+
+// CorrelationInterceptor - id запроса из metadata или новый, если вызывающий его не передал
+func CorrelationInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		correlationId := ""
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(CorrelationHeader); len(values) > 0 {
+				correlationId = values[0]
+			}
+		}
+		if correlationId == "" {
+			correlationId = events.NewCorrelationId()
+		}
+		return handler(events.WithCorrelationId(ctx, correlationId), req)
+	}
+}
@@ -0,0 +1,16 @@
+package logs
+
+import (
+	"context"
+	"time"
+)
+
+// RunJob - запуск фоновой задачи с тем же сбором логов, что и у gRPC запроса
+func RunJob(ctx context.Context, name string, job func(ctx context.Context)) {
+	start := time.Now()
+	ctx = context.WithValue(ctx, LogsKey, &CtxLog{
+		Data: make([]*LogString, 0),
+	})
+	job(ctx)
+	logContext(ctx, name, start)
+}
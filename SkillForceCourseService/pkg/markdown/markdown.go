@@ -0,0 +1,32 @@
+package markdown
+
+import (
+	"bytes"
+	"skillForce/pkg/sanitize"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+// md - CommonMark с таблицами, зачёркиванием, списками задач и ссылками из GFM и формулами.
+// Сырой HTML не выводится: goldmark без WithUnsafe заменяет его комментарием
+var md = goldmark.New(
+	goldmark.WithExtensions(
+		extension.NewTable(extension.WithTableCellAlignMethod(extension.TableCellAlignAttribute)),
+		extension.Strikethrough,
+		extension.Linkify,
+		extension.TaskList,
+		&mathExtension{},
+	),
+)
+
+// Render - HTML урока из markdown исходника автора. Результат очищается той же политикой,
+// что и при выдаче, поэтому предпросмотр совпадает с тем, что увидит ученик
+func Render(source string) (string, error) {
+	var buf bytes.Buffer
+	if err := md.Convert([]byte(source), &buf); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(sanitize.Markdown(buf.String())), nil
+}
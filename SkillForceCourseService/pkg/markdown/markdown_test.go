@@ -0,0 +1,101 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "emphasis and headings",
+			source:   "# Title\n\n**bold** *em* ~~del~~",
+			contains: []string{"<h1>Title</h1>", "<strong>bold</strong>", "<em>em</em>", "<del>del</del>"},
+		},
+		{
+			name:     "table",
+			source:   "| a | b |\n|:--|--:|\n| 1 | 2 |",
+			contains: []string{"<table>", `<th align="left">a</th>`, `<td align="right">2</td>`},
+		},
+		{
+			name:     "fenced code",
+			source:   "```go\nfmt.Println(\"<b>\")\n```",
+			contains: []string{`<pre><code class="language-go">fmt.Println(&#34;&lt;b&gt;&#34;)`},
+		},
+		{
+			name:     "c++ code",
+			source:   "```c++\nint main() {}\n```",
+			contains: []string{`<code class="language-c++">`},
+		},
+		{
+			name:     "links",
+			source:   "[site](https://example.com) and https://go.dev",
+			contains: []string{`href="https://example.com"`, `rel="nofollow noopener"`, `target="_blank"`, `href="https://go.dev"`},
+		},
+		{
+			name:     "javascript link",
+			source:   "[click](javascript:alert(1))",
+			excludes: []string{"javascript", "href"},
+		},
+		{
+			name:     "raw html",
+			source:   "<script>alert(1)</script>\n\ntext <img src=x onerror=alert(1)> <b onclick=x>b</b>",
+			contains: []string{"text"},
+			excludes: []string{"<script", "alert", "onerror", "onclick", "<img"},
+		},
+		{
+			name:     "inline math",
+			source:   "Energy $E = mc^2$ and $a<b$",
+			contains: []string{`<span class="math math-inline">E = mc^2</span>`, `<span class="math math-inline">a&lt;b</span>`},
+		},
+		{
+			name:     "prices are not math",
+			source:   "from $5 to $10",
+			contains: []string{"from $5 to $10"},
+			excludes: []string{"math"},
+		},
+		{
+			name:     "display math block",
+			source:   "$$\n\\int_0^1 x\\,dx\n= \\frac{1}{2}\n$$\n\nafter",
+			contains: []string{"<div class=\"math math-display\">\\int_0^1 x\\,dx\n= \\frac{1}{2}</div>", "<p>after</p>"},
+		},
+		{
+			name:     "single line display math",
+			source:   "$$x^2$$",
+			contains: []string{`<div class="math math-display">x^2</div>`},
+		},
+		{
+			name:     "task list",
+			source:   "- [x] done\n- [ ] todo",
+			contains: []string{`<input checked="" disabled="" type="checkbox"`, `<input disabled="" type="checkbox"`},
+		},
+		{
+			name:     "image",
+			source:   "![alt](https://example.com/a.png)",
+			excludes: []string{"<img"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Render(tt.source)
+			if err != nil {
+				t.Fatalf("Render() error = %v", err)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(got, want) {
+					t.Errorf("Render() = %q, want it to contain %q", got, want)
+				}
+			}
+			for _, unwanted := range tt.excludes {
+				if strings.Contains(got, unwanted) {
+					t.Errorf("Render() = %q, want it not to contain %q", got, unwanted)
+				}
+			}
+		})
+	}
+}
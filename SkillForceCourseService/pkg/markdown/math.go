@@ -0,0 +1,203 @@
+package markdown
+
+import (
+	"bytes"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// Формулы TeX: $...$ в тексте, $$...$$ отдельной строкой или блоком из нескольких строк.
+// Сервер формулы не рисует, а отдаёт экранированный TeX в элементах с классами math-inline
+// и math-display, которые клиент передаёт в KaTeX
+
+var (
+	KindMathInline = ast.NewNodeKind("MathInline")
+	KindMathBlock  = ast.NewNodeKind("MathBlock")
+)
+
+type MathInline struct {
+	ast.BaseInline
+	Content []byte
+	Display bool
+}
+
+func (n *MathInline) Kind() ast.NodeKind {
+	return KindMathInline
+}
+
+func (n *MathInline) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"Content": string(n.Content)}, nil)
+}
+
+type MathBlock struct {
+	ast.BaseBlock
+	Content []byte
+	closed  bool
+}
+
+func (n *MathBlock) Kind() ast.NodeKind {
+	return KindMathBlock
+}
+
+func (n *MathBlock) IsRaw() bool {
+	return true
+}
+
+func (n *MathBlock) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"Content": string(n.Content)}, nil)
+}
+
+type mathInlineParser struct{}
+
+func (p *mathInlineParser) Trigger() []byte {
+	return []byte{'$'}
+}
+
+// Parse - как в pandoc: после открывающего и перед закрывающим $ нет пробела, а после закрывающего
+// нет цифры, поэтому "от $5 до $10" остаётся текстом
+func (p *mathInlineParser) Parse(parent ast.Node, block text.Reader, pc parser.Context) ast.Node {
+	line, _ := block.PeekLine()
+
+	if bytes.HasPrefix(line, []byte("$$")) {
+		end := bytes.Index(line[2:], []byte("$$"))
+		if end <= 0 {
+			return nil
+		}
+		block.Advance(end + 4)
+		return &MathInline{Content: bytes.TrimSpace(line[2 : end+2]), Display: true}
+	}
+
+	if len(line) < 3 || util.IsSpace(line[1]) {
+		return nil
+	}
+	for i := 2; i < len(line); i++ {
+		if line[i] != '$' || line[i-1] == '\\' {
+			continue
+		}
+		if util.IsSpace(line[i-1]) || (i+1 < len(line) && line[i+1] >= '0' && line[i+1] <= '9') {
+			return nil
+		}
+		block.Advance(i + 1)
+		return &MathInline{Content: line[1:i]}
+	}
+	return nil
+}
+
+type mathBlockParser struct{}
+
+func (p *mathBlockParser) Trigger() []byte {
+	return []byte{'$'}
+}
+
+func (p *mathBlockParser) Open(parent ast.Node, reader text.Reader, pc parser.Context) (ast.Node, parser.State) {
+	line, _ := reader.PeekLine()
+	pos := pc.BlockOffset()
+	if pos < 0 || !bytes.HasPrefix(line[pos:], []byte("$$")) {
+		return nil, parser.NoChildren
+	}
+
+	node := &MathBlock{}
+	rest := bytes.TrimSpace(line[pos+2:])
+	// $$ ... $$ в одну строку
+	if len(rest) >= 2 && bytes.HasSuffix(rest, []byte("$$")) {
+		node.Content = bytes.TrimSpace(rest[:len(rest)-2])
+		node.closed = true
+	} else if len(rest) > 0 {
+		node.Content = append(node.Content, rest...)
+		node.Content = append(node.Content, '\n')
+	}
+	advanceLine(reader)
+	return node, parser.NoChildren
+}
+
+func (p *mathBlockParser) Continue(node ast.Node, reader text.Reader, pc parser.Context) parser.State {
+	math := node.(*MathBlock)
+	if math.closed {
+		return parser.Close
+	}
+
+	line, _ := reader.PeekLine()
+	trimmed := bytes.TrimSpace(line)
+	if bytes.HasSuffix(trimmed, []byte("$$")) {
+		math.Content = append(math.Content, bytes.TrimSpace(trimmed[:len(trimmed)-2])...)
+		math.Content = bytes.TrimSpace(math.Content)
+		math.closed = true
+		advanceLine(reader)
+		return parser.Close
+	}
+
+	math.Content = append(math.Content, trimmed...)
+	math.Content = append(math.Content, '\n')
+	advanceLine(reader)
+	return parser.Continue | parser.NoChildren
+}
+
+func (p *mathBlockParser) Close(node ast.Node, reader text.Reader, pc parser.Context) {
+	math := node.(*MathBlock)
+	math.Content = bytes.TrimSpace(math.Content)
+}
+
+func (p *mathBlockParser) CanInterruptParagraph() bool {
+	return true
+}
+
+func (p *mathBlockParser) CanAcceptIndentedLine() bool {
+	return false
+}
+
+// advanceLine - переход к следующей строке без перевода строки текущей, как в парсере блоков кода
+func advanceLine(reader text.Reader) {
+	line, segment := reader.PeekLine()
+	newline := 0
+	if len(line) > 0 && line[len(line)-1] == '\n' {
+		newline = 1
+	}
+	reader.Advance(segment.Stop - segment.Start - newline + segment.Padding)
+}
+
+type mathRenderer struct{}
+
+func (r *mathRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(KindMathInline, r.renderInline)
+	reg.Register(KindMathBlock, r.renderBlock)
+}
+
+func (r *mathRenderer) renderInline(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	math := node.(*MathInline)
+	class := "math math-inline"
+	if math.Display {
+		class = "math math-display"
+	}
+	_, _ = w.WriteString(`<span class="` + class + `">`)
+	_, _ = w.Write(util.EscapeHTML(math.Content))
+	_, _ = w.WriteString("</span>")
+	return ast.WalkSkipChildren, nil
+}
+
+func (r *mathRenderer) renderBlock(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	_, _ = w.WriteString(`<div class="math math-display">`)
+	_, _ = w.Write(util.EscapeHTML(node.(*MathBlock).Content))
+	_, _ = w.WriteString("</div>\n")
+	return ast.WalkSkipChildren, nil
+}
+
+type mathExtension struct{}
+
+func (e *mathExtension) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(
+		parser.WithBlockParsers(util.Prioritized(&mathBlockParser{}, 701)),
+		parser.WithInlineParsers(util.Prioritized(&mathInlineParser{}, 501)),
+	)
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(util.Prioritized(&mathRenderer{}, 500)))
+}
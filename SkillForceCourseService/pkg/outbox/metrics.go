@@ -0,0 +1,32 @@
+package outbox
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	Published = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "outbox_published_total",
+			Help: "Количество событий outbox, отправленных в Kafka",
+		},
+		[]string{"topic"},
+	)
+
+	PublishErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "outbox_publish_errors_total",
+			Help: "Количество неудачных попыток отправить событие outbox",
+		},
+		[]string{"topic"},
+	)
+
+	Pending = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "outbox_pending_events",
+			Help: "Количество событий outbox, ещё не отправленных в Kafka",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(Published, PublishErrors, Pending)
+}
@@ -0,0 +1,51 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"skillForce/pkg/events"
+)
+
+// HeaderEventId - заголовок Kafka сообщения с id события. Повторная публикация того же события
+// приходит с тем же id, по нему консьюмер отбрасывает дубли
+const HeaderEventId = "event_id"
+
+// Event - событие, записанное в outbox_events и ещё не отправленное в Kafka
+type Event struct {
+	Id       int64
+	EventId  string
+	Topic    string
+	Key      string
+	Payload  []byte
+	Attempts int
+}
+
+// Execer - *sql.DB или *sql.Tx. Событие, записанное в транзакции, появится в outbox только вместе
+// с остальными изменениями этой транзакции
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// Enqueue - запись события для отправки в topic. Отправляет его Relay. Событие проверяется
+// до записи: невалидное событие не попадёт в Kafka
+func Enqueue(ctx context.Context, db Execer, topic string, key string, env *events.Envelope) error {
+	data, err := events.Marshal(env)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, "INSERT INTO outbox_events (event_id, source, topic, key, payload) VALUES ($1, $2, $3, $4, $5)",
+		env.GetId(), env.GetSource(), topic, key, data)
+	return err
+}
+
+// Backoff - пауза перед следующей попыткой отправить событие после attempts неудачных
+func Backoff(attempts int) time.Duration {
+	delay := time.Second
+	for i := 1; i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxBackoff)
+}
@@ -0,0 +1,103 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"skillForce/pkg/events"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePublisher struct {
+	published []string
+	fail      map[string]bool
+}
+
+func (p *fakePublisher) Publish(ctx context.Context, event Event) error {
+	if p.fail[event.EventId] {
+		return errors.New("broker is down")
+	}
+	p.published = append(p.published, event.EventId)
+	return nil
+}
+
+func TestEnqueue(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	env, err := events.New(context.Background(), "user-service", &events.ConfirmRegistrationMail{
+		Recipient: &events.Recipient{Email: "alice@example.com"},
+		Token:     "token",
+	})
+	require.NoError(t, err)
+	payload, err := events.Marshal(env)
+	require.NoError(t, err)
+
+	mock.ExpectExec("INSERT INTO outbox_events \\(event_id, source, topic, key, payload\\)").
+		WithArgs(env.GetId(), "user-service", "mail", "alice@example.com", payload).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	require.NoError(t, Enqueue(context.Background(), db, "mail", "alice@example.com", env))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEnqueue_Invalid(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	err = Enqueue(context.Background(), db, "mail", "", &events.Envelope{Type: events.TypeConfirmRegistrationMail})
+	require.ErrorIs(t, err, events.ErrInvalidEvent)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRelayOnce(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	publisher := &fakePublisher{fail: map[string]bool{"e2": true}}
+	relay := NewRelay(db, publisher, "user-service", Config{BatchSize: 10})
+
+	// строки из RETURNING приходят в произвольном порядке
+	mock.ExpectQuery("UPDATE outbox_events SET attempts = attempts \\+ 1").
+		WithArgs("user-service", 10, 60).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "topic", "key", "payload", "attempts"}).
+			AddRow(3, "e3", "mail", "", []byte("{}"), 1).
+			AddRow(1, "e1", "mail", "", []byte("{}"), 1).
+			AddRow(2, "e2", "mail", "", []byte("{}"), 3))
+	mock.ExpectExec("UPDATE outbox_events SET sent_at = NOW\\(\\)").WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE outbox_events SET next_attempt_at").WithArgs(int64(2), 4, "broker is down").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE outbox_events SET sent_at = NOW\\(\\)").WithArgs(int64(3)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	sent, err := relay.RelayOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 2, sent)
+	require.Equal(t, []string{"e1", "e3"}, publisher.published)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRelayOnce_MarkFailed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	relay := NewRelay(db, &fakePublisher{}, "user-service", Config{BatchSize: 10})
+
+	mock.ExpectQuery("UPDATE outbox_events SET attempts = attempts \\+ 1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "event_id", "topic", "key", "payload", "attempts"}).
+			AddRow(1, "e1", "mail", "", []byte("{}"), 1))
+	mock.ExpectExec("UPDATE outbox_events SET sent_at = NOW\\(\\)").WillReturnError(errors.New("db is down"))
+
+	sent, err := relay.RelayOnce(context.Background())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "e1")
+	require.Equal(t, 0, sent)
+}
+
+func TestBackoff(t *testing.T) {
+	require.Equal(t, time.Second, Backoff(1))
+	require.Equal(t, 4*time.Second, Backoff(3))
+	require.Equal(t, maxBackoff, Backoff(30))
+}
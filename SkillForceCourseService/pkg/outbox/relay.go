@@ -0,0 +1,157 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+)
+
+const (
+	// lease - на это время взятое событие скрыто от других экземпляров Relay. Если экземпляр упал
+	// до отметки об отправке, событие будет отправлено повторно
+	lease      = time.Minute
+	maxBackoff = 10 * time.Minute
+	errorLen   = 1000
+)
+
+// Publisher - отправка события в Kafka с ожиданием подтверждения
+type Publisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+type Config struct {
+	Interval  time.Duration
+	BatchSize int
+	// Retention - сколько хранить отправленные события. В письмах бывают токены, поэтому недолго
+	Retention time.Duration
+}
+
+// Relay - отправка событий source из outbox_events в Kafka. Несколько экземпляров сервиса могут
+// работать одновременно: событие берёт один из них
+type Relay struct {
+	db        *sql.DB
+	publisher Publisher
+	source    string
+	conf      Config
+}
+
+func NewRelay(db *sql.DB, publisher Publisher, source string, conf Config) *Relay {
+	return &Relay{db: db, publisher: publisher, source: source, conf: conf}
+}
+
+// claim - до BatchSize событий, время отправки которых пришло, в порядке записи
+func (r *Relay) claim(ctx context.Context) ([]Event, error) {
+	rows, err := r.db.QueryContext(ctx, `UPDATE outbox_events SET attempts = attempts + 1, next_attempt_at = NOW() + $3 * INTERVAL '1 second'
+		WHERE id IN (
+			SELECT id FROM outbox_events WHERE source = $1 AND sent_at IS NULL AND next_attempt_at <= NOW()
+			ORDER BY id LIMIT $2 FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, event_id, topic, key, payload, attempts`,
+		r.source, r.conf.BatchSize, int(lease.Seconds()))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []Event
+	for rows.Next() {
+		var event Event
+		if err := rows.Scan(&event.Id, &event.EventId, &event.Topic, &event.Key, &event.Payload, &event.Attempts); err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].Id < events[j].Id })
+	return events, rows.Err()
+}
+
+// RelayOnce - отправка одной пачки событий. Возвращает количество отправленных
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	events, err := r.claim(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, event := range events {
+		if err := r.publisher.Publish(ctx, event); err != nil {
+			PublishErrors.WithLabelValues(event.Topic).Inc()
+			message := err.Error()
+			if len(message) > errorLen {
+				message = message[:errorLen]
+			}
+			// событие будет отправлено позже, следующие в пачке не ждут
+			if _, err := r.db.ExecContext(ctx, "UPDATE outbox_events SET next_attempt_at = NOW() + $2 * INTERVAL '1 second', last_error = $3 WHERE id = $1",
+				event.Id, int(Backoff(event.Attempts).Seconds()), message); err != nil {
+				return sent, err
+			}
+			continue
+		}
+
+		if _, err := r.db.ExecContext(ctx, "UPDATE outbox_events SET sent_at = NOW(), last_error = NULL WHERE id = $1", event.Id); err != nil {
+			return sent, fmt.Errorf("event %s is published but not marked: %w", event.EventId, err)
+		}
+		Published.WithLabelValues(event.Topic).Inc()
+		sent++
+	}
+	return sent, nil
+}
+
+// Cleanup - удаление событий, отправленных раньше Retention
+func (r *Relay) Cleanup(ctx context.Context) (int64, error) {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM outbox_events WHERE source = $1 AND sent_at < NOW() - $2 * INTERVAL '1 second'",
+		r.source, int(r.conf.Retention.Seconds()))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (r *Relay) updatePending(ctx context.Context) error {
+	var pending int
+	err := r.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM outbox_events WHERE source = $1 AND sent_at IS NULL", r.source).Scan(&pending)
+	if err != nil {
+		return err
+	}
+	Pending.Set(float64(pending))
+	return nil
+}
+
+// Run - отправка событий раз в Interval до отмены контекста. Очистка запускается раз в час
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.conf.Interval)
+	defer ticker.Stop()
+
+	var lastCleanup time.Time
+	for {
+		// пачка за пачкой, пока события не кончатся
+		for ctx.Err() == nil {
+			sent, err := r.RelayOnce(ctx)
+			if err != nil {
+				log.Printf("outbox relay %s: %v", r.source, err)
+			}
+			if err != nil || sent < r.conf.BatchSize {
+				break
+			}
+		}
+		if err := r.updatePending(ctx); err != nil {
+			log.Printf("outbox relay %s: %v", r.source, err)
+		}
+
+		if time.Since(lastCleanup) > time.Hour {
+			if _, err := r.Cleanup(ctx); err != nil {
+				log.Printf("outbox cleanup %s: %v", r.source, err)
+			}
+			lastCleanup = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -1,11 +1,20 @@
 package sanitize
 
 import (
+	"html"
 	"regexp"
+	"strings"
 
 	"github.com/microcosm-cc/bluemonday"
 )
 
+// codeLanguageClass - класс подсветки кода для любого языка, а не только Go
+var codeLanguageClass = regexp.MustCompile(`^language-[a-z0-9][a-z0-9+#.-]{0,29}$`)
+
+var plainTextPolicy = bluemonday.StrictPolicy()
+
+var markdownPolicy = newMarkdownPolicy()
+
 func Sanitize(input string) string {
 	p := bluemonday.NewPolicy()
 	p.AllowStandardURLs()
@@ -15,6 +24,39 @@ func Sanitize(input string) string {
 	p.AllowElements("strong")
 	p.AllowElements("table", "tr", "td", "th")
 	p.AllowElements("pre", "code")
-	p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-go$`)).OnElements("code")
+	p.AllowAttrs("class").Matching(codeLanguageClass).OnElements("code")
 	return p.Sanitize(input)
 }
+
+// PlainText - текст без разметки для полей, которые клиент выводит как текст (подписи, варианты ответов)
+func PlainText(input string) string {
+	return strings.TrimSpace(html.UnescapeString(plainTextPolicy.Sanitize(input)))
+}
+
+// Markdown - очистка HTML, полученного из markdown: разметка CommonMark и GFM, подсветка кода и формулы.
+// Ссылки открываются в новой вкладке с nofollow, картинки вставляются только блоками image
+func Markdown(input string) string {
+	return markdownPolicy.Sanitize(input)
+}
+
+func newMarkdownPolicy() *bluemonday.Policy {
+	p := bluemonday.NewPolicy()
+	p.AllowStandardURLs()
+	p.AllowAttrs("href").OnElements("a")
+	p.RequireNoFollowOnLinks(true)
+	p.AddTargetBlankToFullyQualifiedLinks(true)
+	p.AllowElements("p", "br", "hr", "blockquote")
+	p.AllowElements("h1", "h2", "h3", "h4", "h5", "h6")
+	p.AllowElements("ul", "ol", "li")
+	p.AllowAttrs("start").Matching(bluemonday.Integer).OnElements("ol")
+	p.AllowElements("strong", "em", "del")
+	p.AllowElements("table", "thead", "tbody", "tr", "th", "td")
+	p.AllowAttrs("align").Matching(regexp.MustCompile(`^(left|center|right)$`)).OnElements("th", "td")
+	p.AllowElements("pre", "code")
+	p.AllowAttrs("class").Matching(codeLanguageClass).OnElements("code")
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^math math-(inline|display)$`)).OnElements("span", "div")
+	// списки задач
+	p.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	p.AllowAttrs("checked", "disabled").Matching(regexp.MustCompile(`^$`)).OnElements("input")
+	return p
+}
@@ -0,0 +1,24 @@
+package scheduler
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	JobsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "scheduler_jobs_total",
+			Help: "Количество выполненных отложенных задач",
+		},
+		[]string{"kind", "status"},
+	)
+
+	Delay = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "scheduler_delay_seconds",
+			Help: "Насколько опаздывает самая давняя задача, время которой пришло",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(JobsTotal, Delay)
+}
@@ -0,0 +1,193 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"skillForce/pkg/logs"
+
+	"github.com/lib/pq"
+)
+
+const (
+	// lease - на это время взятая задача скрыта от других экземпляров Scheduler. Если экземпляр упал
+	// до конца задачи, задачу возьмёт другой
+	lease      = 5 * time.Minute
+	maxBackoff = time.Hour
+	errorLen   = 1000
+)
+
+// Job - отложенная задача из scheduled_jobs
+type Job struct {
+	Id   int64
+	Kind string
+	// Key - отличает задачи одного вида, например id пользователя
+	Key     string
+	Payload []byte
+	// RunAt - время, на которое задача была запланирована
+	RunAt    time.Time
+	Attempts int
+}
+
+// Handler - выполнение задачи. Ненулевое next - время следующего запуска повторяющейся задачи,
+// нулевое - задача выполнена и удаляется. После ошибки задача повторяется с паузой
+type Handler func(ctx context.Context, job *Job) (next time.Time, err error)
+
+// Execer - *sql.DB или *sql.Tx
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+type Config struct {
+	Interval  time.Duration
+	BatchSize int
+}
+
+// Scheduler - выполнение задач из scheduled_jobs, время которых пришло. Задачи хранятся в базе
+// и переживают перезапуск. Несколько экземпляров сервиса могут работать одновременно: задачу
+// берёт один из них
+type Scheduler struct {
+	db       *sql.DB
+	conf     Config
+	handlers map[string]Handler
+}
+
+func New(db *sql.DB, conf Config) *Scheduler {
+	return &Scheduler{db: db, conf: conf, handlers: make(map[string]Handler)}
+}
+
+// Handle - обработчик задач вида kind. Задачи видов без обработчика этот экземпляр не берёт
+func (s *Scheduler) Handle(kind string, handler Handler) {
+	s.handlers[kind] = handler
+}
+
+// Schedule - задача kind с key на время runAt. Если такая задача уже есть, она не меняется
+func Schedule(ctx context.Context, db Execer, kind string, key string, runAt time.Time) error {
+	_, err := db.ExecContext(ctx, "INSERT INTO scheduled_jobs (kind, key, run_at) VALUES ($1, $2, $3) ON CONFLICT (kind, key) DO NOTHING",
+		kind, key, runAt)
+	return err
+}
+
+// Backoff - пауза перед повтором задачи после attempts неудачных попыток
+func Backoff(attempts int) time.Duration {
+	delay := time.Minute
+	for i := 1; i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxBackoff)
+}
+
+// claim - до BatchSize задач, время которых пришло, от самых давних
+func (s *Scheduler) claim(ctx context.Context) ([]*Job, error) {
+	kinds := make([]string, 0, len(s.handlers))
+	for kind := range s.handlers {
+		kinds = append(kinds, kind)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		WITH due AS (
+			SELECT id, run_at FROM scheduled_jobs WHERE kind = ANY($1) AND run_at <= NOW()
+			ORDER BY run_at LIMIT $2 FOR UPDATE SKIP LOCKED
+		)
+		UPDATE scheduled_jobs j SET attempts = j.attempts + 1, run_at = NOW() + $3 * INTERVAL '1 second'
+		FROM due WHERE j.id = due.id
+		RETURNING j.id, j.kind, j.key, j.payload, due.run_at, j.attempts`,
+		pq.Array(kinds), s.conf.BatchSize, int(lease.Seconds()))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []*Job
+	for rows.Next() {
+		var job Job
+		if err := rows.Scan(&job.Id, &job.Kind, &job.Key, &job.Payload, &job.RunAt, &job.Attempts); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, &job)
+	}
+	return jobs, rows.Err()
+}
+
+// RunOnce - выполнение одной пачки задач. Возвращает количество взятых задач
+func (s *Scheduler) RunOnce(ctx context.Context) (int, error) {
+	if len(s.handlers) == 0 {
+		return 0, nil
+	}
+	jobs, err := s.claim(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, job := range jobs {
+		var next time.Time
+		var jobErr error
+		logs.RunJob(ctx, job.Kind, func(ctx context.Context) {
+			next, jobErr = s.handlers[job.Kind](ctx, job)
+		})
+
+		switch {
+		case jobErr != nil:
+			JobsTotal.WithLabelValues(job.Kind, "error").Inc()
+			message := jobErr.Error()
+			if len(message) > errorLen {
+				message = message[:errorLen]
+			}
+			_, err = s.db.ExecContext(ctx, "UPDATE scheduled_jobs SET run_at = NOW() + $2 * INTERVAL '1 second', last_error = $3 WHERE id = $1",
+				job.Id, int(Backoff(job.Attempts).Seconds()), message)
+		case next.IsZero():
+			JobsTotal.WithLabelValues(job.Kind, "done").Inc()
+			_, err = s.db.ExecContext(ctx, "DELETE FROM scheduled_jobs WHERE id = $1", job.Id)
+		default:
+			JobsTotal.WithLabelValues(job.Kind, "done").Inc()
+			_, err = s.db.ExecContext(ctx, "UPDATE scheduled_jobs SET run_at = $2, attempts = 0, last_error = NULL WHERE id = $1", job.Id, next)
+		}
+		if err != nil {
+			// задача выполнена, но не отмечена: после аренды она запустится снова
+			return len(jobs), fmt.Errorf("%s job %s is not marked: %w", job.Kind, job.Key, err)
+		}
+	}
+	return len(jobs), nil
+}
+
+func (s *Scheduler) updateDelay(ctx context.Context) error {
+	var delay sql.NullFloat64
+	err := s.db.QueryRowContext(ctx, "SELECT EXTRACT(EPOCH FROM NOW() - MIN(run_at)) FROM scheduled_jobs WHERE run_at <= NOW()").Scan(&delay)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	Delay.Set(delay.Float64)
+	return nil
+}
+
+// Run - выполнение задач раз в Interval до отмены контекста
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.conf.Interval)
+	defer ticker.Stop()
+
+	for {
+		// пачка за пачкой, пока задачи не кончатся
+		for ctx.Err() == nil {
+			claimed, err := s.RunOnce(ctx)
+			if err != nil {
+				log.Printf("scheduler: %v", err)
+			}
+			if err != nil || claimed < s.conf.BatchSize {
+				break
+			}
+		}
+		if err := s.updateDelay(ctx); err != nil {
+			log.Printf("scheduler: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
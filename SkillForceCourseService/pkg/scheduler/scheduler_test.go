@@ -0,0 +1,77 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedule(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	runAt := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	mock.ExpectExec("INSERT INTO scheduled_jobs \\(kind, key, run_at\\) VALUES \\(\\$1, \\$2, \\$3\\) ON CONFLICT \\(kind, key\\) DO NOTHING").
+		WithArgs("weekly_digest", "7", runAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	require.NoError(t, Schedule(context.Background(), db, "weekly_digest", "7", runAt))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRunOnce(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	scheduler := New(db, Config{BatchSize: 10})
+	next := time.Date(2026, 10, 26, 9, 0, 0, 0, time.UTC)
+	var handled []string
+	scheduler.Handle("digest", func(ctx context.Context, job *Job) (time.Time, error) {
+		handled = append(handled, job.Key)
+		switch job.Key {
+		case "failing":
+			return time.Time{}, errors.New("db is down")
+		case "repeating":
+			return next, nil
+		}
+		return time.Time{}, nil
+	})
+
+	runAt := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	mock.ExpectQuery("UPDATE scheduled_jobs j SET attempts = j.attempts \\+ 1").
+		WithArgs(pq.Array([]string{"digest"}), 10, 300).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "kind", "key", "payload", "run_at", "attempts"}).
+			AddRow(1, "digest", "once", []byte("{}"), runAt, 1).
+			AddRow(2, "digest", "failing", []byte("{}"), runAt, 3).
+			AddRow(3, "digest", "repeating", []byte("{}"), runAt, 1))
+	mock.ExpectExec("DELETE FROM scheduled_jobs WHERE id = \\$1").WithArgs(int64(1)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE scheduled_jobs SET run_at = NOW\\(\\)").WithArgs(int64(2), 240, "db is down").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE scheduled_jobs SET run_at = \\$2, attempts = 0").WithArgs(int64(3), next).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	claimed, err := scheduler.RunOnce(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 3, claimed)
+	require.Equal(t, []string{"once", "failing", "repeating"}, handled)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRunOnce_NoHandlers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	claimed, err := New(db, Config{BatchSize: 10}).RunOnce(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, claimed)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBackoff(t *testing.T) {
+	require.Equal(t, time.Minute, Backoff(1))
+	require.Equal(t, 4*time.Minute, Backoff(3))
+	require.Equal(t, time.Hour, Backoff(100))
+}
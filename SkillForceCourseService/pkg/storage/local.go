@@ -0,0 +1,371 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// служебные папки начинаются с точки, бакет так называться не может
+	localMetaDir    = ".meta"
+	localUploadsDir = ".uploads"
+	localTempPrefix = ".tmp-"
+	maxPartNumber   = 10000
+)
+
+// localStorage - бакеты в виде папок на диске: <dir>/<бакет>/<объект>. Тип содержимого и ETag лежат
+// рядом в <dir>/.meta. Для разработки и тестов: ссылки Presign не подписаны и не истекают, объекты по ним
+// отдаёт main-service (storage.public_url указывает на его /storage/)
+type localStorage struct {
+	root      string
+	publicURL string
+}
+
+type localMeta struct {
+	ContentType  string `json:"content_type"`
+	CacheControl string `json:"cache_control,omitempty"`
+	ETag         string `json:"etag"`
+}
+
+type localUpload struct {
+	Bucket string     `json:"bucket"`
+	Name   string     `json:"name"`
+	Opts   PutOptions `json:"opts"`
+}
+
+func NewLocal(dir string, publicURL string) (Storage, error) {
+	if dir == "" {
+		return nil, errors.New("local storage dir is not set")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &localStorage{root: dir, publicURL: publicURL}, nil
+}
+
+func validBucket(bucket string) bool {
+	return bucket != "" && !strings.HasPrefix(bucket, ".") && !strings.ContainsAny(bucket, "/\\")
+}
+
+func (s *localStorage) objectPath(bucket string, name string) (string, error) {
+	if !validBucket(bucket) || !validName(name) {
+		return "", ErrInvalidObjectName
+	}
+	return filepath.Join(s.root, bucket, filepath.FromSlash(name)), nil
+}
+
+func (s *localStorage) metaPath(bucket string, name string) string {
+	return filepath.Join(s.root, localMetaDir, bucket, filepath.FromSlash(name)+".json")
+}
+
+// writeAtomic - запись во временный файл рядом с dst и переименование, чтобы читатели
+// не увидели недописанный объект. При size >= 0 данных должно быть ровно size байт.
+// Возвращает md5 записанного
+func writeAtomic(dst string, data io.Reader, size int64) (string, error) {
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return "", err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(dst), localTempPrefix+"*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	if size >= 0 {
+		data = io.LimitReader(data, size)
+	}
+	hash := md5.New()
+	n, err := io.Copy(io.MultiWriter(tmp, hash), data)
+	if err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if size >= 0 && n != size {
+		return "", fmt.Errorf("%s: read %d of %d bytes", filepath.Base(dst), n, size)
+	}
+	if err := os.Rename(tmp.Name(), dst); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func (s *localStorage) writeMeta(bucket string, name string, meta localMeta) error {
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	_, err = writeAtomic(s.metaPath(bucket, name), bytes.NewReader(data), -1)
+	return err
+}
+
+func (s *localStorage) Put(ctx context.Context, bucket string, name string, data io.Reader, size int64, opts PutOptions) error {
+	dst, err := s.objectPath(bucket, name)
+	if err != nil {
+		return err
+	}
+	etag, err := writeAtomic(dst, data, size)
+	if err != nil {
+		return err
+	}
+	return s.writeMeta(bucket, name, localMeta{ContentType: opts.ContentType, CacheControl: opts.CacheControl, ETag: etag})
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+func (s *localStorage) GetRange(ctx context.Context, bucket string, name string, start int64, end int64) (io.ReadCloser, error) {
+	info, err := s.Stat(ctx, bucket, name)
+	if err != nil {
+		return nil, err
+	}
+	if start < 0 || (end >= 0 && end < start) || (start > 0 && start >= info.Size) {
+		return nil, fmt.Errorf("invalid range %d-%d for object of %d bytes", start, end, info.Size)
+	}
+
+	src, _ := s.objectPath(bucket, name)
+	file, err := os.Open(src)
+	if err != nil {
+		return nil, localError(err)
+	}
+	if _, err := file.Seek(start, io.SeekStart); err != nil {
+		file.Close()
+		return nil, err
+	}
+	if end < 0 {
+		return file, nil
+	}
+	return readCloser{Reader: io.LimitReader(file, end-start+1), Closer: file}, nil
+}
+
+// localError - отсутствующий файл превращается в ErrNotFound, как у MinIO
+func localError(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w: %v", ErrNotFound, err)
+	}
+	return err
+}
+
+func (s *localStorage) Stat(ctx context.Context, bucket string, name string) (ObjectInfo, error) {
+	src, err := s.objectPath(bucket, name)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	info, err := os.Stat(src)
+	if err != nil {
+		return ObjectInfo{}, localError(err)
+	}
+	if info.IsDir() {
+		return ObjectInfo{}, fmt.Errorf("%w: %s is a folder", ErrNotFound, name)
+	}
+
+	meta := localMeta{ContentType: "application/octet-stream"}
+	if data, err := os.ReadFile(s.metaPath(bucket, name)); err == nil {
+		if err := json.Unmarshal(data, &meta); err != nil {
+			return ObjectInfo{}, err
+		}
+	}
+	return ObjectInfo{Name: name, Size: info.Size(), ContentType: meta.ContentType, ETag: meta.ETag, LastModified: info.ModTime()}, nil
+}
+
+func (s *localStorage) Delete(ctx context.Context, bucket string, name string) error {
+	src, err := s.objectPath(bucket, name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(src); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err := os.Remove(s.metaPath(bucket, name)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Presign - ссылка без срока действия: проверить срок без подписи нельзя, поэтому ttl не используется
+func (s *localStorage) Presign(ctx context.Context, bucket string, name string, ttl time.Duration, params url.Values) (string, error) {
+	if _, err := s.objectPath(bucket, name); err != nil {
+		return "", err
+	}
+	link := PublicURL(s.publicURL, bucket, name)
+	if len(params) > 0 {
+		link += "?" + params.Encode()
+	}
+	return link, nil
+}
+
+func (s *localStorage) List(ctx context.Context, bucket string, prefix string) ([]ObjectInfo, error) {
+	if !validBucket(bucket) {
+		return nil, ErrInvalidObjectName
+	}
+	dir := filepath.Join(s.root, bucket)
+
+	var objects []ObjectInfo
+	err := filepath.WalkDir(dir, func(filePath string, entry fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) && filePath == dir {
+			return fs.SkipAll
+		}
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), localTempPrefix) {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, filePath)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		if !strings.HasPrefix(name, prefix) {
+			return nil
+		}
+		info, err := s.Stat(ctx, bucket, name)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, info)
+		return nil
+	})
+	return objects, err
+}
+
+func (s *localStorage) uploadDir(uploadId string) (string, error) {
+	if _, err := hex.DecodeString(uploadId); err != nil || uploadId == "" {
+		return "", fmt.Errorf("%w: upload %s", ErrNotFound, uploadId)
+	}
+	return filepath.Join(s.root, localUploadsDir, uploadId), nil
+}
+
+// upload - загрузка uploadId, начатая для того же объекта
+func (s *localStorage) upload(bucket string, name string, uploadId string) (string, *localUpload, error) {
+	dir, err := s.uploadDir(uploadId)
+	if err != nil {
+		return "", nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "upload.json"))
+	if err != nil {
+		return "", nil, localError(err)
+	}
+	var upload localUpload
+	if err := json.Unmarshal(data, &upload); err != nil {
+		return "", nil, err
+	}
+	if upload.Bucket != bucket || upload.Name != name {
+		return "", nil, fmt.Errorf("%w: upload %s is for another object", ErrNotFound, uploadId)
+	}
+	return dir, &upload, nil
+}
+
+func partPath(dir string, partNumber int) string {
+	return filepath.Join(dir, fmt.Sprintf("part-%05d", partNumber))
+}
+
+func (s *localStorage) NewMultipartUpload(ctx context.Context, bucket string, name string, opts PutOptions) (string, error) {
+	if _, err := s.objectPath(bucket, name); err != nil {
+		return "", err
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	uploadId := hex.EncodeToString(id)
+
+	data, err := json.Marshal(localUpload{Bucket: bucket, Name: name, Opts: opts})
+	if err != nil {
+		return "", err
+	}
+	dir, _ := s.uploadDir(uploadId)
+	if _, err := writeAtomic(filepath.Join(dir, "upload.json"), bytes.NewReader(data), -1); err != nil {
+		return "", err
+	}
+	return uploadId, nil
+}
+
+func (s *localStorage) PutPart(ctx context.Context, bucket string, name string, uploadId string, partNumber int, data io.Reader, size int64) (string, error) {
+	if partNumber < 1 || partNumber > maxPartNumber {
+		return "", fmt.Errorf("invalid part number %d", partNumber)
+	}
+	dir, _, err := s.upload(bucket, name, uploadId)
+	if err != nil {
+		return "", err
+	}
+
+	return writeAtomic(partPath(dir, partNumber), data, size)
+}
+
+// CompleteMultipartUpload - части склеиваются в объект в порядке parts. ETag объекта считается,
+// как у S3: md5 от md5 частей и их количество
+func (s *localStorage) CompleteMultipartUpload(ctx context.Context, bucket string, name string, uploadId string, parts []Part) error {
+	dir, upload, err := s.upload(bucket, name, uploadId)
+	if err != nil {
+		return err
+	}
+	if len(parts) == 0 {
+		return errors.New("no parts to complete")
+	}
+
+	readers := make([]io.Reader, 0, len(parts))
+	sums := md5.New()
+	for i, part := range parts {
+		if i > 0 && part.PartNumber <= parts[i-1].PartNumber {
+			return errors.New("parts must be in ascending order")
+		}
+		file, err := os.Open(partPath(dir, part.PartNumber))
+		if err != nil {
+			return localError(err)
+		}
+		defer file.Close()
+
+		hash := md5.New()
+		if _, err := io.Copy(hash, file); err != nil {
+			return err
+		}
+		if hex.EncodeToString(hash.Sum(nil)) != strings.Trim(part.ETag, `"`) {
+			return fmt.Errorf("part %d: etag mismatch", part.PartNumber)
+		}
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		sums.Write(hash.Sum(nil))
+		readers = append(readers, file)
+	}
+
+	dst, _ := s.objectPath(bucket, name)
+	if _, err := writeAtomic(dst, io.MultiReader(readers...), -1); err != nil {
+		return err
+	}
+	etag := fmt.Sprintf("%s-%d", hex.EncodeToString(sums.Sum(nil)), len(parts))
+	if err := s.writeMeta(bucket, name, localMeta{ContentType: upload.Opts.ContentType, CacheControl: upload.Opts.CacheControl, ETag: etag}); err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
+
+func (s *localStorage) AbortMultipartUpload(ctx context.Context, bucket string, name string, uploadId string) error {
+	dir, _, err := s.upload(bucket, name, uploadId)
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
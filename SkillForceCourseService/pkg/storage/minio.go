@@ -0,0 +1,131 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/minio/minio-go"
+)
+
+type minioStorage struct {
+	client *minio.Client
+}
+
+func NewMinio(endpoint string, accessKeyID string, secretAccessKey string, useSSL bool) (Storage, error) {
+	client, err := minio.New(endpoint, accessKeyID, secretAccessKey, useSSL)
+	if err != nil {
+		return nil, err
+	}
+	return &minioStorage{client: client}, nil
+}
+
+// minioError - отсутствующий объект превращается в ErrNotFound, чтобы его можно было отличить от сбоя
+func minioError(err error) error {
+	if err == nil {
+		return nil
+	}
+	switch minio.ToErrorResponse(err).Code {
+	case "NoSuchKey", "NoSuchBucket", "NoSuchUpload":
+		return fmt.Errorf("%w: %v", ErrNotFound, err)
+	}
+	return err
+}
+
+func (s *minioStorage) Put(ctx context.Context, bucket string, name string, data io.Reader, size int64, opts PutOptions) error {
+	_, err := s.client.PutObjectWithContext(ctx, bucket, name, data, size, minio.PutObjectOptions{
+		ContentType:  opts.ContentType,
+		CacheControl: opts.CacheControl,
+	})
+	return minioError(err)
+}
+
+func (s *minioStorage) GetRange(ctx context.Context, bucket string, name string, start int64, end int64) (io.ReadCloser, error) {
+	opts := minio.GetObjectOptions{}
+	var err error
+	switch {
+	case end >= 0:
+		err = opts.SetRange(start, end)
+	case start > 0:
+		// у minio-go конец 0 означает чтение до конца объекта
+		err = opts.SetRange(start, 0)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	object, err := s.client.GetObjectWithContext(ctx, bucket, name, opts)
+	if err != nil {
+		return nil, minioError(err)
+	}
+	return object, nil
+}
+
+func (s *minioStorage) Stat(ctx context.Context, bucket string, name string) (ObjectInfo, error) {
+	info, err := s.client.StatObject(bucket, name, minio.StatObjectOptions{})
+	if err != nil {
+		return ObjectInfo{}, minioError(err)
+	}
+	return ObjectInfo{Name: name, Size: info.Size, ContentType: info.ContentType, ETag: info.ETag, LastModified: info.LastModified}, nil
+}
+
+func (s *minioStorage) Delete(ctx context.Context, bucket string, name string) error {
+	return minioError(s.client.RemoveObject(bucket, name))
+}
+
+func (s *minioStorage) Presign(ctx context.Context, bucket string, name string, ttl time.Duration, params url.Values) (string, error) {
+	link, err := s.client.PresignedGetObject(bucket, name, ttl, params)
+	if err != nil {
+		return "", minioError(err)
+	}
+	return link.String(), nil
+}
+
+func (s *minioStorage) List(ctx context.Context, bucket string, prefix string) ([]ObjectInfo, error) {
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+
+	var objects []ObjectInfo
+	for info := range s.client.ListObjectsV2(bucket, prefix, true, doneCh) {
+		if info.Err != nil {
+			return nil, minioError(info.Err)
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		objects = append(objects, ObjectInfo{Name: info.Key, Size: info.Size, ContentType: info.ContentType, ETag: info.ETag, LastModified: info.LastModified})
+	}
+	return objects, nil
+}
+
+func (s *minioStorage) NewMultipartUpload(ctx context.Context, bucket string, name string, opts PutOptions) (string, error) {
+	core := minio.Core{Client: s.client}
+	uploadId, err := core.NewMultipartUpload(bucket, name, minio.PutObjectOptions{ContentType: opts.ContentType, CacheControl: opts.CacheControl})
+	return uploadId, minioError(err)
+}
+
+func (s *minioStorage) PutPart(ctx context.Context, bucket string, name string, uploadId string, partNumber int, data io.Reader, size int64) (string, error) {
+	core := minio.Core{Client: s.client}
+	part, err := core.PutObjectPart(bucket, name, uploadId, partNumber, data, size, "", "", nil)
+	if err != nil {
+		return "", minioError(err)
+	}
+	return part.ETag, nil
+}
+
+func (s *minioStorage) CompleteMultipartUpload(ctx context.Context, bucket string, name string, uploadId string, parts []Part) error {
+	core := minio.Core{Client: s.client}
+	completeParts := make([]minio.CompletePart, 0, len(parts))
+	for _, part := range parts {
+		completeParts = append(completeParts, minio.CompletePart{PartNumber: part.PartNumber, ETag: part.ETag})
+	}
+	_, err := core.CompleteMultipartUpload(bucket, name, uploadId, completeParts)
+	return minioError(err)
+}
+
+func (s *minioStorage) AbortMultipartUpload(ctx context.Context, bucket string, name string, uploadId string) error {
+	core := minio.Core{Client: s.client}
+	return minioError(core.AbortMultipartUpload(bucket, name, uploadId))
+}
@@ -0,0 +1,145 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// Хранилища объектов. local - папка на диске для разработки и тестов без MinIO
+const (
+	BackendMinio = "minio"
+	BackendLocal = "local"
+)
+
+var (
+	ErrNotFound          = errors.New("object not found")
+	ErrInvalidObjectName = errors.New("invalid object name")
+)
+
+// ObjectInfo - сведения об объекте без его содержимого
+type ObjectInfo struct {
+	Name         string
+	Size         int64
+	ContentType  string
+	ETag         string
+	LastModified time.Time
+}
+
+type PutOptions struct {
+	ContentType  string
+	CacheControl string
+}
+
+// Part - загруженная часть multipart загрузки
+type Part struct {
+	PartNumber int
+	ETag       string
+}
+
+// Storage - объекты, разложенные по бакетам
+type Storage interface {
+	Put(ctx context.Context, bucket string, name string, data io.Reader, size int64, opts PutOptions) error
+	// GetRange - байты объекта с start по end включительно. При end < 0 объект читается до конца
+	GetRange(ctx context.Context, bucket string, name string, start int64, end int64) (io.ReadCloser, error)
+	Stat(ctx context.Context, bucket string, name string) (ObjectInfo, error)
+	// Delete - удаление объекта. Удаление отсутствующего объекта не считается ошибкой
+	Delete(ctx context.Context, bucket string, name string) error
+	// Presign - ссылка на скачивание объекта, которая перестаёт работать через ttl
+	Presign(ctx context.Context, bucket string, name string, ttl time.Duration, params url.Values) (string, error)
+	// List - все объекты бакета, имена которых начинаются с prefix, включая вложенные папки
+	List(ctx context.Context, bucket string, prefix string) ([]ObjectInfo, error)
+
+	// загрузка большого объекта частями, которые приходят в разных запросах
+	NewMultipartUpload(ctx context.Context, bucket string, name string, opts PutOptions) (string, error)
+	PutPart(ctx context.Context, bucket string, name string, uploadId string, partNumber int, data io.Reader, size int64) (string, error)
+	CompleteMultipartUpload(ctx context.Context, bucket string, name string, uploadId string, parts []Part) error
+	AbortMultipartUpload(ctx context.Context, bucket string, name string, uploadId string) error
+}
+
+type Config struct {
+	Backend         string
+	Endpoint        string
+	AccessKey       string
+	SecretAccessKey string
+	UseSSL          bool
+	LocalDir        string
+	PublicURL       string
+}
+
+// New - хранилище, выбранное в конфиге. По умолчанию MinIO
+func New(conf Config) (Storage, error) {
+	switch conf.Backend {
+	case BackendMinio, "":
+		return NewMinio(conf.Endpoint, conf.AccessKey, conf.SecretAccessKey, conf.UseSSL)
+	case BackendLocal:
+		return NewLocal(conf.LocalDir, conf.PublicURL)
+	}
+	return nil, fmt.Errorf("unknown storage backend %q", conf.Backend)
+}
+
+// PublicURL - адрес объекта в открытом бакете вида <base>/<бакет>/<объект>
+func PublicURL(base string, bucket string, name string) string {
+	return strings.TrimRight(base, "/") + "/" + url.PathEscape(bucket) + "/" + (&url.URL{Path: name}).EscapedPath()
+}
+
+// Get - объект целиком вместе со сведениями о нём
+func Get(ctx context.Context, s Storage, bucket string, name string) (io.ReadCloser, ObjectInfo, error) {
+	info, err := s.Stat(ctx, bucket, name)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	reader, err := s.GetRange(ctx, bucket, name, 0, -1)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	return reader, info, nil
+}
+
+// PutFile - загрузка локального файла
+func PutFile(ctx context.Context, s Storage, bucket string, name string, filePath string, opts PutOptions) error {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	return s.Put(ctx, bucket, name, file, info.Size(), opts)
+}
+
+// GetFile - сохранение объекта в локальный файл
+func GetFile(ctx context.Context, s Storage, bucket string, name string, filePath string) error {
+	reader, err := s.GetRange(ctx, bucket, name, 0, -1)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(file, reader); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// validName - имя объекта не может выходить за пределы бакета
+func validName(name string) bool {
+	if name == "" || strings.HasPrefix(name, "/") || strings.Contains(name, "\\") || path.Clean(name) != name {
+		return false
+	}
+	return name != ".." && !strings.HasPrefix(name, "../")
+}
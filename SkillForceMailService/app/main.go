@@ -2,85 +2,223 @@ package main
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+	_ "time/tzdata"
 
 	"skillForce/config"
+	"skillForce/db"
+	"skillForce/delivery"
 	"skillForce/mail"
 	"skillForce/metrics"
+	"skillForce/pkg/events"
+	"skillForce/pkg/unsubscribe"
 
-	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 func main() {
 	config := config.LoadConfig()
 
-	mailClient := mail.NewMail(config.Mail.From, config.Mail.Password, config.Mail.Host, config.Mail.Port)
-	consumer, err := kafka.NewConsumer(&kafka.ConfigMap{
-		"bootstrap.servers": "kafka:9092",
-		"group.id":          "mail-service",
-		"auto.offset.reset": "earliest",
+	templates, err := mail.LoadTemplates(config.Templates.Dir, config.Templates.DefaultLocale)
+	if err != nil {
+		log.Fatalf("Failed to load mail templates: %v", err)
+	}
+	transport, err := mail.NewTransport(mail.TransportConfig{
+		Kind:         config.Mail.Transport,
+		Host:         config.Mail.Host,
+		Port:         config.Mail.Port,
+		Username:     config.Mail.From,
+		Password:     config.Mail.Password,
+		TLS:          config.Mail.Tls,
+		PoolSize:     config.Mail.PoolSize,
+		IdleTimeout:  config.Mail.IdleTimeout,
+		File:         config.Mail.File,
+		CaptureLimit: config.Mail.CaptureLimit,
 	})
 	if err != nil {
-		log.Fatalf("Failed to create consumer: %s", err)
+		log.Fatalf("Failed to create mail transport: %v", err)
 	}
+	// письма уходят не быстрее ограничений limits, перехват memory транспорта ограничивается так же
+	limited := mail.NewRateLimitedTransport(transport, mail.RateLimits{
+		Rate:        config.Limits.Rate,
+		Burst:       config.Limits.Burst,
+		DomainRate:  config.Limits.DomainRate,
+		DomainBurst: config.Limits.DomainBurst,
+		Domains:     config.Limits.Domains,
+	})
 	defer func() {
-		if err := consumer.Close(); err != nil {
-			log.Printf("Failed to close Kafka consumer: %v", err)
+		if err := limited.Close(); err != nil {
+			log.Printf("Failed to close mail transport: %v", err)
 		}
 	}()
+	mailClient := mail.NewMail(config.Mail.From, limited, templates, config.Urls.Base, unsubscribe.NewSigner(config.Secrets.UnsubscribeSecret))
+
+	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable", config.Database.Host, config.Database.Port, config.Database.User, config.Database.Password, config.Database.Name)
+	database, err := db.NewDatabase(dsn)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer database.Close()
 
 	metrics.Init()
 	go func() {
 		http.Handle("/metrics", promhttp.Handler())
+		// порт опубликован в docker-compose, поэтому всё, кроме метрик, только с токеном администратора
+		// предпросмотр писем с тестовыми данными: /preview
+		preview := delivery.RequireToken(config.Secrets.AdminApiToken, mailClient.PreviewHandler())
+		http.Handle("/preview", preview)
+		http.Handle("/preview/", preview)
+		// список подавления: /api/suppressions
+		suppressions := delivery.SuppressionsHandler(database, config.Secrets.AdminApiToken)
+		http.Handle("/api/suppressions", suppressions)
+		http.Handle("/api/suppressions/", suppressions)
+		// перехваченные письма транспорта memory: /inbox и /api/inbox. В них токены подтверждения регистрации
+		if capture, ok := transport.(*mail.CaptureTransport); ok {
+			inbox := delivery.RequireToken(config.Secrets.AdminApiToken, capture.Handler())
+			http.Handle("/inbox", inbox)
+			http.Handle("/inbox/", inbox)
+			http.Handle("/api/inbox", inbox)
+			http.Handle("/api/inbox/", inbox)
+		}
 		log.Println("Prometheus metrics available at :9083/metrics")
 		if err := http.ListenAndServe(":9083", nil); err != nil {
 			log.Fatalf("failed to start metrics HTTP server: %v", err)
 		}
 	}()
 
-	topic := "mail"
-	err = consumer.SubscribeTopics([]string{topic}, nil)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// id обработанных событий хранятся неделю
+	go database.RunCleanup(ctx, time.Hour, 7*24*time.Hour)
+
+	producer, err := delivery.NewProducer(config.Kafka.Brokers)
 	if err != nil {
-		log.Fatalf("Failed to subscribe to topic: %s", err)
+		log.Fatalf("Failed to create producer: %s", err)
 	}
+	defer func() {
+		producer.Flush(10000)
+		producer.Close()
+	}()
 
-	log.Println("Waiting for Kafka messages...")
+	policy := delivery.Policy{
+		MaxAttempts: config.Delivery.MaxAttempts,
+		BaseDelay:   config.Delivery.BaseDelay,
+		MaxDelay:    config.Delivery.MaxDelay,
+	}
 
-	for {
-		msg, err := consumer.ReadMessage(-1) // -1 = блокировать до получения сообщения
-		if err == nil {
-			handleMessage(mailClient, msg)
-		} else {
-			log.Printf("Consumer error: %v (%v)\n", err, msg)
+	// новые письма и повторные попытки читаются разными группами, чтобы ожидание повтора
+	// не задерживало новые письма
+	var wg sync.WaitGroup
+	for group, topic := range map[string]string{
+		"mail-service":       delivery.Topic,
+		"mail-service-retry": delivery.RetryTopic,
+	} {
+		consumer, err := delivery.NewConsumer(config.Kafka.Brokers, group)
+		if err != nil {
+			log.Fatalf("Failed to create consumer: %s", err)
+		}
+		if err := consumer.SubscribeTopics([]string{topic}, nil); err != nil {
+			log.Fatalf("Failed to subscribe to topic: %s", err)
 		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			delivery.NewWorker(consumer, producer, policy, database, func(ctx context.Context, env *events.Envelope) error {
+				return handleMessage(ctx, mailClient, database, env)
+			}).Run(ctx)
+			if err := consumer.Close(); err != nil {
+				log.Printf("Failed to close Kafka consumer: %v", err)
+			}
+		}()
 	}
-}
 
-func handleMessage(mailClient *mail.Mail, msg *kafka.Message) {
-	var message mail.KafkaMessage
-	if err := json.Unmarshal(msg.Value, &message); err != nil {
-		log.Printf("Failed to unmarshal JSON: %v\n", err)
-		return
+	dlq, err := delivery.NewDLQ(config.Kafka.Brokers)
+	if err != nil {
+		log.Fatalf("Failed to create dlq client: %s", err)
 	}
+	defer dlq.Close()
+	go dlq.RunSizeMetric(ctx, time.Minute)
 
-	log.Printf("Received message: %+v\n", message)
+	log.Println("Waiting for Kafka messages...")
+	wg.Wait()
+}
+
+// handleMessage - отправка письма по типу события. Конверт уже проверен в delivery.Worker.
+// Продюсеры проверяют настройки уведомлений при отправке, но пользователь мог отписаться, пока письмо
+// было в очереди, поэтому перед отправкой настройки проверяются ещё раз. Письма на адреса из списка
+// подавления не отправляются, жёсткий отказ добавляет адрес в список
+func handleMessage(ctx context.Context, mailClient *mail.Mail, database *db.Database, env *events.Envelope) error {
+	recipient := events.GetRecipient(env)
+	category := events.Category(env)
+	if userId := recipient.GetUserId(); category != events.CategoryTransactional && userId > 0 {
+		subscribed, err := database.IsSubscribed(ctx, int(userId), category)
+		if err != nil {
+			return err
+		}
+		if !subscribed {
+			metrics.MailRequestsTotal.WithLabelValues(env.GetType(), "unsubscribed").Inc()
+			log.Printf("User %d unsubscribed from %s, skip event %s", userId, category, env.GetId())
+			return nil
+		}
+	}
 
-	ctx := context.Background()
-	var sendErr error
+	suppressed, err := database.IsSuppressed(ctx, recipient.GetEmail())
+	if err != nil {
+		return err
+	}
+	if suppressed {
+		metrics.MailRequestsTotal.WithLabelValues(env.GetType(), "suppressed").Inc()
+		log.Printf("Address %s is suppressed, skip event %s", recipient.GetEmail(), env.GetId())
+		return nil
+	}
 
-	switch message.Method {
-	case "send_confirm_mail":
-		sendErr = mailClient.SendRegMail(ctx, message)
-	case "send_welcome_course_mail":
-		sendErr = mailClient.SendWelcomeCourseMail(ctx, message)
-	case "send_middle_course_mail":
-		// TODO: implement send_middle_course_mail
+	err = sendMail(ctx, mailClient, env)
+	// повтор не поможет, а письмо в dlq разбирать незачем: отказ сохраняется в списке подавления
+	if bounce, ok := mail.HardBounce(err); ok {
+		if err := database.Suppress(ctx, db.Suppression{
+			Email:     bounce.Address,
+			Reason:    bounce.Message,
+			SmtpCode:  bounce.Code,
+			EventType: env.GetType(),
+		}); err != nil {
+			return err
+		}
+		metrics.MailBouncesTotal.WithLabelValues(env.GetType()).Inc()
+		log.Printf("Address %s bounced (%d %s), suppressed", bounce.Address, bounce.Code, bounce.Message)
+		return nil
 	}
+	return err
+}
 
-	if sendErr != nil {
-		log.Printf("Failed to send %s mail: %v", message.Method, sendErr)
+func sendMail(ctx context.Context, mailClient *mail.Mail, env *events.Envelope) error {
+	switch payload := events.GetPayload(env).(type) {
+	case *events.ConfirmRegistrationMail:
+		return mailClient.SendRegMail(ctx, payload)
+	case *events.WelcomeCourseMail:
+		return mailClient.SendWelcomeCourseMail(ctx, payload)
+	case *events.DataExportMail:
+		return mailClient.SendDataExportMail(ctx, payload)
+	case *events.EngagementMail:
+		err := mailClient.SendEngagementMail(ctx, payload)
+		// шаблона кампании нет: повтор не поможет, письмо уходит в dlq до обновления mail-service
+		if errors.Is(err, mail.ErrTemplateNotFound) {
+			return fmt.Errorf("%w: %v", delivery.ErrPermanent, err)
+		}
+		return err
+	case *events.WeeklyDigestMail:
+		return mailClient.SendWeeklyDigestMail(ctx, payload)
+	default:
+		// mail-service старше отправителя события: повтор не поможет, событие уходит в dlq
+		return fmt.Errorf("%w: unknown event type %s", delivery.ErrPermanent, env.GetType())
 	}
 }
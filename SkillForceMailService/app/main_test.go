@@ -0,0 +1,18 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"skillForce/delivery"
+	"skillForce/pkg/events"
+)
+
+func TestSendMail_UnknownType(t *testing.T) {
+	// содержимое, которого нет в switch, повтором не исправить: событие должно уйти в dlq
+	err := sendMail(context.Background(), nil, &events.Envelope{Type: "course.published"})
+	if !errors.Is(err, delivery.ErrPermanent) {
+		t.Fatalf("sendMail() error = %v, want %v", err, delivery.ErrPermanent)
+	}
+}
@@ -0,0 +1,86 @@
+// dlq - разбор писем, которые не удалось отправить:
+//
+//	dlq list [-n 20]     неразобранные письма с причиной последней ошибки
+//	dlq replay [-n 0]    вернуть письма в топик mail (0 - все)
+//	dlq skip -n 1        отметить письма разобранными без отправки
+//
+// Запускается в контейнере сервиса: docker exec mail-service ./dlq list
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"skillForce/config"
+	"skillForce/delivery"
+)
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: dlq list|replay|skip [-n count]")
+	os.Exit(2)
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	command := os.Args[1]
+
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	defaultLimit := 0
+	if command == "list" {
+		defaultLimit = 20
+	}
+	limit := flags.Int("n", defaultLimit, "сколько писем обработать, 0 - все")
+	_ = flags.Parse(os.Args[2:])
+
+	config := config.LoadConfig()
+	dlq, err := delivery.NewDLQ(config.Kafka.Brokers)
+	if err != nil {
+		log.Fatalf("Failed to create dlq client: %s", err)
+	}
+	defer dlq.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var count int
+	switch command {
+	case "list":
+		size, err := dlq.Size()
+		if err != nil {
+			log.Fatalf("Failed to get dlq size: %s", err)
+		}
+		fmt.Printf("%d messages in %s\n", size, delivery.DLQTopic)
+
+		count, err = dlq.List(ctx, *limit, func(letter delivery.DeadLetter) error {
+			fmt.Printf("%d/%d\tattempt %d\t%s\t%s\n\t%s\n", letter.Partition, letter.Offset, letter.Attempt,
+				letter.FailedAt.Format(time.RFC3339), letter.Error, letter.Value)
+			return nil
+		})
+		if err != nil {
+			log.Fatalf("Failed to list dlq: %s", err)
+		}
+		return
+	case "replay":
+		count, err = dlq.Replay(ctx, *limit)
+	case "skip":
+		if *limit <= 0 {
+			log.Fatal("skip requires -n")
+		}
+		count, err = dlq.Skip(ctx, *limit)
+	default:
+		usage()
+	}
+
+	fmt.Printf("%s: %d messages\n", command, count)
+	if err != nil {
+		log.Fatalf("Failed to %s dlq: %s", command, err)
+	}
+}
@@ -0,0 +1,47 @@
+// preview - письмо с тестовыми данными, как его отрисует сервис:
+//
+//	preview                                   список шаблонов и языков
+//	preview [-locale en] [-format text] name  письмо в формате html, text или eml
+//
+// Запускается в контейнере сервиса: docker exec mail-service ./preview -format text confirm_registration.
+// То же доступно по HTTP на порту метрик: http://mail-service:9083/preview
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"skillForce/config"
+	"skillForce/mail"
+	"skillForce/pkg/unsubscribe"
+)
+
+func main() {
+	locale := flag.String("locale", "", "язык письма, по умолчанию templates.default_locale")
+	format := flag.String("format", mail.PreviewHTML, "html, text или eml")
+	flag.Parse()
+
+	config := config.LoadConfig()
+	templates, err := mail.LoadTemplates(config.Templates.Dir, config.Templates.DefaultLocale)
+	if err != nil {
+		log.Fatalf("Failed to load mail templates: %v", err)
+	}
+
+	if flag.NArg() == 0 {
+		fmt.Println("locales:", templates.Locales())
+		for _, name := range templates.Names() {
+			fmt.Println(name)
+		}
+		return
+	}
+
+	// предпросмотр ничего не отправляет, транспорт не нужен
+	mailClient := mail.NewMail(config.Mail.From, nil, templates, config.Urls.Base, unsubscribe.NewSigner(config.Secrets.UnsubscribeSecret))
+	body, _, err := mailClient.Preview(flag.Arg(0), *locale, *format)
+	if err != nil {
+		log.Fatal(err)
+	}
+	_, _ = os.Stdout.Write(body)
+}
@@ -3,6 +3,8 @@ package config
 import (
 	"log"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 	"gopkg.in/yaml.v2"
@@ -27,14 +29,50 @@ type Config struct {
 	}
 
 	Secrets struct {
-		JwtSessionSecret string
+		JwtSessionSecret  string
+		UnsubscribeSecret string
+		// AdminApiToken - Bearer токен API списка подавления
+		AdminApiToken string
 	}
 
 	Mail struct {
-		From     string
-		Password string
-		Host     string
-		Port     string
+		From         string
+		Password     string
+		Host         string
+		Port         string
+		Transport    string
+		Tls          string
+		PoolSize     int
+		IdleTimeout  time.Duration
+		File         string
+		CaptureLimit int
+	}
+
+	Limits struct {
+		Rate        float64
+		Burst       int
+		DomainRate  float64
+		DomainBurst int
+		Domains     map[string]float64
+	}
+
+	Kafka struct {
+		Brokers string
+	}
+
+	Delivery struct {
+		MaxAttempts int
+		BaseDelay   time.Duration
+		MaxDelay    time.Duration
+	}
+
+	Templates struct {
+		Dir           string
+		DefaultLocale string
+	}
+
+	Urls struct {
+		Base string
 	}
 }
 
@@ -51,6 +89,42 @@ type yamlConfig struct {
 		VideoBucket string `yaml:"video_bucket_name"`
 		UseSSL      bool   `yaml:"use_ssl"`
 	} `yaml:"minio"`
+
+	Mail struct {
+		Transport    string        `yaml:"transport"`
+		Tls          string        `yaml:"tls"`
+		PoolSize     int           `yaml:"pool_size"`
+		IdleTimeout  time.Duration `yaml:"idle_timeout"`
+		File         string        `yaml:"file"`
+		CaptureLimit int           `yaml:"capture_limit"`
+	} `yaml:"mail"`
+
+	Limits struct {
+		Rate        float64            `yaml:"rate"`
+		Burst       int                `yaml:"burst"`
+		DomainRate  float64            `yaml:"domain_rate"`
+		DomainBurst int                `yaml:"domain_burst"`
+		Domains     map[string]float64 `yaml:"domains"`
+	} `yaml:"limits"`
+
+	Kafka struct {
+		Brokers string `yaml:"brokers"`
+	} `yaml:"kafka"`
+
+	Delivery struct {
+		MaxAttempts int           `yaml:"max_attempts"`
+		BaseDelay   time.Duration `yaml:"base_delay"`
+		MaxDelay    time.Duration `yaml:"max_delay"`
+	} `yaml:"delivery"`
+
+	Templates struct {
+		Dir           string `yaml:"dir"`
+		DefaultLocale string `yaml:"default_locale"`
+	} `yaml:"templates"`
+
+	Urls struct {
+		Base string `yaml:"base"`
+	} `yaml:"urls"`
 }
 
 func LoadConfig() *Config {
@@ -99,19 +173,82 @@ func LoadConfig() *Config {
 			VideoBucket:     ycfg.Minio.VideoBucket,
 			UseSSL:          ycfg.Minio.UseSSL,
 		},
-		Secrets: struct{ JwtSessionSecret string }{
-			JwtSessionSecret: os.Getenv("JWT_SESSION_SECRET"),
+		Secrets: struct {
+			JwtSessionSecret  string
+			UnsubscribeSecret string
+			AdminApiToken     string
+		}{
+			JwtSessionSecret:  os.Getenv("JWT_SESSION_SECRET"),
+			UnsubscribeSecret: requireEnv("UNSUBSCRIBE_SECRET"),
+			AdminApiToken:     requireEnv("ADMIN_API_TOKEN"),
 		},
 		Mail: struct {
-			From     string
-			Password string
-			Host     string
-			Port     string
+			From         string
+			Password     string
+			Host         string
+			Port         string
+			Transport    string
+			Tls          string
+			PoolSize     int
+			IdleTimeout  time.Duration
+			File         string
+			CaptureLimit int
+		}{
+			From:         os.Getenv("MAIL_FROM"),
+			Password:     os.Getenv("MAIL_PASSWORD"),
+			Host:         os.Getenv("MAIL_HOST"),
+			Port:         os.Getenv("MAIL_PORT"),
+			Transport:    ycfg.Mail.Transport,
+			Tls:          ycfg.Mail.Tls,
+			PoolSize:     ycfg.Mail.PoolSize,
+			IdleTimeout:  ycfg.Mail.IdleTimeout,
+			File:         ycfg.Mail.File,
+			CaptureLimit: ycfg.Mail.CaptureLimit,
+		},
+		Limits: struct {
+			Rate        float64
+			Burst       int
+			DomainRate  float64
+			DomainBurst int
+			Domains     map[string]float64
 		}{
-			From:     os.Getenv("MAIL_FROM"),
-			Password: os.Getenv("MAIL_PASSWORD"),
-			Host:     os.Getenv("MAIL_HOST"),
-			Port:     os.Getenv("MAIL_PORT"),
+			Rate:        ycfg.Limits.Rate,
+			Burst:       ycfg.Limits.Burst,
+			DomainRate:  ycfg.Limits.DomainRate,
+			DomainBurst: ycfg.Limits.DomainBurst,
+			Domains:     ycfg.Limits.Domains,
+		},
+		Kafka: struct{ Brokers string }{
+			Brokers: ycfg.Kafka.Brokers,
 		},
+		Delivery: struct {
+			MaxAttempts int
+			BaseDelay   time.Duration
+			MaxDelay    time.Duration
+		}{
+			MaxAttempts: ycfg.Delivery.MaxAttempts,
+			BaseDelay:   ycfg.Delivery.BaseDelay,
+			MaxDelay:    ycfg.Delivery.MaxDelay,
+		},
+		Templates: struct {
+			Dir           string
+			DefaultLocale string
+		}{
+			Dir:           ycfg.Templates.Dir,
+			DefaultLocale: ycfg.Templates.DefaultLocale,
+		},
+		Urls: struct{ Base string }{
+			Base: strings.TrimSuffix(ycfg.Urls.Base, "/"),
+		},
+	}
+}
+
+// requireEnv - секрет из .env. Пустой секрет не допускается: HMAC подпись с пустым ключом
+// может подделать кто угодно
+func requireEnv(name string) string {
+	value := os.Getenv(name)
+	if value == "" {
+		log.Fatalf("не задана переменная окружения %s", name)
 	}
+	return value
 }
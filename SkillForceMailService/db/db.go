@@ -0,0 +1,78 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+type Database struct {
+	conn *sql.DB
+}
+
+func NewDatabase(connStr string) (*Database, error) {
+	conn, err := sql.Open("postgres", connStr)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.SetMaxOpenConns(2)
+	conn.SetMaxIdleConns(1)
+	conn.SetConnMaxLifetime(30 * time.Minute)
+
+	if err := conn.Ping(); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return &Database{conn: conn}, nil
+}
+
+func (d *Database) Close() {
+	if err := d.conn.Close(); err != nil {
+		log.Printf("Failed to close database: %v", err)
+	}
+}
+
+// IsEventProcessed - письмо этого события уже отправлено
+func (d *Database) IsEventProcessed(ctx context.Context, eventId string) (bool, error) {
+	var exists bool
+	err := d.conn.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM mail_processed_events WHERE event_id = $1)", eventId).Scan(&exists)
+	return exists, err
+}
+
+func (d *Database) MarkEventProcessed(ctx context.Context, eventId string) error {
+	_, err := d.conn.ExecContext(ctx, "INSERT INTO mail_processed_events (event_id) VALUES ($1) ON CONFLICT DO NOTHING", eventId)
+	return err
+}
+
+// IsSubscribed - пользователь не отключал категорию писем. Категории без строки в notification_preferences включены
+func (d *Database) IsSubscribed(ctx context.Context, userId int, category string) (bool, error) {
+	var disabled bool
+	err := d.conn.QueryRowContext(ctx,
+		"SELECT EXISTS(SELECT 1 FROM notification_preferences WHERE user_id = $1 AND category = $2 AND NOT enabled)",
+		userId, category).Scan(&disabled)
+	return !disabled, err
+}
+
+// RunCleanup - удаление записей старше retention раз в interval до отмены контекста. Событие outbox
+// не доставляется повторно спустя столько времени
+func (d *Database) RunCleanup(ctx context.Context, interval time.Duration, retention time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		_, err := d.conn.ExecContext(ctx, "DELETE FROM mail_processed_events WHERE processed_at < NOW() - $1 * INTERVAL '1 second'", int(retention.Seconds()))
+		if err != nil && ctx.Err() == nil {
+			log.Printf("Failed to clean up processed events: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Suppression - адрес из списка подавления и отказ, после которого он туда попал
+type Suppression struct {
+	Email     string    `json:"email"`
+	Reason    string    `json:"reason"`
+	SmtpCode  int       `json:"smtp_code"`
+	EventType string    `json:"event_type"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// IsSuppressed - письма на email не отправляются. Адреса сравниваются без учёта регистра
+func (d *Database) IsSuppressed(ctx context.Context, email string) (bool, error) {
+	var exists bool
+	err := d.conn.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM mail_suppressions WHERE email = $1)", normalizeEmail(email)).Scan(&exists)
+	return exists, err
+}
+
+// Suppress - добавление адреса в список подавления. Повторный отказ обновляет причину
+func (d *Database) Suppress(ctx context.Context, s Suppression) error {
+	_, err := d.conn.ExecContext(ctx, `
+		INSERT INTO mail_suppressions (email, reason, smtp_code, event_type) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (email) DO UPDATE SET reason = EXCLUDED.reason, smtp_code = EXCLUDED.smtp_code, event_type = EXCLUDED.event_type`,
+		normalizeEmail(s.Email), s.Reason, s.SmtpCode, s.EventType)
+	return err
+}
+
+// ListSuppressions - адреса от новых к старым. Непустой query оставляет адреса, содержащие его
+func (d *Database) ListSuppressions(ctx context.Context, query string, limit int) ([]Suppression, error) {
+	rows, err := d.conn.QueryContext(ctx, `
+		SELECT email, reason, smtp_code, event_type, created_at FROM mail_suppressions
+		WHERE $1 = '' OR strpos(email, $1) > 0
+		ORDER BY created_at DESC, email LIMIT $2`, normalizeEmail(query), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	suppressions := make([]Suppression, 0)
+	for rows.Next() {
+		var s Suppression
+		if err := rows.Scan(&s.Email, &s.Reason, &s.SmtpCode, &s.EventType, &s.CreatedAt); err != nil {
+			return nil, err
+		}
+		suppressions = append(suppressions, s)
+	}
+	return suppressions, rows.Err()
+}
+
+// GetSuppression - nil, если адреса нет в списке
+func (d *Database) GetSuppression(ctx context.Context, email string) (*Suppression, error) {
+	var s Suppression
+	err := d.conn.QueryRowContext(ctx,
+		"SELECT email, reason, smtp_code, event_type, created_at FROM mail_suppressions WHERE email = $1",
+		normalizeEmail(email)).Scan(&s.Email, &s.Reason, &s.SmtpCode, &s.EventType, &s.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// DeleteSuppression - удаление адреса из списка, false - адреса в списке не было
+func (d *Database) DeleteSuppression(ctx context.Context, email string) (bool, error) {
+	res, err := d.conn.ExecContext(ctx, "DELETE FROM mail_suppressions WHERE email = $1", normalizeEmail(email))
+	if err != nil {
+		return false, err
+	}
+	deleted, err := res.RowsAffected()
+	return deleted > 0, err
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
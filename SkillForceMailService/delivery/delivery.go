@@ -0,0 +1,337 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/textproto"
+	"strconv"
+	"time"
+
+	"skillForce/metrics"
+	"skillForce/pkg/events"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// Топики писем: в retry письмо ждёт следующей попытки, в dlq попадает после последней
+const (
+	Topic      = "mail"
+	RetryTopic = "mail.retry"
+	DLQTopic   = "mail.dlq"
+)
+
+// Заголовки сообщений retry и dlq топиков
+const (
+	HeaderAttempt     = "attempt"
+	HeaderNextAttempt = "next_attempt_at"
+	HeaderError       = "error"
+	HeaderFailedAt    = "failed_at"
+	HeaderReplayedBy  = "replayed_from"
+	// id события outbox, совпадает с id в events.Envelope
+	HeaderEventId = "event_id"
+)
+
+const (
+	pollTimeoutMs     = 1000
+	deliveryTimeout   = 30 * time.Second
+	errorHeaderLength = 1000
+)
+
+// ErrPermanent - ошибка, после которой повторять отправку бессмысленно: письмо сразу уходит в dlq
+var ErrPermanent = errors.New("permanent delivery error")
+
+// EventLog - id событий, письма которых уже отправлены
+type EventLog interface {
+	IsEventProcessed(ctx context.Context, eventId string) (bool, error)
+	MarkEventProcessed(ctx context.Context, eventId string) error
+}
+
+// Consumer - методы kafka.Consumer, которыми пользуется Worker
+type Consumer interface {
+	Poll(timeoutMs int) kafka.Event
+	CommitMessage(m *kafka.Message) ([]kafka.TopicPartition, error)
+	Seek(partition kafka.TopicPartition, ignoredTimeoutMs int) error
+	Pause(partitions []kafka.TopicPartition) error
+	Resume(partitions []kafka.TopicPartition) error
+}
+
+// Producer - запись в retry и dlq топики, реализуется kafka.Producer
+type Producer interface {
+	Produce(msg *kafka.Message, deliveryChan chan kafka.Event) error
+}
+
+// Handler - отправка письма. Сообщение считается обработанным, только если Handler вернул nil
+type Handler func(ctx context.Context, env *events.Envelope) error
+
+// Policy - сколько раз и с какими паузами повторять отправку
+type Policy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// Backoff - пауза перед попыткой attempt (начиная со второй): BaseDelay, 2*BaseDelay, 4*BaseDelay... не больше MaxDelay
+func (p Policy) Backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 2; i < attempt && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return delay
+}
+
+// Worker - чтение одного топика с ручным коммитом офсетов. Офсет сообщения коммитится только после
+// отправки письма или после того, как сообщение записано в retry или dlq топик, поэтому письмо
+// доставляется хотя бы один раз
+type Worker struct {
+	consumer Consumer
+	producer Producer
+	policy   Policy
+	handle   Handler
+	events   EventLog
+	// partition -> время, когда приостановленную партицию retry топика можно читать снова
+	paused map[int32]pausedPartition
+}
+
+type pausedPartition struct {
+	partition kafka.TopicPartition
+	until     time.Time
+}
+
+func NewWorker(consumer Consumer, producer Producer, policy Policy, events EventLog, handle Handler) *Worker {
+	return &Worker{
+		consumer: consumer,
+		producer: producer,
+		policy:   policy,
+		handle:   handle,
+		events:   events,
+		paused:   make(map[int32]pausedPartition),
+	}
+}
+
+// NewConsumer - консьюмер без автокоммита, офсеты коммитит Worker
+func NewConsumer(brokers string, group string) (*kafka.Consumer, error) {
+	return kafka.NewConsumer(&kafka.ConfigMap{
+		"bootstrap.servers":  brokers,
+		"group.id":           group,
+		"auto.offset.reset":  "earliest",
+		"enable.auto.commit": false,
+	})
+}
+
+// NewProducer - продюсер retry и dlq топиков, запись подтверждается всеми репликами
+func NewProducer(brokers string) (*kafka.Producer, error) {
+	return kafka.NewProducer(&kafka.ConfigMap{
+		"bootstrap.servers":  brokers,
+		"acks":               "all",
+		"enable.idempotence": true,
+	})
+}
+
+// Run - чтение сообщений до отмены контекста
+func (w *Worker) Run(ctx context.Context) {
+	for ctx.Err() == nil {
+		w.resumeDue()
+
+		switch ev := w.consumer.Poll(pollTimeoutMs).(type) {
+		case *kafka.Message:
+			if err := w.process(ctx, ev); err != nil {
+				log.Printf("Failed to process %v: %v", ev.TopicPartition, err)
+				// офсет не закоммичен: сообщение будет прочитано снова
+				if err := w.consumer.Seek(ev.TopicPartition, 0); err != nil {
+					log.Printf("Failed to seek %v: %v", ev.TopicPartition, err)
+				}
+				sleep(ctx, time.Second)
+			}
+		case kafka.Error:
+			log.Printf("Consumer error: %v", ev)
+		}
+	}
+}
+
+func (w *Worker) process(ctx context.Context, msg *kafka.Message) error {
+	// письмо из retry топика ждёт своего времени: партиция приостанавливается, а сообщение
+	// будет прочитано заново после паузы
+	if next := headerTime(msg, HeaderNextAttempt); time.Now().Before(next) {
+		return w.pause(msg, next)
+	}
+
+	attempt := headerInt(msg, HeaderAttempt) + 1
+
+	// событие не прошло проверку контракта: повтор не поможет
+	env, err := decode(ctx, msg)
+	if err != nil {
+		if err := w.deadLetter(msg, unknownType, attempt, fmt.Errorf("%w: %v", ErrPermanent, err)); err != nil {
+			return err
+		}
+		_, err := w.consumer.CommitMessage(msg)
+		return err
+	}
+	log.Printf("Received %s v%d %s (correlation %s, attempt %d)\n", env.GetType(), env.GetVersion(), env.GetId(), env.GetCorrelationId(), attempt)
+
+	processed, err := w.events.IsEventProcessed(ctx, env.GetId())
+	if err != nil {
+		return err
+	}
+	if processed {
+		log.Printf("Skip duplicate event %s", env.GetId())
+		_, err := w.consumer.CommitMessage(msg)
+		return err
+	}
+
+	sendErr := w.handle(ctx, env)
+	switch {
+	case sendErr == nil:
+		// письмо уже ушло: если отметка не сохранится, дубль возможен только при повторной доставке
+		if err := w.events.MarkEventProcessed(ctx, env.GetId()); err != nil {
+			log.Printf("Failed to mark event %s: %v", env.GetId(), err)
+		}
+	case permanent(sendErr) || attempt >= w.policy.MaxAttempts:
+		if err := w.deadLetter(msg, env.GetType(), attempt, sendErr); err != nil {
+			return err
+		}
+	default:
+		if err := w.retry(msg, env.GetType(), attempt, sendErr); err != nil {
+			return err
+		}
+	}
+
+	_, err = w.consumer.CommitMessage(msg)
+	return err
+}
+
+// permanent - ошибки разбора сообщения и отказы SMTP сервера с кодом 5xx (например, адреса не существует)
+func permanent(err error) bool {
+	var smtpErr *textproto.Error
+	return errors.Is(err, ErrPermanent) || (errors.As(err, &smtpErr) && smtpErr.Code >= 500)
+}
+
+func (w *Worker) retry(msg *kafka.Message, eventType string, attempt int, sendErr error) error {
+	delay := w.policy.Backoff(attempt + 1)
+	log.Printf("Failed to send %s mail (attempt %d), retry in %s: %v", eventType, attempt, delay, sendErr)
+
+	err := w.produce(RetryTopic, msg, []kafka.Header{
+		header(HeaderAttempt, strconv.Itoa(attempt)),
+		header(HeaderNextAttempt, time.Now().Add(delay).Format(time.RFC3339Nano)),
+		header(HeaderError, truncate(sendErr.Error())),
+	})
+	if err != nil {
+		return err
+	}
+	metrics.MailRetriesTotal.WithLabelValues(eventType).Inc()
+	return nil
+}
+
+func (w *Worker) deadLetter(msg *kafka.Message, eventType string, attempt int, sendErr error) error {
+	log.Printf("Failed to send %s mail (attempt %d), moved to %s: %v", eventType, attempt, DLQTopic, sendErr)
+
+	err := w.produce(DLQTopic, msg, []kafka.Header{
+		header(HeaderAttempt, strconv.Itoa(attempt)),
+		header(HeaderError, truncate(sendErr.Error())),
+		header(HeaderFailedAt, time.Now().Format(time.RFC3339Nano)),
+	})
+	if err != nil {
+		return err
+	}
+	metrics.MailDeadLetteredTotal.WithLabelValues(eventType).Inc()
+	metrics.MailDLQSize.Inc()
+	return nil
+}
+
+// produce - запись копии сообщения в topic с ожиданием подтверждения. id события сохраняется
+func (w *Worker) produce(topic string, msg *kafka.Message, headers []kafka.Header) error {
+	if eventId := headerValue(msg, HeaderEventId); eventId != "" {
+		headers = append(headers, header(HeaderEventId, eventId))
+	}
+	return produce(w.producer, &kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: kafka.PartitionAny},
+		Key:            msg.Key,
+		Value:          msg.Value,
+		Headers:        headers,
+	})
+}
+
+func produce(producer Producer, msg *kafka.Message) error {
+	deliveryChan := make(chan kafka.Event, 1)
+	if err := producer.Produce(msg, deliveryChan); err != nil {
+		return err
+	}
+
+	select {
+	case e := <-deliveryChan:
+		if m, ok := e.(*kafka.Message); ok && m.TopicPartition.Error != nil {
+			return m.TopicPartition.Error
+		}
+		return nil
+	case <-time.After(deliveryTimeout):
+		return fmt.Errorf("delivery to %s timed out", *msg.TopicPartition.Topic)
+	}
+}
+
+func (w *Worker) pause(msg *kafka.Message, until time.Time) error {
+	partition := msg.TopicPartition
+	if err := w.consumer.Pause([]kafka.TopicPartition{partition}); err != nil {
+		return err
+	}
+	// уже прочитанные сообщения партиции отбрасываются, после паузы чтение начнётся с msg
+	if err := w.consumer.Seek(partition, 0); err != nil {
+		return err
+	}
+	w.paused[partition.Partition] = pausedPartition{partition: partition, until: until}
+	return nil
+}
+
+func (w *Worker) resumeDue() {
+	now := time.Now()
+	for id, p := range w.paused {
+		if now.Before(p.until) {
+			continue
+		}
+		// после ребалансировки партиция может принадлежать другому консьюмеру
+		if err := w.consumer.Resume([]kafka.TopicPartition{p.partition}); err != nil {
+			log.Printf("Failed to resume %v: %v", p.partition, err)
+		}
+		delete(w.paused, id)
+	}
+}
+
+func header(key string, value string) kafka.Header {
+	return kafka.Header{Key: key, Value: []byte(value)}
+}
+
+func headerValue(msg *kafka.Message, key string) string {
+	for _, h := range msg.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func headerInt(msg *kafka.Message, key string) int {
+	value, _ := strconv.Atoi(headerValue(msg, key))
+	return value
+}
+
+func headerTime(msg *kafka.Message, key string) time.Time {
+	value, _ := time.Parse(time.RFC3339Nano, headerValue(msg, key))
+	return value
+}
+
+func truncate(s string) string {
+	if len(s) > errorHeaderLength {
+		return s[:errorHeaderLength]
+	}
+	return s
+}
+
+func sleep(ctx context.Context, d time.Duration) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(d):
+	}
+}
@@ -0,0 +1,279 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/textproto"
+	"strconv"
+	"testing"
+	"time"
+
+	"skillForce/pkg/events"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+type fakeConsumer struct {
+	committed []*kafka.Message
+	paused    []kafka.TopicPartition
+	seeks     []kafka.TopicPartition
+}
+
+func (c *fakeConsumer) Poll(timeoutMs int) kafka.Event {
+	return nil
+}
+
+func (c *fakeConsumer) CommitMessage(m *kafka.Message) ([]kafka.TopicPartition, error) {
+	c.committed = append(c.committed, m)
+	return []kafka.TopicPartition{m.TopicPartition}, nil
+}
+
+func (c *fakeConsumer) Seek(partition kafka.TopicPartition, ignoredTimeoutMs int) error {
+	c.seeks = append(c.seeks, partition)
+	return nil
+}
+
+func (c *fakeConsumer) Pause(partitions []kafka.TopicPartition) error {
+	c.paused = append(c.paused, partitions...)
+	return nil
+}
+
+func (c *fakeConsumer) Resume(partitions []kafka.TopicPartition) error {
+	return nil
+}
+
+// fakeProducer - запись подтверждается сразу, если не задана ошибка
+type fakeProducer struct {
+	err      error
+	produced []*kafka.Message
+}
+
+func (p *fakeProducer) Produce(msg *kafka.Message, deliveryChan chan kafka.Event) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.produced = append(p.produced, msg)
+	deliveryChan <- msg
+	return nil
+}
+
+type fakeEventLog struct {
+	processed map[string]bool
+	err       error
+}
+
+func (l *fakeEventLog) IsEventProcessed(ctx context.Context, eventId string) (bool, error) {
+	return l.processed[eventId], l.err
+}
+
+func (l *fakeEventLog) MarkEventProcessed(ctx context.Context, eventId string) error {
+	l.processed[eventId] = true
+	return nil
+}
+
+func TestPolicy_Backoff(t *testing.T) {
+	policy := Policy{MaxAttempts: 10, BaseDelay: time.Second, MaxDelay: 10 * time.Second}
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: time.Second},
+		{attempt: 2, want: time.Second},
+		{attempt: 3, want: 2 * time.Second},
+		{attempt: 4, want: 4 * time.Second},
+		{attempt: 5, want: 8 * time.Second},
+		{attempt: 6, want: 10 * time.Second},
+		{attempt: 50, want: 10 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := policy.Backoff(tt.attempt); got != tt.want {
+			t.Errorf("Backoff(%d) = %s, want %s", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestPermanent(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "permanent", err: ErrPermanent, want: true},
+		{name: "wrapped permanent", err: fmt.Errorf("%w: bad payload", ErrPermanent), want: true},
+		{name: "smtp 550", err: &textproto.Error{Code: 550, Msg: "user unknown"}, want: true},
+		{name: "wrapped smtp 554", err: fmt.Errorf("send: %w", &textproto.Error{Code: 554, Msg: "rejected"}), want: true},
+		{name: "smtp 451", err: &textproto.Error{Code: 451, Msg: "try again later"}, want: false},
+		{name: "network", err: errors.New("connection refused"), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := permanent(tt.err); got != tt.want {
+				t.Fatalf("permanent(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHeaderRoundTrip(t *testing.T) {
+	next := time.Date(2026, 10, 19, 12, 30, 0, 123456789, time.FixedZone("MSK", 3*60*60))
+	tests := []struct {
+		name     string
+		headers  []kafka.Header
+		wantInt  int
+		wantTime time.Time
+	}{
+		{name: "written by retry", headers: []kafka.Header{
+			header(HeaderAttempt, strconv.Itoa(3)),
+			header(HeaderNextAttempt, next.Format(time.RFC3339Nano)),
+		}, wantInt: 3, wantTime: next},
+		{name: "missing", wantInt: 0},
+		{name: "malformed", headers: []kafka.Header{
+			header(HeaderAttempt, "three"),
+			header(HeaderNextAttempt, "tomorrow"),
+		}, wantInt: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := &kafka.Message{Headers: tt.headers}
+			if got := headerInt(msg, HeaderAttempt); got != tt.wantInt {
+				t.Fatalf("headerInt() = %d, want %d", got, tt.wantInt)
+			}
+			if got := headerTime(msg, HeaderNextAttempt); !got.Equal(tt.wantTime) {
+				t.Fatalf("headerTime() = %s, want %s", got, tt.wantTime)
+			}
+		})
+	}
+}
+
+// mailMessage - сообщение основного топика с письмом подтверждения регистрации
+func mailMessage(t *testing.T, headers ...kafka.Header) (*kafka.Message, *events.Envelope) {
+	env, err := events.New(context.Background(), "user-service", &events.ConfirmRegistrationMail{
+		Recipient: &events.Recipient{Email: "alice@example.com", Name: "Alice"},
+		Token:     "3f2a9c",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, err := events.Marshal(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	topic := Topic
+	return &kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: 2, Offset: 40},
+		Key:            []byte("alice@example.com"),
+		Value:          value,
+		Headers:        append(headers, header(HeaderEventId, env.GetId())),
+	}, env
+}
+
+func TestWorker_Process(t *testing.T) {
+	policy := Policy{MaxAttempts: 3, BaseDelay: time.Minute, MaxDelay: time.Hour}
+	tests := []struct {
+		name        string
+		headers     []kafka.Header
+		value       []byte
+		sendErr     error
+		processed   bool
+		produceErr  error
+		wantTopic   string
+		wantAttempt int
+		wantSent    bool
+		wantCommit  bool
+		wantErr     bool
+	}{
+		{name: "sent", wantSent: true, wantCommit: true},
+		{name: "transient error", sendErr: errors.New("connection refused"), wantTopic: RetryTopic, wantAttempt: 1, wantSent: true, wantCommit: true},
+		{name: "smtp 4xx", sendErr: &textproto.Error{Code: 421, Msg: "busy"}, wantTopic: RetryTopic, wantAttempt: 1, wantSent: true, wantCommit: true},
+		{name: "last attempt", headers: []kafka.Header{header(HeaderAttempt, "2")}, sendErr: errors.New("connection refused"),
+			wantTopic: DLQTopic, wantAttempt: 3, wantSent: true, wantCommit: true},
+		{name: "smtp 5xx", sendErr: &textproto.Error{Code: 550, Msg: "user unknown"}, wantTopic: DLQTopic, wantAttempt: 1, wantSent: true, wantCommit: true},
+		{name: "duplicate", processed: true, wantCommit: true},
+		{name: "undecodable", value: []byte("not an event"), wantTopic: DLQTopic, wantAttempt: 1, wantCommit: true},
+		{name: "producer failed", sendErr: errors.New("connection refused"), produceErr: errors.New("broker down"), wantSent: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, env := mailMessage(t, tt.headers...)
+			if tt.value != nil {
+				msg.Value = tt.value
+			}
+			consumer := &fakeConsumer{}
+			producer := &fakeProducer{err: tt.produceErr}
+			eventLog := &fakeEventLog{processed: map[string]bool{env.GetId(): tt.processed}}
+			sent := false
+			w := NewWorker(consumer, producer, policy, eventLog, func(ctx context.Context, got *events.Envelope) error {
+				sent = true
+				return tt.sendErr
+			})
+
+			err := w.process(context.Background(), msg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("process() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if sent != tt.wantSent {
+				t.Fatalf("handler called = %v, want %v", sent, tt.wantSent)
+			}
+			// офсет коммитится только после отправки или записи в retry/dlq
+			if got := len(consumer.committed) == 1; got != tt.wantCommit {
+				t.Fatalf("committed %d messages, want commit %v", len(consumer.committed), tt.wantCommit)
+			}
+			if tt.wantSent && tt.sendErr == nil && !eventLog.processed[env.GetId()] {
+				t.Fatalf("event %s not marked processed", env.GetId())
+			}
+
+			if tt.wantTopic == "" {
+				if len(producer.produced) != 0 {
+					t.Fatalf("produced %d messages, want none", len(producer.produced))
+				}
+				return
+			}
+			if len(producer.produced) != 1 {
+				t.Fatalf("produced %d messages, want 1", len(producer.produced))
+			}
+			out := producer.produced[0]
+			if *out.TopicPartition.Topic != tt.wantTopic || string(out.Value) != string(msg.Value) || string(out.Key) != string(msg.Key) {
+				t.Fatalf("produced to %s %q, want %s %q", *out.TopicPartition.Topic, out.Value, tt.wantTopic, msg.Value)
+			}
+			if got := headerInt(out, HeaderAttempt); got != tt.wantAttempt {
+				t.Fatalf("attempt header = %d, want %d", got, tt.wantAttempt)
+			}
+			if headerValue(out, HeaderEventId) != env.GetId() || headerValue(out, HeaderError) == "" {
+				t.Fatalf("headers = %v", out.Headers)
+			}
+			if tt.wantTopic == RetryTopic {
+				next := headerTime(out, HeaderNextAttempt)
+				if delay := time.Until(next); delay <= 0 || delay > policy.Backoff(tt.wantAttempt+1) {
+					t.Fatalf("next attempt in %s, want up to %s", delay, policy.Backoff(tt.wantAttempt+1))
+				}
+			}
+		})
+	}
+}
+
+func TestWorker_ProcessPausesUntilNextAttempt(t *testing.T) {
+	next := time.Now().Add(time.Minute)
+	msg, env := mailMessage(t, header(HeaderAttempt, "1"), header(HeaderNextAttempt, next.Format(time.RFC3339Nano)))
+	consumer := &fakeConsumer{}
+	producer := &fakeProducer{}
+	eventLog := &fakeEventLog{processed: map[string]bool{}}
+	w := NewWorker(consumer, producer, Policy{MaxAttempts: 3, BaseDelay: time.Minute}, eventLog, func(ctx context.Context, got *events.Envelope) error {
+		t.Fatalf("handler called for %s before next attempt", got.GetId())
+		return nil
+	})
+
+	if err := w.process(context.Background(), msg); err != nil {
+		t.Fatalf("process() error = %v", err)
+	}
+	// сообщение не коммитится и будет прочитано заново после паузы
+	if len(consumer.committed) != 0 || len(producer.produced) != 0 || eventLog.processed[env.GetId()] {
+		t.Fatalf("committed %d, produced %d", len(consumer.committed), len(producer.produced))
+	}
+	if len(consumer.paused) != 1 || len(consumer.seeks) != 1 || consumer.seeks[0].Offset != msg.TopicPartition.Offset {
+		t.Fatalf("paused %v, seeks %v", consumer.paused, consumer.seeks)
+	}
+	if p, ok := w.paused[msg.TopicPartition.Partition]; !ok || !p.until.Equal(next) {
+		t.Fatalf("paused partitions = %v", w.paused)
+	}
+}
@@ -0,0 +1,214 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"skillForce/metrics"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// DLQGroup - группа, офсеты которой отмечают разобранные сообщения dlq: всё, что дальше, ещё ждёт решения
+const DLQGroup = "mail-dlq-admin"
+
+const metadataTimeoutMs = 10000
+
+// DeadLetter - письмо из dlq вместе с причиной последней неудачи
+type DeadLetter struct {
+	Partition int32
+	Offset    int64
+	EventId   string
+	Attempt   int
+	Error     string
+	FailedAt  time.Time
+	Value     []byte
+}
+
+// DLQ - просмотр и повторная отправка писем из dlq топика
+type DLQ struct {
+	consumer *kafka.Consumer
+	producer *kafka.Producer
+}
+
+func NewDLQ(brokers string) (*DLQ, error) {
+	consumer, err := NewConsumer(brokers, DLQGroup)
+	if err != nil {
+		return nil, err
+	}
+	producer, err := NewProducer(brokers)
+	if err != nil {
+		consumer.Close()
+		return nil, err
+	}
+	return &DLQ{consumer: consumer, producer: producer}, nil
+}
+
+func (d *DLQ) Close() {
+	d.producer.Flush(metadataTimeoutMs)
+	d.producer.Close()
+	d.consumer.Close()
+}
+
+// pending - для каждой партиции dlq первый неразобранный офсет и офсет, следующий за последним сообщением
+func (d *DLQ) pending() ([]kafka.TopicPartition, map[int32]int64, error) {
+	topic := DLQTopic
+	metadata, err := d.consumer.GetMetadata(&topic, false, metadataTimeoutMs)
+	if err != nil {
+		return nil, nil, err
+	}
+	info, ok := metadata.Topics[DLQTopic]
+	if !ok || info.Error.Code() == kafka.ErrUnknownTopicOrPart || len(info.Partitions) == 0 {
+		return nil, map[int32]int64{}, nil
+	}
+
+	partitions := make([]kafka.TopicPartition, 0, len(info.Partitions))
+	for _, p := range info.Partitions {
+		partitions = append(partitions, kafka.TopicPartition{Topic: &topic, Partition: p.ID})
+	}
+	committed, err := d.consumer.Committed(partitions, metadataTimeoutMs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	high := make(map[int32]int64, len(committed))
+	for i, p := range committed {
+		low, hi, err := d.consumer.QueryWatermarkOffsets(DLQTopic, p.Partition, metadataTimeoutMs)
+		if err != nil {
+			return nil, nil, err
+		}
+		// сообщения старше low уже удалены по retention
+		if p.Offset < 0 || int64(p.Offset) < low {
+			committed[i].Offset = kafka.Offset(low)
+		}
+		high[p.Partition] = hi
+	}
+	return committed, high, nil
+}
+
+// Size - количество неразобранных писем в dlq
+func (d *DLQ) Size() (int64, error) {
+	partitions, high, err := d.pending()
+	if err != nil {
+		return 0, err
+	}
+	var size int64
+	for _, p := range partitions {
+		size += max(high[p.Partition]-int64(p.Offset), 0)
+	}
+	return size, nil
+}
+
+// read - до limit неразобранных писем (limit <= 0 - все) по порядку партиций. Для каждого письма
+// вызывается fn, после успешного fn офсет коммитится, если commit
+func (d *DLQ) read(ctx context.Context, limit int, commit bool, fn func(DeadLetter) error) (int, error) {
+	partitions, high, err := d.pending()
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, p := range partitions {
+		if int64(p.Offset) >= high[p.Partition] {
+			continue
+		}
+		if err := d.consumer.Assign([]kafka.TopicPartition{p}); err != nil {
+			return count, err
+		}
+
+		for offset := int64(p.Offset); offset < high[p.Partition]; {
+			if limit > 0 && count >= limit {
+				return count, d.consumer.Unassign()
+			}
+			if err := ctx.Err(); err != nil {
+				return count, err
+			}
+
+			msg, err := d.consumer.ReadMessage(time.Duration(metadataTimeoutMs) * time.Millisecond)
+			var kafkaErr kafka.Error
+			if errors.As(err, &kafkaErr) && kafkaErr.Code() == kafka.ErrTimedOut {
+				// до high остались только служебные записи
+				break
+			}
+			if err != nil {
+				return count, err
+			}
+			offset = int64(msg.TopicPartition.Offset) + 1
+
+			letter := DeadLetter{
+				Partition: msg.TopicPartition.Partition,
+				Offset:    int64(msg.TopicPartition.Offset),
+				EventId:   headerValue(msg, HeaderEventId),
+				Attempt:   headerInt(msg, HeaderAttempt),
+				Error:     headerValue(msg, HeaderError),
+				FailedAt:  headerTime(msg, HeaderFailedAt),
+				Value:     msg.Value,
+			}
+			if err := fn(letter); err != nil {
+				return count, err
+			}
+			count++
+
+			if commit {
+				if _, err := d.consumer.CommitMessage(msg); err != nil {
+					return count, err
+				}
+			}
+		}
+	}
+	return count, d.consumer.Unassign()
+}
+
+// List - неразобранные письма без изменения офсетов
+func (d *DLQ) List(ctx context.Context, limit int, fn func(DeadLetter) error) (int, error) {
+	return d.read(ctx, limit, false, fn)
+}
+
+// Replay - повторная отправка писем: они возвращаются в основной топик с обнулённым счётчиком попыток
+func (d *DLQ) Replay(ctx context.Context, limit int) (int, error) {
+	topic := Topic
+	return d.read(ctx, limit, true, func(letter DeadLetter) error {
+		headers := []kafka.Header{header(HeaderReplayedBy, fmt.Sprintf("%s/%d/%d", DLQTopic, letter.Partition, letter.Offset))}
+		if letter.EventId != "" {
+			headers = append(headers, header(HeaderEventId, letter.EventId))
+		}
+		err := produce(d.producer, &kafka.Message{
+			TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: kafka.PartitionAny},
+			Value:          letter.Value,
+			Headers:        headers,
+		})
+		if err != nil {
+			return err
+		}
+		log.Printf("Replayed %s/%d/%d", DLQTopic, letter.Partition, letter.Offset)
+		return nil
+	})
+}
+
+// Skip - письма отмечаются разобранными без повторной отправки
+func (d *DLQ) Skip(ctx context.Context, limit int) (int, error) {
+	return d.read(ctx, limit, true, func(DeadLetter) error { return nil })
+}
+
+// RunSizeMetric - обновление метрики размера dlq раз в interval до отмены контекста
+func (d *DLQ) RunSizeMetric(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if size, err := d.Size(); err != nil {
+			log.Printf("Failed to get %s size: %v", DLQTopic, err)
+		} else {
+			metrics.MailDLQSize.Set(float64(size))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
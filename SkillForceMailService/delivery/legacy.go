@@ -0,0 +1,58 @@
+package delivery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"skillForce/pkg/events"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+// unknownType - метка метрик для сообщений, которые не удалось разобрать
+const unknownType = "unknown"
+
+// legacyMessage - письмо в формате до events.Envelope. Такие сообщения могут оставаться в retry и
+// dlq топиках, записанных до перехода на конверт
+type legacyMessage struct {
+	Method     string
+	Token      string
+	UserEmail  string
+	UserName   string
+	CourseName string
+	CourseId   int
+	Url        string
+}
+
+// decode - событие из сообщения. Сообщение старого формата переводится в конверт версии 1
+func decode(ctx context.Context, msg *kafka.Message) (*events.Envelope, error) {
+	var legacy legacyMessage
+	if err := json.Unmarshal(msg.Value, &legacy); err != nil || legacy.Method == "" {
+		return events.Unmarshal(msg.Value)
+	}
+
+	recipient := &events.Recipient{Email: legacy.UserEmail, Name: legacy.UserName}
+	var payload events.Payload
+	switch legacy.Method {
+	case "send_confirm_mail":
+		payload = &events.ConfirmRegistrationMail{Recipient: recipient, Token: legacy.Token}
+	case "send_welcome_course_mail":
+		payload = &events.WelcomeCourseMail{Recipient: recipient, CourseId: int32(legacy.CourseId), CourseName: legacy.CourseName}
+	case "send_data_export_mail":
+		payload = &events.DataExportMail{Recipient: recipient, Url: legacy.Url}
+	default:
+		return nil, fmt.Errorf("%w: legacy method %q", events.ErrUnknownType, legacy.Method)
+	}
+
+	env, err := events.New(ctx, "legacy", payload)
+	if err != nil {
+		return nil, err
+	}
+	// у сообщений из outbox id события в заголовке: повторная доставка отбрасывается как и раньше
+	if eventId := headerValue(msg, HeaderEventId); eventId != "" {
+		env.Id = eventId
+		env.CorrelationId = eventId
+	}
+	return env, events.Validate(env)
+}
@@ -0,0 +1,102 @@
+package delivery
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"skillForce/db"
+)
+
+const (
+	defaultSuppressionsLimit = 100
+	maxSuppressionsLimit     = 1000
+)
+
+// SuppressionList - адреса, на которые письма не отправляются после жёстких отказов
+type SuppressionList interface {
+	ListSuppressions(ctx context.Context, query string, limit int) ([]db.Suppression, error)
+	GetSuppression(ctx context.Context, email string) (*db.Suppression, error)
+	DeleteSuppression(ctx context.Context, email string) (bool, error)
+}
+
+// SuppressionsHandler - API списка подавления:
+//
+//	GET /api/suppressions?q=example.com&limit=100   адреса от новых к старым
+//	GET /api/suppressions/{email}                   адрес и причина отказа
+//	DELETE /api/suppressions/{email}                снова отправлять письма на адрес
+//
+// Запросы без заголовка Authorization: Bearer token отклоняются: в списке адреса пользователей
+func SuppressionsHandler(list SuppressionList, token string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/suppressions", func(w http.ResponseWriter, r *http.Request) {
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		if err != nil || limit <= 0 {
+			limit = defaultSuppressionsLimit
+		}
+		limit = min(limit, maxSuppressionsLimit)
+
+		suppressions, err := list.ListSuppressions(r.Context(), r.URL.Query().Get("q"), limit)
+		if err != nil {
+			internalError(w, "list suppressions", err)
+			return
+		}
+		writeJSON(w, suppressions)
+	})
+	mux.HandleFunc("GET /api/suppressions/{email}", func(w http.ResponseWriter, r *http.Request) {
+		suppression, err := list.GetSuppression(r.Context(), r.PathValue("email"))
+		if err != nil {
+			internalError(w, "get suppression", err)
+			return
+		}
+		if suppression == nil {
+			http.Error(w, "address is not suppressed", http.StatusNotFound)
+			return
+		}
+		writeJSON(w, suppression)
+	})
+	mux.HandleFunc("DELETE /api/suppressions/{email}", func(w http.ResponseWriter, r *http.Request) {
+		email := r.PathValue("email")
+		deleted, err := list.DeleteSuppression(r.Context(), email)
+		if err != nil {
+			internalError(w, "delete suppression", err)
+			return
+		}
+		if !deleted {
+			http.Error(w, "address is not suppressed", http.StatusNotFound)
+			return
+		}
+		log.Printf("Suppression of %s cleared", email)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return RequireToken(token, mux)
+}
+
+// RequireToken - пропускает запросы с заголовком Authorization: Bearer token. Пустой token не принимается никогда
+func RequireToken(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func internalError(w http.ResponseWriter, action string, err error) {
+	log.Printf("Failed to %s: %v", action, err)
+	http.Error(w, "internal error", http.StatusInternalServerError)
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Failed to write response: %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package delivery
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"skillForce/db"
+)
+
+type fakeSuppressionList struct {
+	deleted []string
+}
+
+func (l *fakeSuppressionList) ListSuppressions(ctx context.Context, query string, limit int) ([]db.Suppression, error) {
+	return []db.Suppression{{Email: "user@example.com", Reason: "user unknown", SmtpCode: 550}}, nil
+}
+
+func (l *fakeSuppressionList) GetSuppression(ctx context.Context, email string) (*db.Suppression, error) {
+	return nil, nil
+}
+
+func (l *fakeSuppressionList) DeleteSuppression(ctx context.Context, email string) (bool, error) {
+	l.deleted = append(l.deleted, email)
+	return true, nil
+}
+
+func TestSuppressionsHandler_Auth(t *testing.T) {
+	tests := []struct {
+		name   string
+		token  string
+		header string
+		want   int
+	}{
+		{name: "no header", token: "admin-token", want: http.StatusUnauthorized},
+		{name: "wrong token", token: "admin-token", header: "Bearer other", want: http.StatusUnauthorized},
+		{name: "not bearer", token: "admin-token", header: "admin-token", want: http.StatusUnauthorized},
+		{name: "empty token configured", token: "", header: "Bearer ", want: http.StatusUnauthorized},
+		{name: "valid", token: "admin-token", header: "Bearer admin-token", want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := SuppressionsHandler(&fakeSuppressionList{}, tt.token)
+			req := httptest.NewRequest(http.MethodGet, "/api/suppressions", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestSuppressionsHandler_DeleteRequiresToken(t *testing.T) {
+	list := &fakeSuppressionList{}
+	handler := SuppressionsHandler(list, "admin-token")
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/suppressions/user@example.com", nil)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized || len(list.deleted) != 0 {
+		t.Fatalf("unauthorized delete: status %d, deleted %v", rec.Code, list.deleted)
+	}
+
+	req.Header.Set("Authorization", "Bearer admin-token")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNoContent || len(list.deleted) != 1 || list.deleted[0] != "user@example.com" {
+		t.Fatalf("authorized delete: status %d, deleted %v", rec.Code, list.deleted)
+	}
+}
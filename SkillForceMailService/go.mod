@@ -7,7 +7,10 @@ toolchain go1.23.6
 require (
 	github.com/confluentinc/confluent-kafka-go/v2 v2.10.0
 	github.com/joho/godotenv v1.5.1
+	github.com/lib/pq v1.10.9
 	github.com/prometheus/client_golang v1.17.0
+	golang.org/x/time v0.6.0
+	google.golang.org/protobuf v1.36.5
 	gopkg.in/yaml.v2 v2.4.0
 )
 
@@ -21,5 +24,4 @@ require (
 	github.com/prometheus/common v0.44.0 // indirect
 	github.com/prometheus/procfs v0.12.0 // indirect
 	golang.org/x/sys v0.25.0 // indirect
-	google.golang.org/protobuf v1.33.0 // indirect
 )
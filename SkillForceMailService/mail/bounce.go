@@ -0,0 +1,49 @@
+package mail
+
+import (
+	"errors"
+	"net/textproto"
+	"strings"
+)
+
+// RecipientError - сервер получателя отказался принять адрес (ответ на RCPT TO)
+type RecipientError struct {
+	Address string
+	Err     error
+}
+
+func (e *RecipientError) Error() string {
+	return "recipient " + e.Address + ": " + e.Err.Error()
+}
+
+func (e *RecipientError) Unwrap() error {
+	return e.Err
+}
+
+// Bounce - жёсткий отказ: ящика не существует или он отключён, повторная отправка на адрес бессмысленна
+type Bounce struct {
+	Address string
+	Code    int
+	Message string
+}
+
+// HardBounce - жёсткий отказ из ошибки отправки. Отказами считаются только ответы 5xx на RCPT TO:
+// с расширенным кодом 5.1.x (адрес) или 5.2.1 (ящик отключён), без расширенного кода - 550, 551 и 553.
+// Остальные 5xx (5.7.x - политика сервера, 5.2.2 - ящик переполнен, отказ письму после DATA)
+// говорят о проблеме письма или отправителя, а не адреса
+func HardBounce(err error) (*Bounce, bool) {
+	var rcptErr *RecipientError
+	var smtpErr *textproto.Error
+	if !errors.As(err, &rcptErr) || !errors.As(rcptErr.Err, &smtpErr) || smtpErr.Code < 500 {
+		return nil, false
+	}
+
+	hard := smtpErr.Code == 550 || smtpErr.Code == 551 || smtpErr.Code == 553
+	if status, _, _ := strings.Cut(smtpErr.Msg, " "); strings.HasPrefix(status, "5.") && strings.Count(status, ".") == 2 {
+		hard = strings.HasPrefix(status, "5.1.") || status == "5.2.1"
+	}
+	if !hard {
+		return nil, false
+	}
+	return &Bounce{Address: rcptErr.Address, Code: smtpErr.Code, Message: smtpErr.Msg}, true
+}
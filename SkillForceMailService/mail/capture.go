@@ -0,0 +1,241 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"html"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	netmail "net/mail"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultCaptureLimit = 200
+
+// CapturedMail - письмо, перехваченное транспортом memory. Text и HTML - раскодированные части письма
+type CapturedMail struct {
+	Id      int               `json:"id"`
+	From    string            `json:"from"`
+	To      []string          `json:"to"`
+	Subject string            `json:"subject"`
+	Date    time.Time         `json:"date"`
+	Text    string            `json:"text"`
+	HTML    string            `json:"html"`
+	Headers map[string]string `json:"headers"`
+	Raw     []byte            `json:"-"`
+}
+
+// CaptureTransport - письма не отправляются, а хранятся в памяти: последние limit писем видны
+// в почтовом ящике на порту метрик (/inbox) и по API (/api/inbox), по которому интеграционные тесты
+// проверяют отправленные письма
+type CaptureTransport struct {
+	mu     sync.Mutex
+	limit  int
+	nextId int
+	mails  []*CapturedMail
+}
+
+func NewCaptureTransport(limit int) *CaptureTransport {
+	if limit <= 0 {
+		limit = defaultCaptureLimit
+	}
+	return &CaptureTransport{limit: limit, nextId: 1}
+}
+
+func (t *CaptureTransport) Send(ctx context.Context, from string, to []string, msg []byte) error {
+	captured, err := parseCaptured(msg)
+	if err != nil {
+		return err
+	}
+	captured.From = from
+	captured.To = append([]string(nil), to...)
+	captured.Raw = append([]byte(nil), msg...)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	captured.Id = t.nextId
+	t.nextId++
+	t.mails = append(t.mails, captured)
+	if len(t.mails) > t.limit {
+		t.mails = t.mails[len(t.mails)-t.limit:]
+	}
+	return nil
+}
+
+func (t *CaptureTransport) Close() error {
+	return nil
+}
+
+// Mails - письма от новых к старым. Непустой to оставляет только письма этому получателю
+func (t *CaptureTransport) Mails(to string) []*CapturedMail {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	mails := make([]*CapturedMail, 0, len(t.mails))
+	for i := len(t.mails) - 1; i >= 0; i-- {
+		if to == "" || containsAddress(t.mails[i].To, to) {
+			mails = append(mails, t.mails[i])
+		}
+	}
+	return mails
+}
+
+func (t *CaptureTransport) Get(id int) *CapturedMail {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, captured := range t.mails {
+		if captured.Id == id {
+			return captured
+		}
+	}
+	return nil
+}
+
+func (t *CaptureTransport) Clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.mails = nil
+}
+
+// Handler - почтовый ящик перехваченных писем:
+//
+//	GET /inbox                  список писем
+//	GET /inbox/{id}             HTML версия письма, ?format=text или eml
+//	GET /api/inbox?to=email     письма в JSON от новых к старым
+//	GET /api/inbox/{id}         письмо в JSON
+//	DELETE /api/inbox           очистка ящика
+//
+// Доступен только на внутреннем порту метрик
+func (t *CaptureTransport) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /inbox", func(w http.ResponseWriter, r *http.Request) {
+		var list strings.Builder
+		list.WriteString("<table><tr><th>#</th><th>Дата</th><th>Кому</th><th>Тема</th><th></th></tr>")
+		for _, captured := range t.Mails(r.URL.Query().Get("to")) {
+			link := "/inbox/" + strconv.Itoa(captured.Id)
+			list.WriteString("<tr><td>" + strconv.Itoa(captured.Id) + "</td><td>" + captured.Date.Format(time.DateTime) +
+				"</td><td>" + html.EscapeString(strings.Join(captured.To, ", ")) + `</td><td><a href="` + link + `">` +
+				html.EscapeString(captured.Subject) + `</a></td><td><a href="` + link + `?format=text">text</a> <a href="` +
+				link + `?format=eml">eml</a></td></tr>`)
+		}
+		list.WriteString("</table>")
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(list.String()))
+	})
+	mux.HandleFunc("GET /inbox/{id}", func(w http.ResponseWriter, r *http.Request) {
+		captured := t.lookup(w, r)
+		if captured == nil {
+			return
+		}
+		switch r.URL.Query().Get("format") {
+		case PreviewText:
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			_, _ = w.Write([]byte("Subject: " + captured.Subject + "\n\n" + captured.Text))
+		case PreviewEml:
+			w.Header().Set("Content-Type", "message/rfc822")
+			_, _ = w.Write(captured.Raw)
+		default:
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write([]byte(captured.HTML))
+		}
+	})
+	mux.HandleFunc("GET /api/inbox", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, t.Mails(r.URL.Query().Get("to")))
+	})
+	mux.HandleFunc("GET /api/inbox/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if captured := t.lookup(w, r); captured != nil {
+			writeJSON(w, captured)
+		}
+	})
+	mux.HandleFunc("DELETE /api/inbox", func(w http.ResponseWriter, r *http.Request) {
+		t.Clear()
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return mux
+}
+
+func (t *CaptureTransport) lookup(w http.ResponseWriter, r *http.Request) *CapturedMail {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "invalid mail id", http.StatusBadRequest)
+		return nil
+	}
+	captured := t.Get(id)
+	if captured == nil {
+		http.Error(w, "mail not found", http.StatusNotFound)
+	}
+	return captured
+}
+
+// parseCaptured - тема, заголовки и текстовые части письма из buildMessage
+func parseCaptured(msg []byte) (*CapturedMail, error) {
+	parsed, err := netmail.ReadMessage(bytes.NewReader(msg))
+	if err != nil {
+		return nil, err
+	}
+
+	captured := &CapturedMail{Headers: make(map[string]string, len(parsed.Header))}
+	for name, values := range parsed.Header {
+		captured.Headers[name] = strings.Join(values, ", ")
+	}
+	if captured.Subject, err = new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject")); err != nil {
+		return nil, err
+	}
+	if captured.Date, err = parsed.Header.Date(); err != nil {
+		captured.Date = time.Now()
+	}
+
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		body, err := io.ReadAll(parsed.Body)
+		captured.Text = string(body)
+		return captured, err
+	}
+
+	// multipart.Reader сам раскодирует quoted-printable
+	reader := multipart.NewReader(parsed.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return captured, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		body, err := io.ReadAll(part)
+		if err != nil {
+			return nil, err
+		}
+		partType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		switch partType {
+		case "text/plain":
+			captured.Text = string(body)
+		case "text/html":
+			captured.HTML = string(body)
+		}
+	}
+}
+
+func containsAddress(addresses []string, address string) bool {
+	for _, a := range addresses {
+		if strings.EqualFold(a, address) {
+			return true
+		}
+	}
+	return false
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,56 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// mboxFromLine - строки тела, которые в mbox нужно экранировать, иначе они начнут новое письмо
+var mboxFromLine = regexp.MustCompile(`(?m)^(>*From )`)
+
+// FileTransport - письма дописываются в mbox файл (формат mboxrd), его открывают почтовые клиенты
+// и mutt: mutt -f mail.mbox
+type FileTransport struct {
+	mu   sync.Mutex
+	path string
+}
+
+func NewFileTransport(path string) (*FileTransport, error) {
+	if path == "" {
+		return nil, errors.New("mbox file is not set")
+	}
+	return &FileTransport{path: path}, nil
+}
+
+func (t *FileTransport) Send(ctx context.Context, from string, to []string, msg []byte) error {
+	var entry bytes.Buffer
+	fmt.Fprintf(&entry, "From %s %s\n", from, time.Now().UTC().Format(time.ANSIC))
+	body := bytes.ReplaceAll(msg, []byte("\r\n"), []byte("\n"))
+	entry.Write(mboxFromLine.ReplaceAll(body, []byte(">$1")))
+	if !bytes.HasSuffix(body, []byte("\n")) {
+		entry.WriteByte('\n')
+	}
+	entry.WriteByte('\n')
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	f, err := os.OpenFile(t.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(entry.Bytes()); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (t *FileTransport) Close() error {
+	return nil
+}
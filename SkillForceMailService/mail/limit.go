@@ -0,0 +1,110 @@
+package mail
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"skillForce/metrics"
+
+	"golang.org/x/time/rate"
+)
+
+// после стольких доменов из ограничителя удаляются домены с полным запасом писем
+const maxIdleDomains = 10000
+
+// RateLimits - скорость отправки в письмах в секунду. Нулевая скорость - без ограничения
+type RateLimits struct {
+	Rate        float64
+	Burst       int
+	DomainRate  float64
+	DomainBurst int
+	// Domains - своя скорость для доменов получателей, например крупных почтовых сервисов
+	Domains map[string]float64
+}
+
+// RateLimitedTransport - отправка через next не быстрее общего ограничения и ограничения домена получателя.
+// Письмо ждёт своей очереди, пока не отменён контекст
+type RateLimitedTransport struct {
+	next    Transport
+	limits  RateLimits
+	global  *rate.Limiter
+	mu      sync.Mutex
+	domains map[string]*rate.Limiter
+}
+
+func NewRateLimitedTransport(next Transport, limits RateLimits) *RateLimitedTransport {
+	return &RateLimitedTransport{
+		next:    next,
+		limits:  limits,
+		global:  newLimiter(limits.Rate, limits.Burst),
+		domains: make(map[string]*rate.Limiter),
+	}
+}
+
+func (t *RateLimitedTransport) Send(ctx context.Context, from string, to []string, msg []byte) error {
+	for _, rcpt := range to {
+		if err := wait(ctx, t.domain(domainOf(rcpt)), "domain"); err != nil {
+			return err
+		}
+	}
+	if err := wait(ctx, t.global, "global"); err != nil {
+		return err
+	}
+	return t.next.Send(ctx, from, to, msg)
+}
+
+func (t *RateLimitedTransport) Close() error {
+	return t.next.Close()
+}
+
+// domain - ограничитель домена, создаётся при первом письме на домен
+func (t *RateLimitedTransport) domain(domain string) *rate.Limiter {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if limiter, ok := t.domains[domain]; ok {
+		return limiter
+	}
+	if len(t.domains) >= maxIdleDomains {
+		now := time.Now()
+		for name, limiter := range t.domains {
+			if limiter.TokensAt(now) >= float64(limiter.Burst()) {
+				delete(t.domains, name)
+			}
+		}
+	}
+
+	domainRate, ok := t.limits.Domains[domain]
+	if !ok {
+		domainRate = t.limits.DomainRate
+	}
+	limiter := newLimiter(domainRate, t.limits.DomainBurst)
+	t.domains[domain] = limiter
+	return limiter
+}
+
+func newLimiter(perSecond float64, burst int) *rate.Limiter {
+	if perSecond <= 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return rate.NewLimiter(rate.Limit(perSecond), burst)
+}
+
+func wait(ctx context.Context, limiter *rate.Limiter, limit string) error {
+	if limiter.Limit() == rate.Inf {
+		return nil
+	}
+	startTime := time.Now()
+	err := limiter.Wait(ctx)
+	metrics.MailRateLimitWait.WithLabelValues(limit).Observe(time.Since(startTime).Seconds())
+	return err
+}
+
+func domainOf(address string) string {
+	return strings.ToLower(address[strings.LastIndex(address, "@")+1:])
+}
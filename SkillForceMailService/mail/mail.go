@@ -1,138 +1,159 @@
 package mail
 
 import (
-	"bytes"
 	"context"
 	"fmt"
-	"net/smtp"
-	"os"
-	"text/template"
 	"time"
 
 	"skillForce/metrics"
+	"skillForce/pkg/events"
+	"skillForce/pkg/unsubscribe"
 )
 
-type KafkaMessage struct {
-	Method     string
-	Token      string
-	UserEmail  string
-	UserName   string
-	CourseName string
-	CourseId   int
-	Url        string
-}
-
-type EmailData struct {
-	UserName   string
-	CourseName string
-	CourseId   int
-	Url        string
-}
-
 type Mail struct {
-	from     string
-	password string
-	host     string
-	port     string
-	auth     smtp.Auth
+	from      string
+	transport Transport
+	templates *Templates
+	baseUrl   string
+	signer    *unsubscribe.Signer
 }
 
-func NewMail(from string, password string, host string, port string) *Mail {
+// NewMail - отправка писем по шаблонам templates через transport. baseUrl - адрес сайта для ссылок в письмах,
+// signer подписывает ссылки отписки
+func NewMail(from string, transport Transport, templates *Templates, baseUrl string, signer *unsubscribe.Signer) *Mail {
 	return &Mail{
-		from:     from,
-		password: password,
-		host:     host,
-		port:     port,
-		auth:     smtp.PlainAuth("", from, password, host),
+		from:      from,
+		transport: transport,
+		templates: templates,
+		baseUrl:   baseUrl,
+		signer:    signer,
 	}
 }
 
-func (m *Mail) SendRegMail(ctx context.Context, kafkaMsg KafkaMessage) error {
-	startTime := time.Now()
-	status := "success"
+func (m *Mail) SendRegMail(ctx context.Context, event *events.ConfirmRegistrationMail) error {
+	data := EmailData{
+		UserName: event.GetRecipient().GetName(),
+		Url:      ConfirmUrl(m.baseUrl, event.GetToken()),
+	}
+	return m.send(ctx, events.TypeConfirmRegistrationMail, TemplateConfirmRegistration, events.PayloadCategory(event), event.GetRecipient(), data)
+}
 
-	defer func() {
-		duration := time.Since(startTime).Seconds()
-		metrics.MailRequestDuration.WithLabelValues(kafkaMsg.Method, status).Observe(duration)
-		metrics.MailRequestsTotal.WithLabelValues(kafkaMsg.Method, status).Inc()
-	}()
-	subject := "Регистрация на платформе SkillForce"
+func (m *Mail) SendWelcomeCourseMail(ctx context.Context, event *events.WelcomeCourseMail) error {
+	data := EmailData{
+		UserName:   event.GetRecipient().GetName(),
+		CourseName: event.GetCourseName(),
+		CourseId:   int(event.GetCourseId()),
+		Url:        CourseUrl(m.baseUrl, int(event.GetCourseId())),
+	}
+	return m.send(ctx, events.TypeWelcomeCourseMail, TemplateWelcomeCourse, events.PayloadCategory(event), event.GetRecipient(), data)
+}
 
-	templatePath := "./mail/layouts/confirm_mail.html"
-	tmplBytes, err := os.ReadFile(templatePath)
-	if err != nil {
-		fmt.Println("SendRegMail", err.Error())
-		status = "error"
-		return err
+// SendDataExportMail - письмо со ссылкой на архив с данными пользователя
+func (m *Mail) SendDataExportMail(ctx context.Context, event *events.DataExportMail) error {
+	data := EmailData{
+		UserName: event.GetRecipient().GetName(),
+		Url:      event.GetUrl(),
 	}
+	return m.send(ctx, events.TypeDataExportMail, TemplateDataExport, events.PayloadCategory(event), event.GetRecipient(), data)
+}
 
-	tmpl, err := template.New("email").Parse(string(tmplBytes))
-	if err != nil {
-		fmt.Println("SendRegMail", err.Error())
-		status = "error"
-		return err
+// SendEngagementMail - письмо кампании вовлечения. Незнакомая кампания - ErrTemplateNotFound
+func (m *Mail) SendEngagementMail(ctx context.Context, event *events.EngagementMail) error {
+	name := event.GetCampaign()
+	if !campaignTemplates[name] {
+		return fmt.Errorf("campaign %q: %w", name, ErrTemplateNotFound)
+	}
+	data := EmailData{
+		UserName:   event.GetRecipient().GetName(),
+		CourseName: event.GetCourseName(),
+		CourseId:   int(event.GetCourseId()),
+		Progress:   int(event.GetProgressPercent()),
+		Url:        campaignUrl(m.baseUrl, name, int(event.GetCourseId())),
 	}
+	return m.send(ctx, events.TypeEngagementMail+"."+name, name, events.PayloadCategory(event), event.GetRecipient(), data)
+}
 
-	url := fmt.Sprintf("https://skill-force.ru/validate/%s", kafkaMsg.Token)
-	var body bytes.Buffer
-	err = tmpl.Execute(&body, EmailData{UserName: kafkaMsg.UserName, Url: url})
+// SendWeeklyDigestMail - еженедельная сводка обучения. Даты недели показываются в часовом поясе получателя
+func (m *Mail) SendWeeklyDigestMail(ctx context.Context, event *events.WeeklyDigestMail) error {
+	loc, err := time.LoadLocation(event.GetTimezone())
 	if err != nil {
-		fmt.Println("SendRegMail", err.Error())
-		status = "error"
-		return err
+		loc = time.UTC
+	}
+	digest := &Digest{
+		FirstDay: event.GetPeriodStart().AsTime().In(loc),
+		// неделя [period_start, period_end), последний день - день перед period_end
+		LastDay:    event.GetPeriodEnd().AsTime().In(loc).AddDate(0, 0, -1),
+		Lessons:    int(event.GetLessonsCompleted()),
+		Courses:    m.digestCourses(event.GetCourses()),
+		NewCourses: m.digestCourses(event.GetNewCourses()),
+	}
+	for _, rating := range event.GetRatings() {
+		digest.Ratings = append(digest.Ratings, DigestRating{
+			CourseName: rating.GetCourseName(),
+			Url:        CourseUrl(m.baseUrl, int(rating.GetCourseId())),
+			Position:   int(rating.GetPosition()),
+			Change:     int(rating.GetChange()),
+		})
 	}
 
-	msg := fmt.Sprintf("To: %s\r\nFrom: %s\r\nSubject: %s\r\n", kafkaMsg.UserEmail, m.from, subject)
-	msg += "MIME-Version: 1.0\r\nContent-Type: text/html; charset=\"UTF-8\"\r\n\r\n"
-	msg += body.String()
-
-	err = smtp.SendMail(fmt.Sprintf("%s:%s", m.host, m.port), m.auth, m.from, []string{kafkaMsg.UserEmail}, []byte(msg))
-	if err != nil {
-		fmt.Println("SendRegMail", err.Error())
-		status = "error"
-		return err
+	data := EmailData{
+		UserName: event.GetRecipient().GetName(),
+		Url:      m.baseUrl,
+		Digest:   digest,
 	}
+	return m.send(ctx, events.TypeWeeklyDigestMail, TemplateWeeklyDigest, events.PayloadCategory(event), event.GetRecipient(), data)
+}
 
-	fmt.Println("SendRegMail", fmt.Sprintf("mail sent to %s", kafkaMsg.UserEmail))
-	return nil
+func (m *Mail) digestCourses(courses []*events.DigestCourse) []DigestCourse {
+	result := make([]DigestCourse, 0, len(courses))
+	for _, course := range courses {
+		result = append(result, DigestCourse{
+			Name:     course.GetCourseName(),
+			Url:      CourseUrl(m.baseUrl, int(course.GetCourseId())),
+			Lessons:  int(course.GetLessonsCompleted()),
+			Progress: int(course.GetProgressPercent()),
+		})
+	}
+	return result
 }
 
-func (m *Mail) SendWelcomeCourseMail(ctx context.Context, kafkaMsg KafkaMessage) error {
-	auth := smtp.PlainAuth("", m.from, m.password, m.host)
+// send - отрисовка шаблона на языке получателя и отправка. method - метка метрик. В нетранзакционные письма
+// зарегистрированным пользователям добавляется ссылка отписки от category
+func (m *Mail) send(ctx context.Context, method string, name string, category string, recipient *events.Recipient, data EmailData) error {
+	startTime := time.Now()
+	status := "success"
 
-	subject := "Продолжайте своё обучение!"
+	defer func() {
+		duration := time.Since(startTime).Seconds()
+		metrics.MailRequestDuration.WithLabelValues(method, status).Observe(duration)
+		metrics.MailRequestsTotal.WithLabelValues(method, status).Inc()
+	}()
 
-	templatePath := "./mail/layouts/welcome_course_mail.html"
-	tmplBytes, err := os.ReadFile(templatePath)
-	if err != nil {
-		fmt.Println("SendWelcomeMail", err.Error())
-		return err
+	data.BaseUrl = m.baseUrl
+	if category != events.CategoryTransactional && recipient.GetUserId() > 0 {
+		data.UnsubscribeUrl = m.signer.URL(m.baseUrl, int(recipient.GetUserId()), category)
 	}
-
-	tmpl, err := template.New("email").Parse(string(tmplBytes))
+	message, err := m.templates.Render(name, recipient.GetLocale(), data)
 	if err != nil {
-		fmt.Println("SendWelcomeMail", err.Error())
+		fmt.Println(name, err.Error())
+		status = "error"
 		return err
 	}
 
-	url := fmt.Sprintf("https://skill-force.ru/course/%d", kafkaMsg.CourseId)
-	var body bytes.Buffer
-	err = tmpl.Execute(&body, EmailData{CourseName: kafkaMsg.CourseName, UserName: kafkaMsg.UserName, Url: url})
+	msg, err := buildMessage(m.from, recipient.GetEmail(), message)
 	if err != nil {
-		fmt.Println("SendWelcomeMail", err.Error())
+		fmt.Println(name, err.Error())
+		status = "error"
 		return err
 	}
 
-	msg := fmt.Sprintf("To: %s\r\nFrom: %s\r\nSubject: %s\r\n", kafkaMsg.UserEmail, m.from, subject)
-	msg += "MIME-Version: 1.0\r\nContent-Type: text/html; charset=\"UTF-8\"\r\n\r\n"
-	msg += body.String()
-
-	err = smtp.SendMail(fmt.Sprintf("%s:%s", m.host, m.port), auth, m.from, []string{kafkaMsg.UserEmail}, []byte(msg))
+	err = m.transport.Send(ctx, m.from, []string{recipient.GetEmail()}, msg)
 	if err != nil {
-		fmt.Println("SendWelcomeMail", err.Error())
+		fmt.Println(name, err.Error())
+		status = "error"
 		return err
 	}
 
-	fmt.Println("SendWelcomeMail", fmt.Sprintf("mail sent to %s", kafkaMsg.UserEmail))
+	fmt.Println(name, fmt.Sprintf("mail sent to %s", recipient.GetEmail()))
 	return nil
 }
@@ -0,0 +1,59 @@
+package mail
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"time"
+)
+
+// buildMessage - письмо multipart/alternative: текстовая версия для клиентов без HTML и HTML версия.
+// Клиент показывает последнюю версию, которую умеет отображать, поэтому HTML идёт второй.
+// У письма со ссылкой отписки есть заголовки отписки в один клик по RFC 8058
+func buildMessage(from string, to string, message *Message) ([]byte, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	for _, part := range []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=UTF-8", message.Text},
+		{"text/html; charset=UTF-8", message.HTML},
+	} {
+		partWriter, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(partWriter)
+		if _, err := qp.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", to)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("UTF-8", message.Subject))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	if message.UnsubscribeUrl != "" {
+		fmt.Fprintf(&msg, "List-Unsubscribe: <%s>\r\n", message.UnsubscribeUrl)
+		msg.WriteString("List-Unsubscribe-Post: List-Unsubscribe=One-Click\r\n")
+	}
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&msg, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", writer.Boundary())
+	msg.Write(body.Bytes())
+	return msg.Bytes(), nil
+}
@@ -0,0 +1,70 @@
+package mail
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"strings"
+)
+
+// Форматы предпросмотра
+const (
+	PreviewHTML = "html"
+	PreviewText = "text"
+	PreviewEml  = "eml"
+)
+
+// Preview - письмо name с тестовыми данными в формате html, text или eml (письмо целиком, как его
+// получит SMTP сервер)
+func (m *Mail) Preview(name string, locale string, format string) ([]byte, string, error) {
+	message, err := m.templates.Render(name, locale, SampleData(name, m.baseUrl))
+	if err != nil {
+		return nil, "", err
+	}
+
+	switch format {
+	case PreviewHTML, "":
+		return []byte(message.HTML), "text/html; charset=utf-8", nil
+	case PreviewText:
+		return []byte("Subject: " + message.Subject + "\n\n" + message.Text), "text/plain; charset=utf-8", nil
+	case PreviewEml:
+		msg, err := buildMessage(m.from, "preview@example.com", message)
+		return msg, "message/rfc822", err
+	}
+	return nil, "", fmt.Errorf("unknown format %q", format)
+}
+
+// PreviewHandler - GET /preview со списком шаблонов и GET /preview/{name}?locale=en&format=text.
+// Доступен только на внутреннем порту метрик
+func (m *Mail) PreviewHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /preview", func(w http.ResponseWriter, r *http.Request) {
+		var list strings.Builder
+		list.WriteString("<ul>")
+		for _, name := range m.templates.Names() {
+			for _, locale := range m.templates.Locales() {
+				link := html.EscapeString(fmt.Sprintf("/preview/%s?locale=%s", name, locale))
+				fmt.Fprintf(&list, `<li><a href="%s">%s (%s)</a> <a href="%s&format=text">text</a></li>`,
+					link, html.EscapeString(name), html.EscapeString(locale), link)
+			}
+		}
+		list.WriteString("</ul>")
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(list.String()))
+	})
+	mux.HandleFunc("GET /preview/{name}", func(w http.ResponseWriter, r *http.Request) {
+		body, contentType, err := m.Preview(r.PathValue("name"), r.URL.Query().Get("locale"), r.URL.Query().Get("format"))
+		if errors.Is(err, ErrTemplateNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", contentType)
+		_, _ = w.Write(body)
+	})
+	return mux
+}
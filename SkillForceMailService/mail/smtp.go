@@ -0,0 +1,194 @@
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"time"
+)
+
+const (
+	smtpDialTimeout = 30 * time.Second
+	smtpSendTimeout = time.Minute
+)
+
+// SMTPTransport - отправка через SMTP сервер. Соединения с пройденными TLS и авторизацией
+// переиспользуются: после письма соединение возвращается в пул, если в нём есть место.
+// Соединение, простаивавшее дольше IdleTimeout или не ответившее на NOOP, закрывается
+type SMTPTransport struct {
+	addr        string
+	host        string
+	tlsMode     string
+	auth        smtp.Auth
+	idleTimeout time.Duration
+	pool        chan *smtpConn
+}
+
+type smtpConn struct {
+	conn     net.Conn
+	client   *smtp.Client
+	lastUsed time.Time
+}
+
+func NewSMTPTransport(conf TransportConfig) (*SMTPTransport, error) {
+	tlsMode := conf.TLS
+	if tlsMode == "" {
+		tlsMode = TLSStartTLS
+	}
+	if tlsMode != TLSStartTLS && tlsMode != TLSImplicit && tlsMode != TLSNone {
+		return nil, fmt.Errorf("unknown smtp tls mode %q", conf.TLS)
+	}
+
+	poolSize := conf.PoolSize
+	if poolSize < 1 {
+		poolSize = 1
+	}
+
+	transport := &SMTPTransport{
+		addr:        net.JoinHostPort(conf.Host, conf.Port),
+		host:        conf.Host,
+		tlsMode:     tlsMode,
+		idleTimeout: conf.IdleTimeout,
+		pool:        make(chan *smtpConn, poolSize),
+	}
+	// без пароля письма отправляются без авторизации, например на локальный SMTP сервер
+	if conf.Password != "" {
+		transport.auth = smtp.PlainAuth("", conf.Username, conf.Password, conf.Host)
+	}
+	return transport, nil
+}
+
+func (t *SMTPTransport) Send(ctx context.Context, from string, to []string, msg []byte) error {
+	c, err := t.get(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := c.send(from, to, msg); err != nil {
+		// после ошибки состояние сессии неизвестно, соединение не переиспользуется
+		_ = c.client.Close()
+		return err
+	}
+	t.put(c)
+	return nil
+}
+
+// Close - закрытие соединений из пула
+func (t *SMTPTransport) Close() error {
+	for {
+		select {
+		case c := <-t.pool:
+			c.quit()
+		default:
+			return nil
+		}
+	}
+}
+
+// get - живое соединение из пула или новое
+func (t *SMTPTransport) get(ctx context.Context) (*smtpConn, error) {
+	for {
+		select {
+		case c := <-t.pool:
+			if t.idleTimeout > 0 && time.Since(c.lastUsed) > t.idleTimeout {
+				c.quit()
+				continue
+			}
+			if err := c.conn.SetDeadline(time.Now().Add(smtpDialTimeout)); err != nil || c.client.Noop() != nil {
+				_ = c.client.Close()
+				continue
+			}
+			return c, nil
+		default:
+			return t.dial(ctx)
+		}
+	}
+}
+
+func (t *SMTPTransport) put(c *smtpConn) {
+	c.lastUsed = time.Now()
+	select {
+	case t.pool <- c:
+	default:
+		c.quit()
+	}
+}
+
+func (t *SMTPTransport) dial(ctx context.Context) (*smtpConn, error) {
+	dialer := &net.Dialer{Timeout: smtpDialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", t.addr)
+	if err != nil {
+		return nil, err
+	}
+	if err := conn.SetDeadline(time.Now().Add(smtpDialTimeout)); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	if t.tlsMode == TLSImplicit {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: t.host})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			_ = conn.Close()
+			return nil, err
+		}
+		conn = tlsConn
+	}
+
+	client, err := smtp.NewClient(conn, t.host)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	c := &smtpConn{conn: conn, client: client}
+
+	if t.tlsMode == TLSStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			_ = client.Close()
+			return nil, errors.New("smtp server does not support STARTTLS")
+		}
+		if err := client.StartTLS(&tls.Config{ServerName: t.host}); err != nil {
+			_ = client.Close()
+			return nil, err
+		}
+	}
+
+	if t.auth != nil {
+		if err := client.Auth(t.auth); err != nil {
+			_ = client.Close()
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+func (c *smtpConn) send(from string, to []string, msg []byte) error {
+	if err := c.conn.SetDeadline(time.Now().Add(smtpSendTimeout)); err != nil {
+		return err
+	}
+	if err := c.client.Mail(from); err != nil {
+		return err
+	}
+	for _, rcpt := range to {
+		if err := c.client.Rcpt(rcpt); err != nil {
+			return &RecipientError{Address: rcpt, Err: err}
+		}
+	}
+	w, err := c.client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	return w.Close()
+}
+
+func (c *smtpConn) quit() {
+	if err := c.conn.SetDeadline(time.Now().Add(smtpDialTimeout)); err == nil && c.client.Quit() == nil {
+		return
+	}
+	_ = c.client.Close()
+}
@@ -0,0 +1,349 @@
+package mail
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+// Шаблоны писем лежат в templates_dir:
+//
+//	layouts/*.html.tmpl, layouts/*.txt.tmpl   общая разметка письма, шаблон "layout"
+//	partials/*.html.tmpl, partials/*.txt.tmpl общие куски, например "button"
+//	<locale>/_*.html.tmpl, <locale>/_*.txt.tmpl общие для языка куски, например "signature"
+//	<locale>/<name>.txt.tmpl                  "subject" и текстовая версия "content"
+//	<locale>/<name>.html.tmpl                 HTML версия "content"
+const (
+	layoutsDir  = "layouts"
+	partialsDir = "partials"
+	htmlExt     = ".html.tmpl"
+	textExt     = ".txt.tmpl"
+)
+
+// Имена шаблонов писем
+const (
+	TemplateConfirmRegistration = "confirm_registration"
+	TemplateWelcomeCourse       = "welcome_course"
+	TemplateDataExport          = "data_export"
+	TemplateMidCourse           = "mid_course"
+	TemplateInactivity          = "inactivity"
+	TemplateCourseCompleted     = "course_completed"
+	TemplateAbandonedCheckout   = "abandoned_checkout"
+	TemplateWeeklyDigest        = "weekly_digest"
+)
+
+// campaignTemplates - шаблоны писем кампаний вовлечения, имя шаблона приходит в событии
+var campaignTemplates = map[string]bool{
+	TemplateMidCourse:         true,
+	TemplateInactivity:        true,
+	TemplateCourseCompleted:   true,
+	TemplateAbandonedCheckout: true,
+}
+
+var ErrTemplateNotFound = errors.New("template not found")
+
+// EmailData - данные для шаблона. Progress - процент пройденных уроков курса, UnsubscribeUrl - ссылка
+// отписки от категории письма, пустая у транзакционных писем. Digest есть только у еженедельной сводки.
+// Locale и Subject заполняет Render
+type EmailData struct {
+	UserName       string
+	CourseName     string
+	CourseId       int
+	Progress       int
+	Url            string
+	BaseUrl        string
+	UnsubscribeUrl string
+	Digest         *Digest
+	Locale         string
+	Subject        string
+}
+
+// Digest - еженедельная сводка. FirstDay и LastDay - первый и последний день недели по времени получателя
+type Digest struct {
+	FirstDay   time.Time
+	LastDay    time.Time
+	Lessons    int
+	Courses    []DigestCourse
+	Ratings    []DigestRating
+	NewCourses []DigestCourse
+}
+
+// DigestCourse - курс в сводке: уроки за неделю и процент пройденных уроков
+type DigestCourse struct {
+	Name     string
+	Url      string
+	Lessons  int
+	Progress int
+}
+
+// DigestRating - место в рейтинге курса. Change - на сколько мест поднялся с прошлой сводки,
+// отрицательное - опустился
+type DigestRating struct {
+	CourseName string
+	Url        string
+	Position   int
+	Change     int
+}
+
+// Message - готовое письмо: тема, HTML и текстовая версия. UnsubscribeUrl попадает в заголовок List-Unsubscribe
+type Message struct {
+	Subject        string
+	HTML           string
+	Text           string
+	UnsubscribeUrl string
+}
+
+type template struct {
+	html *htmltemplate.Template
+	text *texttemplate.Template
+}
+
+// Templates - шаблоны всех писем на всех языках, загруженные и проверенные при старте
+type Templates struct {
+	defaultLocale string
+	// locale -> имя шаблона -> шаблон
+	locales map[string]map[string]*template
+}
+
+var funcs = map[string]any{
+	// dict - аргументы для partials: {{ template "button" (dict "Url" .Url "Label" "Открыть") }}
+	"dict": func(pairs ...any) (map[string]any, error) {
+		if len(pairs)%2 != 0 {
+			return nil, errors.New("dict: odd number of arguments")
+		}
+		dict := make(map[string]any, len(pairs)/2)
+		for i := 0; i < len(pairs); i += 2 {
+			key, ok := pairs[i].(string)
+			if !ok {
+				return nil, fmt.Errorf("dict: key %v is not a string", pairs[i])
+			}
+			dict[key] = pairs[i+1]
+		}
+		return dict, nil
+	},
+}
+
+// LoadTemplates - загрузка шаблонов из dir. Каждый шаблон должен быть на всех языках в обеих версиях
+// и отрисовываться с тестовыми данными SampleData, иначе сервис не стартует
+func LoadTemplates(dir string, defaultLocale string) (*Templates, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Templates{defaultLocale: defaultLocale, locales: make(map[string]map[string]*template)}
+	for _, entry := range entries {
+		if !entry.IsDir() || entry.Name() == layoutsDir || entry.Name() == partialsDir {
+			continue
+		}
+		templates, err := loadLocale(dir, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		t.locales[entry.Name()] = templates
+	}
+
+	if _, ok := t.locales[defaultLocale]; !ok {
+		return nil, fmt.Errorf("no templates for default locale %q", defaultLocale)
+	}
+	if err := t.validate(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func loadLocale(dir string, locale string) (map[string]*template, error) {
+	files, err := filepath.Glob(filepath.Join(dir, locale, "*"+textExt))
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make(map[string]*template)
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), textExt)
+		if strings.HasPrefix(name, "_") {
+			continue
+		}
+
+		text := texttemplate.New(name).Funcs(funcs).Option("missingkey=error")
+		for _, pattern := range sharedFiles(dir, locale, textExt) {
+			if text, err = parseGlob(text, pattern); err != nil {
+				return nil, err
+			}
+		}
+		if text, err = text.ParseFiles(file); err != nil {
+			return nil, err
+		}
+
+		html := htmltemplate.New(name).Funcs(funcs).Option("missingkey=error")
+		for _, pattern := range sharedFiles(dir, locale, htmlExt) {
+			if html, err = parseHTMLGlob(html, pattern); err != nil {
+				return nil, err
+			}
+		}
+		if html, err = html.ParseFiles(filepath.Join(dir, locale, name+htmlExt)); err != nil {
+			return nil, fmt.Errorf("%s/%s: %w", locale, name, err)
+		}
+
+		templates[name] = &template{html: html, text: text}
+	}
+	return templates, nil
+}
+
+func sharedFiles(dir string, locale string, ext string) []string {
+	return []string{
+		filepath.Join(dir, layoutsDir, "*"+ext),
+		filepath.Join(dir, partialsDir, "*"+ext),
+		filepath.Join(dir, locale, "_*"+ext),
+	}
+}
+
+// parseGlob - ParseGlob, которому не нужен хотя бы один файл
+func parseGlob(t *texttemplate.Template, pattern string) (*texttemplate.Template, error) {
+	if files, _ := filepath.Glob(pattern); len(files) == 0 {
+		return t, nil
+	}
+	return t.ParseGlob(pattern)
+}
+
+func parseHTMLGlob(t *htmltemplate.Template, pattern string) (*htmltemplate.Template, error) {
+	if files, _ := filepath.Glob(pattern); len(files) == 0 {
+		return t, nil
+	}
+	return t.ParseGlob(pattern)
+}
+
+// validate - одинаковый набор шаблонов во всех языках и отрисовка каждого с тестовыми данными
+func (t *Templates) validate() error {
+	names := t.Names()
+	for locale, templates := range t.locales {
+		if len(templates) != len(names) {
+			return fmt.Errorf("locale %q has %d templates, %q has %d", locale, len(templates), t.defaultLocale, len(names))
+		}
+		for _, name := range names {
+			if _, ok := templates[name]; !ok {
+				return fmt.Errorf("%s/%s: %w", locale, name, ErrTemplateNotFound)
+			}
+			if _, err := t.Render(name, locale, SampleData(name, "https://example.com")); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Names - имена шаблонов языка по умолчанию
+func (t *Templates) Names() []string {
+	names := make([]string, 0, len(t.locales[t.defaultLocale]))
+	for name := range t.locales[t.defaultLocale] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Locales - языки, для которых есть шаблоны
+func (t *Templates) Locales() []string {
+	locales := make([]string, 0, len(t.locales))
+	for locale := range t.locales {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Render - письмо name на языке locale. Незнакомый или пустой язык заменяется языком по умолчанию
+func (t *Templates) Render(name string, locale string, data EmailData) (*Message, error) {
+	templates, ok := t.locales[locale]
+	if !ok {
+		locale = t.defaultLocale
+		templates = t.locales[locale]
+	}
+	tmpl, ok := templates[name]
+	if !ok {
+		return nil, fmt.Errorf("%s/%s: %w", locale, name, ErrTemplateNotFound)
+	}
+	data.Locale = locale
+
+	var subject, text, html bytes.Buffer
+	if err := tmpl.text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, fmt.Errorf("%s/%s: %w", locale, name, err)
+	}
+	data.Subject = strings.TrimSpace(subject.String())
+	if err := tmpl.text.ExecuteTemplate(&text, "layout", data); err != nil {
+		return nil, fmt.Errorf("%s/%s: %w", locale, name, err)
+	}
+	if err := tmpl.html.ExecuteTemplate(&html, "layout", data); err != nil {
+		return nil, fmt.Errorf("%s/%s: %w", locale, name, err)
+	}
+
+	return &Message{Subject: data.Subject, HTML: html.String(), Text: text.String(), UnsubscribeUrl: data.UnsubscribeUrl}, nil
+}
+
+// SampleData - тестовые данные шаблона для проверки при старте и предпросмотра
+func SampleData(name string, baseUrl string) EmailData {
+	data := EmailData{UserName: "Алиса", BaseUrl: baseUrl}
+	switch name {
+	case TemplateConfirmRegistration:
+		data.Url = ConfirmUrl(baseUrl, "sample-token")
+	case TemplateWelcomeCourse:
+		data.CourseId = 1
+		data.CourseName = "Основы Go"
+		data.Url = CourseUrl(baseUrl, data.CourseId)
+		data.UnsubscribeUrl = baseUrl + "/api/unsubscribe?token=sample-token"
+	case TemplateDataExport:
+		data.Url = baseUrl + "/exports/sample.zip"
+	case TemplateMidCourse, TemplateInactivity, TemplateCourseCompleted, TemplateAbandonedCheckout:
+		data.CourseId = 1
+		data.CourseName = "Основы Go"
+		data.Progress = 50
+		data.Url = campaignUrl(baseUrl, name, data.CourseId)
+		data.UnsubscribeUrl = baseUrl + "/api/unsubscribe?token=sample-token"
+	case TemplateWeeklyDigest:
+		data.Url = baseUrl
+		data.UnsubscribeUrl = baseUrl + "/api/unsubscribe?token=sample-token"
+		data.Digest = &Digest{
+			FirstDay: time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC),
+			LastDay:  time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+			Lessons:  5,
+			Courses: []DigestCourse{
+				{Name: "Основы Go", Url: CourseUrl(baseUrl, 1), Lessons: 4, Progress: 60},
+				{Name: "PostgreSQL для разработчиков", Url: CourseUrl(baseUrl, 2), Lessons: 1, Progress: 15},
+			},
+			Ratings: []DigestRating{
+				{CourseName: "Основы Go", Url: CourseUrl(baseUrl, 1), Position: 3, Change: 2},
+				{CourseName: "PostgreSQL для разработчиков", Url: CourseUrl(baseUrl, 2), Position: 12, Change: -1},
+			},
+			NewCourses: []DigestCourse{{Name: "Конкурентность в Go", Url: CourseUrl(baseUrl, 3)}},
+		}
+	}
+	return data
+}
+
+func ConfirmUrl(baseUrl string, token string) string {
+	return fmt.Sprintf("%s/validate/%s", baseUrl, token)
+}
+
+func CourseUrl(baseUrl string, courseId int) string {
+	return fmt.Sprintf("%s/course/%d", baseUrl, courseId)
+}
+
+// SertificateUrl - страница сертификата пройденного курса
+func SertificateUrl(baseUrl string, courseId int) string {
+	return fmt.Sprintf("%s/course/%d/sertificate", baseUrl, courseId)
+}
+
+// campaignUrl - куда ведёт кнопка письма кампании: к сертификату после окончания курса, иначе к курсу
+func campaignUrl(baseUrl string, name string, courseId int) string {
+	if name == TemplateCourseCompleted {
+		return SertificateUrl(baseUrl, courseId)
+	}
+	return CourseUrl(baseUrl, courseId)
+}
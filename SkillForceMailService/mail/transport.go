@@ -0,0 +1,60 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Транспорты писем
+const (
+	TransportSMTP   = "smtp"
+	TransportFile   = "file"
+	TransportMemory = "memory"
+)
+
+// Режимы TLS для SMTP: STARTTLS после подключения (порт 587), неявный TLS с первого байта (порт 465)
+// или без шифрования - только для локального SMTP сервера
+const (
+	TLSStartTLS = "starttls"
+	TLSImplicit = "tls"
+	TLSNone     = "none"
+)
+
+// Transport - доставка готового письма получателям
+type Transport interface {
+	Send(ctx context.Context, from string, to []string, msg []byte) error
+	Close() error
+}
+
+// TransportConfig - настройки транспорта, используются только поля выбранного Kind
+type TransportConfig struct {
+	Kind string
+
+	Host        string
+	Port        string
+	Username    string
+	Password    string
+	TLS         string
+	PoolSize    int
+	IdleTimeout time.Duration
+
+	// File - mbox файл транспорта file
+	File string
+
+	// CaptureLimit - сколько последних писем хранит транспорт memory
+	CaptureLimit int
+}
+
+// NewTransport - транспорт conf.Kind. file и memory не отправляют письма наружу и нужны для разработки и тестов
+func NewTransport(conf TransportConfig) (Transport, error) {
+	switch conf.Kind {
+	case TransportSMTP, "":
+		return NewSMTPTransport(conf)
+	case TransportFile:
+		return NewFileTransport(conf.File)
+	case TransportMemory:
+		return NewCaptureTransport(conf.CaptureLimit), nil
+	}
+	return nil, fmt.Errorf("unknown mail transport %q", conf.Kind)
+}
@@ -0,0 +1,346 @@
+package mail
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"skillForce/pkg/events"
+	"skillForce/pkg/unsubscribe"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func newTestMail(t *testing.T, transport Transport) *Mail {
+	templates, err := LoadTemplates("templates", "ru")
+	if err != nil {
+		t.Fatalf("LoadTemplates() error = %v", err)
+	}
+	return NewMail("noreply@skill-force.ru", transport, templates, "https://skill-force.ru", unsubscribe.NewSigner("secret"))
+}
+
+// регистрация: письмо подтверждения уходит на адрес пользователя со ссылкой на токен
+func TestSendRegMail_Capture(t *testing.T) {
+	capture := NewCaptureTransport(10)
+	m := newTestMail(t, capture)
+
+	err := m.SendRegMail(context.Background(), &events.ConfirmRegistrationMail{
+		Recipient: &events.Recipient{Email: "alice@example.com", Name: "Alice", Locale: "en"},
+		Token:     "a1b2c3",
+	})
+	if err != nil {
+		t.Fatalf("SendRegMail() error = %v", err)
+	}
+
+	mails := capture.Mails("alice@example.com")
+	if len(mails) != 1 {
+		t.Fatalf("captured %d mails, want 1", len(mails))
+	}
+	captured := mails[0]
+	if captured.From != "noreply@skill-force.ru" || captured.Subject == "" {
+		t.Fatalf("captured from %q subject %q", captured.From, captured.Subject)
+	}
+	// письмо подтверждения транзакционное, ссылки отписки в нём нет
+	if _, ok := captured.Headers["List-Unsubscribe"]; ok {
+		t.Fatal("confirmation mail has List-Unsubscribe header")
+	}
+
+	for _, body := range []string{captured.Text, captured.HTML} {
+		link := regexp.MustCompile(`https://skill-force\.ru/validate/[^\s"<]+`).FindString(body)
+		if link == "" {
+			t.Fatalf("no confirmation link in %q", body)
+		}
+		parsed, err := url.Parse(link)
+		if err != nil || parsed.Path != "/validate/a1b2c3" {
+			t.Fatalf("confirmation link %q, error %v", link, err)
+		}
+	}
+}
+
+func TestCaptureTransport_Limit(t *testing.T) {
+	capture := NewCaptureTransport(2)
+	m := newTestMail(t, capture)
+
+	for _, email := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+		if err := m.SendRegMail(context.Background(), &events.ConfirmRegistrationMail{
+			Recipient: &events.Recipient{Email: email, Name: "User"},
+			Token:     "token",
+		}); err != nil {
+			t.Fatalf("SendRegMail() error = %v", err)
+		}
+	}
+
+	mails := capture.Mails("")
+	if len(mails) != 2 || mails[0].To[0] != "c@example.com" || mails[1].To[0] != "b@example.com" {
+		t.Fatalf("captured %+v", mails)
+	}
+	if capture.Get(mails[0].Id) != mails[0] {
+		t.Fatal("Get() did not return the mail")
+	}
+
+	capture.Clear()
+	if len(capture.Mails("")) != 0 {
+		t.Fatal("Clear() left mails")
+	}
+}
+
+func TestFileTransport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mail.mbox")
+	transport, err := NewFileTransport(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, body := range []string{"first\r\nFrom here\r\n", "second\r\n>From there"} {
+		msg := []byte("Subject: test\r\n\r\n" + body)
+		if err := transport.Send(context.Background(), "noreply@skill-force.ru", []string{"alice@example.com"}, msg); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mbox := string(data)
+	if strings.Count(mbox, "\nFrom noreply@skill-force.ru ") != 1 || !strings.HasPrefix(mbox, "From noreply@skill-force.ru ") {
+		t.Fatalf("expected 2 mbox entries, got %q", mbox)
+	}
+	// строки тела, похожие на начало письма, экранированы
+	if !strings.Contains(mbox, "\n>From here\n") || !strings.Contains(mbox, "\n>>From there\n") {
+		t.Fatalf("From lines are not escaped: %q", mbox)
+	}
+	if strings.Contains(mbox, "\r") {
+		t.Fatal("mbox contains CRLF")
+	}
+}
+
+// fakeSMTP - SMTP сервер без TLS и авторизации, считает соединения и принятые письма
+type fakeSMTP struct {
+	listener net.Listener
+	mu       sync.Mutex
+	conns    int
+	mails    []string
+}
+
+func newFakeSMTP(t *testing.T) *fakeSMTP {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := &fakeSMTP{listener: listener}
+	t.Cleanup(func() { _ = listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			server.mu.Lock()
+			server.conns++
+			server.mu.Unlock()
+			go server.serve(conn)
+		}
+	}()
+	return server
+}
+
+func (s *fakeSMTP) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	write := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+
+	write("220 localhost ESMTP")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(command, "EHLO"):
+			write("250-localhost")
+			write("250 8BITMIME")
+		case strings.HasPrefix(command, "RCPT TO:<BOUNCE@"):
+			write("550 5.1.1 mailbox unavailable")
+		case strings.HasPrefix(command, "DATA"):
+			write("354 go ahead")
+			var data strings.Builder
+			for {
+				line, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				data.WriteString(line)
+			}
+			s.mu.Lock()
+			s.mails = append(s.mails, data.String())
+			s.mu.Unlock()
+			write("250 queued")
+		case strings.HasPrefix(command, "QUIT"):
+			write("221 bye")
+			return
+		default:
+			write("250 ok")
+		}
+	}
+}
+
+func TestSMTPTransport_Pool(t *testing.T) {
+	server := newFakeSMTP(t)
+	host, port, _ := net.SplitHostPort(server.listener.Addr().String())
+	transport, err := NewSMTPTransport(TransportConfig{Host: host, Port: port, TLS: TLSNone, PoolSize: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer transport.Close()
+
+	for i := 0; i < 3; i++ {
+		if err := transport.Send(context.Background(), "noreply@skill-force.ru", []string{"alice@example.com"}, []byte("Subject: test\r\n\r\nhello\r\n")); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+
+	// отказ сервера - ошибка SMTP, соединение после неё не переиспользуется
+	err = transport.Send(context.Background(), "noreply@skill-force.ru", []string{"bounce@example.com"}, []byte("Subject: test\r\n\r\nhello\r\n"))
+	if bounce, ok := HardBounce(err); !ok || bounce.Address != "bounce@example.com" || bounce.Code != 550 {
+		t.Fatalf("Send() to rejected mailbox error = %v", err)
+	}
+	if err := transport.Send(context.Background(), "noreply@skill-force.ru", []string{"alice@example.com"}, []byte("Subject: test\r\n\r\nhello\r\n")); err != nil {
+		t.Fatalf("Send() after error = %v", err)
+	}
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	if len(server.mails) != 4 || server.conns != 2 {
+		t.Fatalf("server received %d mails over %d connections, want 4 over 2", len(server.mails), server.conns)
+	}
+}
+
+func TestNewTransport(t *testing.T) {
+	if _, err := NewTransport(TransportConfig{Kind: "pigeon"}); err == nil {
+		t.Fatal("unknown transport accepted")
+	}
+	if _, err := NewTransport(TransportConfig{Kind: TransportSMTP, TLS: "ssl3"}); err == nil {
+		t.Fatal("unknown tls mode accepted")
+	}
+	if transport, err := NewTransport(TransportConfig{Kind: TransportMemory}); err != nil {
+		t.Fatalf("NewTransport(memory) error = %v", err)
+	} else if _, ok := transport.(*CaptureTransport); !ok {
+		t.Fatalf("NewTransport(memory) = %T", transport)
+	}
+}
+
+func TestHardBounce(t *testing.T) {
+	rcpt := func(code int, msg string) error {
+		return &RecipientError{Address: "alice@example.com", Err: &textproto.Error{Code: code, Msg: msg}}
+	}
+	tests := []struct {
+		name string
+		err  error
+		hard bool
+	}{
+		{"no mailbox", rcpt(550, "5.1.1 user unknown"), true},
+		{"disabled mailbox", rcpt(550, "5.2.1 mailbox disabled"), true},
+		{"bad domain", rcpt(550, "5.1.2 host unknown"), true},
+		{"no enhanced code", rcpt(553, "mailbox name not allowed"), true},
+		{"policy", rcpt(550, "5.7.1 sender blocked"), false},
+		{"mailbox full", rcpt(552, "5.2.2 mailbox full"), false},
+		{"temporary", rcpt(450, "4.2.1 try again later"), false},
+		{"rejected after data", &textproto.Error{Code: 554, Msg: "5.6.0 message rejected"}, false},
+		{"network", &RecipientError{Address: "alice@example.com", Err: errors.New("connection reset")}, false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bounce, hard := HardBounce(tt.err)
+			if hard != tt.hard || (hard && bounce.Address != "alice@example.com") {
+				t.Fatalf("HardBounce() = %+v, %v, want hard %v", bounce, hard, tt.hard)
+			}
+		})
+	}
+}
+
+func TestRateLimitedTransport(t *testing.T) {
+	capture := NewCaptureTransport(10)
+	transport := NewRateLimitedTransport(capture, RateLimits{
+		DomainRate:  1,
+		DomainBurst: 1,
+		Domains:     map[string]float64{"fast.example.com": 1000},
+	})
+	send := func(ctx context.Context, to string) error {
+		return transport.Send(ctx, "noreply@skill-force.ru", []string{to}, []byte("Subject: test\r\nContent-Type: text/plain\r\n\r\nhello\r\n"))
+	}
+
+	// у домена свой лимит, другие домены его не ждут
+	for _, to := range []string{"a@slow.example.com", "b@fast.example.com", "c@fast.example.com", "d@Other.example.com"} {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		err := send(ctx, to)
+		cancel()
+		if err != nil {
+			t.Fatalf("Send(%s) error = %v", to, err)
+		}
+	}
+
+	// второе письмо на slow.example.com ждёт секунду и не укладывается в контекст
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := send(ctx, "e@SLOW.example.com"); err == nil {
+		t.Fatal("Send() over the domain limit succeeded")
+	}
+	if len(capture.Mails("")) != 4 {
+		t.Fatalf("captured %d mails, want 4", len(capture.Mails("")))
+	}
+}
+
+func TestSendWeeklyDigestMail(t *testing.T) {
+	capture := NewCaptureTransport(10)
+	m := newTestMail(t, capture)
+
+	// неделя с понедельника 9:00 по Москве, в UTC она начинается в 6:00
+	err := m.SendWeeklyDigestMail(context.Background(), &events.WeeklyDigestMail{
+		Recipient:        &events.Recipient{Email: "alice@example.com", Name: "Alice", Locale: "en", UserId: 4},
+		PeriodStart:      timestamppb.New(time.Date(2026, 10, 12, 6, 0, 0, 0, time.UTC)),
+		PeriodEnd:        timestamppb.New(time.Date(2026, 10, 19, 6, 0, 0, 0, time.UTC)),
+		LessonsCompleted: 5,
+		Courses:          []*events.DigestCourse{{CourseId: 7, CourseName: "Go", LessonsCompleted: 3, ProgressPercent: 60}},
+		Ratings:          []*events.DigestRating{{CourseId: 7, CourseName: "Go", Position: 2, Change: -1}},
+		Timezone:         "Europe/Moscow",
+	})
+	if err != nil {
+		t.Fatalf("SendWeeklyDigestMail() error = %v", err)
+	}
+
+	mails := capture.Mails("alice@example.com")
+	if len(mails) != 1 {
+		t.Fatalf("captured %d mails, want 1", len(mails))
+	}
+	captured := mails[0]
+	if captured.Subject != "Your learning week: Oct 12 – Oct 18" {
+		t.Fatalf("subject %q", captured.Subject)
+	}
+	if _, ok := captured.Headers["List-Unsubscribe"]; !ok {
+		t.Fatal("digest has no List-Unsubscribe header")
+	}
+	for _, want := range []string{"Lessons completed: 5", "- Go: 60% completed, 3 this week (https://skill-force.ru/course/7)", "- Go: #2 (-1)"} {
+		if !strings.Contains(captured.Text, want) {
+			t.Fatalf("text has no %q:\n%s", want, captured.Text)
+		}
+	}
+	if strings.Contains(captured.Text, "New courses") {
+		t.Fatalf("empty section in text:\n%s", captured.Text)
+	}
+}
@@ -19,8 +19,48 @@ var (
 		},
 		[]string{"method", "status"},
 	)
+
+	MailRetriesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "mail_retries_total",
+			Help: "Количество писем, отправленных на повторную попытку",
+		},
+		[]string{"method"},
+	)
+
+	MailDeadLetteredTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "mail_dead_lettered_total",
+			Help: "Количество писем, перемещённых в mail.dlq",
+		},
+		[]string{"method"},
+	)
+
+	MailBouncesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "mail_bounces_total",
+			Help: "Количество жёстких отказов: адрес получателя добавлен в список подавления",
+		},
+		[]string{"method"},
+	)
+
+	MailRateLimitWait = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "mail_rate_limit_wait_seconds",
+			Help:    "Ожидание ограничения скорости отправки",
+			Buckets: []float64{0.01, 0.05, 0.1, 0.5, 1, 2, 5, 10, 30},
+		},
+		[]string{"limit"},
+	)
+
+	MailDLQSize = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "mail_dlq_size",
+			Help: "Количество неразобранных писем в mail.dlq",
+		},
+	)
 )
 
 func Init() {
-	prometheus.MustRegister(MailRequestsTotal, MailRequestDuration)
+	prometheus.MustRegister(MailRequestsTotal, MailRequestDuration, MailRetriesTotal, MailDeadLetteredTotal, MailBouncesTotal, MailRateLimitWait, MailDLQSize)
 }
@@ -0,0 +1,222 @@
+package events
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Типы событий
+const (
+	TypeConfirmRegistrationMail = "mail.confirm_registration"
+	TypeWelcomeCourseMail       = "mail.welcome_course"
+	TypeDataExportMail          = "mail.data_export"
+	TypeEngagementMail          = "mail.engagement"
+	TypeWeeklyDigestMail        = "mail.weekly_digest"
+)
+
+var (
+	ErrInvalidEvent       = errors.New("invalid event")
+	ErrUnknownType        = errors.New("unknown event type")
+	ErrUnsupportedVersion = errors.New("unsupported event version")
+)
+
+// Contract - тип события, его текущая версия и категория письма для настроек уведомлений.
+// Консьюмер принимает версии с 1 по Version: событие новее консьюмера не обрабатывается,
+// пока консьюмер не обновят
+type Contract struct {
+	Type     string
+	Version  uint32
+	Category string
+}
+
+// contracts - вариант Envelope.payload -> контракт. Новое событие: сообщение и вариант oneof
+// в events.proto, строка здесь и при необходимости метод Validate у сообщения
+var contracts = map[protoreflect.FullName]Contract{
+	"events.ConfirmRegistrationMail": {Type: TypeConfirmRegistrationMail, Version: 1, Category: CategoryTransactional},
+	"events.WelcomeCourseMail":       {Type: TypeWelcomeCourseMail, Version: 1, Category: CategoryCourseProgress},
+	"events.DataExportMail":          {Type: TypeDataExportMail, Version: 1, Category: CategoryTransactional},
+	"events.EngagementMail":          {Type: TypeEngagementMail, Version: 1, Category: CategoryCourseProgress},
+	"events.WeeklyDigestMail":        {Type: TypeWeeklyDigestMail, Version: 1, Category: CategoryDigest},
+}
+
+// Payload - содержимое события, одно из сообщений oneof payload
+type Payload interface {
+	proto.Message
+}
+
+// validator - проверка содержимого события. Реализована у сообщений с обязательными полями
+type validator interface {
+	Validate() error
+}
+
+func payloadOneof() protoreflect.OneofDescriptor {
+	return (&Envelope{}).ProtoReflect().Descriptor().Oneofs().ByName("payload")
+}
+
+// payloadField - поле oneof payload для сообщения этого типа
+func payloadField(name protoreflect.FullName) protoreflect.FieldDescriptor {
+	fields := payloadOneof().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		if fields.Get(i).Message().FullName() == name {
+			return fields.Get(i)
+		}
+	}
+	return nil
+}
+
+// New - событие из payload с новым id и текущим временем. correlation_id берётся из контекста,
+// если его там нет, совпадает с id события
+func New(ctx context.Context, source string, payload Payload) (*Envelope, error) {
+	name := payload.ProtoReflect().Descriptor().FullName()
+	contract, ok := contracts[name]
+	field := payloadField(name)
+	if !ok || field == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownType, name)
+	}
+
+	id, err := newId()
+	if err != nil {
+		return nil, err
+	}
+	env := &Envelope{
+		Id:            id,
+		Type:          contract.Type,
+		Version:       contract.Version,
+		OccurredAt:    timestamppb.Now(),
+		CorrelationId: CorrelationId(ctx),
+		Source:        source,
+	}
+	if env.CorrelationId == "" {
+		env.CorrelationId = id
+	}
+	env.ProtoReflect().Set(field, protoreflect.ValueOfMessage(payload.ProtoReflect()))
+
+	if err := Validate(env); err != nil {
+		return nil, err
+	}
+	return env, nil
+}
+
+// GetPayload - содержимое события или nil, если его нет
+func GetPayload(env *Envelope) Payload {
+	field := env.ProtoReflect().WhichOneof(payloadOneof())
+	if field == nil {
+		return nil
+	}
+	return env.ProtoReflect().Get(field).Message().Interface()
+}
+
+// Validate - проверка обёртки, соответствия type содержимому, версии и самого содержимого
+func Validate(env *Envelope) error {
+	if env == nil {
+		return fmt.Errorf("%w: empty envelope", ErrInvalidEvent)
+	}
+	if !validId(env.GetId()) {
+		return fmt.Errorf("%w: id %q is not uuid", ErrInvalidEvent, env.GetId())
+	}
+	if env.GetSource() == "" {
+		return fmt.Errorf("%w: source is empty", ErrInvalidEvent)
+	}
+	if env.GetOccurredAt() == nil || env.GetOccurredAt().CheckValid() != nil {
+		return fmt.Errorf("%w: occurred_at is not set", ErrInvalidEvent)
+	}
+
+	payload := GetPayload(env)
+	if payload == nil {
+		return fmt.Errorf("%w: %s without payload", ErrInvalidEvent, env.GetType())
+	}
+	contract, ok := contracts[payload.ProtoReflect().Descriptor().FullName()]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownType, payload.ProtoReflect().Descriptor().FullName())
+	}
+	if contract.Type != env.GetType() {
+		return fmt.Errorf("%w: type %s does not match payload %s", ErrInvalidEvent, env.GetType(), contract.Type)
+	}
+	if env.GetVersion() < 1 || env.GetVersion() > contract.Version {
+		return fmt.Errorf("%w: %s v%d, supported up to v%d", ErrUnsupportedVersion, env.GetType(), env.GetVersion(), contract.Version)
+	}
+
+	if v, ok := payload.(validator); ok {
+		if err := v.Validate(); err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrInvalidEvent, env.GetType(), err)
+		}
+	}
+	return nil
+}
+
+// Marshal - проверенное событие в JSON. В JSON имена полей как в events.proto
+func Marshal(env *Envelope) ([]byte, error) {
+	if err := Validate(env); err != nil {
+		return nil, err
+	}
+	return protojson.MarshalOptions{UseProtoNames: true}.Marshal(env)
+}
+
+// Unmarshal - событие из JSON. Неизвестные поля пропускаются: продюсер может быть новее консьюмера
+func Unmarshal(data []byte) (*Envelope, error) {
+	env := &Envelope{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, env); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEvent, err)
+	}
+	if err := Validate(env); err != nil {
+		return nil, err
+	}
+	return env, nil
+}
+
+type correlationKey struct{}
+
+// WithCorrelationId - id запроса, который получат события, созданные с этим контекстом
+func WithCorrelationId(ctx context.Context, correlationId string) context.Context {
+	return context.WithValue(ctx, correlationKey{}, correlationId)
+}
+
+func CorrelationId(ctx context.Context) string {
+	correlationId, _ := ctx.Value(correlationKey{}).(string)
+	return correlationId
+}
+
+// NewCorrelationId - id для запроса, который пришёл без него
+func NewCorrelationId() string {
+	id, err := newId()
+	if err != nil {
+		return ""
+	}
+	return id
+}
+
+// newId - случайный uuid версии 4
+func newId() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
+func validId(id string) bool {
+	if len(id) != 36 {
+		return false
+	}
+	for i, c := range id {
+		switch {
+		case i == 8 || i == 13 || i == 18 || i == 23:
+			if c != '-' {
+				return false
+			}
+		case '0' <= c && c <= '9', 'a' <= c && c <= 'f', 'A' <= c && c <= 'F':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,4 @@
+// Копия events/ из корня репозитория, правки вносятся там
+//go:generate sh ../../../events/sync.sh
+
+package events
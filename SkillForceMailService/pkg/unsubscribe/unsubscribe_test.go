@@ -0,0 +1,54 @@
+package unsubscribe
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	signer := NewSigner("secret")
+	token := signer.Token(42, "marketing")
+
+	userId, category, err := signer.Parse(token)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if userId != 42 || category != "marketing" {
+		t.Fatalf("Parse() = %d, %q", userId, category)
+	}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "other user", token: "43" + token[2:]},
+		{name: "other category", token: "42.course_progress" + token[len("42.marketing"):]},
+		{name: "other secret", token: NewSigner("other").Token(42, "marketing")},
+		{name: "not hex", token: "42.marketing.zz"},
+		{name: "no category", token: "42..abc"},
+		{name: "zero user", token: signer.Token(0, "marketing")},
+		{name: "empty", token: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := signer.Parse(tt.token); !errors.Is(err, ErrInvalidToken) {
+				t.Fatalf("Parse() error = %v, want ErrInvalidToken", err)
+			}
+		})
+	}
+}
+
+func TestURL(t *testing.T) {
+	signer := NewSigner("secret")
+	link, err := url.Parse(signer.URL("https://skill-force.ru/", 7, "course_progress"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if link.Path != "/api/unsubscribe" {
+		t.Fatalf("path %q", link.Path)
+	}
+	if userId, category, err := signer.Parse(link.Query().Get("token")); err != nil || userId != 7 || category != "course_progress" {
+		t.Fatalf("Parse() = %d, %q, %v", userId, category, err)
+	}
+}
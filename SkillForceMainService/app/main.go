@@ -1,17 +1,30 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"net/http"
 	"skillForce/config"
+	"skillForce/internal/delivery/http/localstorage"
 	"skillForce/internal/delivery/http/middleware"
+	"skillForce/pkg/auth"
 	"skillForce/pkg/logs"
+	"skillForce/pkg/mediasign"
+	"skillForce/pkg/notifyhub"
+	"skillForce/pkg/storage"
+	"skillForce/pkg/unsubscribe"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
 
 	cookie "skillForce/internal/delivery/http/cookie"
+	accountHandler "skillForce/internal/delivery/http/handlers/account"
 	billingHandler "skillForce/internal/delivery/http/handlers/billing"
 	courseHandler "skillForce/internal/delivery/http/handlers/course"
+	notificationHandler "skillForce/internal/delivery/http/handlers/notification"
+	profileHandler "skillForce/internal/delivery/http/handlers/profile"
 	userHandler "skillForce/internal/delivery/http/handlers/user"
 
 	courseUsecase "skillForce/internal/usecase/course"
@@ -35,13 +48,42 @@ func main() {
 	userUsecase := userUsecase.NewUserUsecase(userIfrustructure)
 	cookieManager := cookie.NewCookieManager(userUsecase)
 
+	tlsFiles := auth.TLSFiles{
+		CaFile:   config.Tls.CaFile,
+		CertFile: config.Tls.CertFile,
+		KeyFile:  config.Tls.KeyFile,
+	}
+	signer := auth.NewTokenSigner(config.Secrets.ServiceTokenSecret, auth.ServiceMain)
+	dialOptions := func(service string) []grpc.DialOption {
+		options, err := auth.DialOptions(tlsFiles, signer, service)
+		if err != nil {
+			log.Fatalf("failed to load tls credentials for %s: %v", service, err)
+		}
+		return options
+	}
+
 	courseInfrastructure := courseInfrastructure.NewCourseInfrastructure(config)
 	defer courseInfrastructure.Close()
 	courseUsecase := courseUsecase.NewCourseUsecase(courseInfrastructure)
-	courseHandler := courseHandler.NewHandler(cookieManager, courseUsecase)
-	billingHandler := billingHandler.NewHandler(cookieManager)
-
-	userHandler := userHandler.NewHandler(cookieManager)
+	go courseUsecase.RunTranscoder(context.Background(), time.Minute)
+	go courseUsecase.RunBundleBuilder(context.Background(), time.Minute)
+	go courseUsecase.RunStorageGC(context.Background(), config.StorageGC.Interval, config.StorageGC.GracePeriod, config.StorageGC.DryRun)
+	go courseUsecase.RunVideoUploadCleaner(context.Background(), config.VideoUploads.CleanupInterval, config.VideoUploads.StaleAfter)
+	mediaSigner, err := mediasign.NewSigner(config.Secrets.MediaUrlSecret, mediasign.LinkTTL)
+	if err != nil {
+		log.Fatalf("failed to create media signer: %v", err)
+	}
+	courseHandler := courseHandler.NewHandler(cookieManager, courseUsecase, mediaSigner, dialOptions(auth.ServiceCourse)...)
+	billingHandler := billingHandler.NewHandler(cookieManager, dialOptions(auth.ServiceBilling)...)
+
+	userHandler := userHandler.NewHandler(cookieManager, dialOptions(auth.ServiceUser)...)
+	profileHandler := profileHandler.NewHandler(mediaSigner, dialOptions(auth.ServiceUser), dialOptions(auth.ServiceCourse))
+	accountHandler := accountHandler.NewHandler(dialOptions(auth.ServiceUser), dialOptions(auth.ServiceCourse), dialOptions(auth.ServiceBilling))
+	// user-service сообщает о новых уведомлениях через LISTEN/NOTIFY, hub будит открытые потоки
+	notificationHub := notifyhub.New()
+	go notificationHub.Listen(context.Background(), fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+		config.Database.Host, config.Database.Port, config.Database.User, config.Database.Password, config.Database.Name))
+	notificationHandler := notificationHandler.NewHandler(unsubscribe.NewSigner(config.Secrets.UnsubscribeSecret), notificationHub, dialOptions(auth.ServiceUser)...)
 
 	siteMux.HandleFunc("/api/register", userHandler.RegisterUser)
 	siteMux.HandleFunc("/api/login", userHandler.LoginUser)
@@ -51,6 +93,22 @@ func main() {
 	siteMux.Handle("/api/updateProfilePhoto", middleware.CSRFMiddleware(http.HandlerFunc(userHandler.UpdateProfilePhoto)))
 	siteMux.Handle("/api/deleteProfilePhoto", middleware.CSRFMiddleware(http.HandlerFunc(userHandler.DeleteProfilePhoto)))
 	siteMux.HandleFunc("/api/validEmail", userHandler.ConfirmUserEmail)
+	siteMux.HandleFunc("/api/resendConfirmation", userHandler.ResendConfirmation)
+	siteMux.HandleFunc("/api/getUserProfile", profileHandler.GetUserProfile)
+	siteMux.Handle("/api/requestDataExport", middleware.RequirePermission(auth.PermLearn, middleware.CSRFMiddleware(http.HandlerFunc(accountHandler.RequestDataExport))))
+	siteMux.Handle("/api/deleteAccount", middleware.RequirePermission(auth.PermLearn, middleware.CSRFMiddleware(http.HandlerFunc(accountHandler.DeleteAccount))))
+	siteMux.Handle("/api/cancelAccountDeletion", middleware.RequirePermission(auth.PermLearn, middleware.CSRFMiddleware(http.HandlerFunc(accountHandler.CancelAccountDeletion))))
+	siteMux.Handle("/api/notificationPreferences", middleware.RequirePermission(auth.PermLearn, middleware.CSRFMiddleware(http.HandlerFunc(notificationHandler.NotificationPreferences))))
+	// ссылка отписки из письма: почтовый клиент отправляет POST без cookie и CSRF токена, пользователь и категория в подписанном токене
+	siteMux.HandleFunc("/api/unsubscribe", notificationHandler.Unsubscribe)
+	siteMux.Handle("/api/getNotifications", middleware.RequirePermission(auth.PermLearn, http.HandlerFunc(notificationHandler.GetNotifications)))
+	siteMux.Handle("/api/getUnreadNotificationCount", middleware.RequirePermission(auth.PermLearn, http.HandlerFunc(notificationHandler.GetUnreadNotificationCount)))
+	siteMux.Handle("/api/markNotificationsRead", middleware.RequirePermission(auth.PermLearn, middleware.CSRFMiddleware(http.HandlerFunc(notificationHandler.MarkNotificationsRead))))
+	siteMux.Handle("/api/notifications/stream", middleware.RequirePermission(auth.PermLearn, http.HandlerFunc(notificationHandler.NotificationStream)))
+
+	siteMux.Handle("/api/admin/getUserRoles", middleware.RequirePermission(auth.PermManageRoles, http.HandlerFunc(userHandler.GetUserRoles)))
+	siteMux.Handle("/api/admin/grantRole", middleware.RequirePermission(auth.PermManageRoles, middleware.CSRFMiddleware(http.HandlerFunc(userHandler.GrantRole))))
+	siteMux.Handle("/api/admin/revokeRole", middleware.RequirePermission(auth.PermManageRoles, middleware.CSRFMiddleware(http.HandlerFunc(userHandler.RevokeRole))))
 
 	siteMux.HandleFunc("/api/getCourses", courseHandler.GetCourses)
 	siteMux.HandleFunc("/api/getPurchasedCourses", courseHandler.GetPurchasedCourses)
@@ -67,7 +125,21 @@ func main() {
 	siteMux.HandleFunc("/api/getCourseRoadmap", courseHandler.GetCourseRoadmap)
 	siteMux.HandleFunc("/api/getRating", courseHandler.GetRating)
 	siteMux.HandleFunc("/api/video", courseHandler.ServeVideo)
-	siteMux.HandleFunc("/api/createCourse", courseHandler.CreateCourse)
+	siteMux.HandleFunc("/api/video/hls/", courseHandler.ServeHLS)
+	siteMux.Handle("/api/getVideoLink", middleware.RequirePermission(auth.PermLearn, http.HandlerFunc(courseHandler.GetVideoLink)))
+	siteMux.HandleFunc("/api/media/sertificates/", courseHandler.ServeSertificate)
+	siteMux.HandleFunc("/api/media/lesson-files/", courseHandler.ServeLessonFile)
+	siteMux.HandleFunc("/api/media/bundles/", courseHandler.ServeCourseBundle)
+	siteMux.Handle("/api/getCourseBundle", middleware.RequirePermission(auth.PermLearn, http.HandlerFunc(courseHandler.GetCourseBundle)))
+	siteMux.Handle("/api/markLessonsCompleted", middleware.RequirePermission(auth.PermLearn, middleware.CSRFMiddleware(http.HandlerFunc(courseHandler.MarkLessonsCompleted))))
+	siteMux.Handle("/api/createCourse", middleware.RequirePermission(auth.PermCreateCourse, http.HandlerFunc(courseHandler.CreateCourse)))
+	siteMux.Handle("/api/initVideoUpload", middleware.RequirePermission(auth.PermCreateCourse, middleware.CSRFMiddleware(http.HandlerFunc(courseHandler.InitVideoUpload))))
+	siteMux.Handle("/api/uploadVideoChunk", middleware.RequirePermission(auth.PermCreateCourse, middleware.CSRFMiddleware(http.HandlerFunc(courseHandler.UploadVideoChunk))))
+	siteMux.Handle("/api/previewLessonBlocks", middleware.RequirePermission(auth.PermCreateCourse, middleware.CSRFMiddleware(http.HandlerFunc(courseHandler.PreviewLessonBlocks))))
+	siteMux.Handle("/api/uploadLessonFile", middleware.RequirePermission(auth.PermCreateCourse, middleware.CSRFMiddleware(http.HandlerFunc(courseHandler.UploadLessonFile))))
+	siteMux.Handle("/api/getVideoUploadStatus", middleware.RequirePermission(auth.PermCreateCourse, http.HandlerFunc(courseHandler.GetVideoUploadStatus)))
+	siteMux.Handle("/api/completeVideoUpload", middleware.RequirePermission(auth.PermCreateCourse, middleware.CSRFMiddleware(http.HandlerFunc(courseHandler.CompleteVideoUpload))))
+	siteMux.Handle("/api/abortVideoUpload", middleware.RequirePermission(auth.PermCreateCourse, middleware.CSRFMiddleware(http.HandlerFunc(courseHandler.AbortVideoUpload))))
 	siteMux.HandleFunc("/api/addCourseToFavourites", courseHandler.AddCourseToFavourites)
 	siteMux.HandleFunc("/api/deleteCourseFromFavourites", courseHandler.DeleteCourseFromFavourites)
 	siteMux.HandleFunc("/api/getFavouriteCourses", courseHandler.GetFavouriteCourses)
@@ -75,16 +147,24 @@ func main() {
 	siteMux.HandleFunc("/api/AnswerQuiz", courseHandler.AnswerQuiz)
 	siteMux.HandleFunc("/api/GetQuestionTestLesson", courseHandler.GetQuestionTestLesson)
 	siteMux.HandleFunc("/api/AnswerQuestion", courseHandler.AnswerQuestion)
+	siteMux.Handle("/api/answerBlockQuiz", middleware.RequirePermission(auth.PermLearn, middleware.CSRFMiddleware(http.HandlerFunc(courseHandler.AnswerBlockQuiz))))
 	siteMux.HandleFunc("/api/getStatistic", courseHandler.GetStatistic)
+	siteMux.Handle("/api/moderation/setCourseHidden", middleware.RequirePermission(auth.PermModerate, middleware.CSRFMiddleware(http.HandlerFunc(courseHandler.SetCourseHidden))))
 
-	siteMux.HandleFunc("/api/createPaymentHandler", billingHandler.CreatePaymentHandler)
+	siteMux.Handle("/api/createPaymentHandler", middleware.RequirePermission(auth.PermPurchase, http.HandlerFunc(billingHandler.CreatePaymentHandler)))
 	siteMux.HandleFunc("/api/webhookHandler", billingHandler.WebhookHandler)
 
 	siteMux.HandleFunc("/api/docs/", httpSwagger.WrapHandler)
 
+	// без MinIO открытые бакеты из общего с user- и course-service local_dir отдаёт main-service
+	if config.Storage.Backend == storage.BackendLocal {
+		siteMux.Handle("/storage/", localstorage.Handler(courseInfrastructure.Minio.Storage, "/storage/", config.Minio.BucketName, config.Minio.ExportBucket))
+	}
+
 	siteMux.Handle("/metrics", promhttp.Handler())
 
-	siteHandler := logs.LoggerMiddleware(siteMux)
+	siteHandler := middleware.AuthMiddleware(cookieManager, siteMux)
+	siteHandler = logs.LoggerMiddleware(siteHandler)
 	siteHandler = middleware.MetricsMiddleware(siteHandler)
 	siteHandler = middleware.PanicMiddleware(siteHandler)
 	siteHandler = middleware.CorsOptionsMiddleware(siteHandler)
@@ -3,6 +3,7 @@ package config
 import (
 	"log"
 	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 	"gopkg.in/yaml.v2"
@@ -18,16 +19,46 @@ type Config struct {
 	}
 
 	Minio struct {
-		Endpoint        string
-		AccessKey       string
-		SecretAccessKey string
-		BucketName      string
-		VideoBucket     string
-		UseSSL          bool
+		Endpoint           string
+		AccessKey          string
+		SecretAccessKey    string
+		BucketName         string
+		VideoBucket        string
+		SertificatesBucket string
+		LessonFilesBucket  string
+		BundlesBucket      string
+		ExportBucket       string
+		UseSSL             bool
+	}
+
+	Storage struct {
+		Backend   string
+		LocalDir  string
+		PublicURL string
+	}
+
+	StorageGC struct {
+		Interval    time.Duration
+		GracePeriod time.Duration
+		DryRun      bool
+	}
+
+	VideoUploads struct {
+		CleanupInterval time.Duration
+		StaleAfter      time.Duration
 	}
 
 	Secrets struct {
-		JwtSessionSecret string
+		JwtSessionSecret   string
+		ServiceTokenSecret string
+		MediaUrlSecret     string
+		UnsubscribeSecret  string
+	}
+
+	Tls struct {
+		CaFile   string
+		CertFile string
+		KeyFile  string
 	}
 
 	Mail struct {
@@ -46,11 +77,38 @@ type yamlConfig struct {
 	} `yaml:"database"`
 
 	Minio struct {
-		Endpoint    string `yaml:"endpoint"`
-		BucketName  string `yaml:"bucket_name"`
-		VideoBucket string `yaml:"video_bucket_name"`
-		UseSSL      bool   `yaml:"use_ssl"`
+		Endpoint           string `yaml:"endpoint"`
+		BucketName         string `yaml:"bucket_name"`
+		VideoBucket        string `yaml:"video_bucket_name"`
+		SertificatesBucket string `yaml:"sertificates_bucket_name"`
+		LessonFilesBucket  string `yaml:"lesson_files_bucket_name"`
+		BundlesBucket      string `yaml:"bundles_bucket_name"`
+		ExportBucket       string `yaml:"export_bucket_name"`
+		UseSSL             bool   `yaml:"use_ssl"`
 	} `yaml:"minio"`
+
+	Storage struct {
+		Backend   string `yaml:"backend"`
+		LocalDir  string `yaml:"local_dir"`
+		PublicURL string `yaml:"public_url"`
+	} `yaml:"storage"`
+
+	StorageGC struct {
+		Interval    time.Duration `yaml:"interval"`
+		GracePeriod time.Duration `yaml:"grace_period"`
+		DryRun      bool          `yaml:"dry_run"`
+	} `yaml:"storage_gc"`
+
+	VideoUploads struct {
+		CleanupInterval time.Duration `yaml:"cleanup_interval"`
+		StaleAfter      time.Duration `yaml:"stale_after"`
+	} `yaml:"video_uploads"`
+
+	Tls struct {
+		CaFile   string `yaml:"ca_file"`
+		CertFile string `yaml:"cert_file"`
+		KeyFile  string `yaml:"key_file"`
+	} `yaml:"tls"`
 }
 
 func LoadConfig() *Config {
@@ -85,22 +143,72 @@ func LoadConfig() *Config {
 			Name:     ycfg.Database.Name,
 		},
 		Minio: struct {
-			Endpoint        string
-			AccessKey       string
-			SecretAccessKey string
-			BucketName      string
-			VideoBucket     string
-			UseSSL          bool
+			Endpoint           string
+			AccessKey          string
+			SecretAccessKey    string
+			BucketName         string
+			VideoBucket        string
+			SertificatesBucket string
+			LessonFilesBucket  string
+			BundlesBucket      string
+			ExportBucket       string
+			UseSSL             bool
 		}{
-			Endpoint:        ycfg.Minio.Endpoint,
-			AccessKey:       os.Getenv("MINIO_ACCESS_KEY"),
-			SecretAccessKey: os.Getenv("MINIO_SECRET_KEY"),
-			BucketName:      ycfg.Minio.BucketName,
-			VideoBucket:     ycfg.Minio.VideoBucket,
-			UseSSL:          ycfg.Minio.UseSSL,
+			Endpoint:           ycfg.Minio.Endpoint,
+			AccessKey:          os.Getenv("MINIO_ACCESS_KEY"),
+			SecretAccessKey:    os.Getenv("MINIO_SECRET_KEY"),
+			BucketName:         ycfg.Minio.BucketName,
+			VideoBucket:        ycfg.Minio.VideoBucket,
+			SertificatesBucket: ycfg.Minio.SertificatesBucket,
+			LessonFilesBucket:  ycfg.Minio.LessonFilesBucket,
+			BundlesBucket:      ycfg.Minio.BundlesBucket,
+			ExportBucket:       ycfg.Minio.ExportBucket,
+			UseSSL:             ycfg.Minio.UseSSL,
 		},
-		Secrets: struct{ JwtSessionSecret string }{
-			JwtSessionSecret: os.Getenv("JWT_SESSION_SECRET"),
+		Storage: struct {
+			Backend   string
+			LocalDir  string
+			PublicURL string
+		}{
+			Backend:   ycfg.Storage.Backend,
+			LocalDir:  ycfg.Storage.LocalDir,
+			PublicURL: ycfg.Storage.PublicURL,
+		},
+		StorageGC: struct {
+			Interval    time.Duration
+			GracePeriod time.Duration
+			DryRun      bool
+		}{
+			Interval:    ycfg.StorageGC.Interval,
+			GracePeriod: ycfg.StorageGC.GracePeriod,
+			DryRun:      ycfg.StorageGC.DryRun,
+		},
+		VideoUploads: struct {
+			CleanupInterval time.Duration
+			StaleAfter      time.Duration
+		}{
+			CleanupInterval: ycfg.VideoUploads.CleanupInterval,
+			StaleAfter:      ycfg.VideoUploads.StaleAfter,
+		},
+		Secrets: struct {
+			JwtSessionSecret   string
+			ServiceTokenSecret string
+			MediaUrlSecret     string
+			UnsubscribeSecret  string
+		}{
+			JwtSessionSecret:   os.Getenv("JWT_SESSION_SECRET"),
+			ServiceTokenSecret: requireEnv("SERVICE_TOKEN_SECRET"),
+			MediaUrlSecret:     requireEnv("MEDIA_URL_SECRET"),
+			UnsubscribeSecret:  requireEnv("UNSUBSCRIBE_SECRET"),
+		},
+		Tls: struct {
+			CaFile   string
+			CertFile string
+			KeyFile  string
+		}{
+			CaFile:   ycfg.Tls.CaFile,
+			CertFile: ycfg.Tls.CertFile,
+			KeyFile:  ycfg.Tls.KeyFile,
 		},
 		Mail: struct {
 			From     string
@@ -115,3 +223,13 @@ func LoadConfig() *Config {
 		},
 	}
 }
+
+// requireEnv - секрет из .env. Пустой секрет не допускается: HMAC подпись с пустым ключом
+// может подделать кто угодно
+func requireEnv(name string) string {
+	value := os.Getenv(name)
+	if value == "" {
+		log.Fatalf("не задана переменная окружения %s", name)
+	}
+	return value
+}
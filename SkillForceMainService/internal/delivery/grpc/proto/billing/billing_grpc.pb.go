@@ -21,6 +21,7 @@ const _ = grpc.SupportPackageIsVersion7
 type BillingServiceClient interface {
 	CreatePayment(ctx context.Context, in *CreatePaymentRequest, opts ...grpc.CallOption) (*CreatePaymentResponse, error)
 	HandleWebhook(ctx context.Context, in *YooKassaWebhook, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (*ExportUserDataResponse, error)
 }
 
 type billingServiceClient struct {
@@ -49,12 +50,22 @@ func (c *billingServiceClient) HandleWebhook(ctx context.Context, in *YooKassaWe
 	return out, nil
 }
 
+func (c *billingServiceClient) ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (*ExportUserDataResponse, error) {
+	out := new(ExportUserDataResponse)
+	err := c.cc.Invoke(ctx, "/billing.BillingService/ExportUserData", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BillingServiceServer is the server API for BillingService service.
 // All implementations must embed UnimplementedBillingServiceServer
 // for forward compatibility
 type BillingServiceServer interface {
 	CreatePayment(context.Context, *CreatePaymentRequest) (*CreatePaymentResponse, error)
 	HandleWebhook(context.Context, *YooKassaWebhook) (*emptypb.Empty, error)
+	ExportUserData(context.Context, *ExportUserDataRequest) (*ExportUserDataResponse, error)
 	mustEmbedUnimplementedBillingServiceServer()
 }
 
@@ -68,6 +79,9 @@ func (UnimplementedBillingServiceServer) CreatePayment(context.Context, *CreateP
 func (UnimplementedBillingServiceServer) HandleWebhook(context.Context, *YooKassaWebhook) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method HandleWebhook not implemented")
 }
+func (UnimplementedBillingServiceServer) ExportUserData(context.Context, *ExportUserDataRequest) (*ExportUserDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportUserData not implemented")
+}
 func (UnimplementedBillingServiceServer) mustEmbedUnimplementedBillingServiceServer() {}
 
 // UnsafeBillingServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -117,6 +131,24 @@ func _BillingService_HandleWebhook_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BillingService_ExportUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportUserDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BillingServiceServer).ExportUserData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/billing.BillingService/ExportUserData",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BillingServiceServer).ExportUserData(ctx, req.(*ExportUserDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BillingService_ServiceDesc is the grpc.ServiceDesc for BillingService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -132,6 +164,10 @@ var BillingService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "HandleWebhook",
 			Handler:    _BillingService_HandleWebhook_Handler,
 		},
+		{
+			MethodName: "ExportUserData",
+			Handler:    _BillingService_ExportUserData_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "billing.proto",
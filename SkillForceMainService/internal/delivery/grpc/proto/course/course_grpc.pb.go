@@ -42,6 +42,14 @@ type CourseServiceClient interface {
 	GetQuestionTestLesson(ctx context.Context, in *GetQuestionTestLessonRequest, opts ...grpc.CallOption) (*GetQuestionTestLessonResponse, error)
 	AnswerQuestion(ctx context.Context, in *AnswerQuestionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	SearchCoursesByTitle(ctx context.Context, in *SearchCoursesByTitleRequest, opts ...grpc.CallOption) (*GetBucketCoursesResponse, error)
+	GetUserCoursesSummary(ctx context.Context, in *GetUserCoursesSummaryRequest, opts ...grpc.CallOption) (*GetUserCoursesSummaryResponse, error)
+	ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (*ExportUserDataResponse, error)
+	CanViewLesson(ctx context.Context, in *CanViewLessonRequest, opts ...grpc.CallOption) (*CanViewLessonResponse, error)
+	AnswerBlockQuiz(ctx context.Context, in *AnswerBlockQuizRequest, opts ...grpc.CallOption) (*AnswerBlockQuizResponse, error)
+	PreviewLessonBlocks(ctx context.Context, in *PreviewLessonBlocksRequest, opts ...grpc.CallOption) (*PreviewLessonBlocksResponse, error)
+	GetCourseBundle(ctx context.Context, in *GetCourseBundleRequest, opts ...grpc.CallOption) (*GetCourseBundleResponse, error)
+	MarkLessonsCompleted(ctx context.Context, in *MarkLessonsCompletedRequest, opts ...grpc.CallOption) (*MarkLessonsCompletedResponse, error)
+	SetCourseHidden(ctx context.Context, in *SetCourseHiddenRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type courseServiceClient struct {
@@ -259,6 +267,78 @@ func (c *courseServiceClient) SearchCoursesByTitle(ctx context.Context, in *Sear
 	return out, nil
 }
 
+func (c *courseServiceClient) GetUserCoursesSummary(ctx context.Context, in *GetUserCoursesSummaryRequest, opts ...grpc.CallOption) (*GetUserCoursesSummaryResponse, error) {
+	out := new(GetUserCoursesSummaryResponse)
+	err := c.cc.Invoke(ctx, "/course.CourseService/GetUserCoursesSummary", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *courseServiceClient) ExportUserData(ctx context.Context, in *ExportUserDataRequest, opts ...grpc.CallOption) (*ExportUserDataResponse, error) {
+	out := new(ExportUserDataResponse)
+	err := c.cc.Invoke(ctx, "/course.CourseService/ExportUserData", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *courseServiceClient) CanViewLesson(ctx context.Context, in *CanViewLessonRequest, opts ...grpc.CallOption) (*CanViewLessonResponse, error) {
+	out := new(CanViewLessonResponse)
+	err := c.cc.Invoke(ctx, "/course.CourseService/CanViewLesson", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *courseServiceClient) AnswerBlockQuiz(ctx context.Context, in *AnswerBlockQuizRequest, opts ...grpc.CallOption) (*AnswerBlockQuizResponse, error) {
+	out := new(AnswerBlockQuizResponse)
+	err := c.cc.Invoke(ctx, "/course.CourseService/AnswerBlockQuiz", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *courseServiceClient) PreviewLessonBlocks(ctx context.Context, in *PreviewLessonBlocksRequest, opts ...grpc.CallOption) (*PreviewLessonBlocksResponse, error) {
+	out := new(PreviewLessonBlocksResponse)
+	err := c.cc.Invoke(ctx, "/course.CourseService/PreviewLessonBlocks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *courseServiceClient) GetCourseBundle(ctx context.Context, in *GetCourseBundleRequest, opts ...grpc.CallOption) (*GetCourseBundleResponse, error) {
+	out := new(GetCourseBundleResponse)
+	err := c.cc.Invoke(ctx, "/course.CourseService/GetCourseBundle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *courseServiceClient) MarkLessonsCompleted(ctx context.Context, in *MarkLessonsCompletedRequest, opts ...grpc.CallOption) (*MarkLessonsCompletedResponse, error) {
+	out := new(MarkLessonsCompletedResponse)
+	err := c.cc.Invoke(ctx, "/course.CourseService/MarkLessonsCompleted", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *courseServiceClient) SetCourseHidden(ctx context.Context, in *SetCourseHiddenRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/course.CourseService/SetCourseHidden", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CourseServiceServer is the server API for CourseService service.
 // All implementations must embed UnimplementedCourseServiceServer
 // for forward compatibility
@@ -286,6 +366,14 @@ type CourseServiceServer interface {
 	GetQuestionTestLesson(context.Context, *GetQuestionTestLessonRequest) (*GetQuestionTestLessonResponse, error)
 	AnswerQuestion(context.Context, *AnswerQuestionRequest) (*emptypb.Empty, error)
 	SearchCoursesByTitle(context.Context, *SearchCoursesByTitleRequest) (*GetBucketCoursesResponse, error)
+	GetUserCoursesSummary(context.Context, *GetUserCoursesSummaryRequest) (*GetUserCoursesSummaryResponse, error)
+	ExportUserData(context.Context, *ExportUserDataRequest) (*ExportUserDataResponse, error)
+	CanViewLesson(context.Context, *CanViewLessonRequest) (*CanViewLessonResponse, error)
+	AnswerBlockQuiz(context.Context, *AnswerBlockQuizRequest) (*AnswerBlockQuizResponse, error)
+	PreviewLessonBlocks(context.Context, *PreviewLessonBlocksRequest) (*PreviewLessonBlocksResponse, error)
+	GetCourseBundle(context.Context, *GetCourseBundleRequest) (*GetCourseBundleResponse, error)
+	MarkLessonsCompleted(context.Context, *MarkLessonsCompletedRequest) (*MarkLessonsCompletedResponse, error)
+	SetCourseHidden(context.Context, *SetCourseHiddenRequest) (*emptypb.Empty, error)
 	mustEmbedUnimplementedCourseServiceServer()
 }
 
@@ -362,6 +450,30 @@ func (UnimplementedCourseServiceServer) AnswerQuestion(context.Context, *AnswerQ
 func (UnimplementedCourseServiceServer) SearchCoursesByTitle(context.Context, *SearchCoursesByTitleRequest) (*GetBucketCoursesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SearchCoursesByTitle not implemented")
 }
+func (UnimplementedCourseServiceServer) GetUserCoursesSummary(context.Context, *GetUserCoursesSummaryRequest) (*GetUserCoursesSummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserCoursesSummary not implemented")
+}
+func (UnimplementedCourseServiceServer) ExportUserData(context.Context, *ExportUserDataRequest) (*ExportUserDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportUserData not implemented")
+}
+func (UnimplementedCourseServiceServer) CanViewLesson(context.Context, *CanViewLessonRequest) (*CanViewLessonResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CanViewLesson not implemented")
+}
+func (UnimplementedCourseServiceServer) AnswerBlockQuiz(context.Context, *AnswerBlockQuizRequest) (*AnswerBlockQuizResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnswerBlockQuiz not implemented")
+}
+func (UnimplementedCourseServiceServer) PreviewLessonBlocks(context.Context, *PreviewLessonBlocksRequest) (*PreviewLessonBlocksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewLessonBlocks not implemented")
+}
+func (UnimplementedCourseServiceServer) GetCourseBundle(context.Context, *GetCourseBundleRequest) (*GetCourseBundleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCourseBundle not implemented")
+}
+func (UnimplementedCourseServiceServer) MarkLessonsCompleted(context.Context, *MarkLessonsCompletedRequest) (*MarkLessonsCompletedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkLessonsCompleted not implemented")
+}
+func (UnimplementedCourseServiceServer) SetCourseHidden(context.Context, *SetCourseHiddenRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCourseHidden not implemented")
+}
 func (UnimplementedCourseServiceServer) mustEmbedUnimplementedCourseServiceServer() {}
 
 // UnsafeCourseServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -789,6 +901,150 @@ func _CourseService_SearchCoursesByTitle_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CourseService_GetUserCoursesSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserCoursesSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CourseServiceServer).GetUserCoursesSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/course.CourseService/GetUserCoursesSummary",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CourseServiceServer).GetUserCoursesSummary(ctx, req.(*GetUserCoursesSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CourseService_ExportUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportUserDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CourseServiceServer).ExportUserData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/course.CourseService/ExportUserData",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CourseServiceServer).ExportUserData(ctx, req.(*ExportUserDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CourseService_CanViewLesson_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CanViewLessonRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CourseServiceServer).CanViewLesson(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/course.CourseService/CanViewLesson",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CourseServiceServer).CanViewLesson(ctx, req.(*CanViewLessonRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CourseService_AnswerBlockQuiz_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnswerBlockQuizRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CourseServiceServer).AnswerBlockQuiz(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/course.CourseService/AnswerBlockQuiz",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CourseServiceServer).AnswerBlockQuiz(ctx, req.(*AnswerBlockQuizRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CourseService_PreviewLessonBlocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewLessonBlocksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CourseServiceServer).PreviewLessonBlocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/course.CourseService/PreviewLessonBlocks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CourseServiceServer).PreviewLessonBlocks(ctx, req.(*PreviewLessonBlocksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CourseService_GetCourseBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCourseBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CourseServiceServer).GetCourseBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/course.CourseService/GetCourseBundle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CourseServiceServer).GetCourseBundle(ctx, req.(*GetCourseBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CourseService_MarkLessonsCompleted_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkLessonsCompletedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CourseServiceServer).MarkLessonsCompleted(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/course.CourseService/MarkLessonsCompleted",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CourseServiceServer).MarkLessonsCompleted(ctx, req.(*MarkLessonsCompletedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CourseService_SetCourseHidden_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCourseHiddenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CourseServiceServer).SetCourseHidden(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/course.CourseService/SetCourseHidden",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CourseServiceServer).SetCourseHidden(ctx, req.(*SetCourseHiddenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CourseService_ServiceDesc is the grpc.ServiceDesc for CourseService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -888,6 +1144,38 @@ var CourseService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SearchCoursesByTitle",
 			Handler:    _CourseService_SearchCoursesByTitle_Handler,
 		},
+		{
+			MethodName: "GetUserCoursesSummary",
+			Handler:    _CourseService_GetUserCoursesSummary_Handler,
+		},
+		{
+			MethodName: "ExportUserData",
+			Handler:    _CourseService_ExportUserData_Handler,
+		},
+		{
+			MethodName: "CanViewLesson",
+			Handler:    _CourseService_CanViewLesson_Handler,
+		},
+		{
+			MethodName: "AnswerBlockQuiz",
+			Handler:    _CourseService_AnswerBlockQuiz_Handler,
+		},
+		{
+			MethodName: "PreviewLessonBlocks",
+			Handler:    _CourseService_PreviewLessonBlocks_Handler,
+		},
+		{
+			MethodName: "GetCourseBundle",
+			Handler:    _CourseService_GetCourseBundle_Handler,
+		},
+		{
+			MethodName: "MarkLessonsCompleted",
+			Handler:    _CourseService_MarkLessonsCompleted_Handler,
+		},
+		{
+			MethodName: "SetCourseHidden",
+			Handler:    _CourseService_SetCourseHidden_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "course.proto",
@@ -14,6 +14,13 @@ type CookieUsecaseInterface interface {
 	LogoutUser(ctx context.Context, userId int) error
 }
 
+type userProfileKey struct{}
+
+// WithUserProfile - сохранение пользователя, найденного по сессии, в контексте запроса
+func WithUserProfile(ctx context.Context, userProfile *models.UserProfile) context.Context {
+	return context.WithValue(ctx, userProfileKey{}, userProfile)
+}
+
 type CookieManager struct {
 	usecase CookieUsecaseInterface
 }
@@ -50,6 +57,9 @@ func (c *CookieManager) DeleteCookie(w http.ResponseWriter) {
 }
 
 func (c *CookieManager) CheckCookie(r *http.Request) *models.UserProfile {
+	if userProfile, ok := r.Context().Value(userProfileKey{}).(*models.UserProfile); ok {
+		return userProfile
+	}
 	session, err := r.Cookie("session_id")
 	logs.PrintLog(r.Context(), "checkCookie", "checking cookie")
 	loggedIn := (err != http.ErrNoCookie)
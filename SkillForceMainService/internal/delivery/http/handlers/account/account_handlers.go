@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	billingpb "skillForce/internal/delivery/grpc/proto/billing"
+	coursepb "skillForce/internal/delivery/grpc/proto/course"
+	userpb "skillForce/internal/delivery/grpc/proto/user"
+	"skillForce/internal/delivery/http/response"
+	"skillForce/pkg/logs"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+type Handler struct {
+	userClient    userpb.UserServiceClient
+	courseClient  coursepb.CourseServiceClient
+	billingClient billingpb.BillingServiceClient
+}
+
+func NewHandler(userDialOptions []grpc.DialOption, courseDialOptions []grpc.DialOption, billingDialOptions []grpc.DialOption) *Handler {
+	userConn, err := grpc.NewClient("user-service:8081", userDialOptions...)
+	if err != nil {
+		log.Fatalf("failed to connect to user service: %v", err)
+	}
+	courseConn, err := grpc.NewClient("course-service:8082", courseDialOptions...)
+	if err != nil {
+		log.Fatalf("failed to connect to course service: %v", err)
+	}
+	billingConn, err := grpc.NewClient("billing-service:8084", billingDialOptions...)
+	if err != nil {
+		log.Fatalf("failed to connect to billing service: %v", err)
+	}
+
+	return &Handler{
+		userClient:    userpb.NewUserServiceClient(userConn),
+		courseClient:  coursepb.NewCourseServiceClient(courseConn),
+		billingClient: billingpb.NewBillingServiceClient(billingConn),
+	}
+}
+
+// RequestDataExport godoc
+// @Summary Request data export
+// @Description Collects profile, purchases, progress, answers, favourites and sertificates of the authorized user into a zip archive and sends a download link by email.
+// @Description The archive is uploaded before the response, an export can be requested once a day
+// @Tags users
+// @Produce json
+// @Success 200 {string} string "200 OK"
+// @Failure 401 {object} response.ErrorResponse "not authorized"
+// @Failure 403 {object} response.ErrorResponse "permission denied"
+// @Failure 405 {object} response.ErrorResponse "method not allowed"
+// @Failure 429 {object} response.ErrorResponse "export too early"
+// @Failure 500 {object} response.ErrorResponse "server error"
+// @Router /api/requestDataExport [post]
+func (h *Handler) RequestDataExport(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		response.SendOKResponse(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		logs.PrintLog(r.Context(), "RequestDataExport", "method not allowed")
+		response.SendErrorResponse("method not allowed", http.StatusMethodNotAllowed, w, r)
+		return
+	}
+
+	courseData, err := h.courseClient.ExportUserData(r.Context(), &coursepb.ExportUserDataRequest{})
+	if err != nil {
+		logs.PrintLog(r.Context(), "RequestDataExport", fmt.Sprintf("%+v", err))
+		response.SendErrorResponse("server error", http.StatusInternalServerError, w, r)
+		return
+	}
+
+	billingData, err := h.billingClient.ExportUserData(r.Context(), &billingpb.ExportUserDataRequest{})
+	if err != nil {
+		logs.PrintLog(r.Context(), "RequestDataExport", fmt.Sprintf("%+v", err))
+		response.SendErrorResponse("server error", http.StatusInternalServerError, w, r)
+		return
+	}
+
+	// файлы каждого сервиса кладутся в архив в свою папку
+	files := make([]*userpb.UserDataFile, 0, len(courseData.Files)+len(billingData.Files))
+	for _, file := range courseData.Files {
+		files = append(files, &userpb.UserDataFile{Name: "courses/" + file.Name, Content: file.Content})
+	}
+	for _, file := range billingData.Files {
+		files = append(files, &userpb.UserDataFile{Name: "billing/" + file.Name, Content: file.Content})
+	}
+
+	_, err = h.userClient.RequestDataExport(r.Context(), &userpb.RequestDataExportRequest{Files: files})
+	if err != nil {
+		logs.PrintLog(r.Context(), "RequestDataExport", fmt.Sprintf("%+v", err))
+		if st, ok := status.FromError(err); ok && st.Message() == "export too early" {
+			response.SendErrorResponse(st.Message(), http.StatusTooManyRequests, w, r)
+			return
+		}
+		response.SendErrorResponse("server error", http.StatusInternalServerError, w, r)
+		return
+	}
+
+	logs.PrintLog(r.Context(), "RequestDataExport", "data export requested")
+	response.SendOKResponse(w, r)
+}
+
+// DeleteAccount godoc
+// @Summary Delete account
+// @Description Schedules deletion of the authorized user's account. Until delete_after the deletion can be cancelled, after it personal data is removed or anonymized. Payments are kept as financial records
+// @Tags users
+// @Produce json
+// @Success 200 {object} response.AccountDeletionResponse "Date after which the account will be deleted"
+// @Failure 401 {object} response.ErrorResponse "not authorized"
+// @Failure 403 {object} response.ErrorResponse "permission denied"
+// @Failure 405 {object} response.ErrorResponse "method not allowed"
+// @Failure 500 {object} response.ErrorResponse "server error"
+// @Router /api/deleteAccount [post]
+func (h *Handler) DeleteAccount(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		response.SendOKResponse(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		logs.PrintLog(r.Context(), "DeleteAccount", "method not allowed")
+		response.SendErrorResponse("method not allowed", http.StatusMethodNotAllowed, w, r)
+		return
+	}
+
+	resp, err := h.userClient.DeleteAccount(r.Context(), &userpb.DeleteAccountRequest{})
+	if err != nil {
+		logs.PrintLog(r.Context(), "DeleteAccount", fmt.Sprintf("%+v", err))
+		response.SendErrorResponse("server error", http.StatusInternalServerError, w, r)
+		return
+	}
+
+	deleteAfter := time.Unix(resp.DeleteAfter, 0)
+	logs.PrintLog(r.Context(), "DeleteAccount", fmt.Sprintf("account will be deleted after %v", deleteAfter))
+	response.SendAccountDeletion(deleteAfter, w, r)
+}
+
+// CancelAccountDeletion godoc
+// @Summary Cancel account deletion
+// @Description Cancels scheduled deletion of the authorized user's account
+// @Tags users
+// @Produce json
+// @Success 200 {string} string "200 OK"
+// @Failure 401 {object} response.ErrorResponse "not authorized"
+// @Failure 403 {object} response.ErrorResponse "permission denied"
+// @Failure 404 {object} response.ErrorResponse "account deletion not found"
+// @Failure 405 {object} response.ErrorResponse "method not allowed"
+// @Failure 500 {object} response.ErrorResponse "server error"
+// @Router /api/cancelAccountDeletion [post]
+func (h *Handler) CancelAccountDeletion(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		response.SendOKResponse(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		logs.PrintLog(r.Context(), "CancelAccountDeletion", "method not allowed")
+		response.SendErrorResponse("method not allowed", http.StatusMethodNotAllowed, w, r)
+		return
+	}
+
+	_, err := h.userClient.CancelAccountDeletion(r.Context(), &userpb.DeleteAccountRequest{})
+	if err != nil {
+		logs.PrintLog(r.Context(), "CancelAccountDeletion", fmt.Sprintf("%+v", err))
+		if st, ok := status.FromError(err); ok && st.Message() == "account deletion not found" {
+			response.SendErrorResponse(st.Message(), http.StatusNotFound, w, r)
+			return
+		}
+		response.SendErrorResponse("server error", http.StatusInternalServerError, w, r)
+		return
+	}
+
+	logs.PrintLog(r.Context(), "CancelAccountDeletion", "account deletion cancelled")
+	response.SendOKResponse(w, r)
+}
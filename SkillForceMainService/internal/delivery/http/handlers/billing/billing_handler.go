@@ -2,7 +2,6 @@ package handlers
 
 import (
 	"bytes"
-	"context"
 	"io"
 	"log"
 	"net/http"
@@ -14,7 +13,6 @@ import (
 
 	"github.com/mailru/easyjson"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 type CookieManagerInterface interface {
@@ -26,8 +24,8 @@ type Handler struct {
 	cookieManager CookieManagerInterface
 }
 
-func NewHandler(cookieManager CookieManagerInterface) *Handler {
-	conn, err := grpc.NewClient("billing-service:8084", grpc.WithTransportCredentials(insecure.NewCredentials()))
+func NewHandler(cookieManager CookieManagerInterface, dialOptions ...grpc.DialOption) *Handler {
+	conn, err := grpc.NewClient("billing-service:8084", dialOptions...)
 	if err != nil {
 		log.Fatalf("failed to connect to billing service: %v", err)
 	}
@@ -63,7 +61,7 @@ func (h *Handler) CreatePaymentHandler(w http.ResponseWriter, r *http.Request) {
 
 	req.User_ID = int32(userProfile.Id)
 
-	resp, err := h.billingClient.CreatePayment(context.Background(), &billingpb.CreatePaymentRequest{
+	resp, err := h.billingClient.CreatePayment(r.Context(), &billingpb.CreatePaymentRequest{
 		ReturnUrl: req.ReturnURL,
 		UserId:    req.User_ID,
 		CourseId:  req.CourseID,
@@ -99,7 +97,7 @@ func (h *Handler) WebhookHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, err = h.billingClient.HandleWebhook(context.Background(), &billingpb.YooKassaWebhook{
+	_, err = h.billingClient.HandleWebhook(r.Context(), &billingpb.YooKassaWebhook{
 		Event:      data.Event,
 		PaymentId:  data.Object.ID,
 		Status:     data.Object.Status,
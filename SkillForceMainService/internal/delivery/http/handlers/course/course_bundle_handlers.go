@@ -0,0 +1,239 @@
+package handlers
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	coursepb "skillForce/internal/delivery/grpc/proto/course"
+	"skillForce/internal/delivery/http/response"
+	"skillForce/internal/models/dto"
+	courseUsecase "skillForce/internal/usecase/course"
+	"skillForce/pkg/logs"
+	"skillForce/pkg/mediasign"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mailru/easyjson"
+	"google.golang.org/grpc/status"
+)
+
+// GetCourseBundle godoc
+// @Summary Get offline course bundle
+// @Description Returns the status of the offline bundle of a purchased course: lesson pages, images, attachments and optionally low-bitrate videos packaged as zip or EPUB.
+// @Description The first request queues the build, the client polls until status is ready and downloads the bundle by the signed url. The bundle is rebuilt when the course content changes
+// @Tags courses
+// @Produce json
+// @Param course_id query int true "Course ID"
+// @Param format query string false "zip (default) or epub"
+// @Param videos query bool false "Include low-bitrate lesson videos"
+// @Success 200 {object} response.CourseBundleResponse "Bundle status and link when ready"
+// @Failure 400 {object} response.ErrorResponse "invalid course_id parameter or bundle format"
+// @Failure 401 {object} response.ErrorResponse "not authorized"
+// @Failure 403 {object} response.ErrorResponse "course is not purchased"
+// @Failure 404 {object} response.ErrorResponse "course not found"
+// @Failure 405 {object} response.ErrorResponse "method not allowed"
+// @Failure 500 {object} response.ErrorResponse "server error"
+// @Router /api/getCourseBundle [get]
+func (h *Handler) GetCourseBundle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		logs.PrintLog(r.Context(), "GetCourseBundle", "method not allowed")
+		response.SendErrorResponse("method not allowed", http.StatusMethodNotAllowed, w, r)
+		return
+	}
+
+	courseId, err := strconv.Atoi(r.URL.Query().Get("course_id"))
+	if err != nil {
+		response.SendErrorResponse("invalid course_id parameter", http.StatusBadRequest, w, r)
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = courseUsecase.BundleFormatZip
+	}
+	withVideos := r.URL.Query().Get("videos") == "true"
+
+	res, err := h.courseClient.GetCourseBundle(r.Context(), &coursepb.GetCourseBundleRequest{CourseId: int32(courseId)})
+	if err != nil {
+		logs.PrintLog(r.Context(), "GetCourseBundle", fmt.Sprintf("%+v", err))
+		st, _ := status.FromError(err)
+		switch st.Message() {
+		case "course is not purchased":
+			response.SendErrorResponse(st.Message(), http.StatusForbidden, w, r)
+		case "course not found":
+			response.SendErrorResponse(st.Message(), http.StatusNotFound, w, r)
+		default:
+			response.SendErrorResponse("server error", http.StatusInternalServerError, w, r)
+		}
+		return
+	}
+
+	bundle, err := h.videoManager.RequestCourseBundle(r.Context(), courseBundleContent(res), format, withVideos)
+	if err != nil {
+		logs.PrintLog(r.Context(), "GetCourseBundle", fmt.Sprintf("%+v", err))
+		if err.Error() == "invalid bundle format" {
+			response.SendErrorResponse(err.Error(), http.StatusBadRequest, w, r)
+			return
+		}
+		response.SendErrorResponse("server error", http.StatusInternalServerError, w, r)
+		return
+	}
+
+	if bundle.Status != courseUsecase.BundleReady {
+		response.SendCourseBundle(bundle.Status, "", time.Time{}, w, r)
+		return
+	}
+	url, expiresAt := h.mediaSigner.CourseBundleURL(bundle.ObjectName, courseUsecase.BundleFileName(res.Title, format), time.Now())
+	response.SendCourseBundle(bundle.Status, url, expiresAt, w, r)
+}
+
+// courseBundleContent - снимок курса для сборки. Файлы блоков остаются именами объектов,
+// сборщик кладёт их в выгрузку сам
+func courseBundleContent(res *coursepb.GetCourseBundleResponse) *dto.CourseBundleDTO {
+	content := &dto.CourseBundleDTO{
+		CourseId:    int(res.CourseId),
+		Title:       res.Title,
+		Description: res.Description,
+		Version:     res.Version,
+		Lessons:     make([]dto.BundleLessonDTO, 0, len(res.Lessons)),
+	}
+	for _, lesson := range res.Lessons {
+		blocks := make([]dto.LessonBlockDTO, 0, len(lesson.Blocks))
+		for _, block := range lesson.Blocks {
+			blocks = append(blocks, dto.LessonBlockDTO{
+				Type:        block.Type,
+				Body:        block.Body,
+				Text:        block.Text,
+				Object:      block.Object,
+				FileName:    block.FileName,
+				ContentType: block.ContentType,
+				Size:        block.Size,
+				Alt:         block.Alt,
+				Caption:     block.Caption,
+				Language:    block.Language,
+				Code:        block.Code,
+				Variant:     block.Variant,
+				EmbedUrl:    block.EmbedUrl,
+				Question:    block.Question,
+				Options:     block.Options,
+			})
+		}
+		content.Lessons = append(content.Lessons, dto.BundleLessonDTO{
+			LessonId:    int(lesson.LessonId),
+			PartTitle:   lesson.PartTitle,
+			BucketTitle: lesson.BucketTitle,
+			Title:       lesson.Title,
+			Type:        lesson.Type,
+			Blocks:      blocks,
+		})
+	}
+	return content
+}
+
+// ServeCourseBundle godoc
+// @Summary Download offline course bundle
+// @Description Serves a bundle by a signed link from /api/getCourseBundle
+// @Tags courses
+// @Param object path string true "Object name"
+// @Param expires query int true "Link expiry"
+// @Param signature query string true "Link signature"
+// @Param name query string false "File name for download"
+// @Success 200 {file} file "Bundle"
+// @Failure 403 {object} response.ErrorResponse "invalid signature or link expired"
+// @Failure 404 {object} response.ErrorResponse "bundle not found"
+// @Failure 405 {object} response.ErrorResponse "method not allowed"
+// @Router /api/media/bundles/{object} [get]
+func (h *Handler) ServeCourseBundle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		logs.PrintLog(r.Context(), "ServeCourseBundle", "method not allowed")
+		response.SendErrorResponse("method not allowed", http.StatusMethodNotAllowed, w, r)
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, "/api/media/bundles/")
+	err := h.mediaSigner.Verify(mediasign.CourseBundleResource(name), r.URL.Query().Get("expires"), r.URL.Query().Get("signature"), time.Now())
+	if err != nil {
+		logs.PrintLog(r.Context(), "ServeCourseBundle", fmt.Sprintf("%s: %+v", name, err))
+		response.SendErrorResponse(err.Error(), http.StatusForbidden, w, r)
+		return
+	}
+
+	reader, meta, err := h.videoManager.GetCourseBundleFile(r.Context(), name)
+	if err != nil {
+		response.SendErrorResponse(err.Error(), http.StatusNotFound, w, r)
+		return
+	}
+	defer func() {
+		if err := reader.Close(); err != nil {
+			logs.PrintLog(r.Context(), "ServeCourseBundle", "failed to close reader")
+		}
+	}()
+
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": r.URL.Query().Get("name")})
+	if disposition == "" {
+		disposition = "attachment"
+	}
+	w.Header().Set("Content-Disposition", disposition)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	response.SendMediaFile(meta, reader, w, r)
+}
+
+// MarkLessonsCompleted godoc
+// @Summary Upload offline progress
+// @Description Marks lessons completed while the learner was offline. All lessons must belong to the course, otherwise none is marked
+// @Tags lessons
+// @Accept json
+// @Produce json
+// @Param progress body dto.MarkLessonsCompletedRequest true "Course and completed lessons"
+// @Success 200 {object} response.MarkLessonsCompletedResponse "Number of marked lessons"
+// @Failure 400 {object} response.ErrorResponse "invalid request or too many lessons"
+// @Failure 401 {object} response.ErrorResponse "not authorized"
+// @Failure 403 {object} response.ErrorResponse "course is not purchased"
+// @Failure 404 {object} response.ErrorResponse "course or lesson not found"
+// @Failure 405 {object} response.ErrorResponse "method not allowed"
+// @Failure 500 {object} response.ErrorResponse "server error"
+// @Router /api/markLessonsCompleted [post]
+func (h *Handler) MarkLessonsCompleted(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		response.SendOKResponse(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		logs.PrintLog(r.Context(), "MarkLessonsCompleted", "method not allowed")
+		response.SendErrorResponse("method not allowed", http.StatusMethodNotAllowed, w, r)
+		return
+	}
+
+	var input dto.MarkLessonsCompletedRequest
+	if err := easyjson.UnmarshalFromReader(r.Body, &input); err != nil {
+		logs.PrintLog(r.Context(), "MarkLessonsCompleted", fmt.Sprintf("%+v", err))
+		response.SendErrorResponse("invalid request", http.StatusBadRequest, w, r)
+		return
+	}
+
+	lessonIds := make([]int32, 0, len(input.LessonIds))
+	for _, lessonId := range input.LessonIds {
+		lessonIds = append(lessonIds, int32(lessonId))
+	}
+	res, err := h.courseClient.MarkLessonsCompleted(r.Context(), &coursepb.MarkLessonsCompletedRequest{
+		CourseId:  int32(input.CourseId),
+		LessonIds: lessonIds,
+	})
+	if err != nil {
+		logs.PrintLog(r.Context(), "MarkLessonsCompleted", fmt.Sprintf("%+v", err))
+		st, _ := status.FromError(err)
+		switch {
+		case strings.HasPrefix(st.Message(), "too many lessons"):
+			response.SendErrorResponse(st.Message(), http.StatusBadRequest, w, r)
+		case st.Message() == "course is not purchased":
+			response.SendErrorResponse(st.Message(), http.StatusForbidden, w, r)
+		case st.Message() == "course not found", st.Message() == "lesson not found":
+			response.SendErrorResponse(st.Message(), http.StatusNotFound, w, r)
+		default:
+			response.SendErrorResponse("server error", http.StatusInternalServerError, w, r)
+		}
+		return
+	}
+
+	response.SendMarkedLessons(int(res.Marked), w, r)
+}
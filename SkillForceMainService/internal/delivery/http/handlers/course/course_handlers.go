@@ -6,18 +6,25 @@ import (
 	"io"
 	"log"
 	"net/http"
+	"path"
 	coursepb "skillForce/internal/delivery/grpc/proto/course"
+	"skillForce/internal/delivery/http/media"
 	"skillForce/internal/delivery/http/response"
+	coursemodels "skillForce/internal/models/course"
 	"skillForce/internal/models/dto"
 	models "skillForce/internal/models/user"
+	"skillForce/pkg/auth"
+	"skillForce/pkg/avatar"
 	"skillForce/pkg/logs"
+	"skillForce/pkg/mediasign"
 	"strconv"
+	"time"
 
 	"strings"
 
 	"github.com/mailru/easyjson"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 )
 
 type CookieManagerInterface interface {
@@ -28,16 +35,32 @@ type VideoManagerInterface interface {
 	GetVideoUrl(ctx context.Context, lesson_id int) (string, error)
 	GetMeta(ctx context.Context, name string) (dto.VideoMeta, error)
 	GetFragment(ctx context.Context, name string, start, end int64) (io.ReadCloser, error)
+
+	InitVideoUpload(ctx context.Context, identity *auth.Identity, req *dto.InitVideoUploadDTO) (*dto.VideoUploadDTO, error)
+	UploadVideoChunk(ctx context.Context, userId int, uploadId string, chunk int, data io.Reader, size int64) (*dto.VideoUploadDTO, error)
+	GetVideoUploadStatus(ctx context.Context, userId int, uploadId string) (*dto.VideoUploadDTO, error)
+	CompleteVideoUpload(ctx context.Context, userId int, uploadId string) (*dto.VideoUploadDTO, error)
+	AbortVideoUpload(ctx context.Context, userId int, uploadId string) error
+
+	GetHLSFile(ctx context.Context, lessonId int, file string) (io.ReadCloser, dto.VideoMeta, error)
+	GetSertificateFile(ctx context.Context, name string) (io.ReadCloser, dto.VideoMeta, error)
+
+	UploadLessonFile(ctx context.Context, identity *auth.Identity, kind string, fileName string, data []byte) (*dto.LessonFileDTO, error)
+	GetLessonFile(ctx context.Context, objectName string) (io.ReadCloser, dto.VideoMeta, error)
+
+	RequestCourseBundle(ctx context.Context, content *dto.CourseBundleDTO, format string, withVideos bool) (*coursemodels.CourseBundle, error)
+	GetCourseBundleFile(ctx context.Context, objectName string) (io.ReadCloser, dto.VideoMeta, error)
 }
 
 type Handler struct {
 	courseClient  coursepb.CourseServiceClient
 	cookieManager CookieManagerInterface
 	videoManager  VideoManagerInterface
+	mediaSigner   *mediasign.Signer
 }
 
-func NewHandler(cookieManager CookieManagerInterface, videoManager VideoManagerInterface) *Handler {
-	conn, err := grpc.NewClient("course-service:8082", grpc.WithTransportCredentials(insecure.NewCredentials()))
+func NewHandler(cookieManager CookieManagerInterface, videoManager VideoManagerInterface, mediaSigner *mediasign.Signer, dialOptions ...grpc.DialOption) *Handler {
+	conn, err := grpc.NewClient("course-service:8082", dialOptions...)
 	if err != nil {
 		log.Fatalf("failed to connect to user service: %v", err)
 	}
@@ -45,6 +68,8 @@ func NewHandler(cookieManager CookieManagerInterface, videoManager VideoManagerI
 	return &Handler{
 		courseClient:  courseClient,
 		cookieManager: cookieManager,
+		videoManager:  videoManager,
+		mediaSigner:   mediaSigner,
 	}
 }
 
@@ -462,14 +487,7 @@ func (h *Handler) GetCourseLesson(w http.ResponseWriter, r *http.Request) {
 		lesson.LessonHeader.Points = append(lesson.LessonHeader.Points, point)
 	}
 
-	for _, grpcBlock := range grpcGetCourseLessonResponse.Lesson.Body.Blocks {
-		block := struct {
-			Body string `json:"body"`
-		}{
-			Body: grpcBlock.Body,
-		}
-		lesson.LessonBody.Blocks = append(lesson.LessonBody.Blocks, block)
-	}
+	lesson.LessonBody.Blocks = h.lessonBlocks(grpcGetCourseLessonResponse.Lesson.Body.Blocks)
 
 	lesson.LessonBody.Footer = struct {
 		NextLessonId     int `json:"next_lesson_id"`
@@ -578,14 +596,7 @@ func (h *Handler) GetNextLesson(w http.ResponseWriter, r *http.Request) {
 		lesson.LessonHeader.Points = append(lesson.LessonHeader.Points, point)
 	}
 
-	for _, grpcBlock := range grpcGetNextLessonResponse.Lesson.Body.Blocks {
-		block := struct {
-			Body string `json:"body"`
-		}{
-			Body: grpcBlock.Body,
-		}
-		lesson.LessonBody.Blocks = append(lesson.LessonBody.Blocks, block)
-	}
+	lesson.LessonBody.Blocks = h.lessonBlocks(grpcGetNextLessonResponse.Lesson.Body.Blocks)
 
 	lesson.LessonBody.Footer = struct {
 		NextLessonId     int `json:"next_lesson_id"`
@@ -878,12 +889,13 @@ func (h *Handler) GetRating(w http.ResponseWriter, r *http.Request) {
 
 		ratingItems = append(ratingItems, dto.RaitingItem{
 			User: dto.UserProfileDTO{
-				Name:      user.GetName(),
-				Email:     user.GetEmail(),
-				Bio:       user.GetBio(),
-				AvatarSrc: user.GetAvatarSrc(),
-				HideEmail: user.GetHideEmail(),
-				IsAdmin:   user.GetIsAdmin(),
+				Name:        user.GetName(),
+				Email:       user.GetEmail(),
+				Bio:         user.GetBio(),
+				AvatarSrc:   user.GetAvatarSrc(),
+				AvatarSizes: avatar.Thumbnails(user.GetAvatarSrc()),
+				HideEmail:   user.GetHideEmail(),
+				IsAdmin:     user.GetIsAdmin(),
 			},
 			Rating: int(item.GetRating()),
 		})
@@ -944,7 +956,7 @@ func (h *Handler) GetSertificate(w http.ResponseWriter, r *http.Request) {
 
 	sertificateUrl := grpcGetSertificateResponse.SertificateUrl
 	logs.PrintLog(r.Context(), "GetSertificate", fmt.Sprintf("get sertificate url: %s from grpc", sertificateUrl))
-	response.SendSertificateUrl(sertificateUrl, w, r)
+	response.SendSertificateUrl(h.signSertificateUrl(sertificateUrl), w, r)
 }
 
 func (h *Handler) GetGeneratedSertificate(w http.ResponseWriter, r *http.Request) {
@@ -997,7 +1009,7 @@ func (h *Handler) GetGeneratedSertificate(w http.ResponseWriter, r *http.Request
 
 	sertificateUrl := grpcGetSertificateResponse.SertificateUrl
 	logs.PrintLog(r.Context(), "GetSertificate", fmt.Sprintf("get sertificate url: %s from grpc", sertificateUrl))
-	response.SendSertificateUrl(sertificateUrl, w, r)
+	response.SendSertificateUrl(h.signSertificateUrl(sertificateUrl), w, r)
 }
 
 func (h *Handler) GetStatistic(w http.ResponseWriter, r *http.Request) {
@@ -1057,17 +1069,27 @@ func (h *Handler) GetStatistic(w http.ResponseWriter, r *http.Request) {
 // @Summary Serve video content
 // @Description Streams video content for a lesson based on the lesson ID provided in the query parameters.
 //
-//	If a "Range" header is present, it streams the requested byte range; otherwise, it streams the entire video.
+//	Supports HEAD, single, suffix and multiple byte ranges (multipart/byteranges), If-Range,
+//	If-None-Match and If-Modified-Since.
 //
 // @Tags videos
 // @Accept */*
 // @Produce video/mp4
 // @Param lesson_id query int true "Lesson ID"
+// @Param expires query int true "Link expiry from /api/getVideoLink"
+// @Param signature query string true "Link signature from /api/getVideoLink"
+// @Param Range header string false "Byte ranges, e.g. bytes=0-1023,-512"
+// @Param If-Range header string false "ETag or Last-Modified the ranges are valid for"
+// @Param If-None-Match header string false "ETag of a cached copy"
+// @Success 200 {file} video/mp4 "Whole video"
 // @Success 206 {file} video/mp4 "Partial Content"
+// @Success 304 "Not Modified"
 // @Failure 400 {object} response.ErrorResponse "Invalid lesson ID parameter"
+// @Failure 403 {object} response.ErrorResponse "invalid signature or link expired"
 // @Failure 404 {object} response.ErrorResponse "Video not found"
-// @Failure 500 {object} response.ErrorResponse "Internal server error"
-// @Router /api/serveVideo [get]
+// @Failure 416 {object} response.ErrorResponse "range not satisfiable"
+// @Router /api/video [get]
+// @Router /api/video [head]
 func (h *Handler) ServeVideo(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	lesson_id := r.URL.Query().Get("lesson_id")
@@ -1083,62 +1105,23 @@ func (h *Handler) ServeVideo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	videoSrc, err := h.videoManager.GetVideoUrl(ctx, lesson_id_int)
-
+	err = h.mediaSigner.Verify(mediasign.VideoResource(lesson_id_int), r.URL.Query().Get("expires"), r.URL.Query().Get("signature"), time.Now())
 	if err != nil {
-		response.SendErrorResponse("video not found", http.StatusNotFound, w, r)
+		logs.PrintLog(ctx, "ServeVideo", fmt.Sprintf("lesson %d: %+v", lesson_id_int, err))
+		response.SendErrorResponse(err.Error(), http.StatusForbidden, w, r)
 		return
 	}
 
-	name := strings.Split(videoSrc, "/")[len(strings.Split(videoSrc, "/"))-1]
+	videoSrc, err := h.videoManager.GetVideoUrl(ctx, lesson_id_int)
 
-	meta, err := h.videoManager.GetMeta(ctx, name)
 	if err != nil {
 		response.SendErrorResponse("video not found", http.StatusNotFound, w, r)
 		return
 	}
 
-	rangeHeader := r.Header.Get("Range")
-	if rangeHeader == "" {
-		reader, err := h.videoManager.GetFragment(ctx, name, 0, meta.Size-1)
-		if err != nil {
-			response.SendErrorResponse("video getting error"+err.Error(), http.StatusInternalServerError, w, r)
-			return
-		}
-		defer func() {
-			if err := reader.Close(); err != nil {
-				logs.PrintLog(ctx, "ServeVideo", "failed to close reader")
-			}
-		}()
-
-		response.SendVideoRange(0, meta.Size-1, meta.Size, reader, w, r)
-		return
-	}
-
-	var start, end int64
-	rangeParts := strings.Split(strings.Replace(rangeHeader, "bytes=", "", 1), "-")
-	start, _ = strconv.ParseInt(rangeParts[0], 10, 64)
-	if rangeParts[1] != "" {
-		end, _ = strconv.ParseInt(rangeParts[1], 10, 64)
-	} else {
-		end = meta.Size - 1
-	}
-	if end >= meta.Size {
-		end = meta.Size - 1
-	}
-
-	reader, err := h.videoManager.GetFragment(ctx, name, start, end)
-	if err != nil {
-		response.SendErrorResponse("reading frame error"+err.Error(), http.StatusInternalServerError, w, r)
-		return
-	}
-	defer func() {
-		if err := reader.Close(); err != nil {
-			logs.PrintLog(ctx, "ServeVideo", "failed to close reader")
-		}
-	}()
+	name := strings.Split(videoSrc, "/")[len(strings.Split(videoSrc, "/"))-1]
 
-	response.SendVideoRange(start, end, meta.Size, reader, w, r)
+	media.Serve(w, r, h.videoManager, name)
 }
 
 func (h *Handler) CreateCourse(w http.ResponseWriter, r *http.Request) {
@@ -1174,6 +1157,7 @@ func (h *Handler) CreateCourse(w http.ResponseWriter, r *http.Request) {
 					Title:    lesson.Title,
 					Value:    lesson.Value,
 					IsDone:   lesson.IsDone,
+					Blocks:   grpcLessonBlocks(lesson.Blocks),
 				})
 			}
 
@@ -1220,6 +1204,10 @@ func (h *Handler) CreateCourse(w http.ResponseWriter, r *http.Request) {
 	_, err := h.courseClient.CreateCourse(r.Context(), grpcCreateCourseRequest)
 	if err != nil {
 		logs.PrintLog(r.Context(), "CreateCourse", fmt.Sprintf("%+v", err))
+		if st, ok := status.FromError(err); ok && strings.HasPrefix(st.Message(), "invalid lesson block") {
+			response.SendErrorResponse(st.Message(), http.StatusBadRequest, w, r)
+			return
+		}
 		response.SendErrorResponse(err.Error(), http.StatusInternalServerError, w, r)
 		return
 	}
@@ -1273,6 +1261,7 @@ func (h *Handler) AddCourseToFavourites(w http.ResponseWriter, r *http.Request)
 					Title:    lesson.Title,
 					Value:    lesson.Value,
 					IsDone:   lesson.IsDone,
+					Blocks:   grpcLessonBlocks(lesson.Blocks),
 				})
 			}
 
@@ -1372,6 +1361,7 @@ func (h *Handler) DeleteCourseFromFavourites(w http.ResponseWriter, r *http.Requ
 					Title:    lesson.Title,
 					Value:    lesson.Value,
 					IsDone:   lesson.IsDone,
+					Blocks:   grpcLessonBlocks(lesson.Blocks),
 				})
 			}
 
@@ -1722,3 +1712,221 @@ func (h *Handler) AnswerQuestion(w http.ResponseWriter, r *http.Request) {
 
 	response.SendOKResponse(w, r)
 }
+
+// ServeHLS godoc
+// @Summary Stream video lesson over HLS
+// @Description Serves master playlist, media playlists and segments of the adaptive bitrate version of the lesson video.
+// @Description Player starts from hls_url of /api/getVideoLink and picks a rendition by connection speed.
+// @Description Until the video is transcoded the endpoint returns 404 and url of /api/getVideoLink can be used instead
+// @Tags videos
+// @Produce application/vnd.apple.mpegurl
+// @Produce video/mp2t
+// @Param lesson_id path int true "Lesson ID"
+// @Param expires path int true "Link expiry"
+// @Param signature path string true "Link signature"
+// @Param file path string true "master.m3u8, {rendition}/index.m3u8 or {rendition}/seg_{n}.ts"
+// @Success 200 {file} file "Playlist or segment"
+// @Failure 400 {object} response.ErrorResponse "invalid lesson_id parameter"
+// @Failure 403 {object} response.ErrorResponse "invalid signature or link expired"
+// @Failure 404 {object} response.ErrorResponse "video not found"
+// @Failure 405 {object} response.ErrorResponse "method not allowed"
+// @Router /api/video/hls/{lesson_id}/{expires}/{signature}/{file} [get]
+func (h *Handler) ServeHLS(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		logs.PrintLog(r.Context(), "ServeHLS", "method not allowed")
+		response.SendErrorResponse("method not allowed", http.StatusMethodNotAllowed, w, r)
+		return
+	}
+
+	// относительные ссылки плейлистов разрешаются от пути, поэтому урок, подпись и файл передаются в пути, а не в query
+	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/api/video/hls/"), "/", 4)
+	if len(parts) != 4 {
+		response.SendErrorResponse("video not found", http.StatusNotFound, w, r)
+		return
+	}
+	lessonId, err := strconv.Atoi(parts[0])
+	if err != nil {
+		response.SendErrorResponse("invalid lesson_id parameter", http.StatusBadRequest, w, r)
+		return
+	}
+	if err := h.mediaSigner.Verify(mediasign.VideoResource(lessonId), parts[1], parts[2], time.Now()); err != nil {
+		logs.PrintLog(r.Context(), "ServeHLS", fmt.Sprintf("lesson %d: %+v", lessonId, err))
+		response.SendErrorResponse(err.Error(), http.StatusForbidden, w, r)
+		return
+	}
+	file := parts[3]
+
+	reader, meta, err := h.videoManager.GetHLSFile(r.Context(), lessonId, file)
+	if err != nil {
+		logs.PrintLog(r.Context(), "ServeHLS", fmt.Sprintf("%+v", err))
+		if err.Error() == "video is not transcoded yet" {
+			response.SendErrorResponse(err.Error(), http.StatusNotFound, w, r)
+			return
+		}
+		response.SendErrorResponse("video not found", http.StatusNotFound, w, r)
+		return
+	}
+	defer func() {
+		if err := reader.Close(); err != nil {
+			logs.PrintLog(r.Context(), "ServeHLS", "failed to close reader")
+		}
+	}()
+
+	response.SendHLSFile(meta, reader, w, r)
+}
+
+// GetVideoLink godoc
+// @Summary Get video links
+// @Description Issues short-lived signed links to the lesson video for users who purchased the course, its author and admins.
+// @Description url streams mp4 with Range support, hls_url is the adaptive bitrate master playlist
+// @Tags videos
+// @Produce json
+// @Param lesson_id query int true "Lesson ID"
+// @Success 200 {object} response.VideoLinkResponse "Signed links"
+// @Failure 400 {object} response.ErrorResponse "invalid lesson_id parameter"
+// @Failure 401 {object} response.ErrorResponse "not authorized"
+// @Failure 403 {object} response.ErrorResponse "course is not purchased"
+// @Failure 404 {object} response.ErrorResponse "lesson not found"
+// @Failure 405 {object} response.ErrorResponse "method not allowed"
+// @Failure 500 {object} response.ErrorResponse "server error"
+// @Router /api/getVideoLink [get]
+func (h *Handler) GetVideoLink(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		logs.PrintLog(r.Context(), "GetVideoLink", "method not allowed")
+		response.SendErrorResponse("method not allowed", http.StatusMethodNotAllowed, w, r)
+		return
+	}
+
+	lessonId, err := strconv.Atoi(r.URL.Query().Get("lesson_id"))
+	if err != nil {
+		response.SendErrorResponse("invalid lesson_id parameter", http.StatusBadRequest, w, r)
+		return
+	}
+
+	access, err := h.courseClient.CanViewLesson(r.Context(), &coursepb.CanViewLessonRequest{LessonId: int32(lessonId)})
+	if err != nil {
+		logs.PrintLog(r.Context(), "GetVideoLink", fmt.Sprintf("%+v", err))
+		if st, ok := status.FromError(err); ok && st.Message() == "lesson not found" {
+			response.SendErrorResponse(st.Message(), http.StatusNotFound, w, r)
+			return
+		}
+		response.SendErrorResponse("server error", http.StatusInternalServerError, w, r)
+		return
+	}
+	if !access.Allowed {
+		logs.PrintLog(r.Context(), "GetVideoLink", fmt.Sprintf("lesson %d is not available", lessonId))
+		response.SendErrorResponse("course is not purchased", http.StatusForbidden, w, r)
+		return
+	}
+
+	now := time.Now()
+	url, expiresAt := h.mediaSigner.VideoURL(lessonId, now)
+	response.SendVideoLink(url, h.mediaSigner.HLSURL(lessonId, now), expiresAt, w, r)
+}
+
+// ServeSertificate godoc
+// @Summary Download sertificate
+// @Description Serves sertificate pdf by a signed link from /api/getSertificate or /api/generateSertificate
+// @Tags courses
+// @Produce application/pdf
+// @Param name path string true "Sertificate file name"
+// @Param expires query int true "Link expiry"
+// @Param signature query string true "Link signature"
+// @Success 200 {file} file "Sertificate"
+// @Failure 403 {object} response.ErrorResponse "invalid signature or link expired"
+// @Failure 404 {object} response.ErrorResponse "sertificate not found"
+// @Failure 405 {object} response.ErrorResponse "method not allowed"
+// @Router /api/media/sertificates/{name} [get]
+func (h *Handler) ServeSertificate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		logs.PrintLog(r.Context(), "ServeSertificate", "method not allowed")
+		response.SendErrorResponse("method not allowed", http.StatusMethodNotAllowed, w, r)
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, "/api/media/sertificates/")
+	err := h.mediaSigner.Verify(mediasign.SertificateResource(name), r.URL.Query().Get("expires"), r.URL.Query().Get("signature"), time.Now())
+	if err != nil {
+		logs.PrintLog(r.Context(), "ServeSertificate", fmt.Sprintf("%s: %+v", name, err))
+		response.SendErrorResponse(err.Error(), http.StatusForbidden, w, r)
+		return
+	}
+
+	reader, meta, err := h.videoManager.GetSertificateFile(r.Context(), name)
+	if err != nil {
+		response.SendErrorResponse(err.Error(), http.StatusNotFound, w, r)
+		return
+	}
+	defer func() {
+		if err := reader.Close(); err != nil {
+			logs.PrintLog(r.Context(), "ServeSertificate", "failed to close reader")
+		}
+	}()
+
+	response.SendMediaFile(meta, reader, w, r)
+}
+
+// lessonBlocks - блоки урока из course-service, файлы блоков отдаются по подписанным ссылкам
+func (h *Handler) lessonBlocks(grpcBlocks []*coursepb.Block) []dto.LessonBlockDTO {
+	now := time.Now()
+	blocks := make([]dto.LessonBlockDTO, 0, len(grpcBlocks))
+	for _, grpcBlock := range grpcBlocks {
+		block := dto.LessonBlockDTO{
+			Type:        grpcBlock.Type,
+			Body:        grpcBlock.Body,
+			Text:        grpcBlock.Text,
+			FileName:    grpcBlock.FileName,
+			ContentType: grpcBlock.ContentType,
+			Size:        grpcBlock.Size,
+			Alt:         grpcBlock.Alt,
+			Caption:     grpcBlock.Caption,
+			Language:    grpcBlock.Language,
+			Code:        grpcBlock.Code,
+			Variant:     grpcBlock.Variant,
+			Provider:    grpcBlock.Provider,
+			VideoId:     grpcBlock.VideoId,
+			EmbedUrl:    grpcBlock.EmbedUrl,
+			Question:    grpcBlock.Question,
+			Options:     grpcBlock.Options,
+		}
+		if grpcBlock.Object != "" {
+			block.Url = h.mediaSigner.LessonFileURL(grpcBlock.Object, grpcBlock.FileName, now)
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+// grpcLessonBlocks - блоки урока из запроса автора, проверяются в course-service
+func grpcLessonBlocks(blocks []dto.LessonBlockDTO) []*coursepb.Block {
+	grpcBlocks := make([]*coursepb.Block, 0, len(blocks))
+	for _, block := range blocks {
+		grpcBlock := &coursepb.Block{
+			Type:     block.Type,
+			Text:     block.Text,
+			Object:   block.Object,
+			Alt:      block.Alt,
+			Caption:  block.Caption,
+			Language: block.Language,
+			Code:     block.Code,
+			Variant:  block.Variant,
+			Url:      block.Url,
+			Question: block.Question,
+			Options:  block.Options,
+		}
+		if block.Answer != nil {
+			answer := int32(*block.Answer)
+			grpcBlock.Answer = &answer
+		}
+		grpcBlocks = append(grpcBlocks, grpcBlock)
+	}
+	return grpcBlocks
+}
+
+// signSertificateUrl - course-service возвращает адрес объекта в MinIO, наружу отдаётся подписанная ссылка на него
+func (h *Handler) signSertificateUrl(sertificateUrl string) string {
+	if sertificateUrl == "" {
+		return ""
+	}
+	return h.mediaSigner.SertificateURL(path.Base(sertificateUrl), time.Now())
+}
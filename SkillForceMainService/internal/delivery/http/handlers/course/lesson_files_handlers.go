@@ -0,0 +1,250 @@
+package handlers
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	coursepb "skillForce/internal/delivery/grpc/proto/course"
+	"skillForce/internal/delivery/http/response"
+	"skillForce/internal/models/dto"
+	courseUsecase "skillForce/internal/usecase/course"
+	"skillForce/pkg/auth"
+	"skillForce/pkg/logs"
+	"skillForce/pkg/mediasign"
+	"strings"
+	"time"
+
+	"github.com/mailru/easyjson"
+	"google.golang.org/grpc/status"
+)
+
+// lessonFileErrors - ошибки загрузки файла урока, за которые отвечает клиент
+var lessonFileErrors = map[string]int{
+	"empty file":               http.StatusBadRequest,
+	"invalid file kind":        http.StatusBadRequest,
+	"file is too large":        http.StatusRequestEntityTooLarge,
+	"unsupported image format": http.StatusUnsupportedMediaType,
+}
+
+// UploadLessonFile godoc
+// @Summary Upload lesson image or attachment
+// @Description Uploads a file for an image or file block of a text lesson. The returned object is referenced from the block when the course is created
+// @Tags courses
+// @Accept multipart/form-data
+// @Produce json
+// @Param kind query string true "image (png, jpeg, gif, webp up to 10 MB) or file (up to 50 MB)"
+// @Param file formData file true "File"
+// @Success 200 {object} response.LessonFileResponse "Uploaded file"
+// @Failure 400 {object} response.ErrorResponse "invalid file kind or empty file"
+// @Failure 401 {object} response.ErrorResponse "not authorized"
+// @Failure 405 {object} response.ErrorResponse "method not allowed"
+// @Failure 413 {object} response.ErrorResponse "file is too large"
+// @Failure 415 {object} response.ErrorResponse "unsupported image format"
+// @Failure 500 {object} response.ErrorResponse "server error"
+// @Router /api/uploadLessonFile [post]
+func (h *Handler) UploadLessonFile(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		response.SendOKResponse(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		logs.PrintLog(r.Context(), "UploadLessonFile", "method not allowed")
+		response.SendErrorResponse("method not allowed", http.StatusMethodNotAllowed, w, r)
+		return
+	}
+
+	identity, ok := auth.IdentityFromContext(r.Context())
+	if !ok {
+		response.SendErrorResponse("not authorized", http.StatusUnauthorized, w, r)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, courseUsecase.MaxLessonFileSize+1<<20)
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		logs.PrintLog(r.Context(), "UploadLessonFile", fmt.Sprintf("%+v", err))
+		response.SendErrorResponse("file is too large or missing", http.StatusBadRequest, w, r)
+		return
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			logs.PrintLog(r.Context(), "UploadLessonFile", "failed to close file")
+		}
+	}()
+
+	data, err := io.ReadAll(io.LimitReader(file, courseUsecase.MaxLessonFileSize+1))
+	if err != nil {
+		logs.PrintLog(r.Context(), "UploadLessonFile", fmt.Sprintf("%+v", err))
+		response.SendErrorResponse("failed to read file", http.StatusBadRequest, w, r)
+		return
+	}
+
+	lessonFile, err := h.videoManager.UploadLessonFile(r.Context(), identity, r.URL.Query().Get("kind"), header.Filename, data)
+	if err != nil {
+		logs.PrintLog(r.Context(), "UploadLessonFile", fmt.Sprintf("%+v", err))
+		if code, ok := lessonFileErrors[err.Error()]; ok {
+			response.SendErrorResponse(err.Error(), code, w, r)
+			return
+		}
+		response.SendErrorResponse("server error", http.StatusInternalServerError, w, r)
+		return
+	}
+
+	response.SendLessonFile(lessonFile, w, r)
+}
+
+// ServeLessonFile godoc
+// @Summary Download lesson image or attachment
+// @Description Serves a file of a lesson block by a signed link from the lesson. Attachments are always downloaded
+// @Tags courses
+// @Param object path string true "Object name"
+// @Param expires query int true "Link expiry"
+// @Param signature query string true "Link signature"
+// @Param name query string false "File name for download"
+// @Success 200 {file} file "File"
+// @Failure 403 {object} response.ErrorResponse "invalid signature or link expired"
+// @Failure 404 {object} response.ErrorResponse "file not found"
+// @Failure 405 {object} response.ErrorResponse "method not allowed"
+// @Router /api/media/lesson-files/{object} [get]
+func (h *Handler) ServeLessonFile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		logs.PrintLog(r.Context(), "ServeLessonFile", "method not allowed")
+		response.SendErrorResponse("method not allowed", http.StatusMethodNotAllowed, w, r)
+		return
+	}
+
+	name := strings.TrimPrefix(r.URL.Path, "/api/media/lesson-files/")
+	err := h.mediaSigner.Verify(mediasign.LessonFileResource(name), r.URL.Query().Get("expires"), r.URL.Query().Get("signature"), time.Now())
+	if err != nil {
+		logs.PrintLog(r.Context(), "ServeLessonFile", fmt.Sprintf("%s: %+v", name, err))
+		response.SendErrorResponse(err.Error(), http.StatusForbidden, w, r)
+		return
+	}
+
+	reader, meta, err := h.videoManager.GetLessonFile(r.Context(), name)
+	if err != nil {
+		response.SendErrorResponse(err.Error(), http.StatusNotFound, w, r)
+		return
+	}
+	defer func() {
+		if err := reader.Close(); err != nil {
+			logs.PrintLog(r.Context(), "ServeLessonFile", "failed to close reader")
+		}
+	}()
+
+	// картинки показываются в уроке, всё остальное только скачивается, чтобы загруженный автором
+	// HTML или SVG не выполнился в контексте сайта
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if !strings.HasPrefix(meta.ContentType, "image/") || meta.ContentType == "image/svg+xml" {
+		meta.ContentType = "application/octet-stream"
+		disposition := mime.FormatMediaType("attachment", map[string]string{"filename": r.URL.Query().Get("name")})
+		if disposition == "" {
+			disposition = "attachment"
+		}
+		w.Header().Set("Content-Disposition", disposition)
+	}
+	response.SendMediaFile(meta, reader, w, r)
+}
+
+// AnswerBlockQuiz godoc
+// @Summary Answer a quiz block of a text lesson
+// @Description Checks the chosen option of a quiz block. Correct answers are never sent with the lesson
+// @Tags lessons
+// @Accept json
+// @Produce json
+// @Param answer body dto.AnswerBlockQuizRequest true "Lesson, block index and chosen option"
+// @Success 200 {object} response.Result "Whether the option is correct"
+// @Failure 400 {object} response.ErrorResponse "invalid request or option"
+// @Failure 401 {object} response.ErrorResponse "not authorized"
+// @Failure 403 {object} response.ErrorResponse "course is not purchased"
+// @Failure 404 {object} response.ErrorResponse "quiz not found"
+// @Failure 405 {object} response.ErrorResponse "method not allowed"
+// @Failure 500 {object} response.ErrorResponse "server error"
+// @Router /api/answerBlockQuiz [post]
+func (h *Handler) AnswerBlockQuiz(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		response.SendOKResponse(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		logs.PrintLog(r.Context(), "AnswerBlockQuiz", "method not allowed")
+		response.SendErrorResponse("method not allowed", http.StatusMethodNotAllowed, w, r)
+		return
+	}
+
+	var input dto.AnswerBlockQuizRequest
+	if err := easyjson.UnmarshalFromReader(r.Body, &input); err != nil {
+		logs.PrintLog(r.Context(), "AnswerBlockQuiz", fmt.Sprintf("%+v", err))
+		response.SendErrorResponse("invalid request", http.StatusBadRequest, w, r)
+		return
+	}
+
+	res, err := h.courseClient.AnswerBlockQuiz(r.Context(), &coursepb.AnswerBlockQuizRequest{
+		LessonId:   int32(input.LessonId),
+		BlockIndex: int32(input.BlockIndex),
+		Option:     int32(input.Option),
+	})
+	if err != nil {
+		logs.PrintLog(r.Context(), "AnswerBlockQuiz", fmt.Sprintf("%+v", err))
+		st, _ := status.FromError(err)
+		switch st.Message() {
+		case "invalid option":
+			response.SendErrorResponse(st.Message(), http.StatusBadRequest, w, r)
+		case "course is not purchased":
+			response.SendErrorResponse(st.Message(), http.StatusForbidden, w, r)
+		case "quiz not found", "lesson not found":
+			response.SendErrorResponse(st.Message(), http.StatusNotFound, w, r)
+		default:
+			response.SendErrorResponse("server error", http.StatusInternalServerError, w, r)
+		}
+		return
+	}
+
+	response.SendQuizResult(res.Correct, w, r)
+}
+
+// PreviewLessonBlocks godoc
+// @Summary Preview text lesson blocks
+// @Description Validates blocks and renders them exactly as learners will see them: markdown becomes sanitized HTML, files get signed links, quiz answers are hidden
+// @Tags courses
+// @Accept json
+// @Produce json
+// @Param blocks body dto.LessonBlocksDTO true "Lesson blocks"
+// @Success 200 {object} response.LessonBlocksResponse "Rendered blocks"
+// @Failure 400 {object} response.ErrorResponse "invalid lesson block"
+// @Failure 401 {object} response.ErrorResponse "not authorized"
+// @Failure 405 {object} response.ErrorResponse "method not allowed"
+// @Failure 500 {object} response.ErrorResponse "server error"
+// @Router /api/previewLessonBlocks [post]
+func (h *Handler) PreviewLessonBlocks(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		response.SendOKResponse(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		logs.PrintLog(r.Context(), "PreviewLessonBlocks", "method not allowed")
+		response.SendErrorResponse("method not allowed", http.StatusMethodNotAllowed, w, r)
+		return
+	}
+
+	var input dto.LessonBlocksDTO
+	if err := easyjson.UnmarshalFromReader(r.Body, &input); err != nil {
+		logs.PrintLog(r.Context(), "PreviewLessonBlocks", fmt.Sprintf("%+v", err))
+		response.SendErrorResponse("invalid request", http.StatusBadRequest, w, r)
+		return
+	}
+
+	res, err := h.courseClient.PreviewLessonBlocks(r.Context(), &coursepb.PreviewLessonBlocksRequest{Blocks: grpcLessonBlocks(input.Blocks)})
+	if err != nil {
+		logs.PrintLog(r.Context(), "PreviewLessonBlocks", fmt.Sprintf("%+v", err))
+		if st, ok := status.FromError(err); ok && strings.HasPrefix(st.Message(), "invalid lesson block") {
+			response.SendErrorResponse(st.Message(), http.StatusBadRequest, w, r)
+			return
+		}
+		response.SendErrorResponse("server error", http.StatusInternalServerError, w, r)
+		return
+	}
+
+	response.SendLessonBlocks(h.lessonBlocks(res.Blocks), w, r)
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	coursepb "skillForce/internal/delivery/grpc/proto/course"
+	"skillForce/internal/delivery/http/response"
+	"skillForce/internal/models/dto"
+	"skillForce/pkg/logs"
+
+	"github.com/mailru/easyjson"
+	"google.golang.org/grpc/status"
+)
+
+// SetCourseHidden godoc
+// @Summary Hide or restore a course
+// @Description Moderators hide a course from the catalog, search and digests with a reason or restore it. Buyers keep access to a hidden course
+// @Tags moderation
+// @Accept json
+// @Produce json
+// @Param course body dto.SetCourseHiddenRequest true "Course, hidden flag and reason"
+// @Success 200 {object} string "OK"
+// @Failure 400 {object} response.ErrorResponse "invalid request or hidden reason"
+// @Failure 401 {object} response.ErrorResponse "not authorized"
+// @Failure 403 {object} response.ErrorResponse "permission denied"
+// @Failure 404 {object} response.ErrorResponse "course not found"
+// @Failure 405 {object} response.ErrorResponse "method not allowed"
+// @Failure 500 {object} response.ErrorResponse "server error"
+// @Router /api/moderation/setCourseHidden [post]
+func (h *Handler) SetCourseHidden(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		response.SendOKResponse(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		logs.PrintLog(r.Context(), "SetCourseHidden", "method not allowed")
+		response.SendErrorResponse("method not allowed", http.StatusMethodNotAllowed, w, r)
+		return
+	}
+
+	var input dto.SetCourseHiddenRequest
+	if err := easyjson.UnmarshalFromReader(r.Body, &input); err != nil {
+		logs.PrintLog(r.Context(), "SetCourseHidden", fmt.Sprintf("%+v", err))
+		response.SendErrorResponse("invalid request", http.StatusBadRequest, w, r)
+		return
+	}
+
+	_, err := h.courseClient.SetCourseHidden(r.Context(), &coursepb.SetCourseHiddenRequest{
+		CourseId: int32(input.CourseId),
+		Hidden:   input.Hidden,
+		Reason:   input.Reason,
+	})
+	if err != nil {
+		logs.PrintLog(r.Context(), "SetCourseHidden", fmt.Sprintf("%+v", err))
+		st, _ := status.FromError(err)
+		switch st.Message() {
+		case "hidden reason is required", "hidden reason is too long":
+			response.SendErrorResponse(st.Message(), http.StatusBadRequest, w, r)
+		case "course not found":
+			response.SendErrorResponse(st.Message(), http.StatusNotFound, w, r)
+		default:
+			response.SendErrorResponse("server error", http.StatusInternalServerError, w, r)
+		}
+		return
+	}
+
+	response.SendOKResponse(w, r)
+}
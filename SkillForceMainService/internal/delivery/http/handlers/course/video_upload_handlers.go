@@ -0,0 +1,274 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"skillForce/internal/delivery/http/response"
+	"skillForce/internal/models/dto"
+	"skillForce/pkg/auth"
+	"skillForce/pkg/logs"
+	"strconv"
+
+	"github.com/mailru/easyjson"
+)
+
+// videoUploadErrorStatus - коды ответа для ошибок загрузки видео, остальные ошибки считаются ошибками сервера
+var videoUploadErrorStatus = map[string]int{
+	"unsupported content type":  http.StatusUnsupportedMediaType,
+	"invalid video size":        http.StatusBadRequest,
+	"invalid chunk number":      http.StatusBadRequest,
+	"invalid chunk size":        http.StatusBadRequest,
+	"lesson not found":          http.StatusNotFound,
+	"upload not found":          http.StatusNotFound,
+	"permission denied":         http.StatusForbidden,
+	"upload is not in progress": http.StatusConflict,
+	"upload is incomplete":      http.StatusConflict,
+	"video verification failed": http.StatusUnprocessableEntity,
+}
+
+func sendVideoUploadError(funcName string, err error, w http.ResponseWriter, r *http.Request) {
+	logs.PrintLog(r.Context(), funcName, fmt.Sprintf("%+v", err))
+	if status, ok := videoUploadErrorStatus[err.Error()]; ok {
+		response.SendErrorResponse(err.Error(), status, w, r)
+		return
+	}
+	response.SendErrorResponse("server error", http.StatusInternalServerError, w, r)
+}
+
+// InitVideoUpload godoc
+// @Summary Start video upload
+// @Description Starts resumable chunked upload of a video lesson. Only the course author can upload video. The file is sent in chunks of chunk_size bytes, the last chunk holds the rest
+// @Tags videos
+// @Accept json
+// @Produce json
+// @Param request body dto.InitVideoUploadDTO true "Lesson, content type (video/mp4 or video/webm) and size of the file"
+// @Success 200 {object} response.VideoUploadResponse "Upload state"
+// @Failure 400 {object} response.ErrorResponse "invalid request"
+// @Failure 401 {object} response.ErrorResponse "not authorized"
+// @Failure 403 {object} response.ErrorResponse "permission denied"
+// @Failure 404 {object} response.ErrorResponse "lesson not found"
+// @Failure 405 {object} response.ErrorResponse "method not allowed"
+// @Failure 415 {object} response.ErrorResponse "unsupported content type"
+// @Failure 500 {object} response.ErrorResponse "server error"
+// @Router /api/initVideoUpload [post]
+func (h *Handler) InitVideoUpload(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		response.SendOKResponse(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		logs.PrintLog(r.Context(), "InitVideoUpload", "method not allowed")
+		response.SendErrorResponse("method not allowed", http.StatusMethodNotAllowed, w, r)
+		return
+	}
+
+	identity, ok := auth.IdentityFromContext(r.Context())
+	if !ok {
+		response.SendErrorResponse("not authorized", http.StatusUnauthorized, w, r)
+		return
+	}
+
+	req := dto.InitVideoUploadDTO{}
+	if err := easyjson.UnmarshalFromReader(r.Body, &req); err != nil {
+		logs.PrintLog(r.Context(), "InitVideoUpload", fmt.Sprintf("%+v", err))
+		response.SendErrorResponse("invalid request", http.StatusBadRequest, w, r)
+		return
+	}
+
+	upload, err := h.videoManager.InitVideoUpload(r.Context(), identity, &req)
+	if err != nil {
+		sendVideoUploadError("InitVideoUpload", err, w, r)
+		return
+	}
+
+	logs.PrintLog(r.Context(), "InitVideoUpload", fmt.Sprintf("start upload %s", upload.UploadId))
+	response.SendVideoUpload(upload, w, r)
+}
+
+// UploadVideoChunk godoc
+// @Summary Upload video chunk
+// @Description Uploads one chunk of the video as a raw request body. Chunks are numbered from 1, uploading the same chunk again replaces it
+// @Tags videos
+// @Accept application/octet-stream
+// @Produce json
+// @Param upload_id query string true "Upload ID"
+// @Param chunk query int true "Chunk number"
+// @Success 200 {object} response.VideoUploadResponse "Upload state"
+// @Failure 400 {object} response.ErrorResponse "invalid chunk number or size"
+// @Failure 401 {object} response.ErrorResponse "not authorized"
+// @Failure 404 {object} response.ErrorResponse "upload not found"
+// @Failure 405 {object} response.ErrorResponse "method not allowed"
+// @Failure 409 {object} response.ErrorResponse "upload is not in progress"
+// @Failure 415 {object} response.ErrorResponse "unsupported content type"
+// @Failure 500 {object} response.ErrorResponse "server error"
+// @Router /api/uploadVideoChunk [put]
+func (h *Handler) UploadVideoChunk(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		response.SendOKResponse(w, r)
+		return
+	}
+	if r.Method != http.MethodPut {
+		logs.PrintLog(r.Context(), "UploadVideoChunk", "method not allowed")
+		response.SendErrorResponse("method not allowed", http.StatusMethodNotAllowed, w, r)
+		return
+	}
+
+	identity, ok := auth.IdentityFromContext(r.Context())
+	if !ok {
+		response.SendErrorResponse("not authorized", http.StatusUnauthorized, w, r)
+		return
+	}
+
+	uploadId := r.URL.Query().Get("upload_id")
+	chunk, err := strconv.Atoi(r.URL.Query().Get("chunk"))
+	if err != nil {
+		response.SendErrorResponse("invalid chunk number", http.StatusBadRequest, w, r)
+		return
+	}
+	// размер части сверяется до чтения тела, поэтому Content-Length обязателен
+	if r.ContentLength <= 0 {
+		response.SendErrorResponse("invalid chunk size", http.StatusBadRequest, w, r)
+		return
+	}
+
+	body := http.MaxBytesReader(w, r.Body, r.ContentLength)
+	upload, err := h.videoManager.UploadVideoChunk(r.Context(), identity.UserId, uploadId, chunk, body, r.ContentLength)
+	if err != nil {
+		sendVideoUploadError("UploadVideoChunk", err, w, r)
+		return
+	}
+
+	response.SendVideoUpload(upload, w, r)
+}
+
+// GetVideoUploadStatus godoc
+// @Summary Get video upload status
+// @Description Returns uploaded chunks so that an interrupted upload can be resumed
+// @Tags videos
+// @Produce json
+// @Param upload_id query string true "Upload ID"
+// @Success 200 {object} response.VideoUploadResponse "Upload state"
+// @Failure 401 {object} response.ErrorResponse "not authorized"
+// @Failure 404 {object} response.ErrorResponse "upload not found"
+// @Failure 405 {object} response.ErrorResponse "method not allowed"
+// @Failure 500 {object} response.ErrorResponse "server error"
+// @Router /api/getVideoUploadStatus [get]
+func (h *Handler) GetVideoUploadStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		logs.PrintLog(r.Context(), "GetVideoUploadStatus", "method not allowed")
+		response.SendErrorResponse("method not allowed", http.StatusMethodNotAllowed, w, r)
+		return
+	}
+
+	identity, ok := auth.IdentityFromContext(r.Context())
+	if !ok {
+		response.SendErrorResponse("not authorized", http.StatusUnauthorized, w, r)
+		return
+	}
+
+	upload, err := h.videoManager.GetVideoUploadStatus(r.Context(), identity.UserId, r.URL.Query().Get("upload_id"))
+	if err != nil {
+		sendVideoUploadError("GetVideoUploadStatus", err, w, r)
+		return
+	}
+
+	response.SendVideoUpload(upload, w, r)
+}
+
+// CompleteVideoUpload godoc
+// @Summary Complete video upload
+// @Description Assembles uploaded chunks, verifies the resulting file and attaches it to the lesson
+// @Tags videos
+// @Accept json
+// @Produce json
+// @Param request body dto.VideoUploadIDRequest true "Upload ID"
+// @Success 200 {object} response.VideoUploadResponse "Upload state with video_src"
+// @Failure 400 {object} response.ErrorResponse "invalid request"
+// @Failure 401 {object} response.ErrorResponse "not authorized"
+// @Failure 404 {object} response.ErrorResponse "upload not found"
+// @Failure 405 {object} response.ErrorResponse "method not allowed"
+// @Failure 409 {object} response.ErrorResponse "upload is incomplete"
+// @Failure 422 {object} response.ErrorResponse "video verification failed"
+// @Failure 500 {object} response.ErrorResponse "server error"
+// @Router /api/completeVideoUpload [post]
+func (h *Handler) CompleteVideoUpload(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		response.SendOKResponse(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		logs.PrintLog(r.Context(), "CompleteVideoUpload", "method not allowed")
+		response.SendErrorResponse("method not allowed", http.StatusMethodNotAllowed, w, r)
+		return
+	}
+
+	identity, ok := auth.IdentityFromContext(r.Context())
+	if !ok {
+		response.SendErrorResponse("not authorized", http.StatusUnauthorized, w, r)
+		return
+	}
+
+	req := dto.VideoUploadIDRequest{}
+	if err := easyjson.UnmarshalFromReader(r.Body, &req); err != nil {
+		logs.PrintLog(r.Context(), "CompleteVideoUpload", fmt.Sprintf("%+v", err))
+		response.SendErrorResponse("invalid request", http.StatusBadRequest, w, r)
+		return
+	}
+
+	upload, err := h.videoManager.CompleteVideoUpload(r.Context(), identity.UserId, req.UploadId)
+	if err != nil {
+		sendVideoUploadError("CompleteVideoUpload", err, w, r)
+		return
+	}
+
+	logs.PrintLog(r.Context(), "CompleteVideoUpload", fmt.Sprintf("video %s attached to lesson %d", upload.VideoSrc, upload.LessonId))
+	response.SendVideoUpload(upload, w, r)
+}
+
+// AbortVideoUpload godoc
+// @Summary Abort video upload
+// @Description Cancels the upload and removes uploaded chunks
+// @Tags videos
+// @Accept json
+// @Produce json
+// @Param request body dto.VideoUploadIDRequest true "Upload ID"
+// @Success 200 {string} string "200 OK"
+// @Failure 400 {object} response.ErrorResponse "invalid request"
+// @Failure 401 {object} response.ErrorResponse "not authorized"
+// @Failure 404 {object} response.ErrorResponse "upload not found"
+// @Failure 405 {object} response.ErrorResponse "method not allowed"
+// @Failure 409 {object} response.ErrorResponse "upload is not in progress"
+// @Failure 500 {object} response.ErrorResponse "server error"
+// @Router /api/abortVideoUpload [post]
+func (h *Handler) AbortVideoUpload(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		response.SendOKResponse(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		logs.PrintLog(r.Context(), "AbortVideoUpload", "method not allowed")
+		response.SendErrorResponse("method not allowed", http.StatusMethodNotAllowed, w, r)
+		return
+	}
+
+	identity, ok := auth.IdentityFromContext(r.Context())
+	if !ok {
+		response.SendErrorResponse("not authorized", http.StatusUnauthorized, w, r)
+		return
+	}
+
+	req := dto.VideoUploadIDRequest{}
+	if err := easyjson.UnmarshalFromReader(r.Body, &req); err != nil {
+		logs.PrintLog(r.Context(), "AbortVideoUpload", fmt.Sprintf("%+v", err))
+		response.SendErrorResponse("invalid request", http.StatusBadRequest, w, r)
+		return
+	}
+
+	if err := h.videoManager.AbortVideoUpload(r.Context(), identity.UserId, req.UploadId); err != nil {
+		sendVideoUploadError("AbortVideoUpload", err, w, r)
+		return
+	}
+
+	logs.PrintLog(r.Context(), "AbortVideoUpload", fmt.Sprintf("upload %s aborted", req.UploadId))
+	response.SendOKResponse(w, r)
+}
@@ -0,0 +1,293 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	userpb "skillForce/internal/delivery/grpc/proto/user"
+	"skillForce/internal/delivery/http/response"
+	"skillForce/internal/models/dto"
+	"skillForce/pkg/auth"
+	"skillForce/pkg/logs"
+	"strconv"
+	"time"
+
+	"github.com/mailru/easyjson"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// streamHeartbeat - комментарий в потоке, чтобы прокси не закрывали соединение без данных
+	streamHeartbeat = 25 * time.Second
+	// streamLifetime - поток закрывается, и браузер переподключается с проверкой сессии: после выхода
+	// из аккаунта уведомления не должны приходить в открытую вкладку
+	streamLifetime = 30 * time.Minute
+	// streamRetryMs - пауза перед переподключением EventSource
+	streamRetryMs = 3000
+	// streamPageSize - уведомления, пропущенные потоком, догружаются страницами
+	streamPageSize = 100
+)
+
+// GetNotifications godoc
+// @Summary Get in-app notifications
+// @Description Page of notifications of the authorized user from newest to oldest and the number of unread notifications. Kinds: course_signup, course_progress, certificate_ready, data_export_ready. Pass the id of the last notification on the page as before_id to get the next page
+// @Tags notifications
+// @Produce json
+// @Param before_id query int false "Only notifications older than this id"
+// @Param limit query int false "Page size, 20 by default, 100 at most"
+// @Success 200 {object} response.NotificationsResponse "Notifications"
+// @Failure 400 {object} response.ErrorResponse "invalid request"
+// @Failure 401 {object} response.ErrorResponse "not authorized"
+// @Failure 403 {object} response.ErrorResponse "permission denied"
+// @Failure 405 {object} response.ErrorResponse "method not allowed"
+// @Failure 500 {object} response.ErrorResponse "server error"
+// @Router /api/getNotifications [get]
+func (h *Handler) GetNotifications(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		logs.PrintLog(r.Context(), "GetNotifications", "method not allowed")
+		response.SendErrorResponse("method not allowed", http.StatusMethodNotAllowed, w, r)
+		return
+	}
+
+	beforeId, err := queryInt(r, "before_id")
+	if err != nil {
+		logs.PrintLog(r.Context(), "GetNotifications", "invalid before_id")
+		response.SendErrorResponse("invalid request", http.StatusBadRequest, w, r)
+		return
+	}
+	limit, err := queryInt(r, "limit")
+	if err != nil {
+		logs.PrintLog(r.Context(), "GetNotifications", "invalid limit")
+		response.SendErrorResponse("invalid request", http.StatusBadRequest, w, r)
+		return
+	}
+
+	resp, err := h.userClient.ListNotifications(r.Context(), &userpb.ListNotificationsRequest{BeforeId: beforeId, Limit: int32(limit)})
+	if err != nil {
+		logs.PrintLog(r.Context(), "GetNotifications", fmt.Sprintf("%+v", err))
+		response.SendErrorResponse("server error", http.StatusInternalServerError, w, r)
+		return
+	}
+
+	notifications := make([]*dto.NotificationDTO, 0, len(resp.Notifications))
+	for _, notification := range resp.Notifications {
+		notifications = append(notifications, toNotificationDTO(notification))
+	}
+	response.SendNotifications(notifications, int(resp.UnreadCount), w, r)
+}
+
+// MarkNotificationsRead godoc
+// @Summary Mark notifications as read
+// @Description Marks notifications with the given ids or, with all set, every notification of the authorized user as read. Returns the number of unread notifications left. Other open tabs receive the new count over /api/notifications/stream
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param request body dto.MarkNotificationsReadDTO true "Notifications to mark"
+// @Success 200 {object} response.UnreadNotificationCountResponse "Unread notifications left"
+// @Failure 400 {object} response.ErrorResponse "invalid request | no notifications to mark read"
+// @Failure 401 {object} response.ErrorResponse "not authorized"
+// @Failure 403 {object} response.ErrorResponse "permission denied"
+// @Failure 405 {object} response.ErrorResponse "method not allowed"
+// @Failure 500 {object} response.ErrorResponse "server error"
+// @Router /api/markNotificationsRead [post]
+func (h *Handler) MarkNotificationsRead(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		logs.PrintLog(r.Context(), "MarkNotificationsRead", "method not allowed")
+		response.SendErrorResponse("method not allowed", http.StatusMethodNotAllowed, w, r)
+		return
+	}
+
+	var input dto.MarkNotificationsReadDTO
+	if err := easyjson.UnmarshalFromReader(r.Body, &input); err != nil {
+		logs.PrintLog(r.Context(), "MarkNotificationsRead", fmt.Sprintf("%+v", err))
+		response.SendErrorResponse("invalid request", http.StatusBadRequest, w, r)
+		return
+	}
+
+	resp, err := h.userClient.MarkNotificationsRead(r.Context(), &userpb.MarkNotificationsReadRequest{Ids: input.Ids, All: input.All})
+	if err != nil {
+		logs.PrintLog(r.Context(), "MarkNotificationsRead", fmt.Sprintf("%+v", err))
+		if st, ok := status.FromError(err); ok && st.Message() == "no notifications to mark read" {
+			response.SendErrorResponse(st.Message(), http.StatusBadRequest, w, r)
+			return
+		}
+		response.SendErrorResponse("server error", http.StatusInternalServerError, w, r)
+		return
+	}
+	response.SendUnreadNotificationCount(int(resp.UnreadCount), w, r)
+}
+
+// GetUnreadNotificationCount godoc
+// @Summary Get the number of unread notifications
+// @Description Number of unread notifications of the authorized user, for the badge in the header
+// @Tags notifications
+// @Produce json
+// @Success 200 {object} response.UnreadNotificationCountResponse "Unread notifications"
+// @Failure 401 {object} response.ErrorResponse "not authorized"
+// @Failure 403 {object} response.ErrorResponse "permission denied"
+// @Failure 405 {object} response.ErrorResponse "method not allowed"
+// @Failure 500 {object} response.ErrorResponse "server error"
+// @Router /api/getUnreadNotificationCount [get]
+func (h *Handler) GetUnreadNotificationCount(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		logs.PrintLog(r.Context(), "GetUnreadNotificationCount", "method not allowed")
+		response.SendErrorResponse("method not allowed", http.StatusMethodNotAllowed, w, r)
+		return
+	}
+
+	resp, err := h.userClient.GetUnreadNotificationCount(r.Context(), &userpb.GetUnreadNotificationCountRequest{})
+	if err != nil {
+		logs.PrintLog(r.Context(), "GetUnreadNotificationCount", fmt.Sprintf("%+v", err))
+		response.SendErrorResponse("server error", http.StatusInternalServerError, w, r)
+		return
+	}
+	response.SendUnreadNotificationCount(int(resp.UnreadCount), w, r)
+}
+
+// NotificationStream godoc
+// @Summary Stream in-app notifications
+// @Description Server-Sent Events stream authorized by the session_id cookie. Each new notification is sent as an event "notification" with the notification id as the event id, after it and after marking notifications read comes an event "unread" with the unread count. After reconnecting EventSource sends Last-Event-ID and receives the notifications it missed. The stream is closed every 30 minutes, EventSource reconnects by itself
+// @Tags notifications
+// @Produce text/event-stream
+// @Param Last-Event-ID header int false "Id of the last received notification"
+// @Success 200 {string} string "Event stream"
+// @Failure 401 {object} response.ErrorResponse "not authorized"
+// @Failure 403 {object} response.ErrorResponse "permission denied"
+// @Failure 405 {object} response.ErrorResponse "method not allowed"
+// @Failure 500 {object} response.ErrorResponse "server error"
+// @Router /api/notifications/stream [get]
+func (h *Handler) NotificationStream(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		logs.PrintLog(r.Context(), "NotificationStream", "method not allowed")
+		response.SendErrorResponse("method not allowed", http.StatusMethodNotAllowed, w, r)
+		return
+	}
+	identity, _ := auth.IdentityFromContext(r.Context())
+
+	// подписка до чтения уведомлений, чтобы не пропустить созданные между чтением и подпиской
+	signals, cancel := h.hub.Subscribe(identity.UserId)
+	defer cancel()
+
+	// после переподключения поток продолжается с Last-Event-ID, новый поток - с последнего уведомления
+	lastId, err := strconv.ParseInt(r.Header.Get("Last-Event-ID"), 10, 64)
+	if err != nil || lastId < 0 {
+		resp, err := h.userClient.ListNotifications(r.Context(), &userpb.ListNotificationsRequest{Limit: 1})
+		if err != nil {
+			logs.PrintLog(r.Context(), "NotificationStream", fmt.Sprintf("%+v", err))
+			response.SendErrorResponse("server error", http.StatusInternalServerError, w, r)
+			return
+		}
+		lastId = 0
+		if len(resp.Notifications) > 0 {
+			lastId = resp.Notifications[0].Id
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	// nginx не должен буферизовать поток
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "retry: %d\n\n", streamRetryMs)
+
+	controller := http.NewResponseController(w)
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+	lifetime := time.NewTimer(streamLifetime)
+	defer lifetime.Stop()
+
+	logs.PrintLog(r.Context(), "NotificationStream", fmt.Sprintf("stream for user %d from notification %d", identity.UserId, lastId))
+	changed := true
+	for {
+		if changed {
+			if lastId, err = h.sendNewNotifications(r.Context(), w, lastId); err != nil {
+				logs.PrintLog(r.Context(), "NotificationStream", fmt.Sprintf("%+v", err))
+				return
+			}
+		}
+		if err := controller.Flush(); err != nil {
+			logs.PrintLog(r.Context(), "NotificationStream", fmt.Sprintf("%+v", err))
+			return
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-lifetime.C:
+			return
+		case <-heartbeat.C:
+			changed = false
+			if _, err := io.WriteString(w, ": ping\n\n"); err != nil {
+				return
+			}
+		case <-signals:
+			changed = true
+		}
+	}
+}
+
+// sendNewNotifications - отправка уведомлений новее lastId и числа непрочитанных. Возвращает id последнего отправленного
+func (h *Handler) sendNewNotifications(ctx context.Context, w io.Writer, lastId int64) (int64, error) {
+	for {
+		resp, err := h.userClient.ListNotifications(ctx, &userpb.ListNotificationsRequest{AfterId: lastId, Limit: streamPageSize})
+		if err != nil {
+			return lastId, err
+		}
+
+		for _, notification := range resp.Notifications {
+			data, err := easyjson.Marshal(toNotificationDTO(notification))
+			if err != nil {
+				return lastId, err
+			}
+			if err := writeEvent(w, strconv.FormatInt(notification.Id, 10), "notification", data); err != nil {
+				return lastId, err
+			}
+			lastId = notification.Id
+		}
+
+		if len(resp.Notifications) < streamPageSize {
+			data, err := easyjson.Marshal(response.UnreadNotificationCountResponse{UnreadCount: int(resp.UnreadCount)})
+			if err != nil {
+				return lastId, err
+			}
+			return lastId, writeEvent(w, "", "unread", data)
+		}
+	}
+}
+
+// writeEvent - событие Server-Sent Events. Пустой id не меняет Last-Event-ID браузера
+func writeEvent(w io.Writer, id string, event string, data []byte) error {
+	if id != "" {
+		if _, err := fmt.Fprintf(w, "id: %s\n", id); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+	return err
+}
+
+func toNotificationDTO(notification *userpb.Notification) *dto.NotificationDTO {
+	return &dto.NotificationDTO{
+		Id:         notification.Id,
+		Kind:       notification.Kind,
+		CourseId:   int(notification.CourseId),
+		CourseName: notification.CourseName,
+		Url:        notification.Url,
+		CreatedAt:  time.Unix(notification.CreatedAt, 0).UTC().Format(time.RFC3339),
+		IsRead:     notification.IsRead,
+	}
+}
+
+// queryInt - необязательный числовой параметр запроса, 0 если его нет
+func queryInt(r *http.Request, name string) (int64, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return 0, nil
+	}
+	number, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || number < 0 {
+		return 0, fmt.Errorf("invalid %s", name)
+	}
+	return number, nil
+}
@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	userpb "skillForce/internal/delivery/grpc/proto/user"
+	"skillForce/internal/delivery/http/response"
+	"skillForce/internal/models/dto"
+	"skillForce/pkg/logs"
+	"skillForce/pkg/notifyhub"
+	"skillForce/pkg/unsubscribe"
+
+	"github.com/mailru/easyjson"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// categoryNames - названия категорий писем на странице отписки
+var categoryNames = map[string]string{
+	"course_progress": "письма о прогрессе в курсах",
+	"marketing":       "письма с предложениями и напоминаниями о покупках",
+	"review_results":  "письма с результатами проверки заданий",
+	"digest":          "еженедельная сводка обучения",
+}
+
+// unsubscribePage - страница отписки по ссылке из письма. GET только показывает форму: почтовые сканеры
+// открывают ссылки из писем, и переход по ссылке не должен отписывать
+var unsubscribePage = template.Must(template.New("unsubscribe").Parse(`<!DOCTYPE html>
+<html lang="ru">
+<head><meta charset="UTF-8" /><title>Отписка от писем SkillForce</title></head>
+<body>
+{{ if .Done }}<p>Вы отписались: {{ .Category }} больше не будут приходить. Включить их снова можно в настройках профиля.</p>
+{{ else if .Error }}<p>{{ .Error }}</p>
+{{ else }}<form method="post" action="/api/unsubscribe">
+  <p>Отписаться: {{ .Category }}?</p>
+  <input type="hidden" name="token" value="{{ .Token }}" />
+  <button type="submit">Отписаться</button>
+</form>
+{{ end }}</body>
+</html>
+`))
+
+type unsubscribePageData struct {
+	Token    string
+	Category string
+	Done     bool
+	Error    string
+}
+
+type Handler struct {
+	userClient userpb.UserServiceClient
+	signer     *unsubscribe.Signer
+	hub        *notifyhub.Hub
+}
+
+// NewHandler - настройки уведомлений, отписка и уведомления в приложении. hub будит потоки уведомлений
+func NewHandler(signer *unsubscribe.Signer, hub *notifyhub.Hub, userDialOptions ...grpc.DialOption) *Handler {
+	userConn, err := grpc.NewClient("user-service:8081", userDialOptions...)
+	if err != nil {
+		log.Fatalf("failed to connect to user service: %v", err)
+	}
+	return &Handler{
+		userClient: userpb.NewUserServiceClient(userConn),
+		signer:     signer,
+		hub:        hub,
+	}
+}
+
+// NotificationPreferences godoc
+// @Summary Get or update notification preferences
+// @Description GET returns mail settings of the authorized user by category: transactional, course_progress, marketing, review_results. POST enables or disables categories, categories missing in the request are not changed. Transactional mails are always enabled
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param preferences body dto.NotificationPreferencesDTO false "Categories to change, POST only"
+// @Success 200 {object} response.NotificationPreferencesResponse "Notification preferences"
+// @Failure 400 {object} response.ErrorResponse "invalid request | invalid notification category | transactional mails can not be disabled"
+// @Failure 401 {object} response.ErrorResponse "not authorized"
+// @Failure 403 {object} response.ErrorResponse "permission denied"
+// @Failure 405 {object} response.ErrorResponse "method not allowed"
+// @Failure 500 {object} response.ErrorResponse "server error"
+// @Router /api/notificationPreferences [get]
+// @Router /api/notificationPreferences [post]
+func (h *Handler) NotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	var (
+		resp *userpb.NotificationPreferences
+		err  error
+	)
+	switch r.Method {
+	case http.MethodGet:
+		resp, err = h.userClient.GetNotificationPreferences(r.Context(), &userpb.GetNotificationPreferencesRequest{})
+	case http.MethodPost:
+		var input dto.NotificationPreferencesDTO
+		if err := easyjson.UnmarshalFromReader(r.Body, &input); err != nil {
+			logs.PrintLog(r.Context(), "NotificationPreferences", fmt.Sprintf("%+v", err))
+			response.SendErrorResponse("invalid request", http.StatusBadRequest, w, r)
+			return
+		}
+		req := &userpb.NotificationPreferences{}
+		for _, pref := range input.Preferences {
+			req.Preferences = append(req.Preferences, &userpb.NotificationPreference{Category: pref.Category, Enabled: pref.Enabled})
+		}
+		resp, err = h.userClient.UpdateNotificationPreferences(r.Context(), req)
+	default:
+		logs.PrintLog(r.Context(), "NotificationPreferences", "method not allowed")
+		response.SendErrorResponse("method not allowed", http.StatusMethodNotAllowed, w, r)
+		return
+	}
+	if err != nil {
+		logs.PrintLog(r.Context(), "NotificationPreferences", fmt.Sprintf("%+v", err))
+		if st, ok := status.FromError(err); ok && isPreferenceError(st.Message()) {
+			response.SendErrorResponse(st.Message(), http.StatusBadRequest, w, r)
+			return
+		}
+		response.SendErrorResponse("server error", http.StatusInternalServerError, w, r)
+		return
+	}
+
+	preferences := make([]*dto.NotificationPreferenceDTO, 0, len(resp.Preferences))
+	for _, pref := range resp.Preferences {
+		preferences = append(preferences, &dto.NotificationPreferenceDTO{Category: pref.Category, Enabled: pref.Enabled})
+	}
+	response.SendNotificationPreferences(preferences, w, r)
+}
+
+// Unsubscribe godoc
+// @Summary Unsubscribe from mail category
+// @Description Link from the List-Unsubscribe header and the footer of non-transactional mails. GET shows a confirmation form, POST unsubscribes. Mail clients send the POST themselves on one-click unsubscribe (RFC 8058). Authorization is not required, the user and category are taken from the signed token
+// @Tags users
+// @Accept x-www-form-urlencoded
+// @Produce html
+// @Param token query string true "Signed unsubscribe token"
+// @Success 200 {string} string "HTML page"
+// @Failure 400 {string} string "invalid unsubscribe link"
+// @Failure 405 {string} string "method not allowed"
+// @Failure 500 {string} string "server error"
+// @Router /api/unsubscribe [get]
+// @Router /api/unsubscribe [post]
+func (h *Handler) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		logs.PrintLog(r.Context(), "Unsubscribe", "method not allowed")
+		sendUnsubscribePage(w, http.StatusMethodNotAllowed, unsubscribePageData{Error: "Метод не поддерживается"})
+		return
+	}
+
+	// токен приходит в адресе ссылки или в форме страницы подтверждения
+	token := r.FormValue("token")
+	userId, category, err := h.signer.Parse(token)
+	name, known := categoryNames[category]
+	if err != nil || !known {
+		logs.PrintLog(r.Context(), "Unsubscribe", fmt.Sprintf("invalid token: %v", err))
+		sendUnsubscribePage(w, http.StatusBadRequest, unsubscribePageData{Error: "Ссылка для отписки недействительна"})
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		sendUnsubscribePage(w, http.StatusOK, unsubscribePageData{Token: token, Category: name})
+		return
+	}
+
+	_, err = h.userClient.Unsubscribe(r.Context(), &userpb.UnsubscribeRequest{UserId: int32(userId), Category: category})
+	if err != nil {
+		logs.PrintLog(r.Context(), "Unsubscribe", fmt.Sprintf("%+v", err))
+		sendUnsubscribePage(w, http.StatusInternalServerError, unsubscribePageData{Error: "Не удалось отписаться, попробуйте позже"})
+		return
+	}
+
+	logs.PrintLog(r.Context(), "Unsubscribe", fmt.Sprintf("user %d unsubscribed from %s", userId, category))
+	sendUnsubscribePage(w, http.StatusOK, unsubscribePageData{Category: name, Done: true})
+}
+
+func isPreferenceError(message string) bool {
+	return message == "invalid notification category" || message == "transactional mails can not be disabled"
+}
+
+func sendUnsubscribePage(w http.ResponseWriter, code int, data unsubscribePageData) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(code)
+	if err := unsubscribePage.Execute(w, data); err != nil {
+		log.Printf("failed to render unsubscribe page: %v", err)
+	}
+}
@@ -0,0 +1,156 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"path"
+	coursepb "skillForce/internal/delivery/grpc/proto/course"
+	userpb "skillForce/internal/delivery/grpc/proto/user"
+	"skillForce/internal/delivery/http/response"
+	"skillForce/internal/models/dto"
+	"skillForce/pkg/avatar"
+	"skillForce/pkg/logs"
+	"skillForce/pkg/mediasign"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+type Handler struct {
+	userClient   userpb.UserServiceClient
+	courseClient coursepb.CourseServiceClient
+	mediaSigner  *mediasign.Signer
+}
+
+func NewHandler(mediaSigner *mediasign.Signer, userDialOptions []grpc.DialOption, courseDialOptions []grpc.DialOption) *Handler {
+	userConn, err := grpc.NewClient("user-service:8081", userDialOptions...)
+	if err != nil {
+		log.Fatalf("failed to connect to user service: %v", err)
+	}
+	courseConn, err := grpc.NewClient("course-service:8082", courseDialOptions...)
+	if err != nil {
+		log.Fatalf("failed to connect to course service: %v", err)
+	}
+
+	return &Handler{
+		userClient:   userpb.NewUserServiceClient(userConn),
+		courseClient: coursepb.NewCourseServiceClient(courseConn),
+		mediaSigner:  mediaSigner,
+	}
+}
+
+func mapCourse(course *coursepb.CourseDTO) *dto.CourseDTO {
+	return &dto.CourseDTO{
+		Id:              int(course.Id),
+		Price:           int(course.Price),
+		PurchasesAmount: int(course.PurchasesAmount),
+		CreatorId:       int(course.CreatorId),
+		TimeToPass:      int(course.TimeToPass),
+		Rating:          course.Rating,
+		Tags:            course.Tags,
+		Title:           course.Title,
+		Description:     course.Description,
+		ScrImage:        course.ScrImage,
+		IsCompleted:     course.IsCompleted,
+	}
+}
+
+// GetUserProfile godoc
+// @Summary Get public user profile
+// @Description Returns public profile of the user: email (if not hidden), bio, avatar, authored courses, completed courses with sertificates, rating positions and author statistics
+// @Tags users
+// @Produce json
+// @Param user_id query int true "User ID"
+// @Success 200 {object} response.PublicProfileResponse "Public profile"
+// @Failure 400 {object} response.ErrorResponse "invalid user id"
+// @Failure 404 {object} response.ErrorResponse "user not found"
+// @Failure 405 {object} response.ErrorResponse "method not allowed"
+// @Failure 500 {object} response.ErrorResponse "server error"
+// @Router /api/getUserProfile [get]
+func (h *Handler) GetUserProfile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		logs.PrintLog(r.Context(), "GetUserProfile", "method not allowed")
+		response.SendErrorResponse("method not allowed", http.StatusMethodNotAllowed, w, r)
+		return
+	}
+
+	userId, err := strconv.Atoi(r.URL.Query().Get("user_id"))
+	if err != nil || userId <= 0 {
+		logs.PrintLog(r.Context(), "GetUserProfile", "invalid user id")
+		response.SendErrorResponse("invalid user id", http.StatusBadRequest, w, r)
+		return
+	}
+
+	userProfile, err := h.userClient.GetPublicProfile(r.Context(), &userpb.GetPublicProfileRequest{UserId: int32(userId)})
+	if err != nil {
+		logs.PrintLog(r.Context(), "GetUserProfile", fmt.Sprintf("%+v", err))
+		if st, ok := status.FromError(err); ok && st.Message() == "user not found" {
+			response.SendErrorResponse("user not found", http.StatusNotFound, w, r)
+			return
+		}
+		response.SendErrorResponse("server error", http.StatusInternalServerError, w, r)
+		return
+	}
+
+	summary, err := h.courseClient.GetUserCoursesSummary(r.Context(), &coursepb.GetUserCoursesSummaryRequest{UserId: int32(userId)})
+	if err != nil {
+		logs.PrintLog(r.Context(), "GetUserProfile", fmt.Sprintf("%+v", err))
+		response.SendErrorResponse("server error", http.StatusInternalServerError, w, r)
+		return
+	}
+
+	profile := &dto.PublicProfileDTO{
+		Id:               int(userProfile.Id),
+		Name:             userProfile.Name,
+		Email:            userProfile.Email,
+		Bio:              userProfile.Bio,
+		AvatarSrc:        userProfile.AvatarSrc,
+		AvatarSizes:      avatar.Thumbnails(userProfile.AvatarSrc),
+		Roles:            userProfile.Roles,
+		AuthoredCourses:  make([]*dto.CourseDTO, 0, len(summary.AuthoredCourses)),
+		CompletedCourses: make([]*dto.CompletedCourseDTO, 0, len(summary.CompletedCourses)),
+		RatingPositions:  make([]*dto.RatingPositionDTO, 0, len(summary.RatingPositions)),
+	}
+
+	for _, course := range summary.AuthoredCourses {
+		profile.AuthoredCourses = append(profile.AuthoredCourses, mapCourse(course))
+	}
+
+	for _, completed := range summary.CompletedCourses {
+		profile.CompletedCourses = append(profile.CompletedCourses, &dto.CompletedCourseDTO{
+			Course:         mapCourse(completed.Course),
+			SertificateUrl: h.signSertificateUrl(completed.SertificateUrl),
+		})
+	}
+
+	for _, position := range summary.RatingPositions {
+		profile.RatingPositions = append(profile.RatingPositions, &dto.RatingPositionDTO{
+			CourseId:    int(position.CourseId),
+			CourseTitle: position.CourseTitle,
+			Position:    int(position.Position),
+			Score:       int(position.Score),
+		})
+	}
+
+	if summary.AuthorStats != nil {
+		profile.AuthorStats = &dto.AuthorStatsDTO{
+			CoursesAmount:  int(summary.AuthorStats.CoursesAmount),
+			StudentsAmount: int(summary.AuthorStats.StudentsAmount),
+			Rating:         summary.AuthorStats.Rating,
+		}
+	}
+
+	logs.PrintLog(r.Context(), "GetUserProfile", fmt.Sprintf("send public profile of user %d", userId))
+	response.SendPublicProfile(profile, w, r)
+}
+
+// signSertificateUrl - бакет сертификатов закрыт, в профиле отдаётся подписанная ссылка, как и в курсах
+func (h *Handler) signSertificateUrl(sertificateUrl string) string {
+	if sertificateUrl == "" {
+		return ""
+	}
+	return h.mediaSigner.SertificateURL(path.Base(sertificateUrl), time.Now())
+}
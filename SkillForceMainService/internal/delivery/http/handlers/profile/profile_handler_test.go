@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	coursepb "skillForce/internal/delivery/grpc/proto/course"
+	userpb "skillForce/internal/delivery/grpc/proto/user"
+	"skillForce/internal/delivery/http/response"
+	"skillForce/pkg/logs"
+	"skillForce/pkg/mediasign"
+
+	"google.golang.org/grpc"
+)
+
+type fakeUserClient struct {
+	userpb.UserServiceClient
+	profile *userpb.UserProfile
+}
+
+func (c *fakeUserClient) GetPublicProfile(ctx context.Context, in *userpb.GetPublicProfileRequest, opts ...grpc.CallOption) (*userpb.UserProfile, error) {
+	return c.profile, nil
+}
+
+type fakeCourseClient struct {
+	coursepb.CourseServiceClient
+	summary *coursepb.GetUserCoursesSummaryResponse
+}
+
+func (c *fakeCourseClient) GetUserCoursesSummary(ctx context.Context, in *coursepb.GetUserCoursesSummaryRequest, opts ...grpc.CallOption) (*coursepb.GetUserCoursesSummaryResponse, error) {
+	return c.summary, nil
+}
+
+func TestGetUserProfile_SignsSertificateUrls(t *testing.T) {
+	signer, err := mediasign.NewSigner("secret", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := &Handler{
+		userClient: &fakeUserClient{profile: &userpb.UserProfile{Id: 7, Name: "Alice"}},
+		courseClient: &fakeCourseClient{summary: &coursepb.GetUserCoursesSummaryResponse{
+			CompletedCourses: []*coursepb.CompletedCourse{
+				{Course: &coursepb.CourseDTO{Id: 1, Title: "Go"}, SertificateUrl: "http://minio:9000/sertificates/7_1.pdf"},
+				{Course: &coursepb.CourseDTO{Id: 2, Title: "SQL"}},
+			},
+		}},
+		mediaSigner: signer,
+	}
+
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+	req := httptest.NewRequest(http.MethodGet, "/api/getUserProfile?user_id=7", nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	h.GetUserProfile(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", rec.Code, rec.Body.String())
+	}
+
+	var resp response.PublicProfileResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	completed := resp.Profile.CompletedCourses
+	if len(completed) != 2 {
+		t.Fatalf("completed courses = %d, want 2", len(completed))
+	}
+
+	// адрес объекта в MinIO наружу не отдаётся, только подписанная ссылка
+	link, err := url.Parse(completed[0].SertificateUrl)
+	if err != nil {
+		t.Fatalf("parse sertificate url: %v", err)
+	}
+	if strings.Contains(completed[0].SertificateUrl, "minio") || link.Path != "/api/media/sertificates/7_1.pdf" {
+		t.Fatalf("sertificate url = %q, want signed media link", completed[0].SertificateUrl)
+	}
+	query := link.Query()
+	if err := signer.Verify(mediasign.SertificateResource("7_1.pdf"), query.Get("expires"), query.Get("signature"), time.Now()); err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+
+	if completed[1].SertificateUrl != "" {
+		t.Fatalf("course without sertificate got url %q", completed[1].SertificateUrl)
+	}
+}
@@ -11,12 +11,13 @@ import (
 	"skillForce/internal/delivery/http/response"
 	"skillForce/internal/models/dto"
 	models "skillForce/internal/models/user"
+	"skillForce/pkg/avatar"
 	"skillForce/pkg/logs"
+	"strconv"
 
 	"github.com/badoux/checkmail"
 	"github.com/mailru/easyjson"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/status"
 )
 
@@ -32,8 +33,8 @@ type Handler struct {
 	cookieManager CookieManagerInterface
 }
 
-func NewHandler(cookieManager CookieManagerInterface) *Handler {
-	conn, err := grpc.NewClient("user-service:8081", grpc.WithTransportCredentials(insecure.NewCredentials()))
+func NewHandler(cookieManager CookieManagerInterface, dialOptions ...grpc.DialOption) *Handler {
+	conn, err := grpc.NewClient("user-service:8081", dialOptions...)
 	if err != nil {
 		log.Fatalf("failed to connect to user service: %v", err)
 	}
@@ -86,6 +87,7 @@ func isValidLoginFields(user *dto.UserDTO) error {
 // @Failure 400 {object} response.ErrorResponse "invalid request | missing required fields | password too short | invalid email"
 // @Failure 404 {object} response.ErrorResponse "email exists"
 // @Failure 405 {object} response.ErrorResponse "method not allowed"
+// @Failure 409 {object} response.ErrorResponse "registration pending"
 // @Failure 500 {object} response.ErrorResponse "server error"
 // @Router /api/register [post]
 func (h *Handler) RegisterUser(w http.ResponseWriter, r *http.Request) {
@@ -118,10 +120,17 @@ func (h *Handler) RegisterUser(w http.ResponseWriter, r *http.Request) {
 	_, err = h.userClient.ValidUser(r.Context(), grpcUser)
 
 	if err != nil {
-		if errors.Is(err, errors.New("email exists")) {
+		st, _ := status.FromError(err)
+		switch st.Message() {
+		case "email exists":
 			logs.PrintLog(r.Context(), "RegisterUser", "email exists")
 			response.SendErrorResponse("email exists", http.StatusNotFound, w, r)
 			return
+		case "registration pending":
+			// письмо по действующей заявке отправляется повторно через /api/resendConfirmation
+			logs.PrintLog(r.Context(), "RegisterUser", "registration pending")
+			response.SendErrorResponse("registration pending", http.StatusConflict, w, r)
+			return
 		}
 		logs.PrintLog(r.Context(), "RegisterUser", fmt.Sprintf("gRPC error: %+v", err))
 		response.SendErrorResponse("server error", http.StatusInternalServerError, w, r)
@@ -164,12 +173,13 @@ func (h *Handler) ConfirmUserEmail(w http.ResponseWriter, r *http.Request) {
 	}
 	registerResp, err := h.userClient.RegisterUser(r.Context(), grpcToken)
 	if err != nil {
-		if errors.Is(err, errors.New("invalid token")) {
+		st, _ := status.FromError(err)
+		if st.Message() == "invalid token" {
 			logs.PrintLog(r.Context(), "ConfirmUserEmail", fmt.Sprintf("%+v", err))
 			response.SendErrorResponse("invalid token", http.StatusBadRequest, w, r)
 			return
 		}
-		if errors.Is(err, errors.New("email exists")) {
+		if st.Message() == "email exists" {
 			logs.PrintLog(r.Context(), "ConfirmUserEmail", fmt.Sprintf("%+v", err))
 			response.SendErrorResponse("email exists", http.StatusNotFound, w, r)
 			return
@@ -183,6 +193,70 @@ func (h *Handler) ConfirmUserEmail(w http.ResponseWriter, r *http.Request) {
 	response.SendOKResponse(w, r)
 }
 
+// ResendConfirmation godoc
+// @Summary Resend email confirmation
+// @Description Sends a new confirmation link to the email of a pending registration
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param user body dto.UserDTO true "User email"
+// @Success 200 {string} string "200 OK"
+// @Failure 400 {object} response.ErrorResponse "invalid request | invalid email"
+// @Failure 404 {object} response.ErrorResponse "registration not found"
+// @Failure 405 {object} response.ErrorResponse "method not allowed"
+// @Failure 429 {object} response.ErrorResponse "resend too early | resend limit exceeded"
+// @Failure 500 {object} response.ErrorResponse "server error"
+// @Router /api/resendConfirmation [post]
+func (h *Handler) ResendConfirmation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		logs.PrintLog(r.Context(), "ResendConfirmation", "method not allowed")
+		response.SendErrorResponse("method not allowed", http.StatusMethodNotAllowed, w, r)
+		return
+	}
+
+	var userInput dto.UserDTO
+	if err := easyjson.UnmarshalFromReader(r.Body, &userInput); err != nil {
+		logs.PrintLog(r.Context(), "ResendConfirmation", fmt.Sprintf("%+v", err))
+		response.SendErrorResponse("invalid request", http.StatusBadRequest, w, r)
+		return
+	}
+
+	err := checkmail.ValidateFormat(userInput.Email)
+	if err != nil {
+		logs.PrintLog(r.Context(), "ResendConfirmation", fmt.Sprintf("%+v", err))
+		response.SendErrorResponse("invalid email", http.StatusBadRequest, w, r)
+		return
+	}
+
+	_, err = h.userClient.ResendConfirmation(r.Context(), &userpb.ResendConfirmationRequest{Email: userInput.Email})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if !ok {
+			logs.PrintLog(r.Context(), "ResendConfirmation", fmt.Sprintf("Not grpc error: %+v", err))
+			response.SendErrorResponse("server error", http.StatusInternalServerError, w, r)
+			return
+		}
+
+		switch st.Message() {
+		case "registration not found":
+			logs.PrintLog(r.Context(), "ResendConfirmation", fmt.Sprintf("%+v", err))
+			response.SendErrorResponse(st.Message(), http.StatusNotFound, w, r)
+			return
+		case "resend too early", "resend limit exceeded":
+			logs.PrintLog(r.Context(), "ResendConfirmation", fmt.Sprintf("%+v", err))
+			response.SendErrorResponse(st.Message(), http.StatusTooManyRequests, w, r)
+			return
+		}
+
+		logs.PrintLog(r.Context(), "ResendConfirmation", fmt.Sprintf("%+v", err))
+		response.SendErrorResponse("server error", http.StatusInternalServerError, w, r)
+		return
+	}
+
+	logs.PrintLog(r.Context(), "ResendConfirmation", fmt.Sprintf("resend email to confirm user %+v", userInput.Email))
+	response.SendOKResponse(w, r)
+}
+
 // LoginUser godoc
 // @Summary Login user
 // @Description Login user with the given email, password and send cookie
@@ -283,12 +357,16 @@ func (h *Handler) IsAuthorized(w http.ResponseWriter, r *http.Request) {
 	userProfile := h.cookieManager.CheckCookie(r)
 	if userProfile != nil {
 		userProfileOut := dto.UserProfileDTO{
-			Name:      userProfile.Name,
-			Email:     userProfile.Email,
-			Bio:       userProfile.Bio,
-			AvatarSrc: userProfile.AvatarSrc,
-			HideEmail: userProfile.HideEmail,
-			IsAdmin:   userProfile.IsAdmin,
+			Name:        userProfile.Name,
+			Email:       userProfile.Email,
+			Bio:         userProfile.Bio,
+			AvatarSrc:   userProfile.AvatarSrc,
+			AvatarSizes: avatar.Thumbnails(userProfile.AvatarSrc),
+			HideEmail:   userProfile.HideEmail,
+			IsAdmin:     userProfile.IsAdmin,
+			Roles:       userProfile.Roles,
+			Locale:      userProfile.Locale,
+			Timezone:    userProfile.Timezone,
 		}
 
 		logs.PrintLog(r.Context(), "IsAuthorized", fmt.Sprintf("user %+v is authorized", userProfile))
@@ -336,6 +414,8 @@ func (h *Handler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 		Email:     UserProfileInput.Email,
 		AvatarSrc: UserProfileInput.AvatarSrc,
 		HideEmail: UserProfileInput.HideEmail,
+		Locale:    UserProfileInput.Locale,
+		Timezone:  UserProfileInput.Timezone,
 	}
 
 	grpcUpdateProfileRequest := &userpb.UpdateProfileRequest{
@@ -357,6 +437,8 @@ func (h *Handler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 		AvatarSrc: grpcNewUserProfile.AvatarSrc,
 		HideEmail: grpcNewUserProfile.HideEmail,
 		IsAdmin:   grpcNewUserProfile.IsAdmin,
+		Locale:    grpcNewUserProfile.Locale,
+		Timezone:  grpcNewUserProfile.Timezone,
 	}
 
 	logs.PrintLog(r.Context(), "UpdateProfile", fmt.Sprintf("user %+v updated profile with values %+v", userProfile, newUserProfile))
@@ -364,18 +446,30 @@ func (h *Handler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 	response.SendOKResponse(w, r)
 }
 
+// avatarMaxSize - ограничение на размер файла аватарки, такое же как в user-service
+const avatarMaxSize = 10 << 20
+
+// avatarErrors - ошибки проверки аватарки в user-service, о которых нужно сказать пользователю
+var avatarErrors = map[string]bool{
+	"unsupported image format": true,
+	"invalid image":            true,
+	"image is too large":       true,
+}
+
 // UpdateProfilePhoto godoc
 // @Summary Update user profile photo
-// @Description Updates the profile photo of the authorized user
+// @Description Updates the profile photo of the authorized user. JPEG, PNG and GIF up to 10 MB are accepted,
+// @Description the format is detected by content. The photo is cropped to a square, EXIF is removed
+// @Description and 64, 128 and 256 px thumbnails are returned.
 // @Tags users
-// @Accept json
+// @Accept multipart/form-data
 // @Produce json
-// @Param profile body usermodels.UserProfile true "Updated user profile"
-// @Success 200 {string} string "200 OK"
-// @Failure 400 {object} response.ErrorResponse "invalid request"
+// @Param avatar formData file true "Profile photo"
+// @Success 200 {object} response.PhotoUrlResponse
+// @Failure 400 {object} response.ErrorResponse "unsupported image format, invalid image or image is too large"
 // @Failure 401 {object} response.ErrorResponse "not authorized"
 // @Failure 500 {object} response.ErrorResponse "server error"
-// @Router /api/updateProfile [post]
+// @Router /api/updateProfilePhoto [post]
 func (h *Handler) UpdateProfilePhoto(w http.ResponseWriter, r *http.Request) {
 	if r.Method == http.MethodGet {
 		response.SendOKResponse(w, r)
@@ -388,7 +482,9 @@ func (h *Handler) UpdateProfilePhoto(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := r.ParseMultipartForm(10 << 20) // 10 MB
+	// запас сверху на заголовки multipart
+	r.Body = http.MaxBytesReader(w, r.Body, avatarMaxSize+1<<20)
+	err := r.ParseMultipartForm(avatarMaxSize)
 	if err != nil {
 		logs.PrintLog(r.Context(), "UpdateProfilePhoto", fmt.Sprintf("%+v", err))
 		response.SendErrorResponse("photo is too big", http.StatusBadRequest, w, r)
@@ -422,6 +518,10 @@ func (h *Handler) UpdateProfilePhoto(w http.ResponseWriter, r *http.Request) {
 	grpcUploadFileResp, err := h.userClient.UploadFile(r.Context(), grpcUploadFileRequest)
 	if err != nil {
 		logs.PrintLog(r.Context(), "UpdateProfilePhoto", fmt.Sprintf("%+v", err))
+		if st, ok := status.FromError(err); ok && avatarErrors[st.Message()] {
+			response.SendErrorResponse(st.Message(), http.StatusBadRequest, w, r)
+			return
+		}
 		response.SendErrorResponse("server error", http.StatusInternalServerError, w, r)
 		return
 	}
@@ -439,7 +539,7 @@ func (h *Handler) UpdateProfilePhoto(w http.ResponseWriter, r *http.Request) {
 	}
 
 	logs.PrintLog(r.Context(), "UpdateProfilePhoto", fmt.Sprintf("Файл загружен: %s", grpcSaveProfilePhotoResp.NewPhtotoUrl))
-	response.SendPhotoUrl(grpcSaveProfilePhotoResp.NewPhtotoUrl, w, r)
+	response.SendPhotoUrl(grpcSaveProfilePhotoResp.NewPhtotoUrl, avatar.Thumbnails(grpcSaveProfilePhotoResp.NewPhtotoUrl), w, r)
 }
 
 // DeleteProfilePhoto godoc
@@ -480,3 +580,152 @@ func (h *Handler) DeleteProfilePhoto(w http.ResponseWriter, r *http.Request) {
 	logs.PrintLog(r.Context(), "DeleteProfilePhoto", fmt.Sprintf("Аватарка пользователя %+v заменена на стандартную", userProfile.Id))
 	response.SendOKResponse(w, r)
 }
+
+// writeRoleError - перевод ошибок сервиса пользователей при работе с ролями в HTTP ответ
+func writeRoleError(funcName string, err error, w http.ResponseWriter, r *http.Request) {
+	logs.PrintLog(r.Context(), funcName, fmt.Sprintf("%+v", err))
+	st, ok := status.FromError(err)
+	if !ok {
+		response.SendErrorResponse("server error", http.StatusInternalServerError, w, r)
+		return
+	}
+
+	switch st.Message() {
+	case "invalid role", "cannot revoke own admin role":
+		response.SendErrorResponse(st.Message(), http.StatusBadRequest, w, r)
+	case "user not found", "role not found":
+		response.SendErrorResponse(st.Message(), http.StatusNotFound, w, r)
+	case "permission denied":
+		response.SendErrorResponse(st.Message(), http.StatusForbidden, w, r)
+	default:
+		response.SendErrorResponse("server error", http.StatusInternalServerError, w, r)
+	}
+}
+
+// GetUserRoles godoc
+// @Summary Get user roles
+// @Description Returns roles granted to the user. Available to admins only
+// @Tags admin
+// @Produce json
+// @Param user_id query int true "User ID"
+// @Success 200 {object} response.RolesResponse "User roles"
+// @Failure 400 {object} response.ErrorResponse "invalid user id"
+// @Failure 401 {object} response.ErrorResponse "not authorized"
+// @Failure 403 {object} response.ErrorResponse "permission denied"
+// @Failure 405 {object} response.ErrorResponse "method not allowed"
+// @Failure 500 {object} response.ErrorResponse "server error"
+// @Router /api/admin/getUserRoles [get]
+func (h *Handler) GetUserRoles(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		logs.PrintLog(r.Context(), "GetUserRoles", "method not allowed")
+		response.SendErrorResponse("method not allowed", http.StatusMethodNotAllowed, w, r)
+		return
+	}
+
+	userId, err := strconv.Atoi(r.URL.Query().Get("user_id"))
+	if err != nil || userId <= 0 {
+		logs.PrintLog(r.Context(), "GetUserRoles", "invalid user id")
+		response.SendErrorResponse("invalid user id", http.StatusBadRequest, w, r)
+		return
+	}
+
+	resp, err := h.userClient.GetUserRoles(r.Context(), &userpb.GetUserRolesRequest{UserId: int32(userId)})
+	if err != nil {
+		writeRoleError("GetUserRoles", err, w, r)
+		return
+	}
+
+	response.SendRoles(resp.Roles, w, r)
+}
+
+// GrantRole godoc
+// @Summary Grant role
+// @Description Grants role (learner, author, moderator, admin) to the user. Available to admins only
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param role body dto.RoleDTO true "User and role"
+// @Success 200 {string} string "200 OK"
+// @Failure 400 {object} response.ErrorResponse "invalid request | invalid role"
+// @Failure 401 {object} response.ErrorResponse "not authorized"
+// @Failure 403 {object} response.ErrorResponse "permission denied"
+// @Failure 404 {object} response.ErrorResponse "user not found"
+// @Failure 405 {object} response.ErrorResponse "method not allowed"
+// @Failure 500 {object} response.ErrorResponse "server error"
+// @Router /api/admin/grantRole [post]
+func (h *Handler) GrantRole(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		response.SendOKResponse(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		logs.PrintLog(r.Context(), "GrantRole", "method not allowed")
+		response.SendErrorResponse("method not allowed", http.StatusMethodNotAllowed, w, r)
+		return
+	}
+
+	var roleInput dto.RoleDTO
+	if err := easyjson.UnmarshalFromReader(r.Body, &roleInput); err != nil {
+		logs.PrintLog(r.Context(), "GrantRole", fmt.Sprintf("%+v", err))
+		response.SendErrorResponse("invalid request", http.StatusBadRequest, w, r)
+		return
+	}
+
+	_, err := h.userClient.GrantRole(r.Context(), &userpb.RoleRequest{
+		UserId: int32(roleInput.UserId),
+		Role:   roleInput.Role,
+	})
+	if err != nil {
+		writeRoleError("GrantRole", err, w, r)
+		return
+	}
+
+	logs.PrintLog(r.Context(), "GrantRole", fmt.Sprintf("role %+v granted to user %+v", roleInput.Role, roleInput.UserId))
+	response.SendOKResponse(w, r)
+}
+
+// RevokeRole godoc
+// @Summary Revoke role
+// @Description Revokes role from the user. Available to admins only
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param role body dto.RoleDTO true "User and role"
+// @Success 200 {string} string "200 OK"
+// @Failure 400 {object} response.ErrorResponse "invalid request | invalid role | cannot revoke own admin role"
+// @Failure 401 {object} response.ErrorResponse "not authorized"
+// @Failure 403 {object} response.ErrorResponse "permission denied"
+// @Failure 404 {object} response.ErrorResponse "role not found"
+// @Failure 405 {object} response.ErrorResponse "method not allowed"
+// @Failure 500 {object} response.ErrorResponse "server error"
+// @Router /api/admin/revokeRole [post]
+func (h *Handler) RevokeRole(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		response.SendOKResponse(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		logs.PrintLog(r.Context(), "RevokeRole", "method not allowed")
+		response.SendErrorResponse("method not allowed", http.StatusMethodNotAllowed, w, r)
+		return
+	}
+
+	var roleInput dto.RoleDTO
+	if err := easyjson.UnmarshalFromReader(r.Body, &roleInput); err != nil {
+		logs.PrintLog(r.Context(), "RevokeRole", fmt.Sprintf("%+v", err))
+		response.SendErrorResponse("invalid request", http.StatusBadRequest, w, r)
+		return
+	}
+
+	_, err := h.userClient.RevokeRole(r.Context(), &userpb.RoleRequest{
+		UserId: int32(roleInput.UserId),
+		Role:   roleInput.Role,
+	})
+	if err != nil {
+		writeRoleError("RevokeRole", err, w, r)
+		return
+	}
+
+	logs.PrintLog(r.Context(), "RevokeRole", fmt.Sprintf("role %+v revoked from user %+v", roleInput.Role, roleInput.UserId))
+	response.SendOKResponse(w, r)
+}
@@ -0,0 +1,49 @@
+package localstorage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"skillForce/internal/delivery/http/media"
+	"skillForce/internal/delivery/http/response"
+	"skillForce/internal/models/dto"
+	"skillForce/pkg/storage"
+	"strings"
+)
+
+// Handler - отдача объектов локального хранилища (storage.backend: local) по адресам storage.PublicURL
+// вида <prefix><бакет>/<объект>. Отдаются только открытые бакеты buckets: видео, сертификаты, файлы уроков
+// и архивы курсов по-прежнему доступны только по подписанным ссылкам /api/...
+func Handler(store storage.Storage, prefix string, buckets ...string) http.Handler {
+	public := make(map[string]bool, len(buckets))
+	for _, bucket := range buckets {
+		public[bucket] = true
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bucket, name, ok := strings.Cut(strings.TrimPrefix(r.URL.Path, prefix), "/")
+		if !ok || name == "" || !public[bucket] {
+			response.SendErrorResponse("not found", http.StatusNotFound, w, r)
+			return
+		}
+		media.Serve(w, r, &bucketSource{store: store, bucket: bucket}, name)
+	})
+}
+
+// bucketSource - объекты одного бакета как media.Source
+type bucketSource struct {
+	store  storage.Storage
+	bucket string
+}
+
+func (s *bucketSource) GetMeta(ctx context.Context, name string) (dto.VideoMeta, error) {
+	info, err := s.store.Stat(ctx, s.bucket, name)
+	if err != nil {
+		return dto.VideoMeta{}, err
+	}
+	return dto.VideoMeta{Name: info.Name, Size: info.Size, ContentType: info.ContentType, ETag: info.ETag, LastModified: info.LastModified}, nil
+}
+
+func (s *bucketSource) GetFragment(ctx context.Context, name string, start, end int64) (io.ReadCloser, error) {
+	return s.store.GetRange(ctx, s.bucket, name, start, end)
+}
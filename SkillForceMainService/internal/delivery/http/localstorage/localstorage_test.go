@@ -0,0 +1,56 @@
+package localstorage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"skillForce/pkg/logs"
+	"skillForce/pkg/storage"
+	"strings"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	ctx := context.Background()
+	store, err := storage.New(storage.Config{Backend: storage.BackendLocal, LocalDir: t.TempDir(), PublicURL: "https://skill-force.ru/storage"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, object := range []struct{ bucket, name, data string }{
+		{bucket: "avatars", name: "u1/256.jpg", data: "jpeg"},
+		{bucket: "videos", name: "lesson.mp4", data: "mp4"},
+	} {
+		if err := store.Put(ctx, object.bucket, object.name, strings.NewReader(object.data), int64(len(object.data)), storage.PutOptions{ContentType: "image/jpeg"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	handler := Handler(store, "/storage/", "avatars", "exports")
+
+	tests := []struct {
+		name string
+		path string
+		want int
+		body string
+	}{
+		{name: "public object", path: "/storage/avatars/u1/256.jpg", want: http.StatusOK, body: "jpeg"},
+		{name: "missing object", path: "/storage/avatars/u2/256.jpg", want: http.StatusNotFound},
+		{name: "paid video", path: "/storage/videos/lesson.mp4", want: http.StatusNotFound},
+		{name: "escape from bucket", path: "/storage/avatars/../videos/lesson.mp4", want: http.StatusNotFound},
+		{name: "bucket only", path: "/storage/avatars", want: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.URL.Path = tt.path
+			req = req.WithContext(context.WithValue(req.Context(), logs.LogsKey, &logs.CtxLog{}))
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.want)
+			}
+			if tt.body != "" && (rec.Body.String() != tt.body || rec.Header().Get("Content-Type") != "image/jpeg") {
+				t.Fatalf("body %q, content type %q", rec.Body.String(), rec.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
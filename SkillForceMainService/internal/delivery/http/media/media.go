@@ -0,0 +1,318 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"skillForce/internal/delivery/http/response"
+	"skillForce/internal/models/dto"
+	"skillForce/pkg/logs"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxRanges - ограничение на число диапазонов в одном запросе, чтобы multipart ответ
+// из тысяч мелких кусков не превращался в тысячи запросов к хранилищу
+const maxRanges = 32
+
+// Source - файл, который отдаётся по частям. Реализуется VideoManagerInterface
+type Source interface {
+	GetMeta(ctx context.Context, name string) (dto.VideoMeta, error)
+	GetFragment(ctx context.Context, name string, start, end int64) (io.ReadCloser, error)
+}
+
+// httpRange - диапазон байт [start, start+length)
+type httpRange struct {
+	start  int64
+	length int64
+}
+
+func (r httpRange) end() int64 {
+	return r.start + r.length - 1
+}
+
+func (r httpRange) contentRange(size int64) string {
+	return fmt.Sprintf("bytes %d-%d/%d", r.start, r.end(), size)
+}
+
+var (
+	errInvalidRange       = errors.New("invalid range")
+	errUnsatisfiableRange = errors.New("range not satisfiable")
+)
+
+// Serve - отдача файла по RFC 7232 и RFC 7233: GET и HEAD, одиночные, суффиксные и множественные
+// (multipart/byteranges) диапазоны, If-Range, If-None-Match и If-Modified-Since
+func Serve(w http.ResponseWriter, r *http.Request, source Source, name string) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		response.SendErrorResponse("method not allowed", http.StatusMethodNotAllowed, w, r)
+		return
+	}
+
+	meta, err := source.GetMeta(r.Context(), name)
+	if err != nil {
+		logs.PrintLog(r.Context(), "Serve", fmt.Sprintf("%s: %+v", name, err))
+		response.SendErrorResponse("video not found", http.StatusNotFound, w, r)
+		return
+	}
+
+	etag := ""
+	if meta.ETag != "" {
+		etag = `"` + strings.Trim(meta.ETag, `"`) + `"`
+		w.Header().Set("ETag", etag)
+	}
+	if !meta.LastModified.IsZero() {
+		w.Header().Set("Last-Modified", meta.LastModified.UTC().Format(http.TimeFormat))
+	}
+	w.Header().Set("Accept-Ranges", "bytes")
+
+	if notModified(r, etag, meta.LastModified) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	contentType := meta.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	// Range учитывается только для GET, только в байтах и только если If-Range совпадает с текущей версией файла
+	var ranges []httpRange
+	rangeHeader := r.Header.Get("Range")
+	if strings.HasPrefix(rangeHeader, "bytes=") && r.Method == http.MethodGet && ifRangeMatches(r, etag, meta.LastModified) {
+		ranges, err = parseRange(rangeHeader, meta.Size)
+		if err != nil {
+			logs.PrintLog(r.Context(), "Serve", fmt.Sprintf("%s: %q: %+v", name, rangeHeader, err))
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", meta.Size))
+			response.SendErrorResponse(errUnsatisfiableRange.Error(), http.StatusRequestedRangeNotSatisfiable, w, r)
+			return
+		}
+		// диапазоны, которые в сумме больше файла, выгоднее отдать одним ответом
+		if sumRanges(ranges) > meta.Size {
+			ranges = nil
+		}
+	}
+
+	switch len(ranges) {
+	case 0:
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Length", strconv.FormatInt(meta.Size, 10))
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodHead || meta.Size == 0 {
+			return
+		}
+		copyRange(w, r, source, name, httpRange{start: 0, length: meta.Size})
+	case 1:
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Range", ranges[0].contentRange(meta.Size))
+		w.Header().Set("Content-Length", strconv.FormatInt(ranges[0].length, 10))
+		w.WriteHeader(http.StatusPartialContent)
+		copyRange(w, r, source, name, ranges[0])
+	default:
+		serveMultipart(w, r, source, name, ranges, contentType, meta.Size)
+	}
+}
+
+func serveMultipart(w http.ResponseWriter, r *http.Request, source Source, name string, ranges []httpRange, contentType string, size int64) {
+	mw := multipart.NewWriter(w)
+
+	// длина ответа считается заранее тем же multipart writer с той же границей
+	counter := &countingWriter{}
+	cw := multipart.NewWriter(counter)
+	if err := cw.SetBoundary(mw.Boundary()); err != nil {
+		response.SendErrorResponse("server error", http.StatusInternalServerError, w, r)
+		return
+	}
+	for _, ra := range ranges {
+		if _, err := cw.CreatePart(partHeader(ra, contentType, size)); err != nil {
+			response.SendErrorResponse("server error", http.StatusInternalServerError, w, r)
+			return
+		}
+		counter.n += ra.length
+	}
+	if err := cw.Close(); err != nil {
+		response.SendErrorResponse("server error", http.StatusInternalServerError, w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "multipart/byteranges; boundary="+mw.Boundary())
+	w.Header().Set("Content-Length", strconv.FormatInt(counter.n, 10))
+	w.WriteHeader(http.StatusPartialContent)
+
+	for _, ra := range ranges {
+		part, err := mw.CreatePart(partHeader(ra, contentType, size))
+		if err != nil {
+			logs.PrintLog(r.Context(), "Serve", fmt.Sprintf("%+v", err))
+			return
+		}
+		if !copyRange(part, r, source, name, ra) {
+			return
+		}
+	}
+	if err := mw.Close(); err != nil {
+		logs.PrintLog(r.Context(), "Serve", fmt.Sprintf("%+v", err))
+	}
+}
+
+func partHeader(ra httpRange, contentType string, size int64) textproto.MIMEHeader {
+	return textproto.MIMEHeader{
+		"Content-Type":  {contentType},
+		"Content-Range": {ra.contentRange(size)},
+	}
+}
+
+// copyRange - копирование диапазона из хранилища. Заголовки к этому моменту уже отправлены,
+// поэтому ошибка только логируется и обрывает ответ
+func copyRange(w io.Writer, r *http.Request, source Source, name string, ra httpRange) bool {
+	reader, err := source.GetFragment(r.Context(), name, ra.start, ra.end())
+	if err != nil {
+		logs.PrintLog(r.Context(), "Serve", fmt.Sprintf("%s: %+v", name, err))
+		return false
+	}
+	defer func() {
+		if err := reader.Close(); err != nil {
+			logs.PrintLog(r.Context(), "Serve", "failed to close reader")
+		}
+	}()
+
+	if _, err := io.CopyN(w, reader, ra.length); err != nil {
+		logs.PrintLog(r.Context(), "Serve", fmt.Sprintf("%s: %+v", name, err))
+		return false
+	}
+	return true
+}
+
+// parseRange - разбор заголовка Range. Диапазоны за пределами файла отбрасываются,
+// если не осталось ни одного, возвращается errUnsatisfiableRange
+func parseRange(header string, size int64) ([]httpRange, error) {
+	const prefix = "bytes="
+	if !strings.HasPrefix(header, prefix) {
+		return nil, errInvalidRange
+	}
+
+	specs := strings.Split(header[len(prefix):], ",")
+	if len(specs) > maxRanges {
+		return nil, errInvalidRange
+	}
+
+	ranges := make([]httpRange, 0, len(specs))
+	for _, spec := range specs {
+		spec = strings.TrimSpace(spec)
+		first, last, ok := strings.Cut(spec, "-")
+		if !ok {
+			return nil, errInvalidRange
+		}
+		first, last = strings.TrimSpace(first), strings.TrimSpace(last)
+
+		if first == "" {
+			// суффиксный диапазон: последние n байт
+			n, err := parseOffset(last)
+			if err != nil {
+				return nil, err
+			}
+			if n == 0 || size == 0 {
+				continue
+			}
+			if n > size {
+				n = size
+			}
+			ranges = append(ranges, httpRange{start: size - n, length: n})
+			continue
+		}
+
+		start, err := parseOffset(first)
+		if err != nil {
+			return nil, err
+		}
+		end := size - 1
+		if last != "" {
+			end, err = parseOffset(last)
+			if err != nil {
+				return nil, err
+			}
+			if end < start {
+				return nil, errInvalidRange
+			}
+			if end >= size {
+				end = size - 1
+			}
+		}
+		if start >= size {
+			continue
+		}
+		ranges = append(ranges, httpRange{start: start, length: end - start + 1})
+	}
+
+	if len(ranges) == 0 {
+		return nil, errUnsatisfiableRange
+	}
+	return ranges, nil
+}
+
+func parseOffset(s string) (int64, error) {
+	if s == "" || strings.TrimLeft(s, "0123456789") != "" {
+		return 0, errInvalidRange
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, errInvalidRange
+	}
+	return n, nil
+}
+
+func sumRanges(ranges []httpRange) int64 {
+	var sum int64
+	for _, ra := range ranges {
+		sum += ra.length
+	}
+	return sum
+}
+
+// notModified - If-None-Match сравнивается слабым сравнением, If-Modified-Since учитывается только без If-None-Match
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		if etag == "" {
+			return false
+		}
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimSpace(candidate)
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" && !lastModified.IsZero() {
+		t, err := http.ParseTime(ims)
+		return err == nil && !lastModified.Truncate(time.Second).After(t)
+	}
+	return false
+}
+
+// ifRangeMatches - If-Range с ETag требует строгого совпадения, с датой - точного совпадения Last-Modified
+func ifRangeMatches(r *http.Request, etag string, lastModified time.Time) bool {
+	ifRange := strings.TrimSpace(r.Header.Get("If-Range"))
+	if ifRange == "" {
+		return true
+	}
+	if strings.HasPrefix(ifRange, `"`) || strings.HasPrefix(ifRange, "W/") {
+		return etag != "" && !strings.HasPrefix(ifRange, "W/") && ifRange == etag
+	}
+	t, err := http.ParseTime(ifRange)
+	return err == nil && !lastModified.IsZero() && lastModified.Truncate(time.Second).Equal(t)
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	c.n += int64(len(p))
+	return len(p), nil
+}
@@ -0,0 +1,210 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"skillForce/internal/models/dto"
+	"skillForce/pkg/logs"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const content = "0123456789abcdefghij"
+
+var modified = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+type fakeSource struct {
+	data string
+	meta dto.VideoMeta
+	err  error
+}
+
+func newFakeSource() *fakeSource {
+	return &fakeSource{
+		data: content,
+		meta: dto.VideoMeta{Name: "video.mp4", Size: int64(len(content)), ContentType: "video/mp4", ETag: "abc", LastModified: modified},
+	}
+}
+
+func (f *fakeSource) GetMeta(ctx context.Context, name string) (dto.VideoMeta, error) {
+	return f.meta, f.err
+}
+
+func (f *fakeSource) GetFragment(ctx context.Context, name string, start, end int64) (io.ReadCloser, error) {
+	if start < 0 || end >= int64(len(f.data)) || start > end {
+		return nil, errors.New("invalid fragment")
+	}
+	return io.NopCloser(bytes.NewReader([]byte(f.data[start : end+1]))), nil
+}
+
+func serve(t *testing.T, source Source, method string, headers map[string]string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, "/api/video", nil)
+	req = req.WithContext(context.WithValue(req.Context(), logs.LogsKey, &logs.CtxLog{}))
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	Serve(rec, req, source, "video.mp4")
+	return rec
+}
+
+func TestServe(t *testing.T) {
+	lastModified := modified.Format(http.TimeFormat)
+
+	tests := []struct {
+		name         string
+		method       string
+		headers      map[string]string
+		wantStatus   int
+		wantBody     string
+		wantRange    string
+		wantLength   string
+		wantNoBodyOK bool
+	}{
+		{name: "full", method: http.MethodGet, wantStatus: http.StatusOK, wantBody: content, wantLength: "20"},
+		{name: "head", method: http.MethodHead, wantStatus: http.StatusOK, wantLength: "20", wantNoBodyOK: true},
+		{name: "head ignores range", method: http.MethodHead, headers: map[string]string{"Range": "bytes=0-4"}, wantStatus: http.StatusOK, wantLength: "20", wantNoBodyOK: true},
+		{name: "single", method: http.MethodGet, headers: map[string]string{"Range": "bytes=2-5"}, wantStatus: http.StatusPartialContent, wantBody: "2345", wantRange: "bytes 2-5/20", wantLength: "4"},
+		{name: "open ended", method: http.MethodGet, headers: map[string]string{"Range": "bytes=15-"}, wantStatus: http.StatusPartialContent, wantBody: "fghij", wantRange: "bytes 15-19/20"},
+		{name: "suffix", method: http.MethodGet, headers: map[string]string{"Range": "bytes=-3"}, wantStatus: http.StatusPartialContent, wantBody: "hij", wantRange: "bytes 17-19/20"},
+		{name: "suffix longer than file", method: http.MethodGet, headers: map[string]string{"Range": "bytes=-500"}, wantStatus: http.StatusPartialContent, wantBody: content, wantRange: "bytes 0-19/20"},
+		{name: "end clamped", method: http.MethodGet, headers: map[string]string{"Range": "bytes=18-100"}, wantStatus: http.StatusPartialContent, wantBody: "ij", wantRange: "bytes 18-19/20"},
+		{name: "start beyond size", method: http.MethodGet, headers: map[string]string{"Range": "bytes=20-"}, wantStatus: http.StatusRequestedRangeNotSatisfiable, wantRange: "bytes */20"},
+		{name: "zero suffix", method: http.MethodGet, headers: map[string]string{"Range": "bytes=-0"}, wantStatus: http.StatusRequestedRangeNotSatisfiable, wantRange: "bytes */20"},
+		{name: "not a number", method: http.MethodGet, headers: map[string]string{"Range": "bytes=a-5"}, wantStatus: http.StatusRequestedRangeNotSatisfiable, wantRange: "bytes */20"},
+		{name: "negative", method: http.MethodGet, headers: map[string]string{"Range": "bytes=-5-6"}, wantStatus: http.StatusRequestedRangeNotSatisfiable, wantRange: "bytes */20"},
+		{name: "end before start", method: http.MethodGet, headers: map[string]string{"Range": "bytes=5-2"}, wantStatus: http.StatusRequestedRangeNotSatisfiable, wantRange: "bytes */20"},
+		{name: "no dash", method: http.MethodGet, headers: map[string]string{"Range": "bytes=5"}, wantStatus: http.StatusRequestedRangeNotSatisfiable, wantRange: "bytes */20"},
+		{name: "unknown unit ignored", method: http.MethodGet, headers: map[string]string{"Range": "items=0-1"}, wantStatus: http.StatusOK, wantBody: content},
+		{name: "satisfiable part of multi range", method: http.MethodGet, headers: map[string]string{"Range": "bytes=30-40, 0-1"}, wantStatus: http.StatusPartialContent, wantBody: "01", wantRange: "bytes 0-1/20"},
+		{name: "ranges larger than file", method: http.MethodGet, headers: map[string]string{"Range": "bytes=0-15, 5-19"}, wantStatus: http.StatusOK, wantBody: content},
+		{name: "if-range etag matches", method: http.MethodGet, headers: map[string]string{"Range": "bytes=0-1", "If-Range": `"abc"`}, wantStatus: http.StatusPartialContent, wantBody: "01", wantRange: "bytes 0-1/20"},
+		{name: "if-range etag changed", method: http.MethodGet, headers: map[string]string{"Range": "bytes=0-1", "If-Range": `"old"`}, wantStatus: http.StatusOK, wantBody: content},
+		{name: "if-range weak etag", method: http.MethodGet, headers: map[string]string{"Range": "bytes=0-1", "If-Range": `W/"abc"`}, wantStatus: http.StatusOK, wantBody: content},
+		{name: "if-range date matches", method: http.MethodGet, headers: map[string]string{"Range": "bytes=0-1", "If-Range": lastModified}, wantStatus: http.StatusPartialContent, wantBody: "01", wantRange: "bytes 0-1/20"},
+		{name: "if-range date changed", method: http.MethodGet, headers: map[string]string{"Range": "bytes=0-1", "If-Range": modified.Add(-time.Hour).Format(http.TimeFormat)}, wantStatus: http.StatusOK, wantBody: content},
+		{name: "if-none-match", method: http.MethodGet, headers: map[string]string{"If-None-Match": `"abc"`}, wantStatus: http.StatusNotModified},
+		{name: "if-none-match list", method: http.MethodGet, headers: map[string]string{"If-None-Match": `"x", W/"abc"`}, wantStatus: http.StatusNotModified},
+		{name: "if-none-match star", method: http.MethodHead, headers: map[string]string{"If-None-Match": "*"}, wantStatus: http.StatusNotModified},
+		{name: "if-none-match changed", method: http.MethodGet, headers: map[string]string{"If-None-Match": `"old"`}, wantStatus: http.StatusOK, wantBody: content},
+		{name: "if-none-match wins over range", method: http.MethodGet, headers: map[string]string{"If-None-Match": `"abc"`, "Range": "bytes=0-1"}, wantStatus: http.StatusNotModified},
+		{name: "if-modified-since", method: http.MethodGet, headers: map[string]string{"If-Modified-Since": lastModified}, wantStatus: http.StatusNotModified},
+		{name: "if-modified-since older", method: http.MethodGet, headers: map[string]string{"If-Modified-Since": modified.Add(-time.Hour).Format(http.TimeFormat)}, wantStatus: http.StatusOK, wantBody: content},
+		{name: "method not allowed", method: http.MethodPost, wantStatus: http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(t, newFakeSource(), tt.method, tt.headers)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantBody != "" && rec.Body.String() != tt.wantBody {
+				t.Errorf("body = %q, want %q", rec.Body.String(), tt.wantBody)
+			}
+			if tt.wantNoBodyOK && rec.Body.Len() != 0 {
+				t.Errorf("body = %q, want empty", rec.Body.String())
+			}
+			if got := rec.Header().Get("Content-Range"); got != tt.wantRange {
+				t.Errorf("Content-Range = %q, want %q", got, tt.wantRange)
+			}
+			if tt.wantLength != "" && rec.Header().Get("Content-Length") != tt.wantLength {
+				t.Errorf("Content-Length = %q, want %q", rec.Header().Get("Content-Length"), tt.wantLength)
+			}
+			if tt.wantStatus != http.StatusMethodNotAllowed {
+				if got := rec.Header().Get("ETag"); got != `"abc"` {
+					t.Errorf("ETag = %q", got)
+				}
+				if got := rec.Header().Get("Last-Modified"); got != lastModified {
+					t.Errorf("Last-Modified = %q", got)
+				}
+			}
+		})
+	}
+}
+
+func TestServeMultipart(t *testing.T) {
+	rec := serve(t, newFakeSource(), http.MethodGet, map[string]string{"Range": "bytes=0-1, 5-7, -2"})
+
+	if rec.Code != http.StatusPartialContent {
+		t.Fatalf("status = %d, want 206", rec.Code)
+	}
+	if got := rec.Header().Get("Content-Length"); got != strconv.Itoa(rec.Body.Len()) {
+		t.Errorf("Content-Length = %s, body length = %d", got, rec.Body.Len())
+	}
+
+	mediaType, params, err := mime.ParseMediaType(rec.Header().Get("Content-Type"))
+	if err != nil || mediaType != "multipart/byteranges" {
+		t.Fatalf("Content-Type = %q", rec.Header().Get("Content-Type"))
+	}
+
+	want := []struct {
+		contentRange string
+		body         string
+	}{
+		{"bytes 0-1/20", "01"},
+		{"bytes 5-7/20", "567"},
+		{"bytes 18-19/20", "ij"},
+	}
+	reader := multipart.NewReader(rec.Body, params["boundary"])
+	for i, w := range want {
+		part, err := reader.NextPart()
+		if err != nil {
+			t.Fatalf("part %d: %v", i, err)
+		}
+		body, _ := io.ReadAll(part)
+		if part.Header.Get("Content-Range") != w.contentRange || string(body) != w.body {
+			t.Errorf("part %d = %q %q, want %q %q", i, part.Header.Get("Content-Range"), body, w.contentRange, w.body)
+		}
+		if part.Header.Get("Content-Type") != "video/mp4" {
+			t.Errorf("part %d Content-Type = %q", i, part.Header.Get("Content-Type"))
+		}
+	}
+	if _, err := reader.NextPart(); err != io.EOF {
+		t.Errorf("expected end of multipart body, got %v", err)
+	}
+}
+
+func TestServeNotFound(t *testing.T) {
+	source := newFakeSource()
+	source.err = errors.New("no such key")
+
+	rec := serve(t, source, http.MethodGet, nil)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want 404", rec.Code)
+	}
+}
+
+func TestServeEmptyFile(t *testing.T) {
+	source := newFakeSource()
+	source.data = ""
+	source.meta.Size = 0
+
+	rec := serve(t, source, http.MethodGet, nil)
+	if rec.Code != http.StatusOK || rec.Body.Len() != 0 {
+		t.Fatalf("status = %d, body = %q", rec.Code, rec.Body.String())
+	}
+
+	rec = serve(t, source, http.MethodGet, map[string]string{"Range": "bytes=0-"})
+	if rec.Code != http.StatusRequestedRangeNotSatisfiable {
+		t.Fatalf("status = %d, want 416", rec.Code)
+	}
+}
+
+func TestParseRangeTooManyRanges(t *testing.T) {
+	header := "bytes=0-0"
+	for i := 1; i <= maxRanges; i++ {
+		header += "," + strconv.Itoa(i) + "-" + strconv.Itoa(i)
+	}
+	if _, err := parseRange(header, 100); err == nil {
+		t.Error("parseRange() expected error for too many ranges")
+	}
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"net/http"
+	"skillForce/internal/delivery/http/cookie"
+	"skillForce/internal/delivery/http/response"
+	models "skillForce/internal/models/user"
+	"skillForce/pkg/auth"
+	"skillForce/pkg/logs"
+)
+
+type CookieCheckerInterface interface {
+	CheckCookie(r *http.Request) *models.UserProfile
+}
+
+// AuthMiddleware - один раз определяет пользователя по сессии и кладет его в контекст запроса.
+// Из контекста пользователь передается в метаданных gRPC вызовов
+func AuthMiddleware(cookieManager CookieCheckerInterface, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := r.Cookie("session_id"); err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		userProfile := cookieManager.CheckCookie(r)
+		if userProfile == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := cookie.WithUserProfile(r.Context(), userProfile)
+		ctx = auth.WithIdentity(ctx, &auth.Identity{UserId: userProfile.Id, Roles: userProfile.Roles})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequirePermission - проверка прав пользователя на вызов ручки
+func RequirePermission(permission auth.Permission, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodOptions {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		identity, ok := auth.IdentityFromContext(r.Context())
+		if !ok {
+			logs.PrintLog(r.Context(), "RequirePermission", "user not logged in")
+			response.SendErrorResponse("not authorized", http.StatusUnauthorized, w, r)
+			return
+		}
+
+		if !auth.HasPermission(identity.Roles, permission) {
+			logs.PrintLog(r.Context(), "RequirePermission", "permission denied: "+string(permission))
+			response.SendErrorResponse("permission denied", http.StatusForbidden, w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"skillForce/pkg/auth"
+	"skillForce/pkg/logs"
+)
+
+func TestRequirePermission_Moderate(t *testing.T) {
+	tests := []struct {
+		name     string
+		identity *auth.Identity
+		want     int
+	}{
+		{name: "anonymous", want: http.StatusUnauthorized},
+		{name: "learner", identity: &auth.Identity{UserId: 1}, want: http.StatusForbidden},
+		{name: "author", identity: &auth.Identity{UserId: 2, Roles: []string{string(auth.RoleAuthor)}}, want: http.StatusForbidden},
+		{name: "moderator", identity: &auth.Identity{UserId: 3, Roles: []string{string(auth.RoleModerator)}}, want: http.StatusOK},
+		{name: "admin", identity: &auth.Identity{UserId: 4, Roles: []string{string(auth.RoleAdmin)}}, want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			handler := RequirePermission(auth.PermModerate, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/moderation/setCourseHidden", nil)
+			req = req.WithContext(context.WithValue(req.Context(), logs.LogsKey, &logs.CtxLog{}))
+			if tt.identity != nil {
+				req = req.WithContext(auth.WithIdentity(req.Context(), tt.identity))
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.want)
+			}
+			if called != (tt.want == http.StatusOK) {
+				t.Fatalf("handler called = %v with status %d", called, rec.Code)
+			}
+		})
+	}
+}
@@ -7,9 +7,9 @@ func CorsOptionsMiddleware(next http.Handler) http.Handler {
 		w.Header().Set("Access-Control-Allow-Origin", "http://217.16.21.64")
 		// w.Header().Set("Access-Control-Allow-Origin", "http://localhost:8001")
 		w.Header().Set("Access-Control-Allow-Credentials", "true")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS, POST")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Credentials, X-CSRF-Token")
-		w.Header().Set("Access-Control-Expose-Headers", "X-CSRF-Token")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, HEAD, OPTIONS, POST, PUT")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Credentials, X-CSRF-Token, Range, If-Range, If-None-Match")
+		w.Header().Set("Access-Control-Expose-Headers", "X-CSRF-Token, Content-Range, Accept-Ranges, ETag")
 
 		if r.Method != http.MethodOptions {
 			next.ServeHTTP(w, r)
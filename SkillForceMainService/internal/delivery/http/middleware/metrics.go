@@ -91,3 +91,8 @@ func (r *statusRecorder) WriteHeader(code int) {
 	r.statusCode = code
 	r.ResponseWriter.WriteHeader(code)
 }
+
+// Unwrap - исходный ResponseWriter для http.ResponseController: поток уведомлений вызывает Flush
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -8,6 +8,9 @@ import (
 	"io"
 	"net/http"
 	"skillForce/internal/models/dto"
+	"skillForce/pkg/logs"
+	"strings"
+	"time"
 
 	jwriter "github.com/mailru/easyjson/jwriter"
 )
@@ -32,9 +35,77 @@ type UserProfileResponse struct {
 	UserProfile *dto.UserProfileDTO `json:"user"`
 }
 
+//easyjson:json
+type PublicProfileResponse struct {
+	Profile *dto.PublicProfileDTO `json:"profile"`
+}
+
+//easyjson:json
+type RolesResponse struct {
+	Roles []string `json:"roles"`
+}
+
+//easyjson:json
+type AccountDeletionResponse struct {
+	DeleteAfter string `json:"delete_after"`
+}
+
+//easyjson:json
+type NotificationPreferencesResponse struct {
+	Preferences []*dto.NotificationPreferenceDTO `json:"preferences"`
+}
+
+//easyjson:json
+type NotificationsResponse struct {
+	Notifications []*dto.NotificationDTO `json:"notifications"`
+	UnreadCount   int                    `json:"unread_count"`
+}
+
+//easyjson:json
+type UnreadNotificationCountResponse struct {
+	UnreadCount int `json:"unread_count"`
+}
+
+//easyjson:json
+type VideoUploadResponse struct {
+	Upload *dto.VideoUploadDTO `json:"upload"`
+}
+
+//easyjson:json
+type LessonBlocksResponse struct {
+	Blocks []dto.LessonBlockDTO `json:"blocks"`
+}
+
+//easyjson:json
+type LessonFileResponse struct {
+	File *dto.LessonFileDTO `json:"file"`
+}
+
+// CourseBundleResponse - статус сборки офлайн выгрузки. Ссылка выдаётся, когда выгрузка готова
+//
+//easyjson:json
+type CourseBundleResponse struct {
+	Status    string `json:"status"`
+	Url       string `json:"url,omitempty"`
+	ExpiresAt string `json:"expires_at,omitempty"`
+}
+
+//easyjson:json
+type MarkLessonsCompletedResponse struct {
+	Marked int `json:"marked"`
+}
+
+//easyjson:json
+type VideoLinkResponse struct {
+	Url       string `json:"url"`
+	HlsUrl    string `json:"hls_url"`
+	ExpiresAt string `json:"expires_at"`
+}
+
 //easyjson:json
 type PhotoUrlResponse struct {
-	Url string `json:"url"`
+	Url   string            `json:"url"`
+	Sizes map[string]string `json:"sizes"`
 }
 
 //easyjson:json
@@ -174,9 +245,105 @@ func SendUserProfile(UserProfile *dto.UserProfileDTO, w http.ResponseWriter, r *
 	marshaling(w, response)
 }
 
-// SendPhotoUrl - отправка ссылки на фото в JSON-формате
-func SendPhotoUrl(url string, w http.ResponseWriter, r *http.Request) {
-	response := PhotoUrlResponse{Url: url}
+// SendPublicProfile - отправка публичного профиля пользователя в JSON-формате
+func SendPublicProfile(profile *dto.PublicProfileDTO, w http.ResponseWriter, r *http.Request) {
+	response := PublicProfileResponse{Profile: profile}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	marshaling(w, response)
+}
+
+// SendRoles - отправка ролей пользователя в JSON-формате
+func SendRoles(roles []string, w http.ResponseWriter, r *http.Request) {
+	response := RolesResponse{Roles: roles}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	marshaling(w, response)
+}
+
+// SendAccountDeletion - отправка даты, после которой аккаунт будет удалён
+func SendAccountDeletion(deleteAfter time.Time, w http.ResponseWriter, r *http.Request) {
+	response := AccountDeletionResponse{DeleteAfter: deleteAfter.UTC().Format(time.RFC3339)}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	marshaling(w, response)
+}
+
+// SendNotificationPreferences - отправка настроек уведомлений по категориям
+func SendNotificationPreferences(preferences []*dto.NotificationPreferenceDTO, w http.ResponseWriter, r *http.Request) {
+	response := NotificationPreferencesResponse{Preferences: preferences}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	marshaling(w, response)
+}
+
+// SendNotifications - отправка страницы уведомлений и числа непрочитанных
+func SendNotifications(notifications []*dto.NotificationDTO, unreadCount int, w http.ResponseWriter, r *http.Request) {
+	response := NotificationsResponse{Notifications: notifications, UnreadCount: unreadCount}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	marshaling(w, response)
+}
+
+func SendUnreadNotificationCount(unreadCount int, w http.ResponseWriter, r *http.Request) {
+	response := UnreadNotificationCountResponse{UnreadCount: unreadCount}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	marshaling(w, response)
+}
+
+// SendVideoUpload - отправка состояния загрузки видео
+func SendVideoUpload(upload *dto.VideoUploadDTO, w http.ResponseWriter, r *http.Request) {
+	response := VideoUploadResponse{Upload: upload}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	marshaling(w, response)
+}
+
+func SendLessonBlocks(blocks []dto.LessonBlockDTO, w http.ResponseWriter, r *http.Request) {
+	response := LessonBlocksResponse{Blocks: blocks}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	marshaling(w, response)
+}
+
+func SendLessonFile(file *dto.LessonFileDTO, w http.ResponseWriter, r *http.Request) {
+	response := LessonFileResponse{File: file}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	marshaling(w, response)
+}
+
+// SendCourseBundle - отправка статуса выгрузки и ссылки на неё, если она собрана
+func SendCourseBundle(status string, url string, expiresAt time.Time, w http.ResponseWriter, r *http.Request) {
+	response := CourseBundleResponse{Status: status}
+	if url != "" {
+		response.Url = url
+		response.ExpiresAt = expiresAt.UTC().Format(time.RFC3339)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	marshaling(w, response)
+}
+
+func SendMarkedLessons(marked int, w http.ResponseWriter, r *http.Request) {
+	response := MarkLessonsCompletedResponse{Marked: marked}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	marshaling(w, response)
+}
+
+// SendVideoLink - отправка подписанных ссылок на видео урока
+func SendVideoLink(url string, hlsUrl string, expiresAt time.Time, w http.ResponseWriter, r *http.Request) {
+	response := VideoLinkResponse{Url: url, HlsUrl: hlsUrl, ExpiresAt: expiresAt.UTC().Format(time.RFC3339)}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	marshaling(w, response)
+}
+
+// SendPhotoUrl - отправка ссылки на фото и на его миниатюры в JSON-формате
+func SendPhotoUrl(url string, sizes map[string]string, w http.ResponseWriter, r *http.Request) {
+	response := PhotoUrlResponse{Url: url, Sizes: sizes}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	marshaling(w, response)
@@ -218,27 +385,33 @@ func SendCourseResponse(course *dto.CourseDTO, w http.ResponseWriter, r *http.Re
 	marshaling(w, response)
 }
 
-func SendVideoRange(start, end, total int64, reader io.Reader, w http.ResponseWriter, r *http.Request) {
-	length := end - start + 1
-
-	w.Header().Set("Content-Type", "video/mp4")
-	w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, total))
-	w.Header().Set("Content-Length", fmt.Sprintf("%d", length))
-	w.Header().Set("Accept-Ranges", "bytes")
-	w.WriteHeader(http.StatusPartialContent)
-
-	buf := make([]byte, 64*1024)
-	for {
-		n, err := reader.Read(buf)
-		if n > 0 {
-			_, err := w.Write(buf[:n])
-			if err != nil {
-				fmt.Println(err)
-			}
-		}
-		if err != nil {
-			break
-		}
+// SendHLSFile - отправка плейлиста или сегмента HLS. Плейлисты не кешируются, так как после
+// повторной загрузки видео по тому же адресу появляется новая версия
+func SendHLSFile(meta dto.VideoMeta, reader io.Reader, w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", meta.ContentType)
+	w.Header().Set("Content-Length", fmt.Sprintf("%d", meta.Size))
+	if strings.HasSuffix(meta.Name, ".m3u8") {
+		w.Header().Set("Cache-Control", "no-cache")
+	} else {
+		w.Header().Set("Cache-Control", "private, max-age=3600")
+	}
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := io.Copy(w, reader); err != nil {
+		logs.PrintLog(r.Context(), "SendHLSFile", fmt.Sprintf("%+v", err))
+	}
+}
+
+// SendMediaFile - отправка файла по подписанной ссылке. Ссылка выдана конкретному пользователю,
+// поэтому файл не кешируется общими кешами
+func SendMediaFile(meta dto.VideoMeta, reader io.Reader, w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", meta.ContentType)
+	w.Header().Set("Content-Length", fmt.Sprintf("%d", meta.Size))
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := io.Copy(w, reader); err != nil {
+		logs.PrintLog(r.Context(), "SendMediaFile", fmt.Sprintf("%+v", err))
 	}
 }
 
@@ -18,7 +18,165 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse(in *jlexer.Lexer, out *UserProfileResponse) {
+func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse(in *jlexer.Lexer, out *VideoUploadResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "upload":
+			if in.IsNull() {
+				in.Skip()
+				out.Upload = nil
+			} else {
+				if out.Upload == nil {
+					out.Upload = new(dto.VideoUploadDTO)
+				}
+				(*out.Upload).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse(out *jwriter.Writer, in VideoUploadResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"upload\":"
+		out.RawString(prefix[1:])
+		if in.Upload == nil {
+			out.RawString("null")
+		} else {
+			(*in.Upload).MarshalEasyJSON(out)
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v VideoUploadResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v VideoUploadResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *VideoUploadResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *VideoUploadResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse(l, v)
+}
+func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse1(in *jlexer.Lexer, out *VideoLinkResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "url":
+			out.Url = string(in.String())
+		case "hls_url":
+			out.HlsUrl = string(in.String())
+		case "expires_at":
+			out.ExpiresAt = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse1(out *jwriter.Writer, in VideoLinkResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"url\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Url))
+	}
+	{
+		const prefix string = ",\"hls_url\":"
+		out.RawString(prefix)
+		out.String(string(in.HlsUrl))
+	}
+	{
+		const prefix string = ",\"expires_at\":"
+		out.RawString(prefix)
+		out.String(string(in.ExpiresAt))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v VideoLinkResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v VideoLinkResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *VideoLinkResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *VideoLinkResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse1(l, v)
+}
+func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse2(in *jlexer.Lexer, out *UserProfileResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -57,7 +215,7 @@ func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse(in *jlexer.Lex
 		in.Consumed()
 	}
 }
-func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse(out *jwriter.Writer, in UserProfileResponse) {
+func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse2(out *jwriter.Writer, in UserProfileResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -76,27 +234,93 @@ func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse(out *jwriter.W
 // MarshalJSON supports json.Marshaler interface
 func (v UserProfileResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse(&w, v)
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse2(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v UserProfileResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse(w, v)
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse2(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *UserProfileResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse(&r, v)
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse2(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *UserProfileResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse(l, v)
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse2(l, v)
+}
+func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse3(in *jlexer.Lexer, out *UnreadNotificationCountResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "unread_count":
+			out.UnreadCount = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse3(out *jwriter.Writer, in UnreadNotificationCountResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"unread_count\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.UnreadCount))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UnreadNotificationCountResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UnreadNotificationCountResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *UnreadNotificationCountResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *UnreadNotificationCountResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse3(l, v)
 }
-func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse1(in *jlexer.Lexer, out *TestResponse) {
+func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse4(in *jlexer.Lexer, out *TestResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -135,7 +359,7 @@ func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse1(in *jlexer.Le
 		in.Consumed()
 	}
 }
-func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse1(out *jwriter.Writer, in TestResponse) {
+func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse4(out *jwriter.Writer, in TestResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -154,27 +378,27 @@ func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse1(out *jwriter.
 // MarshalJSON supports json.Marshaler interface
 func (v TestResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse1(&w, v)
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse4(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v TestResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse1(w, v)
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse4(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *TestResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse1(&r, v)
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse4(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *TestResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse1(l, v)
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse4(l, v)
 }
-func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse2(in *jlexer.Lexer, out *SurveyResponse) {
+func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse5(in *jlexer.Lexer, out *SurveyResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -213,7 +437,7 @@ func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse2(in *jlexer.Le
 		in.Consumed()
 	}
 }
-func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse2(out *jwriter.Writer, in SurveyResponse) {
+func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse5(out *jwriter.Writer, in SurveyResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -232,27 +456,27 @@ func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse2(out *jwriter.
 // MarshalJSON supports json.Marshaler interface
 func (v SurveyResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse2(&w, v)
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse5(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v SurveyResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse2(w, v)
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse5(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *SurveyResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse2(&r, v)
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse5(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *SurveyResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse2(l, v)
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse5(l, v)
 }
-func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse3(in *jlexer.Lexer, out *SurveyMetricsResponse) {
+func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse6(in *jlexer.Lexer, out *SurveyMetricsResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -291,7 +515,7 @@ func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse3(in *jlexer.Le
 		in.Consumed()
 	}
 }
-func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse3(out *jwriter.Writer, in SurveyMetricsResponse) {
+func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse6(out *jwriter.Writer, in SurveyMetricsResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -310,27 +534,27 @@ func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse3(out *jwriter.
 // MarshalJSON supports json.Marshaler interface
 func (v SurveyMetricsResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse3(&w, v)
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse6(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v SurveyMetricsResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse3(w, v)
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse6(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *SurveyMetricsResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse3(&r, v)
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse6(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *SurveyMetricsResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse3(l, v)
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse6(l, v)
 }
-func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse4(in *jlexer.Lexer, out *StatisticResponse) {
+func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse7(in *jlexer.Lexer, out *StatisticResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -369,7 +593,7 @@ func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse4(in *jlexer.Le
 		in.Consumed()
 	}
 }
-func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse4(out *jwriter.Writer, in StatisticResponse) {
+func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse7(out *jwriter.Writer, in StatisticResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -388,27 +612,27 @@ func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse4(out *jwriter.
 // MarshalJSON supports json.Marshaler interface
 func (v StatisticResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse4(&w, v)
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse7(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v StatisticResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse4(w, v)
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse7(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *StatisticResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse4(&r, v)
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse7(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *StatisticResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse4(l, v)
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse7(l, v)
 }
-func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse5(in *jlexer.Lexer, out *SertificateUrlResponse) {
+func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse8(in *jlexer.Lexer, out *SertificateUrlResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -439,7 +663,7 @@ func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse5(in *jlexer.Le
 		in.Consumed()
 	}
 }
-func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse5(out *jwriter.Writer, in SertificateUrlResponse) {
+func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse8(out *jwriter.Writer, in SertificateUrlResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -454,27 +678,27 @@ func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse5(out *jwriter.
 // MarshalJSON supports json.Marshaler interface
 func (v SertificateUrlResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse5(&w, v)
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse8(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v SertificateUrlResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse5(w, v)
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse8(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *SertificateUrlResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse5(&r, v)
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse8(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *SertificateUrlResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse5(l, v)
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse8(l, v)
 }
-func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse6(in *jlexer.Lexer, out *Result) {
+func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse9(in *jlexer.Lexer, out *RolesResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -493,8 +717,29 @@ func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse6(in *jlexer.Le
 			continue
 		}
 		switch key {
-		case "result":
-			out.Result = bool(in.Bool())
+		case "roles":
+			if in.IsNull() {
+				in.Skip()
+				out.Roles = nil
+			} else {
+				in.Delim('[')
+				if out.Roles == nil {
+					if !in.IsDelim(']') {
+						out.Roles = make([]string, 0, 4)
+					} else {
+						out.Roles = []string{}
+					}
+				} else {
+					out.Roles = (out.Roles)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 string
+					v1 = string(in.String())
+					out.Roles = append(out.Roles, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -505,42 +750,119 @@ func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse6(in *jlexer.Le
 		in.Consumed()
 	}
 }
-func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse6(out *jwriter.Writer, in Result) {
+func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse9(out *jwriter.Writer, in RolesResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"result\":"
+		const prefix string = ",\"roles\":"
 		out.RawString(prefix[1:])
-		out.Bool(bool(in.Result))
-	}
-	out.RawByte('}')
-}
-
-// MarshalJSON supports json.Marshaler interface
-func (v Result) MarshalJSON() ([]byte, error) {
+		if in.Roles == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Roles {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v3))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RolesResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse6(&w, v)
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse9(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RolesResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse9(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RolesResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse9(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RolesResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse9(l, v)
+}
+func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse10(in *jlexer.Lexer, out *Result) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "result":
+			out.Result = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse10(out *jwriter.Writer, in Result) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"result\":"
+		out.RawString(prefix[1:])
+		out.Bool(bool(in.Result))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Result) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse10(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Result) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse6(w, v)
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse10(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Result) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse6(&r, v)
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse10(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Result) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse6(l, v)
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse10(l, v)
 }
-func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse7(in *jlexer.Lexer, out *RaitingResponse) {
+func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse11(in *jlexer.Lexer, out *RaitingResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -579,46 +901,598 @@ func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse7(in *jlexer.Le
 		in.Consumed()
 	}
 }
-func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse7(out *jwriter.Writer, in RaitingResponse) {
+func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse11(out *jwriter.Writer, in RaitingResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"course_raiting\":"
+		out.RawString(prefix[1:])
+		if in.Raiting == nil {
+			out.RawString("null")
+		} else {
+			(*in.Raiting).MarshalEasyJSON(out)
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RaitingResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse11(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RaitingResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse11(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RaitingResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse11(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RaitingResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse11(l, v)
+}
+func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse12(in *jlexer.Lexer, out *QuestionTestResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "question":
+			if in.IsNull() {
+				in.Skip()
+				out.Question = nil
+			} else {
+				if out.Question == nil {
+					out.Question = new(dto.QuestionTest)
+				}
+				(*out.Question).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse12(out *jwriter.Writer, in QuestionTestResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"question\":"
+		out.RawString(prefix[1:])
+		if in.Question == nil {
+			out.RawString("null")
+		} else {
+			(*in.Question).MarshalEasyJSON(out)
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v QuestionTestResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse12(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v QuestionTestResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse12(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *QuestionTestResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse12(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *QuestionTestResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse12(l, v)
+}
+func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse13(in *jlexer.Lexer, out *PublicProfileResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "profile":
+			if in.IsNull() {
+				in.Skip()
+				out.Profile = nil
+			} else {
+				if out.Profile == nil {
+					out.Profile = new(dto.PublicProfileDTO)
+				}
+				(*out.Profile).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse13(out *jwriter.Writer, in PublicProfileResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"profile\":"
+		out.RawString(prefix[1:])
+		if in.Profile == nil {
+			out.RawString("null")
+		} else {
+			(*in.Profile).MarshalEasyJSON(out)
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PublicProfileResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse13(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PublicProfileResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse13(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PublicProfileResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse13(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PublicProfileResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse13(l, v)
+}
+func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse14(in *jlexer.Lexer, out *PhotoUrlResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "url":
+			out.Url = string(in.String())
+		case "sizes":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.Sizes = make(map[string]string)
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v4 string
+					v4 = string(in.String())
+					(out.Sizes)[key] = v4
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse14(out *jwriter.Writer, in PhotoUrlResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"url\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Url))
+	}
+	{
+		const prefix string = ",\"sizes\":"
+		out.RawString(prefix)
+		if in.Sizes == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v5First := true
+			for v5Name, v5Value := range in.Sizes {
+				if v5First {
+					v5First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v5Name))
+				out.RawByte(':')
+				out.String(string(v5Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PhotoUrlResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse14(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PhotoUrlResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse14(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PhotoUrlResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse14(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PhotoUrlResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse14(l, v)
+}
+func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse15(in *jlexer.Lexer, out *NotificationsResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "notifications":
+			if in.IsNull() {
+				in.Skip()
+				out.Notifications = nil
+			} else {
+				in.Delim('[')
+				if out.Notifications == nil {
+					if !in.IsDelim(']') {
+						out.Notifications = make([]*dto.NotificationDTO, 0, 8)
+					} else {
+						out.Notifications = []*dto.NotificationDTO{}
+					}
+				} else {
+					out.Notifications = (out.Notifications)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v6 *dto.NotificationDTO
+					if in.IsNull() {
+						in.Skip()
+						v6 = nil
+					} else {
+						if v6 == nil {
+							v6 = new(dto.NotificationDTO)
+						}
+						(*v6).UnmarshalEasyJSON(in)
+					}
+					out.Notifications = append(out.Notifications, v6)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "unread_count":
+			out.UnreadCount = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse15(out *jwriter.Writer, in NotificationsResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"notifications\":"
+		out.RawString(prefix[1:])
+		if in.Notifications == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v7, v8 := range in.Notifications {
+				if v7 > 0 {
+					out.RawByte(',')
+				}
+				if v8 == nil {
+					out.RawString("null")
+				} else {
+					(*v8).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"unread_count\":"
+		out.RawString(prefix)
+		out.Int(int(in.UnreadCount))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v NotificationsResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse15(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v NotificationsResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse15(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *NotificationsResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse15(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *NotificationsResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse15(l, v)
+}
+func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse16(in *jlexer.Lexer, out *NotificationPreferencesResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "preferences":
+			if in.IsNull() {
+				in.Skip()
+				out.Preferences = nil
+			} else {
+				in.Delim('[')
+				if out.Preferences == nil {
+					if !in.IsDelim(']') {
+						out.Preferences = make([]*dto.NotificationPreferenceDTO, 0, 8)
+					} else {
+						out.Preferences = []*dto.NotificationPreferenceDTO{}
+					}
+				} else {
+					out.Preferences = (out.Preferences)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v9 *dto.NotificationPreferenceDTO
+					if in.IsNull() {
+						in.Skip()
+						v9 = nil
+					} else {
+						if v9 == nil {
+							v9 = new(dto.NotificationPreferenceDTO)
+						}
+						(*v9).UnmarshalEasyJSON(in)
+					}
+					out.Preferences = append(out.Preferences, v9)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse16(out *jwriter.Writer, in NotificationPreferencesResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"preferences\":"
+		out.RawString(prefix[1:])
+		if in.Preferences == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v10, v11 := range in.Preferences {
+				if v10 > 0 {
+					out.RawByte(',')
+				}
+				if v11 == nil {
+					out.RawString("null")
+				} else {
+					(*v11).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v NotificationPreferencesResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse16(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v NotificationPreferencesResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse16(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *NotificationPreferencesResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse16(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *NotificationPreferencesResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse16(l, v)
+}
+func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse17(in *jlexer.Lexer, out *MarkLessonsCompletedResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "marked":
+			out.Marked = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse17(out *jwriter.Writer, in MarkLessonsCompletedResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"course_raiting\":"
+		const prefix string = ",\"marked\":"
 		out.RawString(prefix[1:])
-		if in.Raiting == nil {
-			out.RawString("null")
-		} else {
-			(*in.Raiting).MarshalEasyJSON(out)
-		}
+		out.Int(int(in.Marked))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v RaitingResponse) MarshalJSON() ([]byte, error) {
+func (v MarkLessonsCompletedResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse7(&w, v)
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse17(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v RaitingResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse7(w, v)
+func (v MarkLessonsCompletedResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse17(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *RaitingResponse) UnmarshalJSON(data []byte) error {
+func (v *MarkLessonsCompletedResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse7(&r, v)
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse17(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *RaitingResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse7(l, v)
+func (v *MarkLessonsCompletedResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse17(l, v)
 }
-func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse8(in *jlexer.Lexer, out *QuestionTestResponse) {
+func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse18(in *jlexer.Lexer, out *LessonResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -637,15 +1511,15 @@ func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse8(in *jlexer.Le
 			continue
 		}
 		switch key {
-		case "question":
+		case "lesson":
 			if in.IsNull() {
 				in.Skip()
-				out.Question = nil
+				out.Lesson = nil
 			} else {
-				if out.Question == nil {
-					out.Question = new(dto.QuestionTest)
+				if out.Lesson == nil {
+					out.Lesson = new(dto.LessonDTO)
 				}
-				(*out.Question).UnmarshalEasyJSON(in)
+				(*out.Lesson).UnmarshalEasyJSON(in)
 			}
 		default:
 			in.SkipRecursive()
@@ -657,46 +1531,46 @@ func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse8(in *jlexer.Le
 		in.Consumed()
 	}
 }
-func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse8(out *jwriter.Writer, in QuestionTestResponse) {
+func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse18(out *jwriter.Writer, in LessonResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"question\":"
+		const prefix string = ",\"lesson\":"
 		out.RawString(prefix[1:])
-		if in.Question == nil {
+		if in.Lesson == nil {
 			out.RawString("null")
 		} else {
-			(*in.Question).MarshalEasyJSON(out)
+			(*in.Lesson).MarshalEasyJSON(out)
 		}
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v QuestionTestResponse) MarshalJSON() ([]byte, error) {
+func (v LessonResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse8(&w, v)
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse18(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v QuestionTestResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse8(w, v)
+func (v LessonResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse18(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *QuestionTestResponse) UnmarshalJSON(data []byte) error {
+func (v *LessonResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse8(&r, v)
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse18(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *QuestionTestResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse8(l, v)
+func (v *LessonResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse18(l, v)
 }
-func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse9(in *jlexer.Lexer, out *PhotoUrlResponse) {
+func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse19(in *jlexer.Lexer, out *LessonFileResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -715,8 +1589,16 @@ func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse9(in *jlexer.Le
 			continue
 		}
 		switch key {
-		case "url":
-			out.Url = string(in.String())
+		case "file":
+			if in.IsNull() {
+				in.Skip()
+				out.File = nil
+			} else {
+				if out.File == nil {
+					out.File = new(dto.LessonFileDTO)
+				}
+				(*out.File).UnmarshalEasyJSON(in)
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -727,42 +1609,46 @@ func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse9(in *jlexer.Le
 		in.Consumed()
 	}
 }
-func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse9(out *jwriter.Writer, in PhotoUrlResponse) {
+func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse19(out *jwriter.Writer, in LessonFileResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"url\":"
+		const prefix string = ",\"file\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Url))
+		if in.File == nil {
+			out.RawString("null")
+		} else {
+			(*in.File).MarshalEasyJSON(out)
+		}
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v PhotoUrlResponse) MarshalJSON() ([]byte, error) {
+func (v LessonFileResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse9(&w, v)
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse19(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v PhotoUrlResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse9(w, v)
+func (v LessonFileResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse19(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *PhotoUrlResponse) UnmarshalJSON(data []byte) error {
+func (v *LessonFileResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse9(&r, v)
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse19(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *PhotoUrlResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse9(l, v)
+func (v *LessonFileResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse19(l, v)
 }
-func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse10(in *jlexer.Lexer, out *LessonResponse) {
+func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse20(in *jlexer.Lexer, out *LessonBodyResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -781,15 +1667,15 @@ func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse10(in *jlexer.L
 			continue
 		}
 		switch key {
-		case "lesson":
+		case "lesson_body":
 			if in.IsNull() {
 				in.Skip()
-				out.Lesson = nil
+				out.LessonBody = nil
 			} else {
-				if out.Lesson == nil {
-					out.Lesson = new(dto.LessonDTO)
+				if out.LessonBody == nil {
+					out.LessonBody = new(dto.LessonDtoBody)
 				}
-				(*out.Lesson).UnmarshalEasyJSON(in)
+				(*out.LessonBody).UnmarshalEasyJSON(in)
 			}
 		default:
 			in.SkipRecursive()
@@ -801,46 +1687,46 @@ func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse10(in *jlexer.L
 		in.Consumed()
 	}
 }
-func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse10(out *jwriter.Writer, in LessonResponse) {
+func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse20(out *jwriter.Writer, in LessonBodyResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"lesson\":"
+		const prefix string = ",\"lesson_body\":"
 		out.RawString(prefix[1:])
-		if in.Lesson == nil {
+		if in.LessonBody == nil {
 			out.RawString("null")
 		} else {
-			(*in.Lesson).MarshalEasyJSON(out)
+			(*in.LessonBody).MarshalEasyJSON(out)
 		}
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v LessonResponse) MarshalJSON() ([]byte, error) {
+func (v LessonBodyResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse10(&w, v)
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse20(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v LessonResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse10(w, v)
+func (v LessonBodyResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse20(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *LessonResponse) UnmarshalJSON(data []byte) error {
+func (v *LessonBodyResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse10(&r, v)
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse20(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *LessonResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse10(l, v)
+func (v *LessonBodyResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse20(l, v)
 }
-func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse11(in *jlexer.Lexer, out *LessonBodyResponse) {
+func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse21(in *jlexer.Lexer, out *LessonBlocksResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -859,15 +1745,28 @@ func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse11(in *jlexer.L
 			continue
 		}
 		switch key {
-		case "lesson_body":
+		case "blocks":
 			if in.IsNull() {
 				in.Skip()
-				out.LessonBody = nil
+				out.Blocks = nil
 			} else {
-				if out.LessonBody == nil {
-					out.LessonBody = new(dto.LessonDtoBody)
+				in.Delim('[')
+				if out.Blocks == nil {
+					if !in.IsDelim(']') {
+						out.Blocks = make([]dto.LessonBlockDTO, 0, 0)
+					} else {
+						out.Blocks = []dto.LessonBlockDTO{}
+					}
+				} else {
+					out.Blocks = (out.Blocks)[:0]
 				}
-				(*out.LessonBody).UnmarshalEasyJSON(in)
+				for !in.IsDelim(']') {
+					var v12 dto.LessonBlockDTO
+					(v12).UnmarshalEasyJSON(in)
+					out.Blocks = append(out.Blocks, v12)
+					in.WantComma()
+				}
+				in.Delim(']')
 			}
 		default:
 			in.SkipRecursive()
@@ -879,46 +1778,53 @@ func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse11(in *jlexer.L
 		in.Consumed()
 	}
 }
-func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse11(out *jwriter.Writer, in LessonBodyResponse) {
+func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse21(out *jwriter.Writer, in LessonBlocksResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"lesson_body\":"
+		const prefix string = ",\"blocks\":"
 		out.RawString(prefix[1:])
-		if in.LessonBody == nil {
+		if in.Blocks == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 			out.RawString("null")
 		} else {
-			(*in.LessonBody).MarshalEasyJSON(out)
+			out.RawByte('[')
+			for v13, v14 := range in.Blocks {
+				if v13 > 0 {
+					out.RawByte(',')
+				}
+				(v14).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
 		}
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v LessonBodyResponse) MarshalJSON() ([]byte, error) {
+func (v LessonBlocksResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse11(&w, v)
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse21(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v LessonBodyResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse11(w, v)
+func (v LessonBlocksResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse21(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *LessonBodyResponse) UnmarshalJSON(data []byte) error {
+func (v *LessonBlocksResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse11(&r, v)
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse21(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *LessonBodyResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse11(l, v)
+func (v *LessonBlocksResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse21(l, v)
 }
-func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse12(in *jlexer.Lexer, out *ErrorResponse) {
+func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse22(in *jlexer.Lexer, out *ErrorResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -949,7 +1855,7 @@ func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse12(in *jlexer.L
 		in.Consumed()
 	}
 }
-func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse12(out *jwriter.Writer, in ErrorResponse) {
+func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse22(out *jwriter.Writer, in ErrorResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -964,27 +1870,27 @@ func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse12(out *jwriter
 // MarshalJSON supports json.Marshaler interface
 func (v ErrorResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse12(&w, v)
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse22(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v ErrorResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse12(w, v)
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse22(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *ErrorResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse12(&r, v)
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse22(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *ErrorResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse12(l, v)
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse22(l, v)
 }
-func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse13(in *jlexer.Lexer, out *CourseRoadmapResponse) {
+func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse23(in *jlexer.Lexer, out *CourseRoadmapResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1023,7 +1929,7 @@ func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse13(in *jlexer.L
 		in.Consumed()
 	}
 }
-func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse13(out *jwriter.Writer, in CourseRoadmapResponse) {
+func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse23(out *jwriter.Writer, in CourseRoadmapResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1042,27 +1948,27 @@ func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse13(out *jwriter
 // MarshalJSON supports json.Marshaler interface
 func (v CourseRoadmapResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse13(&w, v)
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse23(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v CourseRoadmapResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse13(w, v)
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse23(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *CourseRoadmapResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse13(&r, v)
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse23(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *CourseRoadmapResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse13(l, v)
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse23(l, v)
 }
-func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse14(in *jlexer.Lexer, out *CourseResponse) {
+func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse24(in *jlexer.Lexer, out *CourseResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1101,7 +2007,7 @@ func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse14(in *jlexer.L
 		in.Consumed()
 	}
 }
-func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse14(out *jwriter.Writer, in CourseResponse) {
+func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse24(out *jwriter.Writer, in CourseResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1120,27 +2026,107 @@ func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse14(out *jwriter
 // MarshalJSON supports json.Marshaler interface
 func (v CourseResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse14(&w, v)
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse24(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v CourseResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse14(w, v)
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse24(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *CourseResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse14(&r, v)
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse24(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *CourseResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse14(l, v)
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse24(l, v)
+}
+func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse25(in *jlexer.Lexer, out *CourseBundleResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "status":
+			out.Status = string(in.String())
+		case "url":
+			out.Url = string(in.String())
+		case "expires_at":
+			out.ExpiresAt = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse25(out *jwriter.Writer, in CourseBundleResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Status))
+	}
+	if in.Url != "" {
+		const prefix string = ",\"url\":"
+		out.RawString(prefix)
+		out.String(string(in.Url))
+	}
+	if in.ExpiresAt != "" {
+		const prefix string = ",\"expires_at\":"
+		out.RawString(prefix)
+		out.String(string(in.ExpiresAt))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CourseBundleResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse25(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CourseBundleResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse25(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CourseBundleResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse25(&r, v)
+	return r.Error()
 }
-func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse15(in *jlexer.Lexer, out *BucketCoursesResponse) {
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CourseBundleResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse25(l, v)
+}
+func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse26(in *jlexer.Lexer, out *BucketCoursesResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1175,17 +2161,17 @@ func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse15(in *jlexer.L
 					out.BucketCourses = (out.BucketCourses)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v1 *dto.CourseDTO
+					var v15 *dto.CourseDTO
 					if in.IsNull() {
 						in.Skip()
-						v1 = nil
+						v15 = nil
 					} else {
-						if v1 == nil {
-							v1 = new(dto.CourseDTO)
+						if v15 == nil {
+							v15 = new(dto.CourseDTO)
 						}
-						(*v1).UnmarshalEasyJSON(in)
+						(*v15).UnmarshalEasyJSON(in)
 					}
-					out.BucketCourses = append(out.BucketCourses, v1)
+					out.BucketCourses = append(out.BucketCourses, v15)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -1200,7 +2186,7 @@ func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse15(in *jlexer.L
 		in.Consumed()
 	}
 }
-func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse15(out *jwriter.Writer, in BucketCoursesResponse) {
+func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse26(out *jwriter.Writer, in BucketCoursesResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1211,14 +2197,14 @@ func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse15(out *jwriter
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v2, v3 := range in.BucketCourses {
-				if v2 > 0 {
+			for v16, v17 := range in.BucketCourses {
+				if v16 > 0 {
 					out.RawByte(',')
 				}
-				if v3 == nil {
+				if v17 == nil {
 					out.RawString("null")
 				} else {
-					(*v3).MarshalEasyJSON(out)
+					(*v17).MarshalEasyJSON(out)
 				}
 			}
 			out.RawByte(']')
@@ -1230,27 +2216,27 @@ func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse15(out *jwriter
 // MarshalJSON supports json.Marshaler interface
 func (v BucketCoursesResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse15(&w, v)
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse26(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v BucketCoursesResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse15(w, v)
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse26(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *BucketCoursesResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse15(&r, v)
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse26(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *BucketCoursesResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse15(l, v)
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse26(l, v)
 }
-func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse16(in *jlexer.Lexer, out *Billing) {
+func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse27(in *jlexer.Lexer, out *Billing) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1281,7 +2267,7 @@ func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse16(in *jlexer.L
 		in.Consumed()
 	}
 }
-func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse16(out *jwriter.Writer, in Billing) {
+func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse27(out *jwriter.Writer, in Billing) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1296,23 +2282,89 @@ func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse16(out *jwriter
 // MarshalJSON supports json.Marshaler interface
 func (v Billing) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse16(&w, v)
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse27(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Billing) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse16(w, v)
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse27(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Billing) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse16(&r, v)
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse27(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Billing) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse16(l, v)
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse27(l, v)
+}
+func easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse28(in *jlexer.Lexer, out *AccountDeletionResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "delete_after":
+			out.DeleteAfter = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse28(out *jwriter.Writer, in AccountDeletionResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"delete_after\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.DeleteAfter))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AccountDeletionResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse28(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AccountDeletionResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson6ff3ac1dEncodeSkillForceInternalDeliveryHttpResponse28(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AccountDeletionResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse28(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AccountDeletionResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson6ff3ac1dDecodeSkillForceInternalDeliveryHttpResponse28(l, v)
 }
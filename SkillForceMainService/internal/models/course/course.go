@@ -72,3 +72,52 @@ type UserAnswer struct {
 	Username string
 	Answer   int
 }
+
+type VideoUpload struct {
+	Id            string
+	UserId        int
+	LessonId      int
+	ObjectName    string
+	MinioUploadId string
+	ContentType   string
+	TotalSize     int64
+	ChunkSize     int64
+	Status        string
+	Parts         []*VideoUploadPart
+}
+
+type VideoUploadPart struct {
+	PartNumber int
+	ETag       string
+	Size       int64
+}
+
+// TranscodeJob - задача перекодирования исходного видео урока в HLS
+type TranscodeJob struct {
+	LessonId     int
+	SourceObject string
+	Attempts     int
+}
+
+// LessonFile - картинка или вложение, загруженные автором для блока текстового урока
+type LessonFile struct {
+	ObjectName  string
+	AuthorId    int
+	Kind        string
+	FileName    string
+	ContentType string
+	Size        int64
+}
+
+// CourseBundle - офлайн выгрузка курса в одном формате. Content - снимок содержимого курса в JSON,
+// ObjectName - последняя собранная выгрузка в бакете, она удаляется после сборки новой версии
+type CourseBundle struct {
+	CourseId   int
+	Format     string
+	WithVideos bool
+	Version    string
+	Content    []byte
+	Status     string
+	ObjectName string
+	Attempts   int
+}
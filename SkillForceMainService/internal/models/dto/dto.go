@@ -2,6 +2,8 @@
 
 package dto
 
+import "time"
+
 //easyjson:json
 type UserDTO struct {
 	Name     string `json:"name"`
@@ -11,12 +13,54 @@ type UserDTO struct {
 
 //easyjson:json
 type UserProfileDTO struct {
-	Name      string `json:"name"`
-	Email     string `json:"email"`
-	Bio       string `json:"bio"`
-	AvatarSrc string `json:"avatar_src"`
-	HideEmail bool   `json:"hide_email"`
-	IsAdmin   bool   `json:"is_admin"`
+	Name        string            `json:"name"`
+	Email       string            `json:"email"`
+	Bio         string            `json:"bio"`
+	AvatarSrc   string            `json:"avatar_src"`
+	AvatarSizes map[string]string `json:"avatar_sizes"`
+	HideEmail   bool              `json:"hide_email"`
+	IsAdmin     bool              `json:"is_admin"`
+	Roles       []string          `json:"roles"`
+	Locale      string            `json:"locale,omitempty"`
+	Timezone    string            `json:"timezone,omitempty"`
+}
+
+//easyjson:json
+type RoleDTO struct {
+	UserId int    `json:"user_id"`
+	Role   string `json:"role"`
+}
+
+//easyjson:json
+type NotificationPreferenceDTO struct {
+	Category string `json:"category"`
+	Enabled  bool   `json:"enabled"`
+}
+
+//easyjson:json
+type NotificationPreferencesDTO struct {
+	Preferences []*NotificationPreferenceDTO `json:"preferences"`
+}
+
+// NotificationDTO - уведомление в приложении. Kind: course_signup, course_progress, certificate_ready, data_export_ready
+//
+//easyjson:json
+type NotificationDTO struct {
+	Id         int64  `json:"id"`
+	Kind       string `json:"kind"`
+	CourseId   int    `json:"course_id,omitempty"`
+	CourseName string `json:"course_name,omitempty"`
+	Url        string `json:"url,omitempty"`
+	CreatedAt  string `json:"created_at"`
+	IsRead     bool   `json:"is_read"`
+}
+
+// MarkNotificationsReadDTO - уведомления ids или все уведомления, если all
+//
+//easyjson:json
+type MarkNotificationsReadDTO struct {
+	Ids []int64 `json:"ids"`
+	All bool    `json:"all"`
 }
 
 //easyjson:json
@@ -45,9 +89,7 @@ type LessonDTO struct {
 
 //easyjson:json
 type LessonDtoBody struct {
-	Blocks []struct {
-		Body string `json:"body"`
-	} `json:"blocks"`
+	Blocks []LessonBlockDTO `json:"blocks"`
 	Footer struct {
 		NextLessonId     int `json:"next_lesson_id"`
 		CurrentLessonId  int `json:"current_lesson_id"`
@@ -86,6 +128,89 @@ type LessonPointDTO struct {
 	Title    string `json:"lesson_title"`
 	Value    string `json:"lesson_value"`
 	IsDone   bool   `json:"is_done"`
+	// Blocks - типизированные блоки текстового урока, при их наличии lesson_value не используется
+	Blocks []LessonBlockDTO `json:"blocks,omitempty"`
+}
+
+// LessonBlockDTO - блок текстового урока. body - готовый HTML для клиентов, не знающих типов блоков,
+// остальные поля зависят от type. Для image и file url - подписанная ссылка на файл
+//
+//easyjson:json
+type LessonBlockDTO struct {
+	Type        string   `json:"type,omitempty"`
+	Body        string   `json:"body"`
+	Text        string   `json:"text,omitempty"`
+	Object      string   `json:"object,omitempty"`
+	FileName    string   `json:"file_name,omitempty"`
+	ContentType string   `json:"content_type,omitempty"`
+	Size        int64    `json:"size,omitempty"`
+	Alt         string   `json:"alt,omitempty"`
+	Caption     string   `json:"caption,omitempty"`
+	Language    string   `json:"language,omitempty"`
+	Code        string   `json:"code,omitempty"`
+	Variant     string   `json:"variant,omitempty"`
+	Url         string   `json:"url,omitempty"`
+	Provider    string   `json:"provider,omitempty"`
+	VideoId     string   `json:"video_id,omitempty"`
+	EmbedUrl    string   `json:"embed_url,omitempty"`
+	Question    string   `json:"question,omitempty"`
+	Options     []string `json:"options,omitempty"`
+	Answer      *int     `json:"answer,omitempty"`
+}
+
+//easyjson:json
+type LessonBlocksDTO struct {
+	Blocks []LessonBlockDTO `json:"blocks"`
+}
+
+//easyjson:json
+type LessonFileDTO struct {
+	Object      string `json:"object"`
+	Kind        string `json:"kind"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+}
+
+//easyjson:json
+type AnswerBlockQuizRequest struct {
+	LessonId   int `json:"lesson_id"`
+	BlockIndex int `json:"block_index"`
+	Option     int `json:"option"`
+}
+
+// CourseBundleDTO - снимок содержимого курса, из которого собирается офлайн выгрузка
+//
+//easyjson:json
+type CourseBundleDTO struct {
+	CourseId    int               `json:"course_id"`
+	Title       string            `json:"title"`
+	Description string            `json:"description"`
+	Version     string            `json:"version"`
+	Lessons     []BundleLessonDTO `json:"lessons"`
+}
+
+//easyjson:json
+type BundleLessonDTO struct {
+	LessonId    int              `json:"lesson_id"`
+	PartTitle   string           `json:"part_title"`
+	BucketTitle string           `json:"bucket_title"`
+	Title       string           `json:"title"`
+	Type        string           `json:"type"`
+	Blocks      []LessonBlockDTO `json:"blocks,omitempty"`
+}
+
+//easyjson:json
+type MarkLessonsCompletedRequest struct {
+	CourseId  int   `json:"course_id"`
+	LessonIds []int `json:"lesson_ids"`
+}
+
+//easyjson:json
+type SetCourseHiddenRequest struct {
+	CourseId int    `json:"course_id"`
+	Hidden   bool   `json:"hidden"`
+	Reason   string `json:"reason"`
 }
 
 //easyjson:json
@@ -120,8 +245,37 @@ type VideoRangeRequest struct {
 
 //easyjson:json
 type VideoMeta struct {
-	Name string
-	Size int64
+	Name         string
+	Size         int64
+	ContentType  string
+	ETag         string
+	LastModified time.Time
+}
+
+//easyjson:json
+type InitVideoUploadDTO struct {
+	LessonId    int    `json:"lesson_id"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+}
+
+//easyjson:json
+type VideoUploadIDRequest struct {
+	UploadId string `json:"upload_id"`
+}
+
+//easyjson:json
+type VideoUploadDTO struct {
+	UploadId       string `json:"upload_id"`
+	LessonId       int    `json:"lesson_id"`
+	ContentType    string `json:"content_type"`
+	Size           int64  `json:"size"`
+	ChunkSize      int64  `json:"chunk_size"`
+	TotalChunks    int    `json:"total_chunks"`
+	UploadedChunks []int  `json:"uploaded_chunks"`
+	UploadedSize   int64  `json:"uploaded_size"`
+	Status         string `json:"status"`
+	VideoSrc       string `json:"video_src,omitempty"`
 }
 
 //easyjson:json
@@ -253,3 +407,39 @@ type WebhookHandlerData struct {
 		Status string `json:"status"`
 	} `json:"object"`
 }
+
+//easyjson:json
+type CompletedCourseDTO struct {
+	Course         *CourseDTO `json:"course"`
+	SertificateUrl string     `json:"sertificate_url"`
+}
+
+//easyjson:json
+type RatingPositionDTO struct {
+	CourseId    int    `json:"course_id"`
+	CourseTitle string `json:"course_title"`
+	Position    int    `json:"position"`
+	Score       int    `json:"score"`
+}
+
+//easyjson:json
+type AuthorStatsDTO struct {
+	CoursesAmount  int     `json:"courses_amount"`
+	StudentsAmount int     `json:"students_amount"`
+	Rating         float32 `json:"rating"`
+}
+
+//easyjson:json
+type PublicProfileDTO struct {
+	Id               int                   `json:"id"`
+	Name             string                `json:"name"`
+	Email            string                `json:"email,omitempty"`
+	Bio              string                `json:"bio"`
+	AvatarSrc        string                `json:"avatar_src"`
+	AvatarSizes      map[string]string     `json:"avatar_sizes"`
+	Roles            []string              `json:"roles"`
+	AuthoredCourses  []*CourseDTO          `json:"authored_courses"`
+	CompletedCourses []*CompletedCourseDTO `json:"completed_courses"`
+	RatingPositions  []*RatingPositionDTO  `json:"rating_positions"`
+	AuthorStats      *AuthorStatsDTO       `json:"author_stats,omitempty"`
+}
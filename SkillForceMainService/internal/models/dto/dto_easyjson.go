@@ -145,7 +145,234 @@ func easyjson56de76c1Encode(out *jwriter.Writer, in struct {
 	}
 	out.RawByte('}')
 }
-func easyjson56de76c1DecodeSkillForceInternalModelsDto1(in *jlexer.Lexer, out *VideoRangeRequest) {
+func easyjson56de76c1DecodeSkillForceInternalModelsDto1(in *jlexer.Lexer, out *VideoUploadIDRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "upload_id":
+			out.UploadId = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeSkillForceInternalModelsDto1(out *jwriter.Writer, in VideoUploadIDRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"upload_id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.UploadId))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v VideoUploadIDRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeSkillForceInternalModelsDto1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v VideoUploadIDRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *VideoUploadIDRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeSkillForceInternalModelsDto1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *VideoUploadIDRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeSkillForceInternalModelsDto1(l, v)
+}
+func easyjson56de76c1DecodeSkillForceInternalModelsDto2(in *jlexer.Lexer, out *VideoUploadDTO) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "upload_id":
+			out.UploadId = string(in.String())
+		case "lesson_id":
+			out.LessonId = int(in.Int())
+		case "content_type":
+			out.ContentType = string(in.String())
+		case "size":
+			out.Size = int64(in.Int64())
+		case "chunk_size":
+			out.ChunkSize = int64(in.Int64())
+		case "total_chunks":
+			out.TotalChunks = int(in.Int())
+		case "uploaded_chunks":
+			if in.IsNull() {
+				in.Skip()
+				out.UploadedChunks = nil
+			} else {
+				in.Delim('[')
+				if out.UploadedChunks == nil {
+					if !in.IsDelim(']') {
+						out.UploadedChunks = make([]int, 0, 8)
+					} else {
+						out.UploadedChunks = []int{}
+					}
+				} else {
+					out.UploadedChunks = (out.UploadedChunks)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 int
+					v1 = int(in.Int())
+					out.UploadedChunks = append(out.UploadedChunks, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "uploaded_size":
+			out.UploadedSize = int64(in.Int64())
+		case "status":
+			out.Status = string(in.String())
+		case "video_src":
+			out.VideoSrc = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeSkillForceInternalModelsDto2(out *jwriter.Writer, in VideoUploadDTO) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"upload_id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.UploadId))
+	}
+	{
+		const prefix string = ",\"lesson_id\":"
+		out.RawString(prefix)
+		out.Int(int(in.LessonId))
+	}
+	{
+		const prefix string = ",\"content_type\":"
+		out.RawString(prefix)
+		out.String(string(in.ContentType))
+	}
+	{
+		const prefix string = ",\"size\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Size))
+	}
+	{
+		const prefix string = ",\"chunk_size\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.ChunkSize))
+	}
+	{
+		const prefix string = ",\"total_chunks\":"
+		out.RawString(prefix)
+		out.Int(int(in.TotalChunks))
+	}
+	{
+		const prefix string = ",\"uploaded_chunks\":"
+		out.RawString(prefix)
+		if in.UploadedChunks == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.UploadedChunks {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				out.Int(int(v3))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"uploaded_size\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.UploadedSize))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.String(string(in.Status))
+	}
+	if in.VideoSrc != "" {
+		const prefix string = ",\"video_src\":"
+		out.RawString(prefix)
+		out.String(string(in.VideoSrc))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v VideoUploadDTO) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeSkillForceInternalModelsDto2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v VideoUploadDTO) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *VideoUploadDTO) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeSkillForceInternalModelsDto2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *VideoUploadDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeSkillForceInternalModelsDto2(l, v)
+}
+func easyjson56de76c1DecodeSkillForceInternalModelsDto3(in *jlexer.Lexer, out *VideoRangeRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -178,7 +405,7 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto1(in *jlexer.Lexer, out *V
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeSkillForceInternalModelsDto1(out *jwriter.Writer, in VideoRangeRequest) {
+func easyjson56de76c1EncodeSkillForceInternalModelsDto3(out *jwriter.Writer, in VideoRangeRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -198,27 +425,27 @@ func easyjson56de76c1EncodeSkillForceInternalModelsDto1(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v VideoRangeRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeSkillForceInternalModelsDto1(&w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto3(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v VideoRangeRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeSkillForceInternalModelsDto1(w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto3(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *VideoRangeRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeSkillForceInternalModelsDto1(&r, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto3(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *VideoRangeRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeSkillForceInternalModelsDto1(l, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto3(l, v)
 }
-func easyjson56de76c1DecodeSkillForceInternalModelsDto2(in *jlexer.Lexer, out *VideoMeta) {
+func easyjson56de76c1DecodeSkillForceInternalModelsDto4(in *jlexer.Lexer, out *VideoMeta) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -241,6 +468,14 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto2(in *jlexer.Lexer, out *V
 			out.Name = string(in.String())
 		case "Size":
 			out.Size = int64(in.Int64())
+		case "ContentType":
+			out.ContentType = string(in.String())
+		case "ETag":
+			out.ETag = string(in.String())
+		case "LastModified":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.LastModified).UnmarshalJSON(data))
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -251,7 +486,7 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto2(in *jlexer.Lexer, out *V
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeSkillForceInternalModelsDto2(out *jwriter.Writer, in VideoMeta) {
+func easyjson56de76c1EncodeSkillForceInternalModelsDto4(out *jwriter.Writer, in VideoMeta) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -265,33 +500,48 @@ func easyjson56de76c1EncodeSkillForceInternalModelsDto2(out *jwriter.Writer, in
 		out.RawString(prefix)
 		out.Int64(int64(in.Size))
 	}
+	{
+		const prefix string = ",\"ContentType\":"
+		out.RawString(prefix)
+		out.String(string(in.ContentType))
+	}
+	{
+		const prefix string = ",\"ETag\":"
+		out.RawString(prefix)
+		out.String(string(in.ETag))
+	}
+	{
+		const prefix string = ",\"LastModified\":"
+		out.RawString(prefix)
+		out.Raw((in.LastModified).MarshalJSON())
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v VideoMeta) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeSkillForceInternalModelsDto2(&w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto4(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v VideoMeta) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeSkillForceInternalModelsDto2(w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto4(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *VideoMeta) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeSkillForceInternalModelsDto2(&r, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto4(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *VideoMeta) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeSkillForceInternalModelsDto2(l, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto4(l, v)
 }
-func easyjson56de76c1DecodeSkillForceInternalModelsDto3(in *jlexer.Lexer, out *UserStats) {
+func easyjson56de76c1DecodeSkillForceInternalModelsDto5(in *jlexer.Lexer, out *UserStats) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -342,7 +592,7 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto3(in *jlexer.Lexer, out *U
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeSkillForceInternalModelsDto3(out *jwriter.Writer, in UserStats) {
+func easyjson56de76c1EncodeSkillForceInternalModelsDto5(out *jwriter.Writer, in UserStats) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -407,27 +657,27 @@ func easyjson56de76c1EncodeSkillForceInternalModelsDto3(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v UserStats) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeSkillForceInternalModelsDto3(&w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto5(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v UserStats) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeSkillForceInternalModelsDto3(w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto5(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *UserStats) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeSkillForceInternalModelsDto3(&r, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto5(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *UserStats) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeSkillForceInternalModelsDto3(l, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto5(l, v)
 }
-func easyjson56de76c1DecodeSkillForceInternalModelsDto4(in *jlexer.Lexer, out *UserQuestionAnswer) {
+func easyjson56de76c1DecodeSkillForceInternalModelsDto6(in *jlexer.Lexer, out *UserQuestionAnswer) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -460,7 +710,7 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto4(in *jlexer.Lexer, out *U
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeSkillForceInternalModelsDto4(out *jwriter.Writer, in UserQuestionAnswer) {
+func easyjson56de76c1EncodeSkillForceInternalModelsDto6(out *jwriter.Writer, in UserQuestionAnswer) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -480,27 +730,27 @@ func easyjson56de76c1EncodeSkillForceInternalModelsDto4(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v UserQuestionAnswer) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeSkillForceInternalModelsDto4(&w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto6(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v UserQuestionAnswer) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeSkillForceInternalModelsDto4(w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto6(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *UserQuestionAnswer) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeSkillForceInternalModelsDto4(&r, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto6(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *UserQuestionAnswer) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeSkillForceInternalModelsDto4(l, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto6(l, v)
 }
-func easyjson56de76c1DecodeSkillForceInternalModelsDto5(in *jlexer.Lexer, out *UserProfileDTO) {
+func easyjson56de76c1DecodeSkillForceInternalModelsDto7(in *jlexer.Lexer, out *UserProfileDTO) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -527,10 +777,53 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto5(in *jlexer.Lexer, out *U
 			out.Bio = string(in.String())
 		case "avatar_src":
 			out.AvatarSrc = string(in.String())
+		case "avatar_sizes":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.AvatarSizes = make(map[string]string)
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v4 string
+					v4 = string(in.String())
+					(out.AvatarSizes)[key] = v4
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
 		case "hide_email":
 			out.HideEmail = bool(in.Bool())
 		case "is_admin":
 			out.IsAdmin = bool(in.Bool())
+		case "roles":
+			if in.IsNull() {
+				in.Skip()
+				out.Roles = nil
+			} else {
+				in.Delim('[')
+				if out.Roles == nil {
+					if !in.IsDelim(']') {
+						out.Roles = make([]string, 0, 4)
+					} else {
+						out.Roles = []string{}
+					}
+				} else {
+					out.Roles = (out.Roles)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v5 string
+					v5 = string(in.String())
+					out.Roles = append(out.Roles, v5)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "locale":
+			out.Locale = string(in.String())
+		case "timezone":
+			out.Timezone = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -541,7 +834,7 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto5(in *jlexer.Lexer, out *U
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeSkillForceInternalModelsDto5(out *jwriter.Writer, in UserProfileDTO) {
+func easyjson56de76c1EncodeSkillForceInternalModelsDto7(out *jwriter.Writer, in UserProfileDTO) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -565,6 +858,27 @@ func easyjson56de76c1EncodeSkillForceInternalModelsDto5(out *jwriter.Writer, in
 		out.RawString(prefix)
 		out.String(string(in.AvatarSrc))
 	}
+	{
+		const prefix string = ",\"avatar_sizes\":"
+		out.RawString(prefix)
+		if in.AvatarSizes == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v6First := true
+			for v6Name, v6Value := range in.AvatarSizes {
+				if v6First {
+					v6First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v6Name))
+				out.RawByte(':')
+				out.String(string(v6Value))
+			}
+			out.RawByte('}')
+		}
+	}
 	{
 		const prefix string = ",\"hide_email\":"
 		out.RawString(prefix)
@@ -575,33 +889,59 @@ func easyjson56de76c1EncodeSkillForceInternalModelsDto5(out *jwriter.Writer, in
 		out.RawString(prefix)
 		out.Bool(bool(in.IsAdmin))
 	}
-	out.RawByte('}')
-}
-
-// MarshalJSON supports json.Marshaler interface
-func (v UserProfileDTO) MarshalJSON() ([]byte, error) {
-	w := jwriter.Writer{}
-	easyjson56de76c1EncodeSkillForceInternalModelsDto5(&w, v)
-	return w.Buffer.BuildBytes(), w.Error
-}
-
-// MarshalEasyJSON supports easyjson.Marshaler interface
-func (v UserProfileDTO) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeSkillForceInternalModelsDto5(w, v)
-}
+	{
+		const prefix string = ",\"roles\":"
+		out.RawString(prefix)
+		if in.Roles == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v7, v8 := range in.Roles {
+				if v7 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v8))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Locale != "" {
+		const prefix string = ",\"locale\":"
+		out.RawString(prefix)
+		out.String(string(in.Locale))
+	}
+	if in.Timezone != "" {
+		const prefix string = ",\"timezone\":"
+		out.RawString(prefix)
+		out.String(string(in.Timezone))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v UserProfileDTO) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeSkillForceInternalModelsDto7(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v UserProfileDTO) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto7(w, v)
+}
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *UserProfileDTO) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeSkillForceInternalModelsDto5(&r, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto7(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *UserProfileDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeSkillForceInternalModelsDto5(l, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto7(l, v)
 }
-func easyjson56de76c1DecodeSkillForceInternalModelsDto6(in *jlexer.Lexer, out *UserDTO) {
+func easyjson56de76c1DecodeSkillForceInternalModelsDto8(in *jlexer.Lexer, out *UserDTO) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -636,7 +976,7 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto6(in *jlexer.Lexer, out *U
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeSkillForceInternalModelsDto6(out *jwriter.Writer, in UserDTO) {
+func easyjson56de76c1EncodeSkillForceInternalModelsDto8(out *jwriter.Writer, in UserDTO) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -661,27 +1001,27 @@ func easyjson56de76c1EncodeSkillForceInternalModelsDto6(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v UserDTO) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeSkillForceInternalModelsDto6(&w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto8(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v UserDTO) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeSkillForceInternalModelsDto6(w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto8(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *UserDTO) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeSkillForceInternalModelsDto6(&r, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto8(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *UserDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeSkillForceInternalModelsDto6(l, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto8(l, v)
 }
-func easyjson56de76c1DecodeSkillForceInternalModelsDto7(in *jlexer.Lexer, out *UserAnswerDTO) {
+func easyjson56de76c1DecodeSkillForceInternalModelsDto9(in *jlexer.Lexer, out *UserAnswerDTO) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -714,7 +1054,7 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto7(in *jlexer.Lexer, out *U
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeSkillForceInternalModelsDto7(out *jwriter.Writer, in UserAnswerDTO) {
+func easyjson56de76c1EncodeSkillForceInternalModelsDto9(out *jwriter.Writer, in UserAnswerDTO) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -734,27 +1074,27 @@ func easyjson56de76c1EncodeSkillForceInternalModelsDto7(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v UserAnswerDTO) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeSkillForceInternalModelsDto7(&w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto9(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v UserAnswerDTO) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeSkillForceInternalModelsDto7(w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto9(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *UserAnswerDTO) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeSkillForceInternalModelsDto7(&r, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto9(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *UserAnswerDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeSkillForceInternalModelsDto7(l, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto9(l, v)
 }
-func easyjson56de76c1DecodeSkillForceInternalModelsDto8(in *jlexer.Lexer, out *UserAnswer) {
+func easyjson56de76c1DecodeSkillForceInternalModelsDto10(in *jlexer.Lexer, out *UserAnswer) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -789,7 +1129,7 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto8(in *jlexer.Lexer, out *U
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeSkillForceInternalModelsDto8(out *jwriter.Writer, in UserAnswer) {
+func easyjson56de76c1EncodeSkillForceInternalModelsDto10(out *jwriter.Writer, in UserAnswer) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -814,27 +1154,27 @@ func easyjson56de76c1EncodeSkillForceInternalModelsDto8(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v UserAnswer) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeSkillForceInternalModelsDto8(&w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto10(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v UserAnswer) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeSkillForceInternalModelsDto8(w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto10(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *UserAnswer) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeSkillForceInternalModelsDto8(&r, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto10(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *UserAnswer) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeSkillForceInternalModelsDto8(l, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto10(l, v)
 }
-func easyjson56de76c1DecodeSkillForceInternalModelsDto9(in *jlexer.Lexer, out *Test) {
+func easyjson56de76c1DecodeSkillForceInternalModelsDto11(in *jlexer.Lexer, out *Test) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -873,17 +1213,17 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto9(in *jlexer.Lexer, out *T
 					out.Answers = (out.Answers)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v1 *QuizAnswer
+					var v9 *QuizAnswer
 					if in.IsNull() {
 						in.Skip()
-						v1 = nil
+						v9 = nil
 					} else {
-						if v1 == nil {
-							v1 = new(QuizAnswer)
+						if v9 == nil {
+							v9 = new(QuizAnswer)
 						}
-						(*v1).UnmarshalEasyJSON(in)
+						(*v9).UnmarshalEasyJSON(in)
 					}
-					out.Answers = append(out.Answers, v1)
+					out.Answers = append(out.Answers, v9)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -900,7 +1240,7 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto9(in *jlexer.Lexer, out *T
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeSkillForceInternalModelsDto9(out *jwriter.Writer, in Test) {
+func easyjson56de76c1EncodeSkillForceInternalModelsDto11(out *jwriter.Writer, in Test) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -921,14 +1261,14 @@ func easyjson56de76c1EncodeSkillForceInternalModelsDto9(out *jwriter.Writer, in
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v2, v3 := range in.Answers {
-				if v2 > 0 {
+			for v10, v11 := range in.Answers {
+				if v10 > 0 {
 					out.RawByte(',')
 				}
-				if v3 == nil {
+				if v11 == nil {
 					out.RawString("null")
 				} else {
-					(*v3).MarshalEasyJSON(out)
+					(*v11).MarshalEasyJSON(out)
 				}
 			}
 			out.RawByte(']')
@@ -945,27 +1285,27 @@ func easyjson56de76c1EncodeSkillForceInternalModelsDto9(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v Test) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeSkillForceInternalModelsDto9(&w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto11(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Test) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeSkillForceInternalModelsDto9(w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto11(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Test) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeSkillForceInternalModelsDto9(&r, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto11(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Test) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeSkillForceInternalModelsDto9(l, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto11(l, v)
 }
-func easyjson56de76c1DecodeSkillForceInternalModelsDto10(in *jlexer.Lexer, out *SurveyMetricsDTO) {
+func easyjson56de76c1DecodeSkillForceInternalModelsDto12(in *jlexer.Lexer, out *SurveyMetricsDTO) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1000,9 +1340,9 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto10(in *jlexer.Lexer, out *
 					out.Metrics = (out.Metrics)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v4 SurveyMetricDTO
-					(v4).UnmarshalEasyJSON(in)
-					out.Metrics = append(out.Metrics, v4)
+					var v12 SurveyMetricDTO
+					(v12).UnmarshalEasyJSON(in)
+					out.Metrics = append(out.Metrics, v12)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -1017,7 +1357,7 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto10(in *jlexer.Lexer, out *
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeSkillForceInternalModelsDto10(out *jwriter.Writer, in SurveyMetricsDTO) {
+func easyjson56de76c1EncodeSkillForceInternalModelsDto12(out *jwriter.Writer, in SurveyMetricsDTO) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1028,11 +1368,11 @@ func easyjson56de76c1EncodeSkillForceInternalModelsDto10(out *jwriter.Writer, in
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v5, v6 := range in.Metrics {
-				if v5 > 0 {
+			for v13, v14 := range in.Metrics {
+				if v13 > 0 {
 					out.RawByte(',')
 				}
-				(v6).MarshalEasyJSON(out)
+				(v14).MarshalEasyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -1043,27 +1383,27 @@ func easyjson56de76c1EncodeSkillForceInternalModelsDto10(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v SurveyMetricsDTO) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeSkillForceInternalModelsDto10(&w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto12(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v SurveyMetricsDTO) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeSkillForceInternalModelsDto10(w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto12(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *SurveyMetricsDTO) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeSkillForceInternalModelsDto10(&r, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto12(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *SurveyMetricsDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeSkillForceInternalModelsDto10(l, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto12(l, v)
 }
-func easyjson56de76c1DecodeSkillForceInternalModelsDto11(in *jlexer.Lexer, out *SurveyMetricDTO) {
+func easyjson56de76c1DecodeSkillForceInternalModelsDto13(in *jlexer.Lexer, out *SurveyMetricDTO) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1104,9 +1444,9 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto11(in *jlexer.Lexer, out *
 					out.Distribution = (out.Distribution)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v7 int
-					v7 = int(in.Int())
-					out.Distribution = append(out.Distribution, v7)
+					var v15 int
+					v15 = int(in.Int())
+					out.Distribution = append(out.Distribution, v15)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -1127,9 +1467,9 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto11(in *jlexer.Lexer, out *
 					out.Answers = (out.Answers)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v8 UserAnswerDTO
-					(v8).UnmarshalEasyJSON(in)
-					out.Answers = append(out.Answers, v8)
+					var v16 UserAnswerDTO
+					(v16).UnmarshalEasyJSON(in)
+					out.Answers = append(out.Answers, v16)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -1144,7 +1484,7 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto11(in *jlexer.Lexer, out *
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeSkillForceInternalModelsDto11(out *jwriter.Writer, in SurveyMetricDTO) {
+func easyjson56de76c1EncodeSkillForceInternalModelsDto13(out *jwriter.Writer, in SurveyMetricDTO) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1170,11 +1510,11 @@ func easyjson56de76c1EncodeSkillForceInternalModelsDto11(out *jwriter.Writer, in
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v9, v10 := range in.Distribution {
-				if v9 > 0 {
+			for v17, v18 := range in.Distribution {
+				if v17 > 0 {
 					out.RawByte(',')
 				}
-				out.Int(int(v10))
+				out.Int(int(v18))
 			}
 			out.RawByte(']')
 		}
@@ -1186,11 +1526,11 @@ func easyjson56de76c1EncodeSkillForceInternalModelsDto11(out *jwriter.Writer, in
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v11, v12 := range in.Answers {
-				if v11 > 0 {
+			for v19, v20 := range in.Answers {
+				if v19 > 0 {
 					out.RawByte(',')
 				}
-				(v12).MarshalEasyJSON(out)
+				(v20).MarshalEasyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -1201,27 +1541,27 @@ func easyjson56de76c1EncodeSkillForceInternalModelsDto11(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v SurveyMetricDTO) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeSkillForceInternalModelsDto11(&w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto13(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v SurveyMetricDTO) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeSkillForceInternalModelsDto11(w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto13(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *SurveyMetricDTO) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeSkillForceInternalModelsDto11(&r, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto13(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *SurveyMetricDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeSkillForceInternalModelsDto11(l, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto13(l, v)
 }
-func easyjson56de76c1DecodeSkillForceInternalModelsDto12(in *jlexer.Lexer, out *SurveyDTO) {
+func easyjson56de76c1DecodeSkillForceInternalModelsDto14(in *jlexer.Lexer, out *SurveyDTO) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1256,9 +1596,9 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto12(in *jlexer.Lexer, out *
 					out.Questions = (out.Questions)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v13 QuestionDTO
-					(v13).UnmarshalEasyJSON(in)
-					out.Questions = append(out.Questions, v13)
+					var v21 QuestionDTO
+					(v21).UnmarshalEasyJSON(in)
+					out.Questions = append(out.Questions, v21)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -1273,7 +1613,7 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto12(in *jlexer.Lexer, out *
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeSkillForceInternalModelsDto12(out *jwriter.Writer, in SurveyDTO) {
+func easyjson56de76c1EncodeSkillForceInternalModelsDto14(out *jwriter.Writer, in SurveyDTO) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1284,11 +1624,11 @@ func easyjson56de76c1EncodeSkillForceInternalModelsDto12(out *jwriter.Writer, in
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v14, v15 := range in.Questions {
-				if v14 > 0 {
+			for v22, v23 := range in.Questions {
+				if v22 > 0 {
 					out.RawByte(',')
 				}
-				(v15).MarshalEasyJSON(out)
+				(v23).MarshalEasyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -1299,27 +1639,27 @@ func easyjson56de76c1EncodeSkillForceInternalModelsDto12(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v SurveyDTO) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeSkillForceInternalModelsDto12(&w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto14(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v SurveyDTO) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeSkillForceInternalModelsDto12(w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto14(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *SurveyDTO) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeSkillForceInternalModelsDto12(&r, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto14(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *SurveyDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeSkillForceInternalModelsDto12(l, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto14(l, v)
 }
-func easyjson56de76c1DecodeSkillForceInternalModelsDto13(in *jlexer.Lexer, out *SurveyAnswerDTO) {
+func easyjson56de76c1DecodeSkillForceInternalModelsDto15(in *jlexer.Lexer, out *SurveyAnswerDTO) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1352,7 +1692,7 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto13(in *jlexer.Lexer, out *
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeSkillForceInternalModelsDto13(out *jwriter.Writer, in SurveyAnswerDTO) {
+func easyjson56de76c1EncodeSkillForceInternalModelsDto15(out *jwriter.Writer, in SurveyAnswerDTO) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1372,27 +1712,27 @@ func easyjson56de76c1EncodeSkillForceInternalModelsDto13(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v SurveyAnswerDTO) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeSkillForceInternalModelsDto13(&w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto15(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v SurveyAnswerDTO) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeSkillForceInternalModelsDto13(w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto15(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *SurveyAnswerDTO) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeSkillForceInternalModelsDto13(&r, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto15(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *SurveyAnswerDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeSkillForceInternalModelsDto13(l, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto15(l, v)
 }
-func easyjson56de76c1DecodeSkillForceInternalModelsDto14(in *jlexer.Lexer, out *RaitingItem) {
+func easyjson56de76c1DecodeSkillForceInternalModelsDto16(in *jlexer.Lexer, out *SetCourseHiddenRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1411,10 +1751,12 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto14(in *jlexer.Lexer, out *
 			continue
 		}
 		switch key {
-		case "user":
-			(out.User).UnmarshalEasyJSON(in)
-		case "rating":
-			out.Rating = int(in.Int())
+		case "course_id":
+			out.CourseId = int(in.Int())
+		case "hidden":
+			out.Hidden = bool(in.Bool())
+		case "reason":
+			out.Reason = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -1425,47 +1767,52 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto14(in *jlexer.Lexer, out *
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeSkillForceInternalModelsDto14(out *jwriter.Writer, in RaitingItem) {
+func easyjson56de76c1EncodeSkillForceInternalModelsDto16(out *jwriter.Writer, in SetCourseHiddenRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"user\":"
+		const prefix string = ",\"course_id\":"
 		out.RawString(prefix[1:])
-		(in.User).MarshalEasyJSON(out)
+		out.Int(int(in.CourseId))
 	}
 	{
-		const prefix string = ",\"rating\":"
+		const prefix string = ",\"hidden\":"
 		out.RawString(prefix)
-		out.Int(int(in.Rating))
+		out.Bool(bool(in.Hidden))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v RaitingItem) MarshalJSON() ([]byte, error) {
+func (v SetCourseHiddenRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeSkillForceInternalModelsDto14(&w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto16(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v RaitingItem) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeSkillForceInternalModelsDto14(w, v)
+func (v SetCourseHiddenRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto16(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *RaitingItem) UnmarshalJSON(data []byte) error {
+func (v *SetCourseHiddenRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeSkillForceInternalModelsDto14(&r, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto16(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *RaitingItem) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeSkillForceInternalModelsDto14(l, v)
+func (v *SetCourseHiddenRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeSkillForceInternalModelsDto16(l, v)
 }
-func easyjson56de76c1DecodeSkillForceInternalModelsDto15(in *jlexer.Lexer, out *Raiting) {
+func easyjson56de76c1DecodeSkillForceInternalModelsDto17(in *jlexer.Lexer, out *RoleDTO) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1484,29 +1831,10 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto15(in *jlexer.Lexer, out *
 			continue
 		}
 		switch key {
-		case "rating":
-			if in.IsNull() {
-				in.Skip()
-				out.Rating = nil
-			} else {
-				in.Delim('[')
-				if out.Rating == nil {
-					if !in.IsDelim(']') {
-						out.Rating = make([]RaitingItem, 0, 0)
-					} else {
-						out.Rating = []RaitingItem{}
-					}
-				} else {
-					out.Rating = (out.Rating)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v16 RaitingItem
-					(v16).UnmarshalEasyJSON(in)
-					out.Rating = append(out.Rating, v16)
-					in.WantComma()
-				}
-				in.Delim(']')
-			}
+		case "user_id":
+			out.UserId = int(in.Int())
+		case "role":
+			out.Role = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -1517,53 +1845,47 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto15(in *jlexer.Lexer, out *
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeSkillForceInternalModelsDto15(out *jwriter.Writer, in Raiting) {
+func easyjson56de76c1EncodeSkillForceInternalModelsDto17(out *jwriter.Writer, in RoleDTO) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"rating\":"
+		const prefix string = ",\"user_id\":"
 		out.RawString(prefix[1:])
-		if in.Rating == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-			out.RawString("null")
-		} else {
-			out.RawByte('[')
-			for v17, v18 := range in.Rating {
-				if v17 > 0 {
-					out.RawByte(',')
-				}
-				(v18).MarshalEasyJSON(out)
-			}
-			out.RawByte(']')
-		}
+		out.Int(int(in.UserId))
+	}
+	{
+		const prefix string = ",\"role\":"
+		out.RawString(prefix)
+		out.String(string(in.Role))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v Raiting) MarshalJSON() ([]byte, error) {
+func (v RoleDTO) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeSkillForceInternalModelsDto15(&w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto17(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v Raiting) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeSkillForceInternalModelsDto15(w, v)
+func (v RoleDTO) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto17(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *Raiting) UnmarshalJSON(data []byte) error {
+func (v *RoleDTO) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeSkillForceInternalModelsDto15(&r, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto17(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *Raiting) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeSkillForceInternalModelsDto15(l, v)
+func (v *RoleDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeSkillForceInternalModelsDto17(l, v)
 }
-func easyjson56de76c1DecodeSkillForceInternalModelsDto16(in *jlexer.Lexer, out *QuizAnswer) {
+func easyjson56de76c1DecodeSkillForceInternalModelsDto18(in *jlexer.Lexer, out *RatingPositionDTO) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1582,12 +1904,14 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto16(in *jlexer.Lexer, out *
 			continue
 		}
 		switch key {
-		case "answer_id":
-			out.AnswerID = int64(in.Int64())
-		case "answer":
-			out.Answer = string(in.String())
-		case "is_right":
-			out.IsRight = bool(in.Bool())
+		case "course_id":
+			out.CourseId = int(in.Int())
+		case "course_title":
+			out.CourseTitle = string(in.String())
+		case "position":
+			out.Position = int(in.Int())
+		case "score":
+			out.Score = int(in.Int())
 		default:
 			in.SkipRecursive()
 		}
@@ -1598,52 +1922,57 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto16(in *jlexer.Lexer, out *
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeSkillForceInternalModelsDto16(out *jwriter.Writer, in QuizAnswer) {
+func easyjson56de76c1EncodeSkillForceInternalModelsDto18(out *jwriter.Writer, in RatingPositionDTO) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"answer_id\":"
+		const prefix string = ",\"course_id\":"
 		out.RawString(prefix[1:])
-		out.Int64(int64(in.AnswerID))
+		out.Int(int(in.CourseId))
 	}
 	{
-		const prefix string = ",\"answer\":"
+		const prefix string = ",\"course_title\":"
 		out.RawString(prefix)
-		out.String(string(in.Answer))
+		out.String(string(in.CourseTitle))
 	}
 	{
-		const prefix string = ",\"is_right\":"
+		const prefix string = ",\"position\":"
 		out.RawString(prefix)
-		out.Bool(bool(in.IsRight))
+		out.Int(int(in.Position))
+	}
+	{
+		const prefix string = ",\"score\":"
+		out.RawString(prefix)
+		out.Int(int(in.Score))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v QuizAnswer) MarshalJSON() ([]byte, error) {
+func (v RatingPositionDTO) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeSkillForceInternalModelsDto16(&w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto18(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v QuizAnswer) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeSkillForceInternalModelsDto16(w, v)
+func (v RatingPositionDTO) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto18(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *QuizAnswer) UnmarshalJSON(data []byte) error {
+func (v *RatingPositionDTO) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeSkillForceInternalModelsDto16(&r, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto18(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *QuizAnswer) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeSkillForceInternalModelsDto16(l, v)
+func (v *RatingPositionDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeSkillForceInternalModelsDto18(l, v)
 }
-func easyjson56de76c1DecodeSkillForceInternalModelsDto17(in *jlexer.Lexer, out *QuestionTest) {
+func easyjson56de76c1DecodeSkillForceInternalModelsDto19(in *jlexer.Lexer, out *RaitingItem) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1662,12 +1991,10 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto17(in *jlexer.Lexer, out *
 			continue
 		}
 		switch key {
-		case "question_id":
-			out.QuestionID = int64(in.Int64())
-		case "question":
-			out.Question = string(in.String())
-		case "user_answer":
-			(out.UserAnswer).UnmarshalEasyJSON(in)
+		case "user":
+			(out.User).UnmarshalEasyJSON(in)
+		case "rating":
+			out.Rating = int(in.Int())
 		default:
 			in.SkipRecursive()
 		}
@@ -1678,52 +2005,47 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto17(in *jlexer.Lexer, out *
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeSkillForceInternalModelsDto17(out *jwriter.Writer, in QuestionTest) {
+func easyjson56de76c1EncodeSkillForceInternalModelsDto19(out *jwriter.Writer, in RaitingItem) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"question_id\":"
+		const prefix string = ",\"user\":"
 		out.RawString(prefix[1:])
-		out.Int64(int64(in.QuestionID))
-	}
-	{
-		const prefix string = ",\"question\":"
-		out.RawString(prefix)
-		out.String(string(in.Question))
+		(in.User).MarshalEasyJSON(out)
 	}
 	{
-		const prefix string = ",\"user_answer\":"
+		const prefix string = ",\"rating\":"
 		out.RawString(prefix)
-		(in.UserAnswer).MarshalEasyJSON(out)
+		out.Int(int(in.Rating))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v QuestionTest) MarshalJSON() ([]byte, error) {
+func (v RaitingItem) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeSkillForceInternalModelsDto17(&w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto19(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v QuestionTest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeSkillForceInternalModelsDto17(w, v)
+func (v RaitingItem) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto19(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *QuestionTest) UnmarshalJSON(data []byte) error {
+func (v *RaitingItem) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeSkillForceInternalModelsDto17(&r, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto19(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *QuestionTest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeSkillForceInternalModelsDto17(l, v)
+func (v *RaitingItem) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeSkillForceInternalModelsDto19(l, v)
 }
-func easyjson56de76c1DecodeSkillForceInternalModelsDto18(in *jlexer.Lexer, out *QuestionDTO) {
+func easyjson56de76c1DecodeSkillForceInternalModelsDto20(in *jlexer.Lexer, out *Raiting) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1742,16 +2064,29 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto18(in *jlexer.Lexer, out *
 			continue
 		}
 		switch key {
-		case "question_id":
-			out.QuestionId = int(in.Int())
-		case "question":
-			out.Question = string(in.String())
-		case "left_lebal":
-			out.LeftLebal = string(in.String())
-		case "right_lebal":
-			out.RightLebal = string(in.String())
-		case "metric":
-			out.Metric = string(in.String())
+		case "rating":
+			if in.IsNull() {
+				in.Skip()
+				out.Rating = nil
+			} else {
+				in.Delim('[')
+				if out.Rating == nil {
+					if !in.IsDelim(']') {
+						out.Rating = make([]RaitingItem, 0, 0)
+					} else {
+						out.Rating = []RaitingItem{}
+					}
+				} else {
+					out.Rating = (out.Rating)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v24 RaitingItem
+					(v24).UnmarshalEasyJSON(in)
+					out.Rating = append(out.Rating, v24)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -1762,62 +2097,213 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto18(in *jlexer.Lexer, out *
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeSkillForceInternalModelsDto18(out *jwriter.Writer, in QuestionDTO) {
+func easyjson56de76c1EncodeSkillForceInternalModelsDto20(out *jwriter.Writer, in Raiting) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"question_id\":"
+		const prefix string = ",\"rating\":"
 		out.RawString(prefix[1:])
-		out.Int(int(in.QuestionId))
+		if in.Rating == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v25, v26 := range in.Rating {
+				if v25 > 0 {
+					out.RawByte(',')
+				}
+				(v26).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Raiting) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeSkillForceInternalModelsDto20(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Raiting) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto20(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Raiting) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeSkillForceInternalModelsDto20(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Raiting) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeSkillForceInternalModelsDto20(l, v)
+}
+func easyjson56de76c1DecodeSkillForceInternalModelsDto21(in *jlexer.Lexer, out *QuizAnswer) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "answer_id":
+			out.AnswerID = int64(in.Int64())
+		case "answer":
+			out.Answer = string(in.String())
+		case "is_right":
+			out.IsRight = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeSkillForceInternalModelsDto21(out *jwriter.Writer, in QuizAnswer) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"answer_id\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.AnswerID))
 	}
 	{
-		const prefix string = ",\"question\":"
+		const prefix string = ",\"answer\":"
 		out.RawString(prefix)
-		out.String(string(in.Question))
+		out.String(string(in.Answer))
 	}
 	{
-		const prefix string = ",\"left_lebal\":"
+		const prefix string = ",\"is_right\":"
 		out.RawString(prefix)
-		out.String(string(in.LeftLebal))
+		out.Bool(bool(in.IsRight))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v QuizAnswer) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeSkillForceInternalModelsDto21(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v QuizAnswer) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto21(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *QuizAnswer) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeSkillForceInternalModelsDto21(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *QuizAnswer) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeSkillForceInternalModelsDto21(l, v)
+}
+func easyjson56de76c1DecodeSkillForceInternalModelsDto22(in *jlexer.Lexer, out *QuestionTest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "question_id":
+			out.QuestionID = int64(in.Int64())
+		case "question":
+			out.Question = string(in.String())
+		case "user_answer":
+			(out.UserAnswer).UnmarshalEasyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
 	}
+}
+func easyjson56de76c1EncodeSkillForceInternalModelsDto22(out *jwriter.Writer, in QuestionTest) {
+	out.RawByte('{')
+	first := true
+	_ = first
 	{
-		const prefix string = ",\"right_lebal\":"
+		const prefix string = ",\"question_id\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.QuestionID))
+	}
+	{
+		const prefix string = ",\"question\":"
 		out.RawString(prefix)
-		out.String(string(in.RightLebal))
+		out.String(string(in.Question))
 	}
 	{
-		const prefix string = ",\"metric\":"
+		const prefix string = ",\"user_answer\":"
 		out.RawString(prefix)
-		out.String(string(in.Metric))
+		(in.UserAnswer).MarshalEasyJSON(out)
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v QuestionDTO) MarshalJSON() ([]byte, error) {
+func (v QuestionTest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeSkillForceInternalModelsDto18(&w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto22(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v QuestionDTO) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeSkillForceInternalModelsDto18(w, v)
+func (v QuestionTest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto22(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *QuestionDTO) UnmarshalJSON(data []byte) error {
+func (v *QuestionTest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeSkillForceInternalModelsDto18(&r, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto22(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *QuestionDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeSkillForceInternalModelsDto18(l, v)
+func (v *QuestionTest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeSkillForceInternalModelsDto22(l, v)
 }
-func easyjson56de76c1DecodeSkillForceInternalModelsDto19(in *jlexer.Lexer, out *LessonPointDTO) {
+func easyjson56de76c1DecodeSkillForceInternalModelsDto23(in *jlexer.Lexer, out *QuestionDTO) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1836,16 +2322,1764 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto19(in *jlexer.Lexer, out *
 			continue
 		}
 		switch key {
-		case "lesson_id":
-			out.LessonId = int(in.Int())
-		case "lesson_type":
-			out.Type = string(in.String())
-		case "lesson_title":
+		case "question_id":
+			out.QuestionId = int(in.Int())
+		case "question":
+			out.Question = string(in.String())
+		case "left_lebal":
+			out.LeftLebal = string(in.String())
+		case "right_lebal":
+			out.RightLebal = string(in.String())
+		case "metric":
+			out.Metric = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeSkillForceInternalModelsDto23(out *jwriter.Writer, in QuestionDTO) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"question_id\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.QuestionId))
+	}
+	{
+		const prefix string = ",\"question\":"
+		out.RawString(prefix)
+		out.String(string(in.Question))
+	}
+	{
+		const prefix string = ",\"left_lebal\":"
+		out.RawString(prefix)
+		out.String(string(in.LeftLebal))
+	}
+	{
+		const prefix string = ",\"right_lebal\":"
+		out.RawString(prefix)
+		out.String(string(in.RightLebal))
+	}
+	{
+		const prefix string = ",\"metric\":"
+		out.RawString(prefix)
+		out.String(string(in.Metric))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v QuestionDTO) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeSkillForceInternalModelsDto23(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v QuestionDTO) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto23(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *QuestionDTO) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeSkillForceInternalModelsDto23(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *QuestionDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeSkillForceInternalModelsDto23(l, v)
+}
+func easyjson56de76c1DecodeSkillForceInternalModelsDto24(in *jlexer.Lexer, out *PublicProfileDTO) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.Id = int(in.Int())
+		case "name":
+			out.Name = string(in.String())
+		case "email":
+			out.Email = string(in.String())
+		case "bio":
+			out.Bio = string(in.String())
+		case "avatar_src":
+			out.AvatarSrc = string(in.String())
+		case "avatar_sizes":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.AvatarSizes = make(map[string]string)
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v27 string
+					v27 = string(in.String())
+					(out.AvatarSizes)[key] = v27
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "roles":
+			if in.IsNull() {
+				in.Skip()
+				out.Roles = nil
+			} else {
+				in.Delim('[')
+				if out.Roles == nil {
+					if !in.IsDelim(']') {
+						out.Roles = make([]string, 0, 4)
+					} else {
+						out.Roles = []string{}
+					}
+				} else {
+					out.Roles = (out.Roles)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v28 string
+					v28 = string(in.String())
+					out.Roles = append(out.Roles, v28)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "authored_courses":
+			if in.IsNull() {
+				in.Skip()
+				out.AuthoredCourses = nil
+			} else {
+				in.Delim('[')
+				if out.AuthoredCourses == nil {
+					if !in.IsDelim(']') {
+						out.AuthoredCourses = make([]*CourseDTO, 0, 8)
+					} else {
+						out.AuthoredCourses = []*CourseDTO{}
+					}
+				} else {
+					out.AuthoredCourses = (out.AuthoredCourses)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v29 *CourseDTO
+					if in.IsNull() {
+						in.Skip()
+						v29 = nil
+					} else {
+						if v29 == nil {
+							v29 = new(CourseDTO)
+						}
+						(*v29).UnmarshalEasyJSON(in)
+					}
+					out.AuthoredCourses = append(out.AuthoredCourses, v29)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "completed_courses":
+			if in.IsNull() {
+				in.Skip()
+				out.CompletedCourses = nil
+			} else {
+				in.Delim('[')
+				if out.CompletedCourses == nil {
+					if !in.IsDelim(']') {
+						out.CompletedCourses = make([]*CompletedCourseDTO, 0, 8)
+					} else {
+						out.CompletedCourses = []*CompletedCourseDTO{}
+					}
+				} else {
+					out.CompletedCourses = (out.CompletedCourses)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v30 *CompletedCourseDTO
+					if in.IsNull() {
+						in.Skip()
+						v30 = nil
+					} else {
+						if v30 == nil {
+							v30 = new(CompletedCourseDTO)
+						}
+						(*v30).UnmarshalEasyJSON(in)
+					}
+					out.CompletedCourses = append(out.CompletedCourses, v30)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "rating_positions":
+			if in.IsNull() {
+				in.Skip()
+				out.RatingPositions = nil
+			} else {
+				in.Delim('[')
+				if out.RatingPositions == nil {
+					if !in.IsDelim(']') {
+						out.RatingPositions = make([]*RatingPositionDTO, 0, 8)
+					} else {
+						out.RatingPositions = []*RatingPositionDTO{}
+					}
+				} else {
+					out.RatingPositions = (out.RatingPositions)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v31 *RatingPositionDTO
+					if in.IsNull() {
+						in.Skip()
+						v31 = nil
+					} else {
+						if v31 == nil {
+							v31 = new(RatingPositionDTO)
+						}
+						(*v31).UnmarshalEasyJSON(in)
+					}
+					out.RatingPositions = append(out.RatingPositions, v31)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "author_stats":
+			if in.IsNull() {
+				in.Skip()
+				out.AuthorStats = nil
+			} else {
+				if out.AuthorStats == nil {
+					out.AuthorStats = new(AuthorStatsDTO)
+				}
+				(*out.AuthorStats).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeSkillForceInternalModelsDto24(out *jwriter.Writer, in PublicProfileDTO) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Id))
+	}
+	{
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	if in.Email != "" {
+		const prefix string = ",\"email\":"
+		out.RawString(prefix)
+		out.String(string(in.Email))
+	}
+	{
+		const prefix string = ",\"bio\":"
+		out.RawString(prefix)
+		out.String(string(in.Bio))
+	}
+	{
+		const prefix string = ",\"avatar_src\":"
+		out.RawString(prefix)
+		out.String(string(in.AvatarSrc))
+	}
+	{
+		const prefix string = ",\"avatar_sizes\":"
+		out.RawString(prefix)
+		if in.AvatarSizes == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v32First := true
+			for v32Name, v32Value := range in.AvatarSizes {
+				if v32First {
+					v32First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v32Name))
+				out.RawByte(':')
+				out.String(string(v32Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	{
+		const prefix string = ",\"roles\":"
+		out.RawString(prefix)
+		if in.Roles == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v33, v34 := range in.Roles {
+				if v33 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v34))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"authored_courses\":"
+		out.RawString(prefix)
+		if in.AuthoredCourses == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v35, v36 := range in.AuthoredCourses {
+				if v35 > 0 {
+					out.RawByte(',')
+				}
+				if v36 == nil {
+					out.RawString("null")
+				} else {
+					(*v36).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"completed_courses\":"
+		out.RawString(prefix)
+		if in.CompletedCourses == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v37, v38 := range in.CompletedCourses {
+				if v37 > 0 {
+					out.RawByte(',')
+				}
+				if v38 == nil {
+					out.RawString("null")
+				} else {
+					(*v38).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"rating_positions\":"
+		out.RawString(prefix)
+		if in.RatingPositions == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v39, v40 := range in.RatingPositions {
+				if v39 > 0 {
+					out.RawByte(',')
+				}
+				if v40 == nil {
+					out.RawString("null")
+				} else {
+					(*v40).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.AuthorStats != nil {
+		const prefix string = ",\"author_stats\":"
+		out.RawString(prefix)
+		(*in.AuthorStats).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PublicProfileDTO) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeSkillForceInternalModelsDto24(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PublicProfileDTO) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto24(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PublicProfileDTO) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeSkillForceInternalModelsDto24(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PublicProfileDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeSkillForceInternalModelsDto24(l, v)
+}
+func easyjson56de76c1DecodeSkillForceInternalModelsDto25(in *jlexer.Lexer, out *NotificationPreferencesDTO) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "preferences":
+			if in.IsNull() {
+				in.Skip()
+				out.Preferences = nil
+			} else {
+				in.Delim('[')
+				if out.Preferences == nil {
+					if !in.IsDelim(']') {
+						out.Preferences = make([]*NotificationPreferenceDTO, 0, 8)
+					} else {
+						out.Preferences = []*NotificationPreferenceDTO{}
+					}
+				} else {
+					out.Preferences = (out.Preferences)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v41 *NotificationPreferenceDTO
+					if in.IsNull() {
+						in.Skip()
+						v41 = nil
+					} else {
+						if v41 == nil {
+							v41 = new(NotificationPreferenceDTO)
+						}
+						(*v41).UnmarshalEasyJSON(in)
+					}
+					out.Preferences = append(out.Preferences, v41)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeSkillForceInternalModelsDto25(out *jwriter.Writer, in NotificationPreferencesDTO) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"preferences\":"
+		out.RawString(prefix[1:])
+		if in.Preferences == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v42, v43 := range in.Preferences {
+				if v42 > 0 {
+					out.RawByte(',')
+				}
+				if v43 == nil {
+					out.RawString("null")
+				} else {
+					(*v43).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v NotificationPreferencesDTO) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeSkillForceInternalModelsDto25(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v NotificationPreferencesDTO) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto25(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *NotificationPreferencesDTO) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeSkillForceInternalModelsDto25(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *NotificationPreferencesDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeSkillForceInternalModelsDto25(l, v)
+}
+func easyjson56de76c1DecodeSkillForceInternalModelsDto26(in *jlexer.Lexer, out *NotificationPreferenceDTO) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "category":
+			out.Category = string(in.String())
+		case "enabled":
+			out.Enabled = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeSkillForceInternalModelsDto26(out *jwriter.Writer, in NotificationPreferenceDTO) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"category\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Category))
+	}
+	{
+		const prefix string = ",\"enabled\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Enabled))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v NotificationPreferenceDTO) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeSkillForceInternalModelsDto26(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v NotificationPreferenceDTO) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto26(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *NotificationPreferenceDTO) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeSkillForceInternalModelsDto26(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *NotificationPreferenceDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeSkillForceInternalModelsDto26(l, v)
+}
+func easyjson56de76c1DecodeSkillForceInternalModelsDto27(in *jlexer.Lexer, out *NotificationDTO) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.Id = int64(in.Int64())
+		case "kind":
+			out.Kind = string(in.String())
+		case "course_id":
+			out.CourseId = int(in.Int())
+		case "course_name":
+			out.CourseName = string(in.String())
+		case "url":
+			out.Url = string(in.String())
+		case "created_at":
+			out.CreatedAt = string(in.String())
+		case "is_read":
+			out.IsRead = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeSkillForceInternalModelsDto27(out *jwriter.Writer, in NotificationDTO) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.Id))
+	}
+	{
+		const prefix string = ",\"kind\":"
+		out.RawString(prefix)
+		out.String(string(in.Kind))
+	}
+	if in.CourseId != 0 {
+		const prefix string = ",\"course_id\":"
+		out.RawString(prefix)
+		out.Int(int(in.CourseId))
+	}
+	if in.CourseName != "" {
+		const prefix string = ",\"course_name\":"
+		out.RawString(prefix)
+		out.String(string(in.CourseName))
+	}
+	if in.Url != "" {
+		const prefix string = ",\"url\":"
+		out.RawString(prefix)
+		out.String(string(in.Url))
+	}
+	{
+		const prefix string = ",\"created_at\":"
+		out.RawString(prefix)
+		out.String(string(in.CreatedAt))
+	}
+	{
+		const prefix string = ",\"is_read\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsRead))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v NotificationDTO) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeSkillForceInternalModelsDto27(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v NotificationDTO) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto27(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *NotificationDTO) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeSkillForceInternalModelsDto27(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *NotificationDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeSkillForceInternalModelsDto27(l, v)
+}
+func easyjson56de76c1DecodeSkillForceInternalModelsDto28(in *jlexer.Lexer, out *MarkNotificationsReadDTO) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "ids":
+			if in.IsNull() {
+				in.Skip()
+				out.Ids = nil
+			} else {
+				in.Delim('[')
+				if out.Ids == nil {
+					if !in.IsDelim(']') {
+						out.Ids = make([]int64, 0, 8)
+					} else {
+						out.Ids = []int64{}
+					}
+				} else {
+					out.Ids = (out.Ids)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v44 int64
+					v44 = int64(in.Int64())
+					out.Ids = append(out.Ids, v44)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "all":
+			out.All = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeSkillForceInternalModelsDto28(out *jwriter.Writer, in MarkNotificationsReadDTO) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"ids\":"
+		out.RawString(prefix[1:])
+		if in.Ids == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v45, v46 := range in.Ids {
+				if v45 > 0 {
+					out.RawByte(',')
+				}
+				out.Int64(int64(v46))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"all\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.All))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v MarkNotificationsReadDTO) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeSkillForceInternalModelsDto28(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MarkNotificationsReadDTO) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto28(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MarkNotificationsReadDTO) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeSkillForceInternalModelsDto28(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MarkNotificationsReadDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeSkillForceInternalModelsDto28(l, v)
+}
+func easyjson56de76c1DecodeSkillForceInternalModelsDto29(in *jlexer.Lexer, out *MarkLessonsCompletedRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "course_id":
+			out.CourseId = int(in.Int())
+		case "lesson_ids":
+			if in.IsNull() {
+				in.Skip()
+				out.LessonIds = nil
+			} else {
+				in.Delim('[')
+				if out.LessonIds == nil {
+					if !in.IsDelim(']') {
+						out.LessonIds = make([]int, 0, 8)
+					} else {
+						out.LessonIds = []int{}
+					}
+				} else {
+					out.LessonIds = (out.LessonIds)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v47 int
+					v47 = int(in.Int())
+					out.LessonIds = append(out.LessonIds, v47)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeSkillForceInternalModelsDto29(out *jwriter.Writer, in MarkLessonsCompletedRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"course_id\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.CourseId))
+	}
+	{
+		const prefix string = ",\"lesson_ids\":"
+		out.RawString(prefix)
+		if in.LessonIds == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v48, v49 := range in.LessonIds {
+				if v48 > 0 {
+					out.RawByte(',')
+				}
+				out.Int(int(v49))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v MarkLessonsCompletedRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeSkillForceInternalModelsDto29(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MarkLessonsCompletedRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto29(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MarkLessonsCompletedRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeSkillForceInternalModelsDto29(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MarkLessonsCompletedRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeSkillForceInternalModelsDto29(l, v)
+}
+func easyjson56de76c1DecodeSkillForceInternalModelsDto30(in *jlexer.Lexer, out *LessonPointDTO) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "lesson_id":
+			out.LessonId = int(in.Int())
+		case "lesson_type":
+			out.Type = string(in.String())
+		case "lesson_title":
+			out.Title = string(in.String())
+		case "lesson_value":
+			out.Value = string(in.String())
+		case "is_done":
+			out.IsDone = bool(in.Bool())
+		case "blocks":
+			if in.IsNull() {
+				in.Skip()
+				out.Blocks = nil
+			} else {
+				in.Delim('[')
+				if out.Blocks == nil {
+					if !in.IsDelim(']') {
+						out.Blocks = make([]LessonBlockDTO, 0, 0)
+					} else {
+						out.Blocks = []LessonBlockDTO{}
+					}
+				} else {
+					out.Blocks = (out.Blocks)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v50 LessonBlockDTO
+					(v50).UnmarshalEasyJSON(in)
+					out.Blocks = append(out.Blocks, v50)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeSkillForceInternalModelsDto30(out *jwriter.Writer, in LessonPointDTO) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"lesson_id\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.LessonId))
+	}
+	{
+		const prefix string = ",\"lesson_type\":"
+		out.RawString(prefix)
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"lesson_title\":"
+		out.RawString(prefix)
+		out.String(string(in.Title))
+	}
+	{
+		const prefix string = ",\"lesson_value\":"
+		out.RawString(prefix)
+		out.String(string(in.Value))
+	}
+	{
+		const prefix string = ",\"is_done\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsDone))
+	}
+	if len(in.Blocks) != 0 {
+		const prefix string = ",\"blocks\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v51, v52 := range in.Blocks {
+				if v51 > 0 {
+					out.RawByte(',')
+				}
+				(v52).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v LessonPointDTO) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeSkillForceInternalModelsDto30(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v LessonPointDTO) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto30(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *LessonPointDTO) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeSkillForceInternalModelsDto30(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *LessonPointDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeSkillForceInternalModelsDto30(l, v)
+}
+func easyjson56de76c1DecodeSkillForceInternalModelsDto31(in *jlexer.Lexer, out *LessonIDRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "lesson_id":
+			out.Id = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeSkillForceInternalModelsDto31(out *jwriter.Writer, in LessonIDRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"lesson_id\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Id))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v LessonIDRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeSkillForceInternalModelsDto31(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v LessonIDRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto31(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *LessonIDRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeSkillForceInternalModelsDto31(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *LessonIDRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeSkillForceInternalModelsDto31(l, v)
+}
+func easyjson56de76c1DecodeSkillForceInternalModelsDto32(in *jlexer.Lexer, out *LessonFileDTO) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "object":
+			out.Object = string(in.String())
+		case "kind":
+			out.Kind = string(in.String())
+		case "file_name":
+			out.FileName = string(in.String())
+		case "content_type":
+			out.ContentType = string(in.String())
+		case "size":
+			out.Size = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeSkillForceInternalModelsDto32(out *jwriter.Writer, in LessonFileDTO) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"object\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Object))
+	}
+	{
+		const prefix string = ",\"kind\":"
+		out.RawString(prefix)
+		out.String(string(in.Kind))
+	}
+	{
+		const prefix string = ",\"file_name\":"
+		out.RawString(prefix)
+		out.String(string(in.FileName))
+	}
+	{
+		const prefix string = ",\"content_type\":"
+		out.RawString(prefix)
+		out.String(string(in.ContentType))
+	}
+	{
+		const prefix string = ",\"size\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Size))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v LessonFileDTO) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeSkillForceInternalModelsDto32(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v LessonFileDTO) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto32(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *LessonFileDTO) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeSkillForceInternalModelsDto32(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *LessonFileDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeSkillForceInternalModelsDto32(l, v)
+}
+func easyjson56de76c1DecodeSkillForceInternalModelsDto33(in *jlexer.Lexer, out *LessonDtoHeader) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "course_title":
+			out.CourseTitle = string(in.String())
+		case "course_id":
+			out.CourseId = int(in.Int())
+		case "part":
+			easyjson56de76c1Decode1(in, &out.Part)
+		case "bucket":
+			easyjson56de76c1Decode1(in, &out.Bucket)
+		case "Points":
+			if in.IsNull() {
+				in.Skip()
+				out.Points = nil
+			} else {
+				in.Delim('[')
+				if out.Points == nil {
+					if !in.IsDelim(']') {
+						out.Points = make([]struct {
+							LessonId int    `json:"lesson_id"`
+							Type     string `json:"type"`
+							IsDone   bool   `json:"is_done"`
+						}, 0, 2)
+					} else {
+						out.Points = []struct {
+							LessonId int    `json:"lesson_id"`
+							Type     string `json:"type"`
+							IsDone   bool   `json:"is_done"`
+						}{}
+					}
+				} else {
+					out.Points = (out.Points)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v53 struct {
+						LessonId int    `json:"lesson_id"`
+						Type     string `json:"type"`
+						IsDone   bool   `json:"is_done"`
+					}
+					easyjson56de76c1Decode2(in, &v53)
+					out.Points = append(out.Points, v53)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeSkillForceInternalModelsDto33(out *jwriter.Writer, in LessonDtoHeader) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"course_title\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.CourseTitle))
+	}
+	{
+		const prefix string = ",\"course_id\":"
+		out.RawString(prefix)
+		out.Int(int(in.CourseId))
+	}
+	{
+		const prefix string = ",\"part\":"
+		out.RawString(prefix)
+		easyjson56de76c1Encode1(out, in.Part)
+	}
+	{
+		const prefix string = ",\"bucket\":"
+		out.RawString(prefix)
+		easyjson56de76c1Encode1(out, in.Bucket)
+	}
+	{
+		const prefix string = ",\"Points\":"
+		out.RawString(prefix)
+		if in.Points == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v54, v55 := range in.Points {
+				if v54 > 0 {
+					out.RawByte(',')
+				}
+				easyjson56de76c1Encode2(out, v55)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v LessonDtoHeader) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeSkillForceInternalModelsDto33(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v LessonDtoHeader) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto33(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *LessonDtoHeader) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeSkillForceInternalModelsDto33(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *LessonDtoHeader) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeSkillForceInternalModelsDto33(l, v)
+}
+func easyjson56de76c1Decode2(in *jlexer.Lexer, out *struct {
+	LessonId int    `json:"lesson_id"`
+	Type     string `json:"type"`
+	IsDone   bool   `json:"is_done"`
+}) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "lesson_id":
+			out.LessonId = int(in.Int())
+		case "type":
+			out.Type = string(in.String())
+		case "is_done":
+			out.IsDone = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1Encode2(out *jwriter.Writer, in struct {
+	LessonId int    `json:"lesson_id"`
+	Type     string `json:"type"`
+	IsDone   bool   `json:"is_done"`
+}) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"lesson_id\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.LessonId))
+	}
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"is_done\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsDone))
+	}
+	out.RawByte('}')
+}
+func easyjson56de76c1Decode1(in *jlexer.Lexer, out *struct {
+	Order int    `json:"order"`
+	Title string `json:"title"`
+}) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "order":
+			out.Order = int(in.Int())
+		case "title":
+			out.Title = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1Encode1(out *jwriter.Writer, in struct {
+	Order int    `json:"order"`
+	Title string `json:"title"`
+}) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"order\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Order))
+	}
+	{
+		const prefix string = ",\"title\":"
+		out.RawString(prefix)
+		out.String(string(in.Title))
+	}
+	out.RawByte('}')
+}
+func easyjson56de76c1DecodeSkillForceInternalModelsDto34(in *jlexer.Lexer, out *LessonDtoBody) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "blocks":
+			if in.IsNull() {
+				in.Skip()
+				out.Blocks = nil
+			} else {
+				in.Delim('[')
+				if out.Blocks == nil {
+					if !in.IsDelim(']') {
+						out.Blocks = make([]LessonBlockDTO, 0, 0)
+					} else {
+						out.Blocks = []LessonBlockDTO{}
+					}
+				} else {
+					out.Blocks = (out.Blocks)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v56 LessonBlockDTO
+					(v56).UnmarshalEasyJSON(in)
+					out.Blocks = append(out.Blocks, v56)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "footer":
+			easyjson56de76c1Decode3(in, &out.Footer)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeSkillForceInternalModelsDto34(out *jwriter.Writer, in LessonDtoBody) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"blocks\":"
+		out.RawString(prefix[1:])
+		if in.Blocks == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v57, v58 := range in.Blocks {
+				if v57 > 0 {
+					out.RawByte(',')
+				}
+				(v58).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"footer\":"
+		out.RawString(prefix)
+		easyjson56de76c1Encode3(out, in.Footer)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v LessonDtoBody) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeSkillForceInternalModelsDto34(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v LessonDtoBody) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto34(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *LessonDtoBody) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeSkillForceInternalModelsDto34(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *LessonDtoBody) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeSkillForceInternalModelsDto34(l, v)
+}
+func easyjson56de76c1Decode3(in *jlexer.Lexer, out *struct {
+	NextLessonId     int `json:"next_lesson_id"`
+	CurrentLessonId  int `json:"current_lesson_id"`
+	PreviousLessonId int `json:"previous_lesson_id"`
+}) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "next_lesson_id":
+			out.NextLessonId = int(in.Int())
+		case "current_lesson_id":
+			out.CurrentLessonId = int(in.Int())
+		case "previous_lesson_id":
+			out.PreviousLessonId = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1Encode3(out *jwriter.Writer, in struct {
+	NextLessonId     int `json:"next_lesson_id"`
+	CurrentLessonId  int `json:"current_lesson_id"`
+	PreviousLessonId int `json:"previous_lesson_id"`
+}) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"next_lesson_id\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.NextLessonId))
+	}
+	{
+		const prefix string = ",\"current_lesson_id\":"
+		out.RawString(prefix)
+		out.Int(int(in.CurrentLessonId))
+	}
+	{
+		const prefix string = ",\"previous_lesson_id\":"
+		out.RawString(prefix)
+		out.Int(int(in.PreviousLessonId))
+	}
+	out.RawByte('}')
+}
+func easyjson56de76c1DecodeSkillForceInternalModelsDto35(in *jlexer.Lexer, out *LessonDTO) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "header":
+			(out.LessonHeader).UnmarshalEasyJSON(in)
+		case "lesson_body":
+			(out.LessonBody).UnmarshalEasyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson56de76c1EncodeSkillForceInternalModelsDto35(out *jwriter.Writer, in LessonDTO) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"header\":"
+		out.RawString(prefix[1:])
+		(in.LessonHeader).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"lesson_body\":"
+		out.RawString(prefix)
+		(in.LessonBody).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v LessonDTO) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeSkillForceInternalModelsDto35(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v LessonDTO) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto35(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *LessonDTO) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeSkillForceInternalModelsDto35(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *LessonDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeSkillForceInternalModelsDto35(l, v)
+}
+func easyjson56de76c1DecodeSkillForceInternalModelsDto36(in *jlexer.Lexer, out *LessonBucketDTO) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "bucket_id":
+			out.Id = int(in.Int())
+		case "bucket_title":
 			out.Title = string(in.String())
-		case "lesson_value":
-			out.Value = string(in.String())
-		case "is_done":
-			out.IsDone = bool(in.Bool())
+		case "lessons":
+			if in.IsNull() {
+				in.Skip()
+				out.Lessons = nil
+			} else {
+				in.Delim('[')
+				if out.Lessons == nil {
+					if !in.IsDelim(']') {
+						out.Lessons = make([]*LessonPointDTO, 0, 8)
+					} else {
+						out.Lessons = []*LessonPointDTO{}
+					}
+				} else {
+					out.Lessons = (out.Lessons)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v59 *LessonPointDTO
+					if in.IsNull() {
+						in.Skip()
+						v59 = nil
+					} else {
+						if v59 == nil {
+							v59 = new(LessonPointDTO)
+						}
+						(*v59).UnmarshalEasyJSON(in)
+					}
+					out.Lessons = append(out.Lessons, v59)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -1856,62 +4090,67 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto19(in *jlexer.Lexer, out *
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeSkillForceInternalModelsDto19(out *jwriter.Writer, in LessonPointDTO) {
+func easyjson56de76c1EncodeSkillForceInternalModelsDto36(out *jwriter.Writer, in LessonBucketDTO) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"lesson_id\":"
+		const prefix string = ",\"bucket_id\":"
 		out.RawString(prefix[1:])
-		out.Int(int(in.LessonId))
-	}
-	{
-		const prefix string = ",\"lesson_type\":"
-		out.RawString(prefix)
-		out.String(string(in.Type))
+		out.Int(int(in.Id))
 	}
 	{
-		const prefix string = ",\"lesson_title\":"
+		const prefix string = ",\"bucket_title\":"
 		out.RawString(prefix)
 		out.String(string(in.Title))
 	}
 	{
-		const prefix string = ",\"lesson_value\":"
-		out.RawString(prefix)
-		out.String(string(in.Value))
-	}
-	{
-		const prefix string = ",\"is_done\":"
+		const prefix string = ",\"lessons\":"
 		out.RawString(prefix)
-		out.Bool(bool(in.IsDone))
+		if in.Lessons == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v60, v61 := range in.Lessons {
+				if v60 > 0 {
+					out.RawByte(',')
+				}
+				if v61 == nil {
+					out.RawString("null")
+				} else {
+					(*v61).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v LessonPointDTO) MarshalJSON() ([]byte, error) {
+func (v LessonBucketDTO) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeSkillForceInternalModelsDto19(&w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto36(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v LessonPointDTO) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeSkillForceInternalModelsDto19(w, v)
+func (v LessonBucketDTO) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto36(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *LessonPointDTO) UnmarshalJSON(data []byte) error {
+func (v *LessonBucketDTO) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeSkillForceInternalModelsDto19(&r, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto36(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *LessonPointDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeSkillForceInternalModelsDto19(l, v)
+func (v *LessonBucketDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeSkillForceInternalModelsDto36(l, v)
 }
-func easyjson56de76c1DecodeSkillForceInternalModelsDto20(in *jlexer.Lexer, out *LessonIDRequest) {
+func easyjson56de76c1DecodeSkillForceInternalModelsDto37(in *jlexer.Lexer, out *LessonBlocksDTO) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1930,8 +4169,29 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto20(in *jlexer.Lexer, out *
 			continue
 		}
 		switch key {
-		case "lesson_id":
-			out.Id = int(in.Int())
+		case "blocks":
+			if in.IsNull() {
+				in.Skip()
+				out.Blocks = nil
+			} else {
+				in.Delim('[')
+				if out.Blocks == nil {
+					if !in.IsDelim(']') {
+						out.Blocks = make([]LessonBlockDTO, 0, 0)
+					} else {
+						out.Blocks = []LessonBlockDTO{}
+					}
+				} else {
+					out.Blocks = (out.Blocks)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v62 LessonBlockDTO
+					(v62).UnmarshalEasyJSON(in)
+					out.Blocks = append(out.Blocks, v62)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -1942,42 +4202,53 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto20(in *jlexer.Lexer, out *
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeSkillForceInternalModelsDto20(out *jwriter.Writer, in LessonIDRequest) {
+func easyjson56de76c1EncodeSkillForceInternalModelsDto37(out *jwriter.Writer, in LessonBlocksDTO) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"lesson_id\":"
+		const prefix string = ",\"blocks\":"
 		out.RawString(prefix[1:])
-		out.Int(int(in.Id))
+		if in.Blocks == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v63, v64 := range in.Blocks {
+				if v63 > 0 {
+					out.RawByte(',')
+				}
+				(v64).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v LessonIDRequest) MarshalJSON() ([]byte, error) {
+func (v LessonBlocksDTO) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeSkillForceInternalModelsDto20(&w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto37(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v LessonIDRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeSkillForceInternalModelsDto20(w, v)
+func (v LessonBlocksDTO) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto37(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *LessonIDRequest) UnmarshalJSON(data []byte) error {
+func (v *LessonBlocksDTO) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeSkillForceInternalModelsDto20(&r, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto37(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *LessonIDRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeSkillForceInternalModelsDto20(l, v)
+func (v *LessonBlocksDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeSkillForceInternalModelsDto37(l, v)
 }
-func easyjson56de76c1DecodeSkillForceInternalModelsDto21(in *jlexer.Lexer, out *LessonDtoHeader) {
+func easyjson56de76c1DecodeSkillForceInternalModelsDto38(in *jlexer.Lexer, out *LessonBlockDTO) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1996,49 +4267,73 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto21(in *jlexer.Lexer, out *
 			continue
 		}
 		switch key {
-		case "course_title":
-			out.CourseTitle = string(in.String())
-		case "course_id":
-			out.CourseId = int(in.Int())
-		case "part":
-			easyjson56de76c1Decode1(in, &out.Part)
-		case "bucket":
-			easyjson56de76c1Decode1(in, &out.Bucket)
-		case "Points":
+		case "type":
+			out.Type = string(in.String())
+		case "body":
+			out.Body = string(in.String())
+		case "text":
+			out.Text = string(in.String())
+		case "object":
+			out.Object = string(in.String())
+		case "file_name":
+			out.FileName = string(in.String())
+		case "content_type":
+			out.ContentType = string(in.String())
+		case "size":
+			out.Size = int64(in.Int64())
+		case "alt":
+			out.Alt = string(in.String())
+		case "caption":
+			out.Caption = string(in.String())
+		case "language":
+			out.Language = string(in.String())
+		case "code":
+			out.Code = string(in.String())
+		case "variant":
+			out.Variant = string(in.String())
+		case "url":
+			out.Url = string(in.String())
+		case "provider":
+			out.Provider = string(in.String())
+		case "video_id":
+			out.VideoId = string(in.String())
+		case "embed_url":
+			out.EmbedUrl = string(in.String())
+		case "question":
+			out.Question = string(in.String())
+		case "options":
 			if in.IsNull() {
 				in.Skip()
-				out.Points = nil
+				out.Options = nil
 			} else {
 				in.Delim('[')
-				if out.Points == nil {
+				if out.Options == nil {
 					if !in.IsDelim(']') {
-						out.Points = make([]struct {
-							LessonId int    `json:"lesson_id"`
-							Type     string `json:"type"`
-							IsDone   bool   `json:"is_done"`
-						}, 0, 2)
+						out.Options = make([]string, 0, 4)
 					} else {
-						out.Points = []struct {
-							LessonId int    `json:"lesson_id"`
-							Type     string `json:"type"`
-							IsDone   bool   `json:"is_done"`
-						}{}
+						out.Options = []string{}
 					}
 				} else {
-					out.Points = (out.Points)[:0]
+					out.Options = (out.Options)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v19 struct {
-						LessonId int    `json:"lesson_id"`
-						Type     string `json:"type"`
-						IsDone   bool   `json:"is_done"`
-					}
-					easyjson56de76c1Decode2(in, &v19)
-					out.Points = append(out.Points, v19)
+					var v65 string
+					v65 = string(in.String())
+					out.Options = append(out.Options, v65)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
+		case "answer":
+			if in.IsNull() {
+				in.Skip()
+				out.Answer = nil
+			} else {
+				if out.Answer == nil {
+					out.Answer = new(int)
+				}
+				*out.Answer = int(in.Int())
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -2049,77 +4344,147 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto21(in *jlexer.Lexer, out *
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeSkillForceInternalModelsDto21(out *jwriter.Writer, in LessonDtoHeader) {
+func easyjson56de76c1EncodeSkillForceInternalModelsDto38(out *jwriter.Writer, in LessonBlockDTO) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	{
-		const prefix string = ",\"course_title\":"
+	if in.Type != "" {
+		const prefix string = ",\"type\":"
+		first = false
 		out.RawString(prefix[1:])
-		out.String(string(in.CourseTitle))
+		out.String(string(in.Type))
 	}
 	{
-		const prefix string = ",\"course_id\":"
+		const prefix string = ",\"body\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Body))
+	}
+	if in.Text != "" {
+		const prefix string = ",\"text\":"
 		out.RawString(prefix)
-		out.Int(int(in.CourseId))
+		out.String(string(in.Text))
 	}
-	{
-		const prefix string = ",\"part\":"
+	if in.Object != "" {
+		const prefix string = ",\"object\":"
 		out.RawString(prefix)
-		easyjson56de76c1Encode1(out, in.Part)
+		out.String(string(in.Object))
 	}
-	{
-		const prefix string = ",\"bucket\":"
+	if in.FileName != "" {
+		const prefix string = ",\"file_name\":"
 		out.RawString(prefix)
-		easyjson56de76c1Encode1(out, in.Bucket)
+		out.String(string(in.FileName))
 	}
-	{
-		const prefix string = ",\"Points\":"
+	if in.ContentType != "" {
+		const prefix string = ",\"content_type\":"
 		out.RawString(prefix)
-		if in.Points == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-			out.RawString("null")
-		} else {
+		out.String(string(in.ContentType))
+	}
+	if in.Size != 0 {
+		const prefix string = ",\"size\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Size))
+	}
+	if in.Alt != "" {
+		const prefix string = ",\"alt\":"
+		out.RawString(prefix)
+		out.String(string(in.Alt))
+	}
+	if in.Caption != "" {
+		const prefix string = ",\"caption\":"
+		out.RawString(prefix)
+		out.String(string(in.Caption))
+	}
+	if in.Language != "" {
+		const prefix string = ",\"language\":"
+		out.RawString(prefix)
+		out.String(string(in.Language))
+	}
+	if in.Code != "" {
+		const prefix string = ",\"code\":"
+		out.RawString(prefix)
+		out.String(string(in.Code))
+	}
+	if in.Variant != "" {
+		const prefix string = ",\"variant\":"
+		out.RawString(prefix)
+		out.String(string(in.Variant))
+	}
+	if in.Url != "" {
+		const prefix string = ",\"url\":"
+		out.RawString(prefix)
+		out.String(string(in.Url))
+	}
+	if in.Provider != "" {
+		const prefix string = ",\"provider\":"
+		out.RawString(prefix)
+		out.String(string(in.Provider))
+	}
+	if in.VideoId != "" {
+		const prefix string = ",\"video_id\":"
+		out.RawString(prefix)
+		out.String(string(in.VideoId))
+	}
+	if in.EmbedUrl != "" {
+		const prefix string = ",\"embed_url\":"
+		out.RawString(prefix)
+		out.String(string(in.EmbedUrl))
+	}
+	if in.Question != "" {
+		const prefix string = ",\"question\":"
+		out.RawString(prefix)
+		out.String(string(in.Question))
+	}
+	if len(in.Options) != 0 {
+		const prefix string = ",\"options\":"
+		out.RawString(prefix)
+		{
 			out.RawByte('[')
-			for v20, v21 := range in.Points {
-				if v20 > 0 {
+			for v66, v67 := range in.Options {
+				if v66 > 0 {
 					out.RawByte(',')
 				}
-				easyjson56de76c1Encode2(out, v21)
+				out.String(string(v67))
 			}
 			out.RawByte(']')
 		}
 	}
+	if in.Answer != nil {
+		const prefix string = ",\"answer\":"
+		out.RawString(prefix)
+		out.Int(int(*in.Answer))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v LessonDtoHeader) MarshalJSON() ([]byte, error) {
+func (v LessonBlockDTO) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeSkillForceInternalModelsDto21(&w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto38(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v LessonDtoHeader) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeSkillForceInternalModelsDto21(w, v)
+func (v LessonBlockDTO) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto38(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *LessonDtoHeader) UnmarshalJSON(data []byte) error {
+func (v *LessonBlockDTO) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeSkillForceInternalModelsDto21(&r, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto38(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *LessonDtoHeader) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeSkillForceInternalModelsDto21(l, v)
+func (v *LessonBlockDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeSkillForceInternalModelsDto38(l, v)
 }
-func easyjson56de76c1Decode2(in *jlexer.Lexer, out *struct {
-	LessonId int    `json:"lesson_id"`
-	Type     string `json:"type"`
-	IsDone   bool   `json:"is_done"`
-}) {
+func easyjson56de76c1DecodeSkillForceInternalModelsDto39(in *jlexer.Lexer, out *InitVideoUploadDTO) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2140,10 +4505,10 @@ func easyjson56de76c1Decode2(in *jlexer.Lexer, out *struct {
 		switch key {
 		case "lesson_id":
 			out.LessonId = int(in.Int())
-		case "type":
-			out.Type = string(in.String())
-		case "is_done":
-			out.IsDone = bool(in.Bool())
+		case "content_type":
+			out.ContentType = string(in.String())
+		case "size":
+			out.Size = int64(in.Int64())
 		default:
 			in.SkipRecursive()
 		}
@@ -2154,11 +4519,7 @@ func easyjson56de76c1Decode2(in *jlexer.Lexer, out *struct {
 		in.Consumed()
 	}
 }
-func easyjson56de76c1Encode2(out *jwriter.Writer, in struct {
-	LessonId int    `json:"lesson_id"`
-	Type     string `json:"type"`
-	IsDone   bool   `json:"is_done"`
-}) {
+func easyjson56de76c1EncodeSkillForceInternalModelsDto39(out *jwriter.Writer, in InitVideoUploadDTO) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -2168,21 +4529,42 @@ func easyjson56de76c1Encode2(out *jwriter.Writer, in struct {
 		out.Int(int(in.LessonId))
 	}
 	{
-		const prefix string = ",\"type\":"
+		const prefix string = ",\"content_type\":"
 		out.RawString(prefix)
-		out.String(string(in.Type))
+		out.String(string(in.ContentType))
 	}
 	{
-		const prefix string = ",\"is_done\":"
+		const prefix string = ",\"size\":"
 		out.RawString(prefix)
-		out.Bool(bool(in.IsDone))
+		out.Int64(int64(in.Size))
 	}
 	out.RawByte('}')
 }
-func easyjson56de76c1Decode1(in *jlexer.Lexer, out *struct {
-	Order int    `json:"order"`
-	Title string `json:"title"`
-}) {
+
+// MarshalJSON supports json.Marshaler interface
+func (v InitVideoUploadDTO) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeSkillForceInternalModelsDto39(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v InitVideoUploadDTO) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto39(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *InitVideoUploadDTO) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeSkillForceInternalModelsDto39(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *InitVideoUploadDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeSkillForceInternalModelsDto39(l, v)
+}
+func easyjson56de76c1DecodeSkillForceInternalModelsDto40(in *jlexer.Lexer, out *CreatePaymentRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2201,10 +4583,12 @@ func easyjson56de76c1Decode1(in *jlexer.Lexer, out *struct {
 			continue
 		}
 		switch key {
-		case "order":
-			out.Order = int(in.Int())
-		case "title":
-			out.Title = string(in.String())
+		case "return_url":
+			out.ReturnURL = string(in.String())
+		case "User_ID":
+			out.User_ID = int32(in.Int32())
+		case "course_id":
+			out.CourseID = int32(in.Int32())
 		default:
 			in.SkipRecursive()
 		}
@@ -2215,26 +4599,52 @@ func easyjson56de76c1Decode1(in *jlexer.Lexer, out *struct {
 		in.Consumed()
 	}
 }
-func easyjson56de76c1Encode1(out *jwriter.Writer, in struct {
-	Order int    `json:"order"`
-	Title string `json:"title"`
-}) {
+func easyjson56de76c1EncodeSkillForceInternalModelsDto40(out *jwriter.Writer, in CreatePaymentRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"order\":"
+		const prefix string = ",\"return_url\":"
 		out.RawString(prefix[1:])
-		out.Int(int(in.Order))
+		out.String(string(in.ReturnURL))
 	}
 	{
-		const prefix string = ",\"title\":"
+		const prefix string = ",\"User_ID\":"
 		out.RawString(prefix)
-		out.String(string(in.Title))
+		out.Int32(int32(in.User_ID))
+	}
+	{
+		const prefix string = ",\"course_id\":"
+		out.RawString(prefix)
+		out.Int32(int32(in.CourseID))
 	}
 	out.RawByte('}')
 }
-func easyjson56de76c1DecodeSkillForceInternalModelsDto22(in *jlexer.Lexer, out *LessonDtoBody) {
+
+// MarshalJSON supports json.Marshaler interface
+func (v CreatePaymentRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeSkillForceInternalModelsDto40(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CreatePaymentRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto40(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CreatePaymentRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeSkillForceInternalModelsDto40(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CreatePaymentRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeSkillForceInternalModelsDto40(l, v)
+}
+func easyjson56de76c1DecodeSkillForceInternalModelsDto41(in *jlexer.Lexer, out *CourseRoadmapDTO) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2253,37 +4663,37 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto22(in *jlexer.Lexer, out *
 			continue
 		}
 		switch key {
-		case "blocks":
+		case "parts":
 			if in.IsNull() {
 				in.Skip()
-				out.Blocks = nil
+				out.Parts = nil
 			} else {
 				in.Delim('[')
-				if out.Blocks == nil {
+				if out.Parts == nil {
 					if !in.IsDelim(']') {
-						out.Blocks = make([]struct {
-							Body string `json:"body"`
-						}, 0, 4)
+						out.Parts = make([]*CoursePartDTO, 0, 8)
 					} else {
-						out.Blocks = []struct {
-							Body string `json:"body"`
-						}{}
+						out.Parts = []*CoursePartDTO{}
 					}
 				} else {
-					out.Blocks = (out.Blocks)[:0]
+					out.Parts = (out.Parts)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v22 struct {
-						Body string `json:"body"`
+					var v68 *CoursePartDTO
+					if in.IsNull() {
+						in.Skip()
+						v68 = nil
+					} else {
+						if v68 == nil {
+							v68 = new(CoursePartDTO)
+						}
+						(*v68).UnmarshalEasyJSON(in)
 					}
-					easyjson56de76c1Decode3(in, &v22)
-					out.Blocks = append(out.Blocks, v22)
+					out.Parts = append(out.Parts, v68)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
-		case "footer":
-			easyjson56de76c1Decode4(in, &out.Footer)
 		default:
 			in.SkipRecursive()
 		}
@@ -2294,62 +4704,57 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto22(in *jlexer.Lexer, out *
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeSkillForceInternalModelsDto22(out *jwriter.Writer, in LessonDtoBody) {
+func easyjson56de76c1EncodeSkillForceInternalModelsDto41(out *jwriter.Writer, in CourseRoadmapDTO) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"blocks\":"
+		const prefix string = ",\"parts\":"
 		out.RawString(prefix[1:])
-		if in.Blocks == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		if in.Parts == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v23, v24 := range in.Blocks {
-				if v23 > 0 {
+			for v69, v70 := range in.Parts {
+				if v69 > 0 {
 					out.RawByte(',')
 				}
-				easyjson56de76c1Encode3(out, v24)
+				if v70 == nil {
+					out.RawString("null")
+				} else {
+					(*v70).MarshalEasyJSON(out)
+				}
 			}
 			out.RawByte(']')
 		}
 	}
-	{
-		const prefix string = ",\"footer\":"
-		out.RawString(prefix)
-		easyjson56de76c1Encode4(out, in.Footer)
-	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v LessonDtoBody) MarshalJSON() ([]byte, error) {
+func (v CourseRoadmapDTO) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeSkillForceInternalModelsDto22(&w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto41(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v LessonDtoBody) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeSkillForceInternalModelsDto22(w, v)
+func (v CourseRoadmapDTO) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto41(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *LessonDtoBody) UnmarshalJSON(data []byte) error {
+func (v *CourseRoadmapDTO) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeSkillForceInternalModelsDto22(&r, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto41(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *LessonDtoBody) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeSkillForceInternalModelsDto22(l, v)
+func (v *CourseRoadmapDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeSkillForceInternalModelsDto41(l, v)
 }
-func easyjson56de76c1Decode4(in *jlexer.Lexer, out *struct {
-	NextLessonId     int `json:"next_lesson_id"`
-	CurrentLessonId  int `json:"current_lesson_id"`
-	PreviousLessonId int `json:"previous_lesson_id"`
-}) {
+func easyjson56de76c1DecodeSkillForceInternalModelsDto42(in *jlexer.Lexer, out *CoursePartDTO) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2368,12 +4773,41 @@ func easyjson56de76c1Decode4(in *jlexer.Lexer, out *struct {
 			continue
 		}
 		switch key {
-		case "next_lesson_id":
-			out.NextLessonId = int(in.Int())
-		case "current_lesson_id":
-			out.CurrentLessonId = int(in.Int())
-		case "previous_lesson_id":
-			out.PreviousLessonId = int(in.Int())
+		case "part_id":
+			out.Id = int(in.Int())
+		case "part_title":
+			out.Title = string(in.String())
+		case "buckets":
+			if in.IsNull() {
+				in.Skip()
+				out.Buckets = nil
+			} else {
+				in.Delim('[')
+				if out.Buckets == nil {
+					if !in.IsDelim(']') {
+						out.Buckets = make([]*LessonBucketDTO, 0, 8)
+					} else {
+						out.Buckets = []*LessonBucketDTO{}
+					}
+				} else {
+					out.Buckets = (out.Buckets)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v71 *LessonBucketDTO
+					if in.IsNull() {
+						in.Skip()
+						v71 = nil
+					} else {
+						if v71 == nil {
+							v71 = new(LessonBucketDTO)
+						}
+						(*v71).UnmarshalEasyJSON(in)
+					}
+					out.Buckets = append(out.Buckets, v71)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -2384,78 +4818,67 @@ func easyjson56de76c1Decode4(in *jlexer.Lexer, out *struct {
 		in.Consumed()
 	}
 }
-func easyjson56de76c1Encode4(out *jwriter.Writer, in struct {
-	NextLessonId     int `json:"next_lesson_id"`
-	CurrentLessonId  int `json:"current_lesson_id"`
-	PreviousLessonId int `json:"previous_lesson_id"`
-}) {
+func easyjson56de76c1EncodeSkillForceInternalModelsDto42(out *jwriter.Writer, in CoursePartDTO) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"next_lesson_id\":"
+		const prefix string = ",\"part_id\":"
 		out.RawString(prefix[1:])
-		out.Int(int(in.NextLessonId))
+		out.Int(int(in.Id))
 	}
 	{
-		const prefix string = ",\"current_lesson_id\":"
+		const prefix string = ",\"part_title\":"
 		out.RawString(prefix)
-		out.Int(int(in.CurrentLessonId))
+		out.String(string(in.Title))
 	}
 	{
-		const prefix string = ",\"previous_lesson_id\":"
+		const prefix string = ",\"buckets\":"
 		out.RawString(prefix)
-		out.Int(int(in.PreviousLessonId))
+		if in.Buckets == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v72, v73 := range in.Buckets {
+				if v72 > 0 {
+					out.RawByte(',')
+				}
+				if v73 == nil {
+					out.RawString("null")
+				} else {
+					(*v73).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
 	}
 	out.RawByte('}')
 }
-func easyjson56de76c1Decode3(in *jlexer.Lexer, out *struct {
-	Body string `json:"body"`
-}) {
-	isTopLevel := in.IsStart()
-	if in.IsNull() {
-		if isTopLevel {
-			in.Consumed()
-		}
-		in.Skip()
-		return
-	}
-	in.Delim('{')
-	for !in.IsDelim('}') {
-		key := in.UnsafeFieldName(false)
-		in.WantColon()
-		if in.IsNull() {
-			in.Skip()
-			in.WantComma()
-			continue
-		}
-		switch key {
-		case "body":
-			out.Body = string(in.String())
-		default:
-			in.SkipRecursive()
-		}
-		in.WantComma()
-	}
-	in.Delim('}')
-	if isTopLevel {
-		in.Consumed()
-	}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CoursePartDTO) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson56de76c1EncodeSkillForceInternalModelsDto42(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
 }
-func easyjson56de76c1Encode3(out *jwriter.Writer, in struct {
-	Body string `json:"body"`
-}) {
-	out.RawByte('{')
-	first := true
-	_ = first
-	{
-		const prefix string = ",\"body\":"
-		out.RawString(prefix[1:])
-		out.String(string(in.Body))
-	}
-	out.RawByte('}')
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CoursePartDTO) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto42(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CoursePartDTO) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson56de76c1DecodeSkillForceInternalModelsDto42(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CoursePartDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeSkillForceInternalModelsDto42(l, v)
 }
-func easyjson56de76c1DecodeSkillForceInternalModelsDto23(in *jlexer.Lexer, out *LessonDTO) {
+func easyjson56de76c1DecodeSkillForceInternalModelsDto43(in *jlexer.Lexer, out *CourseIDRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2474,10 +4897,8 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto23(in *jlexer.Lexer, out *
 			continue
 		}
 		switch key {
-		case "header":
-			(out.LessonHeader).UnmarshalEasyJSON(in)
-		case "lesson_body":
-			(out.LessonBody).UnmarshalEasyJSON(in)
+		case "course_id":
+			out.Id = int(in.Int())
 		default:
 			in.SkipRecursive()
 		}
@@ -2488,47 +4909,42 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto23(in *jlexer.Lexer, out *
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeSkillForceInternalModelsDto23(out *jwriter.Writer, in LessonDTO) {
+func easyjson56de76c1EncodeSkillForceInternalModelsDto43(out *jwriter.Writer, in CourseIDRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"header\":"
+		const prefix string = ",\"course_id\":"
 		out.RawString(prefix[1:])
-		(in.LessonHeader).MarshalEasyJSON(out)
-	}
-	{
-		const prefix string = ",\"lesson_body\":"
-		out.RawString(prefix)
-		(in.LessonBody).MarshalEasyJSON(out)
+		out.Int(int(in.Id))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v LessonDTO) MarshalJSON() ([]byte, error) {
+func (v CourseIDRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeSkillForceInternalModelsDto23(&w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto43(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v LessonDTO) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeSkillForceInternalModelsDto23(w, v)
+func (v CourseIDRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto43(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *LessonDTO) UnmarshalJSON(data []byte) error {
+func (v *CourseIDRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeSkillForceInternalModelsDto23(&r, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto43(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *LessonDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeSkillForceInternalModelsDto23(l, v)
+func (v *CourseIDRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeSkillForceInternalModelsDto43(l, v)
 }
-func easyjson56de76c1DecodeSkillForceInternalModelsDto24(in *jlexer.Lexer, out *LessonBucketDTO) {
+func easyjson56de76c1DecodeSkillForceInternalModelsDto44(in *jlexer.Lexer, out *CourseDTO) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2547,41 +4963,84 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto24(in *jlexer.Lexer, out *
 			continue
 		}
 		switch key {
-		case "bucket_id":
+		case "id":
 			out.Id = int(in.Int())
-		case "bucket_title":
+		case "price":
+			out.Price = int(in.Int())
+		case "purchases_amount":
+			out.PurchasesAmount = int(in.Int())
+		case "creator_id":
+			out.CreatorId = int(in.Int())
+		case "time_to_pass":
+			out.TimeToPass = int(in.Int())
+		case "rating":
+			out.Rating = float32(in.Float32())
+		case "tags":
+			if in.IsNull() {
+				in.Skip()
+				out.Tags = nil
+			} else {
+				in.Delim('[')
+				if out.Tags == nil {
+					if !in.IsDelim(']') {
+						out.Tags = make([]string, 0, 4)
+					} else {
+						out.Tags = []string{}
+					}
+				} else {
+					out.Tags = (out.Tags)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v74 string
+					v74 = string(in.String())
+					out.Tags = append(out.Tags, v74)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "title":
 			out.Title = string(in.String())
-		case "lessons":
+		case "description":
+			out.Description = string(in.String())
+		case "src_image":
+			out.ScrImage = string(in.String())
+		case "is_purchased":
+			out.IsPurchased = bool(in.Bool())
+		case "is_completed":
+			out.IsCompleted = bool(in.Bool())
+		case "parts":
 			if in.IsNull() {
 				in.Skip()
-				out.Lessons = nil
+				out.Parts = nil
 			} else {
 				in.Delim('[')
-				if out.Lessons == nil {
+				if out.Parts == nil {
 					if !in.IsDelim(']') {
-						out.Lessons = make([]*LessonPointDTO, 0, 8)
+						out.Parts = make([]*CoursePartDTO, 0, 8)
 					} else {
-						out.Lessons = []*LessonPointDTO{}
+						out.Parts = []*CoursePartDTO{}
 					}
 				} else {
-					out.Lessons = (out.Lessons)[:0]
+					out.Parts = (out.Parts)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v25 *LessonPointDTO
+					var v75 *CoursePartDTO
 					if in.IsNull() {
 						in.Skip()
-						v25 = nil
+						v75 = nil
 					} else {
-						if v25 == nil {
-							v25 = new(LessonPointDTO)
+						if v75 == nil {
+							v75 = new(CoursePartDTO)
 						}
-						(*v25).UnmarshalEasyJSON(in)
+						(*v75).UnmarshalEasyJSON(in)
 					}
-					out.Lessons = append(out.Lessons, v25)
+					out.Parts = append(out.Parts, v75)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
+		case "is_favorite":
+			out.IsFavorite = bool(in.Bool())
 		default:
 			in.SkipRecursive()
 		}
@@ -2592,67 +5051,133 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto24(in *jlexer.Lexer, out *
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeSkillForceInternalModelsDto24(out *jwriter.Writer, in LessonBucketDTO) {
+func easyjson56de76c1EncodeSkillForceInternalModelsDto44(out *jwriter.Writer, in CourseDTO) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"bucket_id\":"
+		const prefix string = ",\"id\":"
 		out.RawString(prefix[1:])
 		out.Int(int(in.Id))
 	}
 	{
-		const prefix string = ",\"bucket_title\":"
+		const prefix string = ",\"price\":"
+		out.RawString(prefix)
+		out.Int(int(in.Price))
+	}
+	{
+		const prefix string = ",\"purchases_amount\":"
+		out.RawString(prefix)
+		out.Int(int(in.PurchasesAmount))
+	}
+	{
+		const prefix string = ",\"creator_id\":"
+		out.RawString(prefix)
+		out.Int(int(in.CreatorId))
+	}
+	{
+		const prefix string = ",\"time_to_pass\":"
+		out.RawString(prefix)
+		out.Int(int(in.TimeToPass))
+	}
+	{
+		const prefix string = ",\"rating\":"
+		out.RawString(prefix)
+		out.Float32(float32(in.Rating))
+	}
+	{
+		const prefix string = ",\"tags\":"
+		out.RawString(prefix)
+		if in.Tags == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v76, v77 := range in.Tags {
+				if v76 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v77))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"title\":"
 		out.RawString(prefix)
 		out.String(string(in.Title))
 	}
 	{
-		const prefix string = ",\"lessons\":"
+		const prefix string = ",\"description\":"
 		out.RawString(prefix)
-		if in.Lessons == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.String(string(in.Description))
+	}
+	{
+		const prefix string = ",\"src_image\":"
+		out.RawString(prefix)
+		out.String(string(in.ScrImage))
+	}
+	{
+		const prefix string = ",\"is_purchased\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsPurchased))
+	}
+	{
+		const prefix string = ",\"is_completed\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsCompleted))
+	}
+	{
+		const prefix string = ",\"parts\":"
+		out.RawString(prefix)
+		if in.Parts == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v26, v27 := range in.Lessons {
-				if v26 > 0 {
+			for v78, v79 := range in.Parts {
+				if v78 > 0 {
 					out.RawByte(',')
 				}
-				if v27 == nil {
+				if v79 == nil {
 					out.RawString("null")
 				} else {
-					(*v27).MarshalEasyJSON(out)
+					(*v79).MarshalEasyJSON(out)
 				}
 			}
 			out.RawByte(']')
 		}
 	}
+	{
+		const prefix string = ",\"is_favorite\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsFavorite))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v LessonBucketDTO) MarshalJSON() ([]byte, error) {
+func (v CourseDTO) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeSkillForceInternalModelsDto24(&w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto44(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v LessonBucketDTO) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeSkillForceInternalModelsDto24(w, v)
+func (v CourseDTO) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto44(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *LessonBucketDTO) UnmarshalJSON(data []byte) error {
+func (v *CourseDTO) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeSkillForceInternalModelsDto24(&r, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto44(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *LessonBucketDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeSkillForceInternalModelsDto24(l, v)
+func (v *CourseDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeSkillForceInternalModelsDto44(l, v)
 }
-func easyjson56de76c1DecodeSkillForceInternalModelsDto25(in *jlexer.Lexer, out *CreatePaymentRequest) {
+func easyjson56de76c1DecodeSkillForceInternalModelsDto45(in *jlexer.Lexer, out *CourseBundleDTO) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2671,12 +5196,37 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto25(in *jlexer.Lexer, out *
 			continue
 		}
 		switch key {
-		case "return_url":
-			out.ReturnURL = string(in.String())
-		case "User_ID":
-			out.User_ID = int32(in.Int32())
 		case "course_id":
-			out.CourseID = int32(in.Int32())
+			out.CourseId = int(in.Int())
+		case "title":
+			out.Title = string(in.String())
+		case "description":
+			out.Description = string(in.String())
+		case "version":
+			out.Version = string(in.String())
+		case "lessons":
+			if in.IsNull() {
+				in.Skip()
+				out.Lessons = nil
+			} else {
+				in.Delim('[')
+				if out.Lessons == nil {
+					if !in.IsDelim(']') {
+						out.Lessons = make([]BundleLessonDTO, 0, 0)
+					} else {
+						out.Lessons = []BundleLessonDTO{}
+					}
+				} else {
+					out.Lessons = (out.Lessons)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v80 BundleLessonDTO
+					(v80).UnmarshalEasyJSON(in)
+					out.Lessons = append(out.Lessons, v80)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -2687,52 +5237,73 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto25(in *jlexer.Lexer, out *
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeSkillForceInternalModelsDto25(out *jwriter.Writer, in CreatePaymentRequest) {
+func easyjson56de76c1EncodeSkillForceInternalModelsDto45(out *jwriter.Writer, in CourseBundleDTO) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"return_url\":"
+		const prefix string = ",\"course_id\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.ReturnURL))
+		out.Int(int(in.CourseId))
 	}
 	{
-		const prefix string = ",\"User_ID\":"
+		const prefix string = ",\"title\":"
 		out.RawString(prefix)
-		out.Int32(int32(in.User_ID))
+		out.String(string(in.Title))
 	}
 	{
-		const prefix string = ",\"course_id\":"
+		const prefix string = ",\"description\":"
 		out.RawString(prefix)
-		out.Int32(int32(in.CourseID))
+		out.String(string(in.Description))
+	}
+	{
+		const prefix string = ",\"version\":"
+		out.RawString(prefix)
+		out.String(string(in.Version))
+	}
+	{
+		const prefix string = ",\"lessons\":"
+		out.RawString(prefix)
+		if in.Lessons == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v81, v82 := range in.Lessons {
+				if v81 > 0 {
+					out.RawByte(',')
+				}
+				(v82).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v CreatePaymentRequest) MarshalJSON() ([]byte, error) {
+func (v CourseBundleDTO) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeSkillForceInternalModelsDto25(&w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto45(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v CreatePaymentRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeSkillForceInternalModelsDto25(w, v)
+func (v CourseBundleDTO) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto45(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *CreatePaymentRequest) UnmarshalJSON(data []byte) error {
+func (v *CourseBundleDTO) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeSkillForceInternalModelsDto25(&r, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto45(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *CreatePaymentRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeSkillForceInternalModelsDto25(l, v)
+func (v *CourseBundleDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeSkillForceInternalModelsDto45(l, v)
 }
-func easyjson56de76c1DecodeSkillForceInternalModelsDto26(in *jlexer.Lexer, out *CourseRoadmapDTO) {
+func easyjson56de76c1DecodeSkillForceInternalModelsDto46(in *jlexer.Lexer, out *CompletedCourseDTO) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2751,37 +5322,18 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto26(in *jlexer.Lexer, out *
 			continue
 		}
 		switch key {
-		case "parts":
+		case "course":
 			if in.IsNull() {
 				in.Skip()
-				out.Parts = nil
+				out.Course = nil
 			} else {
-				in.Delim('[')
-				if out.Parts == nil {
-					if !in.IsDelim(']') {
-						out.Parts = make([]*CoursePartDTO, 0, 8)
-					} else {
-						out.Parts = []*CoursePartDTO{}
-					}
-				} else {
-					out.Parts = (out.Parts)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v28 *CoursePartDTO
-					if in.IsNull() {
-						in.Skip()
-						v28 = nil
-					} else {
-						if v28 == nil {
-							v28 = new(CoursePartDTO)
-						}
-						(*v28).UnmarshalEasyJSON(in)
-					}
-					out.Parts = append(out.Parts, v28)
-					in.WantComma()
+				if out.Course == nil {
+					out.Course = new(CourseDTO)
 				}
-				in.Delim(']')
+				(*out.Course).UnmarshalEasyJSON(in)
 			}
+		case "sertificate_url":
+			out.SertificateUrl = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -2792,57 +5344,51 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto26(in *jlexer.Lexer, out *
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeSkillForceInternalModelsDto26(out *jwriter.Writer, in CourseRoadmapDTO) {
+func easyjson56de76c1EncodeSkillForceInternalModelsDto46(out *jwriter.Writer, in CompletedCourseDTO) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"parts\":"
+		const prefix string = ",\"course\":"
 		out.RawString(prefix[1:])
-		if in.Parts == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		if in.Course == nil {
 			out.RawString("null")
 		} else {
-			out.RawByte('[')
-			for v29, v30 := range in.Parts {
-				if v29 > 0 {
-					out.RawByte(',')
-				}
-				if v30 == nil {
-					out.RawString("null")
-				} else {
-					(*v30).MarshalEasyJSON(out)
-				}
-			}
-			out.RawByte(']')
+			(*in.Course).MarshalEasyJSON(out)
 		}
 	}
+	{
+		const prefix string = ",\"sertificate_url\":"
+		out.RawString(prefix)
+		out.String(string(in.SertificateUrl))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v CourseRoadmapDTO) MarshalJSON() ([]byte, error) {
+func (v CompletedCourseDTO) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeSkillForceInternalModelsDto26(&w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto46(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v CourseRoadmapDTO) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeSkillForceInternalModelsDto26(w, v)
+func (v CompletedCourseDTO) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto46(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *CourseRoadmapDTO) UnmarshalJSON(data []byte) error {
+func (v *CompletedCourseDTO) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeSkillForceInternalModelsDto26(&r, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto46(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *CourseRoadmapDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeSkillForceInternalModelsDto26(l, v)
+func (v *CompletedCourseDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeSkillForceInternalModelsDto46(l, v)
 }
-func easyjson56de76c1DecodeSkillForceInternalModelsDto27(in *jlexer.Lexer, out *CoursePartDTO) {
+func easyjson56de76c1DecodeSkillForceInternalModelsDto47(in *jlexer.Lexer, out *BundleLessonDTO) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2861,37 +5407,35 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto27(in *jlexer.Lexer, out *
 			continue
 		}
 		switch key {
-		case "part_id":
-			out.Id = int(in.Int())
+		case "lesson_id":
+			out.LessonId = int(in.Int())
 		case "part_title":
+			out.PartTitle = string(in.String())
+		case "bucket_title":
+			out.BucketTitle = string(in.String())
+		case "title":
 			out.Title = string(in.String())
-		case "buckets":
+		case "type":
+			out.Type = string(in.String())
+		case "blocks":
 			if in.IsNull() {
 				in.Skip()
-				out.Buckets = nil
+				out.Blocks = nil
 			} else {
 				in.Delim('[')
-				if out.Buckets == nil {
+				if out.Blocks == nil {
 					if !in.IsDelim(']') {
-						out.Buckets = make([]*LessonBucketDTO, 0, 8)
+						out.Blocks = make([]LessonBlockDTO, 0, 0)
 					} else {
-						out.Buckets = []*LessonBucketDTO{}
+						out.Blocks = []LessonBlockDTO{}
 					}
 				} else {
-					out.Buckets = (out.Buckets)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v31 *LessonBucketDTO
-					if in.IsNull() {
-						in.Skip()
-						v31 = nil
-					} else {
-						if v31 == nil {
-							v31 = new(LessonBucketDTO)
-						}
-						(*v31).UnmarshalEasyJSON(in)
-					}
-					out.Buckets = append(out.Buckets, v31)
+					out.Blocks = (out.Blocks)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v83 LessonBlockDTO
+					(v83).UnmarshalEasyJSON(in)
+					out.Blocks = append(out.Blocks, v83)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -2906,36 +5450,45 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto27(in *jlexer.Lexer, out *
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeSkillForceInternalModelsDto27(out *jwriter.Writer, in CoursePartDTO) {
+func easyjson56de76c1EncodeSkillForceInternalModelsDto47(out *jwriter.Writer, in BundleLessonDTO) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"part_id\":"
+		const prefix string = ",\"lesson_id\":"
 		out.RawString(prefix[1:])
-		out.Int(int(in.Id))
+		out.Int(int(in.LessonId))
 	}
 	{
 		const prefix string = ",\"part_title\":"
 		out.RawString(prefix)
+		out.String(string(in.PartTitle))
+	}
+	{
+		const prefix string = ",\"bucket_title\":"
+		out.RawString(prefix)
+		out.String(string(in.BucketTitle))
+	}
+	{
+		const prefix string = ",\"title\":"
+		out.RawString(prefix)
 		out.String(string(in.Title))
 	}
 	{
-		const prefix string = ",\"buckets\":"
+		const prefix string = ",\"type\":"
 		out.RawString(prefix)
-		if in.Buckets == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-			out.RawString("null")
-		} else {
+		out.String(string(in.Type))
+	}
+	if len(in.Blocks) != 0 {
+		const prefix string = ",\"blocks\":"
+		out.RawString(prefix)
+		{
 			out.RawByte('[')
-			for v32, v33 := range in.Buckets {
-				if v32 > 0 {
+			for v84, v85 := range in.Blocks {
+				if v84 > 0 {
 					out.RawByte(',')
 				}
-				if v33 == nil {
-					out.RawString("null")
-				} else {
-					(*v33).MarshalEasyJSON(out)
-				}
+				(v85).MarshalEasyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -2944,29 +5497,29 @@ func easyjson56de76c1EncodeSkillForceInternalModelsDto27(out *jwriter.Writer, in
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v CoursePartDTO) MarshalJSON() ([]byte, error) {
+func (v BundleLessonDTO) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeSkillForceInternalModelsDto27(&w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto47(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v CoursePartDTO) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeSkillForceInternalModelsDto27(w, v)
+func (v BundleLessonDTO) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto47(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *CoursePartDTO) UnmarshalJSON(data []byte) error {
+func (v *BundleLessonDTO) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeSkillForceInternalModelsDto27(&r, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto47(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *CoursePartDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeSkillForceInternalModelsDto27(l, v)
+func (v *BundleLessonDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeSkillForceInternalModelsDto47(l, v)
 }
-func easyjson56de76c1DecodeSkillForceInternalModelsDto28(in *jlexer.Lexer, out *CourseIDRequest) {
+func easyjson56de76c1DecodeSkillForceInternalModelsDto48(in *jlexer.Lexer, out *AuthorStatsDTO) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2985,8 +5538,12 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto28(in *jlexer.Lexer, out *
 			continue
 		}
 		switch key {
-		case "course_id":
-			out.Id = int(in.Int())
+		case "courses_amount":
+			out.CoursesAmount = int(in.Int())
+		case "students_amount":
+			out.StudentsAmount = int(in.Int())
+		case "rating":
+			out.Rating = float32(in.Float32())
 		default:
 			in.SkipRecursive()
 		}
@@ -2997,42 +5554,52 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto28(in *jlexer.Lexer, out *
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeSkillForceInternalModelsDto28(out *jwriter.Writer, in CourseIDRequest) {
+func easyjson56de76c1EncodeSkillForceInternalModelsDto48(out *jwriter.Writer, in AuthorStatsDTO) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"course_id\":"
+		const prefix string = ",\"courses_amount\":"
 		out.RawString(prefix[1:])
-		out.Int(int(in.Id))
+		out.Int(int(in.CoursesAmount))
+	}
+	{
+		const prefix string = ",\"students_amount\":"
+		out.RawString(prefix)
+		out.Int(int(in.StudentsAmount))
+	}
+	{
+		const prefix string = ",\"rating\":"
+		out.RawString(prefix)
+		out.Float32(float32(in.Rating))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v CourseIDRequest) MarshalJSON() ([]byte, error) {
+func (v AuthorStatsDTO) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeSkillForceInternalModelsDto28(&w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto48(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v CourseIDRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeSkillForceInternalModelsDto28(w, v)
+func (v AuthorStatsDTO) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto48(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *CourseIDRequest) UnmarshalJSON(data []byte) error {
+func (v *AuthorStatsDTO) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeSkillForceInternalModelsDto28(&r, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto48(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *CourseIDRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeSkillForceInternalModelsDto28(l, v)
+func (v *AuthorStatsDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeSkillForceInternalModelsDto48(l, v)
 }
-func easyjson56de76c1DecodeSkillForceInternalModelsDto29(in *jlexer.Lexer, out *CourseDTO) {
+func easyjson56de76c1DecodeSkillForceInternalModelsDto49(in *jlexer.Lexer, out *AnswerQuestion) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -3051,84 +5618,10 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto29(in *jlexer.Lexer, out *
 			continue
 		}
 		switch key {
-		case "id":
-			out.Id = int(in.Int())
-		case "price":
-			out.Price = int(in.Int())
-		case "purchases_amount":
-			out.PurchasesAmount = int(in.Int())
-		case "creator_id":
-			out.CreatorId = int(in.Int())
-		case "time_to_pass":
-			out.TimeToPass = int(in.Int())
-		case "rating":
-			out.Rating = float32(in.Float32())
-		case "tags":
-			if in.IsNull() {
-				in.Skip()
-				out.Tags = nil
-			} else {
-				in.Delim('[')
-				if out.Tags == nil {
-					if !in.IsDelim(']') {
-						out.Tags = make([]string, 0, 4)
-					} else {
-						out.Tags = []string{}
-					}
-				} else {
-					out.Tags = (out.Tags)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v34 string
-					v34 = string(in.String())
-					out.Tags = append(out.Tags, v34)
-					in.WantComma()
-				}
-				in.Delim(']')
-			}
-		case "title":
-			out.Title = string(in.String())
-		case "description":
-			out.Description = string(in.String())
-		case "src_image":
-			out.ScrImage = string(in.String())
-		case "is_purchased":
-			out.IsPurchased = bool(in.Bool())
-		case "is_completed":
-			out.IsCompleted = bool(in.Bool())
-		case "parts":
-			if in.IsNull() {
-				in.Skip()
-				out.Parts = nil
-			} else {
-				in.Delim('[')
-				if out.Parts == nil {
-					if !in.IsDelim(']') {
-						out.Parts = make([]*CoursePartDTO, 0, 8)
-					} else {
-						out.Parts = []*CoursePartDTO{}
-					}
-				} else {
-					out.Parts = (out.Parts)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v35 *CoursePartDTO
-					if in.IsNull() {
-						in.Skip()
-						v35 = nil
-					} else {
-						if v35 == nil {
-							v35 = new(CoursePartDTO)
-						}
-						(*v35).UnmarshalEasyJSON(in)
-					}
-					out.Parts = append(out.Parts, v35)
-					in.WantComma()
-				}
-				in.Delim(']')
-			}
-		case "is_favorite":
-			out.IsFavorite = bool(in.Bool())
+		case "question_id":
+			out.QuestionID = int(in.Int())
+		case "answer":
+			out.Answer = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -3139,133 +5632,47 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto29(in *jlexer.Lexer, out *
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeSkillForceInternalModelsDto29(out *jwriter.Writer, in CourseDTO) {
+func easyjson56de76c1EncodeSkillForceInternalModelsDto49(out *jwriter.Writer, in AnswerQuestion) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"id\":"
+		const prefix string = ",\"question_id\":"
 		out.RawString(prefix[1:])
-		out.Int(int(in.Id))
-	}
-	{
-		const prefix string = ",\"price\":"
-		out.RawString(prefix)
-		out.Int(int(in.Price))
-	}
-	{
-		const prefix string = ",\"purchases_amount\":"
-		out.RawString(prefix)
-		out.Int(int(in.PurchasesAmount))
-	}
-	{
-		const prefix string = ",\"creator_id\":"
-		out.RawString(prefix)
-		out.Int(int(in.CreatorId))
-	}
-	{
-		const prefix string = ",\"time_to_pass\":"
-		out.RawString(prefix)
-		out.Int(int(in.TimeToPass))
-	}
-	{
-		const prefix string = ",\"rating\":"
-		out.RawString(prefix)
-		out.Float32(float32(in.Rating))
-	}
-	{
-		const prefix string = ",\"tags\":"
-		out.RawString(prefix)
-		if in.Tags == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-			out.RawString("null")
-		} else {
-			out.RawByte('[')
-			for v36, v37 := range in.Tags {
-				if v36 > 0 {
-					out.RawByte(',')
-				}
-				out.String(string(v37))
-			}
-			out.RawByte(']')
-		}
-	}
-	{
-		const prefix string = ",\"title\":"
-		out.RawString(prefix)
-		out.String(string(in.Title))
-	}
-	{
-		const prefix string = ",\"description\":"
-		out.RawString(prefix)
-		out.String(string(in.Description))
-	}
-	{
-		const prefix string = ",\"src_image\":"
-		out.RawString(prefix)
-		out.String(string(in.ScrImage))
-	}
-	{
-		const prefix string = ",\"is_purchased\":"
-		out.RawString(prefix)
-		out.Bool(bool(in.IsPurchased))
-	}
-	{
-		const prefix string = ",\"is_completed\":"
-		out.RawString(prefix)
-		out.Bool(bool(in.IsCompleted))
-	}
-	{
-		const prefix string = ",\"parts\":"
-		out.RawString(prefix)
-		if in.Parts == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-			out.RawString("null")
-		} else {
-			out.RawByte('[')
-			for v38, v39 := range in.Parts {
-				if v38 > 0 {
-					out.RawByte(',')
-				}
-				if v39 == nil {
-					out.RawString("null")
-				} else {
-					(*v39).MarshalEasyJSON(out)
-				}
-			}
-			out.RawByte(']')
-		}
+		out.Int(int(in.QuestionID))
 	}
 	{
-		const prefix string = ",\"is_favorite\":"
+		const prefix string = ",\"answer\":"
 		out.RawString(prefix)
-		out.Bool(bool(in.IsFavorite))
+		out.String(string(in.Answer))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v CourseDTO) MarshalJSON() ([]byte, error) {
+func (v AnswerQuestion) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeSkillForceInternalModelsDto29(&w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto49(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v CourseDTO) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeSkillForceInternalModelsDto29(w, v)
+func (v AnswerQuestion) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto49(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *CourseDTO) UnmarshalJSON(data []byte) error {
+func (v *AnswerQuestion) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeSkillForceInternalModelsDto29(&r, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto49(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *CourseDTO) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeSkillForceInternalModelsDto29(l, v)
+func (v *AnswerQuestion) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeSkillForceInternalModelsDto49(l, v)
 }
-func easyjson56de76c1DecodeSkillForceInternalModelsDto30(in *jlexer.Lexer, out *AnswerQuestion) {
+func easyjson56de76c1DecodeSkillForceInternalModelsDto50(in *jlexer.Lexer, out *AnswerBlockQuizRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -3284,10 +5691,12 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto30(in *jlexer.Lexer, out *
 			continue
 		}
 		switch key {
-		case "question_id":
-			out.QuestionID = int(in.Int())
-		case "answer":
-			out.Answer = string(in.String())
+		case "lesson_id":
+			out.LessonId = int(in.Int())
+		case "block_index":
+			out.BlockIndex = int(in.Int())
+		case "option":
+			out.Option = int(in.Int())
 		default:
 			in.SkipRecursive()
 		}
@@ -3298,47 +5707,52 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto30(in *jlexer.Lexer, out *
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeSkillForceInternalModelsDto30(out *jwriter.Writer, in AnswerQuestion) {
+func easyjson56de76c1EncodeSkillForceInternalModelsDto50(out *jwriter.Writer, in AnswerBlockQuizRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"question_id\":"
+		const prefix string = ",\"lesson_id\":"
 		out.RawString(prefix[1:])
-		out.Int(int(in.QuestionID))
+		out.Int(int(in.LessonId))
 	}
 	{
-		const prefix string = ",\"answer\":"
+		const prefix string = ",\"block_index\":"
 		out.RawString(prefix)
-		out.String(string(in.Answer))
+		out.Int(int(in.BlockIndex))
+	}
+	{
+		const prefix string = ",\"option\":"
+		out.RawString(prefix)
+		out.Int(int(in.Option))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v AnswerQuestion) MarshalJSON() ([]byte, error) {
+func (v AnswerBlockQuizRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeSkillForceInternalModelsDto30(&w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto50(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v AnswerQuestion) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeSkillForceInternalModelsDto30(w, v)
+func (v AnswerBlockQuizRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson56de76c1EncodeSkillForceInternalModelsDto50(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *AnswerQuestion) UnmarshalJSON(data []byte) error {
+func (v *AnswerBlockQuizRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeSkillForceInternalModelsDto30(&r, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto50(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *AnswerQuestion) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeSkillForceInternalModelsDto30(l, v)
+func (v *AnswerBlockQuizRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson56de76c1DecodeSkillForceInternalModelsDto50(l, v)
 }
-func easyjson56de76c1DecodeSkillForceInternalModelsDto31(in *jlexer.Lexer, out *Answer) {
+func easyjson56de76c1DecodeSkillForceInternalModelsDto51(in *jlexer.Lexer, out *Answer) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -3373,7 +5787,7 @@ func easyjson56de76c1DecodeSkillForceInternalModelsDto31(in *jlexer.Lexer, out *
 		in.Consumed()
 	}
 }
-func easyjson56de76c1EncodeSkillForceInternalModelsDto31(out *jwriter.Writer, in Answer) {
+func easyjson56de76c1EncodeSkillForceInternalModelsDto51(out *jwriter.Writer, in Answer) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -3398,23 +5812,23 @@ func easyjson56de76c1EncodeSkillForceInternalModelsDto31(out *jwriter.Writer, in
 // MarshalJSON supports json.Marshaler interface
 func (v Answer) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson56de76c1EncodeSkillForceInternalModelsDto31(&w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto51(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Answer) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson56de76c1EncodeSkillForceInternalModelsDto31(w, v)
+	easyjson56de76c1EncodeSkillForceInternalModelsDto51(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Answer) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson56de76c1DecodeSkillForceInternalModelsDto31(&r, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto51(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Answer) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson56de76c1DecodeSkillForceInternalModelsDto31(l, v)
+	easyjson56de76c1DecodeSkillForceInternalModelsDto51(l, v)
 }
@@ -17,4 +17,7 @@ type UserProfile struct {
 	AvatarSrc string
 	HideEmail bool
 	IsAdmin   bool
+	Roles     []string
+	Locale    string
+	Timezone  string
 }
@@ -6,21 +6,36 @@ import (
 	"io"
 	"log"
 	"skillForce/config"
+	coursemodels "skillForce/internal/models/course"
 	"skillForce/internal/models/dto"
+	"skillForce/internal/repository/course/ffmpeg"
 	"skillForce/internal/repository/course/minio"
 	"skillForce/internal/repository/course/postgres"
+	"skillForce/pkg/storage"
+	"skillForce/pkg/storagegc"
+	"time"
 )
 
 type CourseInfrastructure struct {
-	Database *postgres.Database
-	Minio    *minio.Minio
+	Database   *postgres.Database
+	Minio      *minio.Minio
+	Transcoder *ffmpeg.Transcoder
 }
 
 func NewCourseInfrastructure(conf *config.Config) *CourseInfrastructure {
-	mn, err := minio.NewMinio(conf.Minio.Endpoint, conf.Minio.AccessKey, conf.Minio.SecretAccessKey, conf.Minio.UseSSL, conf.Minio.BucketName, conf.Minio.VideoBucket)
+	store, err := storage.New(storage.Config{
+		Backend:         conf.Storage.Backend,
+		Endpoint:        conf.Minio.Endpoint,
+		AccessKey:       conf.Minio.AccessKey,
+		SecretAccessKey: conf.Minio.SecretAccessKey,
+		UseSSL:          conf.Minio.UseSSL,
+		LocalDir:        conf.Storage.LocalDir,
+		PublicURL:       conf.Storage.PublicURL,
+	})
 	if err != nil {
-		log.Fatalf("Failed to connect to MinIO: %v", err)
+		log.Fatalf("Failed to connect to object storage: %v", err)
 	}
+	mn := minio.NewMinio(store, conf.Storage.PublicURL, conf.Minio.BucketName, conf.Minio.VideoBucket, conf.Minio.SertificatesBucket, conf.Minio.LessonFilesBucket, conf.Minio.BundlesBucket, conf.Minio.ExportBucket)
 
 	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable", conf.Database.Host, conf.Database.Port, conf.Database.User, conf.Database.Password, conf.Database.Name)
 	database, err := postgres.NewDatabase(dsn, conf.Secrets.JwtSessionSecret)
@@ -29,8 +44,9 @@ func NewCourseInfrastructure(conf *config.Config) *CourseInfrastructure {
 	}
 
 	return &CourseInfrastructure{
-		Database: database,
-		Minio:    mn,
+		Database:   database,
+		Minio:      mn,
+		Transcoder: ffmpeg.NewTranscoder(),
 	}
 }
 
@@ -49,3 +65,151 @@ func (ci *CourseInfrastructure) GetVideoRange(ctx context.Context, name string,
 func (ci *CourseInfrastructure) GetVideoUrl(ctx context.Context, lessonId int) (string, error) {
 	return ci.Database.GetVideoUrl(ctx, lessonId)
 }
+
+func (ci *CourseInfrastructure) GetVideoLessonAuthor(ctx context.Context, lessonId int) (int, error) {
+	return ci.Database.GetVideoLessonAuthor(ctx, lessonId)
+}
+
+func (ci *CourseInfrastructure) CreateVideoUpload(ctx context.Context, upload *coursemodels.VideoUpload) error {
+	return ci.Database.CreateVideoUpload(ctx, upload)
+}
+
+func (ci *CourseInfrastructure) GetVideoUpload(ctx context.Context, uploadId string) (*coursemodels.VideoUpload, error) {
+	return ci.Database.GetVideoUpload(ctx, uploadId)
+}
+
+func (ci *CourseInfrastructure) SaveVideoUploadPart(ctx context.Context, uploadId string, part *coursemodels.VideoUploadPart) error {
+	return ci.Database.SaveVideoUploadPart(ctx, uploadId, part)
+}
+
+func (ci *CourseInfrastructure) FinishVideoUpload(ctx context.Context, upload *coursemodels.VideoUpload, status string, videoSrc string) error {
+	return ci.Database.FinishVideoUpload(ctx, upload, status, videoSrc)
+}
+
+func (ci *CourseInfrastructure) GetStaleVideoUploads(ctx context.Context, staleAfter time.Duration, limit int) ([]*coursemodels.VideoUpload, error) {
+	return ci.Database.GetStaleVideoUploads(ctx, staleAfter, limit)
+}
+
+func (ci *CourseInfrastructure) NewVideoUpload(ctx context.Context, objectName string, contentType string) (string, error) {
+	return ci.Minio.NewVideoUpload(ctx, objectName, contentType)
+}
+
+func (ci *CourseInfrastructure) PutVideoPart(ctx context.Context, upload *coursemodels.VideoUpload, partNumber int, data io.Reader, size int64) (string, error) {
+	return ci.Minio.PutVideoPart(ctx, upload, partNumber, data, size)
+}
+
+func (ci *CourseInfrastructure) CompleteVideoUpload(ctx context.Context, upload *coursemodels.VideoUpload) error {
+	return ci.Minio.CompleteVideoUpload(ctx, upload)
+}
+
+func (ci *CourseInfrastructure) AbortVideoUpload(ctx context.Context, upload *coursemodels.VideoUpload) error {
+	return ci.Minio.AbortVideoUpload(ctx, upload)
+}
+
+func (ci *CourseInfrastructure) VideoUrl(objectName string) string {
+	return ci.Minio.VideoUrl(objectName)
+}
+
+func (ci *CourseInfrastructure) ClaimTranscodeJob(ctx context.Context, staleAfter time.Duration) (*coursemodels.TranscodeJob, error) {
+	return ci.Database.ClaimTranscodeJob(ctx, staleAfter)
+}
+
+func (ci *CourseInfrastructure) FinishTranscodeJob(ctx context.Context, job *coursemodels.TranscodeJob, hlsPrefix string) error {
+	return ci.Database.FinishTranscodeJob(ctx, job, hlsPrefix)
+}
+
+func (ci *CourseInfrastructure) FailTranscodeJob(ctx context.Context, job *coursemodels.TranscodeJob, reason string, maxAttempts int) error {
+	return ci.Database.FailTranscodeJob(ctx, job, reason, maxAttempts)
+}
+
+func (ci *CourseInfrastructure) GetHLSPrefix(ctx context.Context, lessonId int) (string, error) {
+	return ci.Database.GetHLSPrefix(ctx, lessonId)
+}
+
+func (ci *CourseInfrastructure) DownloadVideo(ctx context.Context, objectName string, filePath string) error {
+	return ci.Minio.DownloadVideo(ctx, objectName, filePath)
+}
+
+func (ci *CourseInfrastructure) UploadVideoFile(ctx context.Context, objectName string, filePath string, contentType string) error {
+	return ci.Minio.UploadVideoFile(ctx, objectName, filePath, contentType)
+}
+
+func (ci *CourseInfrastructure) GetVideoObject(ctx context.Context, objectName string) (io.ReadCloser, dto.VideoMeta, error) {
+	return ci.Minio.GetVideoObject(ctx, objectName)
+}
+
+func (ci *CourseInfrastructure) TranscodeVideo(ctx context.Context, src string, outDir string) error {
+	return ci.Transcoder.Transcode(ctx, src, outDir)
+}
+
+func (ci *CourseInfrastructure) GetSertificateObject(ctx context.Context, objectName string) (io.ReadCloser, dto.VideoMeta, error) {
+	return ci.Minio.GetSertificateObject(ctx, objectName)
+}
+
+func (ci *CourseInfrastructure) PutLessonFile(ctx context.Context, objectName string, data []byte, contentType string) error {
+	return ci.Minio.PutLessonFile(ctx, objectName, data, contentType)
+}
+
+func (ci *CourseInfrastructure) GetLessonFileObject(ctx context.Context, objectName string) (io.ReadCloser, dto.VideoMeta, error) {
+	return ci.Minio.GetLessonFileObject(ctx, objectName)
+}
+
+func (ci *CourseInfrastructure) CreateLessonFile(ctx context.Context, file *coursemodels.LessonFile) error {
+	return ci.Database.CreateLessonFile(ctx, file)
+}
+
+func (ci *CourseInfrastructure) GetCourseBundle(ctx context.Context, courseId int, format string, withVideos bool) (*coursemodels.CourseBundle, error) {
+	return ci.Database.GetCourseBundle(ctx, courseId, format, withVideos)
+}
+
+func (ci *CourseInfrastructure) RequestCourseBundle(ctx context.Context, bundle *coursemodels.CourseBundle) (*coursemodels.CourseBundle, error) {
+	return ci.Database.RequestCourseBundle(ctx, bundle)
+}
+
+func (ci *CourseInfrastructure) ClaimCourseBundleJob(ctx context.Context, staleAfter time.Duration) (*coursemodels.CourseBundle, error) {
+	return ci.Database.ClaimCourseBundleJob(ctx, staleAfter)
+}
+
+func (ci *CourseInfrastructure) FinishCourseBundleJob(ctx context.Context, job *coursemodels.CourseBundle, objectName string) error {
+	return ci.Database.FinishCourseBundleJob(ctx, job, objectName)
+}
+
+func (ci *CourseInfrastructure) FailCourseBundleJob(ctx context.Context, job *coursemodels.CourseBundle, reason string, maxAttempts int) error {
+	return ci.Database.FailCourseBundleJob(ctx, job, reason, maxAttempts)
+}
+
+func (ci *CourseInfrastructure) PutCourseBundle(ctx context.Context, objectName string, filePath string, contentType string) error {
+	return ci.Minio.PutCourseBundle(ctx, objectName, filePath, contentType)
+}
+
+func (ci *CourseInfrastructure) GetCourseBundleObject(ctx context.Context, objectName string) (io.ReadCloser, dto.VideoMeta, error) {
+	return ci.Minio.GetCourseBundleObject(ctx, objectName)
+}
+
+func (ci *CourseInfrastructure) RemoveCourseBundle(ctx context.Context, objectName string) error {
+	return ci.Minio.RemoveCourseBundle(ctx, objectName)
+}
+
+func (ci *CourseInfrastructure) RemuxVideo(ctx context.Context, src string, dst string) error {
+	return ci.Transcoder.Remux(ctx, src, dst)
+}
+
+func (ci *CourseInfrastructure) StorageBucket(kind string) (string, error) {
+	return ci.Minio.StorageBucket(kind)
+}
+
+func (ci *CourseInfrastructure) ListStorageObjects(ctx context.Context, kind string) ([]storagegc.Object, error) {
+	return ci.Minio.ListStorageObjects(ctx, kind)
+}
+
+func (ci *CourseInfrastructure) RemoveStorageObject(ctx context.Context, kind string, objectName string) error {
+	return ci.Minio.RemoveStorageObject(ctx, kind, objectName)
+}
+
+func (ci *CourseInfrastructure) GetStorageReferences(ctx context.Context, kind string) (*storagegc.References, error) {
+	return ci.Database.GetStorageReferences(ctx, kind)
+}
+
+func (ci *CourseInfrastructure) DeleteLessonFile(ctx context.Context, objectName string) error {
+	return ci.Database.DeleteLessonFile(ctx, objectName)
+}
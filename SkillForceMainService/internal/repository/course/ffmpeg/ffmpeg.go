@@ -0,0 +1,173 @@
+package ffmpeg
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Rendition - одно качество HLS видео
+type Rendition struct {
+	Name         string
+	Height       int
+	VideoBitrate int
+	AudioBitrate int
+}
+
+// DefaultRenditions - от низкого качества для мобильного интернета до 720p
+var DefaultRenditions = []Rendition{
+	{Name: "240p", Height: 240, VideoBitrate: 400_000, AudioBitrate: 64_000},
+	{Name: "360p", Height: 360, VideoBitrate: 800_000, AudioBitrate: 96_000},
+	{Name: "480p", Height: 480, VideoBitrate: 1_400_000, AudioBitrate: 128_000},
+	{Name: "720p", Height: 720, VideoBitrate: 2_800_000, AudioBitrate: 128_000},
+}
+
+const (
+	MasterPlaylist = "master.m3u8"
+	MediaPlaylist  = "index.m3u8"
+)
+
+type Transcoder struct {
+	FFmpegPath      string
+	FFprobePath     string
+	SegmentDuration int
+	Renditions      []Rendition
+}
+
+func NewTranscoder() *Transcoder {
+	return &Transcoder{
+		FFmpegPath:      "ffmpeg",
+		FFprobePath:     "ffprobe",
+		SegmentDuration: 6,
+		Renditions:      DefaultRenditions,
+	}
+}
+
+// Transcode - перекодирование src в HLS. В outDir появляются master.m3u8 и по папке
+// на каждое качество с index.m3u8 и сегментами seg_NNN.ts
+func (t *Transcoder) Transcode(ctx context.Context, src string, outDir string) error {
+	width, height, err := t.probe(ctx, src)
+	if err != nil {
+		return err
+	}
+
+	renditions := t.renditionsFor(height)
+	for _, rendition := range renditions {
+		if err := t.transcodeRendition(ctx, src, outDir, rendition); err != nil {
+			return fmt.Errorf("rendition %s: %w", rendition.Name, err)
+		}
+	}
+
+	return os.WriteFile(filepath.Join(outDir, MasterPlaylist), masterPlaylist(renditions, width, height), 0o644)
+}
+
+// renditionsFor - качества не выше исходного видео, чтобы не раздувать файлы апскейлом.
+// Для видео ниже минимального качества остаётся одно качество в исходном размере
+func (t *Transcoder) renditionsFor(height int) []Rendition {
+	renditions := make([]Rendition, 0, len(t.Renditions))
+	for _, rendition := range t.Renditions {
+		if rendition.Height <= height {
+			renditions = append(renditions, rendition)
+		}
+	}
+	if len(renditions) == 0 && len(t.Renditions) > 0 {
+		lowest := t.Renditions[0]
+		lowest.Height = height - height%2
+		renditions = append(renditions, lowest)
+	}
+	return renditions
+}
+
+func (t *Transcoder) probe(ctx context.Context, src string) (int, int, error) {
+	out, err := t.run(ctx, t.FFprobePath, "-v", "error", "-select_streams", "v:0",
+		"-show_entries", "stream=width,height", "-of", "csv=p=0:s=x", src)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	size := strings.SplitN(strings.TrimSpace(out), "x", 2)
+	if len(size) != 2 {
+		return 0, 0, fmt.Errorf("no video stream in %s", src)
+	}
+	width, err := strconv.Atoi(size[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid video width %q", size[0])
+	}
+	height, err := strconv.Atoi(strings.TrimSpace(size[1]))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid video height %q", size[1])
+	}
+	if width <= 0 || height <= 0 {
+		return 0, 0, fmt.Errorf("invalid video size %dx%d", width, height)
+	}
+	return width, height, nil
+}
+
+func (t *Transcoder) transcodeRendition(ctx context.Context, src string, outDir string, rendition Rendition) error {
+	dir := filepath.Join(outDir, rendition.Name)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	// ключевые кадры на границах сегментов, чтобы плеер мог переключать качество между сегментами
+	_, err := t.run(ctx, t.FFmpegPath,
+		"-hide_banner", "-loglevel", "error", "-y",
+		"-i", src,
+		"-map", "0:v:0", "-map", "0:a:0?",
+		"-vf", fmt.Sprintf("scale=-2:%d", rendition.Height),
+		"-c:v", "libx264", "-preset", "veryfast", "-profile:v", "main", "-pix_fmt", "yuv420p",
+		"-b:v", strconv.Itoa(rendition.VideoBitrate),
+		"-maxrate", strconv.Itoa(rendition.VideoBitrate*107/100),
+		"-bufsize", strconv.Itoa(rendition.VideoBitrate*3/2),
+		"-force_key_frames", fmt.Sprintf("expr:gte(t,n_forced*%d)", t.SegmentDuration),
+		"-c:a", "aac", "-b:a", strconv.Itoa(rendition.AudioBitrate), "-ac", "2",
+		"-f", "hls",
+		"-hls_time", strconv.Itoa(t.SegmentDuration),
+		"-hls_playlist_type", "vod",
+		"-hls_segment_filename", filepath.Join(dir, "seg_%03d.ts"),
+		filepath.Join(dir, MediaPlaylist),
+	)
+	return err
+}
+
+// Remux - склеенные сегменты одного качества HLS в mp4 без перекодирования. moov атом переносится
+// в начало файла, чтобы видео начинало играть до полной загрузки
+func (t *Transcoder) Remux(ctx context.Context, src string, dst string) error {
+	_, err := t.run(ctx, t.FFmpegPath,
+		"-hide_banner", "-loglevel", "error", "-y",
+		"-i", src,
+		"-map", "0:v:0", "-map", "0:a:0?",
+		"-c", "copy", "-bsf:a", "aac_adtstoasc",
+		"-movflags", "+faststart",
+		dst,
+	)
+	return err
+}
+
+func (t *Transcoder) run(ctx context.Context, name string, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%s: %w: %s", filepath.Base(name), err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+func masterPlaylist(renditions []Rendition, width int, height int) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("#EXTM3U\n#EXT-X-VERSION:3\n")
+	for _, rendition := range renditions {
+		// ширина считается так же, как scale=-2: с сохранением пропорций и округлением до чётной
+		renditionWidth := (width*rendition.Height/height + 1) &^ 1
+		fmt.Fprintf(&buf, "#EXT-X-STREAM-INF:BANDWIDTH=%d,RESOLUTION=%dx%d\n%s/%s\n",
+			rendition.VideoBitrate+rendition.AudioBitrate, renditionWidth, rendition.Height, rendition.Name, MediaPlaylist)
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,118 @@
+package ffmpeg
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fixtureClip - двухсекундный клип 320x240 со звуком, собранный из тестовых источников ffmpeg
+func fixtureClip(t *testing.T, dir string) string {
+	t.Helper()
+	for _, tool := range []string{"ffmpeg", "ffprobe"} {
+		if _, err := exec.LookPath(tool); err != nil {
+			t.Skipf("%s is not installed", tool)
+		}
+	}
+
+	clip := filepath.Join(dir, "clip.mp4")
+	cmd := exec.Command("ffmpeg", "-hide_banner", "-loglevel", "error", "-y",
+		"-f", "lavfi", "-i", "testsrc=duration=2:size=320x240:rate=24",
+		"-f", "lavfi", "-i", "sine=duration=2",
+		"-shortest", "-c:v", "libx264", "-pix_fmt", "yuv420p", "-c:a", "aac", clip)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("failed to create fixture clip: %v: %s", err, out)
+	}
+	return clip
+}
+
+func TestTranscode(t *testing.T) {
+	dir := t.TempDir()
+	clip := fixtureClip(t, dir)
+	outDir := filepath.Join(dir, "hls")
+
+	transcoder := NewTranscoder()
+	transcoder.SegmentDuration = 1
+	if err := transcoder.Transcode(context.Background(), clip, outDir); err != nil {
+		t.Fatalf("Transcode() error = %v", err)
+	}
+
+	master, err := os.ReadFile(filepath.Join(outDir, MasterPlaylist))
+	if err != nil {
+		t.Fatalf("master playlist: %v", err)
+	}
+	if !strings.Contains(string(master), "RESOLUTION=320x240\n240p/index.m3u8") {
+		t.Errorf("master playlist does not reference 240p rendition:\n%s", master)
+	}
+	if strings.Contains(string(master), "360p") {
+		t.Errorf("master playlist contains upscaled rendition:\n%s", master)
+	}
+
+	media, err := os.ReadFile(filepath.Join(outDir, "240p", MediaPlaylist))
+	if err != nil {
+		t.Fatalf("media playlist: %v", err)
+	}
+	if !strings.Contains(string(media), "#EXT-X-ENDLIST") || !strings.Contains(string(media), "seg_000.ts") {
+		t.Errorf("unexpected media playlist:\n%s", media)
+	}
+	if _, err := os.Stat(filepath.Join(outDir, "240p", "seg_000.ts")); err != nil {
+		t.Errorf("first segment: %v", err)
+	}
+}
+
+func TestTranscodeNoVideo(t *testing.T) {
+	dir := t.TempDir()
+	fixtureClip(t, dir)
+
+	src := filepath.Join(dir, "not_video.mp4")
+	if err := os.WriteFile(src, []byte("not a video"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewTranscoder().Transcode(context.Background(), src, filepath.Join(dir, "hls")); err == nil {
+		t.Error("Transcode() expected error for invalid source")
+	}
+}
+
+func TestRenditionsFor(t *testing.T) {
+	transcoder := NewTranscoder()
+
+	tests := []struct {
+		name   string
+		height int
+		want   []string
+	}{
+		{name: "full hd", height: 1080, want: []string{"240p", "360p", "480p", "720p"}},
+		{name: "between renditions", height: 400, want: []string{"240p", "360p"}},
+		{name: "exact rendition", height: 240, want: []string{"240p"}},
+		{name: "below lowest", height: 181, want: []string{"240p"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := transcoder.renditionsFor(tt.height)
+			names := make([]string, 0, len(got))
+			for _, rendition := range got {
+				names = append(names, rendition.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("renditionsFor(%d) = %v, want %v", tt.height, names, tt.want)
+			}
+			if tt.height < 240 && got[0].Height != 180 {
+				t.Errorf("renditionsFor(%d) height = %d, want 180", tt.height, got[0].Height)
+			}
+		})
+	}
+}
+
+func TestMasterPlaylist(t *testing.T) {
+	got := string(masterPlaylist(DefaultRenditions[:2], 1920, 1080))
+	want := "#EXTM3U\n#EXT-X-VERSION:3\n" +
+		"#EXT-X-STREAM-INF:BANDWIDTH=464000,RESOLUTION=426x240\n240p/index.m3u8\n" +
+		"#EXT-X-STREAM-INF:BANDWIDTH=896000,RESOLUTION=640x360\n360p/index.m3u8\n"
+	if got != want {
+		t.Errorf("masterPlaylist() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -1,66 +1,181 @@
 package minio
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"mime/multipart"
+	"path"
+	coursemodels "skillForce/internal/models/course"
 	"skillForce/internal/models/dto"
-	"strings"
+	"skillForce/pkg/storage"
+	"skillForce/pkg/storagegc"
 
 	"github.com/google/uuid"
-	"github.com/minio/minio-go"
 )
 
 type Minio struct {
-	MinioClient   *minio.Client
-	AvatarsBucket string
-	VideoBucket   string
+	Storage            storage.Storage
+	PublicURL          string
+	AvatarsBucket      string
+	VideoBucket        string
+	SertificatesBucket string
+	LessonFilesBucket  string
+	BundlesBucket      string
+	ExportBucket       string
 }
 
-func NewMinio(endpoint string, accessKeyID string, secretAccessKey string, useSSL bool, bucketName string, videoBucket string) (*Minio, error) {
-	minioClient, err := minio.New(endpoint, accessKeyID, secretAccessKey, useSSL)
-	return &Minio{MinioClient: minioClient, AvatarsBucket: bucketName, VideoBucket: videoBucket}, err
+func NewMinio(store storage.Storage, publicURL string, bucketName string, videoBucket string, sertificatesBucket string, lessonFilesBucket string, bundlesBucket string, exportBucket string) *Minio {
+	return &Minio{Storage: store, PublicURL: publicURL, AvatarsBucket: bucketName, VideoBucket: videoBucket, SertificatesBucket: sertificatesBucket, LessonFilesBucket: lessonFilesBucket, BundlesBucket: bundlesBucket, ExportBucket: exportBucket}
 }
 
 func (mn *Minio) UploadFileToMinIO(ctx context.Context, file multipart.File, fileHeader *multipart.FileHeader) (string, error) {
 
 	uniqueID := uuid.New().String()
-	ext := ""
-	if fileHeader.Filename != "" {
-		ext = fileHeader.Filename[strings.LastIndex(fileHeader.Filename, "."):]
-	}
+	// у имени без точки расширения нет, а не паника на срезе с индексом -1
+	ext := path.Ext(fileHeader.Filename)
 	objectName := fmt.Sprintf("%s%s", uniqueID, ext)
 	contentType := fileHeader.Header.Get("Content-Type")
 
-	_, err := mn.MinioClient.PutObject(
-		mn.AvatarsBucket,
-		objectName,
-		file,
-		fileHeader.Size,
-		minio.PutObjectOptions{ContentType: contentType},
-	)
+	err := mn.Storage.Put(ctx, mn.AvatarsBucket, objectName, file, fileHeader.Size, storage.PutOptions{ContentType: contentType})
 	if err != nil {
 		return "", err
 	}
 
-	fileURL := fmt.Sprintf("http://217.16.21.64:8006/%s/%s", mn.AvatarsBucket, objectName)
-	return fileURL, nil
+	return storage.PublicURL(mn.PublicURL, mn.AvatarsBucket, objectName), nil
 }
 
 func (mn *Minio) GetVideoRange(ctx context.Context, name string, start, end int64) (io.ReadCloser, error) {
-	opts := minio.GetObjectOptions{}
-	err := opts.SetRange(start, end)
+	return mn.Storage.GetRange(ctx, mn.VideoBucket, name, start, end)
+}
+
+func (mn *Minio) Stat(ctx context.Context, name string) (dto.VideoMeta, error) {
+	info, err := mn.Storage.Stat(ctx, mn.VideoBucket, name)
+	if err != nil {
+		return dto.VideoMeta{}, err
+	}
+	return videoMeta(info), nil
+}
+
+func videoMeta(info storage.ObjectInfo) dto.VideoMeta {
+	return dto.VideoMeta{Name: info.Name, Size: info.Size, ContentType: info.ContentType, ETag: info.ETag, LastModified: info.LastModified}
+}
+
+// NewVideoUpload - начало multipart загрузки видео, возвращает id загрузки в хранилище
+func (mn *Minio) NewVideoUpload(ctx context.Context, objectName string, contentType string) (string, error) {
+	return mn.Storage.NewMultipartUpload(ctx, mn.VideoBucket, objectName, storage.PutOptions{ContentType: contentType})
+}
+
+func (mn *Minio) PutVideoPart(ctx context.Context, upload *coursemodels.VideoUpload, partNumber int, data io.Reader, size int64) (string, error) {
+	return mn.Storage.PutPart(ctx, mn.VideoBucket, upload.ObjectName, upload.MinioUploadId, partNumber, data, size)
+}
+
+func (mn *Minio) CompleteVideoUpload(ctx context.Context, upload *coursemodels.VideoUpload) error {
+	parts := make([]storage.Part, 0, len(upload.Parts))
+	for _, part := range upload.Parts {
+		parts = append(parts, storage.Part{PartNumber: part.PartNumber, ETag: part.ETag})
+	}
+	return mn.Storage.CompleteMultipartUpload(ctx, mn.VideoBucket, upload.ObjectName, upload.MinioUploadId, parts)
+}
+
+func (mn *Minio) AbortVideoUpload(ctx context.Context, upload *coursemodels.VideoUpload) error {
+	return mn.Storage.AbortMultipartUpload(ctx, mn.VideoBucket, upload.ObjectName, upload.MinioUploadId)
+}
+
+func (mn *Minio) VideoUrl(objectName string) string {
+	return storage.PublicURL(mn.PublicURL, mn.VideoBucket, objectName)
+}
+
+// DownloadVideo - сохранение исходного видео в локальный файл для перекодирования
+func (mn *Minio) DownloadVideo(ctx context.Context, objectName string, filePath string) error {
+	return storage.GetFile(ctx, mn.Storage, mn.VideoBucket, objectName, filePath)
+}
+
+func (mn *Minio) UploadVideoFile(ctx context.Context, objectName string, filePath string, contentType string) error {
+	return storage.PutFile(ctx, mn.Storage, mn.VideoBucket, objectName, filePath, storage.PutOptions{ContentType: contentType})
+}
+
+// GetVideoObject - объект бакета видео целиком вместе с размером и типом
+func (mn *Minio) GetVideoObject(ctx context.Context, objectName string) (io.ReadCloser, dto.VideoMeta, error) {
+	return mn.getObject(ctx, mn.VideoBucket, objectName)
+}
+
+func (mn *Minio) GetSertificateObject(ctx context.Context, objectName string) (io.ReadCloser, dto.VideoMeta, error) {
+	return mn.getObject(ctx, mn.SertificatesBucket, objectName)
+}
+
+// PutLessonFile - картинка или вложение текстового урока. Бакет закрыт, файлы отдаются по подписанным ссылкам
+func (mn *Minio) PutLessonFile(ctx context.Context, objectName string, data []byte, contentType string) error {
+	return mn.Storage.Put(ctx, mn.LessonFilesBucket, objectName, bytes.NewReader(data), int64(len(data)), storage.PutOptions{ContentType: contentType})
+}
+
+func (mn *Minio) GetLessonFileObject(ctx context.Context, objectName string) (io.ReadCloser, dto.VideoMeta, error) {
+	return mn.getObject(ctx, mn.LessonFilesBucket, objectName)
+}
+
+// PutCourseBundle - собранная офлайн выгрузка курса. Бакет закрыт, выгрузки отдаются по подписанным ссылкам
+func (mn *Minio) PutCourseBundle(ctx context.Context, objectName string, filePath string, contentType string) error {
+	return storage.PutFile(ctx, mn.Storage, mn.BundlesBucket, objectName, filePath, storage.PutOptions{ContentType: contentType})
+}
+
+func (mn *Minio) GetCourseBundleObject(ctx context.Context, objectName string) (io.ReadCloser, dto.VideoMeta, error) {
+	return mn.getObject(ctx, mn.BundlesBucket, objectName)
+}
+
+func (mn *Minio) RemoveCourseBundle(ctx context.Context, objectName string) error {
+	return mn.Storage.Delete(ctx, mn.BundlesBucket, objectName)
+}
+
+// StorageBucket - имя бакета, который сборщик мусора знает как kind
+func (mn *Minio) StorageBucket(kind string) (string, error) {
+	switch kind {
+	case storagegc.Avatars:
+		return mn.AvatarsBucket, nil
+	case storagegc.Videos:
+		return mn.VideoBucket, nil
+	case storagegc.Sertificates:
+		return mn.SertificatesBucket, nil
+	case storagegc.LessonFiles:
+		return mn.LessonFilesBucket, nil
+	case storagegc.Bundles:
+		return mn.BundlesBucket, nil
+	case storagegc.Exports:
+		return mn.ExportBucket, nil
+	}
+	return "", fmt.Errorf("unknown storage %s", kind)
+}
+
+// ListStorageObjects - все объекты бакета, включая вложенные папки
+func (mn *Minio) ListStorageObjects(ctx context.Context, kind string) ([]storagegc.Object, error) {
+	bucket, err := mn.StorageBucket(kind)
 	if err != nil {
 		return nil, err
 	}
-	return mn.MinioClient.GetObject(mn.VideoBucket, name, opts)
+
+	infos, err := mn.Storage.List(ctx, bucket, "")
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]storagegc.Object, 0, len(infos))
+	for _, info := range infos {
+		objects = append(objects, storagegc.Object{Name: info.Name, Size: info.Size, LastModified: info.LastModified})
+	}
+	return objects, nil
 }
 
-func (mn *Minio) Stat(ctx context.Context, name string) (dto.VideoMeta, error) {
-	info, err := mn.MinioClient.StatObject(mn.VideoBucket, name, minio.StatObjectOptions{})
+func (mn *Minio) RemoveStorageObject(ctx context.Context, kind string, objectName string) error {
+	bucket, err := mn.StorageBucket(kind)
 	if err != nil {
-		return dto.VideoMeta{}, err
+		return err
+	}
+	return mn.Storage.Delete(ctx, bucket, objectName)
+}
+
+func (mn *Minio) getObject(ctx context.Context, bucket string, objectName string) (io.ReadCloser, dto.VideoMeta, error) {
+	reader, info, err := storage.Get(ctx, mn.Storage, bucket, objectName)
+	if err != nil {
+		return nil, dto.VideoMeta{}, err
 	}
-	return dto.VideoMeta{Name: name, Size: info.Size}, nil
+	return reader, videoMeta(info), nil
 }
@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	coursemodels "skillForce/internal/models/course"
+	"skillForce/pkg/logs"
+)
+
+// GetCourseBundle - состояние выгрузки курса. Если выгрузку ещё не запрашивали, возвращается nil
+func (d *Database) GetCourseBundle(ctx context.Context, courseId int, format string, withVideos bool) (*coursemodels.CourseBundle, error) {
+	bundle := coursemodels.CourseBundle{CourseId: courseId, Format: format, WithVideos: withVideos}
+	err := d.conn.QueryRowContext(ctx, `
+		SELECT version, status, object_name, attempts FROM course_bundles
+		WHERE course_id = $1 AND format = $2 AND with_videos = $3`, courseId, format, withVideos).
+		Scan(&bundle.Version, &bundle.Status, &bundle.ObjectName, &bundle.Attempts)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		logs.PrintLog(ctx, "GetCourseBundle", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+	return &bundle, nil
+}
+
+// RequestCourseBundle - постановка сборки в очередь. Выгрузка той же версии, которая уже собрана
+// или собирается, не пересобирается. Упавшая сборка по новому запросу начинается заново
+func (d *Database) RequestCourseBundle(ctx context.Context, bundle *coursemodels.CourseBundle) (*coursemodels.CourseBundle, error) {
+	_, err := d.conn.ExecContext(ctx, `
+		INSERT INTO course_bundles (course_id, format, with_videos, version, content)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (course_id, format, with_videos) DO UPDATE
+		SET version = EXCLUDED.version, content = EXCLUDED.content, status = 'pending', attempts = 0, error = '', updated_at = NOW()
+		WHERE course_bundles.version <> EXCLUDED.version OR course_bundles.status = 'failed'`,
+		bundle.CourseId, bundle.Format, bundle.WithVideos, bundle.Version, bundle.Content)
+	if err != nil {
+		logs.PrintLog(ctx, "RequestCourseBundle", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+	return d.GetCourseBundle(ctx, bundle.CourseId, bundle.Format, bundle.WithVideos)
+}
+
+// ClaimCourseBundleJob - взятие следующей сборки. Как и у перекодирования видео, сборки в статусе
+// processing, которые не обновлялись дольше staleAfter, берутся повторно. Если задач нет, возвращается nil
+func (d *Database) ClaimCourseBundleJob(ctx context.Context, staleAfter time.Duration) (*coursemodels.CourseBundle, error) {
+	var job coursemodels.CourseBundle
+	err := d.conn.QueryRowContext(ctx, `
+		UPDATE course_bundles SET status = 'processing', attempts = attempts + 1, updated_at = NOW()
+		WHERE (course_id, format, with_videos) = (
+			SELECT course_id, format, with_videos FROM course_bundles
+			WHERE status = 'pending' OR (status = 'processing' AND updated_at < NOW() - make_interval(secs => $1))
+			ORDER BY updated_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING course_id, format, with_videos, version, content, status, object_name, attempts`, staleAfter.Seconds()).
+		Scan(&job.CourseId, &job.Format, &job.WithVideos, &job.Version, &job.Content, &job.Status, &job.ObjectName, &job.Attempts)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		logs.PrintLog(ctx, "ClaimCourseBundleJob", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+
+	logs.PrintLog(ctx, "ClaimCourseBundleJob", fmt.Sprintf("claim %s bundle of course %d version %s, attempt %d", job.Format, job.CourseId, job.Version, job.Attempts))
+	return &job, nil
+}
+
+// FinishCourseBundleJob - публикация собранной выгрузки. Если за время сборки курс изменился,
+// результат отбрасывается
+func (d *Database) FinishCourseBundleJob(ctx context.Context, job *coursemodels.CourseBundle, objectName string) error {
+	result, err := d.conn.ExecContext(ctx, `
+		UPDATE course_bundles SET status = 'ready', object_name = $1, error = '', updated_at = NOW()
+		WHERE course_id = $2 AND format = $3 AND with_videos = $4 AND version = $5 AND status = 'processing'`,
+		objectName, job.CourseId, job.Format, job.WithVideos, job.Version)
+	if err != nil {
+		logs.PrintLog(ctx, "FinishCourseBundleJob", fmt.Sprintf("%+v", err))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("bundle job is outdated")
+	}
+
+	logs.PrintLog(ctx, "FinishCourseBundleJob", fmt.Sprintf("%s bundle of course %d is available at %s", job.Format, job.CourseId, objectName))
+	return nil
+}
+
+// FailCourseBundleJob - сборка возвращается в очередь, пока не исчерпаны maxAttempts попыток
+func (d *Database) FailCourseBundleJob(ctx context.Context, job *coursemodels.CourseBundle, reason string, maxAttempts int) error {
+	_, err := d.conn.ExecContext(ctx, `
+		UPDATE course_bundles SET status = CASE WHEN attempts >= $1 THEN 'failed' ELSE 'pending' END, error = $2, updated_at = NOW()
+		WHERE course_id = $3 AND format = $4 AND with_videos = $5 AND version = $6 AND status = 'processing'`,
+		maxAttempts, reason, job.CourseId, job.Format, job.WithVideos, job.Version)
+	if err != nil {
+		logs.PrintLog(ctx, "FailCourseBundleJob", fmt.Sprintf("%+v", err))
+		return err
+	}
+	return nil
+}
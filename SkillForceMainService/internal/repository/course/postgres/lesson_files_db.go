@@ -0,0 +1,24 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	coursemodels "skillForce/internal/models/course"
+	"skillForce/pkg/logs"
+)
+
+// CreateLessonFile - запись о загруженном файле, по ней course-service проверяет владельца при создании блока
+func (d *Database) CreateLessonFile(ctx context.Context, file *coursemodels.LessonFile) error {
+	_, err := d.conn.ExecContext(ctx, `
+		INSERT INTO lesson_files (object_name, author_id, kind, file_name, content_type, size)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		file.ObjectName, file.AuthorId, file.Kind, file.FileName, file.ContentType, file.Size)
+	if err != nil {
+		logs.PrintLog(ctx, "CreateLessonFile", fmt.Sprintf("%+v", err))
+		return err
+	}
+
+	logs.PrintLog(ctx, "CreateLessonFile", fmt.Sprintf("user %d uploaded %s %s", file.AuthorId, file.Kind, file.ObjectName))
+	return nil
+}
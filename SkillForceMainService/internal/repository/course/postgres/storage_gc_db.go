@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"skillForce/pkg/logs"
+	"skillForce/pkg/storagegc"
+)
+
+// storageReferenceQueries - где в базе лежат ссылки на объекты каждого бакета. Адреса и HTML
+// разбираются сборщиком, имена объектов и папки сравниваются как есть
+var storageReferenceQueries = map[string]struct {
+	texts    string
+	objects  string
+	prefixes string
+}{
+	storagegc.Avatars: {
+		// картинки старых html блоков загружались в бакет аватарок
+		texts: `SELECT COALESCE(avatar_src, '') FROM usertable
+			UNION ALL SELECT COALESCE(avatar_src, '') FROM course
+			UNION ALL SELECT COALESCE(value, '') FROM text_lesson_block`,
+	},
+	storagegc.Videos: {
+		texts: "SELECT video_src FROM video_lesson",
+		objects: `SELECT source_object FROM video_transcodes
+			UNION ALL SELECT object_name FROM video_uploads WHERE status <> 'aborted'`,
+		prefixes: "SELECT hls_prefix FROM video_transcodes WHERE hls_prefix <> ''",
+	},
+	storagegc.Sertificates: {
+		texts: "SELECT sertificate_src FROM SERTIFICATES",
+	},
+	storagegc.LessonFiles: {
+		objects: "SELECT payload->>'object' FROM text_lesson_block WHERE payload->>'object' <> ''",
+	},
+	storagegc.Bundles: {
+		objects: "SELECT object_name FROM course_bundles WHERE object_name <> ''",
+	},
+	storagegc.Exports: {},
+}
+
+// GetStorageReferences - все ссылки из базы на объекты бакета kind
+func (d *Database) GetStorageReferences(ctx context.Context, kind string) (*storagegc.References, error) {
+	queries, ok := storageReferenceQueries[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown storage %s", kind)
+	}
+
+	refs := &storagegc.References{}
+	for _, query := range []struct {
+		sql    string
+		result *[]string
+	}{
+		{queries.texts, &refs.Texts},
+		{queries.objects, &refs.Objects},
+		{queries.prefixes, &refs.Prefixes},
+	} {
+		if query.sql == "" {
+			continue
+		}
+		values, err := d.queryStrings(ctx, query.sql)
+		if err != nil {
+			logs.PrintLog(ctx, "GetStorageReferences", fmt.Sprintf("%s: %+v", kind, err))
+			return nil, err
+		}
+		*query.result = values
+	}
+	return refs, nil
+}
+
+func (d *Database) queryStrings(ctx context.Context, query string) ([]string, error) {
+	rows, err := d.conn.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, rows.Err()
+}
+
+// DeleteLessonFile - запись о файле удаляется вместе с объектом, на который не ссылается ни один блок
+func (d *Database) DeleteLessonFile(ctx context.Context, objectName string) error {
+	_, err := d.conn.ExecContext(ctx, "DELETE FROM lesson_files WHERE object_name = $1", objectName)
+	if err != nil {
+		logs.PrintLog(ctx, "DeleteLessonFile", fmt.Sprintf("%+v", err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	coursemodels "skillForce/internal/models/course"
+	"skillForce/pkg/logs"
+)
+
+// ClaimTranscodeJob - взятие следующей задачи перекодирования. Задачи в статусе processing, которые
+// не обновлялись дольше staleAfter, считаются брошенными упавшим воркером и берутся повторно.
+// Если задач нет, возвращается nil
+func (d *Database) ClaimTranscodeJob(ctx context.Context, staleAfter time.Duration) (*coursemodels.TranscodeJob, error) {
+	var job coursemodels.TranscodeJob
+	err := d.conn.QueryRowContext(ctx, `
+		UPDATE video_transcodes SET status = 'processing', attempts = attempts + 1, updated_at = NOW()
+		WHERE lesson_id = (
+			SELECT lesson_id FROM video_transcodes
+			WHERE status = 'pending' OR (status = 'processing' AND updated_at < NOW() - make_interval(secs => $1))
+			ORDER BY updated_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING lesson_id, source_object, attempts`, staleAfter.Seconds()).
+		Scan(&job.LessonId, &job.SourceObject, &job.Attempts)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		logs.PrintLog(ctx, "ClaimTranscodeJob", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+
+	logs.PrintLog(ctx, "ClaimTranscodeJob", fmt.Sprintf("claim transcoding of video %s for lesson %d, attempt %d", job.SourceObject, job.LessonId, job.Attempts))
+	return &job, nil
+}
+
+// FinishTranscodeJob - публикация HLS версии видео. Если за время перекодирования к уроку
+// загрузили другое видео, результат отбрасывается
+func (d *Database) FinishTranscodeJob(ctx context.Context, job *coursemodels.TranscodeJob, hlsPrefix string) error {
+	result, err := d.conn.ExecContext(ctx, `
+		UPDATE video_transcodes SET status = 'ready', hls_prefix = $1, error = '', updated_at = NOW()
+		WHERE lesson_id = $2 AND source_object = $3 AND status = 'processing'`, hlsPrefix, job.LessonId, job.SourceObject)
+	if err != nil {
+		logs.PrintLog(ctx, "FinishTranscodeJob", fmt.Sprintf("%+v", err))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("transcode job is outdated")
+	}
+
+	logs.PrintLog(ctx, "FinishTranscodeJob", fmt.Sprintf("video for lesson %d is available in HLS at %s", job.LessonId, hlsPrefix))
+	return nil
+}
+
+// FailTranscodeJob - задача возвращается в очередь, пока не исчерпаны maxAttempts попыток
+func (d *Database) FailTranscodeJob(ctx context.Context, job *coursemodels.TranscodeJob, reason string, maxAttempts int) error {
+	_, err := d.conn.ExecContext(ctx, `
+		UPDATE video_transcodes SET status = CASE WHEN attempts >= $1 THEN 'failed' ELSE 'pending' END, error = $2, updated_at = NOW()
+		WHERE lesson_id = $3 AND source_object = $4 AND status = 'processing'`, maxAttempts, reason, job.LessonId, job.SourceObject)
+	if err != nil {
+		logs.PrintLog(ctx, "FailTranscodeJob", fmt.Sprintf("%+v", err))
+		return err
+	}
+	return nil
+}
+
+// GetHLSPrefix - путь в бакете видео, по которому лежат плейлисты и сегменты урока
+func (d *Database) GetHLSPrefix(ctx context.Context, lessonId int) (string, error) {
+	var status, prefix string
+	err := d.conn.QueryRowContext(ctx, "SELECT status, hls_prefix FROM video_transcodes WHERE lesson_id = $1", lessonId).Scan(&status, &prefix)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", errors.New("video not found")
+	}
+	if err != nil {
+		logs.PrintLog(ctx, "GetHLSPrefix", fmt.Sprintf("%+v", err))
+		return "", err
+	}
+	// пока новое видео перекодируется, отдаётся предыдущая готовая версия
+	if prefix == "" {
+		return "", errors.New("video is not transcoded yet")
+	}
+	return prefix, nil
+}
@@ -0,0 +1,199 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	coursemodels "skillForce/internal/models/course"
+	"skillForce/pkg/logs"
+)
+
+// GetVideoLessonAuthor - id автора курса, к которому относится видеоурок
+func (d *Database) GetVideoLessonAuthor(ctx context.Context, lessonId int) (int, error) {
+	var creatorId int
+	err := d.conn.QueryRowContext(ctx, `
+		SELECT c.creator_user_id
+		FROM video_lesson vl
+		JOIN lesson l ON l.id = vl.lesson_id
+		JOIN lesson_bucket lb ON lb.id = l.lesson_bucket_id
+		JOIN part p ON p.id = lb.part_id
+		JOIN course c ON c.id = p.course_id
+		WHERE vl.lesson_id = $1`, lessonId).Scan(&creatorId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, errors.New("lesson not found")
+	}
+	if err != nil {
+		logs.PrintLog(ctx, "GetVideoLessonAuthor", fmt.Sprintf("%+v", err))
+		return 0, err
+	}
+	return creatorId, nil
+}
+
+func (d *Database) CreateVideoUpload(ctx context.Context, upload *coursemodels.VideoUpload) error {
+	_, err := d.conn.ExecContext(ctx, `
+		INSERT INTO video_uploads (id, user_id, lesson_id, object_name, minio_upload_id, content_type, total_size, chunk_size)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		upload.Id, upload.UserId, upload.LessonId, upload.ObjectName, upload.MinioUploadId, upload.ContentType, upload.TotalSize, upload.ChunkSize)
+	if err != nil {
+		logs.PrintLog(ctx, "CreateVideoUpload", fmt.Sprintf("%+v", err))
+		return err
+	}
+
+	logs.PrintLog(ctx, "CreateVideoUpload", fmt.Sprintf("user %d started upload %s of video for lesson %d", upload.UserId, upload.Id, upload.LessonId))
+	return nil
+}
+
+// GetVideoUpload - загрузка вместе с уже загруженными частями
+func (d *Database) GetVideoUpload(ctx context.Context, uploadId string) (*coursemodels.VideoUpload, error) {
+	var upload coursemodels.VideoUpload
+	err := d.conn.QueryRowContext(ctx, `
+		SELECT id, user_id, lesson_id, object_name, minio_upload_id, content_type, total_size, chunk_size, status
+		FROM video_uploads WHERE id = $1`, uploadId).
+		Scan(&upload.Id, &upload.UserId, &upload.LessonId, &upload.ObjectName, &upload.MinioUploadId, &upload.ContentType, &upload.TotalSize, &upload.ChunkSize, &upload.Status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, errors.New("upload not found")
+	}
+	if err != nil {
+		logs.PrintLog(ctx, "GetVideoUpload", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+
+	rows, err := d.conn.QueryContext(ctx, "SELECT part_number, etag, size FROM video_upload_parts WHERE upload_id = $1 ORDER BY part_number", uploadId)
+	if err != nil {
+		logs.PrintLog(ctx, "GetVideoUpload", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logs.PrintLog(ctx, "GetVideoUpload", fmt.Sprintf("%+v", err))
+		}
+	}()
+
+	for rows.Next() {
+		var part coursemodels.VideoUploadPart
+		if err := rows.Scan(&part.PartNumber, &part.ETag, &part.Size); err != nil {
+			logs.PrintLog(ctx, "GetVideoUpload", fmt.Sprintf("%+v", err))
+			return nil, err
+		}
+		upload.Parts = append(upload.Parts, &part)
+	}
+	return &upload, rows.Err()
+}
+
+// SaveVideoUploadPart - повторная загрузка той же части заменяет предыдущую
+func (d *Database) SaveVideoUploadPart(ctx context.Context, uploadId string, part *coursemodels.VideoUploadPart) error {
+	tx, err := d.conn.BeginTx(ctx, nil)
+	if err != nil {
+		logs.PrintLog(ctx, "SaveVideoUploadPart", fmt.Sprintf("failed to begin transaction: %+v", err))
+		return err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logs.PrintLog(ctx, "transaction rollback failed", fmt.Sprintf("error: %v", err))
+		}
+	}()
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO video_upload_parts (upload_id, part_number, etag, size) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (upload_id, part_number) DO UPDATE SET etag = EXCLUDED.etag, size = EXCLUDED.size`,
+		uploadId, part.PartNumber, part.ETag, part.Size)
+	if err != nil {
+		logs.PrintLog(ctx, "SaveVideoUploadPart", fmt.Sprintf("%+v", err))
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE video_uploads SET updated_at = NOW() WHERE id = $1", uploadId)
+	if err != nil {
+		logs.PrintLog(ctx, "SaveVideoUploadPart", fmt.Sprintf("%+v", err))
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// FinishVideoUpload - перевод загрузки в конечный статус. Для completed видео сразу
+// прикрепляется к уроку, чтобы урок не ссылался на незавершённую загрузку, и ставится в очередь на перекодирование
+func (d *Database) FinishVideoUpload(ctx context.Context, upload *coursemodels.VideoUpload, status string, videoSrc string) error {
+	tx, err := d.conn.BeginTx(ctx, nil)
+	if err != nil {
+		logs.PrintLog(ctx, "FinishVideoUpload", fmt.Sprintf("failed to begin transaction: %+v", err))
+		return err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logs.PrintLog(ctx, "transaction rollback failed", fmt.Sprintf("error: %v", err))
+		}
+	}()
+
+	result, err := tx.ExecContext(ctx, "UPDATE video_uploads SET status = $1, updated_at = NOW() WHERE id = $2 AND status = 'uploading'", status, upload.Id)
+	if err != nil {
+		logs.PrintLog(ctx, "FinishVideoUpload", fmt.Sprintf("%+v", err))
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("upload is not in progress")
+	}
+
+	if status == "completed" {
+		_, err = tx.ExecContext(ctx, "UPDATE video_lesson SET video_src = $1 WHERE lesson_id = $2", videoSrc, upload.LessonId)
+		if err != nil {
+			logs.PrintLog(ctx, "FinishVideoUpload", fmt.Sprintf("%+v", err))
+			return err
+		}
+
+		// новое видео заново ставится в очередь на перекодирование в HLS
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO video_transcodes (lesson_id, source_object) VALUES ($1, $2)
+			ON CONFLICT (lesson_id) DO UPDATE SET source_object = EXCLUDED.source_object, status = 'pending',
+				attempts = 0, error = '', updated_at = NOW()`, upload.LessonId, upload.ObjectName)
+		if err != nil {
+			logs.PrintLog(ctx, "FinishVideoUpload", fmt.Sprintf("%+v", err))
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		logs.PrintLog(ctx, "FinishVideoUpload", fmt.Sprintf("failed to commit transaction: %+v", err))
+		return err
+	}
+
+	logs.PrintLog(ctx, "FinishVideoUpload", fmt.Sprintf("upload %s of video for lesson %d is %s", upload.Id, upload.LessonId, status))
+	return nil
+}
+
+// GetStaleVideoUploads - незавершённые загрузки, в которые не приходили части дольше staleAfter
+func (d *Database) GetStaleVideoUploads(ctx context.Context, staleAfter time.Duration, limit int) ([]*coursemodels.VideoUpload, error) {
+	rows, err := d.conn.QueryContext(ctx, `
+		SELECT id, user_id, lesson_id, object_name, minio_upload_id, content_type, total_size, chunk_size, status
+		FROM video_uploads
+		WHERE status = 'uploading' AND updated_at < NOW() - $1 * INTERVAL '1 second'
+		ORDER BY updated_at
+		LIMIT $2`, staleAfter.Seconds(), limit)
+	if err != nil {
+		logs.PrintLog(ctx, "GetStaleVideoUploads", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logs.PrintLog(ctx, "GetStaleVideoUploads", fmt.Sprintf("%+v", err))
+		}
+	}()
+
+	var uploads []*coursemodels.VideoUpload
+	for rows.Next() {
+		var upload coursemodels.VideoUpload
+		if err := rows.Scan(&upload.Id, &upload.UserId, &upload.LessonId, &upload.ObjectName, &upload.MinioUploadId, &upload.ContentType, &upload.TotalSize, &upload.ChunkSize, &upload.Status); err != nil {
+			logs.PrintLog(ctx, "GetStaleVideoUploads", fmt.Sprintf("%+v", err))
+			return nil, err
+		}
+		uploads = append(uploads, &upload)
+	}
+	return uploads, rows.Err()
+}
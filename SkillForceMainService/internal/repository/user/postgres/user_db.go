@@ -6,20 +6,23 @@ import (
 	"html"
 	usermodels "skillForce/internal/models/user"
 	"skillForce/pkg/logs"
+
+	"github.com/lib/pq"
 )
 
 func (d *Database) GetUserByCookie(ctx context.Context, cookieValue string) (*usermodels.UserProfile, error) {
 	var userProfile usermodels.UserProfile
-	var role string
-	err := d.conn.QueryRow("SELECT u.id, u.email, u.name, COALESCE(u.bio, ''), u.avatar_src, u.hide_email, u.role FROM usertable u JOIN sessions s ON u.id = s.user_id WHERE s.token = $1 AND s.expire > NOW();",
-		cookieValue).Scan(&userProfile.Id, &userProfile.Email, &userProfile.Name, &userProfile.Bio, &userProfile.AvatarSrc, &userProfile.HideEmail, &role)
+	err := d.conn.QueryRow("SELECT u.id, u.email, u.name, COALESCE(u.bio, ''), u.avatar_src, u.hide_email, u.locale, u.timezone, ARRAY(SELECT r.role FROM user_roles r WHERE r.user_id = u.id ORDER BY r.role) FROM usertable u JOIN sessions s ON u.id = s.user_id WHERE s.token = $1 AND s.expire > NOW();",
+		cookieValue).Scan(&userProfile.Id, &userProfile.Email, &userProfile.Name, &userProfile.Bio, &userProfile.AvatarSrc, &userProfile.HideEmail, &userProfile.Locale, &userProfile.Timezone, pq.Array(&userProfile.Roles))
 	if err != nil {
 		logs.PrintLog(ctx, "GetUserByCookie", fmt.Sprintf("error in GetUserByCookie %+v", err))
 		return nil, err
 	}
-	logs.PrintLog(ctx, "GetUserByCookie", fmt.Sprintf("role: %+v", role))
-	if role == "admin" {
-		userProfile.IsAdmin = true
+	logs.PrintLog(ctx, "GetUserByCookie", fmt.Sprintf("roles: %+v", userProfile.Roles))
+	for _, role := range userProfile.Roles {
+		if role == "admin" {
+			userProfile.IsAdmin = true
+		}
 	}
 	userProfile.Email = html.EscapeString(userProfile.Email)
 	userProfile.Name = html.EscapeString(userProfile.Name)
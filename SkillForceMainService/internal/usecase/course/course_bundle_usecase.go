@@ -0,0 +1,480 @@
+package usecase
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	coursemodels "skillForce/internal/models/course"
+	"skillForce/internal/models/dto"
+	"skillForce/pkg/logs"
+	"skillForce/pkg/offline"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mailru/easyjson"
+)
+
+const (
+	BundleFormatZip  = "zip"
+	BundleFormatEPUB = "epub"
+
+	BundlePending    = "pending"
+	BundleProcessing = "processing"
+	BundleReady      = "ready"
+	BundleFailed     = "failed"
+
+	// BundleStaleAfter - через сколько сборка упавшего воркера снова берётся в работу
+	BundleStaleAfter = time.Hour
+	// BundleTimeout - ограничение на сборку одной выгрузки, с видео она может быть долгой
+	BundleTimeout     = 50 * time.Minute
+	BundleMaxAttempts = 3
+	// maxPlaylistSize - плейлисты читаются целиком, поэтому их размер ограничен
+	maxPlaylistSize = 1 << 20
+)
+
+var bundleContentTypes = map[string]string{
+	BundleFormatZip:  "application/zip",
+	BundleFormatEPUB: "application/epub+zip",
+}
+
+// courseBundleObjectPattern - выгрузки лежат в бакете как <курс>/<версия>[-video].<формат>
+var courseBundleObjectPattern = regexp.MustCompile(`^[0-9]+/[0-9a-f]{32}(-video)?\.(zip|epub)$`)
+
+// RequestCourseBundle - выгрузка курса в нужном формате. Если собранной выгрузки текущей версии нет,
+// сборка ставится в очередь, и клиент опрашивает её статус, пока она не станет ready
+func (uc *CourseUsecase) RequestCourseBundle(ctx context.Context, content *dto.CourseBundleDTO, format string, withVideos bool) (*coursemodels.CourseBundle, error) {
+	if _, ok := bundleContentTypes[format]; !ok {
+		return nil, errors.New("invalid bundle format")
+	}
+
+	version := content.Version
+	if withVideos {
+		var err error
+		if version, err = uc.videoBundleVersion(ctx, content); err != nil {
+			logs.PrintLog(ctx, "RequestCourseBundle", fmt.Sprintf("%+v", err))
+			return nil, err
+		}
+	}
+
+	current, err := uc.repo.GetCourseBundle(ctx, content.CourseId, format, withVideos)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil && current.Version == version && current.Status != BundleFailed {
+		return current, nil
+	}
+
+	data, err := easyjson.Marshal(content)
+	if err != nil {
+		logs.PrintLog(ctx, "RequestCourseBundle", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+	return uc.repo.RequestCourseBundle(ctx, &coursemodels.CourseBundle{
+		CourseId:   content.CourseId,
+		Format:     format,
+		WithVideos: withVideos,
+		Version:    version,
+		Content:    data,
+	})
+}
+
+// videoBundleVersion - версия выгрузки с видео учитывает и перекодированные версии видео уроков,
+// так как новое видео загружается без изменения содержимого курса
+func (uc *CourseUsecase) videoBundleVersion(ctx context.Context, content *dto.CourseBundleDTO) (string, error) {
+	hash := sha256.New()
+	hash.Write([]byte(content.Version))
+	for _, lesson := range content.Lessons {
+		if lesson.Type != "video" {
+			continue
+		}
+		prefix, err := uc.hlsPrefix(ctx, lesson.LessonId)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(hash, "\n%d:%s", lesson.LessonId, prefix)
+	}
+	return hex.EncodeToString(hash.Sum(nil)[:16]), nil
+}
+
+// hlsPrefix - папка HLS версии видео урока или пустая строка, если видео ещё нет
+func (uc *CourseUsecase) hlsPrefix(ctx context.Context, lessonId int) (string, error) {
+	prefix, err := uc.repo.GetHLSPrefix(ctx, lessonId)
+	if err != nil {
+		if err.Error() == "video not found" || err.Error() == "video is not transcoded yet" {
+			return "", nil
+		}
+		return "", err
+	}
+	return prefix, nil
+}
+
+// GetCourseBundleFile - собранная выгрузка, ссылку на которую выдали купившему курс
+func (uc *CourseUsecase) GetCourseBundleFile(ctx context.Context, objectName string) (io.ReadCloser, dto.VideoMeta, error) {
+	if !courseBundleObjectPattern.MatchString(objectName) {
+		return nil, dto.VideoMeta{}, errors.New("bundle not found")
+	}
+
+	reader, meta, err := uc.repo.GetCourseBundleObject(ctx, objectName)
+	if err != nil {
+		logs.PrintLog(ctx, "GetCourseBundleFile", fmt.Sprintf("%+v", err))
+		return nil, dto.VideoMeta{}, errors.New("bundle not found")
+	}
+	return reader, meta, nil
+}
+
+// BundleFileName - имя файла выгрузки для скачивания
+func BundleFileName(title string, format string) string {
+	return lessonFileName(title) + "." + format
+}
+
+// BuildNextCourseBundle - сборка одной выгрузки из очереди. Возвращает false, если очередь пуста
+func (uc *CourseUsecase) BuildNextCourseBundle(ctx context.Context) (bool, error) {
+	job, err := uc.repo.ClaimCourseBundleJob(ctx, BundleStaleAfter)
+	if err != nil || job == nil {
+		return false, err
+	}
+
+	if err := uc.buildCourseBundle(ctx, job); err != nil {
+		logs.PrintLog(ctx, "BuildNextCourseBundle", fmt.Sprintf("course %d: %+v", job.CourseId, err))
+		if failErr := uc.repo.FailCourseBundleJob(ctx, job, err.Error(), BundleMaxAttempts); failErr != nil {
+			return true, failErr
+		}
+		return true, err
+	}
+	return true, nil
+}
+
+func (uc *CourseUsecase) buildCourseBundle(ctx context.Context, job *coursemodels.CourseBundle) error {
+	ctx, cancel := context.WithTimeout(ctx, BundleTimeout)
+	defer cancel()
+
+	var content dto.CourseBundleDTO
+	if err := easyjson.Unmarshal(job.Content, &content); err != nil {
+		return err
+	}
+
+	workDir, err := os.MkdirTemp("", "bundle-")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := os.RemoveAll(workDir); err != nil {
+			logs.PrintLog(ctx, "BuildNextCourseBundle", fmt.Sprintf("%+v", err))
+		}
+	}()
+
+	book := &offline.Book{
+		Id:          fmt.Sprintf("urn:skillforce:course:%d:%s", job.CourseId, job.Version),
+		Title:       content.Title,
+		Description: content.Description,
+		Language:    "ru",
+	}
+	files := make(map[string]bool)
+	for _, lesson := range content.Lessons {
+		var body string
+		switch lesson.Type {
+		case "text":
+			body = uc.bundleTextLesson(ctx, book, files, lesson.Blocks)
+		case "video":
+			body, err = uc.bundleVideoLesson(ctx, book, workDir, lesson.LessonId, job.WithVideos)
+			if err != nil {
+				return fmt.Errorf("lesson %d: %w", lesson.LessonId, err)
+			}
+		default:
+			body = "<p>Задание можно выполнить в курсе онлайн</p>"
+		}
+		book.Pages = append(book.Pages, offline.Page{Section: bundleSection(lesson), Title: lesson.Title, Body: body})
+	}
+
+	suffix := ""
+	if job.WithVideos {
+		suffix = "-video"
+	}
+	objectName := fmt.Sprintf("%d/%s%s.%s", job.CourseId, job.Version, suffix, job.Format)
+	filePath := filepath.Join(workDir, "bundle."+job.Format)
+	if err := writeBundle(filePath, book, job.Format); err != nil {
+		return err
+	}
+	if err := uc.repo.PutCourseBundle(ctx, objectName, filePath, bundleContentTypes[job.Format]); err != nil {
+		return err
+	}
+
+	if err := uc.repo.FinishCourseBundleJob(ctx, job, objectName); err != nil {
+		// курс изменился во время сборки, собранная версия никому не нужна
+		if job.ObjectName != objectName {
+			uc.removeCourseBundle(ctx, objectName)
+		}
+		return err
+	}
+	if job.ObjectName != "" && job.ObjectName != objectName {
+		uc.removeCourseBundle(ctx, job.ObjectName)
+	}
+	return nil
+}
+
+func (uc *CourseUsecase) removeCourseBundle(ctx context.Context, objectName string) {
+	if err := uc.repo.RemoveCourseBundle(ctx, objectName); err != nil {
+		logs.PrintLog(ctx, "BuildNextCourseBundle", fmt.Sprintf("%s: %+v", objectName, err))
+	}
+}
+
+func writeBundle(filePath string, book *offline.Book, format string) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	writer := bufio.NewWriter(file)
+	if format == BundleFormatEPUB {
+		err = offline.WriteEPUB(writer, book, time.Now())
+	} else {
+		err = offline.WriteZip(writer, book)
+	}
+	if err == nil {
+		err = writer.Flush()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func bundleSection(lesson dto.BundleLessonDTO) string {
+	if lesson.PartTitle == "" || lesson.BucketTitle == "" {
+		return lesson.PartTitle + lesson.BucketTitle
+	}
+	return lesson.PartTitle + " — " + lesson.BucketTitle
+}
+
+// bundleTextLesson - HTML текстового урока. Картинки и вложения добавляются в выгрузку один раз,
+// даже если используются в нескольких уроках
+func (uc *CourseUsecase) bundleTextLesson(ctx context.Context, book *offline.Book, files map[string]bool, blocks []dto.LessonBlockDTO) string {
+	var b strings.Builder
+	for _, block := range blocks {
+		resource := ""
+		if (block.Type == "image" || block.Type == "file") && lessonFileObjectPattern.MatchString(block.Object) {
+			resource = "files/" + strings.ReplaceAll(block.Object, "/", "-")
+			if !files[resource] {
+				files[resource] = true
+				object := block.Object
+				contentType := block.ContentType
+				if block.Type == "file" || contentType == "" {
+					contentType = "application/octet-stream"
+				}
+				book.Resources = append(book.Resources, offline.Resource{
+					Name:        resource,
+					ContentType: contentType,
+					Open: func() (io.ReadCloser, error) {
+						reader, _, err := uc.repo.GetLessonFileObject(ctx, object)
+						return reader, err
+					},
+				})
+			}
+		}
+		b.WriteString(bundleBlockHTML(block, resource))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// bundleBlockHTML - блок урока без сети: файлы берутся из выгрузки, внешние видео остаются ссылками,
+// а ответы на вопросы проверяются только онлайн
+func bundleBlockHTML(block dto.LessonBlockDTO, resource string) string {
+	e := html.EscapeString
+	switch block.Type {
+	case "image":
+		if resource == "" {
+			return ""
+		}
+		caption := ""
+		if block.Caption != "" {
+			caption = "<figcaption>" + e(block.Caption) + "</figcaption>"
+		}
+		return fmt.Sprintf(`<figure><img src="%s" alt="%s">%s</figure>`, e(resource), e(block.Alt), caption)
+	case "file":
+		if resource == "" {
+			return ""
+		}
+		return fmt.Sprintf(`<p><a href="%s" download="%s">%s</a> (%s)</p>`, e(resource), e(block.FileName), e(block.FileName), fileSize(block.Size))
+	case "callout":
+		return fmt.Sprintf(`<div class="callout callout-%s"><p>%s</p></div>`, e(block.Variant), e(block.Text))
+	case "video":
+		title := block.Caption
+		if title == "" {
+			title = "Видео"
+		}
+		return fmt.Sprintf(`<p><a href="%s">%s</a></p>`, e(block.EmbedUrl), e(title))
+	case "quiz":
+		var b strings.Builder
+		fmt.Fprintf(&b, `<div class="quiz"><p><strong>%s</strong></p><ol>`, e(block.Question))
+		for _, option := range block.Options {
+			fmt.Fprintf(&b, "<li>%s</li>", e(option))
+		}
+		b.WriteString("</ol><p><em>Ответ можно проверить в курсе онлайн</em></p></div>")
+		return b.String()
+	}
+	// html, markdown и code уже очищены course-service
+	return block.Body
+}
+
+func fileSize(size int64) string {
+	switch {
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f МБ", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1f КБ", float64(size)/(1<<10))
+	}
+	return strconv.FormatInt(size, 10) + " Б"
+}
+
+// bundleVideoLesson - видео урока в самом низком качестве HLS, склеенное в mp4. Если видео не нужно
+// или ещё не перекодировано, в уроке остаётся пометка
+func (uc *CourseUsecase) bundleVideoLesson(ctx context.Context, book *offline.Book, workDir string, lessonId int, withVideos bool) (string, error) {
+	const online = "<p>Видео доступно в курсе онлайн</p>"
+	if !withVideos {
+		return online, nil
+	}
+	prefix, err := uc.hlsPrefix(ctx, lessonId)
+	if err != nil || prefix == "" {
+		return online, err
+	}
+
+	segments, err := uc.lowestRenditionSegments(ctx, prefix)
+	if err != nil {
+		return "", err
+	}
+
+	stream := filepath.Join(workDir, fmt.Sprintf("lesson-%d.ts", lessonId))
+	if err := uc.concatVideoSegments(ctx, prefix, segments, stream); err != nil {
+		return "", err
+	}
+	video := filepath.Join(workDir, fmt.Sprintf("lesson-%d.mp4", lessonId))
+	if err := uc.repo.RemuxVideo(ctx, stream, video); err != nil {
+		return "", err
+	}
+	if err := os.Remove(stream); err != nil {
+		logs.PrintLog(ctx, "BuildNextCourseBundle", fmt.Sprintf("%+v", err))
+	}
+
+	resource := fmt.Sprintf("videos/lesson-%d.mp4", lessonId)
+	book.Resources = append(book.Resources, offline.Resource{
+		Name:        resource,
+		ContentType: "video/mp4",
+		Open: func() (io.ReadCloser, error) {
+			return os.Open(video)
+		},
+	})
+	return fmt.Sprintf(`<video controls preload="metadata" src="%s"></video>`, resource), nil
+}
+
+// lowestRenditionSegments - сегменты первого качества из master плейлиста. Качества в нём
+// записываются по возрастанию, поэтому первое - самое лёгкое
+func (uc *CourseUsecase) lowestRenditionSegments(ctx context.Context, prefix string) ([]string, error) {
+	master, err := uc.readPlaylist(ctx, prefix+"/master.m3u8")
+	if err != nil {
+		return nil, err
+	}
+	uris := playlistURIs(master)
+	if len(uris) == 0 || !hlsFilePattern.MatchString(uris[0]) {
+		return nil, errors.New("invalid master playlist")
+	}
+
+	media, err := uc.readPlaylist(ctx, prefix+"/"+uris[0])
+	if err != nil {
+		return nil, err
+	}
+	dir := path.Dir(uris[0])
+	segments := make([]string, 0)
+	for _, uri := range playlistURIs(media) {
+		segment := dir + "/" + uri
+		if !hlsFilePattern.MatchString(segment) {
+			return nil, errors.New("invalid media playlist")
+		}
+		segments = append(segments, segment)
+	}
+	if len(segments) == 0 {
+		return nil, errors.New("empty media playlist")
+	}
+	return segments, nil
+}
+
+func (uc *CourseUsecase) readPlaylist(ctx context.Context, objectName string) (string, error) {
+	reader, _, err := uc.repo.GetVideoObject(ctx, objectName)
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(io.LimitReader(reader, maxPlaylistSize+1))
+	if err != nil {
+		return "", err
+	}
+	if len(data) > maxPlaylistSize {
+		return "", errors.New("playlist is too large")
+	}
+	return string(data), nil
+}
+
+func playlistURIs(playlist string) []string {
+	var uris []string
+	for _, line := range strings.Split(playlist, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			uris = append(uris, line)
+		}
+	}
+	return uris
+}
+
+// concatVideoSegments - сегменты MPEG-TS можно склеить побайтно в один поток
+func (uc *CourseUsecase) concatVideoSegments(ctx context.Context, prefix string, segments []string, filePath string) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	for _, segment := range segments {
+		reader, _, err := uc.repo.GetVideoObject(ctx, prefix+"/"+segment)
+		if err == nil {
+			_, err = io.Copy(file, reader)
+			reader.Close()
+		}
+		if err != nil {
+			file.Close()
+			return fmt.Errorf("segment %s: %w", segment, err)
+		}
+	}
+	return file.Close()
+}
+
+// RunBundleBuilder - обработка очереди сборки выгрузок до отмены контекста
+func (uc *CourseUsecase) RunBundleBuilder(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			found := false
+			logs.RunJob(ctx, "BuildCourseBundle", func(ctx context.Context) {
+				found, _ = uc.BuildNextCourseBundle(ctx)
+			})
+			if !found || ctx.Err() != nil {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -3,11 +3,57 @@ package usecase
 import (
 	"context"
 	"io"
+	coursemodels "skillForce/internal/models/course"
 	"skillForce/internal/models/dto"
+	"skillForce/pkg/storagegc"
+	"time"
 )
 
 type CourseRepository interface {
 	GetVideoUrl(ctx context.Context, lessonId int) (string, error)
 	GetVideoRange(ctx context.Context, name string, start, end int64) (io.ReadCloser, error)
 	Stat(ctx context.Context, name string) (dto.VideoMeta, error)
+
+	GetVideoLessonAuthor(ctx context.Context, lessonId int) (int, error)
+	CreateVideoUpload(ctx context.Context, upload *coursemodels.VideoUpload) error
+	GetVideoUpload(ctx context.Context, uploadId string) (*coursemodels.VideoUpload, error)
+	SaveVideoUploadPart(ctx context.Context, uploadId string, part *coursemodels.VideoUploadPart) error
+	FinishVideoUpload(ctx context.Context, upload *coursemodels.VideoUpload, status string, videoSrc string) error
+	GetStaleVideoUploads(ctx context.Context, staleAfter time.Duration, limit int) ([]*coursemodels.VideoUpload, error)
+
+	NewVideoUpload(ctx context.Context, objectName string, contentType string) (string, error)
+	PutVideoPart(ctx context.Context, upload *coursemodels.VideoUpload, partNumber int, data io.Reader, size int64) (string, error)
+	CompleteVideoUpload(ctx context.Context, upload *coursemodels.VideoUpload) error
+	AbortVideoUpload(ctx context.Context, upload *coursemodels.VideoUpload) error
+	VideoUrl(objectName string) string
+
+	ClaimTranscodeJob(ctx context.Context, staleAfter time.Duration) (*coursemodels.TranscodeJob, error)
+	FinishTranscodeJob(ctx context.Context, job *coursemodels.TranscodeJob, hlsPrefix string) error
+	FailTranscodeJob(ctx context.Context, job *coursemodels.TranscodeJob, reason string, maxAttempts int) error
+	GetHLSPrefix(ctx context.Context, lessonId int) (string, error)
+	DownloadVideo(ctx context.Context, objectName string, filePath string) error
+	UploadVideoFile(ctx context.Context, objectName string, filePath string, contentType string) error
+	GetVideoObject(ctx context.Context, objectName string) (io.ReadCloser, dto.VideoMeta, error)
+	GetSertificateObject(ctx context.Context, objectName string) (io.ReadCloser, dto.VideoMeta, error)
+	TranscodeVideo(ctx context.Context, src string, outDir string) error
+
+	PutLessonFile(ctx context.Context, objectName string, data []byte, contentType string) error
+	GetLessonFileObject(ctx context.Context, objectName string) (io.ReadCloser, dto.VideoMeta, error)
+	CreateLessonFile(ctx context.Context, file *coursemodels.LessonFile) error
+
+	GetCourseBundle(ctx context.Context, courseId int, format string, withVideos bool) (*coursemodels.CourseBundle, error)
+	RequestCourseBundle(ctx context.Context, bundle *coursemodels.CourseBundle) (*coursemodels.CourseBundle, error)
+	ClaimCourseBundleJob(ctx context.Context, staleAfter time.Duration) (*coursemodels.CourseBundle, error)
+	FinishCourseBundleJob(ctx context.Context, job *coursemodels.CourseBundle, objectName string) error
+	FailCourseBundleJob(ctx context.Context, job *coursemodels.CourseBundle, reason string, maxAttempts int) error
+	PutCourseBundle(ctx context.Context, objectName string, filePath string, contentType string) error
+	GetCourseBundleObject(ctx context.Context, objectName string) (io.ReadCloser, dto.VideoMeta, error)
+	RemoveCourseBundle(ctx context.Context, objectName string) error
+	RemuxVideo(ctx context.Context, src string, dst string) error
+
+	StorageBucket(kind string) (string, error)
+	ListStorageObjects(ctx context.Context, kind string) ([]storagegc.Object, error)
+	RemoveStorageObject(ctx context.Context, kind string, objectName string) error
+	GetStorageReferences(ctx context.Context, kind string) (*storagegc.References, error)
+	DeleteLessonFile(ctx context.Context, objectName string) error
 }
@@ -2,8 +2,12 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"skillForce/internal/models/dto"
+	"skillForce/pkg/logs"
+	"strings"
 )
 
 type CourseUsecase struct {
@@ -27,3 +31,17 @@ func (uc *CourseUsecase) GetMeta(ctx context.Context, name string) (dto.VideoMet
 func (uc *CourseUsecase) GetFragment(ctx context.Context, name string, start, end int64) (io.ReadCloser, error) {
 	return uc.repo.GetVideoRange(ctx, name, start, end)
 }
+
+// GetSertificateFile - pdf сертификата, ссылку на который выдали владельцу
+func (uc *CourseUsecase) GetSertificateFile(ctx context.Context, name string) (io.ReadCloser, dto.VideoMeta, error) {
+	if name == "" || strings.ContainsAny(name, "/\\") {
+		return nil, dto.VideoMeta{}, errors.New("sertificate not found")
+	}
+
+	reader, meta, err := uc.repo.GetSertificateObject(ctx, name)
+	if err != nil {
+		logs.PrintLog(ctx, "GetSertificateFile", fmt.Sprintf("%+v", err))
+		return nil, dto.VideoMeta{}, errors.New("sertificate not found")
+	}
+	return reader, meta, nil
+}
@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"regexp"
+	coursemodels "skillForce/internal/models/course"
+	"skillForce/internal/models/dto"
+	"skillForce/pkg/auth"
+	"skillForce/pkg/logs"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MaxLessonImageSize int64 = 10 << 20
+	MaxLessonFileSize  int64 = 50 << 20
+
+	LessonFileImage = "image"
+	LessonFileFile  = "file"
+)
+
+// lessonImageExtensions - картинки, которые браузер покажет в уроке. Тип определяется по содержимому, а не по заголовку запроса
+var lessonImageExtensions = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+var (
+	lessonFileExtPattern    = regexp.MustCompile(`^\.[a-z0-9]{1,10}$`)
+	lessonFileObjectPattern = regexp.MustCompile(`^[0-9]+/[0-9a-f-]{36}(\.[a-z0-9]{1,10})?$`)
+)
+
+// UploadLessonFile - загрузка картинки или вложения для блока текстового урока.
+// Возвращает имя объекта, которое автор указывает в блоке при создании курса
+func (uc *CourseUsecase) UploadLessonFile(ctx context.Context, identity *auth.Identity, kind string, fileName string, data []byte) (*dto.LessonFileDTO, error) {
+	file := &coursemodels.LessonFile{
+		AuthorId: identity.UserId,
+		Kind:     kind,
+		FileName: lessonFileName(fileName),
+		Size:     int64(len(data)),
+	}
+	if file.Size == 0 {
+		return nil, errors.New("empty file")
+	}
+
+	var ext string
+	switch kind {
+	case LessonFileImage:
+		if file.Size > MaxLessonImageSize {
+			return nil, errors.New("file is too large")
+		}
+		file.ContentType = http.DetectContentType(data)
+		var ok bool
+		if ext, ok = lessonImageExtensions[file.ContentType]; !ok {
+			return nil, errors.New("unsupported image format")
+		}
+	case LessonFileFile:
+		if file.Size > MaxLessonFileSize {
+			return nil, errors.New("file is too large")
+		}
+		// вложения всегда отдаются на скачивание, поэтому тип не влияет на то, как их откроет браузер
+		file.ContentType = "application/octet-stream"
+		ext = strings.ToLower(path.Ext(file.FileName))
+		if !lessonFileExtPattern.MatchString(ext) {
+			ext = ""
+		}
+	default:
+		return nil, errors.New("invalid file kind")
+	}
+
+	file.ObjectName = strconv.Itoa(identity.UserId) + "/" + uuid.New().String() + ext
+	if err := uc.repo.PutLessonFile(ctx, file.ObjectName, data, file.ContentType); err != nil {
+		logs.PrintLog(ctx, "UploadLessonFile", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+	if err := uc.repo.CreateLessonFile(ctx, file); err != nil {
+		return nil, err
+	}
+
+	return &dto.LessonFileDTO{
+		Object:      file.ObjectName,
+		Kind:        file.Kind,
+		FileName:    file.FileName,
+		ContentType: file.ContentType,
+		Size:        file.Size,
+	}, nil
+}
+
+// GetLessonFile - файл блока урока, ссылку на который выдали вместе с уроком
+func (uc *CourseUsecase) GetLessonFile(ctx context.Context, objectName string) (io.ReadCloser, dto.VideoMeta, error) {
+	if !lessonFileObjectPattern.MatchString(objectName) {
+		return nil, dto.VideoMeta{}, errors.New("file not found")
+	}
+
+	reader, meta, err := uc.repo.GetLessonFileObject(ctx, objectName)
+	if err != nil {
+		logs.PrintLog(ctx, "GetLessonFile", fmt.Sprintf("%+v", err))
+		return nil, dto.VideoMeta{}, errors.New("file not found")
+	}
+	return reader, meta, nil
+}
+
+// lessonFileName - имя для заголовка Content-Disposition: без пути и управляющих символов
+func lessonFileName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+	if name == "" || name == "." || name == "/" {
+		return "file"
+	}
+	if len(name) > 255 {
+		name = strings.ToValidUTF8(name[:255], "")
+	}
+	return name
+}
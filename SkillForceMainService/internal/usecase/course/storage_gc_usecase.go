@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"skillForce/pkg/logs"
+	"skillForce/pkg/storagegc"
+	"time"
+)
+
+// StorageGCMinGrace - объекты моложе часа не удаляются при любых настройках: ссылка на только что
+// загруженный файл может ещё не дойти до базы
+const StorageGCMinGrace = time.Hour
+
+// storageKeep - объекты, на которые не ссылается база, но которые нужны всегда
+var storageKeep = map[string][]string{
+	storagegc.Avatars: {"default_avatar.png"},
+}
+
+// storageMinAge - бакеты, объекты которых хранятся дольше grace. Архив выгрузки данных нужен,
+// пока действует ссылка на него (DataExportLinkTTL в user-service)
+var storageMinAge = map[string]time.Duration{
+	storagegc.Exports: 7 * 24 * time.Hour,
+}
+
+// CollectStorageGarbage - проверка всех бакетов. Объекты без ссылок из базы старше grace удаляются,
+// в режиме dryRun только попадают в отчёт. Если ссылки на объекты бакета не удалось прочитать,
+// бакет пропускается, чтобы не удалить используемые файлы
+func (uc *CourseUsecase) CollectStorageGarbage(ctx context.Context, grace time.Duration, dryRun bool) ([]storagegc.Report, error) {
+	grace = max(grace, StorageGCMinGrace)
+
+	reports := make([]storagegc.Report, 0, len(storagegc.Kinds))
+	var errs []error
+	for _, kind := range storagegc.Kinds {
+		report, err := uc.collectBucketGarbage(ctx, kind, grace, dryRun)
+		if err != nil {
+			logs.PrintLog(ctx, "CollectStorageGarbage", fmt.Sprintf("%s: %+v", kind, err))
+			errs = append(errs, fmt.Errorf("%s: %w", kind, err))
+		}
+		if report == nil {
+			continue
+		}
+
+		storagegc.Observe(*report)
+		reports = append(reports, *report)
+		logs.PrintLog(ctx, "CollectStorageGarbage", fmt.Sprintf("%s (dry run %t): %d objects, %d bytes; %d orphans, %d bytes; deleted %d, reclaimed %d bytes",
+			report.Bucket, report.DryRun, report.Objects, report.Bytes, report.Orphans, report.OrphanBytes, report.Deleted, report.ReclaimedBytes))
+	}
+	storagegc.LastRunTimestamp.SetToCurrentTime()
+	return reports, errors.Join(errs...)
+}
+
+func (uc *CourseUsecase) collectBucketGarbage(ctx context.Context, kind string, grace time.Duration, dryRun bool) (*storagegc.Report, error) {
+	bucket, err := uc.repo.StorageBucket(kind)
+	if err != nil {
+		return nil, err
+	}
+	// объекты читаются раньше ссылок: файл, загруженный между двумя запросами, моложе grace
+	objects, err := uc.repo.ListStorageObjects(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+	refs, err := uc.repo.GetStorageReferences(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+	refs.Bucket = bucket
+	refs.Objects = append(refs.Objects, storageKeep[kind]...)
+	refs.KeepFolders = kind == storagegc.Avatars
+
+	report := &storagegc.Report{Bucket: bucket, DryRun: dryRun, Objects: len(objects)}
+	for _, object := range objects {
+		report.Bytes += object.Size
+	}
+
+	orphans := storagegc.Orphans(objects, refs, max(grace, storageMinAge[kind]), time.Now())
+	report.Orphans = len(orphans)
+	for _, object := range orphans {
+		report.OrphanBytes += object.Size
+	}
+
+	for _, object := range orphans {
+		if dryRun {
+			logs.PrintLog(ctx, "CollectStorageGarbage", fmt.Sprintf("dry run: %s/%s, %d bytes, modified %s",
+				bucket, object.Name, object.Size, object.LastModified.Format(time.RFC3339)))
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		if err := uc.repo.RemoveStorageObject(ctx, kind, object.Name); err != nil {
+			logs.PrintLog(ctx, "CollectStorageGarbage", fmt.Sprintf("%s/%s: %+v", bucket, object.Name, err))
+			continue
+		}
+		if kind == storagegc.LessonFiles {
+			if err := uc.repo.DeleteLessonFile(ctx, object.Name); err != nil {
+				return report, err
+			}
+		}
+		report.Deleted++
+		report.ReclaimedBytes += object.Size
+	}
+	return report, nil
+}
+
+// RunStorageGC - проверка бакетов раз в interval до отмены контекста. Несколько экземпляров сервиса
+// могут проверять бакеты одновременно: удаление уже удалённого объекта не считается ошибкой
+func (uc *CourseUsecase) RunStorageGC(ctx context.Context, interval time.Duration, grace time.Duration, dryRun bool) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		logs.RunJob(ctx, "CollectStorageGarbage", func(ctx context.Context) {
+			_, _ = uc.CollectStorageGarbage(ctx, grace, dryRun)
+		})
+	}
+}
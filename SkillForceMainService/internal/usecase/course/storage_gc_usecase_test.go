@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"skillForce/pkg/logs"
+	"skillForce/pkg/storagegc"
+)
+
+// gcRepository - бакеты в памяти, остальные методы репозитория в тесте не вызываются
+type gcRepository struct {
+	CourseRepository
+	objects map[string][]storagegc.Object
+	removed map[string][]string
+}
+
+func (r *gcRepository) StorageBucket(kind string) (string, error) {
+	return kind, nil
+}
+
+func (r *gcRepository) ListStorageObjects(ctx context.Context, kind string) ([]storagegc.Object, error) {
+	return r.objects[kind], nil
+}
+
+func (r *gcRepository) GetStorageReferences(ctx context.Context, kind string) (*storagegc.References, error) {
+	return &storagegc.References{}, nil
+}
+
+func (r *gcRepository) RemoveStorageObject(ctx context.Context, kind string, objectName string) error {
+	r.removed[kind] = append(r.removed[kind], objectName)
+	return nil
+}
+
+func (r *gcRepository) DeleteLessonFile(ctx context.Context, objectName string) error {
+	return nil
+}
+
+func TestCollectStorageGarbage_Exports(t *testing.T) {
+	now := time.Now()
+	repo := &gcRepository{
+		objects: map[string][]storagegc.Object{
+			storagegc.Exports: {
+				{Name: "1/fresh.zip", LastModified: now.Add(-2 * 24 * time.Hour)},
+				{Name: "1/expired.zip", LastModified: now.Add(-8 * 24 * time.Hour)},
+			},
+			storagegc.Bundles: {
+				{Name: "old.zip", LastModified: now.Add(-2 * 24 * time.Hour)},
+			},
+		},
+		removed: map[string][]string{},
+	}
+	uc := NewCourseUsecase(repo)
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	// архив удаляется только после истечения ссылки на него, остальные бакеты - после grace
+	if _, err := uc.CollectStorageGarbage(ctx, 24*time.Hour, false); err != nil {
+		t.Fatalf("CollectStorageGarbage() error = %v", err)
+	}
+	for kind, want := range map[string][]string{
+		storagegc.Exports: {"1/expired.zip"},
+		storagegc.Bundles: {"old.zip"},
+	} {
+		got := repo.removed[kind]
+		sort.Strings(got)
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("%s: removed %v, want %v", kind, got, want)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	coursemodels "skillForce/internal/models/course"
+	"skillForce/internal/models/dto"
+	"skillForce/pkg/logs"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// TranscodeStaleAfter - через сколько задача упавшего воркера снова берётся в работу
+	TranscodeStaleAfter = time.Hour
+	// TranscodeTimeout - ограничение на перекодирование одного видео
+	TranscodeTimeout       = 50 * time.Minute
+	TranscodeMaxAttempts   = 3
+	hlsPlaylistContentType = "application/vnd.apple.mpegurl"
+	hlsSegmentContentType  = "video/mp2t"
+)
+
+// hlsFilePattern - файлы, которые можно запросить у HLS версии видео
+var hlsFilePattern = regexp.MustCompile(`^(master\.m3u8|\d+p/(index\.m3u8|seg_\d+\.ts))$`)
+
+// TranscodeNextVideo - перекодирование одного видео из очереди в HLS.
+// Возвращает false, если очередь пуста
+func (uc *CourseUsecase) TranscodeNextVideo(ctx context.Context) (bool, error) {
+	job, err := uc.repo.ClaimTranscodeJob(ctx, TranscodeStaleAfter)
+	if err != nil || job == nil {
+		return false, err
+	}
+
+	if err := uc.transcode(ctx, job); err != nil {
+		logs.PrintLog(ctx, "TranscodeNextVideo", fmt.Sprintf("lesson %d: %+v", job.LessonId, err))
+		if failErr := uc.repo.FailTranscodeJob(ctx, job, err.Error(), TranscodeMaxAttempts); failErr != nil {
+			return true, failErr
+		}
+		return true, err
+	}
+	return true, nil
+}
+
+func (uc *CourseUsecase) transcode(ctx context.Context, job *coursemodels.TranscodeJob) error {
+	ctx, cancel := context.WithTimeout(ctx, TranscodeTimeout)
+	defer cancel()
+
+	workDir, err := os.MkdirTemp("", "transcode-")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := os.RemoveAll(workDir); err != nil {
+			logs.PrintLog(ctx, "TranscodeNextVideo", fmt.Sprintf("%+v", err))
+		}
+	}()
+
+	src := filepath.Join(workDir, "source"+path.Ext(job.SourceObject))
+	if err := uc.repo.DownloadVideo(ctx, job.SourceObject, src); err != nil {
+		return err
+	}
+
+	outDir := filepath.Join(workDir, "hls")
+	if err := uc.repo.TranscodeVideo(ctx, src, outDir); err != nil {
+		return err
+	}
+
+	// каждое перекодирование пишется в новую папку, чтобы не смешивать сегменты со старой версией,
+	// которую в это время продолжают смотреть
+	prefix := fmt.Sprintf("hls/%d/%s", job.LessonId, uuid.New().String())
+	err = filepath.WalkDir(outDir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		name, err := filepath.Rel(outDir, filePath)
+		if err != nil {
+			return err
+		}
+		return uc.repo.UploadVideoFile(ctx, prefix+"/"+filepath.ToSlash(name), filePath, hlsContentType(name))
+	})
+	if err != nil {
+		return err
+	}
+
+	return uc.repo.FinishTranscodeJob(ctx, job, prefix)
+}
+
+// RunTranscoder - обработка очереди перекодирования до отмены контекста. Очередь хранится в базе,
+// поэтому задачи переживают перезапуск, а несколько экземпляров сервиса не берут одно видео дважды
+func (uc *CourseUsecase) RunTranscoder(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			found := false
+			logs.RunJob(ctx, "TranscodeVideo", func(ctx context.Context) {
+				found, _ = uc.TranscodeNextVideo(ctx)
+			})
+			if !found || ctx.Err() != nil {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// GetHLSFile - плейлист или сегмент HLS версии видео урока
+func (uc *CourseUsecase) GetHLSFile(ctx context.Context, lessonId int, file string) (io.ReadCloser, dto.VideoMeta, error) {
+	if !hlsFilePattern.MatchString(file) {
+		return nil, dto.VideoMeta{}, errors.New("video not found")
+	}
+
+	prefix, err := uc.repo.GetHLSPrefix(ctx, lessonId)
+	if err != nil {
+		return nil, dto.VideoMeta{}, err
+	}
+
+	reader, meta, err := uc.repo.GetVideoObject(ctx, prefix+"/"+file)
+	if err != nil {
+		logs.PrintLog(ctx, "GetHLSFile", fmt.Sprintf("%+v", err))
+		return nil, dto.VideoMeta{}, errors.New("video not found")
+	}
+	meta.ContentType = hlsContentType(file)
+	return reader, meta, nil
+}
+
+func hlsContentType(name string) string {
+	if strings.HasSuffix(name, ".m3u8") {
+		return hlsPlaylistContentType
+	}
+	return hlsSegmentContentType
+}
@@ -0,0 +1,300 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	coursemodels "skillForce/internal/models/course"
+	"skillForce/internal/models/dto"
+	"skillForce/pkg/auth"
+	"skillForce/pkg/logs"
+	"skillForce/pkg/storage"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// VideoChunkSize - размер части загрузки, все части кроме последней должны быть ровно такого размера.
+	// Не меньше минимального размера части multipart загрузки S3 в 5 МиБ
+	VideoChunkSize int64 = 8 << 20
+	MaxVideoSize   int64 = 4 << 30
+
+	// staleVideoUploadsBatch - сколько брошенных загрузок отменяется за один проход
+	staleVideoUploadsBatch = 100
+)
+
+// videoExtensions - допустимые типы видео и расширения объектов в MinIO
+var videoExtensions = map[string]string{
+	"video/mp4":  ".mp4",
+	"video/webm": ".webm",
+}
+
+// InitVideoUpload - начало загрузки видео для урока. Загружать видео может только автор курса или администратор
+func (uc *CourseUsecase) InitVideoUpload(ctx context.Context, identity *auth.Identity, req *dto.InitVideoUploadDTO) (*dto.VideoUploadDTO, error) {
+	ext, ok := videoExtensions[req.ContentType]
+	if !ok {
+		return nil, errors.New("unsupported content type")
+	}
+	if req.Size <= 0 || req.Size > MaxVideoSize {
+		return nil, errors.New("invalid video size")
+	}
+
+	authorId, err := uc.repo.GetVideoLessonAuthor(ctx, req.LessonId)
+	if err != nil {
+		return nil, err
+	}
+	if authorId != identity.UserId && !identity.HasRole(auth.RoleAdmin) {
+		return nil, errors.New("permission denied")
+	}
+
+	objectName := uuid.New().String() + ext
+	minioUploadId, err := uc.repo.NewVideoUpload(ctx, objectName, req.ContentType)
+	if err != nil {
+		logs.PrintLog(ctx, "InitVideoUpload", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+
+	upload := &coursemodels.VideoUpload{
+		Id:            uuid.New().String(),
+		UserId:        identity.UserId,
+		LessonId:      req.LessonId,
+		ObjectName:    objectName,
+		MinioUploadId: minioUploadId,
+		ContentType:   req.ContentType,
+		TotalSize:     req.Size,
+		ChunkSize:     VideoChunkSize,
+		Status:        "uploading",
+	}
+	if err := uc.repo.CreateVideoUpload(ctx, upload); err != nil {
+		if abortErr := uc.repo.AbortVideoUpload(ctx, upload); abortErr != nil {
+			logs.PrintLog(ctx, "InitVideoUpload", fmt.Sprintf("%+v", abortErr))
+		}
+		return nil, err
+	}
+
+	return uc.videoUploadDTO(upload, ""), nil
+}
+
+// UploadVideoChunk - загрузка части с номером chunk начиная с 1. Повторная загрузка части заменяет
+// предыдущую, поэтому после обрыва клиент может продолжить с любой незагруженной части
+func (uc *CourseUsecase) UploadVideoChunk(ctx context.Context, userId int, uploadId string, chunk int, data io.Reader, size int64) (*dto.VideoUploadDTO, error) {
+	upload, err := uc.ownUpload(ctx, userId, uploadId)
+	if err != nil {
+		return nil, err
+	}
+	if upload.Status != "uploading" {
+		return nil, errors.New("upload is not in progress")
+	}
+
+	totalChunks := totalVideoChunks(upload)
+	if chunk < 1 || chunk > totalChunks {
+		return nil, errors.New("invalid chunk number")
+	}
+	expectedSize := upload.ChunkSize
+	if chunk == totalChunks {
+		expectedSize = upload.TotalSize - upload.ChunkSize*int64(totalChunks-1)
+	}
+	if size != expectedSize {
+		return nil, errors.New("invalid chunk size")
+	}
+
+	// по началу файла проверяется, что загружается именно заявленный тип видео
+	if chunk == 1 {
+		head := make([]byte, 512)
+		n, err := io.ReadFull(data, head)
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+			logs.PrintLog(ctx, "UploadVideoChunk", fmt.Sprintf("%+v", err))
+			return nil, err
+		}
+		head = head[:n]
+		if http.DetectContentType(head) != upload.ContentType {
+			return nil, errors.New("unsupported content type")
+		}
+		data = io.MultiReader(bytes.NewReader(head), data)
+	}
+
+	etag, err := uc.repo.PutVideoPart(ctx, upload, chunk, data, size)
+	if err != nil {
+		logs.PrintLog(ctx, "UploadVideoChunk", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+
+	part := &coursemodels.VideoUploadPart{PartNumber: chunk, ETag: etag, Size: size}
+	if err := uc.repo.SaveVideoUploadPart(ctx, upload.Id, part); err != nil {
+		return nil, err
+	}
+
+	upload.Parts = setVideoPart(upload.Parts, part)
+	return uc.videoUploadDTO(upload, ""), nil
+}
+
+// GetVideoUploadStatus - прогресс загрузки, по которому клиент определяет, какие части осталось отправить
+func (uc *CourseUsecase) GetVideoUploadStatus(ctx context.Context, userId int, uploadId string) (*dto.VideoUploadDTO, error) {
+	upload, err := uc.ownUpload(ctx, userId, uploadId)
+	if err != nil {
+		return nil, err
+	}
+
+	videoSrc := ""
+	if upload.Status == "completed" {
+		videoSrc = uc.repo.VideoUrl(upload.ObjectName)
+	}
+	return uc.videoUploadDTO(upload, videoSrc), nil
+}
+
+// CompleteVideoUpload - сборка объекта из частей, проверка итогового файла и прикрепление его к уроку
+func (uc *CourseUsecase) CompleteVideoUpload(ctx context.Context, userId int, uploadId string) (*dto.VideoUploadDTO, error) {
+	upload, err := uc.ownUpload(ctx, userId, uploadId)
+	if err != nil {
+		return nil, err
+	}
+	if upload.Status != "uploading" {
+		return nil, errors.New("upload is not in progress")
+	}
+	if len(upload.Parts) != totalVideoChunks(upload) {
+		return nil, errors.New("upload is incomplete")
+	}
+
+	if err := uc.repo.CompleteVideoUpload(ctx, upload); err != nil {
+		logs.PrintLog(ctx, "CompleteVideoUpload", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+
+	meta, err := uc.repo.Stat(ctx, upload.ObjectName)
+	if err != nil || meta.Size != upload.TotalSize {
+		logs.PrintLog(ctx, "CompleteVideoUpload", fmt.Sprintf("video %s: meta %+v, error %v", upload.ObjectName, meta, err))
+		return nil, errors.New("video verification failed")
+	}
+
+	videoSrc := uc.repo.VideoUrl(upload.ObjectName)
+	if err := uc.repo.FinishVideoUpload(ctx, upload, "completed", videoSrc); err != nil {
+		return nil, err
+	}
+
+	upload.Status = "completed"
+	return uc.videoUploadDTO(upload, videoSrc), nil
+}
+
+// AbortVideoUpload - отмена загрузки, загруженные части удаляются из MinIO
+func (uc *CourseUsecase) AbortVideoUpload(ctx context.Context, userId int, uploadId string) error {
+	upload, err := uc.ownUpload(ctx, userId, uploadId)
+	if err != nil {
+		return err
+	}
+	if upload.Status != "uploading" {
+		return errors.New("upload is not in progress")
+	}
+
+	if err := uc.repo.AbortVideoUpload(ctx, upload); err != nil {
+		logs.PrintLog(ctx, "AbortVideoUpload", fmt.Sprintf("%+v", err))
+		return err
+	}
+	return uc.repo.FinishVideoUpload(ctx, upload, "aborted", "")
+}
+
+// AbortStaleVideoUploads - отмена загрузок, в которые не приходили части дольше staleAfter.
+// Брошенные загрузки иначе навсегда занимают место незавершёнными частями в MinIO
+func (uc *CourseUsecase) AbortStaleVideoUploads(ctx context.Context, staleAfter time.Duration) (int, error) {
+	uploads, err := uc.repo.GetStaleVideoUploads(ctx, staleAfter, staleVideoUploadsBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	aborted := 0
+	for _, upload := range uploads {
+		// загрузку уже могли отменить в MinIO, тогда остаётся только пометить её в базе
+		if err := uc.repo.AbortVideoUpload(ctx, upload); err != nil && !errors.Is(err, storage.ErrNotFound) {
+			logs.PrintLog(ctx, "AbortStaleVideoUploads", fmt.Sprintf("upload %s: %+v", upload.Id, err))
+			continue
+		}
+		// за это время загрузку могли завершить или отменить
+		if err := uc.repo.FinishVideoUpload(ctx, upload, "aborted", ""); err != nil {
+			logs.PrintLog(ctx, "AbortStaleVideoUploads", fmt.Sprintf("upload %s: %+v", upload.Id, err))
+			continue
+		}
+		aborted++
+	}
+	return aborted, nil
+}
+
+// RunVideoUploadCleaner - отмена брошенных загрузок раз в interval до отмены контекста
+func (uc *CourseUsecase) RunVideoUploadCleaner(ctx context.Context, interval time.Duration, staleAfter time.Duration) {
+	if interval <= 0 || staleAfter <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		logs.RunJob(ctx, "AbortStaleVideoUploads", func(ctx context.Context) {
+			_, _ = uc.AbortStaleVideoUploads(ctx, staleAfter)
+		})
+	}
+}
+
+func (uc *CourseUsecase) ownUpload(ctx context.Context, userId int, uploadId string) (*coursemodels.VideoUpload, error) {
+	if _, err := uuid.Parse(uploadId); err != nil {
+		return nil, errors.New("upload not found")
+	}
+
+	upload, err := uc.repo.GetVideoUpload(ctx, uploadId)
+	if err != nil {
+		return nil, err
+	}
+	if upload.UserId != userId {
+		return nil, errors.New("upload not found")
+	}
+	return upload, nil
+}
+
+func (uc *CourseUsecase) videoUploadDTO(upload *coursemodels.VideoUpload, videoSrc string) *dto.VideoUploadDTO {
+	uploadedChunks := make([]int, 0, len(upload.Parts))
+	var uploadedSize int64
+	for _, part := range upload.Parts {
+		uploadedChunks = append(uploadedChunks, part.PartNumber)
+		uploadedSize += part.Size
+	}
+
+	return &dto.VideoUploadDTO{
+		UploadId:       upload.Id,
+		LessonId:       upload.LessonId,
+		ContentType:    upload.ContentType,
+		Size:           upload.TotalSize,
+		ChunkSize:      upload.ChunkSize,
+		TotalChunks:    totalVideoChunks(upload),
+		UploadedChunks: uploadedChunks,
+		UploadedSize:   uploadedSize,
+		Status:         upload.Status,
+		VideoSrc:       videoSrc,
+	}
+}
+
+func totalVideoChunks(upload *coursemodels.VideoUpload) int {
+	return int((upload.TotalSize + upload.ChunkSize - 1) / upload.ChunkSize)
+}
+
+// setVideoPart - замена или добавление части с сохранением порядка по номеру
+func setVideoPart(parts []*coursemodels.VideoUploadPart, part *coursemodels.VideoUploadPart) []*coursemodels.VideoUploadPart {
+	for i, p := range parts {
+		if p.PartNumber == part.PartNumber {
+			parts[i] = part
+			return parts
+		}
+		if p.PartNumber > part.PartNumber {
+			parts = append(parts[:i], append([]*coursemodels.VideoUploadPart{part}, parts[i:]...)...)
+			return parts
+		}
+	}
+	return append(parts, part)
+}
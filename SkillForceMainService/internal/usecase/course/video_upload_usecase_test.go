@@ -0,0 +1,405 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	coursemodels "skillForce/internal/models/course"
+	"skillForce/internal/models/dto"
+	"skillForce/pkg/auth"
+	"skillForce/pkg/logs"
+	"skillForce/pkg/storage"
+
+	"github.com/google/uuid"
+)
+
+// videoRepository - загрузки и части в памяти, остальные методы репозитория в тесте не вызываются
+type videoRepository struct {
+	CourseRepository
+	authorId   int
+	uploads    map[string]*coursemodels.VideoUpload
+	stale      []*coursemodels.VideoUpload
+	objectSize int64
+	abortErr   map[string]error
+	aborted    []string
+}
+
+func newVideoRepository() *videoRepository {
+	return &videoRepository{
+		authorId: 1,
+		uploads:  map[string]*coursemodels.VideoUpload{},
+		abortErr: map[string]error{},
+	}
+}
+
+// addUpload - загрузка видео из 3 частей, последняя часть короче остальных
+func (r *videoRepository) addUpload(userId int) *coursemodels.VideoUpload {
+	upload := &coursemodels.VideoUpload{
+		Id:          uuid.New().String(),
+		UserId:      userId,
+		LessonId:    10,
+		ObjectName:  uuid.New().String() + ".mp4",
+		ContentType: "video/mp4",
+		TotalSize:   2500,
+		ChunkSize:   1000,
+		Status:      "uploading",
+	}
+	r.uploads[upload.Id] = upload
+	return upload
+}
+
+func (r *videoRepository) GetVideoLessonAuthor(ctx context.Context, lessonId int) (int, error) {
+	return r.authorId, nil
+}
+
+func (r *videoRepository) NewVideoUpload(ctx context.Context, objectName string, contentType string) (string, error) {
+	return "minio-upload", nil
+}
+
+func (r *videoRepository) CreateVideoUpload(ctx context.Context, upload *coursemodels.VideoUpload) error {
+	r.uploads[upload.Id] = upload
+	return nil
+}
+
+func (r *videoRepository) GetVideoUpload(ctx context.Context, uploadId string) (*coursemodels.VideoUpload, error) {
+	upload, ok := r.uploads[uploadId]
+	if !ok {
+		return nil, errors.New("upload not found")
+	}
+	stored := *upload
+	stored.Parts = append([]*coursemodels.VideoUploadPart(nil), upload.Parts...)
+	return &stored, nil
+}
+
+func (r *videoRepository) PutVideoPart(ctx context.Context, upload *coursemodels.VideoUpload, partNumber int, data io.Reader, size int64) (string, error) {
+	body, err := io.ReadAll(data)
+	if err != nil {
+		return "", err
+	}
+	if int64(len(body)) != size {
+		return "", fmt.Errorf("part %d: read %d bytes, want %d", partNumber, len(body), size)
+	}
+	return fmt.Sprintf("etag-%d", partNumber), nil
+}
+
+func (r *videoRepository) SaveVideoUploadPart(ctx context.Context, uploadId string, part *coursemodels.VideoUploadPart) error {
+	upload := r.uploads[uploadId]
+	upload.Parts = setVideoPart(upload.Parts, part)
+	return nil
+}
+
+func (r *videoRepository) CompleteVideoUpload(ctx context.Context, upload *coursemodels.VideoUpload) error {
+	return nil
+}
+
+func (r *videoRepository) Stat(ctx context.Context, name string) (dto.VideoMeta, error) {
+	return dto.VideoMeta{Name: name, Size: r.objectSize}, nil
+}
+
+func (r *videoRepository) VideoUrl(objectName string) string {
+	return "https://skill-force.ru/videos/" + objectName
+}
+
+func (r *videoRepository) AbortVideoUpload(ctx context.Context, upload *coursemodels.VideoUpload) error {
+	if err := r.abortErr[upload.Id]; err != nil {
+		return err
+	}
+	r.aborted = append(r.aborted, upload.Id)
+	return nil
+}
+
+func (r *videoRepository) FinishVideoUpload(ctx context.Context, upload *coursemodels.VideoUpload, status string, videoSrc string) error {
+	stored, ok := r.uploads[upload.Id]
+	if !ok || stored.Status != "uploading" {
+		return errors.New("upload is not in progress")
+	}
+	stored.Status = status
+	return nil
+}
+
+func (r *videoRepository) GetStaleVideoUploads(ctx context.Context, staleAfter time.Duration, limit int) ([]*coursemodels.VideoUpload, error) {
+	return r.stale, nil
+}
+
+// mp4Chunk - часть заданного размера, начало которой распознаётся как video/mp4
+func mp4Chunk(size int) []byte {
+	data := make([]byte, size)
+	copy(data, "\x00\x00\x00\x18ftypmp42\x00\x00\x00\x00mp42isom")
+	return data
+}
+
+func videoTestContext() context.Context {
+	return context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+}
+
+func TestTotalVideoChunks(t *testing.T) {
+	tests := []struct {
+		totalSize int64
+		chunkSize int64
+		want      int
+	}{
+		{totalSize: 1, chunkSize: 1000, want: 1},
+		{totalSize: 1000, chunkSize: 1000, want: 1},
+		{totalSize: 1001, chunkSize: 1000, want: 2},
+		{totalSize: 2500, chunkSize: 1000, want: 3},
+		{totalSize: MaxVideoSize, chunkSize: VideoChunkSize, want: 512},
+	}
+	for _, tt := range tests {
+		upload := &coursemodels.VideoUpload{TotalSize: tt.totalSize, ChunkSize: tt.chunkSize}
+		if got := totalVideoChunks(upload); got != tt.want {
+			t.Errorf("totalVideoChunks(%d, %d) = %d, want %d", tt.totalSize, tt.chunkSize, got, tt.want)
+		}
+	}
+}
+
+func TestSetVideoPart(t *testing.T) {
+	tests := []struct {
+		name  string
+		parts []int
+		add   int
+		want  []int
+		etags []string
+	}{
+		{name: "empty", parts: nil, add: 1, want: []int{1}},
+		{name: "append", parts: []int{1, 2}, add: 4, want: []int{1, 2, 4}},
+		{name: "insert first", parts: []int{2, 3}, add: 1, want: []int{1, 2, 3}},
+		{name: "insert middle", parts: []int{1, 4}, add: 2, want: []int{1, 2, 4}},
+		{name: "replace", parts: []int{1, 2, 3}, add: 2, want: []int{1, 2, 3}, etags: []string{"old", "new", "old"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var parts []*coursemodels.VideoUploadPart
+			for _, number := range tt.parts {
+				parts = append(parts, &coursemodels.VideoUploadPart{PartNumber: number, ETag: "old"})
+			}
+
+			parts = setVideoPart(parts, &coursemodels.VideoUploadPart{PartNumber: tt.add, ETag: "new"})
+
+			var numbers []int
+			var etags []string
+			for _, part := range parts {
+				numbers = append(numbers, part.PartNumber)
+				etags = append(etags, part.ETag)
+			}
+			if !reflect.DeepEqual(numbers, tt.want) {
+				t.Fatalf("parts = %v, want %v", numbers, tt.want)
+			}
+			if tt.etags != nil && !reflect.DeepEqual(etags, tt.etags) {
+				t.Fatalf("etags = %v, want %v", etags, tt.etags)
+			}
+		})
+	}
+}
+
+func TestInitVideoUpload(t *testing.T) {
+	author := &auth.Identity{UserId: 1}
+	tests := []struct {
+		name     string
+		identity *auth.Identity
+		req      dto.InitVideoUploadDTO
+		wantErr  string
+	}{
+		{name: "unsupported content type", identity: author, req: dto.InitVideoUploadDTO{LessonId: 10, ContentType: "video/avi", Size: 100}, wantErr: "unsupported content type"},
+		{name: "empty video", identity: author, req: dto.InitVideoUploadDTO{LessonId: 10, ContentType: "video/mp4"}, wantErr: "invalid video size"},
+		{name: "too large", identity: author, req: dto.InitVideoUploadDTO{LessonId: 10, ContentType: "video/mp4", Size: MaxVideoSize + 1}, wantErr: "invalid video size"},
+		{name: "not author", identity: &auth.Identity{UserId: 2}, req: dto.InitVideoUploadDTO{LessonId: 10, ContentType: "video/mp4", Size: 100}, wantErr: "permission denied"},
+		{name: "admin", identity: &auth.Identity{UserId: 2, Roles: []string{string(auth.RoleAdmin)}}, req: dto.InitVideoUploadDTO{LessonId: 10, ContentType: "video/webm", Size: 100}},
+		{name: "author", identity: author, req: dto.InitVideoUploadDTO{LessonId: 10, ContentType: "video/mp4", Size: VideoChunkSize*2 + 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newVideoRepository()
+			uc := NewCourseUsecase(repo)
+
+			upload, err := uc.InitVideoUpload(videoTestContext(), tt.identity, &tt.req)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("InitVideoUpload() error = %v, want %q", err, tt.wantErr)
+				}
+				if len(repo.uploads) != 0 {
+					t.Fatalf("upload created on error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("InitVideoUpload() error = %v", err)
+			}
+
+			stored, ok := repo.uploads[upload.UploadId]
+			if !ok || stored.UserId != tt.identity.UserId || stored.ChunkSize != VideoChunkSize || stored.MinioUploadId != "minio-upload" {
+				t.Fatalf("stored upload = %+v", stored)
+			}
+			want := int((tt.req.Size + VideoChunkSize - 1) / VideoChunkSize)
+			if upload.TotalChunks != want || upload.Status != "uploading" || len(upload.UploadedChunks) != 0 {
+				t.Fatalf("upload = %+v, want %d chunks", upload, want)
+			}
+		})
+	}
+}
+
+func TestUploadVideoChunk_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		userId  int
+		chunk   int
+		data    []byte
+		prepare func(upload *coursemodels.VideoUpload)
+		wantErr string
+	}{
+		{name: "another user", userId: 2, chunk: 2, data: make([]byte, 1000), wantErr: "upload not found"},
+		{name: "chunk zero", userId: 1, chunk: 0, data: make([]byte, 1000), wantErr: "invalid chunk number"},
+		{name: "chunk after last", userId: 1, chunk: 4, data: make([]byte, 500), wantErr: "invalid chunk number"},
+		{name: "short middle chunk", userId: 1, chunk: 2, data: make([]byte, 999), wantErr: "invalid chunk size"},
+		{name: "full size last chunk", userId: 1, chunk: 3, data: make([]byte, 1000), wantErr: "invalid chunk size"},
+		{name: "not a video", userId: 1, chunk: 1, data: bytes.Repeat([]byte("text "), 200), wantErr: "unsupported content type"},
+		{name: "completed", userId: 1, chunk: 2, data: make([]byte, 1000), prepare: func(upload *coursemodels.VideoUpload) {
+			upload.Status = "completed"
+		}, wantErr: "upload is not in progress"},
+		{name: "first chunk", userId: 1, chunk: 1, data: mp4Chunk(1000)},
+		{name: "last chunk", userId: 1, chunk: 3, data: make([]byte, 500)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newVideoRepository()
+			upload := repo.addUpload(1)
+			if tt.prepare != nil {
+				tt.prepare(upload)
+			}
+			uc := NewCourseUsecase(repo)
+
+			status, err := uc.UploadVideoChunk(videoTestContext(), tt.userId, upload.Id, tt.chunk, bytes.NewReader(tt.data), int64(len(tt.data)))
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("UploadVideoChunk() error = %v, want %q", err, tt.wantErr)
+				}
+				if len(upload.Parts) != 0 {
+					t.Fatalf("part saved on error: %+v", upload.Parts)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UploadVideoChunk() error = %v", err)
+			}
+			if !reflect.DeepEqual(status.UploadedChunks, []int{tt.chunk}) || status.UploadedSize != int64(len(tt.data)) {
+				t.Fatalf("status = %+v", status)
+			}
+		})
+	}
+}
+
+func TestUploadVideoChunk_InvalidUploadId(t *testing.T) {
+	uc := NewCourseUsecase(newVideoRepository())
+
+	_, err := uc.UploadVideoChunk(videoTestContext(), 1, "../videos", 1, bytes.NewReader(nil), 0)
+	if err == nil || err.Error() != "upload not found" {
+		t.Fatalf("UploadVideoChunk() error = %v, want upload not found", err)
+	}
+}
+
+func TestVideoUpload_Resume(t *testing.T) {
+	ctx := videoTestContext()
+	repo := newVideoRepository()
+	repo.objectSize = 2500
+	upload := repo.addUpload(1)
+	uc := NewCourseUsecase(repo)
+
+	// части приходят не по порядку, последняя отправляется повторно после обрыва
+	for _, chunk := range []struct {
+		number int
+		data   []byte
+	}{
+		{number: 3, data: make([]byte, 500)},
+		{number: 1, data: mp4Chunk(1000)},
+		{number: 3, data: make([]byte, 500)},
+	} {
+		if _, err := uc.UploadVideoChunk(ctx, 1, upload.Id, chunk.number, bytes.NewReader(chunk.data), int64(len(chunk.data))); err != nil {
+			t.Fatalf("UploadVideoChunk(%d) error = %v", chunk.number, err)
+		}
+	}
+
+	status, err := uc.GetVideoUploadStatus(ctx, 1, upload.Id)
+	if err != nil {
+		t.Fatalf("GetVideoUploadStatus() error = %v", err)
+	}
+	if !reflect.DeepEqual(status.UploadedChunks, []int{1, 3}) || status.UploadedSize != 1500 || status.TotalChunks != 3 || status.VideoSrc != "" {
+		t.Fatalf("status = %+v", status)
+	}
+
+	if _, err := uc.CompleteVideoUpload(ctx, 1, upload.Id); err == nil || err.Error() != "upload is incomplete" {
+		t.Fatalf("CompleteVideoUpload() error = %v, want upload is incomplete", err)
+	}
+
+	if _, err := uc.UploadVideoChunk(ctx, 1, upload.Id, 2, bytes.NewReader(make([]byte, 1000)), 1000); err != nil {
+		t.Fatalf("UploadVideoChunk(2) error = %v", err)
+	}
+	completed, err := uc.CompleteVideoUpload(ctx, 1, upload.Id)
+	if err != nil {
+		t.Fatalf("CompleteVideoUpload() error = %v", err)
+	}
+	if completed.Status != "completed" || completed.VideoSrc != repo.VideoUrl(upload.ObjectName) || completed.UploadedSize != 2500 {
+		t.Fatalf("completed = %+v", completed)
+	}
+
+	status, err = uc.GetVideoUploadStatus(ctx, 1, upload.Id)
+	if err != nil {
+		t.Fatalf("GetVideoUploadStatus() error = %v", err)
+	}
+	if status.Status != "completed" || status.VideoSrc != completed.VideoSrc {
+		t.Fatalf("status after complete = %+v", status)
+	}
+}
+
+func TestCompleteVideoUpload_VerificationFailed(t *testing.T) {
+	repo := newVideoRepository()
+	repo.objectSize = 2000
+	upload := repo.addUpload(1)
+	upload.Parts = []*coursemodels.VideoUploadPart{
+		{PartNumber: 1, Size: 1000}, {PartNumber: 2, Size: 1000}, {PartNumber: 3, Size: 500},
+	}
+	uc := NewCourseUsecase(repo)
+
+	if _, err := uc.CompleteVideoUpload(videoTestContext(), 1, upload.Id); err == nil || err.Error() != "video verification failed" {
+		t.Fatalf("CompleteVideoUpload() error = %v, want video verification failed", err)
+	}
+	if upload.Status != "uploading" {
+		t.Fatalf("status = %s, want uploading", upload.Status)
+	}
+}
+
+func TestAbortStaleVideoUploads(t *testing.T) {
+	repo := newVideoRepository()
+	abandoned := repo.addUpload(1)
+	abortedInMinio := repo.addUpload(1)
+	minioDown := repo.addUpload(1)
+	completedMeanwhile := repo.addUpload(1)
+	repo.stale = []*coursemodels.VideoUpload{abandoned, abortedInMinio, minioDown, completedMeanwhile}
+	repo.abortErr[abortedInMinio.Id] = fmt.Errorf("%w: upload %s", storage.ErrNotFound, abortedInMinio.MinioUploadId)
+	repo.abortErr[minioDown.Id] = errors.New("connection refused")
+	completedMeanwhile.Status = "completed"
+	uc := NewCourseUsecase(repo)
+
+	aborted, err := uc.AbortStaleVideoUploads(videoTestContext(), 24*time.Hour)
+	if err != nil {
+		t.Fatalf("AbortStaleVideoUploads() error = %v", err)
+	}
+	if aborted != 2 {
+		t.Fatalf("aborted = %d, want 2", aborted)
+	}
+	// загрузка, которую не удалось отменить в MinIO, остаётся до следующего прохода
+	for upload, want := range map[*coursemodels.VideoUpload]string{
+		abandoned:          "aborted",
+		abortedInMinio:     "aborted",
+		minioDown:          "uploading",
+		completedMeanwhile: "completed",
+	} {
+		if upload.Status != want {
+			t.Errorf("upload %s status = %s, want %s", upload.Id, upload.Status, want)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package auth
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type Role string
+
+const (
+	RoleLearner   Role = "learner"
+	RoleAuthor    Role = "author"
+	RoleModerator Role = "moderator"
+	RoleAdmin     Role = "admin"
+)
+
+type Permission string
+
+const (
+	// PermPublic - метод доступен без авторизации
+	PermPublic       Permission = ""
+	PermLearn        Permission = "course:learn"
+	PermPurchase     Permission = "course:purchase"
+	PermCreateCourse Permission = "course:create"
+	PermModerate     Permission = "course:moderate"
+	PermManageRoles  Permission = "user:manage_roles"
+)
+
+var rolePermissions = map[Role][]Permission{
+	RoleLearner:   {PermLearn, PermPurchase},
+	RoleAuthor:    {PermCreateCourse},
+	RoleModerator: {PermModerate},
+	RoleAdmin:     {PermCreateCourse, PermModerate, PermManageRoles},
+}
+
+// Identity - пользователь, от имени которого пришел запрос
+type Identity struct {
+	UserId int
+	Roles  []string
+}
+
+type identityKey struct{}
+
+func IsValidRole(role string) bool {
+	_, ok := rolePermissions[Role(role)]
+	return ok
+}
+
+// HasPermission - роль learner есть у любого авторизованного пользователя
+func HasPermission(roles []string, permission Permission) bool {
+	if permission == PermPublic {
+		return true
+	}
+	for _, role := range append([]string{string(RoleLearner)}, roles...) {
+		for _, p := range rolePermissions[Role(role)] {
+			if p == permission {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (i *Identity) HasRole(role Role) bool {
+	for _, r := range i.Roles {
+		if r == string(role) {
+			return true
+		}
+	}
+	return false
+}
+
+func IdentityFromContext(ctx context.Context) (*Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(*Identity)
+	return identity, ok
+}
+
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// UnaryClientInterceptor - на каждый исходящий gRPC вызов выпускается короткоживущий токен
+// для сервиса audience с пользователем из контекста запроса
+func UnaryClientInterceptor(signer *TokenSigner, audience string) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		identity, _ := IdentityFromContext(ctx)
+		token, err := signer.Sign(audience, identity)
+		if err != nil {
+			return err
+		}
+		ctx = metadata.AppendToOutgoingContext(ctx, tokenKey, token)
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// DialOptions - mTLS и подпись пользователя для соединения с сервисом service
+func DialOptions(files TLSFiles, signer *TokenSigner, service string) ([]grpc.DialOption, error) {
+	creds, err := ClientCredentials(files, service)
+	if err != nil {
+		return nil, err
+	}
+	return []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithUnaryInterceptor(UnaryClientInterceptor(signer, service)),
+	}, nil
+}
@@ -0,0 +1,27 @@
+package avatar
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// Sizes - стороны миниатюр, которые user-service нарезает при загрузке аватарки
+var Sizes = []int{64, 128, 256}
+
+// thumbnailPattern - ссылка на самую большую миниатюру: .../<uuid>/256.jpg
+var thumbnailPattern = regexp.MustCompile(`^(.*/[0-9a-f-]{36}/)256\.jpg$`)
+
+// Thumbnails - ссылки на миниатюры всех размеров по ссылке из avatar_src.
+// У стандартной аватарки и аватарок, загруженных до нарезки миниатюр, всех размеров одна ссылка
+func Thumbnails(src string) map[string]string {
+	thumbnails := make(map[string]string, len(Sizes))
+	match := thumbnailPattern.FindStringSubmatch(src)
+	for _, size := range Sizes {
+		if match == nil {
+			thumbnails[strconv.Itoa(size)] = src
+			continue
+		}
+		thumbnails[strconv.Itoa(size)] = match[1] + strconv.Itoa(size) + ".jpg"
+	}
+	return thumbnails
+}
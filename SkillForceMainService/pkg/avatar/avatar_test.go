@@ -0,0 +1,49 @@
+package avatar
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestThumbnails(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want map[string]string
+	}{
+		{
+			name: "thumbnails",
+			src:  "https://skill-force.ru/avatars/0b8e7a57-58f4-4f59-9d6e-3f6a1f1d2c4b/256.jpg",
+			want: map[string]string{
+				"64":  "https://skill-force.ru/avatars/0b8e7a57-58f4-4f59-9d6e-3f6a1f1d2c4b/64.jpg",
+				"128": "https://skill-force.ru/avatars/0b8e7a57-58f4-4f59-9d6e-3f6a1f1d2c4b/128.jpg",
+				"256": "https://skill-force.ru/avatars/0b8e7a57-58f4-4f59-9d6e-3f6a1f1d2c4b/256.jpg",
+			},
+		},
+		{
+			name: "default avatar",
+			src:  "https://skill-force.ru/avatars/default_avatar.png",
+			want: map[string]string{
+				"64":  "https://skill-force.ru/avatars/default_avatar.png",
+				"128": "https://skill-force.ru/avatars/default_avatar.png",
+				"256": "https://skill-force.ru/avatars/default_avatar.png",
+			},
+		},
+		{
+			name: "old upload",
+			src:  "https://skill-force.ru/avatars/0b8e7a57-58f4-4f59-9d6e-3f6a1f1d2c4b.jpg",
+			want: map[string]string{
+				"64":  "https://skill-force.ru/avatars/0b8e7a57-58f4-4f59-9d6e-3f6a1f1d2c4b.jpg",
+				"128": "https://skill-force.ru/avatars/0b8e7a57-58f4-4f59-9d6e-3f6a1f1d2c4b.jpg",
+				"256": "https://skill-force.ru/avatars/0b8e7a57-58f4-4f59-9d6e-3f6a1f1d2c4b.jpg",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Thumbnails(tt.src); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Thumbnails() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package mediasign
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// LinkTTL - время жизни ссылки. HLS плеер запрашивает сегменты по ходу просмотра,
+// поэтому ссылка должна жить дольше самого длинного урока
+const LinkTTL = 3 * time.Hour
+
+// Signer - выпуск и проверка короткоживущих ссылок на платные медиа.
+// Подпись покрывает ресурс и время истечения, поэтому ссылку нельзя продлить или перенести на другой урок
+type Signer struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+// NewSigner - подписывающий ключ обязателен: с пустым ключом ссылку на любой ресурс может выпустить кто угодно
+func NewSigner(secret string, ttl time.Duration) (*Signer, error) {
+	if secret == "" {
+		return nil, errors.New("empty media url secret")
+	}
+	return &Signer{secret: []byte(secret), ttl: ttl}, nil
+}
+
+func VideoResource(lessonId int) string {
+	return fmt.Sprintf("video/%d", lessonId)
+}
+
+func SertificateResource(objectName string) string {
+	return "sertificates/" + objectName
+}
+
+func LessonFileResource(objectName string) string {
+	return "lesson-files/" + objectName
+}
+
+func CourseBundleResource(objectName string) string {
+	return "bundles/" + objectName
+}
+
+// Sign - время истечения в unix секундах и подпись ресурса
+func (s *Signer) Sign(resource string, now time.Time) (int64, string) {
+	expires := now.Add(s.ttl).Unix()
+	return expires, s.signature(resource, expires)
+}
+
+// Verify - проверка подписи и срока действия ссылки
+func (s *Signer) Verify(resource string, expires string, signature string, now time.Time) error {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return errors.New("invalid signature")
+	}
+
+	expected, err := hex.DecodeString(s.signature(resource, expiresAt))
+	if err != nil {
+		return err
+	}
+	actual, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, actual) {
+		return errors.New("invalid signature")
+	}
+
+	if now.Unix() > expiresAt {
+		return errors.New("link expired")
+	}
+	return nil
+}
+
+// VideoURL - ссылка на mp4 видео урока
+func (s *Signer) VideoURL(lessonId int, now time.Time) (string, time.Time) {
+	expires, signature := s.Sign(VideoResource(lessonId), now)
+	query := url.Values{}
+	query.Set("lesson_id", strconv.Itoa(lessonId))
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", signature)
+	return "/api/video?" + query.Encode(), time.Unix(expires, 0)
+}
+
+// HLSURL - ссылка на master плейлист. Подпись лежит в пути, чтобы относительные ссылки
+// плейлистов на другие плейлисты и сегменты наследовали её
+func (s *Signer) HLSURL(lessonId int, now time.Time) string {
+	expires, signature := s.Sign(VideoResource(lessonId), now)
+	return fmt.Sprintf("/api/video/hls/%d/%d/%s/master.m3u8", lessonId, expires, signature)
+}
+
+func (s *Signer) SertificateURL(objectName string, now time.Time) string {
+	expires, signature := s.Sign(SertificateResource(objectName), now)
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", signature)
+	return "/api/media/sertificates/" + url.PathEscape(objectName) + "?" + query.Encode()
+}
+
+// LessonFileURL - ссылка на картинку или вложение блока урока. Имя объекта содержит id автора и
+// слеш, который остаётся частью пути. Имя файла для скачивания не подписывается
+func (s *Signer) LessonFileURL(objectName string, fileName string, now time.Time) string {
+	expires, signature := s.Sign(LessonFileResource(objectName), now)
+	query := url.Values{}
+	if fileName != "" {
+		query.Set("name", fileName)
+	}
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", signature)
+	return "/api/media/lesson-files/" + objectName + "?" + query.Encode()
+}
+
+// CourseBundleURL - ссылка на офлайн выгрузку курса. Имя файла для скачивания не подписывается
+func (s *Signer) CourseBundleURL(objectName string, fileName string, now time.Time) (string, time.Time) {
+	expires, signature := s.Sign(CourseBundleResource(objectName), now)
+	query := url.Values{}
+	query.Set("name", fileName)
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", signature)
+	return "/api/media/bundles/" + objectName + "?" + query.Encode(), time.Unix(expires, 0)
+}
+
+func (s *Signer) signature(resource string, expires int64) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(resource))
+	mac.Write([]byte{'\n'})
+	mac.Write([]byte(strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
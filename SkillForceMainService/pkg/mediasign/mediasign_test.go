@@ -0,0 +1,104 @@
+package mediasign
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	signer := newSigner(t, "secret")
+	now := time.Unix(1_700_000_000, 0)
+	expires, signature := signer.Sign(VideoResource(12), now)
+	expiresStr := strconv.FormatInt(expires, 10)
+
+	tests := []struct {
+		name      string
+		resource  string
+		expires   string
+		signature string
+		now       time.Time
+		wantErr   string
+	}{
+		{name: "valid", resource: VideoResource(12), expires: expiresStr, signature: signature, now: now},
+		{name: "last second", resource: VideoResource(12), expires: expiresStr, signature: signature, now: now.Add(time.Hour)},
+		{name: "expired", resource: VideoResource(12), expires: expiresStr, signature: signature, now: now.Add(time.Hour + time.Second), wantErr: "link expired"},
+		{name: "other lesson", resource: VideoResource(13), expires: expiresStr, signature: signature, now: now, wantErr: "invalid signature"},
+		{name: "extended expiry", resource: VideoResource(12), expires: strconv.FormatInt(expires+3600, 10), signature: signature, now: now, wantErr: "invalid signature"},
+		{name: "other secret", resource: VideoResource(12), expires: expiresStr, signature: mustSign(newSigner(t, "other"), now), now: now, wantErr: "invalid signature"},
+		{name: "not hex", resource: VideoResource(12), expires: expiresStr, signature: "zz", now: now, wantErr: "invalid signature"},
+		{name: "empty", resource: VideoResource(12), now: now, wantErr: "invalid signature"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := signer.Verify(tt.resource, tt.expires, tt.signature, tt.now)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Fatalf("Verify() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func newSigner(t *testing.T, secret string) *Signer {
+	t.Helper()
+	signer, err := NewSigner(secret, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signer
+}
+
+func TestNewSigner_EmptySecret(t *testing.T) {
+	if _, err := NewSigner("", time.Hour); err == nil {
+		t.Fatal("NewSigner() accepted an empty secret")
+	}
+}
+
+func mustSign(signer *Signer, now time.Time) string {
+	_, signature := signer.Sign(VideoResource(12), now)
+	return signature
+}
+
+func TestHLSURL(t *testing.T) {
+	signer := newSigner(t, "secret")
+	now := time.Unix(1_700_000_000, 0)
+	expires, signature := signer.Sign(VideoResource(12), now)
+
+	want := "/api/video/hls/12/" + strconv.FormatInt(expires, 10) + "/" + signature + "/master.m3u8"
+	if got := signer.HLSURL(12, now); got != want {
+		t.Errorf("HLSURL() = %s, want %s", got, want)
+	}
+}
+
+func TestLessonFileURL(t *testing.T) {
+	signer := newSigner(t, "secret")
+	now := time.Unix(1_700_000_000, 0)
+	expires, signature := signer.Sign(LessonFileResource("7/0b8e7a57-58f4-4f59-9d6e-3f6a1f1d2c4b.pdf"), now)
+
+	want := "/api/media/lesson-files/7/0b8e7a57-58f4-4f59-9d6e-3f6a1f1d2c4b.pdf?expires=" + strconv.FormatInt(expires, 10) + "&name=notes.pdf&signature=" + signature
+	if got := signer.LessonFileURL("7/0b8e7a57-58f4-4f59-9d6e-3f6a1f1d2c4b.pdf", "notes.pdf", now); got != want {
+		t.Errorf("LessonFileURL() = %s, want %s", got, want)
+	}
+}
+
+func TestCourseBundleURL(t *testing.T) {
+	signer := newSigner(t, "secret")
+	now := time.Unix(1_700_000_000, 0)
+	object := "3/0123456789abcdef0123456789abcdef-video.epub"
+	expires, signature := signer.Sign(CourseBundleResource(object), now)
+
+	want := "/api/media/bundles/" + object + "?expires=" + strconv.FormatInt(expires, 10) + "&name=Go+course.epub&signature=" + signature
+	got, expiresAt := signer.CourseBundleURL(object, "Go course.epub", now)
+	if got != want {
+		t.Errorf("CourseBundleURL() = %s, want %s", got, want)
+	}
+	if expiresAt.Unix() != expires {
+		t.Errorf("CourseBundleURL() expires at %d, want %d", expiresAt.Unix(), expires)
+	}
+	if err := signer.Verify(SertificateResource(object), strconv.FormatInt(expires, 10), signature, now); err == nil {
+		t.Error("bundle signature must not open sertificates")
+	}
+}
@@ -0,0 +1,123 @@
+package notifyhub
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Channel - канал LISTEN/NOTIFY, в который user-service пишет id пользователя при изменении его уведомлений
+const Channel = "notifications"
+
+// pingInterval - проверка соединения LISTEN, если сигналов давно не было
+const pingInterval = 90 * time.Second
+
+// Hub - раздача сигналов об изменении уведомлений открытым потокам пользователей.
+// Сигнал не несёт данных: получив его, поток сам запрашивает новые уведомления, поэтому
+// несколько сигналов подряд схлопываются в один
+type Hub struct {
+	mu          sync.Mutex
+	subscribers map[int]map[chan struct{}]struct{}
+}
+
+func New() *Hub {
+	return &Hub{subscribers: make(map[int]map[chan struct{}]struct{})}
+}
+
+// Subscribe - сигналы для пользователя userId. Вызов cancel отписывает
+func (h *Hub) Subscribe(userId int) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.subscribers[userId] == nil {
+		h.subscribers[userId] = make(map[chan struct{}]struct{})
+	}
+	h.subscribers[userId][ch] = struct{}{}
+
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.subscribers[userId], ch)
+		if len(h.subscribers[userId]) == 0 {
+			delete(h.subscribers, userId)
+		}
+	}
+}
+
+// Notify - сигнал всем потокам пользователя
+func (h *Hub) Notify(userId int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subscribers[userId] {
+		signal(ch)
+	}
+}
+
+// NotifyAll - сигнал всем потокам. Нужен после переподключения к базе: пока соединения не было, сигналы терялись
+func (h *Hub) NotifyAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, subscribers := range h.subscribers {
+		for ch := range subscribers {
+			signal(ch)
+		}
+	}
+}
+
+// Listen - чтение Channel до отмены контекста. pq.Listener сам переподключается к базе
+func (h *Hub) Listen(ctx context.Context, dsn string) {
+	listener := pq.NewListener(dsn, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Printf("notifications listener: %v", err)
+		}
+	})
+	defer func() {
+		if err := listener.Close(); err != nil {
+			log.Printf("failed to close notifications listener: %v", err)
+		}
+	}()
+	if err := listener.Listen(Channel); err != nil {
+		log.Printf("failed to listen %s: %v", Channel, err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case n := <-listener.Notify:
+			h.dispatch(n)
+		case <-time.After(pingInterval):
+			go func() {
+				if err := listener.Ping(); err != nil {
+					log.Printf("notifications listener ping: %v", err)
+				}
+			}()
+		}
+	}
+}
+
+// dispatch - сигнал по уведомлению базы. nil приходит после переподключения
+func (h *Hub) dispatch(n *pq.Notification) {
+	if n == nil {
+		h.NotifyAll()
+		return
+	}
+	userId, err := strconv.Atoi(n.Extra)
+	if err != nil {
+		log.Printf("invalid %s payload %q", Channel, n.Extra)
+		return
+	}
+	h.Notify(userId)
+}
+
+func signal(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,81 @@
+package notifyhub
+
+import (
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+func received(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestNotify(t *testing.T) {
+	hub := New()
+	first, cancelFirst := hub.Subscribe(1)
+	defer cancelFirst()
+	second, cancelSecond := hub.Subscribe(1)
+	defer cancelSecond()
+	other, cancelOther := hub.Subscribe(2)
+	defer cancelOther()
+
+	// несколько сигналов подряд схлопываются
+	hub.Notify(1)
+	hub.Notify(1)
+
+	if !received(first) || !received(second) {
+		t.Fatal("subscribers of user 1 did not receive signal")
+	}
+	if received(first) {
+		t.Fatal("signals were not collapsed")
+	}
+	if received(other) {
+		t.Fatal("user 2 received signal of user 1")
+	}
+}
+
+func TestSubscribe_Cancel(t *testing.T) {
+	hub := New()
+	ch, cancel := hub.Subscribe(1)
+	if len(hub.subscribers[1]) != 1 {
+		t.Fatalf("subscribers = %d, want 1", len(hub.subscribers[1]))
+	}
+
+	cancel()
+	hub.Notify(1)
+	if received(ch) {
+		t.Fatal("cancelled subscriber received signal")
+	}
+	if len(hub.subscribers) != 0 {
+		t.Fatalf("subscribers of %d users left", len(hub.subscribers))
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	hub := New()
+	first, cancelFirst := hub.Subscribe(1)
+	defer cancelFirst()
+	second, cancelSecond := hub.Subscribe(2)
+	defer cancelSecond()
+
+	hub.dispatch(&pq.Notification{Channel: Channel, Extra: "2"})
+	if received(first) || !received(second) {
+		t.Fatal("dispatch did not signal user 2 only")
+	}
+
+	hub.dispatch(&pq.Notification{Channel: Channel, Extra: "not a number"})
+	if received(first) || received(second) {
+		t.Fatal("invalid payload signalled subscribers")
+	}
+
+	// после переподключения сигнал получают все
+	hub.dispatch(nil)
+	if !received(first) || !received(second) {
+		t.Fatal("reconnect did not signal all subscribers")
+	}
+}
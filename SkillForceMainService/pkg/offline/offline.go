@@ -0,0 +1,299 @@
+package offline
+
+import (
+	"archive/zip"
+	"fmt"
+	"html"
+	"io"
+	"path"
+	"strings"
+	"time"
+)
+
+// Page - страница выгрузки. Body - HTML фрагмент, ссылки на файлы в нём относительные, от корня выгрузки
+type Page struct {
+	Section string
+	Title   string
+	Body    string
+}
+
+// Resource - картинка, вложение или видео, на которые ссылаются страницы
+type Resource struct {
+	Name        string
+	ContentType string
+	Open        func() (io.ReadCloser, error)
+}
+
+// Book - выгрузка курса, которую можно открыть без сети
+type Book struct {
+	Id          string
+	Title       string
+	Description string
+	Language    string
+	Pages       []Page
+	Resources   []Resource
+}
+
+// pageName - имя файла страницы без расширения
+func pageName(i int) string {
+	return fmt.Sprintf("lesson-%03d", i+1)
+}
+
+// checkResources - имена ресурсов должны быть относительными путями без выхода за пределы выгрузки
+// и не должны повторяться
+func checkResources(resources []Resource) error {
+	names := make(map[string]struct{}, len(resources))
+	for _, resource := range resources {
+		name := path.Clean(resource.Name)
+		if resource.Name == "" || name != resource.Name || path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return fmt.Errorf("invalid resource name %q", resource.Name)
+		}
+		if _, ok := names[name]; ok {
+			return fmt.Errorf("duplicate resource name %q", resource.Name)
+		}
+		names[name] = struct{}{}
+	}
+	return nil
+}
+
+func copyResource(w io.Writer, resource Resource) error {
+	reader, err := resource.Open()
+	if err != nil {
+		return fmt.Errorf("resource %s: %w", resource.Name, err)
+	}
+	defer reader.Close()
+
+	if _, err := io.Copy(w, reader); err != nil {
+		return fmt.Errorf("resource %s: %w", resource.Name, err)
+	}
+	return nil
+}
+
+// WriteZip - zip архив со страницами index.html и lesson-NNN.html. Ресурсы уже сжаты,
+// поэтому кладутся без сжатия
+func WriteZip(w io.Writer, book *Book) error {
+	if err := checkResources(book.Resources); err != nil {
+		return err
+	}
+
+	writer := zip.NewWriter(w)
+	index, err := writer.Create("index.html")
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(index, htmlDocument(book.Title, tableOfContents(book, ".html"))); err != nil {
+		return err
+	}
+
+	for i, page := range book.Pages {
+		pageWriter, err := writer.Create(pageName(i) + ".html")
+		if err != nil {
+			return err
+		}
+		body := fmt.Sprintf("<p><a href=\"index.html\">%s</a></p>\n<h1>%s</h1>\n%s", html.EscapeString(book.Title), html.EscapeString(page.Title), page.Body)
+		if _, err := io.WriteString(pageWriter, htmlDocument(page.Title, body)); err != nil {
+			return err
+		}
+	}
+
+	for _, resource := range book.Resources {
+		resourceWriter, err := writer.CreateHeader(&zip.FileHeader{Name: resource.Name, Method: zip.Store})
+		if err != nil {
+			return err
+		}
+		if err := copyResource(resourceWriter, resource); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+// tableOfContents - оглавление со страницами, сгруппированными по разделам курса
+func tableOfContents(book *Book, ext string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<h1>%s</h1>\n", html.EscapeString(book.Title))
+	if book.Description != "" {
+		fmt.Fprintf(&b, "<p>%s</p>\n", html.EscapeString(book.Description))
+	}
+
+	section := ""
+	open := false
+	for i, page := range book.Pages {
+		if !open || page.Section != section {
+			if open {
+				b.WriteString("</ol>\n")
+			}
+			section = page.Section
+			open = true
+			if section != "" {
+				fmt.Fprintf(&b, "<h2>%s</h2>\n", html.EscapeString(section))
+			}
+			b.WriteString("<ol>\n")
+		}
+		fmt.Fprintf(&b, "<li><a href=\"%s%s\">%s</a></li>\n", pageName(i), ext, html.EscapeString(page.Title))
+	}
+	if open {
+		b.WriteString("</ol>\n")
+	}
+	return b.String()
+}
+
+const pageStyle = `body{max-width:48em;margin:0 auto;padding:1em;font-family:sans-serif;line-height:1.5}` +
+	`img,video{max-width:100%}pre{overflow-x:auto;background:#f5f5f5;padding:.5em}` +
+	`.callout{border-left:4px solid #888;padding:.5em 1em;background:#f7f7f7}`
+
+func htmlDocument(title string, body string) string {
+	return fmt.Sprintf("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n<style>%s</style>\n</head>\n<body>\n%s\n</body>\n</html>\n",
+		html.EscapeString(title), pageStyle, body)
+}
+
+// WriteEPUB - книга EPUB 3. Страницы переводятся в XHTML, поэтому HTML фрагменты
+// не обязаны быть правильно сформированным XML
+func WriteEPUB(w io.Writer, book *Book, modified time.Time) error {
+	if err := checkResources(book.Resources); err != nil {
+		return err
+	}
+
+	writer := zip.NewWriter(w)
+	// mimetype обязан быть первым и несжатым, по нему читалки узнают формат
+	mimetype, err := writer.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(mimetype, "application/epub+zip"); err != nil {
+		return err
+	}
+
+	files := []struct {
+		name    string
+		content string
+	}{
+		{"META-INF/container.xml", containerXML},
+		{"OEBPS/content.opf", packageDocument(book, modified)},
+		{"OEBPS/nav.xhtml", xhtmlDocument(book.Title, book.Language, tableOfContentsNav(book))},
+	}
+	for i, page := range book.Pages {
+		body, err := XHTML(page.Body)
+		if err != nil {
+			return fmt.Errorf("page %d: %w", i+1, err)
+		}
+		body = fmt.Sprintf("<h1>%s</h1>\n%s", xmlEscape(page.Title), body)
+		files = append(files, struct {
+			name    string
+			content string
+		}{"OEBPS/" + pageName(i) + ".xhtml", xhtmlDocument(page.Title, book.Language, body)})
+	}
+
+	for _, file := range files {
+		fileWriter, err := writer.Create(file.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(fileWriter, file.content); err != nil {
+			return err
+		}
+	}
+
+	for _, resource := range book.Resources {
+		resourceWriter, err := writer.CreateHeader(&zip.FileHeader{Name: "OEBPS/" + resource.Name, Method: zip.Store})
+		if err != nil {
+			return err
+		}
+		if err := copyResource(resourceWriter, resource); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+const containerXML = `<?xml version="1.0" encoding="UTF-8"?>
+<container version="1.0" xmlns="urn:oasis:names:tc:opendocument:xmlns:container">
+  <rootfiles>
+    <rootfile full-path="OEBPS/content.opf" media-type="application/oebps-package+xml"/>
+  </rootfiles>
+</container>
+`
+
+func packageDocument(book *Book, modified time.Time) string {
+	var manifest, spine strings.Builder
+	manifest.WriteString(`    <item id="nav" href="nav.xhtml" media-type="application/xhtml+xml" properties="nav"/>` + "\n")
+	spine.WriteString(`    <itemref idref="nav"/>` + "\n")
+	for i := range book.Pages {
+		fmt.Fprintf(&manifest, "    <item id=\"page-%d\" href=\"%s.xhtml\" media-type=\"application/xhtml+xml\"/>\n", i+1, pageName(i))
+		fmt.Fprintf(&spine, "    <itemref idref=\"page-%d\"/>\n", i+1)
+	}
+	for i, resource := range book.Resources {
+		fmt.Fprintf(&manifest, "    <item id=\"res-%d\" href=\"%s\" media-type=\"%s\"/>\n", i+1, xmlEscape(resource.Name), xmlEscape(resource.ContentType))
+	}
+
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<package xmlns="http://www.idpf.org/2007/opf" version="3.0" unique-identifier="book-id">
+  <metadata xmlns:dc="http://purl.org/dc/elements/1.1/">
+    <dc:identifier id="book-id">%s</dc:identifier>
+    <dc:title>%s</dc:title>
+    <dc:language>%s</dc:language>
+    <dc:description>%s</dc:description>
+    <meta property="dcterms:modified">%s</meta>
+  </metadata>
+  <manifest>
+%s  </manifest>
+  <spine>
+%s  </spine>
+</package>
+`, xmlEscape(book.Id), xmlEscape(book.Title), xmlEscape(book.Language), xmlEscape(book.Description),
+		modified.UTC().Format("2006-01-02T15:04:05Z"), manifest.String(), spine.String())
+}
+
+// tableOfContentsNav - оглавление EPUB 3. Вложенные списки повторяют разделы курса
+func tableOfContentsNav(book *Book) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<h1>%s</h1>\n", xmlEscape(book.Title))
+	if book.Description != "" {
+		fmt.Fprintf(&b, "<p>%s</p>\n", xmlEscape(book.Description))
+	}
+	b.WriteString("<nav epub:type=\"toc\" id=\"toc\">\n<ol>\n")
+
+	section := ""
+	open := false
+	for i, page := range book.Pages {
+		link := fmt.Sprintf("<li><a href=\"%s.xhtml\">%s</a></li>\n", pageName(i), xmlEscape(page.Title))
+		if page.Section == "" {
+			if open {
+				b.WriteString("</ol></li>\n")
+				open = false
+			}
+			b.WriteString(link)
+			continue
+		}
+		if !open || page.Section != section {
+			if open {
+				b.WriteString("</ol></li>\n")
+			}
+			section = page.Section
+			open = true
+			fmt.Fprintf(&b, "<li><span>%s</span>\n<ol>\n", xmlEscape(section))
+		}
+		b.WriteString(link)
+	}
+	if open {
+		b.WriteString("</ol></li>\n")
+	}
+	b.WriteString("</ol>\n</nav>\n")
+	return b.String()
+}
+
+func xhtmlDocument(title string, language string, body string) string {
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:epub="http://www.idpf.org/2007/ops" lang="%[2]s" xml:lang="%[2]s">
+<head>
+<meta charset="utf-8"/>
+<title>%[1]s</title>
+<style>%[3]s</style>
+</head>
+<body>
+%[4]s
+</body>
+</html>
+`, xmlEscape(title), xmlEscape(language), pageStyle, body)
+}
@@ -0,0 +1,151 @@
+package offline
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testBook() *Book {
+	return &Book{
+		Id:       "urn:skillforce:course:3:abc",
+		Title:    "Go & friends",
+		Language: "ru",
+		Pages: []Page{
+			{Section: "Basics", Title: "Intro", Body: `<p>Hello<br>world<img src="files/a.png" alt="a"></p><p>unclosed`},
+			{Section: "Basics", Title: "Types", Body: `<pre><code class="language-go">a &lt; b</code></pre>`},
+			{Section: "Advanced", Title: "Video", Body: `<video controls src="videos/lesson-11.mp4"></video>`},
+		},
+		Resources: []Resource{
+			{Name: "files/a.png", ContentType: "image/png", Open: func() (io.ReadCloser, error) {
+				return io.NopCloser(strings.NewReader("png")), nil
+			}},
+		},
+	}
+}
+
+func readZip(t *testing.T, data []byte) (*zip.Reader, map[string]string) {
+	t.Helper()
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := make(map[string]string, len(reader.File))
+	for _, file := range reader.File {
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[file.Name] = string(content)
+	}
+	return reader, files
+}
+
+func TestWriteZip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteZip(&buf, testBook()); err != nil {
+		t.Fatal(err)
+	}
+
+	_, files := readZip(t, buf.Bytes())
+	for _, name := range []string{"index.html", "lesson-001.html", "lesson-002.html", "lesson-003.html", "files/a.png"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("missing %s", name)
+		}
+	}
+	if !strings.Contains(files["index.html"], `<h2>Basics</h2>`) || !strings.Contains(files["index.html"], `<a href="lesson-003.html">Video</a>`) {
+		t.Errorf("unexpected index: %s", files["index.html"])
+	}
+	if !strings.Contains(files["index.html"], "Go &amp; friends") {
+		t.Errorf("title is not escaped: %s", files["index.html"])
+	}
+	if files["files/a.png"] != "png" {
+		t.Errorf("unexpected resource %q", files["files/a.png"])
+	}
+}
+
+func TestWriteEPUB(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteEPUB(&buf, testBook(), time.Unix(1_700_000_000, 0)); err != nil {
+		t.Fatal(err)
+	}
+
+	reader, files := readZip(t, buf.Bytes())
+	first := reader.File[0]
+	if first.Name != "mimetype" || first.Method != zip.Store || files["mimetype"] != "application/epub+zip" {
+		t.Fatalf("mimetype must be the first stored entry, got %s", first.Name)
+	}
+
+	for name, content := range files {
+		if !strings.HasSuffix(name, ".xhtml") && !strings.HasSuffix(name, ".opf") && !strings.HasSuffix(name, ".xml") {
+			continue
+		}
+		decoder := xml.NewDecoder(strings.NewReader(content))
+		decoder.Strict = true
+		decoder.Entity = map[string]string{}
+		for {
+			_, err := decoder.Token()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatalf("%s is not well-formed: %v\n%s", name, err, content)
+			}
+		}
+	}
+
+	if !strings.Contains(files["OEBPS/content.opf"], `<item id="res-1" href="files/a.png" media-type="image/png"/>`) {
+		t.Errorf("resource is missing from manifest: %s", files["OEBPS/content.opf"])
+	}
+	if !strings.Contains(files["OEBPS/content.opf"], "2023-11-14T22:13:20Z") {
+		t.Errorf("unexpected modified date: %s", files["OEBPS/content.opf"])
+	}
+	if files["OEBPS/files/a.png"] != "png" {
+		t.Errorf("unexpected resource %q", files["OEBPS/files/a.png"])
+	}
+}
+
+func TestInvalidResourceName(t *testing.T) {
+	for _, name := range []string{"", "../a.png", "/a.png", "files/../../a.png", "files//a.png"} {
+		book := &Book{Resources: []Resource{{Name: name}}}
+		if err := WriteZip(io.Discard, book); err == nil {
+			t.Errorf("resource %q must be rejected", name)
+		}
+	}
+
+	book := &Book{Resources: []Resource{{Name: "files/a.png"}, {Name: "files/a.png"}}}
+	if err := WriteEPUB(io.Discard, book, time.Now()); err == nil {
+		t.Error("duplicate resource must be rejected")
+	}
+}
+
+func TestXHTML(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: `<p>a<br>b</p>`, want: `<p>a<br/>b</p>`},
+		{in: `<p>open`, want: `<p>open</p>`},
+		{in: `<img src="a.png" alt="x &amp; y">`, want: `<img src="a.png" alt="x &amp; y"/>`},
+		{in: `<p>1 &lt; 2 &nbsp;</p><!-- comment -->`, want: "<p>1 &lt; 2 \u00a0</p>"},
+		{in: `<input type="checkbox" checked disabled>`, want: `<input type="checkbox" checked="" disabled=""/>`},
+	}
+	for _, tt := range tests {
+		got, err := XHTML(tt.in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("XHTML(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
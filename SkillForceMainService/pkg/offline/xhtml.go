@@ -0,0 +1,77 @@
+package offline
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// voidElements - элементы без содержимого, в XHTML они закрываются сразу
+var voidElements = map[string]bool{
+	"area": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+	"input": true, "link": true, "meta": true, "source": true, "track": true, "wbr": true,
+}
+
+var xmlReplacer = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;", "'", "&#39;")
+
+func xmlEscape(s string) string {
+	return xmlReplacer.Replace(s)
+}
+
+// XHTML - HTML фрагмент в виде правильно сформированного XML: незакрытые теги закрываются,
+// пустые элементы записываются как <br/>, комментарии отбрасываются
+func XHTML(fragment string) (string, error) {
+	nodes, err := html.ParseFragment(strings.NewReader(fragment), &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body})
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	for _, node := range nodes {
+		writeXHTML(&b, node)
+	}
+	return b.String(), nil
+}
+
+func writeXHTML(b *strings.Builder, node *html.Node) {
+	switch node.Type {
+	case html.TextNode:
+		b.WriteString(xmlEscape(node.Data))
+	case html.ElementNode:
+		b.WriteString("<" + node.Data)
+		seen := make(map[string]bool, len(node.Attr))
+		for _, attr := range node.Attr {
+			// в XML атрибут не может повторяться, а пространства имён из HTML не нужны
+			if attr.Namespace != "" || seen[attr.Key] || !validAttrName(attr.Key) {
+				continue
+			}
+			seen[attr.Key] = true
+			b.WriteString(" " + attr.Key + `="` + xmlEscape(attr.Val) + `"`)
+		}
+		if voidElements[node.Data] {
+			b.WriteString("/>")
+			return
+		}
+		b.WriteString(">")
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			writeXHTML(b, child)
+		}
+		b.WriteString("</" + node.Data + ">")
+	}
+}
+
+func validAttrName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r == '_':
+		case i > 0 && (r >= '0' && r <= '9' || r == '-' || r == '.'):
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,56 @@
+package storagegc
+
+import "github.com/prometheus/client_golang/prometheus"
+
+var (
+	ReclaimedBytesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "storage_gc_reclaimed_bytes_total",
+			Help: "Размер удалённых объектов без ссылок",
+		},
+		[]string{"bucket"},
+	)
+
+	DeletedObjectsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "storage_gc_deleted_objects_total",
+			Help: "Количество удалённых объектов без ссылок",
+		},
+		[]string{"bucket"},
+	)
+
+	OrphanBytes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "storage_gc_orphan_bytes",
+			Help: "Размер объектов без ссылок при последней проверке, в том числе в режиме dry run",
+		},
+		[]string{"bucket"},
+	)
+
+	OrphanObjects = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "storage_gc_orphan_objects",
+			Help: "Количество объектов без ссылок при последней проверке",
+		},
+		[]string{"bucket"},
+	)
+
+	LastRunTimestamp = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "storage_gc_last_run_timestamp_seconds",
+			Help: "Время последней проверки бакетов",
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(ReclaimedBytesTotal, DeletedObjectsTotal, OrphanBytes, OrphanObjects, LastRunTimestamp)
+}
+
+// Observe - метрики по итогам проверки бакета
+func Observe(report Report) {
+	OrphanBytes.WithLabelValues(report.Bucket).Set(float64(report.OrphanBytes))
+	OrphanObjects.WithLabelValues(report.Bucket).Set(float64(report.Orphans))
+	ReclaimedBytesTotal.WithLabelValues(report.Bucket).Add(float64(report.ReclaimedBytes))
+	DeletedObjectsTotal.WithLabelValues(report.Bucket).Add(float64(report.Deleted))
+}
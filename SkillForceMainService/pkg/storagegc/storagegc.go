@@ -0,0 +1,126 @@
+package storagegc
+
+import (
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Бакеты, которые проверяет сборщик. Настоящие имена бакетов задаются в конфиге
+const (
+	Avatars      = "avatars"
+	Videos       = "videos"
+	Sertificates = "sertificates"
+	LessonFiles  = "lesson-files"
+	Bundles      = "bundles"
+	// Exports - архивы выгрузки данных user-service. Ссылок из базы на них нет, архив удаляется,
+	// когда истекает ссылка на него в письме
+	Exports = "exports"
+)
+
+var Kinds = []string{Avatars, Videos, Sertificates, LessonFiles, Bundles, Exports}
+
+// Object - объект в бакете
+type Object struct {
+	Name         string
+	Size         int64
+	LastModified time.Time
+}
+
+// References - ссылки из базы на объекты одного бакета
+type References struct {
+	// Bucket - имя бакета, по нему из адресов вида .../<бакет>/<объект> достаются имена объектов
+	Bucket string
+	// Texts - адреса и HTML, в котором такие адреса встречаются
+	Texts []string
+	// Objects - имена объектов
+	Objects []string
+	// Prefixes - папки, которые используются целиком, например HLS версия видео
+	Prefixes []string
+	// KeepFolders - ссылка на объект в папке сохраняет всю папку. Так хранятся миниатюры аватарок:
+	// в базе ссылка на самую большую, остальные лежат рядом
+	KeepFolders bool
+}
+
+// Report - итог проверки одного бакета
+type Report struct {
+	Bucket         string
+	DryRun         bool
+	Objects        int
+	Bytes          int64
+	Orphans        int
+	OrphanBytes    int64
+	Deleted        int
+	ReclaimedBytes int64
+}
+
+// referenced - имена объектов и папки, на которые ссылается база
+func (refs *References) referenced() (map[string]struct{}, []string) {
+	names := make(map[string]struct{}, len(refs.Objects)+len(refs.Texts))
+	for _, name := range refs.Objects {
+		if name != "" {
+			names[name] = struct{}{}
+		}
+	}
+
+	if refs.Bucket != "" {
+		pattern := regexp.MustCompile(`/` + regexp.QuoteMeta(refs.Bucket) + `/([^\s"'<>()?#&\\]+)`)
+		for _, text := range refs.Texts {
+			for _, match := range pattern.FindAllStringSubmatch(text, -1) {
+				name, err := url.PathUnescape(match[1])
+				if err != nil {
+					name = match[1]
+				}
+				names[name] = struct{}{}
+			}
+		}
+	}
+
+	prefixes := make([]string, 0, len(refs.Prefixes))
+	for _, prefix := range refs.Prefixes {
+		// пустой префикс совпал бы со всем бакетом
+		if prefix = strings.Trim(prefix, "/"); prefix != "" {
+			prefixes = append(prefixes, prefix+"/")
+		}
+	}
+	if refs.KeepFolders {
+		for name := range names {
+			if dir := path.Dir(name); dir != "." && dir != "/" {
+				prefixes = append(prefixes, dir+"/")
+			}
+		}
+	}
+	return names, prefixes
+}
+
+// Orphans - объекты, на которые нет ссылок. Объекты моложе grace не трогаются: файл загружается
+// в бакет раньше, чем ссылка на него сохраняется в базе
+func Orphans(objects []Object, refs *References, grace time.Duration, now time.Time) []Object {
+	names, prefixes := refs.referenced()
+
+	var orphans []Object
+	for _, object := range objects {
+		if now.Sub(object.LastModified) < grace {
+			continue
+		}
+		if _, ok := names[object.Name]; ok {
+			continue
+		}
+		if hasAnyPrefix(object.Name, prefixes) {
+			continue
+		}
+		orphans = append(orphans, object)
+	}
+	return orphans
+}
+
+func hasAnyPrefix(name string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
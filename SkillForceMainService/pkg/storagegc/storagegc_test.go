@@ -0,0 +1,105 @@
+package storagegc
+
+import (
+	"sort"
+	"testing"
+	"time"
+)
+
+func names(objects []Object) []string {
+	result := make([]string, 0, len(objects))
+	for _, object := range objects {
+		result = append(result, object.Name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestOrphans(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-48 * time.Hour)
+
+	tests := []struct {
+		name    string
+		objects []string
+		refs    *References
+		want    []string
+	}{
+		{
+			name:    "urls",
+			objects: []string{"a.png", "b.png", "c d.png", "default_avatar.png"},
+			refs: &References{
+				Bucket:  "avatars",
+				Texts:   []string{"https://skill-force.ru/avatars/a.png", `<p><img src="http://217.16.21.64:8006/avatars/c%20d.png?x=1"></p>`},
+				Objects: []string{"default_avatar.png"},
+			},
+			want: []string{"b.png"},
+		},
+		{
+			name:    "other bucket",
+			objects: []string{"a.png"},
+			refs:    &References{Bucket: "avatars", Texts: []string{"https://skill-force.ru/videos/a.png"}},
+			want:    []string{"a.png"},
+		},
+		{
+			name:    "thumbnail folders",
+			objects: []string{"u1/64.jpg", "u1/256.jpg", "u2/64.jpg", "u2/256.jpg"},
+			refs:    &References{Bucket: "avatars", Texts: []string{"https://skill-force.ru/avatars/u1/256.jpg"}, KeepFolders: true},
+			want:    []string{"u2/256.jpg", "u2/64.jpg"},
+		},
+		{
+			name:    "folders are not kept by default",
+			objects: []string{"1/a.png", "1/b.png"},
+			refs:    &References{Objects: []string{"1/a.png"}},
+			want:    []string{"1/b.png"},
+		},
+		{
+			name:    "hls prefixes",
+			objects: []string{"src.mp4", "hls/1/new/master.m3u8", "hls/1/new/360p/seg_0.ts", "hls/1/old/master.m3u8", "hls/1/newer/master.m3u8"},
+			refs:    &References{Bucket: "videos", Objects: []string{"src.mp4"}, Prefixes: []string{"hls/1/new", ""}},
+			want:    []string{"hls/1/newer/master.m3u8", "hls/1/old/master.m3u8"},
+		},
+		{
+			name:    "url boundaries",
+			objects: []string{"f.png", "g.png", "h.png"},
+			refs:    &References{Bucket: "avatars", Texts: []string{`{"html":"\u003cimg src=\"/avatars/f.png\"\u003e"}`, `<a href="/avatars/g.png&amp;x">`, "(/avatars/h.png)"}},
+			want:    []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objects := make([]Object, 0, len(tt.objects))
+			for _, name := range tt.objects {
+				objects = append(objects, Object{Name: name, Size: 10, LastModified: old})
+			}
+			if got := names(Orphans(objects, tt.refs, 24*time.Hour, now)); !equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrphansGracePeriod(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	objects := []Object{
+		{Name: "fresh.png", LastModified: now.Add(-time.Hour)},
+		{Name: "old.png", LastModified: now.Add(-25 * time.Hour)},
+	}
+
+	if got := names(Orphans(objects, &References{}, 24*time.Hour, now)); !equal(got, []string{"old.png"}) {
+		t.Errorf("got %v, want [old.png]", got)
+	}
+}
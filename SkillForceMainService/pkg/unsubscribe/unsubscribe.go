@@ -0,0 +1,64 @@
+package unsubscribe
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidToken - токен повреждён или подписан другим секретом
+var ErrInvalidToken = errors.New("invalid unsubscribe token")
+
+// Signer - выпуск и проверка токенов ссылки отписки от категории писем. У токена нет срока действия:
+// ссылка из старого письма должна работать. Одинаковые копии пакета есть в mail-service, который
+// выпускает токены, и main-service, который их проверяет
+type Signer struct {
+	secret []byte
+}
+
+func NewSigner(secret string) *Signer {
+	return &Signer{secret: []byte(secret)}
+}
+
+// Token - "<id пользователя>.<категория>.<подпись>"
+func (s *Signer) Token(userId int, category string) string {
+	return fmt.Sprintf("%d.%s.%s", userId, category, s.signature(userId, category))
+}
+
+// Parse - id пользователя и категория из проверенного токена
+func (s *Signer) Parse(token string) (int, string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[1] == "" {
+		return 0, "", ErrInvalidToken
+	}
+	userId, err := strconv.Atoi(parts[0])
+	if err != nil || userId <= 0 {
+		return 0, "", ErrInvalidToken
+	}
+
+	expected, err := hex.DecodeString(s.signature(userId, parts[1]))
+	if err != nil {
+		return 0, "", err
+	}
+	actual, err := hex.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(expected, actual) {
+		return 0, "", ErrInvalidToken
+	}
+	return userId, parts[1], nil
+}
+
+// URL - ссылка отписки на сайте baseUrl
+func (s *Signer) URL(baseUrl string, userId int, category string) string {
+	return strings.TrimRight(baseUrl, "/") + "/api/unsubscribe?token=" + url.QueryEscape(s.Token(userId, category))
+}
+
+func (s *Signer) signature(userId int, category string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(fmt.Sprintf("unsubscribe:%d:%s", userId, category)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
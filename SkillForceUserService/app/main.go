@@ -1,11 +1,16 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net"
 	"skillForce/config"
+	"skillForce/pkg/auth"
+	"skillForce/pkg/avatar"
 	"skillForce/pkg/logs"
 	"skillForce/pkg/metrics"
+	"time"
+	_ "time/tzdata"
 
 	userGrpcHandler "skillForce/internal/delivery/grpc/handler"
 	userpb "skillForce/internal/delivery/grpc/proto"
@@ -27,6 +32,13 @@ func main() {
 
 	userUsecase := userUsecase.NewUserUsecase(infrastructure)
 
+	// анонимизация аккаунтов, срок отмены удаления которых истёк
+	go userUsecase.RunAccountPurger(context.Background(), time.Hour)
+	// отправка писем и других событий из outbox_events в Kafka
+	go infrastructure.OutboxRelay.Run(context.Background())
+	// уведомления в приложении по событиям писем
+	go infrastructure.NotificationConsumer.Run(context.Background(), "HandleNotificationEvent", userUsecase.HandleNotificationEvent)
+
 	metrics.Init(":9081")
 
 	lis, err := net.Listen("tcp", ":8081")
@@ -34,11 +46,27 @@ func main() {
 		log.Fatalf("failed to listen: %v", err)
 	}
 
+	creds, err := auth.ServerCredentials(auth.TLSFiles{
+		CaFile:   config.Tls.CaFile,
+		CertFile: config.Tls.CertFile,
+		KeyFile:  config.Tls.KeyFile,
+	})
+	if err != nil {
+		log.Fatalf("failed to load tls credentials: %v", err)
+	}
+	// вызывать сервис может только MainService
+	verifier := auth.NewTokenVerifier(config.Secrets.ServiceTokenSecret, auth.ServiceUser, auth.ServiceMain)
+
 	grpcServer := grpc.NewServer(
+		grpc.Creds(creds),
+		// аватарка до 10 МБ приходит одним сообщением, стандартный лимит 4 МБ
+		grpc.MaxRecvMsgSize(avatar.MaxFileSize+1<<20),
 		grpc.UnaryInterceptor(
 			grpc_middleware.ChainUnaryServer(
 				logs.GRPCLoggerInterceptor(),
+				logs.CorrelationInterceptor(),
 				grpc_prometheus.UnaryServerInterceptor,
+				auth.UnaryServerInterceptor(verifier, userGrpcHandler.MethodPermissions),
 			),
 		),
 	)
@@ -3,6 +3,7 @@ package config
 import (
 	"log"
 	"os"
+	"time"
 
 	"github.com/joho/godotenv"
 	"gopkg.in/yaml.v2"
@@ -23,11 +24,25 @@ type Config struct {
 		SecretAccessKey string
 		BucketName      string
 		VideoBucket     string
+		ExportBucket    string
 		UseSSL          bool
 	}
 
+	Storage struct {
+		Backend   string
+		LocalDir  string
+		PublicURL string
+	}
+
 	Secrets struct {
-		JwtSessionSecret string
+		JwtSessionSecret   string
+		ServiceTokenSecret string
+	}
+
+	Tls struct {
+		CaFile   string
+		CertFile string
+		KeyFile  string
 	}
 
 	Mail struct {
@@ -36,6 +51,16 @@ type Config struct {
 		Host     string
 		Port     string
 	}
+
+	Kafka struct {
+		Brokers string
+	}
+
+	Outbox struct {
+		Interval  time.Duration
+		BatchSize int
+		Retention time.Duration
+	}
 }
 
 type yamlConfig struct {
@@ -46,11 +71,34 @@ type yamlConfig struct {
 	} `yaml:"database"`
 
 	Minio struct {
-		Endpoint    string `yaml:"endpoint"`
-		BucketName  string `yaml:"bucket_name"`
-		VideoBucket string `yaml:"video_bucket_name"`
-		UseSSL      bool   `yaml:"use_ssl"`
+		Endpoint     string `yaml:"endpoint"`
+		BucketName   string `yaml:"bucket_name"`
+		VideoBucket  string `yaml:"video_bucket_name"`
+		ExportBucket string `yaml:"export_bucket_name"`
+		UseSSL       bool   `yaml:"use_ssl"`
 	} `yaml:"minio"`
+
+	Storage struct {
+		Backend   string `yaml:"backend"`
+		LocalDir  string `yaml:"local_dir"`
+		PublicURL string `yaml:"public_url"`
+	} `yaml:"storage"`
+
+	Tls struct {
+		CaFile   string `yaml:"ca_file"`
+		CertFile string `yaml:"cert_file"`
+		KeyFile  string `yaml:"key_file"`
+	} `yaml:"tls"`
+
+	Kafka struct {
+		Brokers string `yaml:"brokers"`
+	} `yaml:"kafka"`
+
+	Outbox struct {
+		Interval  time.Duration `yaml:"interval"`
+		BatchSize int           `yaml:"batch_size"`
+		Retention time.Duration `yaml:"retention"`
+	} `yaml:"outbox"`
 }
 
 func LoadConfig() *Config {
@@ -90,6 +138,7 @@ func LoadConfig() *Config {
 			SecretAccessKey string
 			BucketName      string
 			VideoBucket     string
+			ExportBucket    string
 			UseSSL          bool
 		}{
 			Endpoint:        ycfg.Minio.Endpoint,
@@ -97,10 +146,33 @@ func LoadConfig() *Config {
 			SecretAccessKey: os.Getenv("MINIO_SECRET_KEY"),
 			BucketName:      ycfg.Minio.BucketName,
 			VideoBucket:     ycfg.Minio.VideoBucket,
+			ExportBucket:    ycfg.Minio.ExportBucket,
 			UseSSL:          ycfg.Minio.UseSSL,
 		},
-		Secrets: struct{ JwtSessionSecret string }{
-			JwtSessionSecret: os.Getenv("JWT_SESSION_SECRET"),
+		Storage: struct {
+			Backend   string
+			LocalDir  string
+			PublicURL string
+		}{
+			Backend:   ycfg.Storage.Backend,
+			LocalDir:  ycfg.Storage.LocalDir,
+			PublicURL: ycfg.Storage.PublicURL,
+		},
+		Secrets: struct {
+			JwtSessionSecret   string
+			ServiceTokenSecret string
+		}{
+			JwtSessionSecret:   os.Getenv("JWT_SESSION_SECRET"),
+			ServiceTokenSecret: requireEnv("SERVICE_TOKEN_SECRET"),
+		},
+		Tls: struct {
+			CaFile   string
+			CertFile string
+			KeyFile  string
+		}{
+			CaFile:   ycfg.Tls.CaFile,
+			CertFile: ycfg.Tls.CertFile,
+			KeyFile:  ycfg.Tls.KeyFile,
 		},
 		Mail: struct {
 			From     string
@@ -113,5 +185,27 @@ func LoadConfig() *Config {
 			Host:     os.Getenv("MAIL_HOST"),
 			Port:     os.Getenv("MAIL_PORT"),
 		},
+		Kafka: struct{ Brokers string }{
+			Brokers: ycfg.Kafka.Brokers,
+		},
+		Outbox: struct {
+			Interval  time.Duration
+			BatchSize int
+			Retention time.Duration
+		}{
+			Interval:  ycfg.Outbox.Interval,
+			BatchSize: ycfg.Outbox.BatchSize,
+			Retention: ycfg.Outbox.Retention,
+		},
+	}
+}
+
+// requireEnv - секрет из .env. Пустой секрет не допускается: HMAC подпись с пустым ключом
+// может подделать кто угодно
+func requireEnv(name string) string {
+	value := os.Getenv(name)
+	if value == "" {
+		log.Fatalf("не задана переменная окружения %s", name)
 	}
+	return value
 }
@@ -10,6 +10,7 @@ import (
 	userpb "skillForce/internal/delivery/grpc/proto"
 	models "skillForce/internal/models/user"
 	"skillForce/pkg/logs"
+	"time"
 
 	"google.golang.org/protobuf/types/known/emptypb"
 )
@@ -17,12 +18,26 @@ import (
 type UserUsecaseInterface interface {
 	RegisterUser(ctx context.Context, token string) (string, error)
 	ValidUser(ctx context.Context, user *models.User) error
+	ResendConfirmation(ctx context.Context, email string) error
 	AuthenticateUser(ctx context.Context, user *models.User) (string, error)
 	LogoutUser(ctx context.Context, userId int) error
 	UpdateProfile(ctx context.Context, userId int, userProfile *models.UserProfile) error
 	UploadFile(ctx context.Context, file multipart.File, fileHeader *multipart.FileHeader) (string, error)
 	SaveProfilePhoto(ctx context.Context, url string, userId int) (string, error)
 	DeleteProfilePhoto(ctx context.Context, userId int) error
+	GetPublicProfile(ctx context.Context, userId int) (*models.UserProfile, error)
+	GetUserRoles(ctx context.Context, userId int) ([]string, error)
+	GrantRole(ctx context.Context, userId int, role string, grantedBy int) error
+	RevokeRole(ctx context.Context, userId int, role string, revokedBy int) error
+	RequestDataExport(ctx context.Context, userId int, files []*models.DataFile) error
+	DeleteAccount(ctx context.Context, userId int) (time.Time, error)
+	CancelAccountDeletion(ctx context.Context, userId int) error
+	GetNotificationPreferences(ctx context.Context, userId int) ([]*models.NotificationPreference, error)
+	UpdateNotificationPreferences(ctx context.Context, userId int, prefs []*models.NotificationPreference) ([]*models.NotificationPreference, error)
+	Unsubscribe(ctx context.Context, userId int, category string) error
+	ListNotifications(ctx context.Context, userId int, beforeId int64, afterId int64, limit int) ([]*models.Notification, int, error)
+	MarkNotificationsRead(ctx context.Context, userId int, ids []int64, all bool) (int, error)
+	GetUnreadNotificationCount(ctx context.Context, userId int) (int, error)
 }
 
 type UserHandler struct {
@@ -57,6 +72,15 @@ func (h *UserHandler) ValidUser(ctx context.Context, req *userpb.User) (*emptypb
 	return &emptypb.Empty{}, nil
 }
 
+// ResendConfirmation sends a fresh email confirmation link
+func (h *UserHandler) ResendConfirmation(ctx context.Context, req *userpb.ResendConfirmationRequest) (*emptypb.Empty, error) {
+	err := h.usecase.ResendConfirmation(ctx, req.Email)
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
 // AuthenticateUser handles login
 func (h *UserHandler) AuthenticateUser(ctx context.Context, req *userpb.User) (*userpb.AuthenticateResponse, error) {
 	user := models.User{
@@ -74,13 +98,15 @@ func (h *UserHandler) AuthenticateUser(ctx context.Context, req *userpb.User) (*
 // UpdateProfile handles profile update
 func (h *UserHandler) UpdateProfile(ctx context.Context, req *userpb.UpdateProfileRequest) (*emptypb.Empty, error) {
 	userProfile := models.UserProfile{
-		Id:        int(req.UserId),
+		Id:        userId(ctx),
 		Email:     req.Profile.Email,
 		Name:      req.Profile.Name,
 		Bio:       req.Profile.Bio,
 		HideEmail: req.Profile.HideEmail,
+		Locale:    req.Profile.Locale,
+		Timezone:  req.Profile.Timezone,
 	}
-	err := h.usecase.UpdateProfile(ctx, int(req.UserId), &userProfile)
+	err := h.usecase.UpdateProfile(ctx, userId(ctx), &userProfile)
 	if err != nil {
 		return nil, err
 	}
@@ -102,7 +128,7 @@ func (h *UserHandler) UploadFile(ctx context.Context, req *userpb.UploadFileRequ
 
 // SaveProfilePhoto saves uploaded profile photo URL
 func (h *UserHandler) SaveProfilePhoto(ctx context.Context, req *userpb.SaveProfilePhotoRequest) (*userpb.SaveProfilePhotoResponse, error) {
-	newURL, err := h.usecase.SaveProfilePhoto(ctx, req.Url, int(req.UserId))
+	newURL, err := h.usecase.SaveProfilePhoto(ctx, req.Url, userId(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -111,13 +137,170 @@ func (h *UserHandler) SaveProfilePhoto(ctx context.Context, req *userpb.SaveProf
 
 // DeleteProfilePhoto removes profile photo
 func (h *UserHandler) DeleteProfilePhoto(ctx context.Context, req *userpb.DeleteProfilePhotoRequest) (*emptypb.Empty, error) {
-	err := h.usecase.DeleteProfilePhoto(ctx, int(req.UserId))
+	err := h.usecase.DeleteProfilePhoto(ctx, userId(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// GetPublicProfile returns profile of any user, email is empty if the user hides it
+func (h *UserHandler) GetPublicProfile(ctx context.Context, req *userpb.GetPublicProfileRequest) (*userpb.UserProfile, error) {
+	userProfile, err := h.usecase.GetPublicProfile(ctx, int(req.UserId))
+	if err != nil {
+		return nil, err
+	}
+	return &userpb.UserProfile{
+		Id:        int32(userProfile.Id),
+		Name:      userProfile.Name,
+		Email:     userProfile.Email,
+		Bio:       userProfile.Bio,
+		AvatarSrc: userProfile.AvatarSrc,
+		HideEmail: userProfile.HideEmail,
+		IsAdmin:   userProfile.IsAdmin,
+		Roles:     userProfile.Roles,
+	}, nil
+}
+
+// GetUserRoles returns roles granted to the user
+func (h *UserHandler) GetUserRoles(ctx context.Context, req *userpb.GetUserRolesRequest) (*userpb.GetUserRolesResponse, error) {
+	roles, err := h.usecase.GetUserRoles(ctx, int(req.UserId))
+	if err != nil {
+		return nil, err
+	}
+	return &userpb.GetUserRolesResponse{Roles: roles}, nil
+}
+
+// GrantRole grants role to the user on behalf of the caller
+func (h *UserHandler) GrantRole(ctx context.Context, req *userpb.RoleRequest) (*emptypb.Empty, error) {
+	err := h.usecase.GrantRole(ctx, int(req.UserId), req.Role, userId(ctx))
 	if err != nil {
 		return nil, err
 	}
 	return &emptypb.Empty{}, nil
 }
 
+// RevokeRole revokes role from the user on behalf of the caller
+func (h *UserHandler) RevokeRole(ctx context.Context, req *userpb.RoleRequest) (*emptypb.Empty, error) {
+	err := h.usecase.RevokeRole(ctx, int(req.UserId), req.Role, userId(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// RequestDataExport collects user data with files of other services and mails a download link
+func (h *UserHandler) RequestDataExport(ctx context.Context, req *userpb.RequestDataExportRequest) (*emptypb.Empty, error) {
+	files := make([]*models.DataFile, 0, len(req.Files))
+	for _, file := range req.Files {
+		files = append(files, &models.DataFile{Name: file.Name, Content: file.Content})
+	}
+
+	err := h.usecase.RequestDataExport(ctx, userId(ctx), files)
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// DeleteAccount schedules deletion of the caller's account
+func (h *UserHandler) DeleteAccount(ctx context.Context, req *userpb.DeleteAccountRequest) (*userpb.DeleteAccountResponse, error) {
+	deleteAfter, err := h.usecase.DeleteAccount(ctx, userId(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return &userpb.DeleteAccountResponse{DeleteAfter: deleteAfter.Unix()}, nil
+}
+
+// CancelAccountDeletion cancels scheduled deletion of the caller's account
+func (h *UserHandler) CancelAccountDeletion(ctx context.Context, req *userpb.DeleteAccountRequest) (*emptypb.Empty, error) {
+	err := h.usecase.CancelAccountDeletion(ctx, userId(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// GetNotificationPreferences returns the caller's mail settings by category
+func (h *UserHandler) GetNotificationPreferences(ctx context.Context, req *userpb.GetNotificationPreferencesRequest) (*userpb.NotificationPreferences, error) {
+	prefs, err := h.usecase.GetNotificationPreferences(ctx, userId(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return toNotificationPreferencesPb(prefs), nil
+}
+
+// UpdateNotificationPreferences changes the caller's mail settings and returns all of them
+func (h *UserHandler) UpdateNotificationPreferences(ctx context.Context, req *userpb.NotificationPreferences) (*userpb.NotificationPreferences, error) {
+	prefs := make([]*models.NotificationPreference, 0, len(req.Preferences))
+	for _, pref := range req.Preferences {
+		prefs = append(prefs, &models.NotificationPreference{Category: pref.Category, Enabled: pref.Enabled})
+	}
+
+	updated, err := h.usecase.UpdateNotificationPreferences(ctx, userId(ctx), prefs)
+	if err != nil {
+		return nil, err
+	}
+	return toNotificationPreferencesPb(updated), nil
+}
+
+// Unsubscribe disables a mail category of the user from a verified unsubscribe link
+func (h *UserHandler) Unsubscribe(ctx context.Context, req *userpb.UnsubscribeRequest) (*emptypb.Empty, error) {
+	err := h.usecase.Unsubscribe(ctx, int(req.UserId), req.Category)
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ListNotifications returns a page of the caller's in-app notifications and the unread count
+func (h *UserHandler) ListNotifications(ctx context.Context, req *userpb.ListNotificationsRequest) (*userpb.ListNotificationsResponse, error) {
+	notifications, unread, err := h.usecase.ListNotifications(ctx, userId(ctx), req.BeforeId, req.AfterId, int(req.Limit))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &userpb.ListNotificationsResponse{UnreadCount: int32(unread)}
+	for _, notification := range notifications {
+		resp.Notifications = append(resp.Notifications, &userpb.Notification{
+			Id:         notification.Id,
+			Kind:       notification.Kind,
+			CourseId:   int32(notification.CourseId),
+			CourseName: notification.CourseName,
+			Url:        notification.Url,
+			CreatedAt:  notification.CreatedAt.Unix(),
+			IsRead:     notification.IsRead,
+		})
+	}
+	return resp, nil
+}
+
+// MarkNotificationsRead marks the caller's notifications as read and returns the unread count
+func (h *UserHandler) MarkNotificationsRead(ctx context.Context, req *userpb.MarkNotificationsReadRequest) (*userpb.UnreadNotificationCount, error) {
+	unread, err := h.usecase.MarkNotificationsRead(ctx, userId(ctx), req.Ids, req.All)
+	if err != nil {
+		return nil, err
+	}
+	return &userpb.UnreadNotificationCount{UnreadCount: int32(unread)}, nil
+}
+
+// GetUnreadNotificationCount returns the number of the caller's unread notifications
+func (h *UserHandler) GetUnreadNotificationCount(ctx context.Context, req *userpb.GetUnreadNotificationCountRequest) (*userpb.UnreadNotificationCount, error) {
+	unread, err := h.usecase.GetUnreadNotificationCount(ctx, userId(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return &userpb.UnreadNotificationCount{UnreadCount: int32(unread)}, nil
+}
+
+func toNotificationPreferencesPb(prefs []*models.NotificationPreference) *userpb.NotificationPreferences {
+	resp := &userpb.NotificationPreferences{}
+	for _, pref := range prefs {
+		resp.Preferences = append(resp.Preferences, &userpb.NotificationPreference{Category: pref.Category, Enabled: pref.Enabled})
+	}
+	return resp
+}
+
 func ConvertToMultipart(fileData []byte, fileName, contentType string) (multipart.File, *multipart.FileHeader, error) {
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
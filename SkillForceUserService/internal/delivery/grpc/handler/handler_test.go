@@ -6,9 +6,11 @@ import (
 	"io"
 	"mime/multipart"
 	"testing"
+	"time"
 
 	userpb "skillForce/internal/delivery/grpc/proto"
 	models "skillForce/internal/models/user"
+	"skillForce/pkg/auth"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -29,6 +31,11 @@ func (m *MockUserUsecase) ValidUser(ctx context.Context, user *models.User) erro
 	return args.Error(0)
 }
 
+func (m *MockUserUsecase) ResendConfirmation(ctx context.Context, email string) error {
+	args := m.Called(ctx, email)
+	return args.Error(0)
+}
+
 func (m *MockUserUsecase) AuthenticateUser(ctx context.Context, user *models.User) (string, error) {
 	args := m.Called(ctx, user)
 	return args.String(0), args.Error(1)
@@ -59,6 +66,74 @@ func (m *MockUserUsecase) DeleteProfilePhoto(ctx context.Context, userId int) er
 	return args.Error(0)
 }
 
+func (m *MockUserUsecase) GetPublicProfile(ctx context.Context, userId int) (*models.UserProfile, error) {
+	args := m.Called(ctx, userId)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.UserProfile), args.Error(1)
+}
+
+func (m *MockUserUsecase) GetUserRoles(ctx context.Context, userId int) ([]string, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockUserUsecase) GrantRole(ctx context.Context, userId int, role string, grantedBy int) error {
+	args := m.Called(ctx, userId, role, grantedBy)
+	return args.Error(0)
+}
+
+func (m *MockUserUsecase) RevokeRole(ctx context.Context, userId int, role string, revokedBy int) error {
+	args := m.Called(ctx, userId, role, revokedBy)
+	return args.Error(0)
+}
+
+func (m *MockUserUsecase) RequestDataExport(ctx context.Context, userId int, files []*models.DataFile) error {
+	args := m.Called(ctx, userId, files)
+	return args.Error(0)
+}
+
+func (m *MockUserUsecase) DeleteAccount(ctx context.Context, userId int) (time.Time, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func (m *MockUserUsecase) CancelAccountDeletion(ctx context.Context, userId int) error {
+	args := m.Called(ctx, userId)
+	return args.Error(0)
+}
+
+func (m *MockUserUsecase) GetNotificationPreferences(ctx context.Context, userId int) ([]*models.NotificationPreference, error) {
+	args := m.Called(ctx, userId)
+	return args.Get(0).([]*models.NotificationPreference), args.Error(1)
+}
+
+func (m *MockUserUsecase) UpdateNotificationPreferences(ctx context.Context, userId int, prefs []*models.NotificationPreference) ([]*models.NotificationPreference, error) {
+	args := m.Called(ctx, userId, prefs)
+	return args.Get(0).([]*models.NotificationPreference), args.Error(1)
+}
+
+func (m *MockUserUsecase) Unsubscribe(ctx context.Context, userId int, category string) error {
+	args := m.Called(ctx, userId, category)
+	return args.Error(0)
+}
+
+func (m *MockUserUsecase) ListNotifications(ctx context.Context, userId int, beforeId int64, afterId int64, limit int) ([]*models.Notification, int, error) {
+	args := m.Called(ctx, userId, beforeId, afterId, limit)
+	return args.Get(0).([]*models.Notification), args.Int(1), args.Error(2)
+}
+
+func (m *MockUserUsecase) MarkNotificationsRead(ctx context.Context, userId int, ids []int64, all bool) (int, error) {
+	args := m.Called(ctx, userId, ids, all)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockUserUsecase) GetUnreadNotificationCount(ctx context.Context, userId int) (int, error) {
+	args := m.Called(ctx, userId)
+	return args.Int(0), args.Error(1)
+}
+
 // Тест для RegisterUser
 func TestRegisterUser(t *testing.T) {
 	mockUsecase := new(MockUserUsecase)
@@ -90,6 +165,21 @@ func TestValidUser(t *testing.T) {
 	mockUsecase.AssertExpectations(t)
 }
 
+// Тест для ResendConfirmation
+func TestResendConfirmation(t *testing.T) {
+	mockUsecase := new(MockUserUsecase)
+	handler := NewUserHandler(mockUsecase)
+
+	mockUsecase.On("ResendConfirmation", mock.Anything, "john@example.com").Return(nil)
+
+	req := &userpb.ResendConfirmationRequest{Email: "john@example.com"}
+	resp, err := handler.ResendConfirmation(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, resp)
+	mockUsecase.AssertExpectations(t)
+}
+
 // Тест для AuthenticateUser
 func TestAuthenticateUser(t *testing.T) {
 	mockUsecase := new(MockUserUsecase)
@@ -132,8 +222,10 @@ func TestUpdateProfile(t *testing.T) {
 	userProfile := &models.UserProfile{Id: 1, Email: "john@example.com", Name: "John", Bio: "Hello", HideEmail: false}
 	mockUsecase.On("UpdateProfile", mock.Anything, 1, userProfile).Return(nil)
 
+	// id пользователя берется из метаданных, а не из запроса
+	ctx := auth.WithIdentity(context.Background(), &auth.Identity{UserId: 1})
 	req := &userpb.UpdateProfileRequest{
-		UserId: 1,
+		UserId: 42,
 		Profile: &userpb.UserProfile{
 			Email:     "john@example.com",
 			Name:      "John",
@@ -141,7 +233,7 @@ func TestUpdateProfile(t *testing.T) {
 			HideEmail: false,
 		},
 	}
-	resp, err := handler.UpdateProfile(context.Background(), req)
+	resp, err := handler.UpdateProfile(ctx, req)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
@@ -177,11 +269,12 @@ func TestSaveProfilePhoto(t *testing.T) {
 
 	mockUsecase.On("SaveProfilePhoto", mock.Anything, "http://example.com/photo.jpg", 1).Return("http://example.com/photo.jpg", nil)
 
+	ctx := auth.WithIdentity(context.Background(), &auth.Identity{UserId: 1})
 	req := &userpb.SaveProfilePhotoRequest{
 		Url:    "http://example.com/photo.jpg",
 		UserId: 1,
 	}
-	resp, err := handler.SaveProfilePhoto(context.Background(), req)
+	resp, err := handler.SaveProfilePhoto(ctx, req)
 
 	assert.NoError(t, err)
 	assert.Equal(t, "http://example.com/photo.jpg", resp.NewPhtotoUrl)
@@ -195,10 +288,164 @@ func TestDeleteProfilePhoto(t *testing.T) {
 
 	mockUsecase.On("DeleteProfilePhoto", mock.Anything, 1).Return(nil)
 
+	ctx := auth.WithIdentity(context.Background(), &auth.Identity{UserId: 1})
 	req := &userpb.DeleteProfilePhotoRequest{UserId: 1}
-	resp, err := handler.DeleteProfilePhoto(context.Background(), req)
+	resp, err := handler.DeleteProfilePhoto(ctx, req)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 	mockUsecase.AssertExpectations(t)
 }
+
+// Тест для GrantRole
+func TestGrantRole(t *testing.T) {
+	mockUsecase := new(MockUserUsecase)
+	handler := NewUserHandler(mockUsecase)
+
+	mockUsecase.On("GrantRole", mock.Anything, 2, "author", 1).Return(nil)
+
+	ctx := auth.WithIdentity(context.Background(), &auth.Identity{UserId: 1, Roles: []string{"admin"}})
+	req := &userpb.RoleRequest{UserId: 2, Role: "author"}
+	resp, err := handler.GrantRole(ctx, req)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, resp)
+	mockUsecase.AssertExpectations(t)
+}
+
+// Тест для RevokeRole
+func TestRevokeRole(t *testing.T) {
+	mockUsecase := new(MockUserUsecase)
+	handler := NewUserHandler(mockUsecase)
+
+	mockUsecase.On("RevokeRole", mock.Anything, 2, "author", 1).Return(nil)
+
+	ctx := auth.WithIdentity(context.Background(), &auth.Identity{UserId: 1, Roles: []string{"admin"}})
+	req := &userpb.RoleRequest{UserId: 2, Role: "author"}
+	resp, err := handler.RevokeRole(ctx, req)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, resp)
+	mockUsecase.AssertExpectations(t)
+}
+
+// Тест для GetPublicProfile
+func TestGetPublicProfile(t *testing.T) {
+	mockUsecase := new(MockUserUsecase)
+	handler := NewUserHandler(mockUsecase)
+
+	mockUsecase.On("GetPublicProfile", mock.Anything, 3).Return(&models.UserProfile{Id: 3, Name: "Author", Bio: "Teacher", HideEmail: true, Roles: []string{"author"}}, nil)
+
+	resp, err := handler.GetPublicProfile(context.Background(), &userpb.GetPublicProfileRequest{UserId: 3})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), resp.Id)
+	assert.Empty(t, resp.Email)
+	assert.Equal(t, []string{"author"}, resp.Roles)
+	mockUsecase.AssertExpectations(t)
+}
+
+// Тест для RequestDataExport
+func TestRequestDataExport(t *testing.T) {
+	mockUsecase := new(MockUserUsecase)
+	handler := NewUserHandler(mockUsecase)
+
+	ctx := auth.WithIdentity(context.Background(), &auth.Identity{UserId: 5})
+	files := []*models.DataFile{{Name: "courses/progress.json", Content: []byte("[]")}}
+	mockUsecase.On("RequestDataExport", ctx, 5, files).Return(nil)
+
+	_, err := handler.RequestDataExport(ctx, &userpb.RequestDataExportRequest{
+		Files: []*userpb.UserDataFile{{Name: "courses/progress.json", Content: []byte("[]")}},
+	})
+
+	assert.NoError(t, err)
+	mockUsecase.AssertExpectations(t)
+}
+
+// Тест для DeleteAccount
+func TestDeleteAccount(t *testing.T) {
+	mockUsecase := new(MockUserUsecase)
+	handler := NewUserHandler(mockUsecase)
+
+	ctx := auth.WithIdentity(context.Background(), &auth.Identity{UserId: 5})
+	deleteAfter := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	mockUsecase.On("DeleteAccount", ctx, 5).Return(deleteAfter, nil)
+
+	resp, err := handler.DeleteAccount(ctx, &userpb.DeleteAccountRequest{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, deleteAfter.Unix(), resp.DeleteAfter)
+	mockUsecase.AssertExpectations(t)
+}
+
+// Тест для UpdateNotificationPreferences
+func TestUpdateNotificationPreferences(t *testing.T) {
+	mockUsecase := new(MockUserUsecase)
+	handler := NewUserHandler(mockUsecase)
+
+	ctx := auth.WithIdentity(context.Background(), &auth.Identity{UserId: 5})
+	update := []*models.NotificationPreference{{Category: "marketing", Enabled: false}}
+	mockUsecase.On("UpdateNotificationPreferences", ctx, 5, update).Return([]*models.NotificationPreference{
+		{Category: "transactional", Enabled: true},
+		{Category: "marketing", Enabled: false},
+	}, nil)
+
+	resp, err := handler.UpdateNotificationPreferences(ctx, &userpb.NotificationPreferences{
+		Preferences: []*userpb.NotificationPreference{{Category: "marketing", Enabled: false}},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, resp.Preferences, 2)
+	assert.Equal(t, "marketing", resp.Preferences[1].Category)
+	assert.False(t, resp.Preferences[1].Enabled)
+	mockUsecase.AssertExpectations(t)
+}
+
+// Тест для Unsubscribe
+func TestUnsubscribe(t *testing.T) {
+	mockUsecase := new(MockUserUsecase)
+	handler := NewUserHandler(mockUsecase)
+
+	mockUsecase.On("Unsubscribe", mock.Anything, 7, "course_progress").Return(nil)
+
+	_, err := handler.Unsubscribe(context.Background(), &userpb.UnsubscribeRequest{UserId: 7, Category: "course_progress"})
+
+	assert.NoError(t, err)
+	mockUsecase.AssertExpectations(t)
+}
+
+// Тест для ListNotifications
+func TestListNotifications(t *testing.T) {
+	mockUsecase := new(MockUserUsecase)
+	handler := NewUserHandler(mockUsecase)
+
+	ctx := auth.WithIdentity(context.Background(), &auth.Identity{UserId: 5})
+	createdAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	mockUsecase.On("ListNotifications", ctx, 5, int64(0), int64(0), 20).Return([]*models.Notification{
+		{Id: 8, UserId: 5, Kind: models.NotificationCertificateReady, CourseId: 3, CourseName: "Go", CreatedAt: createdAt},
+	}, 1, nil)
+
+	resp, err := handler.ListNotifications(ctx, &userpb.ListNotificationsRequest{Limit: 20})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), resp.UnreadCount)
+	assert.Len(t, resp.Notifications, 1)
+	assert.Equal(t, "certificate_ready", resp.Notifications[0].Kind)
+	assert.Equal(t, createdAt.Unix(), resp.Notifications[0].CreatedAt)
+	mockUsecase.AssertExpectations(t)
+}
+
+// Тест для MarkNotificationsRead
+func TestMarkNotificationsRead(t *testing.T) {
+	mockUsecase := new(MockUserUsecase)
+	handler := NewUserHandler(mockUsecase)
+
+	ctx := auth.WithIdentity(context.Background(), &auth.Identity{UserId: 5})
+	mockUsecase.On("MarkNotificationsRead", ctx, 5, []int64{8}, false).Return(0, nil)
+
+	resp, err := handler.MarkNotificationsRead(ctx, &userpb.MarkNotificationsReadRequest{Ids: []int64{8}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), resp.UnreadCount)
+	mockUsecase.AssertExpectations(t)
+}
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"context"
+	"skillForce/pkg/auth"
+)
+
+// MethodPermissions - права, необходимые для вызова методов UserService
+var MethodPermissions = map[string]auth.Permission{
+	"/user.UserService/RegisterUser":                  auth.PermPublic,
+	"/user.UserService/ValidUser":                     auth.PermPublic,
+	"/user.UserService/ResendConfirmation":            auth.PermPublic,
+	"/user.UserService/AuthenticateUser":              auth.PermPublic,
+	"/user.UserService/GetPublicProfile":              auth.PermPublic,
+	"/user.UserService/Unsubscribe":                   auth.PermPublic, // токен ссылки отписки проверяет main-service
+	"/user.UserService/UpdateProfile":                 auth.PermLearn,
+	"/user.UserService/UploadFile":                    auth.PermLearn,
+	"/user.UserService/SaveProfilePhoto":              auth.PermLearn,
+	"/user.UserService/DeleteProfilePhoto":            auth.PermLearn,
+	"/user.UserService/RequestDataExport":             auth.PermLearn,
+	"/user.UserService/DeleteAccount":                 auth.PermLearn,
+	"/user.UserService/CancelAccountDeletion":         auth.PermLearn,
+	"/user.UserService/GetNotificationPreferences":    auth.PermLearn,
+	"/user.UserService/UpdateNotificationPreferences": auth.PermLearn,
+	"/user.UserService/ListNotifications":             auth.PermLearn,
+	"/user.UserService/MarkNotificationsRead":         auth.PermLearn,
+	"/user.UserService/GetUnreadNotificationCount":    auth.PermLearn,
+	"/user.UserService/GetUserRoles":                  auth.PermManageRoles,
+	"/user.UserService/GrantRole":                     auth.PermManageRoles,
+	"/user.UserService/RevokeRole":                    auth.PermManageRoles,
+}
+
+func userId(ctx context.Context) int {
+	identity, ok := auth.IdentityFromContext(ctx)
+	if !ok {
+		return 0
+	}
+	return identity.UserId
+}
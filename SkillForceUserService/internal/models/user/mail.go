@@ -0,0 +1,7 @@
+package usermodels
+
+// MailTopic - топик, из которого письма читает mail-service. Письма описаны в pkg/events
+const MailTopic = "mail"
+
+// NotificationsGroup - группа, которой user-service читает MailTopic и создаёт по письмам уведомления в приложении
+const NotificationsGroup = "user-notifications"
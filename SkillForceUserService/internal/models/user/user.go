@@ -1,5 +1,7 @@
 package usermodels
 
+import "time"
+
 type User struct {
 	Id        int
 	Name      string
@@ -7,14 +9,77 @@ type User struct {
 	Password  string
 	Salt      []byte
 	HideEmail bool
+	Locale    string
 }
 
 type UserProfile struct {
-	Id        int
-	Name      string
-	Email     string
-	Bio       string
-	AvatarSrc string
-	HideEmail bool
-	IsAdmin   bool
+	Id        int      `json:"id"`
+	Name      string   `json:"name"`
+	Email     string   `json:"email"`
+	Bio       string   `json:"bio"`
+	AvatarSrc string   `json:"avatar_src"`
+	HideEmail bool     `json:"hide_email"`
+	IsAdmin   bool     `json:"is_admin"`
+	Roles     []string `json:"roles"`
+	Locale    string   `json:"locale"`
+	Timezone  string   `json:"timezone"`
+}
+
+type CourseRef struct {
+	CourseId int    `json:"course_id"`
+	Title    string `json:"title"`
+}
+
+type DataFile struct {
+	Name    string
+	Content []byte
+}
+
+// Языки писем, по умолчанию ru
+const (
+	LocaleRu = "ru"
+	LocaleEn = "en"
+)
+
+func IsValidLocale(locale string) bool {
+	return locale == LocaleRu || locale == LocaleEn
+}
+
+// IsValidTimezone - часовой пояс из базы IANA: Europe/Moscow, UTC. Local - пояс сервера, не пользователя
+func IsValidTimezone(timezone string) bool {
+	if timezone == "" || timezone == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(timezone)
+	return err == nil
+}
+
+// NotificationPreference - включена ли пользователю категория писем, см. events.Categories
+type NotificationPreference struct {
+	Category string
+	Enabled  bool
+}
+
+// Виды уведомлений в приложении
+const (
+	NotificationCourseSignup     = "course_signup"
+	NotificationCourseProgress   = "course_progress"
+	NotificationCertificateReady = "certificate_ready"
+	NotificationDataExportReady  = "data_export_ready"
+)
+
+// NotificationsChannel - канал LISTEN/NOTIFY, в который пишется id пользователя при изменении его уведомлений
+const NotificationsChannel = "notifications"
+
+// Notification - уведомление в приложении. EventId - событие Kafka, из которого оно создано
+type Notification struct {
+	Id         int64
+	UserId     int
+	EventId    string
+	Kind       string
+	CourseId   int
+	CourseName string
+	Url        string
+	CreatedAt  time.Time
+	IsRead     bool
 }
@@ -0,0 +1,85 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"skillForce/pkg/events"
+	"skillForce/pkg/logs"
+
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+)
+
+const pollTimeoutMs = 1000
+
+// Handler - обработка события. Офсет коммитится, только если Handler вернул nil
+type Handler func(ctx context.Context, env *events.Envelope) error
+
+// Consumer - чтение топика своей группой с ручным коммитом офсетов. Сообщение, которое не удалось
+// обработать, читается снова, поэтому Handler должен быть идемпотентным
+type Consumer struct {
+	consumer *kafka.Consumer
+}
+
+func NewKafkaConsumer(brokers string, group string, topic string) *Consumer {
+	consumer, err := kafka.NewConsumer(&kafka.ConfigMap{
+		"bootstrap.servers":  brokers,
+		"group.id":           group,
+		"auto.offset.reset":  "earliest",
+		"enable.auto.commit": false,
+	})
+	if err != nil {
+		log.Fatalf("Failed to create consumer: %s", err)
+	}
+	if err := consumer.SubscribeTopics([]string{topic}, nil); err != nil {
+		log.Fatalf("Failed to subscribe to topic: %s", err)
+	}
+
+	return &Consumer{consumer: consumer}
+}
+
+func (c *Consumer) Close() {
+	if err := c.consumer.Close(); err != nil {
+		log.Printf("Failed to close Kafka consumer: %v", err)
+	}
+}
+
+// Run - чтение сообщений до отмены контекста. Событие, не прошедшее проверку контракта, пропускается
+func (c *Consumer) Run(ctx context.Context, name string, handle Handler) {
+	for ctx.Err() == nil {
+		msg, ok := c.consumer.Poll(pollTimeoutMs).(*kafka.Message)
+		if !ok {
+			continue
+		}
+
+		var err error
+		logs.RunJob(ctx, name, func(ctx context.Context) {
+			err = c.process(ctx, name, msg, handle)
+		})
+		if err != nil {
+			// офсет не закоммичен: сообщение будет прочитано снова
+			if err := c.consumer.Seek(msg.TopicPartition, 0); err != nil {
+				log.Printf("Failed to seek %v: %v", msg.TopicPartition, err)
+			}
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Second):
+			}
+		}
+	}
+}
+
+func (c *Consumer) process(ctx context.Context, name string, msg *kafka.Message, handle Handler) error {
+	env, err := events.Unmarshal(msg.Value)
+	if err != nil {
+		logs.PrintLog(ctx, name, fmt.Sprintf("skip %v: %+v", msg.TopicPartition, err))
+	} else if err := handle(ctx, env); err != nil {
+		logs.PrintLog(ctx, name, fmt.Sprintf("event %s: %+v", env.GetId(), err))
+		return err
+	}
+
+	_, err = c.consumer.CommitMessage(msg)
+	return err
+}
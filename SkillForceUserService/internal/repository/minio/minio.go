@@ -1,47 +1,100 @@
 package minio
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
-	"mime/multipart"
-	"strings"
+	"net/url"
+	"time"
+
+	"skillForce/pkg/storage"
 
 	"github.com/google/uuid"
-	"github.com/minio/minio-go"
+)
+
+const (
+	// DataExportLinkTTL - срок действия ссылки на архив с данными пользователя
+	DataExportLinkTTL = 7 * 24 * time.Hour
+	// DefaultAvatar - аватарка пользователя без своей фотографии
+	DefaultAvatar = "default_avatar.png"
 )
 
 type Minio struct {
-	MinioClient   *minio.Client
+	Storage       storage.Storage
+	PublicURL     string
 	AvatarsBucket string
 	VideoBucket   string
+	ExportBucket  string
 }
 
-func NewMinio(endpoint string, accessKeyID string, secretAccessKey string, useSSL bool, bucketName string, videoBucket string) (*Minio, error) {
-	minioClient, err := minio.New(endpoint, accessKeyID, secretAccessKey, useSSL)
-	return &Minio{MinioClient: minioClient, AvatarsBucket: bucketName, VideoBucket: videoBucket}, err
+func NewMinio(store storage.Storage, publicURL string, bucketName string, videoBucket string, exportBucket string) *Minio {
+	return &Minio{Storage: store, PublicURL: publicURL, AvatarsBucket: bucketName, VideoBucket: videoBucket, ExportBucket: exportBucket}
 }
 
-func (mn *Minio) UploadFileToMinIO(ctx context.Context, file multipart.File, fileHeader *multipart.FileHeader) (string, error) {
+func (mn *Minio) DefaultAvatarURL() string {
+	return storage.PublicURL(mn.PublicURL, mn.AvatarsBucket, DefaultAvatar)
+}
 
-	uniqueID := uuid.New().String()
-	ext := ""
-	if fileHeader.Filename != "" {
-		ext = fileHeader.Filename[strings.LastIndex(fileHeader.Filename, "."):]
+// UploadAvatar - загрузка миниатюр аватарки в папку <uuid>/<размер>.jpg.
+// Возвращает ссылку на самую большую миниатюру
+func (mn *Minio) UploadAvatar(ctx context.Context, thumbnails map[int][]byte) (string, error) {
+	if len(thumbnails) == 0 {
+		return "", errors.New("no avatar thumbnails")
 	}
-	objectName := fmt.Sprintf("%s%s", uniqueID, ext)
-	contentType := fileHeader.Header.Get("Content-Type")
 
-	_, err := mn.MinioClient.PutObject(
-		mn.AvatarsBucket,
+	prefix := uuid.New().String()
+	largest := 0
+	for size, data := range thumbnails {
+		err := mn.Storage.Put(
+			ctx,
+			mn.AvatarsBucket,
+			fmt.Sprintf("%s/%d.jpg", prefix, size),
+			bytes.NewReader(data),
+			int64(len(data)),
+			storage.PutOptions{ContentType: "image/jpeg", CacheControl: "public, max-age=31536000, immutable"},
+		)
+		if err != nil {
+			return "", err
+		}
+		largest = max(largest, size)
+	}
+
+	return storage.PublicURL(mn.PublicURL, mn.AvatarsBucket, fmt.Sprintf("%s/%d.jpg", prefix, largest)), nil
+}
+
+// UploadDataExport - загрузка архива с данными пользователя в закрытый бакет.
+// Возвращает подписанную ссылку, которая перестаёт работать через DataExportLinkTTL
+func (mn *Minio) UploadDataExport(ctx context.Context, userId int, archive []byte) (string, error) {
+	objectName := fmt.Sprintf("%d/%s.zip", userId, uuid.New().String())
+
+	err := mn.Storage.Put(
+		ctx,
+		mn.ExportBucket,
 		objectName,
-		file,
-		fileHeader.Size,
-		minio.PutObjectOptions{ContentType: contentType},
+		bytes.NewReader(archive),
+		int64(len(archive)),
+		storage.PutOptions{ContentType: "application/zip"},
 	)
 	if err != nil {
 		return "", err
 	}
 
-	fileURL := fmt.Sprintf("https://skill-force.ru/%s/%s", mn.AvatarsBucket, objectName)
-	return fileURL, nil
+	reqParams := make(url.Values)
+	reqParams.Set("response-content-disposition", `attachment; filename="skillforce-data.zip"`)
+	return mn.Storage.Presign(ctx, mn.ExportBucket, objectName, DataExportLinkTTL, reqParams)
+}
+
+// DeleteDataExports - удаление всех архивов с данными пользователя при удалении аккаунта
+func (mn *Minio) DeleteDataExports(ctx context.Context, userId int) error {
+	objects, err := mn.Storage.List(ctx, mn.ExportBucket, fmt.Sprintf("%d/", userId))
+	if err != nil {
+		return err
+	}
+	for _, object := range objects {
+		if err := mn.Storage.Delete(ctx, mn.ExportBucket, object.Name); err != nil {
+			return err
+		}
+	}
+	return nil
 }
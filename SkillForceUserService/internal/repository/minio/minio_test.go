@@ -0,0 +1,32 @@
+package minio
+
+import (
+	"context"
+	"testing"
+
+	"skillForce/pkg/storage"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteDataExports(t *testing.T) {
+	ctx := context.Background()
+	store, err := storage.New(storage.Config{Backend: storage.BackendLocal, LocalDir: t.TempDir(), PublicURL: "https://skill-force.ru/"})
+	require.NoError(t, err)
+	mn := NewMinio(store, "https://skill-force.ru/", "avatars", "videos", "exports")
+
+	for _, userId := range []int{1, 1, 12} {
+		_, err := mn.UploadDataExport(ctx, userId, []byte("zip"))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, mn.DeleteDataExports(ctx, 1))
+
+	// архивы пользователя 12 не затронуты, хотя его id начинается с 1
+	left, err := store.List(ctx, "exports", "")
+	require.NoError(t, err)
+	require.Len(t, left, 1)
+	require.Regexp(t, `^12/`, left[0].Name)
+
+	require.NoError(t, mn.DeleteDataExports(ctx, 1))
+}
@@ -0,0 +1,185 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	usermodels "skillForce/internal/models/user"
+	"skillForce/pkg/logs"
+	"time"
+)
+
+func (d *Database) GetFavouriteCourseRefs(ctx context.Context, userId int) ([]*usermodels.CourseRef, error) {
+	return d.getCourseRefs(ctx, "GetFavouriteCourseRefs",
+		"SELECT c.id, c.title FROM FAVOURITE_COURSES f JOIN COURSE c ON c.id = f.course_id WHERE f.user_id = $1 ORDER BY c.id", userId)
+}
+
+func (d *Database) GetPurchasedCourseRefs(ctx context.Context, userId int) ([]*usermodels.CourseRef, error) {
+	return d.getCourseRefs(ctx, "GetPurchasedCourseRefs",
+		"SELECT c.id, c.title FROM SIGNUPS s JOIN COURSE c ON c.id = s.course_id WHERE s.user_id = $1 ORDER BY c.id", userId)
+}
+
+func (d *Database) getCourseRefs(ctx context.Context, funcName string, query string, userId int) ([]*usermodels.CourseRef, error) {
+	courses := make([]*usermodels.CourseRef, 0)
+	rows, err := d.conn.QueryContext(ctx, query, userId)
+	if err != nil {
+		logs.PrintLog(ctx, funcName, fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logs.PrintLog(ctx, funcName, fmt.Sprintf("%+v", err))
+		}
+	}()
+
+	for rows.Next() {
+		var course usermodels.CourseRef
+		if err := rows.Scan(&course.CourseId, &course.Title); err != nil {
+			logs.PrintLog(ctx, funcName, fmt.Sprintf("%+v", err))
+			return nil, err
+		}
+		courses = append(courses, &course)
+	}
+	return courses, rows.Err()
+}
+
+// ReserveDataExport - отметка о запросе выгрузки. Интервал проверяется в самом запросе: из одновременных
+// запросов строку обновит только один, остальные после блокировки увидят новое requested_at
+func (d *Database) ReserveDataExport(ctx context.Context, userId int) error {
+	result, err := d.conn.ExecContext(ctx, `
+		INSERT INTO data_exports (user_id) VALUES ($1)
+		ON CONFLICT (user_id) DO UPDATE SET requested_at = NOW()
+		WHERE data_exports.requested_at < NOW() - $2 * INTERVAL '1 second'`, userId, DataExportCooldown.Seconds())
+	if err != nil {
+		logs.PrintLog(ctx, "ReserveDataExport", fmt.Sprintf("%+v", err))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("export too early")
+	}
+	return nil
+}
+
+// ReleaseDataExport - снятие отметки, если выгрузку не удалось отправить. Раз отметку удалось поставить,
+// прошлая выгрузка была раньше DataExportCooldown, и без строки повторный запрос снова разрешён
+func (d *Database) ReleaseDataExport(ctx context.Context, userId int) error {
+	if _, err := d.conn.ExecContext(ctx, "DELETE FROM data_exports WHERE user_id = $1", userId); err != nil {
+		logs.PrintLog(ctx, "ReleaseDataExport", fmt.Sprintf("%+v", err))
+		return err
+	}
+	return nil
+}
+
+// ScheduleAccountDeletion - постановка аккаунта в очередь на удаление.
+// Повторный запрос не сдвигает уже назначенную дату удаления
+func (d *Database) ScheduleAccountDeletion(ctx context.Context, userId int, deleteAfter time.Time) (time.Time, error) {
+	var scheduled time.Time
+	err := d.conn.QueryRowContext(ctx, `
+		INSERT INTO account_deletions (user_id, delete_after) VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET user_id = EXCLUDED.user_id
+		RETURNING delete_after`, userId, deleteAfter).Scan(&scheduled)
+	if err != nil {
+		logs.PrintLog(ctx, "ScheduleAccountDeletion", fmt.Sprintf("%+v", err))
+		return time.Time{}, err
+	}
+
+	logs.PrintLog(ctx, "ScheduleAccountDeletion", fmt.Sprintf("account of user %d will be deleted after %v", userId, scheduled))
+	return scheduled, nil
+}
+
+// CancelAccountDeletion - отмена удаления, пока данные ещё не начали удаляться
+func (d *Database) CancelAccountDeletion(ctx context.Context, userId int) error {
+	result, err := d.conn.ExecContext(ctx,
+		"DELETE FROM account_deletions WHERE user_id = $1 AND course_purged_at IS NULL AND user_purged_at IS NULL", userId)
+	if err != nil {
+		logs.PrintLog(ctx, "CancelAccountDeletion", fmt.Sprintf("%+v", err))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("account deletion not found")
+	}
+
+	logs.PrintLog(ctx, "CancelAccountDeletion", fmt.Sprintf("cancel deletion of account of user %d", userId))
+	return nil
+}
+
+// GetAccountsToPurge - аккаунты, курсовые данные которых CourseService уже удалил
+func (d *Database) GetAccountsToPurge(ctx context.Context) ([]int, error) {
+	var userIds []int
+	rows, err := d.conn.QueryContext(ctx,
+		"SELECT user_id FROM account_deletions WHERE delete_after <= NOW() AND course_purged_at IS NOT NULL AND user_purged_at IS NULL ORDER BY delete_after")
+	if err != nil {
+		logs.PrintLog(ctx, "GetAccountsToPurge", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logs.PrintLog(ctx, "GetAccountsToPurge", fmt.Sprintf("%+v", err))
+		}
+	}()
+
+	for rows.Next() {
+		var userId int
+		if err := rows.Scan(&userId); err != nil {
+			logs.PrintLog(ctx, "GetAccountsToPurge", fmt.Sprintf("%+v", err))
+			return nil, err
+		}
+		userIds = append(userIds, userId)
+	}
+	return userIds, rows.Err()
+}
+
+// PurgeUser - анонимизация пользователя. Строка usertable остаётся, так как на неё ссылаются
+// платежи и созданные пользователем курсы
+func (d *Database) PurgeUser(ctx context.Context, userId int, defaultAvatar string) error {
+	tx, err := d.conn.BeginTx(ctx, nil)
+	if err != nil {
+		logs.PrintLog(ctx, "PurgeUser", fmt.Sprintf("failed to begin transaction: %+v", err))
+		return err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logs.PrintLog(ctx, "transaction rollback failed", fmt.Sprintf("error: %v", err))
+		}
+	}()
+
+	queries := []struct {
+		query string
+		args  []any
+	}{
+		{"DELETE FROM sessions WHERE user_id = $1", []any{userId}},
+		{"DELETE FROM FAVOURITE_COURSES WHERE user_id = $1", []any{userId}},
+		{"DELETE FROM SIGNUPS WHERE user_id = $1", []any{userId}},
+		{"DELETE FROM user_roles WHERE user_id = $1", []any{userId}},
+		{"DELETE FROM notification_preferences WHERE user_id = $1", []any{userId}},
+		{"DELETE FROM notifications WHERE user_id = $1", []any{userId}},
+		{`UPDATE usertable SET email = 'deleted-' || id || '@deleted.skill-force.ru', name = 'Удалённый пользователь', bio = '',
+			password = '', salt = '', avatar_src = $2, hide_email = TRUE WHERE id = $1`, []any{userId, defaultAvatar}},
+		{"UPDATE account_deletions SET user_purged_at = NOW() WHERE user_id = $1", []any{userId}},
+	}
+	for _, query := range queries {
+		if _, err := tx.ExecContext(ctx, query.query, query.args...); err != nil {
+			logs.PrintLog(ctx, "PurgeUser", fmt.Sprintf("%+v", err))
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		logs.PrintLog(ctx, "PurgeUser", fmt.Sprintf("failed to commit transaction: %+v", err))
+		return err
+	}
+
+	logs.PrintLog(ctx, "PurgeUser", fmt.Sprintf("anonymize user %d", userId))
+	return nil
+}
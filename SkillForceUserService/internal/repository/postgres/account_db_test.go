@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"skillForce/pkg/logs"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduleAccountDeletion(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	database := &Database{conn: db}
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	requested := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	scheduled := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO account_deletions (user_id, delete_after) VALUES ($1, $2)")).
+		WithArgs(1, requested).
+		WillReturnRows(sqlmock.NewRows([]string{"delete_after"}).AddRow(scheduled))
+
+	deleteAfter, err := database.ScheduleAccountDeletion(ctx, 1, requested)
+	require.NoError(t, err)
+	require.Equal(t, scheduled, deleteAfter)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestReserveDataExport(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	database := &Database{conn: db}
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	query := regexp.QuoteMeta("INSERT INTO data_exports (user_id) VALUES ($1)")
+	mock.ExpectExec(query).WithArgs(1, DataExportCooldown.Seconds()).WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, database.ReserveDataExport(ctx, 1))
+
+	// предыдущая выгрузка была меньше DataExportCooldown назад, строка не обновилась
+	mock.ExpectExec(query).WithArgs(1, DataExportCooldown.Seconds()).WillReturnResult(sqlmock.NewResult(0, 0))
+	require.EqualError(t, database.ReserveDataExport(ctx, 1), "export too early")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCancelAccountDeletion_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	database := &Database{conn: db}
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM account_deletions WHERE user_id = $1")).
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = database.CancelAccountDeletion(ctx, 1)
+	require.EqualError(t, err, "account deletion not found")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPurgeUser(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	database := &Database{conn: db}
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	defaultAvatar := "https://skill-force.ru/avatars/default_avatar.png"
+	mock.ExpectBegin()
+	for _, query := range []struct {
+		query string
+		args  []driver.Value
+	}{
+		{"DELETE FROM sessions WHERE user_id = $1", []driver.Value{4}},
+		{"DELETE FROM FAVOURITE_COURSES WHERE user_id = $1", []driver.Value{4}},
+		{"DELETE FROM SIGNUPS WHERE user_id = $1", []driver.Value{4}},
+		{"DELETE FROM user_roles WHERE user_id = $1", []driver.Value{4}},
+		{"DELETE FROM notification_preferences WHERE user_id = $1", []driver.Value{4}},
+		{"DELETE FROM notifications WHERE user_id = $1", []driver.Value{4}},
+		{"UPDATE usertable SET email = 'deleted-' || id", []driver.Value{4, defaultAvatar}},
+		{"UPDATE account_deletions SET user_purged_at = NOW() WHERE user_id = $1", []driver.Value{4}},
+	} {
+		mock.ExpectExec(regexp.QuoteMeta(query.query)).
+			WithArgs(query.args...).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	mock.ExpectCommit()
+
+	require.NoError(t, database.PurgeUser(ctx, 4, defaultAvatar))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,204 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	usermodels "skillForce/internal/models/user"
+	"skillForce/pkg/logs"
+	"strconv"
+
+	"github.com/lib/pq"
+)
+
+// GetNotificationPreferences - сохранённые настройки уведомлений пользователя по категориям.
+// Категории без строки включены
+func (d *Database) GetNotificationPreferences(ctx context.Context, userId int) (map[string]bool, error) {
+	rows, err := d.conn.QueryContext(ctx, "SELECT category, enabled FROM notification_preferences WHERE user_id = $1", userId)
+	if err != nil {
+		logs.PrintLog(ctx, "GetNotificationPreferences", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logs.PrintLog(ctx, "GetNotificationPreferences", fmt.Sprintf("%+v", err))
+		}
+	}()
+
+	prefs := make(map[string]bool)
+	for rows.Next() {
+		var category string
+		var enabled bool
+		if err := rows.Scan(&category, &enabled); err != nil {
+			logs.PrintLog(ctx, "GetNotificationPreferences", fmt.Sprintf("%+v", err))
+			return nil, err
+		}
+		prefs[category] = enabled
+	}
+	return prefs, rows.Err()
+}
+
+// UpdateNotificationPreferences - сохранение настроек уведомлений одной транзакцией
+func (d *Database) UpdateNotificationPreferences(ctx context.Context, userId int, prefs []*usermodels.NotificationPreference) error {
+	tx, err := d.conn.BeginTx(ctx, nil)
+	if err != nil {
+		logs.PrintLog(ctx, "UpdateNotificationPreferences", fmt.Sprintf("failed to begin transaction: %+v", err))
+		return err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logs.PrintLog(ctx, "transaction rollback failed", fmt.Sprintf("error: %v", err))
+		}
+	}()
+
+	for _, pref := range prefs {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO notification_preferences (user_id, category, enabled) VALUES ($1, $2, $3)
+			ON CONFLICT (user_id, category) DO UPDATE SET enabled = EXCLUDED.enabled, updated_at = NOW()`,
+			userId, pref.Category, pref.Enabled)
+		if err != nil {
+			logs.PrintLog(ctx, "UpdateNotificationPreferences", fmt.Sprintf("%+v", err))
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		logs.PrintLog(ctx, "UpdateNotificationPreferences", fmt.Sprintf("failed to commit transaction: %+v", err))
+		return err
+	}
+
+	logs.PrintLog(ctx, "UpdateNotificationPreferences", fmt.Sprintf("update %d notification preferences of user %d", len(prefs), userId))
+	return nil
+}
+
+// AddNotification - сохранение уведомления и сигнал в NotificationsChannel. Повторно доставленное событие
+// и событие удалённого пользователя пропускаются, тогда возвращается false
+func (d *Database) AddNotification(ctx context.Context, notification *usermodels.Notification) (bool, error) {
+	tx, err := d.conn.BeginTx(ctx, nil)
+	if err != nil {
+		logs.PrintLog(ctx, "AddNotification", fmt.Sprintf("failed to begin transaction: %+v", err))
+		return false, err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logs.PrintLog(ctx, "transaction rollback failed", fmt.Sprintf("error: %v", err))
+		}
+	}()
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO notifications (user_id, event_id, kind, course_id, course_name, url)
+		SELECT id, $2, $3, $4, $5, $6 FROM usertable WHERE id = $1
+		ON CONFLICT (event_id) DO NOTHING`,
+		notification.UserId, notification.EventId, notification.Kind, notification.CourseId, notification.CourseName, notification.Url)
+	if err != nil {
+		logs.PrintLog(ctx, "AddNotification", fmt.Sprintf("%+v", err))
+		return false, err
+	}
+	if inserted, err := res.RowsAffected(); err != nil || inserted == 0 {
+		return false, err
+	}
+
+	if err := notifyUser(ctx, tx, notification.UserId); err != nil {
+		return false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logs.PrintLog(ctx, "AddNotification", fmt.Sprintf("failed to commit transaction: %+v", err))
+		return false, err
+	}
+	return true, nil
+}
+
+// ListNotifications - уведомления пользователя. С afterId - новее него от старых к новым,
+// иначе от новых к старым, начиная со старше beforeId (0 - с самого нового)
+func (d *Database) ListNotifications(ctx context.Context, userId int, beforeId int64, afterId int64, limit int) ([]*usermodels.Notification, error) {
+	query := `
+		SELECT id, kind, course_id, course_name, url, created_at, read_at IS NOT NULL FROM notifications
+		WHERE user_id = $1 AND ($2::BIGINT = 0 OR id < $2) ORDER BY id DESC LIMIT $3`
+	cursor := beforeId
+	if afterId > 0 {
+		query = `
+		SELECT id, kind, course_id, course_name, url, created_at, read_at IS NOT NULL FROM notifications
+		WHERE user_id = $1 AND id > $2 ORDER BY id LIMIT $3`
+		cursor = afterId
+	}
+
+	rows, err := d.conn.QueryContext(ctx, query, userId, cursor, limit)
+	if err != nil {
+		logs.PrintLog(ctx, "ListNotifications", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logs.PrintLog(ctx, "ListNotifications", fmt.Sprintf("%+v", err))
+		}
+	}()
+
+	notifications := make([]*usermodels.Notification, 0)
+	for rows.Next() {
+		notification := &usermodels.Notification{UserId: userId}
+		if err := rows.Scan(&notification.Id, &notification.Kind, &notification.CourseId, &notification.CourseName,
+			&notification.Url, &notification.CreatedAt, &notification.IsRead); err != nil {
+			logs.PrintLog(ctx, "ListNotifications", fmt.Sprintf("%+v", err))
+			return nil, err
+		}
+		notifications = append(notifications, notification)
+	}
+	return notifications, rows.Err()
+}
+
+func (d *Database) GetUnreadNotificationCount(ctx context.Context, userId int) (int, error) {
+	var count int
+	err := d.conn.QueryRowContext(ctx, "SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND read_at IS NULL", userId).Scan(&count)
+	if err != nil {
+		logs.PrintLog(ctx, "GetUnreadNotificationCount", fmt.Sprintf("%+v", err))
+		return 0, err
+	}
+	return count, nil
+}
+
+// MarkNotificationsRead - отметка уведомлений ids или всех уведомлений пользователя прочитанными.
+// Открытые вкладки пользователя получают сигнал, чтобы обновить счётчик
+func (d *Database) MarkNotificationsRead(ctx context.Context, userId int, ids []int64, all bool) error {
+	tx, err := d.conn.BeginTx(ctx, nil)
+	if err != nil {
+		logs.PrintLog(ctx, "MarkNotificationsRead", fmt.Sprintf("failed to begin transaction: %+v", err))
+		return err
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logs.PrintLog(ctx, "transaction rollback failed", fmt.Sprintf("error: %v", err))
+		}
+	}()
+
+	res, err := tx.ExecContext(ctx,
+		"UPDATE notifications SET read_at = NOW() WHERE user_id = $1 AND read_at IS NULL AND ($2 OR id = ANY($3))",
+		userId, all, pq.Array(ids))
+	if err != nil {
+		logs.PrintLog(ctx, "MarkNotificationsRead", fmt.Sprintf("%+v", err))
+		return err
+	}
+	if updated, err := res.RowsAffected(); err != nil || updated == 0 {
+		return err
+	}
+
+	if err := notifyUser(ctx, tx, userId); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		logs.PrintLog(ctx, "MarkNotificationsRead", fmt.Sprintf("failed to commit transaction: %+v", err))
+		return err
+	}
+	return nil
+}
+
+// notifyUser - сигнал main-service об изменении уведомлений пользователя. NOTIFY доставляется после коммита
+func notifyUser(ctx context.Context, tx *sql.Tx, userId int) error {
+	_, err := tx.ExecContext(ctx, "SELECT pg_notify($1, $2)", usermodels.NotificationsChannel, strconv.Itoa(userId))
+	if err != nil {
+		logs.PrintLog(ctx, "notifyUser", fmt.Sprintf("%+v", err))
+	}
+	return err
+}
@@ -0,0 +1,162 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	usermodels "skillForce/internal/models/user"
+	"skillForce/pkg/logs"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetNotificationPreferences(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	database := &Database{conn: db}
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT category, enabled FROM notification_preferences WHERE user_id = $1")).
+		WithArgs(4).
+		WillReturnRows(sqlmock.NewRows([]string{"category", "enabled"}).AddRow("marketing", false))
+
+	prefs, err := database.GetNotificationPreferences(ctx, 4)
+	require.NoError(t, err)
+	require.Equal(t, map[string]bool{"marketing": false}, prefs)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateNotificationPreferences(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	database := &Database{conn: db}
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO notification_preferences (user_id, category, enabled)")).
+		WithArgs(4, "marketing", false).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO notification_preferences (user_id, category, enabled)")).
+		WithArgs(4, "review_results", true).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = database.UpdateNotificationPreferences(ctx, 4, []*usermodels.NotificationPreference{
+		{Category: "marketing", Enabled: false},
+		{Category: "review_results", Enabled: true},
+	})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUpdateNotificationPreferences_Error(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	database := &Database{conn: db}
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO notification_preferences")).
+		WillReturnError(errors.New("db error"))
+	mock.ExpectRollback()
+
+	err = database.UpdateNotificationPreferences(ctx, 4, []*usermodels.NotificationPreference{{Category: "marketing"}})
+	require.Error(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddNotification(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	database := &Database{conn: db}
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+	notification := &usermodels.Notification{UserId: 4, EventId: "0b7ad1ea-3c4c-4a4b-9a57-5b1e0d8a1f6e", Kind: usermodels.NotificationCourseSignup, CourseId: 5, CourseName: "Go"}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO notifications (user_id, event_id, kind, course_id, course_name, url)")).
+		WithArgs(4, notification.EventId, usermodels.NotificationCourseSignup, 5, "Go", "").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_notify($1, $2)")).
+		WithArgs(usermodels.NotificationsChannel, "4").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	added, err := database.AddNotification(ctx, notification)
+	require.NoError(t, err)
+	require.True(t, added)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAddNotification_Duplicate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	database := &Database{conn: db}
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	// событие уже обработано: сигнала нет
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO notifications")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	added, err := database.AddNotification(ctx, &usermodels.Notification{UserId: 4, EventId: "0b7ad1ea-3c4c-4a4b-9a57-5b1e0d8a1f6e", Kind: usermodels.NotificationCourseSignup})
+	require.NoError(t, err)
+	require.False(t, added)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListNotifications(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	database := &Database{conn: db}
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+	createdAt := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	columns := []string{"id", "kind", "course_id", "course_name", "url", "created_at", "read"}
+
+	mock.ExpectQuery(regexp.QuoteMeta("ORDER BY id DESC LIMIT $3")).
+		WithArgs(4, int64(0), 20).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(8, "course_signup", 5, "Go", "", createdAt, false))
+	mock.ExpectQuery(regexp.QuoteMeta("AND id > $2 ORDER BY id LIMIT $3")).
+		WithArgs(4, int64(7), 20).
+		WillReturnRows(sqlmock.NewRows(columns))
+
+	notifications, err := database.ListNotifications(ctx, 4, 0, 0, 20)
+	require.NoError(t, err)
+	require.Equal(t, []*usermodels.Notification{{
+		Id: 8, UserId: 4, Kind: "course_signup", CourseId: 5, CourseName: "Go", CreatedAt: createdAt,
+	}}, notifications)
+
+	notifications, err = database.ListNotifications(ctx, 4, 0, 7, 20)
+	require.NoError(t, err)
+	require.Empty(t, notifications)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMarkNotificationsRead(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	database := &Database{conn: db}
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE notifications SET read_at = NOW()")).
+		WithArgs(4, true, nil).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_notify($1, $2)")).
+		WithArgs(usermodels.NotificationsChannel, "4").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	require.NoError(t, database.MarkNotificationsRead(ctx, 4, nil, true))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	usermodels "skillForce/internal/models/user"
+	"skillForce/pkg/events"
+	"skillForce/pkg/logs"
+	"skillForce/pkg/outbox"
+)
+
+// OutboxSource - события user-service в outbox_events
+const OutboxSource = "user-service"
+
+func rollback(ctx context.Context, tx *sql.Tx) {
+	if err := tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+		logs.PrintLog(ctx, "transaction rollback failed", fmt.Sprintf("error: %v", err))
+	}
+}
+
+// enqueueRegMail - письмо с подтверждением регистрации уходит, только если заявка сохранена
+func (d *Database) enqueueRegMail(ctx context.Context, tx *sql.Tx, user *usermodels.User, token string) error {
+	env, err := events.New(ctx, OutboxSource, &events.ConfirmRegistrationMail{
+		Recipient: &events.Recipient{Email: user.Email, Name: user.Name},
+		Token:     token,
+	})
+	if err != nil {
+		return err
+	}
+	return outbox.Enqueue(ctx, tx, usermodels.MailTopic, user.Email, env)
+}
+
+// EnqueueDataExportMail - письмо со ссылкой на архив с данными пользователя
+func (d *Database) EnqueueDataExportMail(ctx context.Context, user *usermodels.User, url string) error {
+	env, err := events.New(ctx, OutboxSource, &events.DataExportMail{
+		Recipient: &events.Recipient{Email: user.Email, Name: user.Name, Locale: user.Locale, UserId: int32(user.Id)},
+		Url:       url,
+	})
+	if err == nil {
+		err = outbox.Enqueue(ctx, d.conn, usermodels.MailTopic, user.Email, env)
+	}
+	if err != nil {
+		logs.PrintLog(ctx, "EnqueueDataExportMail", fmt.Sprintf("%+v", err))
+	}
+	return err
+}
+
+// NewOutboxRelay - отправка событий user-service из outbox_events
+func (d *Database) NewOutboxRelay(publisher outbox.Publisher, conf outbox.Config) *outbox.Relay {
+	return outbox.NewRelay(d.conn, publisher, OutboxSource, conf)
+}
@@ -7,6 +7,13 @@ import (
 	_ "github.com/lib/pq"
 )
 
+const (
+	RegistrationTokenTTL       = time.Hour
+	ConfirmationResendCooldown = time.Minute
+	MaxConfirmationResends     = 5
+	DataExportCooldown         = 24 * time.Hour
+)
+
 type Database struct {
 	conn           *sql.DB
 	SESSION_SECRET string
@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"skillForce/pkg/logs"
+
+	"github.com/lib/pq"
+)
+
+func (d *Database) GetUserRoles(ctx context.Context, userId int) ([]string, error) {
+	var roles []string
+	err := d.conn.QueryRow("SELECT ARRAY(SELECT role FROM user_roles WHERE user_id = $1 ORDER BY role)", userId).Scan(pq.Array(&roles))
+	if err != nil {
+		logs.PrintLog(ctx, "GetUserRoles", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+	return roles, nil
+}
+
+func (d *Database) GrantRole(ctx context.Context, userId int, role string, grantedBy int) error {
+	_, err := d.conn.Exec("INSERT INTO user_roles (user_id, role, granted_by) VALUES ($1, $2, $3) ON CONFLICT (user_id, role) DO NOTHING",
+		userId, role, grantedBy)
+	if err != nil {
+		logs.PrintLog(ctx, "GrantRole", fmt.Sprintf("%+v", err))
+		return err
+	}
+
+	logs.PrintLog(ctx, "GrantRole", fmt.Sprintf("user with id %+v granted role %+v to user with id %+v", grantedBy, role, userId))
+	return nil
+}
+
+func (d *Database) RevokeRole(ctx context.Context, userId int, role string) error {
+	result, err := d.conn.Exec("DELETE FROM user_roles WHERE user_id = $1 AND role = $2", userId, role)
+	if err != nil {
+		logs.PrintLog(ctx, "RevokeRole", fmt.Sprintf("%+v", err))
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return errors.New("role not found")
+	}
+
+	logs.PrintLog(ctx, "RevokeRole", fmt.Sprintf("revoke role %+v from user with id %+v", role, userId))
+	return nil
+}
@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"skillForce/pkg/logs"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetUserRoles(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	database := &Database{conn: db}
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	mock.ExpectQuery("SELECT ARRAY\\(SELECT role FROM user_roles WHERE user_id = \\$1 ORDER BY role\\)").
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"roles"}).AddRow("{author,moderator}"))
+
+	roles, err := database.GetUserRoles(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, []string{"author", "moderator"}, roles)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGrantRole(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	database := &Database{conn: db}
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	mock.ExpectExec("INSERT INTO user_roles \\(user_id, role, granted_by\\) VALUES \\(\\$1, \\$2, \\$3\\) ON CONFLICT").
+		WithArgs(2, "author", 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = database.GrantRole(ctx, 2, "author", 1)
+	require.NoError(t, err)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGrantRole_DBError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	database := &Database{conn: db}
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	mock.ExpectExec("INSERT INTO user_roles").
+		WithArgs(2, "author", 1).
+		WillReturnError(errors.New("db error"))
+
+	err = database.GrantRole(ctx, 2, "author", 1)
+	require.Error(t, err)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRevokeRole(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	database := &Database{conn: db}
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	mock.ExpectExec("DELETE FROM user_roles WHERE user_id = \\$1 AND role = \\$2").
+		WithArgs(2, "author").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = database.RevokeRole(ctx, 2, "author")
+	require.NoError(t, err)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRevokeRole_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	database := &Database{conn: db}
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	mock.ExpectExec("DELETE FROM user_roles").
+		WithArgs(2, "author").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err = database.RevokeRole(ctx, 2, "author")
+	require.Error(t, err)
+	require.Equal(t, "role not found", err.Error())
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
 	"encoding/base64"
 	"errors"
 	"fmt"
@@ -9,12 +10,14 @@ import (
 	usermodels "skillForce/internal/models/user"
 	"skillForce/pkg/hash"
 	"skillForce/pkg/logs"
+	"skillForce/pkg/outbox"
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/lib/pq"
 )
 
-func (d *Database) saveSession(ctx context.Context, userId int) (string, error) {
+func (d *Database) saveSession(ctx context.Context, db outbox.Execer, userId int) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"user_id": userId,
 	})
@@ -24,7 +27,7 @@ func (d *Database) saveSession(ctx context.Context, userId int) (string, error)
 		return "", err
 	}
 
-	_, err = d.conn.Exec("INSERT INTO sessions (user_id, token, expire) VALUES ($1, $2, $3)", userId, secretToken, time.Now().AddDate(1, 0, 0))
+	_, err = db.ExecContext(ctx, "INSERT INTO sessions (user_id, token, expire) VALUES ($1, $2, $3)", userId, secretToken, time.Now().AddDate(1, 0, 0))
 	if err != nil {
 		return "", err
 	}
@@ -40,8 +43,8 @@ func (d *Database) userExists(email string) (bool, error) {
 }
 
 func (d *Database) GetUserById(ctx context.Context, userId int) (*usermodels.User, error) {
-	var user usermodels.User
-	err := d.conn.QueryRow("SELECT email, name, hide_email FROM usertable WHERE id = $1", userId).Scan(&user.Email, &user.Name, &user.HideEmail)
+	user := usermodels.User{Id: userId}
+	err := d.conn.QueryRow("SELECT email, name, hide_email, locale FROM usertable WHERE id = $1", userId).Scan(&user.Email, &user.Name, &user.HideEmail, &user.Locale)
 	if err != nil {
 		logs.PrintLog(ctx, "GetUserById", fmt.Sprintf("%+v", err))
 		return nil, err
@@ -49,63 +52,47 @@ func (d *Database) GetUserById(ctx context.Context, userId int) (*usermodels.Use
 	return &user, nil
 }
 
-func (d *Database) parseToken(ctx context.Context, token string) (map[string]interface{}, error) {
-	parsedToken, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(d.SESSION_SECRET), nil
-	})
-
+// RegisterUser - регистрация по ссылке из письма. Заявка удаляется в одной транзакции с созданием
+// пользователя и сессии: если создать пользователя не удалось, ссылка остаётся действительной
+func (d *Database) RegisterUser(ctx context.Context, token string) (string, error) {
+	tx, err := d.conn.BeginTx(ctx, nil)
 	if err != nil {
-		logs.PrintLog(ctx, "parseToken", fmt.Sprintf("token parse error: %+v", err))
-		return nil, err
+		logs.PrintLog(ctx, "RegisterUser", fmt.Sprintf("failed to begin transaction: %+v", err))
+		return "", err
 	}
+	defer rollback(ctx, tx)
 
-	if claims, ok := parsedToken.Claims.(jwt.MapClaims); ok && parsedToken.Valid {
-		if exp, ok := claims["expire"].(float64); ok {
-			if int64(exp) < time.Now().Unix() {
-				return nil, errors.New("token expired")
-			}
-		} else {
-			return nil, errors.New("invalid or missing 'expire' field")
-		}
-
-		return claims, nil
+	user, err := consumeRegistrationToken(ctx, tx, token)
+	if err != nil {
+		return "", err
 	}
 
-	return nil, errors.New("invalid token")
-}
-
-func (d *Database) RegisterUser(ctx context.Context, user *usermodels.User) (string, error) {
-	emailExists, err := d.userExists(user.Email)
+	var emailExists bool
+	err = tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM usertable WHERE email = $1)", user.Email).Scan(&emailExists)
 	if err != nil {
 		return "", err
 	}
 	if emailExists {
 		return "", errors.New("email exists")
 	}
+
 	saltBase64 := base64.StdEncoding.EncodeToString(user.Salt)
-	_, err = d.conn.Exec("INSERT INTO usertable (email, name, password, salt) VALUES ($1, $2, $3, $4)", user.Email, user.Name, user.Password, saltBase64)
+	err = tx.QueryRowContext(ctx, "INSERT INTO usertable (email, name, password, salt) VALUES ($1, $2, $3, $4) RETURNING id",
+		user.Email, user.Name, user.Password, saltBase64).Scan(&user.Id)
 	if err != nil {
+		logs.PrintLog(ctx, "RegisterUser", fmt.Sprintf("%+v", err))
 		return "", err
 	}
 
 	logs.PrintLog(ctx, "RegisterUser", fmt.Sprintf("save user %+v in db", user))
 
-	rows, err := d.conn.Query("SELECT id FROM usertable WHERE email = $1", user.Email)
+	cookieValue, err := d.saveSession(ctx, tx, user.Id)
 	if err != nil {
 		return "", err
 	}
-	for rows.Next() {
-		err = rows.Scan(&user.Id)
-		if err != nil {
-			return "", err
-		}
-	}
 
-	cookieValue, err := d.saveSession(ctx, user.Id)
-	if err != nil {
+	if err := tx.Commit(); err != nil {
+		logs.PrintLog(ctx, "RegisterUser", fmt.Sprintf("failed to commit transaction: %+v", err))
 		return "", err
 	}
 
@@ -123,49 +110,155 @@ func (d *Database) ValidUser(ctx context.Context, user *usermodels.User) (string
 		return "", errors.New("email exists")
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"name":     user.Name,
-		"email":    user.Email,
-		"password": user.Password,
-		"expire":   time.Now().Add(time.Hour).Unix(),
-	})
+	token, err := hash.GenerateToken()
+	if err != nil {
+		logs.PrintLog(ctx, "ValidUser", fmt.Sprintf("%+v", err))
+		return "", err
+	}
 
-	secretToken, err := token.SignedString([]byte(d.SESSION_SECRET))
+	tx, err := d.conn.BeginTx(ctx, nil)
 	if err != nil {
+		logs.PrintLog(ctx, "ValidUser", fmt.Sprintf("failed to begin transaction: %+v", err))
+		return "", err
+	}
+	defer rollback(ctx, tx)
+
+	// просроченные заявки удаляются вместе со счётчиком повторных писем: после истечения ссылки
+	// email можно зарегистрировать заново
+	if _, err := tx.ExecContext(ctx, "DELETE FROM pending_registrations WHERE expire < NOW()"); err != nil {
 		logs.PrintLog(ctx, "ValidUser", fmt.Sprintf("%+v", err))
 		return "", err
 	}
 
-	logs.PrintLog(ctx, "ValidUser", fmt.Sprintf("create token for user %+v", user))
-	return secretToken, nil
+	// действующая заявка не перезаписывается, иначе имя и пароль в ней мог бы заменить кто угодно,
+	// знающий email. Новое письмо по ней отправляет ResendConfirmation
+	saltBase64 := base64.StdEncoding.EncodeToString(user.Salt)
+	result, err := tx.ExecContext(ctx, `INSERT INTO pending_registrations (email, name, password, salt, token_hash, expire, resend_count, last_sent_at)
+		VALUES ($1, $2, $3, $4, $5, $6, 0, NOW()) ON CONFLICT (email) DO NOTHING`,
+		user.Email, user.Name, user.Password, saltBase64, hash.HashToken(token), time.Now().Add(RegistrationTokenTTL))
+	if err != nil {
+		logs.PrintLog(ctx, "ValidUser", fmt.Sprintf("%+v", err))
+		return "", err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return "", err
+	}
+	if rowsAffected == 0 {
+		return "", errors.New("registration pending")
+	}
+
+	if err := d.enqueueRegMail(ctx, tx, user, token); err != nil {
+		logs.PrintLog(ctx, "ValidUser", fmt.Sprintf("%+v", err))
+		return "", err
+	}
+	if err := tx.Commit(); err != nil {
+		logs.PrintLog(ctx, "ValidUser", fmt.Sprintf("failed to commit transaction: %+v", err))
+		return "", err
+	}
+
+	logs.PrintLog(ctx, "ValidUser", fmt.Sprintf("save pending registration for user with email %+v", user.Email))
+	return token, nil
 }
 
-func (d *Database) GetUserByToken(ctx context.Context, token string) (*usermodels.User, error) {
+func consumeRegistrationToken(ctx context.Context, tx *sql.Tx, token string) (*usermodels.User, error) {
 	var user usermodels.User
-	claims, err := d.parseToken(ctx, token)
+	var salt string
+	err := tx.QueryRowContext(ctx, "DELETE FROM pending_registrations WHERE token_hash = $1 AND expire > NOW() RETURNING name, email, password, salt",
+		hash.HashToken(token)).Scan(&user.Name, &user.Email, &user.Password, &salt)
 	if err != nil {
+		logs.PrintLog(ctx, "ConsumeRegistrationToken", fmt.Sprintf("%+v", err))
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("invalid token")
+		}
 		return nil, err
 	}
 
-	user.Name = claims["name"].(string)
-	user.Email = claims["email"].(string)
-	user.Password = claims["password"].(string)
+	user.Salt, err = base64.StdEncoding.DecodeString(salt)
+	if err != nil {
+		return nil, err
+	}
 
+	logs.PrintLog(ctx, "ConsumeRegistrationToken", fmt.Sprintf("consume pending registration of user with email %+v", user.Email))
 	return &user, nil
 }
 
+func (d *Database) RefreshRegistrationToken(ctx context.Context, email string) (*usermodels.User, string, error) {
+	token, err := hash.GenerateToken()
+	if err != nil {
+		logs.PrintLog(ctx, "RefreshRegistrationToken", fmt.Sprintf("%+v", err))
+		return nil, "", err
+	}
+
+	tx, err := d.conn.BeginTx(ctx, nil)
+	if err != nil {
+		logs.PrintLog(ctx, "RefreshRegistrationToken", fmt.Sprintf("failed to begin transaction: %+v", err))
+		return nil, "", err
+	}
+	defer rollback(ctx, tx)
+
+	// лимит и интервал между письмами проверяются в самом UPDATE: из одновременных запросов строку
+	// обновит только один, остальные после блокировки увидят новые resend_count и last_sent_at
+	var user usermodels.User
+	err = tx.QueryRowContext(ctx, `UPDATE pending_registrations SET token_hash = $1, expire = $2, resend_count = resend_count + 1, last_sent_at = NOW()
+		WHERE email = $3 AND expire > NOW() AND resend_count < $4 AND last_sent_at < NOW() - $5 * INTERVAL '1 second'
+		RETURNING name, email`,
+		hash.HashToken(token), time.Now().Add(RegistrationTokenTTL), email, MaxConfirmationResends, ConfirmationResendCooldown.Seconds()).
+		Scan(&user.Name, &user.Email)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, "", refreshRegistrationError(ctx, tx, email)
+	}
+	if err != nil {
+		logs.PrintLog(ctx, "RefreshRegistrationToken", fmt.Sprintf("%+v", err))
+		return nil, "", err
+	}
+
+	if err := d.enqueueRegMail(ctx, tx, &user, token); err != nil {
+		logs.PrintLog(ctx, "RefreshRegistrationToken", fmt.Sprintf("%+v", err))
+		return nil, "", err
+	}
+	if err := tx.Commit(); err != nil {
+		logs.PrintLog(ctx, "RefreshRegistrationToken", fmt.Sprintf("failed to commit transaction: %+v", err))
+		return nil, "", err
+	}
+
+	logs.PrintLog(ctx, "RefreshRegistrationToken", fmt.Sprintf("refresh confirmation token for user with email %+v", email))
+	return &user, token, nil
+}
+
+// refreshRegistrationError - почему заявка не обновилась: её нет или она просрочена, исчерпан лимит писем
+// или предыдущее письмо отправлено слишком недавно
+func refreshRegistrationError(ctx context.Context, tx *sql.Tx, email string) error {
+	var limitExceeded bool
+	err := tx.QueryRowContext(ctx, "SELECT resend_count >= $2 FROM pending_registrations WHERE email = $1 AND expire > NOW()",
+		email, MaxConfirmationResends).Scan(&limitExceeded)
+	if errors.Is(err, sql.ErrNoRows) {
+		return errors.New("registration not found")
+	}
+	if err != nil {
+		logs.PrintLog(ctx, "RefreshRegistrationToken", fmt.Sprintf("%+v", err))
+		return err
+	}
+	if limitExceeded {
+		return errors.New("resend limit exceeded")
+	}
+	return errors.New("resend too early")
+}
+
 func (d *Database) GetUserByCookie(ctx context.Context, cookieValue string) (*usermodels.UserProfile, error) {
 	var userProfile usermodels.UserProfile
-	var role string
-	err := d.conn.QueryRow("SELECT u.id, u.email, u.name, COALESCE(u.bio, ''), u.avatar_src, u.hide_email, u.role FROM usertable u JOIN sessions s ON u.id = s.user_id WHERE s.token = $1 AND s.expire > NOW();",
-		cookieValue).Scan(&userProfile.Id, &userProfile.Email, &userProfile.Name, &userProfile.Bio, &userProfile.AvatarSrc, &userProfile.HideEmail, &role)
+	err := d.conn.QueryRow("SELECT u.id, u.email, u.name, COALESCE(u.bio, ''), u.avatar_src, u.hide_email, u.locale, u.timezone, ARRAY(SELECT r.role FROM user_roles r WHERE r.user_id = u.id ORDER BY r.role) FROM usertable u JOIN sessions s ON u.id = s.user_id WHERE s.token = $1 AND s.expire > NOW();",
+		cookieValue).Scan(&userProfile.Id, &userProfile.Email, &userProfile.Name, &userProfile.Bio, &userProfile.AvatarSrc, &userProfile.HideEmail, &userProfile.Locale, &userProfile.Timezone, pq.Array(&userProfile.Roles))
 	if err != nil {
 		logs.PrintLog(ctx, "GetUserByCookie", fmt.Sprintf("error in GetUserByCookie %+v", err))
 		return nil, err
 	}
-	logs.PrintLog(ctx, "GetUserByCookie", fmt.Sprintf("role: %+v", role))
-	if role == "admin" {
-		userProfile.IsAdmin = true
+	logs.PrintLog(ctx, "GetUserByCookie", fmt.Sprintf("roles: %+v", userProfile.Roles))
+	for _, role := range userProfile.Roles {
+		if role == "admin" {
+			userProfile.IsAdmin = true
+		}
 	}
 	userProfile.Email = html.EscapeString(userProfile.Email)
 	userProfile.Name = html.EscapeString(userProfile.Name)
@@ -174,6 +267,30 @@ func (d *Database) GetUserByCookie(ctx context.Context, cookieValue string) (*us
 	return &userProfile, err
 }
 
+// GetUserProfileById - профиль пользователя для публичной страницы
+func (d *Database) GetUserProfileById(ctx context.Context, userId int) (*usermodels.UserProfile, error) {
+	var userProfile usermodels.UserProfile
+	err := d.conn.QueryRow("SELECT u.id, u.email, u.name, COALESCE(u.bio, ''), u.avatar_src, u.hide_email, u.locale, u.timezone, ARRAY(SELECT r.role FROM user_roles r WHERE r.user_id = u.id ORDER BY r.role) FROM usertable u WHERE u.id = $1",
+		userId).Scan(&userProfile.Id, &userProfile.Email, &userProfile.Name, &userProfile.Bio, &userProfile.AvatarSrc, &userProfile.HideEmail, &userProfile.Locale, &userProfile.Timezone, pq.Array(&userProfile.Roles))
+	if err != nil {
+		logs.PrintLog(ctx, "GetUserProfileById", fmt.Sprintf("%+v", err))
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+	for _, role := range userProfile.Roles {
+		if role == "admin" {
+			userProfile.IsAdmin = true
+		}
+	}
+	userProfile.Email = html.EscapeString(userProfile.Email)
+	userProfile.Name = html.EscapeString(userProfile.Name)
+	userProfile.Bio = html.EscapeString(userProfile.Bio)
+	userProfile.AvatarSrc = html.EscapeString(userProfile.AvatarSrc)
+	return &userProfile, nil
+}
+
 func (d *Database) AuthenticateUser(ctx context.Context, email string, password string) (string, error) {
 	var id int
 	emailExists, err := d.userExists(email)
@@ -200,7 +317,7 @@ func (d *Database) AuthenticateUser(ctx context.Context, email string, password
 
 	logs.PrintLog(ctx, "AuthenticateUser", fmt.Sprintf("login user with email %+v in db", email))
 
-	cookieValue, err := d.saveSession(ctx, id)
+	cookieValue, err := d.saveSession(ctx, d.conn, id)
 	if err != nil {
 		return "", err
 	}
@@ -220,8 +337,8 @@ func (d *Database) LogoutUser(ctx context.Context, userId int) error {
 
 func (d *Database) UpdateProfile(ctx context.Context, userId int, userProfile *usermodels.UserProfile) error {
 	logs.PrintLog(ctx, "UpdateProfile", fmt.Sprintf("update profile %+v of user with id %+v in db", userProfile, userId))
-	_, err := d.conn.Exec("UPDATE usertable SET email = $1, name = $2, bio = $3, hide_email = $4 WHERE id = $5",
-		userProfile.Email, userProfile.Name, userProfile.Bio, userProfile.HideEmail, userId)
+	_, err := d.conn.Exec("UPDATE usertable SET email = $1, name = $2, bio = $3, hide_email = $4, locale = COALESCE(NULLIF($5, ''), locale), timezone = COALESCE(NULLIF($6, ''), timezone) WHERE id = $7",
+		userProfile.Email, userProfile.Name, userProfile.Bio, userProfile.HideEmail, userProfile.Locale, userProfile.Timezone, userId)
 	if err != nil {
 		return err
 	}
@@ -240,9 +357,8 @@ func (d *Database) UpdateProfilePhoto(ctx context.Context, photoUrl string, user
 	return photoUrl, nil
 }
 
-func (d *Database) DeleteProfilePhoto(ctx context.Context, userId int) error {
-	delaultAvatar := "https://skill-force.ru/avatars/default_avatar.png"
-	_, err := d.conn.Exec("UPDATE usertable SET avatar_src = $1 WHERE id = $2", delaultAvatar, userId)
+func (d *Database) DeleteProfilePhoto(ctx context.Context, userId int, defaultAvatar string) error {
+	_, err := d.conn.Exec("UPDATE usertable SET avatar_src = $1 WHERE id = $2", defaultAvatar, userId)
 	if err != nil {
 		logs.PrintLog(ctx, "DeleteProfilePhoto", fmt.Sprintf("%+v", err))
 		return err
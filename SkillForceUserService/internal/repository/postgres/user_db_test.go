@@ -10,10 +10,8 @@ import (
 	"skillForce/pkg/hash"
 	"skillForce/pkg/logs"
 	"testing"
-	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
-	"github.com/golang-jwt/jwt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -34,7 +32,7 @@ func TestSaveSession_Success(t *testing.T) {
 		WithArgs(userId, sqlmock.AnyArg(), sqlmock.AnyArg()).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	token, err := database.saveSession(ctx, userId)
+	token, err := database.saveSession(ctx, db, userId)
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
 
@@ -52,7 +50,7 @@ func TestSaveSession_JWTSigningError(t *testing.T) {
 	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
 
 	database.SESSION_SECRET = string([]byte{})
-	token, err := database.saveSession(ctx, 1)
+	token, err := database.saveSession(ctx, db, 1)
 	require.Error(t, err)
 	require.Empty(t, token)
 }
@@ -72,7 +70,7 @@ func TestSaveSession_DBError(t *testing.T) {
 		WithArgs(userId, sqlmock.AnyArg(), sqlmock.AnyArg()).
 		WillReturnError(fmt.Errorf("insert error"))
 
-	token, err := database.saveSession(ctx, userId)
+	token, err := database.saveSession(ctx, db, userId)
 	require.Error(t, err)
 	require.Empty(t, token)
 	require.EqualError(t, err, "insert error")
@@ -143,15 +141,17 @@ func TestGetUserById_Success(t *testing.T) {
 	userId := 1
 
 	expectedUser := usermodels.User{
+		Id:        userId,
 		Email:     "test@example.com",
 		Name:      "John Doe",
 		HideEmail: true,
+		Locale:    "en",
 	}
 
-	mock.ExpectQuery("SELECT email, name, hide_email FROM usertable WHERE id = \\$1").
+	mock.ExpectQuery("SELECT email, name, hide_email, locale FROM usertable WHERE id = \\$1").
 		WithArgs(userId).
-		WillReturnRows(sqlmock.NewRows([]string{"email", "name", "hide_email"}).
-			AddRow(expectedUser.Email, expectedUser.Name, expectedUser.HideEmail))
+		WillReturnRows(sqlmock.NewRows([]string{"email", "name", "hide_email", "locale"}).
+			AddRow(expectedUser.Email, expectedUser.Name, expectedUser.HideEmail, expectedUser.Locale))
 
 	user, err := database.GetUserById(ctx, userId)
 	require.NoError(t, err)
@@ -168,7 +168,7 @@ func TestGetUserById_NoRows(t *testing.T) {
 	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
 	userId := 999
 
-	mock.ExpectQuery("SELECT email, name, hide_email FROM usertable WHERE id = \\$1").
+	mock.ExpectQuery("SELECT email, name, hide_email, locale FROM usertable WHERE id = \\$1").
 		WithArgs(userId).
 		WillReturnError(sql.ErrNoRows)
 
@@ -187,7 +187,7 @@ func TestGetUserById_QueryError(t *testing.T) {
 	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
 	userId := 42
 
-	mock.ExpectQuery("SELECT email, name, hide_email FROM usertable WHERE id = \\$1").
+	mock.ExpectQuery("SELECT email, name, hide_email, locale FROM usertable WHERE id = \\$1").
 		WithArgs(userId).
 		WillReturnError(fmt.Errorf("some db error"))
 
@@ -198,66 +198,11 @@ func TestGetUserById_QueryError(t *testing.T) {
 	require.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestParseToken_Success(t *testing.T) {
-	secret := "mysecret"
-	database := &Database{SESSION_SECRET: secret}
-	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_id": 1,
-		"expire":  float64(time.Now().Add(time.Hour).Unix()),
-	})
-	signedToken, err := token.SignedString([]byte(secret))
-	require.NoError(t, err)
-
-	claims, err := database.parseToken(ctx, signedToken)
-	require.NoError(t, err)
-	require.Equal(t, float64(1), claims["user_id"])
-}
-
-func TestParseToken_Expired(t *testing.T) {
-	secret := "mysecret"
-	database := &Database{SESSION_SECRET: secret}
-	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_id": 1,
-		"expire":  float64(time.Now().Add(-time.Hour).Unix()),
-	})
-	signedToken, err := token.SignedString([]byte(secret))
-	require.NoError(t, err)
-
-	claims, err := database.parseToken(ctx, signedToken)
-	require.Error(t, err)
-	require.Contains(t, err.Error(), "token expired")
-	require.Nil(t, claims)
-}
-
-func TestParseToken_MissingExpire(t *testing.T) {
-	secret := "mysecret"
-	database := &Database{SESSION_SECRET: secret}
-	ctx := context.Background()
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_id": 1,
-	})
-	signedToken, err := token.SignedString([]byte(secret))
-	require.NoError(t, err)
-
-	claims, err := database.parseToken(ctx, signedToken)
-	require.Error(t, err)
-	require.Contains(t, err.Error(), "invalid or missing 'expire'")
-	require.Nil(t, claims)
-}
-
-func TestParseToken_MalformedToken(t *testing.T) {
-	secret := "mysecret"
-	database := &Database{SESSION_SECRET: secret}
-	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
-
-	claims, err := database.parseToken(ctx, "not.a.jwt.token")
-	require.Error(t, err)
-	require.Nil(t, claims)
+func expectConsumeRegistrationToken(mock sqlmock.Sqlmock, token string, user *usermodels.User) {
+	mock.ExpectQuery("DELETE FROM pending_registrations WHERE token_hash = \\$1 AND expire > NOW\\(\\) RETURNING name, email, password, salt").
+		WithArgs(hash.HashToken(token)).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "email", "password", "salt"}).
+			AddRow(user.Name, user.Email, user.Password, base64.StdEncoding.EncodeToString(user.Salt)))
 }
 
 func TestRegisterUser_Success(t *testing.T) {
@@ -274,26 +219,43 @@ func TestRegisterUser_Success(t *testing.T) {
 
 	database := &Database{conn: db, SESSION_SECRET: "secret"}
 
+	mock.ExpectBegin()
+	expectConsumeRegistrationToken(mock, "opaque-token", user)
 	mock.ExpectQuery("SELECT EXISTS.*FROM usertable").
 		WithArgs(user.Email).
 		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
-
-	mock.ExpectExec("INSERT INTO usertable").
+	mock.ExpectQuery("INSERT INTO usertable \\(email, name, password, salt\\) VALUES \\(\\$1, \\$2, \\$3, \\$4\\) RETURNING id").
 		WithArgs(user.Email, user.Name, user.Password, base64.StdEncoding.EncodeToString(user.Salt)).
-		WillReturnResult(sqlmock.NewResult(1, 1))
-
-	mock.ExpectQuery("SELECT id FROM usertable").
-		WithArgs(user.Email).
 		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(123))
-
 	mock.ExpectExec("INSERT INTO sessions").
 		WithArgs(123, sqlmock.AnyArg(), sqlmock.AnyArg()).
 		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
 
-	token, err := database.RegisterUser(ctx, user)
+	token, err := database.RegisterUser(ctx, "opaque-token")
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
-	require.Equal(t, 123, user.Id)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRegisterUser_InvalidToken(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	database := &Database{conn: db, SESSION_SECRET: "secret"}
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("DELETE FROM pending_registrations").
+		WithArgs(hash.HashToken("used-token")).
+		WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	token, err := database.RegisterUser(ctx, "used-token")
+	require.Error(t, err)
+	require.Equal(t, "invalid token", err.Error())
+	require.Empty(t, token)
+	require.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestRegisterUser_EmailExists(t *testing.T) {
@@ -303,16 +265,20 @@ func TestRegisterUser_EmailExists(t *testing.T) {
 	database := &Database{conn: db, SESSION_SECRET: "secret"}
 	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
 
-	user := &usermodels.User{Email: "test@example.com"}
+	user := &usermodels.User{Email: "test@example.com", Name: "Test", Password: "hashedpassword", Salt: []byte("randomsalt")}
 
+	mock.ExpectBegin()
+	expectConsumeRegistrationToken(mock, "opaque-token", user)
 	mock.ExpectQuery("SELECT EXISTS.*FROM usertable").
 		WithArgs(user.Email).
 		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectRollback()
 
-	token, err := database.RegisterUser(ctx, user)
+	token, err := database.RegisterUser(ctx, "opaque-token")
 	require.Error(t, err)
 	require.Equal(t, "email exists", err.Error())
 	require.Empty(t, token)
+	require.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestRegisterUser_InsertFail(t *testing.T) {
@@ -329,29 +295,30 @@ func TestRegisterUser_InsertFail(t *testing.T) {
 		Salt:     []byte("randomsalt"),
 	}
 
+	// email заняли между проверкой и вставкой: транзакция откатывается вместе с удалением заявки
+	mock.ExpectBegin()
+	expectConsumeRegistrationToken(mock, "opaque-token", user)
 	mock.ExpectQuery("SELECT EXISTS.*FROM usertable").
 		WithArgs(user.Email).
 		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
-
-	mock.ExpectExec("INSERT INTO usertable").
+	mock.ExpectQuery("INSERT INTO usertable").
 		WithArgs(user.Email, user.Name, user.Password, base64.StdEncoding.EncodeToString(user.Salt)).
-		WillReturnError(errors.New("insert failed"))
+		WillReturnError(errors.New("duplicate key value violates unique constraint"))
+	mock.ExpectRollback()
 
-	token, err := database.RegisterUser(ctx, user)
+	token, err := database.RegisterUser(ctx, "opaque-token")
 	require.Error(t, err)
-	require.Contains(t, err.Error(), "insert failed")
+	require.Contains(t, err.Error(), "duplicate key")
 	require.Empty(t, token)
+	require.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestRegisterUser_GetIDFail(t *testing.T) {
+func TestRegisterUser_SessionFail(t *testing.T) {
 	db, mock, err := sqlmock.New()
 	require.NoError(t, err)
 
 	database := &Database{conn: db, SESSION_SECRET: "secret"}
-	ctx := context.Background()
-	ctx = context.WithValue(ctx, logs.LogsKey, &logs.CtxLog{
-		Data: make([]*logs.LogString, 0),
-	})
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
 	user := &usermodels.User{
 		Email:    "test@example.com",
 		Name:     "Test",
@@ -359,22 +326,22 @@ func TestRegisterUser_GetIDFail(t *testing.T) {
 		Salt:     []byte("randomsalt"),
 	}
 
+	mock.ExpectBegin()
+	expectConsumeRegistrationToken(mock, "opaque-token", user)
 	mock.ExpectQuery("SELECT EXISTS.*FROM usertable").
 		WithArgs(user.Email).
 		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectQuery("INSERT INTO usertable").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(123))
+	mock.ExpectExec("INSERT INTO sessions").
+		WillReturnError(errors.New("insert session failed"))
+	mock.ExpectRollback()
 
-	mock.ExpectExec("INSERT INTO usertable").
-		WithArgs(user.Email, user.Name, user.Password, base64.StdEncoding.EncodeToString(user.Salt)).
-		WillReturnResult(sqlmock.NewResult(1, 1))
-
-	mock.ExpectQuery("SELECT id FROM usertable").
-		WithArgs(user.Email).
-		WillReturnError(errors.New("query id failed"))
-
-	token, err := database.RegisterUser(ctx, user)
+	token, err := database.RegisterUser(ctx, "opaque-token")
 	require.Error(t, err)
-	require.Contains(t, err.Error(), "query id failed")
+	require.Contains(t, err.Error(), "insert session failed")
 	require.Empty(t, token)
+	require.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestValidUser_Success(t *testing.T) {
@@ -396,10 +363,56 @@ func TestValidUser_Success(t *testing.T) {
 	mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM usertable WHERE email = \\$1\\)").
 		WithArgs(user.Email).
 		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM pending_registrations WHERE expire < NOW\\(\\)").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec("INSERT INTO pending_registrations .* ON CONFLICT \\(email\\) DO NOTHING").
+		WithArgs(user.Email, user.Name, user.Password, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	// письмо с подтверждением записывается в outbox в той же транзакции
+	mock.ExpectExec("INSERT INTO outbox_events").
+		WithArgs(sqlmock.AnyArg(), OutboxSource, usermodels.MailTopic, user.Email, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
 
 	token, err := database.ValidUser(ctx, user)
 	require.NoError(t, err)
 	require.NotEmpty(t, token)
+	require.NotContains(t, token, user.Password)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestValidUser_RegistrationPending(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	database := &Database{conn: db}
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	user := &usermodels.User{
+		Email:    "spam@example.com",
+		Name:     "Alice",
+		Password: "securehash",
+	}
+
+	mock.ExpectQuery("SELECT EXISTS\\(SELECT 1 FROM usertable WHERE email = \\$1\\)").
+		WithArgs(user.Email).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+	// у email уже есть действующая заявка: её пароль не заменяется, письмо не отправляется
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM pending_registrations WHERE expire < NOW\\(\\)").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("INSERT INTO pending_registrations").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	token, err := database.ValidUser(ctx, user)
+	require.Error(t, err)
+	require.Equal(t, "registration pending", err.Error())
+	require.Empty(t, token)
+
+	require.NoError(t, mock.ExpectationsWereMet())
 }
 
 func TestValidUser_EmailExists(t *testing.T) {
@@ -450,41 +463,70 @@ func TestValidUser_DBError(t *testing.T) {
 	require.Empty(t, token)
 }
 
-func TestGetUserByToken_Success(t *testing.T) {
-	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
-	database := &Database{
-		SESSION_SECRET: "mysecret",
-	}
-
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"name":     "Alice",
-		"email":    "alice@example.com",
-		"password": "hashedpassword",
-		"expire":   time.Now().Add(time.Hour).Unix(),
-	})
-
-	tokenStr, err := token.SignedString([]byte(database.SESSION_SECRET))
+func TestRefreshRegistrationToken_Success(t *testing.T) {
+	db, mock, err := sqlmock.New()
 	require.NoError(t, err)
 
-	user, err := database.GetUserByToken(ctx, tokenStr)
+	database := &Database{conn: db}
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+	email := "alice@example.com"
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("UPDATE pending_registrations SET token_hash = \\$1, expire = \\$2, resend_count = resend_count \\+ 1, last_sent_at = NOW\\(\\) "+
+		"WHERE email = \\$3 AND expire > NOW\\(\\) AND resend_count < \\$4 AND last_sent_at < NOW\\(\\) - \\$5 \\* INTERVAL '1 second' RETURNING name, email").
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), email, MaxConfirmationResends, ConfirmationResendCooldown.Seconds()).
+		WillReturnRows(sqlmock.NewRows([]string{"name", "email"}).AddRow("Alice", email))
+	mock.ExpectExec("INSERT INTO outbox_events").
+		WithArgs(sqlmock.AnyArg(), OutboxSource, usermodels.MailTopic, email, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	user, token, err := database.RefreshRegistrationToken(ctx, email)
 	require.NoError(t, err)
-	require.NotNil(t, user)
 	require.Equal(t, "Alice", user.Name)
-	require.Equal(t, "alice@example.com", user.Email)
-	require.Equal(t, "hashedpassword", user.Password)
+	require.Equal(t, email, user.Email)
+	require.NotEmpty(t, token)
+
+	require.NoError(t, mock.ExpectationsWereMet())
 }
 
-func TestGetUserByToken_InvalidToken(t *testing.T) {
-	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
-	database := &Database{
-		SESSION_SECRET: "mysecret",
+func TestRefreshRegistrationToken_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		rows        *sqlmock.Rows
+		expectedErr string
+	}{
+		{name: "not found", rows: sqlmock.NewRows([]string{"limit_exceeded"}), expectedErr: "registration not found"},
+		{name: "limit", rows: sqlmock.NewRows([]string{"limit_exceeded"}).AddRow(true), expectedErr: "resend limit exceeded"},
+		{name: "cooldown", rows: sqlmock.NewRows([]string{"limit_exceeded"}).AddRow(false), expectedErr: "resend too early"},
 	}
 
-	invalidToken := "not.a.real.token"
-
-	user, err := database.GetUserByToken(ctx, invalidToken)
-	require.Error(t, err)
-	require.Nil(t, user)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			database := &Database{conn: db}
+			ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+			// UPDATE не затронул строку, причина определяется отдельным запросом, письмо не отправляется
+			mock.ExpectBegin()
+			mock.ExpectQuery("UPDATE pending_registrations").
+				WillReturnRows(sqlmock.NewRows([]string{"name", "email"}))
+			mock.ExpectQuery("SELECT resend_count >= \\$2 FROM pending_registrations WHERE email = \\$1 AND expire > NOW\\(\\)").
+				WithArgs("alice@example.com", MaxConfirmationResends).
+				WillReturnRows(tt.rows)
+			mock.ExpectRollback()
+
+			user, token, err := database.RefreshRegistrationToken(ctx, "alice@example.com")
+			require.Error(t, err)
+			require.Equal(t, tt.expectedErr, err.Error())
+			require.Nil(t, user)
+			require.Empty(t, token)
+
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
 }
 
 // func TestGetUserByCookie_Success(t *testing.T) {
@@ -583,10 +625,12 @@ func TestUpdateProfile(t *testing.T) {
 		Name:      "New Name",
 		Bio:       "Updated bio",
 		HideEmail: true,
+		Locale:    "en",
+		Timezone:  "Asia/Tokyo",
 	}
 
-	mock.ExpectExec("UPDATE usertable SET email = \\$1, name = \\$2, bio = \\$3, hide_email = \\$4 WHERE id = \\$5").
-		WithArgs(profile.Email, profile.Name, profile.Bio, profile.HideEmail, userId).
+	mock.ExpectExec("UPDATE usertable SET email = \\$1, name = \\$2, bio = \\$3, hide_email = \\$4, locale = COALESCE\\(NULLIF\\(\\$5, ''\\), locale\\), timezone = COALESCE\\(NULLIF\\(\\$6, ''\\), timezone\\) WHERE id = \\$7").
+		WithArgs(profile.Email, profile.Name, profile.Bio, profile.HideEmail, profile.Locale, profile.Timezone, userId).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
 	err = database.UpdateProfile(ctx, userId, profile)
@@ -628,7 +672,7 @@ func TestDeleteProfilePhoto(t *testing.T) {
 		WithArgs(defaultAvatar, userId).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
-	err = database.DeleteProfilePhoto(ctx, userId)
+	err = database.DeleteProfilePhoto(ctx, userId, defaultAvatar)
 	require.NoError(t, err)
 
 	require.NoError(t, mock.ExpectationsWereMet())
@@ -673,14 +717,16 @@ func TestGetUserByCookie_Success(t *testing.T) {
 	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
 	cookie := "testcookie"
 
-	mock.ExpectQuery(`SELECT u.id, u.email, u.name, COALESCE\(u.bio, ''\), u.avatar_src, u.hide_email, u.role FROM usertable u JOIN sessions s ON u.id = s.user_id WHERE s.token = \$1 AND s.expire > NOW\(\)`).
+	mock.ExpectQuery(`SELECT u.id, u.email, u.name, COALESCE\(u.bio, ''\), u.avatar_src, u.hide_email, u.locale, u.timezone, ARRAY\(SELECT r.role FROM user_roles r WHERE r.user_id = u.id ORDER BY r.role\) FROM usertable u JOIN sessions s ON u.id = s.user_id WHERE s.token = \$1 AND s.expire > NOW\(\)`).
 		WithArgs(cookie).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "bio", "avatar_src", "hide_email", "role"}).
-			AddRow(1, "user@example.com", "User", "Bio", "/avatars/user.png", false, "admin"))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "bio", "avatar_src", "hide_email", "locale", "timezone", "roles"}).
+			AddRow(1, "user@example.com", "User", "Bio", "/avatars/user.png", false, "ru", "Europe/Moscow", "{admin,author}"))
 
 	userProfile, err := database.GetUserByCookie(ctx, cookie)
 	assert.NoError(t, err)
 	assert.Equal(t, "user@example.com", userProfile.Email)
+	assert.Equal(t, []string{"admin", "author"}, userProfile.Roles)
+	assert.Equal(t, "Europe/Moscow", userProfile.Timezone)
 	assert.True(t, userProfile.IsAdmin)
 }
 
@@ -698,6 +744,33 @@ func TestDeleteProfilePhoto_Success(t *testing.T) {
 		WithArgs(defaultAvatar, userId).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
-	err = database.DeleteProfilePhoto(ctx, userId)
+	err = database.DeleteProfilePhoto(ctx, userId, defaultAvatar)
 	assert.NoError(t, err)
 }
+
+func TestGetUserProfileById(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+
+	database := &Database{conn: db}
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	query := `SELECT u.id, u.email, u.name, COALESCE\(u.bio, ''\), u.avatar_src, u.hide_email, u.locale, u.timezone, ARRAY\(SELECT r.role FROM user_roles r WHERE r.user_id = u.id ORDER BY r.role\) FROM usertable u WHERE u.id = \$1`
+	mock.ExpectQuery(query).
+		WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "name", "bio", "avatar_src", "hide_email", "locale", "timezone", "roles"}).
+			AddRow(3, "author@example.com", "<b>Author</b>", "Bio", "avatar.jpg", false, "ru", "Europe/Moscow", "{admin,author}"))
+	mock.ExpectQuery(query).
+		WithArgs(4).
+		WillReturnError(sql.ErrNoRows)
+
+	userProfile, err := database.GetUserProfileById(ctx, 3)
+	require.NoError(t, err)
+	require.Equal(t, "&lt;b&gt;Author&lt;/b&gt;", userProfile.Name)
+	require.Equal(t, []string{"admin", "author"}, userProfile.Roles)
+	require.True(t, userProfile.IsAdmin)
+
+	_, err = database.GetUserProfileById(ctx, 4)
+	require.EqualError(t, err, "user not found")
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -4,25 +4,39 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"mime/multipart"
 	"skillForce/config"
 	usermodels "skillForce/internal/models/user"
 	"skillForce/internal/repository/kafka"
 	"skillForce/internal/repository/minio"
 	"skillForce/internal/repository/postgres"
+	"skillForce/pkg/outbox"
+	"skillForce/pkg/storage"
+	"time"
 )
 
 type UserInfrastructure struct {
 	Database      *postgres.Database
 	Minio         *minio.Minio
 	KafkaProducer *kafka.Producer
+	OutboxRelay   *outbox.Relay
+	// события писем, по которым создаются уведомления в приложении
+	NotificationConsumer *kafka.Consumer
 }
 
 func NewUserInfrastructure(conf *config.Config) *UserInfrastructure {
-	mn, err := minio.NewMinio(conf.Minio.Endpoint, conf.Minio.AccessKey, conf.Minio.SecretAccessKey, conf.Minio.UseSSL, conf.Minio.BucketName, conf.Minio.VideoBucket)
+	store, err := storage.New(storage.Config{
+		Backend:         conf.Storage.Backend,
+		Endpoint:        conf.Minio.Endpoint,
+		AccessKey:       conf.Minio.AccessKey,
+		SecretAccessKey: conf.Minio.SecretAccessKey,
+		UseSSL:          conf.Minio.UseSSL,
+		LocalDir:        conf.Storage.LocalDir,
+		PublicURL:       conf.Storage.PublicURL,
+	})
 	if err != nil {
-		log.Fatalf("Failed to connect to MinIO: %v", err)
+		log.Fatalf("Failed to connect to object storage: %v", err)
 	}
+	mn := minio.NewMinio(store, conf.Storage.PublicURL, conf.Minio.BucketName, conf.Minio.VideoBucket, conf.Minio.ExportBucket)
 
 	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable", conf.Database.Host, conf.Database.Port, conf.Database.User, conf.Database.Password, conf.Database.Name)
 	database, err := postgres.NewDatabase(dsn, conf.Secrets.JwtSessionSecret)
@@ -30,21 +44,29 @@ func NewUserInfrastructure(conf *config.Config) *UserInfrastructure {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 
-	kafkaProducer := kafka.NewKafkaProducer()
+	kafkaProducer := kafka.NewKafkaProducer(conf.Kafka.Brokers)
+	relay := database.NewOutboxRelay(kafkaProducer, outbox.Config{
+		Interval:  conf.Outbox.Interval,
+		BatchSize: conf.Outbox.BatchSize,
+		Retention: conf.Outbox.Retention,
+	})
 	return &UserInfrastructure{
-		Database:      database,
-		Minio:         mn,
-		KafkaProducer: kafkaProducer,
+		Database:             database,
+		Minio:                mn,
+		KafkaProducer:        kafkaProducer,
+		OutboxRelay:          relay,
+		NotificationConsumer: kafka.NewKafkaConsumer(conf.Kafka.Brokers, usermodels.NotificationsGroup, usermodels.MailTopic),
 	}
 }
 
 func (u *UserInfrastructure) Close() {
+	u.NotificationConsumer.Close()
 	u.Database.Close()
 	u.KafkaProducer.Close()
 }
 
-func (u *UserInfrastructure) RegisterUser(ctx context.Context, user *usermodels.User) (string, error) {
-	return u.Database.RegisterUser(ctx, user)
+func (u *UserInfrastructure) RegisterUser(ctx context.Context, token string) (string, error) {
+	return u.Database.RegisterUser(ctx, token)
 }
 
 func (u *UserInfrastructure) AuthenticateUser(ctx context.Context, email, password string) (string, error) {
@@ -63,8 +85,8 @@ func (i *UserInfrastructure) UpdateProfile(ctx context.Context, userId int, user
 	return i.Database.UpdateProfile(ctx, userId, userProfile)
 }
 
-func (i *UserInfrastructure) UploadFile(ctx context.Context, file multipart.File, fileHeader *multipart.FileHeader) (string, error) {
-	return i.Minio.UploadFileToMinIO(ctx, file, fileHeader)
+func (i *UserInfrastructure) UploadAvatar(ctx context.Context, thumbnails map[int][]byte) (string, error) {
+	return i.Minio.UploadAvatar(ctx, thumbnails)
 }
 
 func (i *UserInfrastructure) UpdateProfilePhoto(ctx context.Context, photo_url string, userId int) (string, error) {
@@ -72,17 +94,102 @@ func (i *UserInfrastructure) UpdateProfilePhoto(ctx context.Context, photo_url s
 }
 
 func (i *UserInfrastructure) DeleteProfilePhoto(ctx context.Context, userId int) error {
-	return i.Database.DeleteProfilePhoto(ctx, userId)
+	return i.Database.DeleteProfilePhoto(ctx, userId, i.Minio.DefaultAvatarURL())
 }
 
 func (i *UserInfrastructure) ValidUser(ctx context.Context, user *usermodels.User) (string, error) {
 	return i.Database.ValidUser(ctx, user)
 }
 
-func (i *UserInfrastructure) GetUserByToken(ctx context.Context, token string) (*usermodels.User, error) {
-	return i.Database.GetUserByToken(ctx, token)
+func (i *UserInfrastructure) RefreshRegistrationToken(ctx context.Context, email string) (*usermodels.User, string, error) {
+	return i.Database.RefreshRegistrationToken(ctx, email)
 }
 
-func (i *UserInfrastructure) SendRegMail(ctx context.Context, user *usermodels.User, token string) error {
-	return i.KafkaProducer.SendRegMail(ctx, user, token)
+func (i *UserInfrastructure) GetUserById(ctx context.Context, userId int) (*usermodels.User, error) {
+	return i.Database.GetUserById(ctx, userId)
+}
+
+func (i *UserInfrastructure) GetUserProfileById(ctx context.Context, userId int) (*usermodels.UserProfile, error) {
+	return i.Database.GetUserProfileById(ctx, userId)
+}
+
+func (i *UserInfrastructure) GetUserRoles(ctx context.Context, userId int) ([]string, error) {
+	return i.Database.GetUserRoles(ctx, userId)
+}
+
+func (i *UserInfrastructure) GrantRole(ctx context.Context, userId int, role string, grantedBy int) error {
+	return i.Database.GrantRole(ctx, userId, role, grantedBy)
+}
+
+func (i *UserInfrastructure) RevokeRole(ctx context.Context, userId int, role string) error {
+	return i.Database.RevokeRole(ctx, userId, role)
+}
+
+func (i *UserInfrastructure) GetFavouriteCourseRefs(ctx context.Context, userId int) ([]*usermodels.CourseRef, error) {
+	return i.Database.GetFavouriteCourseRefs(ctx, userId)
+}
+
+func (i *UserInfrastructure) GetPurchasedCourseRefs(ctx context.Context, userId int) ([]*usermodels.CourseRef, error) {
+	return i.Database.GetPurchasedCourseRefs(ctx, userId)
+}
+
+func (i *UserInfrastructure) ReserveDataExport(ctx context.Context, userId int) error {
+	return i.Database.ReserveDataExport(ctx, userId)
+}
+
+func (i *UserInfrastructure) ReleaseDataExport(ctx context.Context, userId int) error {
+	return i.Database.ReleaseDataExport(ctx, userId)
+}
+
+func (i *UserInfrastructure) UploadDataExport(ctx context.Context, userId int, archive []byte) (string, error) {
+	return i.Minio.UploadDataExport(ctx, userId, archive)
+}
+
+func (i *UserInfrastructure) SendDataExportMail(ctx context.Context, user *usermodels.User, url string) error {
+	return i.Database.EnqueueDataExportMail(ctx, user, url)
+}
+
+func (i *UserInfrastructure) ScheduleAccountDeletion(ctx context.Context, userId int, deleteAfter time.Time) (time.Time, error) {
+	return i.Database.ScheduleAccountDeletion(ctx, userId, deleteAfter)
+}
+
+func (i *UserInfrastructure) CancelAccountDeletion(ctx context.Context, userId int) error {
+	return i.Database.CancelAccountDeletion(ctx, userId)
+}
+
+func (i *UserInfrastructure) GetAccountsToPurge(ctx context.Context) ([]int, error) {
+	return i.Database.GetAccountsToPurge(ctx)
+}
+
+// PurgeUser - архивы выгрузки удаляются раньше данных в базе: если удалить их не удалось,
+// пользователь не помечается удалённым и удаление повторится при следующем запуске
+func (i *UserInfrastructure) PurgeUser(ctx context.Context, userId int) error {
+	if err := i.Minio.DeleteDataExports(ctx, userId); err != nil {
+		return fmt.Errorf("delete data exports: %w", err)
+	}
+	return i.Database.PurgeUser(ctx, userId, i.Minio.DefaultAvatarURL())
+}
+
+func (i *UserInfrastructure) GetNotificationPreferences(ctx context.Context, userId int) (map[string]bool, error) {
+	return i.Database.GetNotificationPreferences(ctx, userId)
+}
+
+func (i *UserInfrastructure) UpdateNotificationPreferences(ctx context.Context, userId int, prefs []*usermodels.NotificationPreference) error {
+	return i.Database.UpdateNotificationPreferences(ctx, userId, prefs)
+}
+
+func (i *UserInfrastructure) AddNotification(ctx context.Context, notification *usermodels.Notification) (bool, error) {
+	return i.Database.AddNotification(ctx, notification)
+}
+
+func (i *UserInfrastructure) ListNotifications(ctx context.Context, userId int, beforeId int64, afterId int64, limit int) ([]*usermodels.Notification, error) {
+	return i.Database.ListNotifications(ctx, userId, beforeId, afterId, limit)
+}
+
+func (i *UserInfrastructure) GetUnreadNotificationCount(ctx context.Context, userId int) (int, error) {
+	return i.Database.GetUnreadNotificationCount(ctx, userId)
+}
+
+func (i *UserInfrastructure) MarkNotificationsRead(ctx context.Context, userId int, ids []int64, all bool) error {
+	return i.Database.MarkNotificationsRead(ctx, userId, ids, all)
 }
@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	usermodels "skillForce/internal/models/user"
+	"skillForce/pkg/logs"
+	"strings"
+	"time"
+)
+
+// AccountDeletionGracePeriod - срок, в течение которого удаление аккаунта можно отменить
+const AccountDeletionGracePeriod = 30 * 24 * time.Hour
+
+// RequestDataExport - сбор данных пользователя в zip архив. Файлы других сервисов приходят в files,
+// архив загружается в MinIO и письмо со ссылкой ставится в outbox до ответа, ошибки возвращаются клиенту.
+// Выгрузку можно запросить не чаще раза в DataExportCooldown, неудачная попытка интервал не занимает
+func (uc *UserUsecase) RequestDataExport(ctx context.Context, userId int, files []*usermodels.DataFile) error {
+	user, err := uc.repo.GetUserById(ctx, userId)
+	if err != nil {
+		logs.PrintLog(ctx, "RequestDataExport", fmt.Sprintf("%+v", err))
+		return errors.New("user not found")
+	}
+
+	if err := uc.repo.ReserveDataExport(ctx, userId); err != nil {
+		logs.PrintLog(ctx, "RequestDataExport", fmt.Sprintf("%+v", err))
+		return err
+	}
+
+	if err := uc.sendDataExport(ctx, user, userId, files); err != nil {
+		logs.PrintLog(ctx, "RequestDataExport", fmt.Sprintf("user: %d, error: %+v", userId, err))
+		if err := uc.repo.ReleaseDataExport(ctx, userId); err != nil {
+			logs.PrintLog(ctx, "RequestDataExport", fmt.Sprintf("release export of user %d: %+v", userId, err))
+		}
+		return err
+	}
+	return nil
+}
+
+func (uc *UserUsecase) collectUserData(ctx context.Context, userId int) ([]*usermodels.DataFile, error) {
+	profile, err := uc.repo.GetUserProfileById(ctx, userId)
+	if err != nil {
+		logs.PrintLog(ctx, "RequestDataExport", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+
+	favourites, err := uc.repo.GetFavouriteCourseRefs(ctx, userId)
+	if err != nil {
+		logs.PrintLog(ctx, "RequestDataExport", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+
+	purchased, err := uc.repo.GetPurchasedCourseRefs(ctx, userId)
+	if err != nil {
+		logs.PrintLog(ctx, "RequestDataExport", fmt.Sprintf("%+v", err))
+		return nil, err
+	}
+
+	parts := []struct {
+		name  string
+		value interface{}
+	}{
+		{"profile.json", profile},
+		{"favourite_courses.json", favourites},
+		{"purchased_courses.json", purchased},
+	}
+
+	files := make([]*usermodels.DataFile, 0, len(parts))
+	for _, part := range parts {
+		content, err := json.MarshalIndent(part.value, "", "  ")
+		if err != nil {
+			logs.PrintLog(ctx, "RequestDataExport", fmt.Sprintf("%+v", err))
+			return nil, err
+		}
+		files = append(files, &usermodels.DataFile{Name: part.name, Content: content})
+	}
+	return files, nil
+}
+
+func (uc *UserUsecase) sendDataExport(ctx context.Context, user *usermodels.User, userId int, files []*usermodels.DataFile) error {
+	userFiles, err := uc.collectUserData(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	archive, err := buildDataExportArchive(append(userFiles, files...))
+	if err != nil {
+		return err
+	}
+
+	url, err := uc.repo.UploadDataExport(ctx, userId, archive)
+	if err != nil {
+		return err
+	}
+	return uc.repo.SendDataExportMail(ctx, user, url)
+}
+
+// buildDataExportArchive - zip архив из файлов выгрузки. Имена файлов должны быть
+// относительными путями без выхода за пределы архива и не должны повторяться
+func buildDataExportArchive(files []*usermodels.DataFile) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+
+	names := make(map[string]struct{}, len(files))
+	for _, file := range files {
+		name := path.Clean(file.Name)
+		if file.Name == "" || path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return nil, fmt.Errorf("invalid export file name %q", file.Name)
+		}
+		if _, ok := names[name]; ok {
+			return nil, fmt.Errorf("duplicate export file name %q", file.Name)
+		}
+		names[name] = struct{}{}
+
+		w, err := writer.Create(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(file.Content); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// DeleteAccount - запрос на удаление аккаунта. Данные удаляются фоновыми задачами сервисов
+// после AccountDeletionGracePeriod, до этого удаление можно отменить
+func (uc *UserUsecase) DeleteAccount(ctx context.Context, userId int) (time.Time, error) {
+	return uc.repo.ScheduleAccountDeletion(ctx, userId, time.Now().Add(AccountDeletionGracePeriod))
+}
+
+func (uc *UserUsecase) CancelAccountDeletion(ctx context.Context, userId int) error {
+	return uc.repo.CancelAccountDeletion(ctx, userId)
+}
+
+// PurgeDeletedAccounts - анонимизация аккаунтов, курсовые данные которых уже удалены
+func (uc *UserUsecase) PurgeDeletedAccounts(ctx context.Context) error {
+	userIds, err := uc.repo.GetAccountsToPurge(ctx)
+	if err != nil {
+		logs.PrintLog(ctx, "PurgeDeletedAccounts", fmt.Sprintf("%+v", err))
+		return err
+	}
+
+	for _, userId := range userIds {
+		if err := uc.repo.PurgeUser(ctx, userId); err != nil {
+			logs.PrintLog(ctx, "PurgeDeletedAccounts", fmt.Sprintf("user %d: %+v", userId, err))
+			return err
+		}
+	}
+	return nil
+}
+
+// RunAccountPurger - периодический запуск PurgeDeletedAccounts до отмены контекста
+func (uc *UserUsecase) RunAccountPurger(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		logs.RunJob(ctx, "PurgeDeletedAccounts", func(ctx context.Context) {
+			_ = uc.PurgeDeletedAccounts(ctx)
+		})
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	user "skillForce/internal/models/user"
+	"skillForce/pkg/logs"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildDataExportArchive(t *testing.T) {
+	archive, err := buildDataExportArchive([]*user.DataFile{
+		{Name: "profile.json", Content: []byte(`{"id":1}`)},
+		{Name: "courses/progress.json", Content: []byte(`[]`)},
+	})
+	require.NoError(t, err)
+
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	require.NoError(t, err)
+	require.Len(t, reader.File, 2)
+	require.Equal(t, "courses/progress.json", reader.File[1].Name)
+
+	f, err := reader.File[0].Open()
+	require.NoError(t, err)
+	content, err := io.ReadAll(f)
+	require.NoError(t, err)
+	require.Equal(t, `{"id":1}`, string(content))
+}
+
+func TestBuildDataExportArchive_InvalidNames(t *testing.T) {
+	for _, names := range [][]string{
+		{""},
+		{"../secret"},
+		{"/etc/passwd"},
+		{"profile.json", "./profile.json"},
+	} {
+		files := make([]*user.DataFile, 0, len(names))
+		for _, name := range names {
+			files = append(files, &user.DataFile{Name: name})
+		}
+		_, err := buildDataExportArchive(files)
+		require.Error(t, err, "names: %v", names)
+	}
+}
+
+func TestRequestDataExport_UserNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockUserRepository(ctrl)
+	uc := NewUserUsecase(mockRepo)
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	mockRepo.EXPECT().GetUserById(ctx, 1).Return(nil, errors.New("sql: no rows in result set"))
+
+	err := uc.RequestDataExport(ctx, 1, nil)
+	require.EqualError(t, err, "user not found")
+}
+
+func TestRequestDataExport(t *testing.T) {
+	u := &user.User{Id: 1, Email: "test@mail.ru", Name: "Test"}
+	files := []*user.DataFile{{Name: "courses/progress.json", Content: []byte(`[]`)}}
+
+	tests := []struct {
+		name       string
+		reserveErr error
+		uploadErr  error
+		wantErr    string
+	}{
+		{name: "sent"},
+		{name: "too early", reserveErr: errors.New("export too early"), wantErr: "export too early"},
+		{name: "upload failed", uploadErr: errors.New("minio unavailable"), wantErr: "minio unavailable"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := NewMockUserRepository(ctrl)
+			uc := NewUserUsecase(mockRepo)
+			ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+			mockRepo.EXPECT().GetUserById(ctx, 1).Return(u, nil)
+			mockRepo.EXPECT().ReserveDataExport(ctx, 1).Return(tt.reserveErr)
+			if tt.reserveErr == nil {
+				mockRepo.EXPECT().GetUserProfileById(ctx, 1).Return(&user.UserProfile{Id: 1}, nil)
+				mockRepo.EXPECT().GetFavouriteCourseRefs(ctx, 1).Return([]*user.CourseRef{}, nil)
+				mockRepo.EXPECT().GetPurchasedCourseRefs(ctx, 1).Return([]*user.CourseRef{}, nil)
+				mockRepo.EXPECT().UploadDataExport(ctx, 1, gomock.Any()).Return("http://minio/exports/1.zip", tt.uploadErr)
+			}
+			if tt.reserveErr == nil && tt.uploadErr == nil {
+				mockRepo.EXPECT().SendDataExportMail(ctx, u, "http://minio/exports/1.zip").Return(nil)
+			}
+			// неудачная выгрузка не должна занимать интервал до следующего запроса
+			if tt.uploadErr != nil {
+				mockRepo.EXPECT().ReleaseDataExport(ctx, 1).Return(nil)
+			}
+
+			err := uc.RequestDataExport(ctx, 1, files)
+			if tt.wantErr != "" {
+				require.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDeleteAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockUserRepository(ctrl)
+	uc := NewUserUsecase(mockRepo)
+	ctx := context.Background()
+
+	scheduled := time.Now().Add(AccountDeletionGracePeriod)
+	mockRepo.EXPECT().ScheduleAccountDeletion(ctx, 1, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, userId int, deleteAfter time.Time) (time.Time, error) {
+			require.WithinDuration(t, scheduled, deleteAfter, time.Minute)
+			return deleteAfter, nil
+		})
+
+	deleteAfter, err := uc.DeleteAccount(ctx, 1)
+	require.NoError(t, err)
+	require.WithinDuration(t, scheduled, deleteAfter, time.Minute)
+}
+
+func TestPurgeDeletedAccounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockUserRepository(ctrl)
+	uc := NewUserUsecase(mockRepo)
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	mockRepo.EXPECT().GetAccountsToPurge(ctx).Return([]int{2, 3}, nil)
+	mockRepo.EXPECT().PurgeUser(ctx, 2).Return(nil)
+	mockRepo.EXPECT().PurgeUser(ctx, 3).Return(nil)
+
+	require.NoError(t, uc.PurgeDeletedAccounts(ctx))
+}
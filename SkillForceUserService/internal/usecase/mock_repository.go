@@ -6,9 +6,9 @@ package usecase
 
 import (
 	context "context"
-	multipart "mime/multipart"
 	reflect "reflect"
 	user "skillForce/internal/models/user"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 )
@@ -36,6 +36,21 @@ func (m *MockUserRepository) EXPECT() *MockUserRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddNotification mocks base method.
+func (m *MockUserRepository) AddNotification(ctx context.Context, notification *user.Notification) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddNotification", ctx, notification)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddNotification indicates an expected call of AddNotification.
+func (mr *MockUserRepositoryMockRecorder) AddNotification(ctx, notification interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNotification", reflect.TypeOf((*MockUserRepository)(nil).AddNotification), ctx, notification)
+}
+
 // AuthenticateUser mocks base method.
 func (m *MockUserRepository) AuthenticateUser(ctx context.Context, email, password string) (string, error) {
 	m.ctrl.T.Helper()
@@ -51,6 +66,20 @@ func (mr *MockUserRepositoryMockRecorder) AuthenticateUser(ctx, email, password
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthenticateUser", reflect.TypeOf((*MockUserRepository)(nil).AuthenticateUser), ctx, email, password)
 }
 
+// CancelAccountDeletion mocks base method.
+func (m *MockUserRepository) CancelAccountDeletion(ctx context.Context, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelAccountDeletion", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelAccountDeletion indicates an expected call of CancelAccountDeletion.
+func (mr *MockUserRepositoryMockRecorder) CancelAccountDeletion(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelAccountDeletion", reflect.TypeOf((*MockUserRepository)(nil).CancelAccountDeletion), ctx, userId)
+}
+
 // DeleteProfilePhoto mocks base method.
 func (m *MockUserRepository) DeleteProfilePhoto(ctx context.Context, userId int) error {
 	m.ctrl.T.Helper()
@@ -65,6 +94,81 @@ func (mr *MockUserRepositoryMockRecorder) DeleteProfilePhoto(ctx, userId interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProfilePhoto", reflect.TypeOf((*MockUserRepository)(nil).DeleteProfilePhoto), ctx, userId)
 }
 
+// GetAccountsToPurge mocks base method.
+func (m *MockUserRepository) GetAccountsToPurge(ctx context.Context) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountsToPurge", ctx)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountsToPurge indicates an expected call of GetAccountsToPurge.
+func (mr *MockUserRepositoryMockRecorder) GetAccountsToPurge(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountsToPurge", reflect.TypeOf((*MockUserRepository)(nil).GetAccountsToPurge), ctx)
+}
+
+// GetFavouriteCourseRefs mocks base method.
+func (m *MockUserRepository) GetFavouriteCourseRefs(ctx context.Context, userId int) ([]*user.CourseRef, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFavouriteCourseRefs", ctx, userId)
+	ret0, _ := ret[0].([]*user.CourseRef)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFavouriteCourseRefs indicates an expected call of GetFavouriteCourseRefs.
+func (mr *MockUserRepositoryMockRecorder) GetFavouriteCourseRefs(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFavouriteCourseRefs", reflect.TypeOf((*MockUserRepository)(nil).GetFavouriteCourseRefs), ctx, userId)
+}
+
+// GetNotificationPreferences mocks base method.
+func (m *MockUserRepository) GetNotificationPreferences(ctx context.Context, userId int) (map[string]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotificationPreferences", ctx, userId)
+	ret0, _ := ret[0].(map[string]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotificationPreferences indicates an expected call of GetNotificationPreferences.
+func (mr *MockUserRepositoryMockRecorder) GetNotificationPreferences(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotificationPreferences", reflect.TypeOf((*MockUserRepository)(nil).GetNotificationPreferences), ctx, userId)
+}
+
+// GetPurchasedCourseRefs mocks base method.
+func (m *MockUserRepository) GetPurchasedCourseRefs(ctx context.Context, userId int) ([]*user.CourseRef, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPurchasedCourseRefs", ctx, userId)
+	ret0, _ := ret[0].([]*user.CourseRef)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPurchasedCourseRefs indicates an expected call of GetPurchasedCourseRefs.
+func (mr *MockUserRepositoryMockRecorder) GetPurchasedCourseRefs(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPurchasedCourseRefs", reflect.TypeOf((*MockUserRepository)(nil).GetPurchasedCourseRefs), ctx, userId)
+}
+
+// GetUnreadNotificationCount mocks base method.
+func (m *MockUserRepository) GetUnreadNotificationCount(ctx context.Context, userId int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnreadNotificationCount", ctx, userId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnreadNotificationCount indicates an expected call of GetUnreadNotificationCount.
+func (mr *MockUserRepositoryMockRecorder) GetUnreadNotificationCount(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnreadNotificationCount", reflect.TypeOf((*MockUserRepository)(nil).GetUnreadNotificationCount), ctx, userId)
+}
+
 // GetUserByCookie mocks base method.
 func (m *MockUserRepository) GetUserByCookie(ctx context.Context, cookieValue string) (*user.UserProfile, error) {
 	m.ctrl.T.Helper()
@@ -80,19 +184,78 @@ func (mr *MockUserRepositoryMockRecorder) GetUserByCookie(ctx, cookieValue inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByCookie", reflect.TypeOf((*MockUserRepository)(nil).GetUserByCookie), ctx, cookieValue)
 }
 
-// GetUserByToken mocks base method.
-func (m *MockUserRepository) GetUserByToken(ctx context.Context, token string) (*user.User, error) {
+// GetUserById mocks base method.
+func (m *MockUserRepository) GetUserById(ctx context.Context, userId int) (*user.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByToken", ctx, token)
+	ret := m.ctrl.Call(m, "GetUserById", ctx, userId)
 	ret0, _ := ret[0].(*user.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserByToken indicates an expected call of GetUserByToken.
-func (mr *MockUserRepositoryMockRecorder) GetUserByToken(ctx, token interface{}) *gomock.Call {
+// GetUserById indicates an expected call of GetUserById.
+func (mr *MockUserRepositoryMockRecorder) GetUserById(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserById", reflect.TypeOf((*MockUserRepository)(nil).GetUserById), ctx, userId)
+}
+
+// GetUserProfileById mocks base method.
+func (m *MockUserRepository) GetUserProfileById(ctx context.Context, userId int) (*user.UserProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserProfileById", ctx, userId)
+	ret0, _ := ret[0].(*user.UserProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserProfileById indicates an expected call of GetUserProfileById.
+func (mr *MockUserRepositoryMockRecorder) GetUserProfileById(ctx, userId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByToken", reflect.TypeOf((*MockUserRepository)(nil).GetUserByToken), ctx, token)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserProfileById", reflect.TypeOf((*MockUserRepository)(nil).GetUserProfileById), ctx, userId)
+}
+
+// GetUserRoles mocks base method.
+func (m *MockUserRepository) GetUserRoles(ctx context.Context, userId int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserRoles", ctx, userId)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserRoles indicates an expected call of GetUserRoles.
+func (mr *MockUserRepositoryMockRecorder) GetUserRoles(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRoles", reflect.TypeOf((*MockUserRepository)(nil).GetUserRoles), ctx, userId)
+}
+
+// GrantRole mocks base method.
+func (m *MockUserRepository) GrantRole(ctx context.Context, userId int, role string, grantedBy int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantRole", ctx, userId, role, grantedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GrantRole indicates an expected call of GrantRole.
+func (mr *MockUserRepositoryMockRecorder) GrantRole(ctx, userId, role, grantedBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantRole", reflect.TypeOf((*MockUserRepository)(nil).GrantRole), ctx, userId, role, grantedBy)
+}
+
+// ListNotifications mocks base method.
+func (m *MockUserRepository) ListNotifications(ctx context.Context, userId int, beforeId, afterId int64, limit int) ([]*user.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotifications", ctx, userId, beforeId, afterId, limit)
+	ret0, _ := ret[0].([]*user.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotifications indicates an expected call of ListNotifications.
+func (mr *MockUserRepositoryMockRecorder) ListNotifications(ctx, userId, beforeId, afterId, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotifications", reflect.TypeOf((*MockUserRepository)(nil).ListNotifications), ctx, userId, beforeId, afterId, limit)
 }
 
 // LogoutUser mocks base method.
@@ -109,47 +272,148 @@ func (mr *MockUserRepositoryMockRecorder) LogoutUser(ctx, userId interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutUser", reflect.TypeOf((*MockUserRepository)(nil).LogoutUser), ctx, userId)
 }
 
+// MarkNotificationsRead mocks base method.
+func (m *MockUserRepository) MarkNotificationsRead(ctx context.Context, userId int, ids []int64, all bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNotificationsRead", ctx, userId, ids, all)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkNotificationsRead indicates an expected call of MarkNotificationsRead.
+func (mr *MockUserRepositoryMockRecorder) MarkNotificationsRead(ctx, userId, ids, all interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationsRead", reflect.TypeOf((*MockUserRepository)(nil).MarkNotificationsRead), ctx, userId, ids, all)
+}
+
+// PurgeUser mocks base method.
+func (m *MockUserRepository) PurgeUser(ctx context.Context, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeUser", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeUser indicates an expected call of PurgeUser.
+func (mr *MockUserRepositoryMockRecorder) PurgeUser(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeUser", reflect.TypeOf((*MockUserRepository)(nil).PurgeUser), ctx, userId)
+}
+
+// RefreshRegistrationToken mocks base method.
+func (m *MockUserRepository) RefreshRegistrationToken(ctx context.Context, email string) (*user.User, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshRegistrationToken", ctx, email)
+	ret0, _ := ret[0].(*user.User)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RefreshRegistrationToken indicates an expected call of RefreshRegistrationToken.
+func (mr *MockUserRepositoryMockRecorder) RefreshRegistrationToken(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshRegistrationToken", reflect.TypeOf((*MockUserRepository)(nil).RefreshRegistrationToken), ctx, email)
+}
+
 // RegisterUser mocks base method.
-func (m *MockUserRepository) RegisterUser(ctx context.Context, user *user.User) (string, error) {
+func (m *MockUserRepository) RegisterUser(ctx context.Context, token string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RegisterUser", ctx, user)
+	ret := m.ctrl.Call(m, "RegisterUser", ctx, token)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // RegisterUser indicates an expected call of RegisterUser.
-func (mr *MockUserRepositoryMockRecorder) RegisterUser(ctx, user interface{}) *gomock.Call {
+func (mr *MockUserRepositoryMockRecorder) RegisterUser(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterUser", reflect.TypeOf((*MockUserRepository)(nil).RegisterUser), ctx, token)
+}
+
+// ReleaseDataExport mocks base method.
+func (m *MockUserRepository) ReleaseDataExport(ctx context.Context, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseDataExport", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseDataExport indicates an expected call of ReleaseDataExport.
+func (mr *MockUserRepositoryMockRecorder) ReleaseDataExport(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseDataExport", reflect.TypeOf((*MockUserRepository)(nil).ReleaseDataExport), ctx, userId)
+}
+
+// ReserveDataExport mocks base method.
+func (m *MockUserRepository) ReserveDataExport(ctx context.Context, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveDataExport", ctx, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReserveDataExport indicates an expected call of ReserveDataExport.
+func (mr *MockUserRepositoryMockRecorder) ReserveDataExport(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveDataExport", reflect.TypeOf((*MockUserRepository)(nil).ReserveDataExport), ctx, userId)
+}
+
+// RevokeRole mocks base method.
+func (m *MockUserRepository) RevokeRole(ctx context.Context, userId int, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeRole", ctx, userId, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeRole indicates an expected call of RevokeRole.
+func (mr *MockUserRepositoryMockRecorder) RevokeRole(ctx, userId, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeRole", reflect.TypeOf((*MockUserRepository)(nil).RevokeRole), ctx, userId, role)
+}
+
+// ScheduleAccountDeletion mocks base method.
+func (m *MockUserRepository) ScheduleAccountDeletion(ctx context.Context, userId int, deleteAfter time.Time) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleAccountDeletion", ctx, userId, deleteAfter)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScheduleAccountDeletion indicates an expected call of ScheduleAccountDeletion.
+func (mr *MockUserRepositoryMockRecorder) ScheduleAccountDeletion(ctx, userId, deleteAfter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterUser", reflect.TypeOf((*MockUserRepository)(nil).RegisterUser), ctx, user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleAccountDeletion", reflect.TypeOf((*MockUserRepository)(nil).ScheduleAccountDeletion), ctx, userId, deleteAfter)
 }
 
-// SendRegMail mocks base method.
-func (m *MockUserRepository) SendRegMail(ctx context.Context, user *user.User, token string) error {
+// SendDataExportMail mocks base method.
+func (m *MockUserRepository) SendDataExportMail(ctx context.Context, user *user.User, url string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SendRegMail", ctx, user, token)
+	ret := m.ctrl.Call(m, "SendDataExportMail", ctx, user, url)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// SendRegMail indicates an expected call of SendRegMail.
-func (mr *MockUserRepositoryMockRecorder) SendRegMail(ctx, user, token interface{}) *gomock.Call {
+// SendDataExportMail indicates an expected call of SendDataExportMail.
+func (mr *MockUserRepositoryMockRecorder) SendDataExportMail(ctx, user, url interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendRegMail", reflect.TypeOf((*MockUserRepository)(nil).SendRegMail), ctx, user, token)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendDataExportMail", reflect.TypeOf((*MockUserRepository)(nil).SendDataExportMail), ctx, user, url)
 }
 
-// SendWelcomeMail mocks base method.
-func (m *MockUserRepository) SendWelcomeMail(ctx context.Context, user *user.User) error {
+// UpdateNotificationPreferences mocks base method.
+func (m *MockUserRepository) UpdateNotificationPreferences(ctx context.Context, userId int, prefs []*user.NotificationPreference) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SendWelcomeMail", ctx, user)
+	ret := m.ctrl.Call(m, "UpdateNotificationPreferences", ctx, userId, prefs)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// SendWelcomeMail indicates an expected call of SendWelcomeMail.
-func (mr *MockUserRepositoryMockRecorder) SendWelcomeMail(ctx, user interface{}) *gomock.Call {
+// UpdateNotificationPreferences indicates an expected call of UpdateNotificationPreferences.
+func (mr *MockUserRepositoryMockRecorder) UpdateNotificationPreferences(ctx, userId, prefs interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendWelcomeMail", reflect.TypeOf((*MockUserRepository)(nil).SendWelcomeMail), ctx, user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNotificationPreferences", reflect.TypeOf((*MockUserRepository)(nil).UpdateNotificationPreferences), ctx, userId, prefs)
 }
 
 // UpdateProfile mocks base method.
@@ -181,19 +445,34 @@ func (mr *MockUserRepositoryMockRecorder) UpdateProfilePhoto(ctx, url, userId in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfilePhoto", reflect.TypeOf((*MockUserRepository)(nil).UpdateProfilePhoto), ctx, url, userId)
 }
 
-// UploadFile mocks base method.
-func (m *MockUserRepository) UploadFile(ctx context.Context, file multipart.File, fileHeader *multipart.FileHeader) (string, error) {
+// UploadAvatar mocks base method.
+func (m *MockUserRepository) UploadAvatar(ctx context.Context, thumbnails map[int][]byte) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadAvatar", ctx, thumbnails)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadAvatar indicates an expected call of UploadAvatar.
+func (mr *MockUserRepositoryMockRecorder) UploadAvatar(ctx, thumbnails interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadAvatar", reflect.TypeOf((*MockUserRepository)(nil).UploadAvatar), ctx, thumbnails)
+}
+
+// UploadDataExport mocks base method.
+func (m *MockUserRepository) UploadDataExport(ctx context.Context, userId int, archive []byte) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UploadFile", ctx, file, fileHeader)
+	ret := m.ctrl.Call(m, "UploadDataExport", ctx, userId, archive)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UploadFile indicates an expected call of UploadFile.
-func (mr *MockUserRepositoryMockRecorder) UploadFile(ctx, file, fileHeader interface{}) *gomock.Call {
+// UploadDataExport indicates an expected call of UploadDataExport.
+func (mr *MockUserRepositoryMockRecorder) UploadDataExport(ctx, userId, archive interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadFile", reflect.TypeOf((*MockUserRepository)(nil).UploadFile), ctx, file, fileHeader)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadDataExport", reflect.TypeOf((*MockUserRepository)(nil).UploadDataExport), ctx, userId, archive)
 }
 
 // ValidUser mocks base method.
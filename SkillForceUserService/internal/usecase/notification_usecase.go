@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	usermodels "skillForce/internal/models/user"
+	"skillForce/pkg/events"
+	"skillForce/pkg/logs"
+)
+
+// GetNotificationPreferences - настройки уведомлений по всем категориям. Не сохранённые категории включены,
+// транзакционные письма включены всегда
+func (uc *UserUsecase) GetNotificationPreferences(ctx context.Context, userId int) ([]*usermodels.NotificationPreference, error) {
+	saved, err := uc.repo.GetNotificationPreferences(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	prefs := make([]*usermodels.NotificationPreference, 0, len(events.Categories))
+	for _, category := range events.Categories {
+		enabled, ok := saved[category]
+		prefs = append(prefs, &usermodels.NotificationPreference{
+			Category: category,
+			Enabled:  !ok || enabled || category == events.CategoryTransactional,
+		})
+	}
+	return prefs, nil
+}
+
+// UpdateNotificationPreferences - изменение настроек уведомлений. Категории, которых нет в prefs, не меняются
+func (uc *UserUsecase) UpdateNotificationPreferences(ctx context.Context, userId int, prefs []*usermodels.NotificationPreference) ([]*usermodels.NotificationPreference, error) {
+	for _, pref := range prefs {
+		if err := validNotificationPreference(pref); err != nil {
+			logs.PrintLog(ctx, "UpdateNotificationPreferences", fmt.Sprintf("user %d: %+v", userId, err))
+			return nil, err
+		}
+	}
+
+	if err := uc.repo.UpdateNotificationPreferences(ctx, userId, prefs); err != nil {
+		return nil, err
+	}
+	return uc.GetNotificationPreferences(ctx, userId)
+}
+
+// Unsubscribe - отключение категории писем по ссылке из письма
+func (uc *UserUsecase) Unsubscribe(ctx context.Context, userId int, category string) error {
+	pref := &usermodels.NotificationPreference{Category: category, Enabled: false}
+	if err := validNotificationPreference(pref); err != nil {
+		logs.PrintLog(ctx, "Unsubscribe", fmt.Sprintf("user %d: %+v", userId, err))
+		return err
+	}
+
+	if err := uc.repo.UpdateNotificationPreferences(ctx, userId, []*usermodels.NotificationPreference{pref}); err != nil {
+		return err
+	}
+
+	logs.PrintLog(ctx, "Unsubscribe", fmt.Sprintf("user %d unsubscribed from %s", userId, category))
+	return nil
+}
+
+func validNotificationPreference(pref *usermodels.NotificationPreference) error {
+	if !events.IsValidCategory(pref.Category) {
+		return errors.New("invalid notification category")
+	}
+	if pref.Category == events.CategoryTransactional && !pref.Enabled {
+		return errors.New("transactional mails can not be disabled")
+	}
+	return nil
+}
+
+// Размер страницы уведомлений по умолчанию и наибольший
+const (
+	defaultNotificationsLimit = 20
+	maxNotificationsLimit     = 100
+)
+
+// notificationCampaigns - кампании писем course-service, о которых пользователь получает уведомление в приложении.
+// Напоминания (inactivity, abandoned_checkout) остаются только письмами
+var notificationCampaigns = map[string]string{
+	"mid_course":       usermodels.NotificationCourseProgress,
+	"course_completed": usermodels.NotificationCertificateReady,
+}
+
+// HandleNotificationEvent - уведомление в приложении по событию письма из топика mail. События без уведомления
+// и события незарегистрированных получателей пропускаются
+func (uc *UserUsecase) HandleNotificationEvent(ctx context.Context, env *events.Envelope) error {
+	notification := notificationFromEvent(env)
+	if notification == nil {
+		return nil
+	}
+
+	added, err := uc.repo.AddNotification(ctx, notification)
+	if err != nil {
+		return err
+	}
+	if added {
+		logs.PrintLog(ctx, "HandleNotificationEvent", fmt.Sprintf("%s notification for user %d from event %s", notification.Kind, notification.UserId, env.GetId()))
+	}
+	return nil
+}
+
+func notificationFromEvent(env *events.Envelope) *usermodels.Notification {
+	userId := events.GetRecipient(env).GetUserId()
+	if userId <= 0 {
+		return nil
+	}
+
+	notification := &usermodels.Notification{UserId: int(userId), EventId: env.GetId()}
+	switch payload := events.GetPayload(env).(type) {
+	case *events.WelcomeCourseMail:
+		notification.Kind = usermodels.NotificationCourseSignup
+		notification.CourseId = int(payload.GetCourseId())
+		notification.CourseName = payload.GetCourseName()
+	case *events.DataExportMail:
+		notification.Kind = usermodels.NotificationDataExportReady
+		notification.Url = payload.GetUrl()
+	case *events.EngagementMail:
+		kind, ok := notificationCampaigns[payload.GetCampaign()]
+		if !ok {
+			return nil
+		}
+		notification.Kind = kind
+		notification.CourseId = int(payload.GetCourseId())
+		notification.CourseName = payload.GetCourseName()
+	default:
+		return nil
+	}
+	return notification
+}
+
+// ListNotifications - страница уведомлений и число непрочитанных, см. Database.ListNotifications
+func (uc *UserUsecase) ListNotifications(ctx context.Context, userId int, beforeId int64, afterId int64, limit int) ([]*usermodels.Notification, int, error) {
+	if limit <= 0 {
+		limit = defaultNotificationsLimit
+	}
+	if limit > maxNotificationsLimit {
+		limit = maxNotificationsLimit
+	}
+
+	notifications, err := uc.repo.ListNotifications(ctx, userId, beforeId, afterId, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	unread, err := uc.repo.GetUnreadNotificationCount(ctx, userId)
+	if err != nil {
+		return nil, 0, err
+	}
+	return notifications, unread, nil
+}
+
+func (uc *UserUsecase) GetUnreadNotificationCount(ctx context.Context, userId int) (int, error) {
+	return uc.repo.GetUnreadNotificationCount(ctx, userId)
+}
+
+// MarkNotificationsRead - отметка уведомлений ids или всех уведомлений прочитанными. Возвращает число непрочитанных
+func (uc *UserUsecase) MarkNotificationsRead(ctx context.Context, userId int, ids []int64, all bool) (int, error) {
+	if !all && len(ids) == 0 {
+		logs.PrintLog(ctx, "MarkNotificationsRead", fmt.Sprintf("user %d: no notifications to mark", userId))
+		return 0, errors.New("no notifications to mark read")
+	}
+
+	if err := uc.repo.MarkNotificationsRead(ctx, userId, ids, all); err != nil {
+		return 0, err
+	}
+	return uc.repo.GetUnreadNotificationCount(ctx, userId)
+}
@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	user "skillForce/internal/models/user"
+	"skillForce/pkg/events"
+	"skillForce/pkg/logs"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetNotificationPreferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockUserRepository(ctrl)
+	uc := NewUserUsecase(mockRepo)
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	mockRepo.EXPECT().GetNotificationPreferences(ctx, 1).Return(map[string]bool{"marketing": false, "transactional": false}, nil)
+
+	prefs, err := uc.GetNotificationPreferences(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, []*user.NotificationPreference{
+		{Category: "transactional", Enabled: true},
+		{Category: "course_progress", Enabled: true},
+		{Category: "marketing", Enabled: false},
+		{Category: "review_results", Enabled: true},
+		{Category: "digest", Enabled: true},
+	}, prefs)
+}
+
+func TestUpdateNotificationPreferences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockUserRepository(ctrl)
+	uc := NewUserUsecase(mockRepo)
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	update := []*user.NotificationPreference{{Category: "review_results", Enabled: false}}
+	mockRepo.EXPECT().UpdateNotificationPreferences(ctx, 1, update).Return(nil)
+	mockRepo.EXPECT().GetNotificationPreferences(ctx, 1).Return(map[string]bool{"review_results": false}, nil)
+
+	prefs, err := uc.UpdateNotificationPreferences(ctx, 1, update)
+	require.NoError(t, err)
+	require.Len(t, prefs, 5)
+	require.False(t, prefs[3].Enabled)
+}
+
+func TestUpdateNotificationPreferences_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockUserRepository(ctrl)
+	uc := NewUserUsecase(mockRepo)
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	_, err := uc.UpdateNotificationPreferences(ctx, 1, []*user.NotificationPreference{{Category: "spam", Enabled: false}})
+	require.EqualError(t, err, "invalid notification category")
+
+	_, err = uc.UpdateNotificationPreferences(ctx, 1, []*user.NotificationPreference{{Category: "transactional", Enabled: false}})
+	require.EqualError(t, err, "transactional mails can not be disabled")
+}
+
+func TestUnsubscribe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockUserRepository(ctrl)
+	uc := NewUserUsecase(mockRepo)
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	mockRepo.EXPECT().UpdateNotificationPreferences(ctx, 1, []*user.NotificationPreference{{Category: "marketing", Enabled: false}}).Return(nil)
+
+	require.NoError(t, uc.Unsubscribe(ctx, 1, "marketing"))
+	require.EqualError(t, uc.Unsubscribe(ctx, 1, "transactional"), "transactional mails can not be disabled")
+}
+
+func TestHandleNotificationEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockUserRepository(ctrl)
+	uc := NewUserUsecase(mockRepo)
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	recipient := &events.Recipient{Email: "alice@example.com", Name: "Alice", UserId: 4}
+	for _, tc := range []struct {
+		payload  events.Payload
+		expected *user.Notification
+	}{
+		{
+			payload:  &events.WelcomeCourseMail{Recipient: recipient, CourseId: 5, CourseName: "Go"},
+			expected: &user.Notification{UserId: 4, Kind: user.NotificationCourseSignup, CourseId: 5, CourseName: "Go"},
+		},
+		{
+			payload:  &events.EngagementMail{Recipient: recipient, Campaign: "course_completed", CourseId: 5, CourseName: "Go"},
+			expected: &user.Notification{UserId: 4, Kind: user.NotificationCertificateReady, CourseId: 5, CourseName: "Go"},
+		},
+		{
+			payload:  &events.DataExportMail{Recipient: recipient, Url: "https://skill-force.ru/export.zip"},
+			expected: &user.Notification{UserId: 4, Kind: user.NotificationDataExportReady, Url: "https://skill-force.ru/export.zip"},
+		},
+	} {
+		env, err := events.New(ctx, "test", tc.payload)
+		require.NoError(t, err)
+		tc.expected.EventId = env.GetId()
+
+		mockRepo.EXPECT().AddNotification(ctx, tc.expected).Return(true, nil)
+		require.NoError(t, uc.HandleNotificationEvent(ctx, env))
+	}
+}
+
+func TestHandleNotificationEvent_Skip(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockUserRepository(ctrl)
+	uc := NewUserUsecase(mockRepo)
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	// подтверждение регистрации приходит до создания аккаунта, у напоминаний нет уведомления
+	for _, payload := range []events.Payload{
+		&events.ConfirmRegistrationMail{Recipient: &events.Recipient{Email: "alice@example.com", Name: "Alice"}, Token: "token"},
+		&events.EngagementMail{Recipient: &events.Recipient{Email: "alice@example.com", Name: "Alice", UserId: 4}, Campaign: "inactivity", CourseId: 5, CourseName: "Go"},
+	} {
+		env, err := events.New(ctx, "test", payload)
+		require.NoError(t, err)
+		require.NoError(t, uc.HandleNotificationEvent(ctx, env))
+	}
+}
+
+func TestListNotifications(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockUserRepository(ctrl)
+	uc := NewUserUsecase(mockRepo)
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	notifications := []*user.Notification{{Id: 7, UserId: 1, Kind: user.NotificationCourseSignup}}
+	// слишком большая страница уменьшается
+	mockRepo.EXPECT().ListNotifications(ctx, 1, int64(10), int64(0), 100).Return(notifications, nil)
+	mockRepo.EXPECT().GetUnreadNotificationCount(ctx, 1).Return(3, nil)
+
+	list, unread, err := uc.ListNotifications(ctx, 1, 10, 0, 1000)
+	require.NoError(t, err)
+	require.Equal(t, notifications, list)
+	require.Equal(t, 3, unread)
+}
+
+func TestMarkNotificationsRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockUserRepository(ctrl)
+	uc := NewUserUsecase(mockRepo)
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{})
+
+	mockRepo.EXPECT().MarkNotificationsRead(ctx, 1, []int64{7}, false).Return(nil)
+	mockRepo.EXPECT().GetUnreadNotificationCount(ctx, 1).Return(2, nil)
+
+	unread, err := uc.MarkNotificationsRead(ctx, 1, []int64{7}, false)
+	require.NoError(t, err)
+	require.Equal(t, 2, unread)
+
+	_, err = uc.MarkNotificationsRead(ctx, 1, nil, false)
+	require.Error(t, err)
+}
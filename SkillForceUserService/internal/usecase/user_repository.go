@@ -2,22 +2,47 @@ package usecase
 
 import (
 	context "context"
-	multipart "mime/multipart"
 	usermodels "skillForce/internal/models/user"
+	time "time"
 )
 
 type UserRepository interface {
-	RegisterUser(ctx context.Context, user *usermodels.User) (string, error)
+	RegisterUser(ctx context.Context, token string) (string, error)
 	AuthenticateUser(ctx context.Context, email, password string) (string, error)
-	GetUserByToken(ctx context.Context, token string) (*usermodels.User, error)
+	RefreshRegistrationToken(ctx context.Context, email string) (*usermodels.User, string, error)
 	GetUserByCookie(ctx context.Context, cookieValue string) (*usermodels.UserProfile, error)
 	ValidUser(ctx context.Context, user *usermodels.User) (string, error)
 	LogoutUser(ctx context.Context, userId int) error
 
-	UploadFile(ctx context.Context, file multipart.File, fileHeader *multipart.FileHeader) (string, error)
+	UploadAvatar(ctx context.Context, thumbnails map[int][]byte) (string, error)
 	UpdateProfile(ctx context.Context, userId int, userProfile *usermodels.UserProfile) error
 	UpdateProfilePhoto(ctx context.Context, url string, userId int) (string, error)
 	DeleteProfilePhoto(ctx context.Context, userId int) error
 
-	SendRegMail(ctx context.Context, user *usermodels.User, token string) error
+	GetUserById(ctx context.Context, userId int) (*usermodels.User, error)
+	GetUserProfileById(ctx context.Context, userId int) (*usermodels.UserProfile, error)
+	GetUserRoles(ctx context.Context, userId int) ([]string, error)
+	GrantRole(ctx context.Context, userId int, role string, grantedBy int) error
+	RevokeRole(ctx context.Context, userId int, role string) error
+
+	GetFavouriteCourseRefs(ctx context.Context, userId int) ([]*usermodels.CourseRef, error)
+	GetPurchasedCourseRefs(ctx context.Context, userId int) ([]*usermodels.CourseRef, error)
+	ReserveDataExport(ctx context.Context, userId int) error
+	ReleaseDataExport(ctx context.Context, userId int) error
+	UploadDataExport(ctx context.Context, userId int, archive []byte) (string, error)
+
+	ScheduleAccountDeletion(ctx context.Context, userId int, deleteAfter time.Time) (time.Time, error)
+	CancelAccountDeletion(ctx context.Context, userId int) error
+	GetAccountsToPurge(ctx context.Context) ([]int, error)
+	PurgeUser(ctx context.Context, userId int) error
+
+	GetNotificationPreferences(ctx context.Context, userId int) (map[string]bool, error)
+	UpdateNotificationPreferences(ctx context.Context, userId int, prefs []*usermodels.NotificationPreference) error
+
+	AddNotification(ctx context.Context, notification *usermodels.Notification) (bool, error)
+	ListNotifications(ctx context.Context, userId int, beforeId int64, afterId int64, limit int) ([]*usermodels.Notification, error)
+	GetUnreadNotificationCount(ctx context.Context, userId int) (int, error)
+	MarkNotificationsRead(ctx context.Context, userId int, ids []int64, all bool) error
+
+	SendDataExportMail(ctx context.Context, user *usermodels.User, url string) error
 }
@@ -2,9 +2,13 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"mime/multipart"
 	usermodels "skillForce/internal/models/user"
+	"skillForce/pkg/auth"
+	"skillForce/pkg/avatar"
 	"skillForce/pkg/hash"
 	"skillForce/pkg/logs"
 )
@@ -20,32 +24,29 @@ func NewUserUsecase(repo UserRepository) *UserUsecase {
 }
 
 func (uc *UserUsecase) ValidUser(ctx context.Context, user *usermodels.User) error {
-	token, err := uc.repo.ValidUser(ctx, user)
+	err := hash.HashPasswordAndCreateSalt(user)
 	if err != nil {
-		logs.PrintLog(ctx, "ValidUser", fmt.Sprintf("%+v", err))
 		return err
 	}
 
-	go func() {
-		if err := uc.repo.SendRegMail(ctx, user, token); err != nil {
-			logs.PrintLog(ctx, "SendRegMail failed", fmt.Sprintf("user: %s, error: %v", user.Email, err))
-		}
-	}()
+	// письмо с подтверждением ставится в очередь в той же транзакции, что и заявка на регистрацию
+	if _, err := uc.repo.ValidUser(ctx, user); err != nil {
+		logs.PrintLog(ctx, "ValidUser", fmt.Sprintf("%+v", err))
+		return err
+	}
 	return nil
 }
 
-func (uc *UserUsecase) RegisterUser(ctx context.Context, token string) (string, error) {
-	user, err := uc.repo.GetUserByToken(ctx, token)
-	if err != nil {
-		return "", err
-	}
-
-	err = hash.HashPasswordAndCreateSalt(user)
-	if err != nil {
-		return "", err
+func (uc *UserUsecase) ResendConfirmation(ctx context.Context, email string) error {
+	if _, _, err := uc.repo.RefreshRegistrationToken(ctx, email); err != nil {
+		logs.PrintLog(ctx, "ResendConfirmation", fmt.Sprintf("%+v", err))
+		return err
 	}
+	return nil
+}
 
-	return uc.repo.RegisterUser(ctx, user)
+func (uc *UserUsecase) RegisterUser(ctx context.Context, token string) (string, error) {
+	return uc.repo.RegisterUser(ctx, token)
 }
 
 func (uc *UserUsecase) AuthenticateUser(ctx context.Context, user *usermodels.User) (string, error) {
@@ -61,11 +62,33 @@ func (uc *UserUsecase) LogoutUser(ctx context.Context, userId int) error {
 }
 
 func (uc *UserUsecase) UpdateProfile(ctx context.Context, userId int, userProfile *usermodels.UserProfile) error {
+	if userProfile.Locale != "" && !usermodels.IsValidLocale(userProfile.Locale) {
+		return errors.New("invalid locale")
+	}
+	if userProfile.Timezone != "" && !usermodels.IsValidTimezone(userProfile.Timezone) {
+		return errors.New("invalid timezone")
+	}
 	return uc.repo.UpdateProfile(ctx, userId, userProfile)
 }
 
+// UploadFile - загрузка новой аватарки. Content-Type и имя файла от клиента не учитываются:
+// формат определяется по содержимому, в хранилище попадают только перекодированные миниатюры.
+// Возвращает ссылку на самую большую миниатюру
 func (uc *UserUsecase) UploadFile(ctx context.Context, file multipart.File, fileHeader *multipart.FileHeader) (string, error) {
-	return uc.repo.UploadFile(ctx, file, fileHeader)
+	data, err := io.ReadAll(io.LimitReader(file, avatar.MaxFileSize+1))
+	if err != nil {
+		return "", err
+	}
+	if len(data) > avatar.MaxFileSize {
+		return "", avatar.ErrTooLarge
+	}
+
+	thumbnails, err := avatar.Process(data)
+	if err != nil {
+		logs.PrintLog(ctx, "UploadFile", fmt.Sprintf("%+v", err))
+		return "", err
+	}
+	return uc.repo.UploadAvatar(ctx, thumbnails)
 }
 
 func (uc *UserUsecase) SaveProfilePhoto(ctx context.Context, url string, userId int) (string, error) {
@@ -75,3 +98,46 @@ func (uc *UserUsecase) SaveProfilePhoto(ctx context.Context, url string, userId
 func (uc *UserUsecase) DeleteProfilePhoto(ctx context.Context, userId int) error {
 	return uc.repo.DeleteProfilePhoto(ctx, userId)
 }
+
+// GetPublicProfile - профиль, видимый другим пользователям: email скрывается, если пользователь так настроил
+func (uc *UserUsecase) GetPublicProfile(ctx context.Context, userId int) (*usermodels.UserProfile, error) {
+	userProfile, err := uc.repo.GetUserProfileById(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	if userProfile.HideEmail {
+		userProfile.Email = ""
+	}
+	return userProfile, nil
+}
+
+func (uc *UserUsecase) GetUserRoles(ctx context.Context, userId int) ([]string, error) {
+	return uc.repo.GetUserRoles(ctx, userId)
+}
+
+func (uc *UserUsecase) GrantRole(ctx context.Context, userId int, role string, grantedBy int) error {
+	if !auth.IsValidRole(role) {
+		return errors.New("invalid role")
+	}
+
+	_, err := uc.repo.GetUserById(ctx, userId)
+	if err != nil {
+		logs.PrintLog(ctx, "GrantRole", fmt.Sprintf("%+v", err))
+		return errors.New("user not found")
+	}
+
+	return uc.repo.GrantRole(ctx, userId, role, grantedBy)
+}
+
+func (uc *UserUsecase) RevokeRole(ctx context.Context, userId int, role string, revokedBy int) error {
+	if !auth.IsValidRole(role) {
+		return errors.New("invalid role")
+	}
+
+	// администратор не может лишить прав сам себя, чтобы не остаться без администраторов
+	if userId == revokedBy && role == string(auth.RoleAdmin) {
+		return errors.New("cannot revoke own admin role")
+	}
+
+	return uc.repo.RevokeRole(ctx, userId, role)
+}
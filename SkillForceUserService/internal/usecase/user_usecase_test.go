@@ -1,10 +1,14 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"image"
+	"image/png"
 	multipart "mime/multipart"
 	usermodels "skillForce/internal/models/user"
+	"skillForce/pkg/avatar"
 	"skillForce/pkg/logs"
 	"testing"
 
@@ -12,28 +16,27 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-// func TestValidUser_Success(t *testing.T) {
-// 	ctrl := gomock.NewController(t)
-// 	defer ctrl.Finish()
+func TestValidUser_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
 
-// 	mockRepo := NewMockUserRepository(ctrl)
-// 	uc := NewUserUsecase(mockRepo)
+	mockRepo := NewMockUserRepository(ctrl)
+	uc := NewUserUsecase(mockRepo)
 
-// 	ctx := context.Background()
-// 	ctx = context.WithValue(ctx, logs.LogsKey, &logs.CtxLog{
-// 		Data: make([]*logs.LogString, 0),
-// 	})
-// 	user := &usermodels.User{}
-// 	expectedToken := "token123"
+	ctx := context.Background()
+	ctx = context.WithValue(ctx, logs.LogsKey, &logs.CtxLog{
+		Data: make([]*logs.LogString, 0),
+	})
+	user := &usermodels.User{}
 
-// 	mockRepo.EXPECT().
-// 		ValidUser(ctx, user).
-// 		Return(expectedToken, nil)
-// 	mockRepo.EXPECT().SendRegMail(ctx, user, expectedToken).Return(nil)
+	// письмо отправляет outbox, отдельного вызова нет
+	mockRepo.EXPECT().
+		ValidUser(ctx, user).
+		Return("token123", nil)
 
-// 	err := uc.ValidUser(ctx, user)
-// 	require.NoError(t, err)
-// }
+	err := uc.ValidUser(ctx, user)
+	require.NoError(t, err)
+}
 
 func TestValidUser_ValidFail(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -46,7 +49,7 @@ func TestValidUser_ValidFail(t *testing.T) {
 	ctx = context.WithValue(ctx, logs.LogsKey, &logs.CtxLog{
 		Data: make([]*logs.LogString, 0),
 	})
-	user := &usermodels.User{}
+	user := &usermodels.User{Password: "plainpassword"}
 
 	mockRepo.EXPECT().
 		ValidUser(ctx, user).
@@ -55,33 +58,32 @@ func TestValidUser_ValidFail(t *testing.T) {
 	err := uc.ValidUser(ctx, user)
 	require.Error(t, err)
 	require.Equal(t, "validation error", err.Error())
+
+	// Пароль хэшируется до сохранения заявки на регистрацию
+	require.NotEqual(t, "plainpassword", user.Password)
+	require.NotEmpty(t, user.Salt)
 }
 
-// func TestValidUser_SendMailFail(t *testing.T) {
-// 	ctrl := gomock.NewController(t)
-// 	defer ctrl.Finish()
+func TestResendConfirmation_Fail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
 
-// 	mockRepo := NewMockUserRepository(ctrl)
-// 	uc := NewUserUsecase(mockRepo)
+	mockRepo := NewMockUserRepository(ctrl)
+	uc := NewUserUsecase(mockRepo)
 
-// 	ctx := context.Background()
-// 	ctx = context.WithValue(ctx, logs.LogsKey, &logs.CtxLog{
-// 		Data: make([]*logs.LogString, 0),
-// 	})
-// 	user := &usermodels.User{}
-// 	expectedToken := "token123"
+	ctx := context.Background()
+	ctx = context.WithValue(ctx, logs.LogsKey, &logs.CtxLog{
+		Data: make([]*logs.LogString, 0),
+	})
 
-// 	mockRepo.EXPECT().
-// 		ValidUser(ctx, user).
-// 		Return(expectedToken, nil)
-// 	mockRepo.EXPECT().
-// 		SendRegMail(ctx, user, expectedToken).
-// 		Return(errors.New("mail error"))
+	mockRepo.EXPECT().
+		RefreshRegistrationToken(ctx, "alice@example.com").
+		Return(nil, "", errors.New("resend too early"))
 
-// 	err := uc.ValidUser(ctx, user)
-// 	require.Error(t, err)
-// 	require.Equal(t, "mail error", err.Error())
-// }
+	err := uc.ResendConfirmation(ctx, "alice@example.com")
+	require.Error(t, err)
+	require.Equal(t, "resend too early", err.Error())
+}
 
 func TestRegisterUser(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -95,29 +97,36 @@ func TestRegisterUser(t *testing.T) {
 		Data: make([]*logs.LogString, 0),
 	})
 	token := "token123"
-	user := &usermodels.User{
-		Password: "plainpassword", // Тестовый пароль
-	}
-
-	// Настраиваем ожидания
-	mockRepo.EXPECT().
-		GetUserByToken(ctx, token).
-		Return(user, nil)
 
+	// заявка удаляется и пользователь создаётся в одной транзакции репозитория
 	mockRepo.EXPECT().
-		RegisterUser(ctx, gomock.Any()).
+		RegisterUser(ctx, token).
 		Return("jwtToken", nil)
 
-	// Вызываем тестируемый метод
 	result, err := uc.RegisterUser(ctx, token)
-
-	// Проверяем результаты
 	require.NoError(t, err)
 	require.Equal(t, "jwtToken", result)
+}
 
-	// Проверяем, что пароль был захэширован
-	require.NotEqual(t, "plainpassword", user.Password)
-	require.NotEmpty(t, user.Salt)
+func TestRegisterUser_InvalidToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockUserRepository(ctrl)
+	uc := NewUserUsecase(mockRepo)
+
+	ctx := context.Background()
+	ctx = context.WithValue(ctx, logs.LogsKey, &logs.CtxLog{
+		Data: make([]*logs.LogString, 0),
+	})
+
+	mockRepo.EXPECT().
+		RegisterUser(ctx, "used").
+		Return("", errors.New("invalid token"))
+
+	result, err := uc.RegisterUser(ctx, "used")
+	require.Error(t, err)
+	require.Empty(t, result)
 }
 
 func TestAuthenticateUser(t *testing.T) {
@@ -162,6 +171,35 @@ func TestUpdateProfile(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestUpdateProfile_InvalidLocale(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := NewUserUsecase(NewMockUserRepository(ctrl))
+
+	err := uc.UpdateProfile(context.Background(), 1, &usermodels.UserProfile{Locale: "de"})
+	require.EqualError(t, err, "invalid locale")
+}
+
+func TestUpdateProfile_InvalidTimezone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	uc := NewUserUsecase(NewMockUserRepository(ctrl))
+
+	for _, timezone := range []string{"Mars/Olympus", "Local", "+03:00"} {
+		err := uc.UpdateProfile(context.Background(), 1, &usermodels.UserProfile{Timezone: timezone})
+		require.EqualError(t, err, "invalid timezone", timezone)
+	}
+}
+
+// memFile - multipart.File поверх байтов
+type memFile struct {
+	*bytes.Reader
+}
+
+func (memFile) Close() error { return nil }
+
 func TestUploadFile(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -173,16 +211,44 @@ func TestUploadFile(t *testing.T) {
 	ctx = context.WithValue(ctx, logs.LogsKey, &logs.CtxLog{
 		Data: make([]*logs.LogString, 0),
 	})
-	var file multipart.File
-	var header *multipart.FileHeader
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 300, 200))))
+	file := memFile{bytes.NewReader(buf.Bytes())}
+	// имя и Content-Type от клиента не совпадают с содержимым и не учитываются
+	header := &multipart.FileHeader{Filename: "avatar", Header: map[string][]string{"Content-Type": {"text/plain"}}}
 
 	mockRepo.EXPECT().
-		UploadFile(ctx, file, header).
-		Return("https://example.com/file.png", nil)
+		UploadAvatar(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, thumbnails map[int][]byte) (string, error) {
+			require.Len(t, thumbnails, len(avatar.Sizes))
+			for _, size := range avatar.Sizes {
+				require.NotEmpty(t, thumbnails[size])
+			}
+			return "https://example.com/avatars/id/256.jpg", nil
+		})
 
 	url, err := uc.UploadFile(ctx, file, header)
 	require.NoError(t, err)
-	require.Equal(t, "https://example.com/file.png", url)
+	require.Equal(t, "https://example.com/avatars/id/256.jpg", url)
+}
+
+func TestUploadFile_NotImage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockUserRepository(ctrl)
+	uc := NewUserUsecase(mockRepo)
+
+	ctx := context.Background()
+	ctx = context.WithValue(ctx, logs.LogsKey, &logs.CtxLog{
+		Data: make([]*logs.LogString, 0),
+	})
+	file := memFile{bytes.NewReader([]byte("<html><script>alert(1)</script></html>"))}
+	header := &multipart.FileHeader{Filename: "avatar.png", Header: map[string][]string{"Content-Type": {"image/png"}}}
+
+	_, err := uc.UploadFile(ctx, file, header)
+	require.ErrorIs(t, err, avatar.ErrUnsupportedFormat)
 }
 
 func TestGetUserByCookie(t *testing.T) {
@@ -273,3 +339,100 @@ func TestDeleteProfilePhoto(t *testing.T) {
 	err := uc.DeleteProfilePhoto(ctx, userId)
 	require.NoError(t, err)
 }
+
+func TestGetPublicProfile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockUserRepository(ctrl)
+	uc := NewUserUsecase(mockRepo)
+
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{
+		Data: make([]*logs.LogString, 0),
+	})
+
+	mockRepo.EXPECT().GetUserProfileById(ctx, 2).Return(&usermodels.UserProfile{Id: 2, Email: "hidden@example.com", HideEmail: true}, nil)
+	mockRepo.EXPECT().GetUserProfileById(ctx, 3).Return(&usermodels.UserProfile{Id: 3, Email: "open@example.com"}, nil)
+
+	hidden, err := uc.GetPublicProfile(ctx, 2)
+	require.NoError(t, err)
+	require.Empty(t, hidden.Email)
+
+	open, err := uc.GetPublicProfile(ctx, 3)
+	require.NoError(t, err)
+	require.Equal(t, "open@example.com", open.Email)
+}
+
+func TestGrantRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockUserRepository(ctrl)
+	uc := NewUserUsecase(mockRepo)
+
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{
+		Data: make([]*logs.LogString, 0),
+	})
+
+	mockRepo.EXPECT().GetUserById(ctx, 2).Return(&usermodels.User{Id: 2}, nil)
+	mockRepo.EXPECT().GrantRole(ctx, 2, "author", 1).Return(nil)
+
+	err := uc.GrantRole(ctx, 2, "author", 1)
+	require.NoError(t, err)
+}
+
+func TestGrantRole_InvalidRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockUserRepository(ctrl)
+	uc := NewUserUsecase(mockRepo)
+
+	err := uc.GrantRole(context.Background(), 2, "superuser", 1)
+	require.Error(t, err)
+	require.Equal(t, "invalid role", err.Error())
+}
+
+func TestGrantRole_UserNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockUserRepository(ctrl)
+	uc := NewUserUsecase(mockRepo)
+
+	ctx := context.WithValue(context.Background(), logs.LogsKey, &logs.CtxLog{
+		Data: make([]*logs.LogString, 0),
+	})
+
+	mockRepo.EXPECT().GetUserById(ctx, 2).Return(nil, errors.New("sql: no rows in result set"))
+
+	err := uc.GrantRole(ctx, 2, "author", 1)
+	require.Error(t, err)
+	require.Equal(t, "user not found", err.Error())
+}
+
+func TestRevokeRole_OwnAdmin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockUserRepository(ctrl)
+	uc := NewUserUsecase(mockRepo)
+
+	err := uc.RevokeRole(context.Background(), 1, "admin", 1)
+	require.Error(t, err)
+	require.Equal(t, "cannot revoke own admin role", err.Error())
+}
+
+func TestRevokeRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockUserRepository(ctrl)
+	uc := NewUserUsecase(mockRepo)
+
+	ctx := context.Background()
+	mockRepo.EXPECT().RevokeRole(ctx, 2, "author").Return(nil)
+
+	err := uc.RevokeRole(ctx, 2, "author", 1)
+	require.NoError(t, err)
+}
@@ -0,0 +1,156 @@
+package auth
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type Role string
+
+const (
+	RoleLearner   Role = "learner"
+	RoleAuthor    Role = "author"
+	RoleModerator Role = "moderator"
+	RoleAdmin     Role = "admin"
+)
+
+type Permission string
+
+const (
+	// PermPublic - метод доступен без авторизации
+	PermPublic       Permission = ""
+	PermLearn        Permission = "course:learn"
+	PermPurchase     Permission = "course:purchase"
+	PermCreateCourse Permission = "course:create"
+	PermModerate     Permission = "course:moderate"
+	PermManageRoles  Permission = "user:manage_roles"
+)
+
+var rolePermissions = map[Role][]Permission{
+	RoleLearner:   {PermLearn, PermPurchase},
+	RoleAuthor:    {PermCreateCourse},
+	RoleModerator: {PermModerate},
+	RoleAdmin:     {PermCreateCourse, PermModerate, PermManageRoles},
+}
+
+// Identity - пользователь, от имени которого пришел запрос
+type Identity struct {
+	UserId int
+	Roles  []string
+}
+
+type identityKey struct{}
+
+func IsValidRole(role string) bool {
+	_, ok := rolePermissions[Role(role)]
+	return ok
+}
+
+// HasPermission - роль learner есть у любого авторизованного пользователя
+func HasPermission(roles []string, permission Permission) bool {
+	if permission == PermPublic {
+		return true
+	}
+	for _, role := range append([]string{string(RoleLearner)}, roles...) {
+		for _, p := range rolePermissions[Role(role)] {
+			if p == permission {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (i *Identity) HasRole(role Role) bool {
+	for _, r := range i.Roles {
+		if r == string(role) {
+			return true
+		}
+	}
+	return false
+}
+
+func IdentityFromContext(ctx context.Context) (*Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(*Identity)
+	return identity, ok
+}
+
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// verifyCaller - вызывающий сервис подтверждается подписанным токеном, а при mTLS
+// токен должен быть выпущен тем же сервисом, что предъявил клиентский сертификат
+func verifyCaller(ctx context.Context, verifier *TokenVerifier) (*Identity, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	tokens := md.Get(tokenKey)
+	if len(tokens) != 1 {
+		return nil, ErrInvalidToken
+	}
+
+	issuer, identity, err := verifier.Verify(tokens[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if name, ok := peerServiceName(ctx); ok && name != issuer {
+		return nil, ErrInvalidToken
+	}
+	return identity, nil
+}
+
+// peerServiceName - имя сервиса из проверенного клиентского сертификата
+func peerServiceName(ctx context.Context) (string, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "", false
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return "", false
+	}
+	return tlsInfo.State.VerifiedChains[0][0].Subject.CommonName, true
+}
+
+// UnaryServerInterceptor - проверка вызывающего сервиса и прав пользователя на вызов метода
+// по таблице permissions. Методы, которых нет в таблице, запрещены
+func UnaryServerInterceptor(verifier *TokenVerifier, permissions map[string]Permission) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		permission, ok := permissions[info.FullMethod]
+		if !ok {
+			return nil, status.Error(codes.PermissionDenied, "permission denied")
+		}
+
+		identity, err := verifyCaller(ctx, verifier)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+
+		if identity == nil {
+			if permission != PermPublic {
+				return nil, status.Error(codes.Unauthenticated, "not authorized")
+			}
+			return handler(ctx, req)
+		}
+
+		if !HasPermission(identity.Roles, permission) {
+			return nil, status.Error(codes.PermissionDenied, "permission denied")
+		}
+
+		return handler(WithIdentity(ctx, identity), req)
+	}
+}
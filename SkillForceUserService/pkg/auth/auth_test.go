@@ -0,0 +1,142 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestHasPermission(t *testing.T) {
+	tests := []struct {
+		name       string
+		roles      []string
+		permission Permission
+		expected   bool
+	}{
+		{name: "public", roles: nil, permission: PermPublic, expected: true},
+		{name: "learner by default", roles: nil, permission: PermLearn, expected: true},
+		{name: "learner cannot create", roles: nil, permission: PermCreateCourse, expected: false},
+		{name: "author creates", roles: []string{"author"}, permission: PermCreateCourse, expected: true},
+		{name: "moderator cannot manage roles", roles: []string{"moderator"}, permission: PermManageRoles, expected: false},
+		{name: "admin manages roles", roles: []string{"admin"}, permission: PermManageRoles, expected: true},
+		{name: "unknown role", roles: []string{"root"}, permission: PermManageRoles, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, HasPermission(tt.roles, tt.permission))
+		})
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	permissions := map[string]Permission{
+		"/svc/Public": PermPublic,
+		"/svc/Learn":  PermLearn,
+		"/svc/Admin":  PermManageRoles,
+	}
+	verifier := NewTokenVerifier("secret", ServiceUser, ServiceMain)
+	interceptor := UnaryServerInterceptor(verifier, permissions)
+
+	var gotIdentity *Identity
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		gotIdentity, _ = IdentityFromContext(ctx)
+		return "ok", nil
+	}
+
+	incomingFrom := func(issuer string, identity *Identity) context.Context {
+		token, err := NewTokenSigner("secret", issuer).Sign(ServiceUser, identity)
+		require.NoError(t, err)
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(tokenKey, token))
+	}
+	incoming := func(userId int, roles ...string) context.Context {
+		return incomingFrom(ServiceMain, &Identity{UserId: userId, Roles: roles})
+	}
+
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		method       string
+		expectedCode codes.Code
+		expectedUser int
+	}{
+		{name: "no token", ctx: context.Background(), method: "/svc/Public", expectedCode: codes.Unauthenticated},
+		{name: "forged user id", ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-user-id", "1")), method: "/svc/Learn", expectedCode: codes.Unauthenticated},
+		{name: "unknown caller", ctx: incomingFrom(ServiceCourse, &Identity{UserId: 7}), method: "/svc/Learn", expectedCode: codes.Unauthenticated},
+		{name: "anonymous public", ctx: incomingFrom(ServiceMain, nil), method: "/svc/Public", expectedCode: codes.OK},
+		{name: "anonymous learn", ctx: incomingFrom(ServiceMain, nil), method: "/svc/Learn", expectedCode: codes.Unauthenticated},
+		{name: "learner learn", ctx: incoming(7), method: "/svc/Learn", expectedCode: codes.OK, expectedUser: 7},
+		{name: "learner admin", ctx: incoming(7), method: "/svc/Admin", expectedCode: codes.PermissionDenied},
+		{name: "admin admin", ctx: incoming(1, "admin"), method: "/svc/Admin", expectedCode: codes.OK, expectedUser: 1},
+		{name: "unknown method", ctx: incoming(1, "admin"), method: "/svc/Unknown", expectedCode: codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotIdentity = nil
+			_, err := interceptor(tt.ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			require.Equal(t, tt.expectedCode, status.Code(err))
+			if tt.expectedUser != 0 {
+				require.NotNil(t, gotIdentity)
+				require.Equal(t, tt.expectedUser, gotIdentity.UserId)
+			}
+		})
+	}
+}
+
+func TestTokenVerify(t *testing.T) {
+	signer := NewTokenSigner("secret", ServiceMain)
+	token, err := signer.Sign(ServiceUser, &Identity{UserId: 5, Roles: []string{"author"}})
+	require.NoError(t, err)
+
+	issuer, identity, err := NewTokenVerifier("secret", ServiceUser, ServiceMain).Verify(token)
+	require.NoError(t, err)
+	require.Equal(t, ServiceMain, issuer)
+	require.Equal(t, &Identity{UserId: 5, Roles: []string{"author"}}, identity)
+
+	tests := []struct {
+		name     string
+		verifier *TokenVerifier
+		token    string
+		expected error
+	}{
+		{name: "wrong secret", verifier: NewTokenVerifier("other", ServiceUser, ServiceMain), token: token, expected: ErrInvalidToken},
+		{name: "wrong audience", verifier: NewTokenVerifier("secret", ServiceCourse, ServiceMain), token: token, expected: ErrInvalidToken},
+		{name: "issuer not allowed", verifier: NewTokenVerifier("secret", ServiceUser, ServiceBilling), token: token, expected: ErrInvalidToken},
+		{name: "malformed", verifier: NewTokenVerifier("secret", ServiceUser, ServiceMain), token: "garbage", expected: ErrInvalidToken},
+		{name: "tampered payload", verifier: NewTokenVerifier("secret", ServiceUser, ServiceMain), token: "e30" + token[strings.Index(token, "."):], expected: ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := tt.verifier.Verify(tt.token)
+			require.ErrorIs(t, err, tt.expected)
+		})
+	}
+}
+
+func TestTokenExpired(t *testing.T) {
+	claims := tokenClaims{
+		Issuer:   ServiceMain,
+		Audience: ServiceUser,
+		UserId:   5,
+		IssuedAt: time.Now().Add(-time.Hour).Unix(),
+		Expires:  time.Now().Add(-time.Hour + ServiceTokenTTL).Unix(),
+	}
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	token := encoded + "." + base64.RawURLEncoding.EncodeToString(sign([]byte("secret"), encoded))
+
+	_, _, err = NewTokenVerifier("secret", ServiceUser, ServiceMain).Verify(token)
+	require.ErrorIs(t, err, ErrTokenExpired)
+}
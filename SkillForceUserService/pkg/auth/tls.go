@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// TLSFiles - пути к сертификатам сервиса, выпущенным локальным CA (см. certs/gen-certs.sh)
+type TLSFiles struct {
+	CaFile   string
+	CertFile string
+	KeyFile  string
+}
+
+func (f TLSFiles) load() (tls.Certificate, *x509.CertPool, error) {
+	cert, err := tls.LoadX509KeyPair(f.CertFile, f.KeyFile)
+	if err != nil {
+		return tls.Certificate{}, nil, fmt.Errorf("load key pair: %w", err)
+	}
+	ca, err := os.ReadFile(f.CaFile)
+	if err != nil {
+		return tls.Certificate{}, nil, fmt.Errorf("read ca: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return tls.Certificate{}, nil, fmt.Errorf("invalid ca file %s", f.CaFile)
+	}
+	return cert, pool, nil
+}
+
+// ServerCredentials - mTLS: сервер принимает только клиентов с сертификатом, подписанным CA
+func ServerCredentials(files TLSFiles) (credentials.TransportCredentials, error) {
+	cert, pool, err := files.load()
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}), nil
+}
+
+// ClientCredentials - mTLS для соединения с сервисом serverName
+func ClientCredentials(files TLSFiles, serverName string) (credentials.TransportCredentials, error) {
+	cert, pool, err := files.load()
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		ServerName:   serverName,
+		MinVersion:   tls.VersionTLS12,
+	}), nil
+}
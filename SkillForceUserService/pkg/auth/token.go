@@ -0,0 +1,129 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	ServiceMain    = "main-service"
+	ServiceUser    = "user-service"
+	ServiceCourse  = "course-service"
+	ServiceBilling = "billing-service"
+)
+
+// tokenKey - ключ метаданных gRPC, в котором передается токен
+const tokenKey = "x-service-token"
+
+// ServiceTokenTTL - время жизни токена, выпускаемого на каждый gRPC вызов
+const ServiceTokenTTL = 30 * time.Second
+
+// допустимое расхождение часов между контейнерами
+const clockSkew = 5 * time.Second
+
+var (
+	ErrInvalidToken = errors.New("invalid service token")
+	ErrTokenExpired = errors.New("service token expired")
+)
+
+type tokenClaims struct {
+	Issuer   string   `json:"iss"`
+	Audience string   `json:"aud"`
+	UserId   int      `json:"uid,omitempty"`
+	Roles    []string `json:"roles,omitempty"`
+	IssuedAt int64    `json:"iat"`
+	Expires  int64    `json:"exp"`
+}
+
+// TokenSigner - выпуск токенов вызывающим сервисом
+type TokenSigner struct {
+	secret []byte
+	issuer string
+}
+
+func NewTokenSigner(secret string, issuer string) *TokenSigner {
+	return &TokenSigner{secret: []byte(secret), issuer: issuer}
+}
+
+// Sign - токен для вызова сервиса audience. identity может быть nil, если запрос анонимный
+func (s *TokenSigner) Sign(audience string, identity *Identity) (string, error) {
+	now := time.Now()
+	claims := tokenClaims{
+		Issuer:   s.issuer,
+		Audience: audience,
+		IssuedAt: now.Unix(),
+		Expires:  now.Add(ServiceTokenTTL).Unix(),
+	}
+	if identity != nil {
+		claims.UserId = identity.UserId
+		claims.Roles = identity.Roles
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(sign(s.secret, encoded)), nil
+}
+
+// TokenVerifier - проверка токенов принимающим сервисом
+type TokenVerifier struct {
+	secret   []byte
+	audience string
+	issuers  map[string]bool
+}
+
+// NewTokenVerifier - audience - имя текущего сервиса, issuers - сервисы, которым разрешено его вызывать
+func NewTokenVerifier(secret string, audience string, issuers ...string) *TokenVerifier {
+	allowed := make(map[string]bool, len(issuers))
+	for _, issuer := range issuers {
+		allowed[issuer] = true
+	}
+	return &TokenVerifier{secret: []byte(secret), audience: audience, issuers: allowed}
+}
+
+// Verify - возвращает вызывающий сервис и пользователя, если токен выпущен от его имени
+func (v *TokenVerifier) Verify(token string) (string, *Identity, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", nil, ErrInvalidToken
+	}
+	gotSignature, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(gotSignature, sign(v.secret, encoded)) {
+		return "", nil, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", nil, ErrInvalidToken
+	}
+	var claims tokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return "", nil, ErrInvalidToken
+	}
+
+	if claims.Audience != v.audience || !v.issuers[claims.Issuer] {
+		return "", nil, ErrInvalidToken
+	}
+	now := time.Now()
+	if now.After(time.Unix(claims.Expires, 0).Add(clockSkew)) || now.Add(clockSkew).Before(time.Unix(claims.IssuedAt, 0)) {
+		return "", nil, ErrTokenExpired
+	}
+
+	if claims.UserId <= 0 {
+		return claims.Issuer, nil, nil
+	}
+	return claims.Issuer, &Identity{UserId: claims.UserId, Roles: claims.Roles}, nil
+}
+
+func sign(secret []byte, payload string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,119 @@
+package avatar
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+)
+
+// Sizes - стороны квадратных миниатюр аватарки, от меньшей к большей
+var Sizes = []int{64, 128, 256}
+
+const (
+	// MaxFileSize - ограничение на размер файла аватарки
+	MaxFileSize = 10 << 20
+	// MaxPixels - ограничение на размер исходной картинки, чтобы маленький файл
+	// с огромным разрешением не занял всю память при декодировании
+	MaxPixels   = 40_000_000
+	jpegQuality = 85
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported image format")
+	ErrTooLarge          = errors.New("image is too large")
+	ErrInvalidImage      = errors.New("invalid image")
+)
+
+type format struct {
+	name         string
+	decode       func(io.Reader) (image.Image, error)
+	decodeConfig func(io.Reader) (image.Config, error)
+}
+
+var (
+	jpegFormat = format{name: "jpeg", decode: jpeg.Decode, decodeConfig: jpeg.DecodeConfig}
+	pngFormat  = format{name: "png", decode: png.Decode, decodeConfig: png.DecodeConfig}
+	gifFormat  = format{name: "gif", decode: gif.Decode, decodeConfig: gif.DecodeConfig}
+)
+
+// detectFormat - формат по сигнатуре файла, Content-Type и расширение от клиента не учитываются
+func detectFormat(data []byte) (format, error) {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xFF, 0xD8, 0xFF}):
+		return jpegFormat, nil
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		return pngFormat, nil
+	case bytes.HasPrefix(data, []byte("GIF87a")), bytes.HasPrefix(data, []byte("GIF89a")):
+		return gifFormat, nil
+	}
+	return format{}, ErrUnsupportedFormat
+}
+
+// Process - проверка картинки и нарезка квадратных JPEG миниатюр всех размеров из Sizes.
+// Картинка поворачивается по EXIF, обрезается по центру до квадрата и перекодируется,
+// поэтому метаданные исходного файла (EXIF, геолокация) в миниатюры не попадают
+func Process(data []byte) (map[int][]byte, error) {
+	f, err := detectFormat(data)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := f.decodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return nil, ErrInvalidImage
+	}
+	if int64(config.Width)*int64(config.Height) > MaxPixels {
+		return nil, ErrTooLarge
+	}
+
+	img, err := f.decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalidImage
+	}
+
+	rgba := flatten(img)
+	if f.name == jpegFormat.name {
+		rgba = orient(rgba, jpegOrientation(data))
+	}
+	square := cropSquare(rgba)
+
+	thumbnails := make(map[int][]byte, len(Sizes))
+	// каждая миниатюра уменьшается из предыдущей большей, так быстрее, чем каждый раз из исходника
+	current := square
+	for i := len(Sizes) - 1; i >= 0; i-- {
+		current = resize(current, Sizes[i])
+
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, current, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, err
+		}
+		thumbnails[Sizes[i]] = buf.Bytes()
+	}
+	return thumbnails, nil
+}
+
+// flatten - перевод в RGBA на белом фоне, так как JPEG не поддерживает прозрачность
+func flatten(img image.Image) *image.RGBA {
+	b := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(rgba, rgba.Bounds(), img, b.Min, draw.Over)
+	return rgba
+}
+
+func cropSquare(img *image.RGBA) *image.RGBA {
+	b := img.Bounds()
+	side := min(b.Dx(), b.Dy())
+	x := b.Min.X + (b.Dx()-side)/2
+	y := b.Min.Y + (b.Dy()-side)/2
+	return img.SubImage(image.Rect(x, y, x+side, y+side)).(*image.RGBA)
+}
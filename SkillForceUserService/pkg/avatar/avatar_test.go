@@ -0,0 +1,210 @@
+package avatar
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+var (
+	red  = color.RGBA{R: 255, A: 255}
+	blue = color.RGBA{B: 255, A: 255}
+)
+
+// halves - картинка w x h, левая половина красная, правая синяя
+func halves(w, h int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if x < w/2 {
+				img.Set(x, y, red)
+			} else {
+				img.Set(x, y, blue)
+			}
+		}
+	}
+	return img
+}
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// encodeJPEG - JPEG с EXIF сегментом, в котором записан тег Orientation
+func encodeJPEG(t *testing.T, img image.Image, orientation uint16, order binary.ByteOrder) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 95}); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	tiff := make([]byte, 26)
+	if order == binary.LittleEndian {
+		copy(tiff, "II")
+	} else {
+		copy(tiff, "MM")
+	}
+	order.PutUint16(tiff[2:], 42)
+	order.PutUint32(tiff[4:], 8)
+	order.PutUint16(tiff[8:], 1)
+	order.PutUint16(tiff[10:], exifOrientationTag)
+	order.PutUint16(tiff[12:], 3)
+	order.PutUint32(tiff[14:], 1)
+	order.PutUint16(tiff[18:], orientation)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(app1[2:], uint16(len(segment)+2))
+	app1 = append(app1, segment...)
+
+	out := append([]byte{}, data[:2]...)
+	out = append(out, app1...)
+	return append(out, data[2:]...)
+}
+
+func decodeThumbnail(t *testing.T, data []byte) image.Image {
+	t.Helper()
+	img, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("thumbnail is not a JPEG: %v", err)
+	}
+	return img
+}
+
+func isColor(c color.Color, want color.RGBA) bool {
+	r, g, b, _ := c.RGBA()
+	near := func(got uint32, want uint8) bool {
+		diff := int(got>>8) - int(want)
+		return diff > -60 && diff < 60
+	}
+	return near(r, want.R) && near(g, want.G) && near(b, want.B)
+}
+
+func TestProcess(t *testing.T) {
+	thumbnails, err := Process(encodePNG(t, halves(300, 200)))
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if len(thumbnails) != len(Sizes) {
+		t.Fatalf("got %d thumbnails, want %d", len(thumbnails), len(Sizes))
+	}
+	for _, size := range Sizes {
+		img := decodeThumbnail(t, thumbnails[size])
+		if img.Bounds().Dx() != size || img.Bounds().Dy() != size {
+			t.Errorf("thumbnail %d has size %v", size, img.Bounds())
+		}
+		if !isColor(img.At(1, size/2), red) || !isColor(img.At(size-2, size/2), blue) {
+			t.Errorf("thumbnail %d is not cropped around the center", size)
+		}
+	}
+}
+
+func TestProcessStripsExifAndAppliesOrientation(t *testing.T) {
+	tests := []struct {
+		name        string
+		orientation uint16
+		order       binary.ByteOrder
+		top, bottom color.RGBA
+		left, right color.RGBA
+	}{
+		{name: "no rotation", orientation: 1, order: binary.BigEndian, left: red, right: blue},
+		{name: "rotate 90 cw", orientation: 6, order: binary.LittleEndian, top: red, bottom: blue},
+		{name: "rotate 90 ccw", orientation: 8, order: binary.BigEndian, top: blue, bottom: red},
+		{name: "rotate 180", orientation: 3, order: binary.LittleEndian, left: blue, right: red},
+		{name: "mirror", orientation: 2, order: binary.BigEndian, left: blue, right: red},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := encodeJPEG(t, halves(40, 20), tt.orientation, tt.order)
+			if got := jpegOrientation(data); got != int(tt.orientation) {
+				t.Fatalf("jpegOrientation() = %d, want %d", got, tt.orientation)
+			}
+
+			thumbnails, err := Process(data)
+			if err != nil {
+				t.Fatalf("Process() error = %v", err)
+			}
+			for size, thumbnail := range thumbnails {
+				if bytes.Contains(thumbnail, []byte("Exif\x00\x00")) {
+					t.Errorf("thumbnail %d contains EXIF", size)
+				}
+			}
+
+			img := decodeThumbnail(t, thumbnails[64])
+			if tt.top != (color.RGBA{}) && (!isColor(img.At(32, 4), tt.top) || !isColor(img.At(32, 59), tt.bottom)) {
+				t.Errorf("unexpected vertical layout: top %v, bottom %v", img.At(32, 4), img.At(32, 59))
+			}
+			if tt.left != (color.RGBA{}) && (!isColor(img.At(4, 32), tt.left) || !isColor(img.At(59, 32), tt.right)) {
+				t.Errorf("unexpected horizontal layout: left %v, right %v", img.At(4, 32), img.At(59, 32))
+			}
+		})
+	}
+}
+
+func TestProcessTransparentBackground(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 10, 10))
+	thumbnails, err := Process(encodePNG(t, img))
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if got := decodeThumbnail(t, thumbnails[128]).At(64, 64); !isColor(got, color.RGBA{R: 255, G: 255, B: 255}) {
+		t.Errorf("transparent pixel = %v, want white", got)
+	}
+}
+
+// withSize - PNG, в заголовке которого записан другой размер
+func withSize(t *testing.T, data []byte, w, h uint32) []byte {
+	t.Helper()
+	out := append([]byte{}, data...)
+	// сигнатура 8 байт, длина и тип IHDR по 4 байта, затем ширина и высота
+	binary.BigEndian.PutUint32(out[16:], w)
+	binary.BigEndian.PutUint32(out[20:], h)
+	binary.BigEndian.PutUint32(out[29:], crc32.ChecksumIEEE(out[12:29]))
+	return out
+}
+
+func TestProcessRejects(t *testing.T) {
+	valid := encodePNG(t, halves(4, 4))
+
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{name: "empty", data: nil, want: ErrUnsupportedFormat},
+		{name: "text", data: []byte("<svg xmlns=\"http://www.w3.org/2000/svg\"></svg>"), want: ErrUnsupportedFormat},
+		{name: "webp", data: []byte("RIFF\x24\x00\x00\x00WEBPVP8 "), want: ErrUnsupportedFormat},
+		{name: "truncated png", data: valid[:20], want: ErrInvalidImage},
+		{name: "truncated jpeg", data: []byte{0xFF, 0xD8, 0xFF, 0xE0, 0x00}, want: ErrInvalidImage},
+		{name: "too many pixels", data: withSize(t, valid, 10000, 10000), want: ErrTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Process(tt.data); !errors.Is(err, tt.want) {
+				t.Errorf("Process() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestJpegOrientationWithoutExif(t *testing.T) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, halves(4, 4), nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := jpegOrientation(buf.Bytes()); got != 1 {
+		t.Errorf("jpegOrientation() = %d, want 1", got)
+	}
+}
@@ -0,0 +1,133 @@
+package avatar
+
+import (
+	"encoding/binary"
+	"image"
+)
+
+const exifOrientationTag = 0x0112
+
+// jpegOrientation - значение тега Orientation из EXIF (APP1) JPEG файла, 1 если тега нет
+func jpegOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 1
+		}
+		marker := data[i+1]
+		switch {
+		case marker == 0xFF:
+			// байты-заполнители перед маркером
+			i++
+			continue
+		case marker == 0xD9 || marker == 0xDA:
+			// конец файла или начало данных изображения: метаданных дальше нет
+			return 1
+		case marker >= 0xD0 && marker <= 0xD7 || marker == 0x01:
+			i += 2
+			continue
+		}
+
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			return 1
+		}
+		if marker == 0xE1 {
+			if orientation := exifOrientation(data[i+4 : i+2+length]); orientation != 0 {
+				return orientation
+			}
+		}
+		i += 2 + length
+	}
+	return 1
+}
+
+// exifOrientation - разбор первого IFD из TIFF заголовка EXIF сегмента, 0 если тег не найден
+func exifOrientation(segment []byte) int {
+	if len(segment) < 14 || string(segment[:6]) != "Exif\x00\x00" {
+		return 0
+	}
+	tiff := segment[6:]
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+	if order.Uint16(tiff[2:]) != 42 {
+		return 0
+	}
+
+	ifd := int64(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > int64(len(tiff)) {
+		return 0
+	}
+	entries := int(order.Uint16(tiff[ifd:]))
+	for k := 0; k < entries; k++ {
+		entry := int(ifd) + 2 + k*12
+		if entry+12 > len(tiff) {
+			return 0
+		}
+		if order.Uint16(tiff[entry:]) != exifOrientationTag {
+			continue
+		}
+		// тип SHORT, значение лежит в начале поля значения
+		if order.Uint16(tiff[entry+2:]) != 3 {
+			return 0
+		}
+		orientation := int(order.Uint16(tiff[entry+8:]))
+		if orientation < 1 || orientation > 8 {
+			return 0
+		}
+		return orientation
+	}
+	return 0
+}
+
+// orient - поворот и отражение картинки так, чтобы она выглядела как в камере.
+// После перекодирования EXIF теряется, поэтому ориентацию нужно применить к пикселям
+func orient(img *image.RGBA, orientation int) *image.RGBA {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2:
+				dx, dy = w-1-x, y
+			case 3:
+				dx, dy = w-1-x, h-1-y
+			case 4:
+				dx, dy = x, h-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = h-1-y, x
+			case 7:
+				dx, dy = h-1-y, w-1-x
+			case 8:
+				dx, dy = y, w-1-x
+			}
+			src := img.PixOffset(b.Min.X+x, b.Min.Y+y)
+			copy(dst.Pix[dst.PixOffset(dx, dy):dst.PixOffset(dx, dy)+4], img.Pix[src:src+4])
+		}
+	}
+	return dst
+}
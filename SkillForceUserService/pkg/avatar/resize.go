@@ -0,0 +1,95 @@
+package avatar
+
+import (
+	"image"
+	"math"
+)
+
+type contribution struct {
+	index  int
+	weight float32
+}
+
+// weights - вклад пикселей исходной строки в каждый пиксель результата.
+// Треугольный фильтр шириной в масштаб: при уменьшении усредняет все покрытые пиксели,
+// при увеличении работает как билинейная интерполяция
+func weights(srcSize, dstSize int) [][]contribution {
+	scale := float64(srcSize) / float64(dstSize)
+	radius := math.Max(scale, 1)
+
+	result := make([][]contribution, dstSize)
+	for i := range result {
+		center := (float64(i)+0.5)*scale - 0.5
+		from := int(math.Ceil(center - radius))
+		to := int(math.Floor(center + radius))
+
+		var sum float64
+		contributions := make([]contribution, 0, to-from+1)
+		for j := from; j <= to; j++ {
+			weight := 1 - math.Abs(float64(j)-center)/radius
+			if weight <= 0 {
+				continue
+			}
+			index := min(max(j, 0), srcSize-1)
+			contributions = append(contributions, contribution{index: index, weight: float32(weight)})
+			sum += weight
+		}
+		for k := range contributions {
+			contributions[k].weight /= float32(sum)
+		}
+		result[i] = contributions
+	}
+	return result
+}
+
+// resize - масштабирование квадратной непрозрачной картинки до стороны size
+func resize(src *image.RGBA, size int) *image.RGBA {
+	b := src.Bounds()
+	side := b.Dx()
+	ws := weights(side, size)
+
+	// сначала по горизонтали: side строк по size пикселей
+	tmp := make([]float32, side*size*3)
+	for y := 0; y < side; y++ {
+		row := src.Pix[src.PixOffset(b.Min.X, b.Min.Y+y):]
+		for x, contributions := range ws {
+			var r, g, bl float32
+			for _, c := range contributions {
+				p := row[c.index*4 : c.index*4+3]
+				r += float32(p[0]) * c.weight
+				g += float32(p[1]) * c.weight
+				bl += float32(p[2]) * c.weight
+			}
+			offset := (y*size + x) * 3
+			tmp[offset], tmp[offset+1], tmp[offset+2] = r, g, bl
+		}
+	}
+
+	// затем по вертикали
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y, contributions := range ws {
+		for x := 0; x < size; x++ {
+			var r, g, bl float32
+			for _, c := range contributions {
+				offset := (c.index*size + x) * 3
+				r += tmp[offset] * c.weight
+				g += tmp[offset+1] * c.weight
+				bl += tmp[offset+2] * c.weight
+			}
+			p := dst.Pix[dst.PixOffset(x, y):]
+			p[0], p[1], p[2], p[3] = clamp(r), clamp(g), clamp(bl), 0xFF
+		}
+	}
+	return dst
+}
+
+func clamp(v float32) uint8 {
+	v = float32(math.Round(float64(v)))
+	if v < 0 {
+		return 0
+	}
+	if v > 255 {
+		return 255
+	}
+	return uint8(v)
+}
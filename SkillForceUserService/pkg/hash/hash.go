@@ -2,7 +2,9 @@ package hash
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"log"
@@ -44,3 +46,20 @@ func CheckPassword(password string, passwordFromDB string, saltBytes []byte) boo
 	hashedInputPassword := hashPassword(password, saltBytes)
 	return hashedInputPassword == passwordFromDB
 }
+
+// Генерация случайного токена для ссылок из писем
+func GenerateToken() (string, error) {
+	tokenBytes := make([]byte, 32)
+	_, err := rand.Read(tokenBytes)
+	if err != nil {
+		return "", errors.New("cannot generate token")
+	}
+
+	return base64.RawURLEncoding.EncodeToString(tokenBytes), nil
+}
+
+// Хэширование токена перед сохранением в базу
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,232 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newLocal(t *testing.T) Storage {
+	t.Helper()
+	s, err := New(Config{Backend: BackendLocal, LocalDir: t.TempDir(), PublicURL: "https://skill-force.ru/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func read(t *testing.T, reader io.ReadCloser, err error) string {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestLocalPutGet(t *testing.T) {
+	ctx := context.Background()
+	s := newLocal(t)
+
+	err := s.Put(ctx, "videos", "hls/1/master.m3u8", strings.NewReader("0123456789"), 10, PutOptions{ContentType: "application/vnd.apple.mpegurl"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := s.Stat(ctx, "videos", "hls/1/master.m3u8")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Size != 10 || info.ContentType != "application/vnd.apple.mpegurl" || info.ETag != "781e5e245d69b566979b86e28d23f2c7" {
+		t.Errorf("unexpected info %+v", info)
+	}
+
+	tests := []struct {
+		start, end int64
+		want       string
+	}{
+		{0, -1, "0123456789"},
+		{2, 4, "234"},
+		{7, -1, "789"},
+		{8, 20, "89"},
+	}
+	for _, tt := range tests {
+		reader, err := s.GetRange(ctx, "videos", "hls/1/master.m3u8", tt.start, tt.end)
+		if got := read(t, reader, err); got != tt.want {
+			t.Errorf("GetRange(%d, %d) = %q, want %q", tt.start, tt.end, got, tt.want)
+		}
+	}
+	if _, err := s.GetRange(ctx, "videos", "hls/1/master.m3u8", 10, -1); err == nil {
+		t.Error("range past the end must fail")
+	}
+
+	reader, info, err := Get(ctx, s, "videos", "hls/1/master.m3u8")
+	if got := read(t, reader, err); got != "0123456789" || info.Name != "hls/1/master.m3u8" {
+		t.Errorf("Get = %q, %+v", got, info)
+	}
+}
+
+func TestLocalShortPutKeepsObject(t *testing.T) {
+	ctx := context.Background()
+	s := newLocal(t)
+
+	if err := s.Put(ctx, "avatars", "a.jpg", strings.NewReader("old"), 3, PutOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Put(ctx, "avatars", "a.jpg", strings.NewReader("new"), 10, PutOptions{}); err == nil {
+		t.Fatal("short body must fail")
+	}
+	reader, err := s.GetRange(ctx, "avatars", "a.jpg", 0, -1)
+	if got := read(t, reader, err); got != "old" {
+		t.Errorf("object was overwritten: %q", got)
+	}
+}
+
+func TestLocalDeleteAndList(t *testing.T) {
+	ctx := context.Background()
+	s := newLocal(t)
+
+	for _, name := range []string{"u1/64.jpg", "u1/256.jpg", "u2/64.jpg", "default_avatar.png"} {
+		if err := s.Put(ctx, "avatars", name, strings.NewReader("x"), 1, PutOptions{ContentType: "image/jpeg"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	objects, err := s.List(ctx, "avatars", "u1/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objects) != 2 {
+		t.Errorf("expected 2 objects, got %+v", objects)
+	}
+
+	if err := s.Delete(ctx, "avatars", "u1/64.jpg"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Delete(ctx, "avatars", "u1/64.jpg"); err != nil {
+		t.Errorf("deleting missing object must succeed: %v", err)
+	}
+	if _, err := s.Stat(ctx, "avatars", "u1/64.jpg"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	objects, err = s.List(ctx, "avatars", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(objects) != 3 {
+		t.Errorf("expected 3 objects, got %+v", objects)
+	}
+
+	if objects, err := s.List(ctx, "empty", ""); err != nil || len(objects) != 0 {
+		t.Errorf("missing bucket must be empty, got %+v %v", objects, err)
+	}
+}
+
+func TestLocalInvalidNames(t *testing.T) {
+	ctx := context.Background()
+	s := newLocal(t)
+
+	for _, name := range []string{"", "/a", "../a", "a/../../b", "a//b", `a\b`} {
+		if err := s.Put(ctx, "avatars", name, strings.NewReader("x"), 1, PutOptions{}); !errors.Is(err, ErrInvalidObjectName) {
+			t.Errorf("Put(%q) = %v", name, err)
+		}
+	}
+	for _, bucket := range []string{"", ".meta", ".uploads", "a/b"} {
+		if err := s.Put(ctx, bucket, "a", strings.NewReader("x"), 1, PutOptions{}); !errors.Is(err, ErrInvalidObjectName) {
+			t.Errorf("Put to bucket %q = %v", bucket, err)
+		}
+	}
+}
+
+func TestLocalMultipartUpload(t *testing.T) {
+	ctx := context.Background()
+	s := newLocal(t)
+
+	uploadId, err := s.NewMultipartUpload(ctx, "videos", "src.mp4", PutOptions{ContentType: "video/mp4"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.PutPart(ctx, "videos", "other.mp4", uploadId, 1, strings.NewReader("a"), 1); !errors.Is(err, ErrNotFound) {
+		t.Errorf("part for another object must be rejected, got %v", err)
+	}
+
+	var parts []Part
+	for i, data := range []string{"hello ", "world"} {
+		etag, err := s.PutPart(ctx, "videos", "src.mp4", uploadId, i+1, strings.NewReader(data), int64(len(data)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		parts = append(parts, Part{PartNumber: i + 1, ETag: etag})
+	}
+
+	if err := s.CompleteMultipartUpload(ctx, "videos", "src.mp4", uploadId, []Part{{PartNumber: 1, ETag: "bad"}}); err == nil {
+		t.Error("wrong etag must be rejected")
+	}
+	if err := s.CompleteMultipartUpload(ctx, "videos", "src.mp4", uploadId, parts); err != nil {
+		t.Fatal(err)
+	}
+
+	reader, info, err := Get(ctx, s, "videos", "src.mp4")
+	if got := read(t, reader, err); got != "hello world" || info.ContentType != "video/mp4" || !strings.HasSuffix(info.ETag, "-2") {
+		t.Errorf("unexpected object %q %+v", got, info)
+	}
+	if err := s.AbortMultipartUpload(ctx, "videos", "src.mp4", uploadId); !errors.Is(err, ErrNotFound) {
+		t.Errorf("completed upload must be gone, got %v", err)
+	}
+}
+
+func TestLocalPutFile(t *testing.T) {
+	ctx := context.Background()
+	s := newLocal(t)
+	dir := t.TempDir()
+
+	src := filepath.Join(dir, "bundle.zip")
+	if err := s.Put(ctx, "course-bundles", "1/a.zip", strings.NewReader("zip"), 3, PutOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := GetFile(ctx, s, "course-bundles", "1/a.zip", src); err != nil {
+		t.Fatal(err)
+	}
+	if err := PutFile(ctx, s, "course-bundles", "1/b.zip", src, PutOptions{ContentType: "application/zip"}); err != nil {
+		t.Fatal(err)
+	}
+	reader, err := s.GetRange(ctx, "course-bundles", "1/b.zip", 0, -1)
+	if got := read(t, reader, err); got != "zip" {
+		t.Errorf("unexpected copy %q", got)
+	}
+}
+
+func TestPresignAndPublicURL(t *testing.T) {
+	s := newLocal(t)
+
+	link, err := s.Presign(context.Background(), "exports", "1/a b.zip", time.Hour, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if link != "https://skill-force.ru/exports/1/a%20b.zip" {
+		t.Errorf("unexpected link %s", link)
+	}
+
+	params := url.Values{"response-content-disposition": {`attachment; filename="export.zip"`}}
+	link, err = s.Presign(context.Background(), "exports", "1/a.zip", time.Hour, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if link != "https://skill-force.ru/exports/1/a.zip?"+params.Encode() {
+		t.Errorf("unexpected link %s", link)
+	}
+
+	if got := PublicURL("https://skill-force.ru", "avatars", "u1/256.jpg"); got != "https://skill-force.ru/avatars/u1/256.jpg" {
+		t.Errorf("unexpected url %s", got)
+	}
+}
@@ -0,0 +1,33 @@
+#!/usr/bin/env bash
+# Генерация локального CA и сертификатов сервисов для mTLS между gRPC сервисами.
+# Запускать из корня репозитория: ./certs/gen-certs.sh
+# Сертификаты монтируются в контейнеры в docker-compose.yml (./certs/<service> -> /app/certs)
+set -euo pipefail
+
+DIR="$(cd "$(dirname "$0")" && pwd)"
+SERVICES="main-service user-service course-service billing-service"
+DAYS=825
+
+cd "$DIR"
+
+if [ ! -f ca.key ]; then
+  openssl req -x509 -newkey rsa:4096 -nodes -sha256 -days 3650 \
+    -subj "/CN=skillforce-internal-ca" \
+    -keyout ca.key -out ca.crt
+fi
+
+for service in $SERVICES; do
+  mkdir -p "$service"
+  openssl req -newkey rsa:2048 -nodes -sha256 \
+    -subj "/CN=$service" \
+    -keyout "$service/service.key" -out "$service/service.csr"
+  # CN - имя сервиса, по нему сервер сверяет вызывающего с выпустившим токен
+  openssl x509 -req -sha256 -days "$DAYS" \
+    -in "$service/service.csr" -CA ca.crt -CAkey ca.key -CAcreateserial \
+    -extfile <(printf "subjectAltName=DNS:%s,DNS:localhost\nextendedKeyUsage=serverAuth,clientAuth" "$service") \
+    -out "$service/service.crt"
+  rm "$service/service.csr"
+  cp ca.crt "$service/ca.crt"
+done
+
+echo "certificates generated in $DIR"
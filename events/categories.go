@@ -0,0 +1,66 @@
+package events
+
+// Категории писем для настроек уведомлений пользователя. Транзакционные письма отключить нельзя
+const (
+	CategoryTransactional  = "transactional"
+	CategoryCourseProgress = "course_progress"
+	CategoryMarketing      = "marketing"
+	CategoryReviewResults  = "review_results"
+	CategoryDigest         = "digest"
+)
+
+// Categories - все категории в порядке показа пользователю
+var Categories = []string{
+	CategoryTransactional,
+	CategoryCourseProgress,
+	CategoryMarketing,
+	CategoryReviewResults,
+	CategoryDigest,
+}
+
+func IsValidCategory(category string) bool {
+	for _, c := range Categories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+// categorized - письмо, категория которого зависит от содержимого, а не только от типа
+type categorized interface {
+	GetCategory() string
+}
+
+// PayloadCategory - категория письма: своя у содержимого, если она задана, иначе из контракта
+func PayloadCategory(payload Payload) string {
+	if c, ok := payload.(categorized); ok && c.GetCategory() != "" {
+		return c.GetCategory()
+	}
+	if contract, ok := contracts[payload.ProtoReflect().Descriptor().FullName()]; ok && contract.Category != "" {
+		return contract.Category
+	}
+	return CategoryTransactional
+}
+
+// Category - категория письма в событии
+func Category(env *Envelope) string {
+	payload := GetPayload(env)
+	if payload == nil {
+		return CategoryTransactional
+	}
+	return PayloadCategory(payload)
+}
+
+// recipientHolder - письмо с получателем
+type recipientHolder interface {
+	GetRecipient() *Recipient
+}
+
+// GetRecipient - получатель письма в событии или nil
+func GetRecipient(env *Envelope) *Recipient {
+	if holder, ok := GetPayload(env).(recipientHolder); ok {
+		return holder.GetRecipient()
+	}
+	return nil
+}
@@ -0,0 +1,7 @@
+module skillForce/pkg/events
+
+go 1.23.0
+
+toolchain go1.23.6
+
+require google.golang.org/protobuf v1.36.5
@@ -0,0 +1,19 @@
+#!/bin/sh
+# Копирование пакета events в сервисы. Правится только этот каталог, pkg/events в сервисах перезаписывается целиком,
+# чтобы удалённые здесь файлы не оставались в копиях. go.mod нужен только для тестов самого events/, копии входят
+# в модули сервисов. generate.go с go:generate есть только в копиях
+set -eu
+
+cd "$(dirname "$0")"
+for service in SkillForceUserService SkillForceCourseService SkillForceMailService; do
+	target="../$service/pkg/events"
+	rm -rf "$target"
+	mkdir -p "$target"
+	find . -mindepth 1 -maxdepth 1 ! -name sync.sh ! -name go.mod ! -name go.sum -exec cp -R {} "$target/" \;
+	cat > "$target/generate.go" <<'GO'
+// Копия events/ из корня репозитория, правки вносятся там
+//go:generate sh ../../../events/sync.sh
+
+package events
+GO
+done
@@ -0,0 +1,121 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+)
+
+// campaignName - имя кампании используется как имя шаблона письма
+var campaignName = regexp.MustCompile(`^[a-z][a-z_]*$`)
+
+func (r *Recipient) Validate() error {
+	if r == nil {
+		return errors.New("recipient is empty")
+	}
+	address, err := mail.ParseAddress(r.GetEmail())
+	if err != nil || address.Address != r.GetEmail() {
+		return fmt.Errorf("invalid recipient email %q", r.GetEmail())
+	}
+	if r.GetUserId() < 0 {
+		return fmt.Errorf("invalid recipient user id %d", r.GetUserId())
+	}
+	return nil
+}
+
+func (m *ConfirmRegistrationMail) Validate() error {
+	if err := m.GetRecipient().Validate(); err != nil {
+		return err
+	}
+	if m.GetToken() == "" {
+		return errors.New("token is empty")
+	}
+	return nil
+}
+
+func (m *WelcomeCourseMail) Validate() error {
+	if err := m.GetRecipient().Validate(); err != nil {
+		return err
+	}
+	if m.GetCourseId() <= 0 {
+		return fmt.Errorf("invalid course id %d", m.GetCourseId())
+	}
+	if m.GetCourseName() == "" {
+		return errors.New("course name is empty")
+	}
+	return nil
+}
+
+func (m *DataExportMail) Validate() error {
+	if err := m.GetRecipient().Validate(); err != nil {
+		return err
+	}
+	link, err := url.Parse(m.GetUrl())
+	if err != nil || (link.Scheme != "http" && link.Scheme != "https") || link.Host == "" {
+		return fmt.Errorf("invalid url %q", m.GetUrl())
+	}
+	return nil
+}
+
+func (m *EngagementMail) Validate() error {
+	if err := m.GetRecipient().Validate(); err != nil {
+		return err
+	}
+	if !campaignName.MatchString(m.GetCampaign()) {
+		return fmt.Errorf("invalid campaign %q", m.GetCampaign())
+	}
+	if m.GetCourseId() <= 0 {
+		return fmt.Errorf("invalid course id %d", m.GetCourseId())
+	}
+	if m.GetCourseName() == "" {
+		return errors.New("course name is empty")
+	}
+	if m.GetProgressPercent() < 0 || m.GetProgressPercent() > 100 {
+		return fmt.Errorf("invalid progress %d", m.GetProgressPercent())
+	}
+	if m.GetCategory() != "" && !IsValidCategory(m.GetCategory()) {
+		return fmt.Errorf("invalid category %q", m.GetCategory())
+	}
+	return nil
+}
+
+func (m *WeeklyDigestMail) Validate() error {
+	if err := m.GetRecipient().Validate(); err != nil {
+		return err
+	}
+	if m.GetPeriodStart().CheckValid() != nil || m.GetPeriodEnd().CheckValid() != nil ||
+		!m.GetPeriodStart().AsTime().Before(m.GetPeriodEnd().AsTime()) {
+		return errors.New("invalid digest period")
+	}
+	if m.GetLessonsCompleted() < 0 {
+		return fmt.Errorf("invalid lessons completed %d", m.GetLessonsCompleted())
+	}
+	for _, courses := range [][]*DigestCourse{m.GetCourses(), m.GetNewCourses()} {
+		for _, course := range courses {
+			if err := course.Validate(); err != nil {
+				return err
+			}
+		}
+	}
+	for _, rating := range m.GetRatings() {
+		if rating.GetCourseId() <= 0 || rating.GetPosition() <= 0 {
+			return fmt.Errorf("invalid rating of course %d", rating.GetCourseId())
+		}
+	}
+	return nil
+}
+
+func (c *DigestCourse) Validate() error {
+	if c.GetCourseId() <= 0 {
+		return fmt.Errorf("invalid course id %d", c.GetCourseId())
+	}
+	if c.GetCourseName() == "" {
+		return errors.New("course name is empty")
+	}
+	if c.GetProgressPercent() < 0 || c.GetProgressPercent() > 100 {
+		return fmt.Errorf("invalid progress %d", c.GetProgressPercent())
+	}
+	return nil
+}
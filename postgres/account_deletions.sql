@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS account_deletions (
+    user_id INT PRIMARY KEY REFERENCES usertable(id) ON DELETE CASCADE,
+    requested_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    delete_after TIMESTAMP NOT NULL,
+    course_purged_at TIMESTAMP,
+    user_purged_at TIMESTAMP
+);
+
+-- строка остаётся после удаления данных, чтобы анонимизированный аккаунт не обрабатывался повторно
+CREATE INDEX IF NOT EXISTS account_deletions_delete_after_idx ON account_deletions (delete_after) WHERE user_purged_at IS NULL;
+
+-- последний запрос выгрузки данных: следующий можно сделать не раньше чем через DataExportCooldown
+CREATE TABLE IF NOT EXISTS data_exports (
+    user_id INT PRIMARY KEY REFERENCES usertable(id) ON DELETE CASCADE,
+    requested_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,17 @@
+-- офлайн выгрузки курсов. content - снимок содержимого курса, из которого собирается выгрузка,
+-- version - его хеш. При изменении курса версия меняется и выгрузка собирается заново
+CREATE TABLE IF NOT EXISTS course_bundles (
+    course_id INT NOT NULL REFERENCES course(id) ON DELETE CASCADE,
+    format TEXT NOT NULL CHECK (format IN ('zip', 'epub')),
+    with_videos BOOLEAN NOT NULL,
+    version TEXT NOT NULL,
+    content JSONB NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'processing', 'ready', 'failed')),
+    object_name TEXT NOT NULL DEFAULT '',
+    attempts INT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (course_id, format, with_videos)
+);
+
+CREATE INDEX IF NOT EXISTS course_bundles_queue_idx ON course_bundles (updated_at) WHERE status IN ('pending', 'processing');
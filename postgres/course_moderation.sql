@@ -0,0 +1,4 @@
+-- модерация: скрытый модератором курс не показывается в каталоге и поиске, в hidden_reason причина
+ALTER TABLE course
+    ADD COLUMN IF NOT EXISTS hidden BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS hidden_reason TEXT NOT NULL DEFAULT '';
@@ -14,11 +14,25 @@ GRANT SELECT, INSERT, UPDATE, DELETE ON TABLE
     user_answers
 TO skillforce_app_course_service;
 
+-- удаление данных пользователя после запроса на удаление аккаунта
+GRANT DELETE ON TABLE 
+    completed_courses,
+    sended_mails,
+    sertificates,
+    survey_answer,
+    welcome_course_sended_mails
+TO skillforce_app_course_service;
+
+GRANT SELECT, UPDATE ON TABLE 
+    account_deletions
+TO skillforce_app_course_service;
+
 GRANT SELECT ON TABLE 
     answer_variant,
     course,
     lesson,
     lesson_bucket,
+    lesson_files,
     part,
     prog_task,
     question_task,
@@ -30,3 +44,26 @@ GRANT SELECT ON TABLE
     valid_tags,
     video_lesson
 TO skillforce_app_course_service;
+
+-- outbox: события пишутся вместе с изменением данных и отправляются в Kafka отдельно
+GRANT SELECT, INSERT, UPDATE, DELETE ON TABLE outbox_events TO skillforce_app_course_service;
+GRANT USAGE, SELECT ON SEQUENCE outbox_events_id_seq TO skillforce_app_course_service;
+
+-- кампании писем вовлечения: кандидаты выбираются по прогрессу, записям на курс и покупкам
+GRANT SELECT ON TABLE
+    completed_courses,
+    notification_preferences,
+    purchaces,
+    signups,
+    usertable
+TO skillforce_app_course_service;
+GRANT SELECT, INSERT, DELETE ON TABLE campaign_mails TO skillforce_app_course_service;
+GRANT USAGE, SELECT ON SEQUENCE campaign_mails_id_seq TO skillforce_app_course_service;
+
+-- еженедельная сводка: избранные курсы для подбора новых по тегам, отложенные задачи и отправленные сводки
+GRANT SELECT ON TABLE favourite_courses TO skillforce_app_course_service;
+GRANT SELECT, INSERT, UPDATE, DELETE ON TABLE scheduled_jobs, digest_mails TO skillforce_app_course_service;
+GRANT USAGE, SELECT ON SEQUENCE scheduled_jobs_id_seq TO skillforce_app_course_service;
+
+-- модерация: модератор скрывает курс из каталога и поиска
+GRANT UPDATE (hidden, hidden_reason) ON TABLE course TO skillforce_app_course_service;
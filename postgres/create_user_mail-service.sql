@@ -0,0 +1,15 @@
+CREATE USER skillforce_app_mail_service WITH PASSWORD 'password';
+
+GRANT CONNECT ON DATABASE postgres TO skillforce_app_mail_service;
+
+GRANT USAGE ON SCHEMA public TO skillforce_app_mail_service;
+
+GRANT SELECT, INSERT, DELETE ON TABLE 
+    mail_processed_events
+TO skillforce_app_mail_service;
+
+-- письма не отправляются пользователям, отключившим их категорию
+GRANT SELECT ON TABLE notification_preferences TO skillforce_app_mail_service;
+
+-- список подавления адресов после жёстких отказов
+GRANT SELECT, INSERT, UPDATE, DELETE ON TABLE mail_suppressions TO skillforce_app_mail_service;
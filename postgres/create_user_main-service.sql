@@ -5,5 +5,26 @@ GRANT CONNECT ON DATABASE postgres TO skillforce_app_main_service;
 GRANT USAGE ON SCHEMA public TO skillforce_app_main_service;
 
 GRANT SELECT, INSERT, UPDATE, DELETE ON TABLE 
-    video_lesson
+    course_bundles,
+    lesson_files,
+    video_lesson,
+    video_transcodes,
+    video_upload_parts,
+    video_uploads
+TO skillforce_app_main_service;
+
+GRANT SELECT ON TABLE 
+    course,
+    lesson,
+    lesson_bucket,
+    part,
+    sertificates,
+    text_lesson_block
+TO skillforce_app_main_service;
+
+-- сборщику мусора в MinIO нужны только ссылки на аватарки
+GRANT SELECT (avatar_src) ON TABLE usertable TO skillforce_app_main_service;
+
+GRANT SELECT ON TABLE 
+    user_roles
 TO skillforce_app_main_service;
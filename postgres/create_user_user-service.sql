@@ -5,9 +5,28 @@ GRANT CONNECT ON DATABASE postgres TO skillforce_app_user_service;
 GRANT USAGE ON SCHEMA public TO skillforce_app_user_service;
 
 GRANT SELECT, INSERT, UPDATE, DELETE ON TABLE 
+    account_deletions,
+    data_exports,
     favourite_courses,
+    notification_preferences,
+    pending_registrations,
     sended_mails,
     sessions,
     signups,
+    user_roles,
     usertable
 TO skillforce_app_user_service;
+
+GRANT SELECT ON TABLE 
+    course
+TO skillforce_app_user_service;
+
+GRANT USAGE, SELECT ON SEQUENCE pending_registrations_id_seq TO skillforce_app_user_service;
+
+-- outbox: события пишутся вместе с изменением данных и отправляются в Kafka отдельно
+GRANT SELECT, INSERT, UPDATE, DELETE ON TABLE outbox_events TO skillforce_app_user_service;
+GRANT USAGE, SELECT ON SEQUENCE outbox_events_id_seq TO skillforce_app_user_service;
+
+-- уведомления в приложении: user-service пишет их из событий Kafka и отдаёт main-service
+GRANT SELECT, INSERT, UPDATE, DELETE ON TABLE notifications TO skillforce_app_user_service;
+GRANT USAGE, SELECT ON SEQUENCE notifications_id_seq TO skillforce_app_user_service;
@@ -0,0 +1,41 @@
+-- время прохождения урока: по последней отметке находятся пользователи, забросившие курс
+ALTER TABLE lesson_checkpoint ADD COLUMN IF NOT EXISTS created_at TIMESTAMP NOT NULL DEFAULT NOW();
+-- время начала оплаты: по нему находятся неоплаченные покупки
+ALTER TABLE purchaces ADD COLUMN IF NOT EXISTS created_at TIMESTAMP NOT NULL DEFAULT NOW();
+
+-- письма кампаний вовлечения. ref отличает повторные письма одной кампании по курсу:
+-- время последней активности для inactivity, id покупки для abandoned_checkout
+CREATE TABLE IF NOT EXISTS campaign_mails (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES usertable(id) ON DELETE CASCADE,
+    course_id INT NOT NULL,
+    campaign TEXT NOT NULL,
+    ref TEXT NOT NULL DEFAULT '',
+    sent_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, course_id, campaign, ref)
+);
+
+-- ограничение частоты: письма пользователю за последнюю неделю
+CREATE INDEX IF NOT EXISTS campaign_mails_user_sent_idx ON campaign_mails (user_id, sent_at);
+
+-- подписки пользователя на категории писем. Нет строки - категория включена
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id INT NOT NULL REFERENCES usertable(id) ON DELETE CASCADE,
+    category TEXT NOT NULL,
+    enabled BOOLEAN NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, category)
+);
+
+-- письма, отправленные до появления кампаний, и события до миграции не повторяются.
+-- sent_at в прошлом, чтобы эти письма не учитывались в ограничении частоты
+INSERT INTO campaign_mails (user_id, course_id, campaign, sent_at)
+    SELECT user_id, course_id, 'welcome', 'epoch' FROM signups
+    UNION SELECT user_id, course_id, 'welcome', 'epoch' FROM welcome_course_sended_mails
+    UNION SELECT user_id, course_id, 'mid_course', 'epoch' FROM sended_mails
+    UNION SELECT user_id, course_id, 'course_completed', 'epoch' FROM completed_courses
+ON CONFLICT DO NOTHING;
+
+INSERT INTO campaign_mails (user_id, course_id, campaign, ref, sent_at)
+    SELECT user_id, course_id, 'abandoned_checkout', id::text, 'epoch' FROM purchaces WHERE status = 'pending'
+ON CONFLICT DO NOTHING;
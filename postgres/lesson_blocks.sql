@@ -0,0 +1,19 @@
+-- типизированные блоки текстовых уроков: старые блоки остаются html со значением в value,
+-- у новых тип в block_type и поля блока в payload
+ALTER TABLE text_lesson_block
+    ADD COLUMN IF NOT EXISTS block_type TEXT NOT NULL DEFAULT 'html'
+        CHECK (block_type IN ('html', 'markdown', 'image', 'file', 'code', 'callout', 'video', 'quiz')),
+    ADD COLUMN IF NOT EXISTS payload JSONB;
+
+-- картинки и вложения, загруженные авторами для блоков image и file
+CREATE TABLE IF NOT EXISTS lesson_files (
+    object_name TEXT PRIMARY KEY,
+    author_id INT NOT NULL REFERENCES usertable(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL CHECK (kind IN ('image', 'file')),
+    file_name TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size BIGINT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS lesson_files_author_idx ON lesson_files (author_id, created_at);
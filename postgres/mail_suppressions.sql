@@ -0,0 +1,11 @@
+-- адреса, на которые mail-service больше не отправляет письма: сервер получателя ответил,
+-- что ящика не существует или он отключён. Удаляются администратором через /api/suppressions
+CREATE TABLE IF NOT EXISTS mail_suppressions (
+    email TEXT PRIMARY KEY,
+    reason TEXT NOT NULL,
+    smtp_code INT NOT NULL DEFAULT 0,
+    event_type TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS mail_suppressions_created_idx ON mail_suppressions (created_at);
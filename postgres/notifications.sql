@@ -0,0 +1,17 @@
+-- уведомления в приложении. Создаются user-service из событий топика mail,
+-- event_id защищает от дублей при повторной доставке события
+CREATE TABLE IF NOT EXISTS notifications (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES usertable(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL UNIQUE,
+    kind TEXT NOT NULL,
+    course_id INT NOT NULL DEFAULT 0,
+    course_name TEXT NOT NULL DEFAULT '',
+    url TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    read_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS notifications_user_idx ON notifications (user_id, id);
+-- счётчик непрочитанных
+CREATE INDEX IF NOT EXISTS notifications_unread_idx ON notifications (user_id) WHERE read_at IS NULL;
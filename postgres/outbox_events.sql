@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id BIGSERIAL PRIMARY KEY,
+    event_id UUID NOT NULL UNIQUE,
+    source TEXT NOT NULL,
+    topic TEXT NOT NULL,
+    key TEXT NOT NULL DEFAULT '',
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    attempts INT NOT NULL DEFAULT 0,
+    -- до этого времени событие не отправляется: пауза после ошибки или аренда экземпляром relay
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_error TEXT,
+    sent_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS outbox_events_pending_idx ON outbox_events (source, id) WHERE sent_at IS NULL;
+
+-- события, которые mail-service уже обработал: повторно доставленное из Kafka письмо не отправляется
+CREATE TABLE IF NOT EXISTS mail_processed_events (
+    event_id UUID PRIMARY KEY,
+    processed_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
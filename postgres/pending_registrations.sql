@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS pending_registrations (
+    id SERIAL PRIMARY KEY,
+    email TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    password TEXT NOT NULL,
+    salt TEXT NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    expire TIMESTAMP NOT NULL,
+    resend_count INT NOT NULL DEFAULT 0,
+    last_sent_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+-- просроченные заявки удаляются при каждой новой регистрации
+CREATE INDEX IF NOT EXISTS pending_registrations_expire_idx ON pending_registrations (expire);
@@ -0,0 +1,2 @@
+-- язык писем, выбранный пользователем в профиле
+ALTER TABLE usertable ADD COLUMN IF NOT EXISTS locale TEXT NOT NULL DEFAULT 'ru' CHECK (locale IN ('ru', 'en'));
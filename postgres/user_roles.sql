@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS user_roles (
+    user_id INT NOT NULL REFERENCES usertable(id) ON DELETE CASCADE,
+    role TEXT NOT NULL CHECK (role IN ('learner', 'author', 'moderator', 'admin')),
+    granted_by INT REFERENCES usertable(id) ON DELETE SET NULL,
+    granted_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, role)
+);
+
+-- перенос ролей из usertable.role и авторов уже созданных курсов
+INSERT INTO user_roles (user_id, role) SELECT id, 'admin' FROM usertable WHERE role = 'admin' ON CONFLICT DO NOTHING;
+INSERT INTO user_roles (user_id, role) SELECT DISTINCT creator_user_id, 'author' FROM course ON CONFLICT DO NOTHING;
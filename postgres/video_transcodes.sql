@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS video_transcodes (
+    lesson_id INT PRIMARY KEY REFERENCES lesson(id) ON DELETE CASCADE,
+    source_object TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'processing', 'ready', 'failed')),
+    hls_prefix TEXT NOT NULL DEFAULT '',
+    attempts INT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS video_transcodes_queue_idx ON video_transcodes (updated_at) WHERE status IN ('pending', 'processing');
+
+-- уже загруженные видео ставятся в очередь на перекодирование
+INSERT INTO video_transcodes (lesson_id, source_object)
+SELECT lesson_id, regexp_replace(video_src, '^.*/', '') FROM video_lesson WHERE video_src <> ''
+ON CONFLICT (lesson_id) DO NOTHING;
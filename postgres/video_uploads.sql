@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS video_uploads (
+    id TEXT PRIMARY KEY,
+    user_id INT NOT NULL REFERENCES usertable(id) ON DELETE CASCADE,
+    lesson_id INT NOT NULL REFERENCES lesson(id) ON DELETE CASCADE,
+    object_name TEXT NOT NULL,
+    minio_upload_id TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    total_size BIGINT NOT NULL,
+    chunk_size BIGINT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'uploading' CHECK (status IN ('uploading', 'completed', 'aborted')),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS video_upload_parts (
+    upload_id TEXT NOT NULL REFERENCES video_uploads(id) ON DELETE CASCADE,
+    part_number INT NOT NULL,
+    etag TEXT NOT NULL,
+    size BIGINT NOT NULL,
+    PRIMARY KEY (upload_id, part_number)
+);
+
+CREATE INDEX IF NOT EXISTS video_uploads_stale_idx ON video_uploads (updated_at) WHERE status = 'uploading';
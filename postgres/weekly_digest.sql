@@ -0,0 +1,33 @@
+-- часовой пояс пользователя (имя из базы IANA): еженедельная сводка приходит в его утро
+ALTER TABLE usertable ADD COLUMN IF NOT EXISTS timezone TEXT NOT NULL DEFAULT 'Europe/Moscow';
+
+-- время публикации курса: по нему находятся новые курсы для сводки. Курсы, созданные до миграции,
+-- новыми не считаются
+ALTER TABLE course ADD COLUMN IF NOT EXISTS created_at TIMESTAMP NOT NULL DEFAULT 'epoch';
+ALTER TABLE course ALTER COLUMN created_at SET DEFAULT NOW();
+
+-- отложенные задачи course-service. Задачу берёт один экземпляр сервиса: run_at сдвигается на время аренды,
+-- после выполнения - на следующий запуск повторяющейся задачи. Задача с kind и key одна
+CREATE TABLE IF NOT EXISTS scheduled_jobs (
+    id BIGSERIAL PRIMARY KEY,
+    kind TEXT NOT NULL,
+    key TEXT NOT NULL DEFAULT '',
+    payload JSONB NOT NULL DEFAULT '{}',
+    run_at TIMESTAMPTZ NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (kind, key)
+);
+
+CREATE INDEX IF NOT EXISTS scheduled_jobs_run_at_idx ON scheduled_jobs (run_at);
+
+-- отправленные еженедельные сводки. week - неделя в часовом поясе пользователя (2026-W43), защищает
+-- от повторной сводки за неделю. rating - места в рейтингах курсов на момент сводки, с ними сравнивается следующая
+CREATE TABLE IF NOT EXISTS digest_mails (
+    user_id INT NOT NULL REFERENCES usertable(id) ON DELETE CASCADE,
+    week TEXT NOT NULL,
+    rating JSONB NOT NULL DEFAULT '{}',
+    sent_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, week)
+);